@@ -58,6 +58,7 @@ func SetCandidatePluginChangeset(
 		e.Chains[newChainSel],
 		feedChainSel,
 		tokenConfig.GetTokenInfo(e.Logger, state.Chains[newChainSel].LinkToken, state.Chains[newChainSel].Weth9),
+		tokenConfig.PriceUpdateInterval,
 		nodes.NonBootstraps(),
 		state.Chains[homeChainSel].RMNHome.Address(),
 		nil,
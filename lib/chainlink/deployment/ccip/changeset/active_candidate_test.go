@@ -123,6 +123,7 @@ func TestActiveCandidate(t *testing.T) {
 		e.Chains[tenv.FeedChainSel],
 		tenv.FeedChainSel,
 		tokenConfig.GetTokenInfo(e.Logger, state.Chains[tenv.FeedChainSel].LinkToken, state.Chains[tenv.FeedChainSel].Weth9),
+		tokenConfig.PriceUpdateInterval,
 		nodes.NonBootstraps(),
 		rmnHomeAddress,
 		nil,
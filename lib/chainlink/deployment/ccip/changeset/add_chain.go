@@ -104,6 +104,7 @@ func AddDonAndSetCandidateChangeset(
 		e.Chains[newChainSel],
 		feedChainSel,
 		tokenConfig.GetTokenInfo(e.Logger, state.Chains[newChainSel].LinkToken, state.Chains[newChainSel].Weth9),
+		tokenConfig.PriceUpdateInterval,
 		nodes.NonBootstraps(),
 		state.Chains[homeChainSel].RMNHome.Address(),
 		nil,
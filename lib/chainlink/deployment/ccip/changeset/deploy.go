@@ -57,14 +57,15 @@ var (
 	CapabilitiesRegistry       deployment.ContractType = "CapabilitiesRegistry"
 	PriceFeed                  deployment.ContractType = "PriceFeed"
 	// Note test router maps to a regular router contract.
-	TestRouter          deployment.ContractType = "TestRouter"
-	CCIPReceiver        deployment.ContractType = "CCIPReceiver"
-	BurnMintToken       deployment.ContractType = "BurnMintToken"
-	BurnMintTokenPool   deployment.ContractType = "BurnMintTokenPool"
-	USDCToken           deployment.ContractType = "USDCToken"
-	USDCMockTransmitter deployment.ContractType = "USDCMockTransmitter"
-	USDCTokenMessenger  deployment.ContractType = "USDCTokenMessenger"
-	USDCTokenPool       deployment.ContractType = "USDCTokenPool"
+	TestRouter           deployment.ContractType = "TestRouter"
+	CCIPReceiver         deployment.ContractType = "CCIPReceiver"
+	BurnMintToken        deployment.ContractType = "BurnMintToken"
+	BurnMintTokenPool    deployment.ContractType = "BurnMintTokenPool"
+	LockReleaseTokenPool deployment.ContractType = "LockReleaseTokenPool"
+	USDCToken            deployment.ContractType = "USDCToken"
+	USDCMockTransmitter  deployment.ContractType = "USDCMockTransmitter"
+	USDCTokenMessenger   deployment.ContractType = "USDCTokenMessenger"
+	USDCTokenPool        deployment.ContractType = "USDCTokenPool"
 )
 
 func DeployPrerequisiteChainContracts(e deployment.Environment, ab deployment.AddressBook, selectors []uint64) error {
@@ -409,6 +410,7 @@ func DeployCCIPContracts(
 			chainState.OffRamp,
 			c.FeedChainSel,
 			tokenInfo,
+			c.TokenConfig.PriceUpdateInterval,
 			chain,
 			e.Chains[c.HomeChainSel],
 			nodes.NonBootstraps(),
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -447,13 +448,14 @@ func AddDON(
 	feedChainSel uint64,
 	// Token address on Dest chain to aggregate address on feed chain
 	tokenInfo map[ccipocr3.UnknownEncodedAddress]pluginconfig.TokenInfo,
+	priceUpdateInterval time.Duration,
 	dest deployment.Chain,
 	home deployment.Chain,
 	nodes deployment.Nodes,
 	tokenConfigs []pluginconfig.TokenDataObserverConfig,
 ) error {
 	ocrConfigs, err := internal.BuildOCR3ConfigForCCIPHome(
-		ocrSecrets, offRamp, dest, feedChainSel, tokenInfo, nodes, rmnHomeAddress, tokenConfigs)
+		ocrSecrets, offRamp, dest, feedChainSel, tokenInfo, priceUpdateInterval, nodes, rmnHomeAddress, tokenConfigs)
 	if err != nil {
 		return err
 	}
@@ -414,10 +414,16 @@ func BuildOCR3ConfigForCCIPHome(
 	dest deployment.Chain,
 	feedChainSel uint64,
 	tokenInfo map[ccipocr3.UnknownEncodedAddress]pluginconfig.TokenInfo,
+	priceUpdateInterval time.Duration,
 	nodes deployment.Nodes,
 	rmnHomeAddress common.Address,
 	configs []pluginconfig.TokenDataObserverConfig,
 ) (map[types.PluginType]ccip_home.CCIPHomeOCR3Config, error) {
+	// the gas and token prices are written every priceUpdateInterval, if given
+	gasPriceWriteFrequency, tokenPriceWriteFrequency := RemoteGasPriceBatchWriteFrequency, TokenPriceBatchWriteFrequency
+	if priceUpdateInterval > 0 {
+		gasPriceWriteFrequency, tokenPriceWriteFrequency = priceUpdateInterval, priceUpdateInterval
+	}
 	p2pIDs := nodes.PeerIDs()
 	// Get OCR3 Config from helper
 	var schedule []int
@@ -445,8 +451,8 @@ func BuildOCR3ConfigForCCIPHome(
 		var err2 error
 		if pluginType == types.PluginTypeCCIPCommit {
 			encodedOffchainConfig, err2 = pluginconfig.EncodeCommitOffchainConfig(pluginconfig.CommitOffchainConfig{
-				RemoteGasPriceBatchWriteFrequency:  *config.MustNewDuration(RemoteGasPriceBatchWriteFrequency),
-				TokenPriceBatchWriteFrequency:      *config.MustNewDuration(TokenPriceBatchWriteFrequency),
+				RemoteGasPriceBatchWriteFrequency:  *config.MustNewDuration(gasPriceWriteFrequency),
+				TokenPriceBatchWriteFrequency:      *config.MustNewDuration(tokenPriceWriteFrequency),
 				PriceFeedChainSelector:             ccipocr3.ChainSelector(feedChainSel),
 				TokenInfo:                          tokenInfo,
 				NewMsgScanBatchSize:                merklemulti.MaxNumberTreeLeaves,
@@ -40,6 +40,7 @@ import (
 	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
 
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/burn_mint_token_pool"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/lock_release_token_pool"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/mock_v3_aggregator_contract"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/onramp"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
@@ -798,10 +799,182 @@ func deployTransferTokenOneEnd(
 	addressBook deployment.AddressBook,
 	tokenSymbol string,
 ) (*burn_mint_erc677.BurnMintERC677, *burn_mint_token_pool.BurnMintTokenPool, error) {
+	rmnAddress, routerAddress, err := tokenPoolDependencies(chain, addressBook)
+	if err != nil {
+		return nil, nil, err
+	}
+	tokenContract, err := deployTransferToken(lggr, chain, addressBook, tokenSymbol)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tokenPool, err := deployment.DeployContract(lggr, chain, addressBook,
+		func(chain deployment.Chain) deployment.ContractDeploy[*burn_mint_token_pool.BurnMintTokenPool] {
+			tokenPoolAddress, tx, tokenPoolContract, err2 := burn_mint_token_pool.DeployBurnMintTokenPool(
+				chain.DeployerKey,
+				chain.Client,
+				tokenContract.Address(),
+				[]common.Address{},
+				rmnAddress,
+				routerAddress,
+			)
+			return deployment.ContractDeploy[*burn_mint_token_pool.BurnMintTokenPool]{
+				tokenPoolAddress, tokenPoolContract, tx, deployment.NewTypeAndVersion(BurnMintTokenPool, deployment.Version1_0_0), err2,
+			}
+		})
+	if err != nil {
+		lggr.Errorw("Failed to deploy token pool", "err", err)
+		return nil, nil, err
+	}
+
+	return tokenContract, tokenPool.Contract, nil
+}
+
+// DeployLockReleaseTransferableToken is like DeployTransferableToken, but connects the tokens with lock/release
+// pools, providing each pool the liquidity given for its chain, if any.
+func DeployLockReleaseTransferableToken(
+	lggr logger.Logger,
+	chains map[uint64]deployment.Chain,
+	src, dst uint64,
+	state CCIPOnChainState,
+	addresses deployment.AddressBook,
+	token string,
+	liquidity map[uint64]*big.Int,
+) (*burn_mint_erc677.BurnMintERC677, *lock_release_token_pool.LockReleaseTokenPool, *burn_mint_erc677.BurnMintERC677, *lock_release_token_pool.LockReleaseTokenPool, error) {
+	// Deploy token and pools
+	srcToken, srcPool, err := deployLockReleaseTokenOneEnd(lggr, chains[src], addresses, token)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	dstToken, dstPool, err := deployLockReleaseTokenOneEnd(lggr, chains[dst], addresses, token)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	// Attach token pools to registry
+	if err := attachTokenToTheRegistry(chains[src], state.Chains[src], chains[src].DeployerKey, srcToken.Address(), srcPool.Address()); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	if err := attachTokenToTheRegistry(chains[dst], state.Chains[dst], chains[dst].DeployerKey, dstToken.Address(), dstPool.Address()); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	// Connect pool to each other
+	if err := setLockReleaseTokenPoolCounterPart(chains[src], srcPool, dst, dstToken.Address(), dstPool.Address()); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	if err := setLockReleaseTokenPoolCounterPart(chains[dst], dstPool, src, srcToken.Address(), srcPool.Address()); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	// Provide the liquidity released by the pools
+	if err := provideLiquidity(lggr, chains[src], srcToken, srcPool, liquidity[src]); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	if err := provideLiquidity(lggr, chains[dst], dstToken, dstPool, liquidity[dst]); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	return srcToken, srcPool, dstToken, dstPool, nil
+}
+
+func setLockReleaseTokenPoolCounterPart(
+	chain deployment.Chain,
+	tokenPool *lock_release_token_pool.LockReleaseTokenPool,
+	destChainSelector uint64,
+	destTokenAddress common.Address,
+	destTokenPoolAddress common.Address,
+) error {
+	pool, err := burn_mint_token_pool.NewBurnMintTokenPool(tokenPool.Address(), chain.Client)
+	if err != nil {
+		return err
+	}
+
+	return setTokenPoolCounterPart(chain, pool, destChainSelector, destTokenAddress, destTokenPoolAddress)
+}
+
+// provideLiquidity mints amount of the token to the deployer and locks it in the pool, nothing is provided
+// for a nil or zero amount.
+func provideLiquidity(
+	lggr logger.Logger,
+	chain deployment.Chain,
+	token *burn_mint_erc677.BurnMintERC677,
+	tokenPool *lock_release_token_pool.LockReleaseTokenPool,
+	amount *big.Int,
+) error {
+	if amount == nil || amount.Sign() == 0 {
+		return nil
+	}
+	lggr.Infow("Providing liquidity", "token", token.Address(), "pool", tokenPool.Address(), "amount", amount)
+	tx, err := tokenPool.SetRebalancer(chain.DeployerKey, chain.DeployerKey.From)
+	if _, err = deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+		return fmt.Errorf("failed to set rebalancer on token pool %s: %w", tokenPool.Address(), err)
+	}
+
+	tx, err = token.Mint(chain.DeployerKey, chain.DeployerKey.From, amount)
+	if _, err = deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+		return err
+	}
+
+	tx, err = token.Approve(chain.DeployerKey, tokenPool.Address(), amount)
+	if _, err = deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+		return err
+	}
+
+	tx, err = tokenPool.ProvideLiquidity(chain.DeployerKey, amount)
+	if _, err = deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+		return fmt.Errorf("failed to provide liquidity to token pool %s: %w", tokenPool.Address(), err)
+	}
+	return nil
+}
+
+func deployLockReleaseTokenOneEnd(
+	lggr logger.Logger,
+	chain deployment.Chain,
+	addressBook deployment.AddressBook,
+	tokenSymbol string,
+) (*burn_mint_erc677.BurnMintERC677, *lock_release_token_pool.LockReleaseTokenPool, error) {
+	rmnAddress, routerAddress, err := tokenPoolDependencies(chain, addressBook)
+	if err != nil {
+		return nil, nil, err
+	}
+	tokenContract, err := deployTransferToken(lggr, chain, addressBook, tokenSymbol)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tokenPool, err := deployment.DeployContract(lggr, chain, addressBook,
+		func(chain deployment.Chain) deployment.ContractDeploy[*lock_release_token_pool.LockReleaseTokenPool] {
+			tokenPoolAddress, tx, tokenPoolContract, err2 := lock_release_token_pool.DeployLockReleaseTokenPool(
+				chain.DeployerKey,
+				chain.Client,
+				tokenContract.Address(),
+				[]common.Address{},
+				rmnAddress,
+				true,
+				routerAddress,
+			)
+			return deployment.ContractDeploy[*lock_release_token_pool.LockReleaseTokenPool]{
+				tokenPoolAddress, tokenPoolContract, tx, deployment.NewTypeAndVersion(LockReleaseTokenPool, deployment.Version1_0_0), err2,
+			}
+		})
+	if err != nil {
+		lggr.Errorw("Failed to deploy token pool", "err", err)
+		return nil, nil, err
+	}
+
+	return tokenContract, tokenPool.Contract, nil
+}
+
+// tokenPoolDependencies returns the addresses of the RMN proxy and the router the token pools of the chain use.
+func tokenPoolDependencies(chain deployment.Chain, addressBook deployment.AddressBook) (common.Address, common.Address, error) {
 	var rmnAddress, routerAddress string
 	chainAddresses, err := addressBook.AddressesForChain(chain.Selector)
 	if err != nil {
-		return nil, nil, err
+		return common.Address{}, common.Address{}, err
 	}
 	for address, v := range chainAddresses {
 		if deployment.NewTypeAndVersion(ARMProxy, deployment.Version1_0_0) == v {
@@ -814,7 +987,16 @@ func deployTransferTokenOneEnd(
 			break
 		}
 	}
+	return common.HexToAddress(rmnAddress), common.HexToAddress(routerAddress), nil
+}
 
+// deployTransferToken deploys the token with the deployer allowed to mint it.
+func deployTransferToken(
+	lggr logger.Logger,
+	chain deployment.Chain,
+	addressBook deployment.AddressBook,
+	tokenSymbol string,
+) (*burn_mint_erc677.BurnMintERC677, error) {
 	tokenContract, err := deployment.DeployContract(lggr, chain, addressBook,
 		func(chain deployment.Chain) deployment.ContractDeploy[*burn_mint_erc677.BurnMintERC677] {
 			USDCTokenAddr, tx, token, err2 := burn_mint_erc677.DeployBurnMintERC677(
@@ -831,36 +1013,16 @@ func deployTransferTokenOneEnd(
 		})
 	if err != nil {
 		lggr.Errorw("Failed to deploy Token ERC677", "err", err)
-		return nil, nil, err
+		return nil, err
 	}
 
 	tx, err := tokenContract.Contract.GrantMintRole(chain.DeployerKey, chain.DeployerKey.From)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	_, err = chain.Confirm(tx)
 	if err != nil {
-		return nil, nil, err
-	}
-
-	tokenPool, err := deployment.DeployContract(lggr, chain, addressBook,
-		func(chain deployment.Chain) deployment.ContractDeploy[*burn_mint_token_pool.BurnMintTokenPool] {
-			tokenPoolAddress, tx, tokenPoolContract, err2 := burn_mint_token_pool.DeployBurnMintTokenPool(
-				chain.DeployerKey,
-				chain.Client,
-				tokenContract.Address,
-				[]common.Address{},
-				common.HexToAddress(rmnAddress),
-				common.HexToAddress(routerAddress),
-			)
-			return deployment.ContractDeploy[*burn_mint_token_pool.BurnMintTokenPool]{
-				tokenPoolAddress, tokenPoolContract, tx, deployment.NewTypeAndVersion(BurnMintTokenPool, deployment.Version1_0_0), err2,
-			}
-		})
-	if err != nil {
-		lggr.Errorw("Failed to deploy token pool", "err", err)
-		return nil, nil, err
+		return nil, err
 	}
-
-	return tokenContract.Contract, tokenPool.Contract, nil
+	return tokenContract.Contract, nil
 }
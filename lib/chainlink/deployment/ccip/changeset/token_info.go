@@ -1,6 +1,8 @@
 package changeset
 
 import (
+	"time"
+
 	"github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
 	"github.com/smartcontractkit/chainlink-ccip/pluginconfig"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/weth9"
@@ -18,6 +20,9 @@ var (
 // and the respective token info.
 type TokenConfig struct {
 	TokenSymbolToInfo map[TokenSymbol]pluginconfig.TokenInfo
+	// PriceUpdateInterval is how often the commit plugin writes the prices,
+	// the internal batch write frequencies if zero.
+	PriceUpdateInterval time.Duration
 }
 
 func NewTokenConfig() TokenConfig {
@@ -27,6 +27,8 @@ type ChainConfig struct {
 	WSRPCs      []string           // websocket rpcs to connect to the chain
 	HTTPRPCs    []string           // http rpcs to connect to the chain
 	DeployerKey *bind.TransactOpts // key to send transactions to the chain
+	// Client is used instead of dialing WSRPCs when set, e.g. a client built by the test harness
+	Client deployment.OnchainClient
 }
 
 func NewChains(logger logger.Logger, configs []ChainConfig) (map[uint64]deployment.Chain, error) {
@@ -37,15 +39,18 @@ func NewChains(logger logger.Logger, configs []ChainConfig) (map[uint64]deployme
 			return nil, fmt.Errorf("failed to get selector from chain id %d: %w", chainCfg.ChainID, err)
 		}
 		// TODO : better client handling
-		var ec *ethclient.Client
+		ec := chainCfg.Client
 		for _, rpc := range chainCfg.WSRPCs {
-			ec, err = ethclient.Dial(rpc)
+			if ec != nil {
+				break
+			}
+			client, err := ethclient.Dial(rpc)
 			if err != nil {
 				logger.Warnf("failed to dial ws rpc %s", rpc)
 				continue
 			}
 			logger.Infof("connected to ws rpc %s", rpc)
-			break
+			ec = client
 		}
 		if ec == nil {
 			return nil, fmt.Errorf("failed to connect to chain %s", chainCfg.ChainName)
@@ -61,13 +66,9 @@ func NewChains(logger logger.Logger, configs []ChainConfig) (map[uint64]deployme
 				}
 				ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
 				defer cancel()
-				chainId, err := ec.ChainID(ctx)
-				if err != nil {
-					return blockNumber, fmt.Errorf("failed to get chain id: %w", err)
-				}
 				receipt, err := bind.WaitMined(ctx, ec, tx)
 				if err != nil {
-					return blockNumber, fmt.Errorf("failed to get confirmed receipt for chain %d: %w", chainId, err)
+					return blockNumber, fmt.Errorf("failed to get confirmed receipt for chain %d: %w", chainCfg.ChainID, err)
 				}
 				if receipt == nil {
 					return blockNumber, fmt.Errorf("receipt was nil for tx %s", tx.Hash().Hex())
@@ -331,11 +331,14 @@ type FundsToSendPayload struct {
 	GasFeeCap  *big.Int
 	GasTipCap  *big.Int
 	TxTimeout  *time.Duration
+	// Nonce is read from the pending nonce of the sender when not set, set it when sending several
+	// transactions from the same key concurrently
+	Nonce *uint64
 }
 
 // TODO: move to CTF?
 // SendFunds sends native token amount (expressed in human-scale) from address controlled by private key
-// to given address. You can override any or none of the following: gas limit, gas price, gas fee cap, gas tip cap.
+// to given address. You can override any or none of the following: nonce, gas limit, gas price, gas fee cap, gas tip cap.
 // Values that are not set will be estimated or taken from config.
 func SendFunds(logger zerolog.Logger, client *seth.Client, payload FundsToSendPayload) (*types.Receipt, error) {
 	fromAddress, err := PrivateKeyToAddress(payload.PrivateKey)
@@ -344,10 +347,15 @@ func SendFunds(logger zerolog.Logger, client *seth.Client, payload FundsToSendPa
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), client.Cfg.Network.TxnTimeout.Duration())
-	nonce, err := client.Client.PendingNonceAt(ctx, fromAddress)
 	defer cancel()
-	if err != nil {
-		return nil, err
+	var nonce uint64
+	if payload.Nonce != nil {
+		nonce = *payload.Nonce
+	} else {
+		nonce, err = client.Client.PendingNonceAt(ctx, fromAddress)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	gasLimit, err := client.EstimateGasLimitForFundTransfer(fromAddress, payload.ToAddress, payload.Amount)
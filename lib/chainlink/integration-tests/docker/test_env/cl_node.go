@@ -45,6 +45,10 @@ var (
 const (
 	RestartContainer  = true
 	StartNewContainer = false
+
+	// DefaultUserEmail and DefaultUserPassword are the API credentials of the nodes NewClNode creates
+	DefaultUserEmail    = "local@local.com"
+	DefaultUserPassword = "localdevpassword"
 )
 
 type ClNode struct {
@@ -57,6 +61,8 @@ type ClNode struct {
 	UserPassword          string                      `json:"userPassword"`
 	AlwaysPullImage       bool                        `json:"-"`
 	GraphqlAPI            grapqlClient.Client         `json:"-"`
+	readinessGated        bool
+	labels                map[string]string
 	t                     *testing.T
 	l                     zerolog.Logger
 }
@@ -86,6 +92,21 @@ func WithStartupTimeout(timeout time.Duration) ClNodeOption {
 	}
 }
 
+// WithReadinessGate starts the node once its API port is listening, leaving waiting for /readyz to
+// the caller's readiness gate
+func WithReadinessGate() ClNodeOption {
+	return func(n *ClNode) {
+		n.readinessGated = true
+	}
+}
+
+// WithLabels sets the docker labels of the node container
+func WithLabels(labels map[string]string) ClNodeOption {
+	return func(n *ClNode) {
+		n.labels = labels
+	}
+}
+
 // Sets custom node container name if name is not empty
 func WithNodeContainerName(name string) ClNodeOption {
 	return func(c *ClNode) {
@@ -143,8 +164,8 @@ func NewClNode(networks []string, imageName, imageVersion string, nodeConfig *ch
 			LogStream:        logStream,
 			StartupTimeout:   3 * time.Minute,
 		},
-		UserEmail:    "local@local.com",
-		UserPassword: "localdevpassword",
+		UserEmail:    DefaultUserEmail,
+		UserPassword: DefaultUserPassword,
 		NodeConfig:   nodeConfig,
 		PostgresDb:   pgDb,
 		l:            log.Logger,
@@ -450,11 +471,20 @@ func (n *ClNode) getContainerRequest(secrets string) (
 	adminCredsPath := "/home/admin-credentials.txt"
 	apiCredsPath := "/home/api-credentials.txt"
 
+	var waitingFor tcwait.Strategy = tcwait.ForHTTP("/readyz").
+		WithPort("6688/tcp").
+		WithStartupTimeout(n.StartupTimeout).
+		WithPollInterval(1 * time.Second)
+	if n.readinessGated {
+		waitingFor = tcwait.ForListeningPort("6688/tcp").WithStartupTimeout(n.StartupTimeout)
+	}
+
 	return &tc.ContainerRequest{
 		Name:            n.ContainerName,
 		AlwaysPullImage: n.AlwaysPullImage,
 		Image:           fmt.Sprintf("%s:%s", n.ContainerImage, n.ContainerVersion),
 		ExposedPorts:    []string{"6688/tcp"},
+		Labels:          n.labels,
 		Env:             n.ContainerEnvs,
 		Entrypoint: []string{"chainlink",
 			"-c", configPath,
@@ -463,11 +493,8 @@ func (n *ClNode) getContainerRequest(secrets string) (
 			"-p", adminCredsPath,
 			"-a", apiCredsPath,
 		},
-		Networks: append(n.Networks, "tracing"),
-		WaitingFor: tcwait.ForHTTP("/readyz").
-			WithPort("6688/tcp").
-			WithStartupTimeout(n.StartupTimeout).
-			WithPollInterval(1 * time.Second),
+		Networks:   append(n.Networks, "tracing"),
+		WaitingFor: waitingFor,
 		Files: []tc.ContainerFile{
 			{
 				HostFilePath:      configFile.Name(),
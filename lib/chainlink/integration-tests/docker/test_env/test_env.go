@@ -2,10 +2,13 @@ package test_env
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -22,6 +25,7 @@ import (
 	"github.com/smartcontractkit/chainlink-testing-framework/lib/logging"
 	"github.com/smartcontractkit/chainlink-testing-framework/lib/logstream"
 	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/runid"
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
 
 	"github.com/smartcontractkit/chainlink/integration-tests/testconfig/ccip"
 	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
@@ -38,6 +42,8 @@ type CLClusterTestEnv struct {
 	DockerNetwork *tc.DockerNetwork
 	LogStream     *logstream.LogStream
 	TestConfig    ctf_config.GlobalTestConfig
+	// Runtime namespaces the network, containers and host ports of the environment, may be nil
+	Runtime *ccip.DockerRuntimeConfig
 
 	/* components */
 	ClCluster              *ClCluster
@@ -46,23 +52,101 @@ type CLClusterTestEnv struct {
 	EVMNetworks            []*blockchain.EVMNetwork
 	rpcProviders           map[int64]*test_env.RpcProvider
 	JobDistributor         *job_distributor.Component
-	l                      zerolog.Logger
-	t                      *testing.T
-	isSimulatedNetwork     bool
+	DegradedComponents     []ccip.DegradedComponent
+	// ReusedState is the stored state the environment was attached from, nil if it was started by this run
+	ReusedState *ccip.EnvState
+	// Reporter collects what the test measures into the report written when the test ends
+	Reporter *ccip.Reporter
+	// CostTracker adds up what the transactions of the test spend, reported by the Reporter
+	CostTracker *ccip.CostTracker
+	// Annotator annotates the chaos events and threshold violations of the test on the Grafana dashboards
+	Annotator *ccip.Annotator
+	// Notifier posts the events of the test to the Notifications webhook
+	Notifier *ccip.Notifier
+	// componentContainers are the containers of components the environment has no other handle on, keyed by
+	// ccip.LOG_COMPONENT_*, for Teardown and log collection
+	componentContainers map[string][]tc.Container
+	l                   zerolog.Logger
+	t                   *testing.T
+	isSimulatedNetwork  bool
 }
 
 func NewTestEnv() (*CLClusterTestEnv, error) {
+	return NewTestEnvWithRuntime(nil)
+}
+
+// NewTestEnvWithRuntime creates the test environment in the docker network of the runtime: the reused
+// network, a network created under its name, or a randomly named one when neither is set.
+func NewTestEnvWithRuntime(runtime *ccip.DockerRuntimeConfig) (*CLClusterTestEnv, error) {
 	log.Logger = logging.GetLogger(nil, "CORE_DOCKER_ENV_LOG_LEVEL")
-	network, err := docker.CreateNetwork(log.Logger)
+	var network *tc.DockerNetwork
+	var err error
+	switch {
+	case runtime != nil && runtime.ReuseNetwork != "":
+		network = &tc.DockerNetwork{Name: runtime.ReuseNetwork}
+	case runtime != nil && runtime.NetworkName != "":
+		network, err = createNamedNetwork(runtime.NetworkName)
+	default:
+		network, err = docker.CreateNetwork(log.Logger)
+	}
 	if err != nil {
 		return nil, err
 	}
 	return &CLClusterTestEnv{
 		DockerNetwork: network,
+		Runtime:       runtime,
 		l:             log.Logger,
 	}, nil
 }
 
+// AttachTestEnv returns the test environment of a kept alive environment from its stored state, without
+// starting any container. Its chains are reached through the stored RPCs and its nodes through the API
+// URLs of the state, so it has no ClCluster.
+func AttachTestEnv(t *testing.T, runtime *ccip.DockerRuntimeConfig, state *ccip.EnvState) *CLClusterTestEnv {
+	te := &CLClusterTestEnv{
+		DockerNetwork: &tc.DockerNetwork{Name: state.DockerNetwork},
+		Runtime:       runtime,
+		ReusedState:   state,
+		rpcProviders:  make(map[int64]*test_env.RpcProvider, len(state.Chains)),
+		l:             log.Logger,
+	}
+	for _, chain := range state.Chains {
+		rpcProvider := test_env.NewRPCProvider(chain.InternalHTTPRPCs, chain.InternalWSRPCs, chain.HTTPRPCs, chain.WSRPCs)
+		te.rpcProviders[int64(chain.ChainID)] = &rpcProvider
+	}
+	return te.WithTestInstance(t)
+}
+
+func createNamedNetwork(name string) (*tc.DockerNetwork, error) {
+	f := false
+	//nolint:staticcheck
+	network, err := tc.GenericNetwork(testcontext.Get(nil), tc.GenericNetworkRequest{
+		//nolint:staticcheck
+		NetworkRequest: tc.NetworkRequest{
+			Name:           name,
+			CheckDuplicate: true,
+			EnableIPv6:     &f,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create docker network %s: %w", name, err)
+	}
+	dockerNetwork, ok := network.(*tc.DockerNetwork)
+	if !ok {
+		return nil, fmt.Errorf("failed to cast network %s to *tc.DockerNetwork", name)
+	}
+	return dockerNetwork, nil
+}
+
+// containerName returns the name of the component prefixed with the runtime's ContainerPrefix, or "" to
+// keep the component's default name.
+func (te *CLClusterTestEnv) containerName(component string) string {
+	if te.Runtime == nil || te.Runtime.ContainerPrefix == "" {
+		return ""
+	}
+	return te.Runtime.ContainerPrefix + "-" + component
+}
+
 // WithTestEnvConfig sets the test environment cfg.
 // Sets up private ethereum chain and MockAdapter containers with the provided cfg.
 func (te *CLClusterTestEnv) WithTestEnvConfig(cfg *TestEnvConfig) *CLClusterTestEnv {
@@ -110,16 +194,77 @@ func (te *CLClusterTestEnv) StartEthereumNetwork(cfg *ctf_config.EthereumNetwork
 	if err != nil {
 		return blockchain.EVMNetwork{}, test_env.RpcProvider{}, err
 	}
+	for _, container := range c.Containers {
+		if container.Container != nil {
+			te.trackContainer(ccip.LOG_COMPONENT_CHAINS, *container.Container)
+		}
+	}
 
 	return n, rpc, nil
 }
 
-func (te *CLClusterTestEnv) StartJobDistributor(cfg *ccip.JDConfig) error {
-	jdDB, err := test_env.NewPostgresDb(
-		[]string{te.DockerNetwork.Name},
+func (te *CLClusterTestEnv) trackContainer(component string, container tc.Container) {
+	if te.componentContainers == nil {
+		te.componentContainers = make(map[string][]tc.Container)
+	}
+	te.componentContainers[component] = append(te.componentContainers[component], container)
+}
+
+// ContainerLogs returns the logs of the containers of a component (ccip.LOG_COMPONENT_*), keyed by container
+// name, for ccip.LogCollection.
+func (te *CLClusterTestEnv) ContainerLogs(ctx context.Context, component string) (map[string][]byte, error) {
+	containers := append([]tc.Container{}, te.componentContainers[component]...)
+	switch component {
+	case ccip.LOG_COMPONENT_NODES:
+		if te.ClCluster != nil {
+			for _, node := range te.ClCluster.Nodes {
+				containers = append(containers, node.Container)
+			}
+		}
+	case ccip.LOG_COMPONENT_JD:
+		if te.JobDistributor != nil {
+			containers = append(containers, te.JobDistributor.Container)
+		}
+	}
+	logs := make(map[string][]byte, len(containers))
+	for _, container := range containers {
+		if container == nil {
+			continue
+		}
+		name, err := container.Name(ctx)
+		if err != nil {
+			return nil, err
+		}
+		reader, err := container.Logs(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the logs of %s: %w", name, err)
+		}
+		content, err := io.ReadAll(reader)
+		_ = reader.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the logs of %s: %w", name, err)
+		}
+		logs[strings.TrimPrefix(name, "/")] = content
+	}
+	return logs, nil
+}
+
+func (te *CLClusterTestEnv) StartJobDistributor(cfg *ccip.JDConfig, grpcPort, wsrpcPort int) error {
+	dbOpts := []test_env.PostgresDbOption{
 		test_env.WithPostgresDbName(cfg.GetJDDBName()),
 		test_env.WithPostgresImageVersion(cfg.GetJDDBVersion()),
-	)
+	}
+	jdOpts := []job_distributor.Option{
+		job_distributor.WithImage(cfg.GetJDImage()),
+		job_distributor.WithVersion(cfg.GetJDVersion()),
+		job_distributor.WithContainerPort(strconv.Itoa(grpcPort)),
+		job_distributor.WithWSRPCContainerPort(strconv.Itoa(wsrpcPort)),
+	}
+	if name := te.containerName("job-distributor"); name != "" {
+		dbOpts = append(dbOpts, test_env.WithPostgresDbContainerName(name+"-db"))
+		jdOpts = append(jdOpts, job_distributor.WithContainerName(name))
+	}
+	jdDB, err := test_env.NewPostgresDb([]string{te.DockerNetwork.Name}, dbOpts...)
 	if err != nil {
 		return fmt.Errorf("failed to create postgres db for job-distributor: %w", err)
 	}
@@ -127,11 +272,8 @@ func (te *CLClusterTestEnv) StartJobDistributor(cfg *ccip.JDConfig) error {
 	if err != nil {
 		return fmt.Errorf("failed to start postgres db for job-distributor: %w", err)
 	}
-	jd := job_distributor.New([]string{te.DockerNetwork.Name},
-		job_distributor.WithImage(cfg.GetJDImage()),
-		job_distributor.WithVersion(cfg.GetJDVersion()),
-		job_distributor.WithDBURL(jdDB.InternalURL.String()),
-	)
+	te.trackContainer(ccip.LOG_COMPONENT_JD, jdDB.Container)
+	jd := job_distributor.New([]string{te.DockerNetwork.Name}, append(jdOpts, job_distributor.WithDBURL(jdDB.InternalURL.String()))...)
 	jd.LogStream = te.LogStream
 	err = jd.StartContainer()
 	if err != nil {
@@ -160,7 +302,11 @@ func (te *CLClusterTestEnv) StartClCluster(nodeConfig *chainlink.Config, count i
 		opts = append(opts, WithSecrets(secretsConfig))
 		te.ClCluster = &ClCluster{}
 		for i := 0; i < count; i++ {
-			ocrNode, err := NewClNode([]string{te.DockerNetwork.Name}, *testconfig.GetChainlinkImageConfig().Image, *testconfig.GetChainlinkImageConfig().Version, nodeConfig, te.LogStream, opts...)
+			nodeOpts := opts
+			if name := te.containerName(fmt.Sprintf("node-%d", i)); name != "" {
+				nodeOpts = append(append([]ClNodeOption{}, opts...), WithNodeContainerName(name), WithDbContainerName(name+"-db"))
+			}
+			ocrNode, err := NewClNode([]string{te.DockerNetwork.Name}, *testconfig.GetChainlinkImageConfig().Image, *testconfig.GetChainlinkImageConfig().Version, nodeConfig, te.LogStream, nodeOpts...)
 			if err != nil {
 				return err
 			}
@@ -185,6 +331,44 @@ func (te *CLClusterTestEnv) Terminate() error {
 	return nil
 }
 
+// Teardown terminates the containers of the environment and removes the network it created. Ryuk does
+// this on its own, Teardown is for environments started with Ryuk disabled to possibly keep them alive.
+func (te *CLClusterTestEnv) Teardown(ctx context.Context) error {
+	var containers []tc.Container
+	for _, component := range te.componentContainers {
+		containers = append(containers, component...)
+	}
+	if te.ClCluster != nil {
+		for _, node := range te.ClCluster.Nodes {
+			containers = append(containers, node.Container)
+			if node.PostgresDb != nil {
+				containers = append(containers, node.PostgresDb.Container)
+			}
+		}
+	}
+	if te.JobDistributor != nil {
+		containers = append(containers, te.JobDistributor.Container)
+	}
+	if te.MockAdapter != nil {
+		containers = append(containers, te.MockAdapter.Container)
+	}
+	var errs error
+	for _, container := range containers {
+		if container == nil {
+			continue
+		}
+		if err := container.Terminate(ctx); err != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
+	if te.DockerNetwork != nil && (te.Runtime == nil || te.Runtime.ReuseNetwork == "") {
+		if err := te.DockerNetwork.Remove(ctx); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to remove docker network %s: %w", te.DockerNetwork.Name, err))
+		}
+	}
+	return errs
+}
+
 type CleanupOpts struct {
 	TestName string
 }
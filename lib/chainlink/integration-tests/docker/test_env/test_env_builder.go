@@ -1,6 +1,7 @@
 package test_env
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"os"
@@ -49,6 +50,12 @@ type CLTestEnvBuilder struct {
 	hasLogStream                    bool
 	hasKillgrave                    bool
 	jdConfig                        *ccip.JDConfig
+	jdGRPCPort                      int
+	jdWSRPCPort                     int
+	componentCriticality            *ccip.Config
+	logCollection                   *ccip.Config
+	dockerRuntime                   *ccip.DockerRuntimeConfig
+	startupPlan                     []ccip.StartupStage
 	clNodeConfig                    *chainlink.Config
 	secretsConfig                   string
 	clNodesCount                    int
@@ -114,7 +121,7 @@ func (b *CLTestEnvBuilder) WithTestEnv(te *CLClusterTestEnv) (*CLTestEnvBuilder,
 	if te != nil {
 		b.te = te
 	} else {
-		b.te, err = NewTestEnv()
+		b.te, err = NewTestEnvWithRuntime(b.dockerRuntime)
 		if err != nil {
 			return nil, err
 		}
@@ -213,11 +220,62 @@ func (b *CLTestEnvBuilder) WithCustomCleanup(customFn func()) *CLTestEnvBuilder
 	return b
 }
 
-func (b *CLTestEnvBuilder) WithJobDistributor(cfg ccip.JDConfig) *CLTestEnvBuilder {
+// WithJobDistributor starts a job distributor listening on the given gRPC and WSRPC ports.
+func (b *CLTestEnvBuilder) WithJobDistributor(cfg ccip.JDConfig, grpcPort, wsrpcPort int) *CLTestEnvBuilder {
 	b.jdConfig = &cfg
+	b.jdGRPCPort, b.jdWSRPCPort = grpcPort, wsrpcPort
 	return b
 }
 
+// WithComponentCriticality makes optional components in cfg that fail to start degrade the environment,
+// listed in CLClusterTestEnv.DegradedComponents, instead of failing the build.
+func (b *CLTestEnvBuilder) WithComponentCriticality(cfg *ccip.Config) *CLTestEnvBuilder {
+	b.componentCriticality = cfg
+	return b
+}
+
+// handleStartError returns err unless the component is optional, in which case the environment is degraded.
+func (b *CLTestEnvBuilder) handleStartError(component string, err error) error {
+	if b.componentCriticality == nil {
+		return err
+	}
+	degraded, err := b.componentCriticality.HandleStartError(component, err)
+	if err != nil {
+		return err
+	}
+	b.te.DegradedComponents = append(b.te.DegradedComponents, *degraded)
+	return nil
+}
+
+// WithLogCollection ships the container logs to the LogCollection targets of cfg on standard cleanup.
+func (b *CLTestEnvBuilder) WithLogCollection(cfg *ccip.Config) *CLTestEnvBuilder {
+	b.logCollection = cfg
+	return b
+}
+
+// WithDockerRuntime starts the environment in the docker network of cfg and prefixes its container names,
+// keeping it apart from other environments started by the same test binary. It has no effect when a test
+// environment is passed to WithTestEnv.
+func (b *CLTestEnvBuilder) WithDockerRuntime(cfg *ccip.DockerRuntimeConfig) *CLTestEnvBuilder {
+	b.dockerRuntime = cfg
+	return b
+}
+
+// WithStartupPlan starts the job distributor after the chains if the plan puts it in a later stage. The
+// builder starts the components of a stage one after the other, nodes and RMN are started by the caller.
+func (b *CLTestEnvBuilder) WithStartupPlan(stages []ccip.StartupStage) *CLTestEnvBuilder {
+	b.startupPlan = stages
+	return b
+}
+
+// startJobDistributor starts the job distributor, if one is configured.
+func (b *CLTestEnvBuilder) startJobDistributor() error {
+	if b.jdConfig == nil {
+		return nil
+	}
+	return b.te.StartJobDistributor(b.jdConfig, b.jdGRPCPort, b.jdWSRPCPort)
+}
+
 type EVMNetworkOption = func(*blockchain.EVMNetwork) *blockchain.EVMNetwork
 
 // WithEVMNetworkOptions sets the options for the EVM network. This is especially useful for simulated networks, which
@@ -259,9 +317,14 @@ func (b *CLTestEnvBuilder) Build() (*CLClusterTestEnv, error) {
 		}
 		b.te.LogStream, err = logstream.NewLogStream(b.te.t, b.testConfig.GetLoggingConfig())
 		if err != nil {
-			return nil, err
+			if err := b.handleStartError(ccip.COMPONENT_OBSERVABILITY, err); err != nil {
+				return nil, err
+			}
+			b.te.LogStream = nil
 		}
+	}
 
+	if b.hasLogStream && b.te.LogStream != nil {
 		// this clean up has to be added as the FIRST one, because cleanup functions are executed in reverse order (LIFO)
 		if b.t != nil && b.cleanUpType != CleanUpTypeNone {
 			b.t.Cleanup(func() {
@@ -378,11 +441,18 @@ func (b *CLTestEnvBuilder) Build() (*CLClusterTestEnv, error) {
 			return nil, fmt.Errorf("test environment builder failed: %w", fmt.Errorf("cannot start mock adapter without a network"))
 		}
 
-		b.te.MockAdapter = test_env.NewKillgrave([]string{b.te.DockerNetwork.Name}, "", test_env.WithLogStream(b.te.LogStream))
+		mockOpts := []test_env.EnvComponentOption{test_env.WithLogStream(b.te.LogStream)}
+		if name := b.te.containerName("mock-adapter"); name != "" {
+			mockOpts = append(mockOpts, test_env.WithContainerName(name))
+		}
+		b.te.MockAdapter = test_env.NewKillgrave([]string{b.te.DockerNetwork.Name}, "", mockOpts...)
 
 		err = b.te.StartMockAdapter()
 		if err != nil {
-			return nil, err
+			if err := b.handleStartError(ccip.COMPONENT_MOCKS, err); err != nil {
+				return nil, err
+			}
+			b.te.MockAdapter = nil
 		}
 	}
 
@@ -393,6 +463,11 @@ func (b *CLTestEnvBuilder) Build() (*CLClusterTestEnv, error) {
 	switch b.cleanUpType {
 	case CleanUpTypeStandard:
 		b.t.Cleanup(func() {
+			if b.logCollection != nil {
+				if err := b.logCollection.LogCollection.Collect(context.Background(), b.t.Name(), b.t.Failed(), b.logCollection.Observability, b.te.ContainerLogs); err != nil {
+					b.l.Error().Err(err).Msg("Error collecting container logs")
+				}
+			}
 			// Cleanup test environment
 			if err := b.te.Cleanup(CleanupOpts{TestName: b.t.Name()}); err != nil {
 				b.l.Error().Err(err).Msg("Error cleaning up test environment")
@@ -410,9 +485,9 @@ func (b *CLTestEnvBuilder) Build() (*CLClusterTestEnv, error) {
 		log.Warn().Msg("Chainlink node log scanner settings provided, but LogStream is not enabled. Ignoring Chainlink node log scanner settings, as no logs will be available.")
 	}
 
-	if b.jdConfig != nil {
-		err := b.te.StartJobDistributor(b.jdConfig)
-		if err != nil {
+	jdAfterChains := ccip.StartupStageOf(b.startupPlan, ccip.COMPONENT_JD) > ccip.StartupStageOf(b.startupPlan, ccip.COMPONENT_CHAINS)
+	if !jdAfterChains {
+		if err := b.startJobDistributor(); err != nil {
 			return nil, err
 		}
 	}
@@ -455,6 +530,11 @@ func (b *CLTestEnvBuilder) Build() (*CLClusterTestEnv, error) {
 		}
 		b.te.isSimulatedNetwork = true
 
+		if jdAfterChains {
+			if err := b.startJobDistributor(); err != nil {
+				return nil, err
+			}
+		}
 		return b.te, nil
 	}
 
@@ -558,6 +638,12 @@ func (b *CLTestEnvBuilder) Build() (*CLClusterTestEnv, error) {
 		b.defaultNodeCsaKeys = nodeCsaKeys
 	}
 
+	if jdAfterChains {
+		if err := b.startJobDistributor(); err != nil {
+			return nil, err
+		}
+	}
+
 	var enDesc string
 	if len(b.te.PrivateEthereumConfigs) > 0 {
 		for _, en := range b.te.PrivateEthereumConfigs {
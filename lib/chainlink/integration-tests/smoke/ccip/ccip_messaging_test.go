@@ -30,6 +30,7 @@ type testCaseSetup struct {
 	deployedEnv            changeset.DeployedEnv
 	onchainState           changeset.CCIPOnChainState
 	sourceChain, destChain uint64
+	recorder               *testsetups.MessageRecorder
 }
 
 type messagingTestCase struct {
@@ -48,7 +49,7 @@ func Test_CCIPMessaging(t *testing.T) {
 	// Setup 2 chains and a single lane.
 	lggr := logger.TestLogger(t)
 	ctx := changeset.Context(t)
-	e, _, _ := testsetups.NewLocalDevEnvironmentWithDefaultPrice(t, lggr)
+	e, testEnv, _ := testsetups.NewLocalDevEnvironmentWithDefaultPrice(t, lggr)
 
 	state, err := changeset.LoadOnchainState(e.Env)
 	require.NoError(t, err)
@@ -78,6 +79,7 @@ func Test_CCIPMessaging(t *testing.T) {
 			onchainState: state,
 			sourceChain:  sourceChain,
 			destChain:    destChain,
+			recorder:     testsetups.NewMessageRecorder(testEnv, e.Env),
 		}
 	)
 
@@ -317,6 +319,7 @@ func runMessagingTestCase(
 		FeeToken:     common.HexToAddress("0x0"),
 		ExtraArgs:    extraArgs,
 	})
+	tc.recorder.Sent(tc.t, tc.sourceChain, tc.destChain, msgSentEvent)
 	expectedSeqNum := make(map[changeset.SourceDestPair]uint64)
 	expectedSeqNum[changeset.SourceDestPair{
 		SourceChainSelector: tc.sourceChain,
@@ -330,8 +333,8 @@ func runMessagingTestCase(
 		out.replayed = true
 	}
 
-	changeset.ConfirmCommitForAllWithExpectedSeqNums(tc.t, tc.deployedEnv.Env, tc.onchainState, expectedSeqNum, startBlocks)
-	execStates := changeset.ConfirmExecWithSeqNrForAll(tc.t, tc.deployedEnv.Env, tc.onchainState, expectedSeqNum, startBlocks)
+	tc.recorder.ConfirmCommitForAll(tc.t, tc.deployedEnv.Env, tc.onchainState, expectedSeqNum, startBlocks)
+	execStates := tc.recorder.ConfirmExecForAll(tc.t, tc.deployedEnv.Env, tc.onchainState, expectedSeqNum, startBlocks)
 
 	require.Equalf(
 		tc.t,
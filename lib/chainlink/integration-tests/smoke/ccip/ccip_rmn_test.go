@@ -176,7 +176,7 @@ const (
 func runRmnTestCase(t *testing.T, tc rmnTestCase) {
 	require.NoError(t, os.Setenv("ENABLE_RMN", "true"))
 
-	envWithRMN, rmnCluster := testsetups.NewLocalDevEnvironmentWithRMN(t, logger.TestLogger(t), len(tc.rmnNodes))
+	envWithRMN, rmnCluster, recorder := testsetups.NewLocalDevEnvironmentWithRMN(t, logger.TestLogger(t), len(tc.rmnNodes))
 	t.Logf("envWithRmn: %#v", envWithRMN)
 
 	var chainSelectors []uint64
@@ -354,6 +354,7 @@ func runRmnTestCase(t *testing.T, tc rmnTestCase) {
 				FeeToken:     common.HexToAddress("0x0"),
 				ExtraArgs:    nil,
 			})
+			recorder.Sent(t, fromChain, toChain, msgSentEvent)
 			expectedSeqNum[changeset.SourceDestPair{
 				SourceChainSelector: fromChain,
 				DestChainSelector:   toChain,
@@ -368,7 +369,7 @@ func runRmnTestCase(t *testing.T, tc rmnTestCase) {
 
 	commitReportReceived := make(chan struct{})
 	go func() {
-		changeset.ConfirmCommitForAllWithExpectedSeqNums(t, envWithRMN.Env, onChainState, expectedSeqNum, startBlocks)
+		recorder.ConfirmCommitForAll(t, envWithRMN.Env, onChainState, expectedSeqNum, startBlocks)
 		commitReportReceived <- struct{}{}
 	}()
 
@@ -390,7 +391,7 @@ func runRmnTestCase(t *testing.T, tc rmnTestCase) {
 
 	if tc.waitForExec {
 		t.Logf("⌛ Waiting for exec reports...")
-		changeset.ConfirmExecWithSeqNrForAll(t, envWithRMN.Env, onChainState, expectedSeqNum, startBlocks)
+		recorder.ConfirmExecForAll(t, envWithRMN.Env, onChainState, expectedSeqNum, startBlocks)
 		t.Logf("✅ Exec report")
 	}
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
 	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
+	ccip_config "github.com/smartcontractkit/chainlink/integration-tests/testconfig/ccip"
 	"github.com/smartcontractkit/chainlink/integration-tests/testsetups"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
@@ -17,13 +18,14 @@ import (
 func TestInitialDeployOnLocal(t *testing.T) {
 	t.Parallel()
 	lggr := logger.TestLogger(t)
-	tenv, _, _ := testsetups.NewLocalDevEnvironmentWithDefaultPrice(t, lggr)
+	tenv, testEnv, cfg := testsetups.NewLocalDevEnvironmentWithDefaultPrice(t, lggr)
+	recorder := testsetups.NewMessageRecorder(testEnv, tenv.Env).WithRemediation(cfg.CCIP)
 	e := tenv.Env
 	state, err := changeset.LoadOnchainState(e)
 	require.NoError(t, err)
 
 	// Add all lanes
-	require.NoError(t, changeset.AddLanesForAll(e, state))
+	require.NoError(t, testsetups.AddLanesForAll(e, state, cfg.CCIP))
 	// Need to keep track of the block number for each chain so that event subscription can be done from that block.
 	startBlocks := make(map[uint64]*uint64)
 	// Send a message from each chain to every other chain.
@@ -33,26 +35,33 @@ func TestInitialDeployOnLocal(t *testing.T) {
 			if src == dest {
 				continue
 			}
+			// lanes are walked in map order, each draws from its own stream to be reproducible
+			msgs, err := cfg.CCIP.BuildLaneMessages(dest, cfg.CCIP.NewLaneRand(ccip_config.RAND_COMPONENT_PAYLOAD, src, dest))
+			require.NoError(t, err)
 			latesthdr, err := destChain.Client.HeaderByNumber(testcontext.Get(t), nil)
 			require.NoError(t, err)
 			block := latesthdr.Number.Uint64()
 			startBlocks[dest] = &block
-			msgSentEvent := changeset.TestSendRequest(t, e, state, src, dest, false, router.ClientEVM2AnyMessage{
-				Receiver:     common.LeftPadBytes(state.Chains[dest].Receiver.Address().Bytes(), 32),
-				Data:         []byte("hello world"),
-				TokenAmounts: nil,
-				FeeToken:     common.HexToAddress("0x0"),
-				ExtraArgs:    nil,
-			})
-			expectedSeqNum[changeset.SourceDestPair{
-				SourceChainSelector: src,
-				DestChainSelector:   dest,
-			}] = msgSentEvent.SequenceNumber
+			// no tokens are deployed, messages of the token carrying payload types go without them
+			for _, msg := range msgs {
+				msgSentEvent := changeset.TestSendRequest(t, e, state, src, dest, false, router.ClientEVM2AnyMessage{
+					Receiver:     common.LeftPadBytes(state.Chains[dest].Receiver.Address().Bytes(), 32),
+					Data:         msg.Data,
+					TokenAmounts: nil,
+					FeeToken:     common.HexToAddress("0x0"),
+					ExtraArgs:    msg.ExtraArgs,
+				})
+				recorder.Sent(t, src, dest, msgSentEvent)
+				expectedSeqNum[changeset.SourceDestPair{
+					SourceChainSelector: src,
+					DestChainSelector:   dest,
+				}] = msgSentEvent.SequenceNumber
+			}
 		}
 	}
 
 	// Wait for all commit reports to land.
-	changeset.ConfirmCommitForAllWithExpectedSeqNums(t, e, state, expectedSeqNum, startBlocks)
+	recorder.ConfirmCommitForAll(t, e, state, expectedSeqNum, startBlocks)
 
 	// After commit is reported on all chains, token prices should be updated in FeeQuoter.
 	for dest := range e.Chains {
@@ -64,7 +73,7 @@ func TestInitialDeployOnLocal(t *testing.T) {
 	}
 
 	// Wait for all exec reports to land
-	changeset.ConfirmExecWithSeqNrForAll(t, e, state, expectedSeqNum, startBlocks)
+	recorder.ConfirmExecForAll(t, e, state, expectedSeqNum, startBlocks)
 
 	// TODO: Apply the proposal.
 }
@@ -72,34 +81,29 @@ func TestInitialDeployOnLocal(t *testing.T) {
 func TestTokenTransfer(t *testing.T) {
 	t.Parallel()
 	lggr := logger.TestLogger(t)
-	tenv, _, _ := testsetups.NewLocalDevEnvironmentWithDefaultPrice(t, lggr)
+	tenv, testEnv, cfg := testsetups.NewLocalDevEnvironmentWithDefaultPrice(t, lggr)
+	recorder := testsetups.NewMessageRecorder(testEnv, tenv.Env).WithRemediation(cfg.CCIP)
 	e := tenv.Env
 	state, err := changeset.LoadOnchainState(e)
 	require.NoError(t, err)
 
-	srcToken, _, dstToken, _, err := changeset.DeployTransferableToken(
-		lggr,
-		tenv.Env.Chains,
-		tenv.HomeChainSel,
-		tenv.FeedChainSel,
-		state,
-		e.ExistingAddresses,
-		"MY_TOKEN",
-	)
+	srcToken, dstToken, err := testsetups.DeployTransferableToken(e, state, cfg.CCIP, tenv.HomeChainSel, tenv.FeedChainSel, "MY_TOKEN")
 	require.NoError(t, err)
 
 	// Add all lanes
-	require.NoError(t, changeset.AddLanesForAll(e, state))
+	require.NoError(t, testsetups.AddLanesForAll(e, state, cfg.CCIP))
 	// Need to keep track of the block number for each chain so that event subscription can be done from that block.
 	startBlocks := make(map[uint64]*uint64)
 	// Send a message from each chain to every other chain.
 	expectedSeqNum := make(map[changeset.SourceDestPair]uint64)
 
 	twoCoins := new(big.Int).Mul(big.NewInt(1e18), big.NewInt(2))
+	// at most every message of a lane transfers twoCoins
+	maxTransferred := new(big.Int).Mul(twoCoins, big.NewInt(int64(cfg.CCIP.Messages.GetCountPerLane())))
 	tx, err := srcToken.Mint(
 		e.Chains[tenv.HomeChainSel].DeployerKey,
 		e.Chains[tenv.HomeChainSel].DeployerKey.From,
-		new(big.Int).Mul(twoCoins, big.NewInt(10)),
+		new(big.Int).Mul(maxTransferred, big.NewInt(10)),
 	)
 	require.NoError(t, err)
 	_, err = e.Chains[tenv.HomeChainSel].Confirm(tx)
@@ -108,17 +112,17 @@ func TestTokenTransfer(t *testing.T) {
 	tx, err = dstToken.Mint(
 		e.Chains[tenv.FeedChainSel].DeployerKey,
 		e.Chains[tenv.FeedChainSel].DeployerKey.From,
-		new(big.Int).Mul(twoCoins, big.NewInt(10)),
+		new(big.Int).Mul(maxTransferred, big.NewInt(10)),
 	)
 	require.NoError(t, err)
 	_, err = e.Chains[tenv.FeedChainSel].Confirm(tx)
 	require.NoError(t, err)
 
-	tx, err = srcToken.Approve(e.Chains[tenv.HomeChainSel].DeployerKey, state.Chains[tenv.HomeChainSel].Router.Address(), twoCoins)
+	tx, err = srcToken.Approve(e.Chains[tenv.HomeChainSel].DeployerKey, state.Chains[tenv.HomeChainSel].Router.Address(), maxTransferred)
 	require.NoError(t, err)
 	_, err = e.Chains[tenv.HomeChainSel].Confirm(tx)
 	require.NoError(t, err)
-	tx, err = dstToken.Approve(e.Chains[tenv.FeedChainSel].DeployerKey, state.Chains[tenv.FeedChainSel].Router.Address(), twoCoins)
+	tx, err = dstToken.Approve(e.Chains[tenv.FeedChainSel].DeployerKey, state.Chains[tenv.FeedChainSel].Router.Address(), maxTransferred)
 	require.NoError(t, err)
 	_, err = e.Chains[tenv.FeedChainSel].Confirm(tx)
 	require.NoError(t, err)
@@ -134,41 +138,35 @@ func TestTokenTransfer(t *testing.T) {
 		}},
 	}
 
+	transfers := 0
 	for src := range e.Chains {
 		for dest, destChain := range e.Chains {
 			if src == dest {
 				continue
 			}
+			// lanes are walked in map order, each draws from its own stream to be reproducible
+			msgs, err := cfg.CCIP.BuildLaneMessages(dest, cfg.CCIP.NewLaneRand(ccip_config.RAND_COMPONENT_PAYLOAD, src, dest))
+			require.NoError(t, err)
 			latesthdr, err := destChain.Client.HeaderByNumber(testcontext.Get(t), nil)
 			require.NoError(t, err)
 			block := latesthdr.Number.Uint64()
 			startBlocks[dest] = &block
 
-			var (
-				receiver = common.LeftPadBytes(state.Chains[dest].Receiver.Address().Bytes(), 32)
-				data     = []byte("hello world")
-				feeToken = common.HexToAddress("0x0")
-			)
-			if src == tenv.HomeChainSel && dest == tenv.FeedChainSel {
+			for i, msg := range msgs {
+				var tokenAmounts []router.ClientEVMTokenAmount
+				// the first message of the lane transfers the token whatever the payload type
+				if src == tenv.HomeChainSel && dest == tenv.FeedChainSel && (i == 0 || msg.WithTokens) {
+					tokenAmounts = tokens[src]
+					transfers++
+				}
 				msgSentEvent := changeset.TestSendRequest(t, e, state, src, dest, false, router.ClientEVM2AnyMessage{
-					Receiver:     receiver,
-					Data:         data,
-					TokenAmounts: tokens[src],
-					FeeToken:     feeToken,
-					ExtraArgs:    nil,
-				})
-				expectedSeqNum[changeset.SourceDestPair{
-					SourceChainSelector: src,
-					DestChainSelector:   dest,
-				}] = msgSentEvent.SequenceNumber
-			} else {
-				msgSentEvent := changeset.TestSendRequest(t, e, state, src, dest, false, router.ClientEVM2AnyMessage{
-					Receiver:     receiver,
-					Data:         data,
-					TokenAmounts: nil,
-					FeeToken:     feeToken,
-					ExtraArgs:    nil,
+					Receiver:     common.LeftPadBytes(state.Chains[dest].Receiver.Address().Bytes(), 32),
+					Data:         msg.Data,
+					TokenAmounts: tokenAmounts,
+					FeeToken:     common.HexToAddress("0x0"),
+					ExtraArgs:    msg.ExtraArgs,
 				})
+				recorder.Sent(t, src, dest, msgSentEvent)
 				expectedSeqNum[changeset.SourceDestPair{
 					SourceChainSelector: src,
 					DestChainSelector:   dest,
@@ -178,7 +176,7 @@ func TestTokenTransfer(t *testing.T) {
 	}
 
 	// Wait for all commit reports to land.
-	changeset.ConfirmCommitForAllWithExpectedSeqNums(t, e, state, expectedSeqNum, startBlocks)
+	recorder.ConfirmCommitForAll(t, e, state, expectedSeqNum, startBlocks)
 
 	// After commit is reported on all chains, token prices should be updated in FeeQuoter.
 	for dest := range e.Chains {
@@ -190,9 +188,9 @@ func TestTokenTransfer(t *testing.T) {
 	}
 
 	// Wait for all exec reports to land
-	changeset.ConfirmExecWithSeqNrForAll(t, e, state, expectedSeqNum, startBlocks)
+	recorder.ConfirmExecForAll(t, e, state, expectedSeqNum, startBlocks)
 
 	balance, err := dstToken.BalanceOf(nil, state.Chains[tenv.FeedChainSel].Receiver.Address())
 	require.NoError(t, err)
-	require.Equal(t, twoCoins, balance)
+	require.Equal(t, new(big.Int).Mul(twoCoins, big.NewInt(int64(transfers))), balance)
 }
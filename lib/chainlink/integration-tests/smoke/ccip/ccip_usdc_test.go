@@ -25,7 +25,8 @@ import (
 
 func TestUSDCTokenTransfer(t *testing.T) {
 	lggr := logger.TestLogger(t)
-	tenv, _, _ := testsetups.NewLocalDevEnvironmentWithDefaultPrice(t, lggr)
+	tenv, testEnv, cfg := testsetups.NewLocalDevEnvironmentWithDefaultPrice(t, lggr)
+	recorder := testsetups.NewMessageRecorder(testEnv, tenv.Env)
 
 	e := tenv.Env
 	state, err := changeset.LoadOnchainState(e)
@@ -38,19 +39,11 @@ func TestUSDCTokenTransfer(t *testing.T) {
 	srcUSDC, dstUSDC, err := changeset.ConfigureUSDCTokenPools(lggr, e.Chains, sourceChain, destChain, state)
 	require.NoError(t, err)
 
-	srcToken, _, dstToken, _, err := changeset.DeployTransferableToken(
-		lggr,
-		tenv.Env.Chains,
-		sourceChain,
-		destChain,
-		state,
-		e.ExistingAddresses,
-		"MY_TOKEN",
-	)
+	srcToken, dstToken, err := testsetups.DeployTransferableToken(e, state, cfg.CCIP, sourceChain, destChain, "MY_TOKEN")
 	require.NoError(t, err)
 
 	// Add all lanes
-	require.NoError(t, changeset.AddLanesForAll(e, state))
+	require.NoError(t, testsetups.AddLanesForAll(e, state, cfg.CCIP))
 
 	mintAndAllow(t, e, state, map[uint64][]*burn_mint_erc677.BurnMintERC677{
 		sourceChain: {srcUSDC, srcToken},
@@ -157,6 +150,7 @@ func TestUSDCTokenTransfer(t *testing.T) {
 
 			transferAndWaitForSuccess(
 				t,
+				recorder,
 				e,
 				state,
 				tt.sourceChain,
@@ -205,6 +199,7 @@ func mintAndAllow(
 // transferAndWaitForSuccess sends a message from sourceChain to destChain and waits for it to be executed
 func transferAndWaitForSuccess(
 	t *testing.T,
+	recorder *testsetups.MessageRecorder,
 	env deployment.Environment,
 	state changeset.CCIPOnChainState,
 	sourceChain, destChain uint64,
@@ -227,16 +222,17 @@ func transferAndWaitForSuccess(
 		FeeToken:     common.HexToAddress("0x0"),
 		ExtraArgs:    nil,
 	})
+	recorder.Sent(t, sourceChain, destChain, msgSentEvent)
 	expectedSeqNum[changeset.SourceDestPair{
 		SourceChainSelector: sourceChain,
 		DestChainSelector:   destChain,
 	}] = msgSentEvent.SequenceNumber
 
 	// Wait for all commit reports to land.
-	changeset.ConfirmCommitForAllWithExpectedSeqNums(t, env, state, expectedSeqNum, startBlocks)
+	recorder.ConfirmCommitForAll(t, env, state, expectedSeqNum, startBlocks)
 
 	// Wait for all exec reports to land
-	changeset.ConfirmExecWithSeqNrForAll(t, env, state, expectedSeqNum, startBlocks)
+	recorder.ConfirmExecForAll(t, env, state, expectedSeqNum, startBlocks)
 }
 
 func waitForTheTokenBalance(
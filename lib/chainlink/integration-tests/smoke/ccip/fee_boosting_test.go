@@ -24,6 +24,7 @@ type feeboostTestCase struct {
 	initialPrices          changeset.InitialPrices
 	priceFeedPrices        priceFeedPrices
 	sourceChain, destChain uint64
+	recorder               *testsetups.MessageRecorder
 }
 
 type priceFeedPrices struct {
@@ -33,8 +34,8 @@ type priceFeedPrices struct {
 
 // TODO: find a way to reuse the same test setup for all tests
 func Test_CCIPFeeBoosting(t *testing.T) {
-	setupTestEnv := func(t *testing.T, numChains int) (changeset.DeployedEnv, changeset.CCIPOnChainState, []uint64) {
-		e, _, _ := testsetups.NewLocalDevEnvironment(
+	setupTestEnv := func(t *testing.T, numChains int) (changeset.DeployedEnv, changeset.CCIPOnChainState, []uint64, *testsetups.MessageRecorder) {
+		e, testEnv, cfg := testsetups.NewLocalDevEnvironment(
 			t, logger.TestLogger(t),
 			deployment.E18Mult(5),
 			big.NewInt(9e8))
@@ -44,11 +45,11 @@ func Test_CCIPFeeBoosting(t *testing.T) {
 
 		allChainSelectors := maps.Keys(e.Env.Chains)
 		require.Len(t, allChainSelectors, numChains)
-		return e, state, allChainSelectors
+		return e, state, allChainSelectors, testsetups.NewMessageRecorder(testEnv, e.Env).WithRemediation(cfg.CCIP)
 	}
 
 	t.Run("boost needed due to WETH price increase (also covering gas price inscrease)", func(t *testing.T) {
-		e, state, chains := setupTestEnv(t, 2)
+		e, state, chains, recorder := setupTestEnv(t, 2)
 		runFeeboostTestCase(feeboostTestCase{
 			t:            t,
 			sender:       common.LeftPadBytes(e.Env.Chains[chains[0]].DeployerKey.From.Bytes(), 32),
@@ -65,11 +66,12 @@ func Test_CCIPFeeBoosting(t *testing.T) {
 			},
 			sourceChain: chains[0],
 			destChain:   chains[1],
+			recorder:    recorder,
 		})
 	})
 
 	t.Run("boost needed due to LINK price decrease", func(t *testing.T) {
-		e, state, chains := setupTestEnv(t, 2)
+		e, state, chains, recorder := setupTestEnv(t, 2)
 		runFeeboostTestCase(feeboostTestCase{
 			t:            t,
 			sender:       common.LeftPadBytes(e.Env.Chains[chains[0]].DeployerKey.From.Bytes(), 32),
@@ -86,6 +88,7 @@ func Test_CCIPFeeBoosting(t *testing.T) {
 			},
 			sourceChain: chains[0],
 			destChain:   chains[1],
+			recorder:    recorder,
 		})
 	})
 }
@@ -102,6 +105,7 @@ func runFeeboostTestCase(tc feeboostTestCase) {
 		FeeToken:     common.HexToAddress("0x0"),
 		ExtraArgs:    nil,
 	})
+	tc.recorder.Sent(tc.t, tc.sourceChain, tc.destChain, msgSentEvent)
 	expectedSeqNum[changeset.SourceDestPair{
 		SourceChainSelector: tc.sourceChain,
 		DestChainSelector:   tc.destChain,
@@ -114,6 +118,6 @@ func runFeeboostTestCase(tc feeboostTestCase) {
 	replayBlocks[tc.destChain] = 1
 	changeset.ReplayLogs(tc.t, tc.deployedEnv.Env.Offchain, replayBlocks)
 
-	changeset.ConfirmCommitForAllWithExpectedSeqNums(tc.t, tc.deployedEnv.Env, tc.onchainState, expectedSeqNum, startBlocks)
-	changeset.ConfirmExecWithSeqNrForAll(tc.t, tc.deployedEnv.Env, tc.onchainState, expectedSeqNum, startBlocks)
+	tc.recorder.ConfirmCommitForAll(tc.t, tc.deployedEnv.Env, tc.onchainState, expectedSeqNum, startBlocks)
+	tc.recorder.ConfirmExecForAll(tc.t, tc.deployedEnv.Env, tc.onchainState, expectedSeqNum, startBlocks)
 }
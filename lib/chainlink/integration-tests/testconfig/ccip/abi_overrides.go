@@ -0,0 +1,87 @@
+package ccip
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// abiRequiredEvents are the events the harness decodes, keyed by the contracts ABIOverrides can
+// override. An overridden ABI must contain all the events of its contract.
+var abiRequiredEvents = map[string][]string{
+	"OnRamp":  {"CCIPMessageSent"},
+	"OffRamp": {"CommitReportAccepted", "ExecutionStateChanged"},
+}
+
+// GetABI returns the ABI ABIOverrides sets for the contract, nil if it's not overridden and the
+// compiled-in bindings apply. The ABIs are parsed by Validate and cached on the config until Revalidate.
+func (o *Config) GetABI(contract string) (*abi.ABI, error) {
+	if parsed, ok := o.abis[contract]; ok {
+		return parsed, nil
+	}
+	override, ok := o.ABIOverrides[contract]
+	if !ok {
+		return nil, nil
+	}
+	parsed, err := parseABIOverride(contract, override)
+	if err != nil {
+		return nil, fieldError("ABIOverrides."+contract, err)
+	}
+	if o.abis == nil {
+		o.abis = make(map[string]*abi.ABI)
+	}
+	o.abis[contract] = parsed
+	return parsed, nil
+}
+
+// parseABIOverride parses an inline JSON ABI, or the ABI in the file at the path, and checks that it
+// has the events of the contract the harness decodes.
+func parseABIOverride(contract, override string) (*abi.ABI, error) {
+	content := strings.TrimSpace(override)
+	if !strings.HasPrefix(content, "[") {
+		file, err := os.ReadFile(override)
+		if err != nil {
+			return nil, err
+		}
+		content = string(file)
+	}
+	parsed, err := abi.JSON(strings.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ABI of %s: %w", contract, err)
+	}
+	var missing []string
+	for _, event := range abiRequiredEvents[contract] {
+		if _, ok := parsed.Events[event]; !ok {
+			missing = append(missing, event)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("ABI of %s is missing events %s, which the assertions decode", contract, strings.Join(missing, ", "))
+	}
+	return &parsed, nil
+}
+
+func (o *Config) validateABIOverrides() error {
+	contracts := make([]string, 0, len(o.ABIOverrides))
+	for contract := range o.ABIOverrides {
+		contracts = append(contracts, contract)
+	}
+	sort.Strings(contracts)
+	for _, contract := range contracts {
+		if _, ok := abiRequiredEvents[contract]; !ok {
+			known := make([]string, 0, len(abiRequiredEvents))
+			for name := range abiRequiredEvents {
+				known = append(known, name)
+			}
+			sort.Strings(known)
+			return fmt.Errorf("ABIOverrides.%s: unknown contract, must be one of %v", contract, known)
+		}
+		if _, err := o.GetABI(contract); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetABI(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(`
+[ABIOverrides]
+OffRamp = 'testdata/offramp_abi.json'
+OnRamp = '[{"type": "event", "name": "CCIPMessageSent", "inputs": [{"name": "destChainSelector", "type": "uint64", "indexed": true}]}]'
+`), &cfg))
+	require.NoError(t, cfg.validateABIOverrides())
+
+	offRamp, err := cfg.GetABI("OffRamp")
+	require.NoError(t, err)
+	require.Len(t, offRamp.Events["ExecutionStateChanged"].Inputs, 7)
+	onRamp, err := cfg.GetABI("OnRamp")
+	require.NoError(t, err)
+	require.Contains(t, onRamp.Events, "CCIPMessageSent")
+
+	// contracts without an override use the compiled-in bindings
+	feeQuoter, err := cfg.GetABI("FeeQuoter")
+	require.NoError(t, err)
+	require.Nil(t, feeQuoter)
+}
+
+func TestValidateABIOverrides(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{
+			name:    "missing events",
+			content: `OffRamp = '[{"type": "event", "name": "ExecutionStateChanged", "inputs": []}]'`,
+			err:     "ABIOverrides.OffRamp: ABI of OffRamp is missing events CommitReportAccepted, which the assertions decode",
+		},
+		{name: "unknown contract", content: "Offramp = '[]'", err: "ABIOverrides.Offramp: unknown contract, must be one of [OffRamp OnRamp]"},
+		{name: "invalid JSON", content: "OnRamp = '[{'", err: "ABIOverrides.OnRamp: invalid ABI of OnRamp"},
+		{name: "missing file", content: "OnRamp = 'testdata/nope.json'", err: "ABIOverrides.OnRamp: open testdata/nope.json"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte("[ABIOverrides]\n"+tc.content), &cfg))
+			require.ErrorContains(t, cfg.validateABIOverrides(), tc.err)
+		})
+	}
+}
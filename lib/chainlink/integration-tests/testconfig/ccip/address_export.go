@@ -0,0 +1,217 @@
+package ccip
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	chainselectors "github.com/smartcontractkit/chain-selectors"
+)
+
+const (
+	ADDRESS_EXPORT_FORMAT_JSON = "json"
+	ADDRESS_EXPORT_FORMAT_CSV  = "csv"
+	ADDRESS_EXPORT_FORMAT_ENV  = "env"
+
+	DEFAULT_ADDRESS_EXPORT_DIR = "deployed-addresses"
+
+	// ADDRESS_EXPORT_FILE is the file name of every format, with the format as its extension
+	ADDRESS_EXPORT_FILE = "addresses"
+)
+
+var addressExportFormats = []string{ADDRESS_EXPORT_FORMAT_JSON, ADDRESS_EXPORT_FORMAT_CSV, ADDRESS_EXPORT_FORMAT_ENV}
+
+// addressExportColumns is the schema of every format, in column order for csv.
+var addressExportColumns = []string{"selector", "chain", "contract", "address", "version"}
+
+// AddressExport configures writing the deployed addresses out for scripts and other tools.
+type AddressExport struct {
+	// Formats are the ADDRESS_EXPORT_FORMAT_* formats to write, all of them when empty
+	Formats   []string `toml:",omitempty"`
+	OutputDir *string  `toml:",omitempty"`
+}
+
+// GetFormats returns the formats to write in a stable order.
+func (a *AddressExport) GetFormats() []string {
+	if a == nil || len(a.Formats) == 0 {
+		return addressExportFormats
+	}
+	var formats []string
+	for _, format := range addressExportFormats {
+		if containsString(a.Formats, format) {
+			formats = append(formats, format)
+		}
+	}
+	return formats
+}
+
+func (a *AddressExport) GetOutputDir() string {
+	if a == nil || pointer.GetString(a.OutputDir) == "" {
+		return DEFAULT_ADDRESS_EXPORT_DIR
+	}
+	return *a.OutputDir
+}
+
+func (a *AddressExport) Validate() error {
+	for _, format := range a.Formats {
+		if !containsString(addressExportFormats, format) {
+			return fmt.Errorf("AddressExport.Formats contains unknown format %q, must be one of %s", format, strings.Join(addressExportFormats, ", "))
+		}
+	}
+	return nil
+}
+
+// DeployedContract is an address book entry. It mirrors deployment.TypeAndVersion, so the address
+// book converts with Type: string(tv.Type), Version: tv.Version.String().
+type DeployedContract struct {
+	Type    string
+	Version string
+}
+
+// ExportedAddress is a single row of the export.
+type ExportedAddress struct {
+	Selector uint64 `json:"selector"`
+	Chain    string `json:"chain"`
+	Contract string `json:"contract"`
+	Address  string `json:"address"`
+	Version  string `json:"version"`
+}
+
+// ExportAddresses writes the address book, keyed by selector then address like
+// deployment.AddressBook.Addresses returns it, in every configured format. It returns the
+// files written, none if AddressExport isn't set.
+func (o *Config) ExportAddresses(addresses map[uint64]map[string]DeployedContract) ([]string, error) {
+	if o.AddressExport == nil {
+		return nil, nil
+	}
+	rows := addressExportRows(addresses)
+	dir := o.AddressExport.GetOutputDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fieldError("AddressExport.OutputDir", err)
+	}
+	var files []string
+	for _, format := range o.AddressExport.GetFormats() {
+		content, err := formatAddresses(format, rows)
+		if err != nil {
+			return files, err
+		}
+		file := filepath.Join(dir, ADDRESS_EXPORT_FILE+"."+format)
+		if err := os.WriteFile(file, content, 0o600); err != nil {
+			return files, fmt.Errorf("writing %s: %w", file, err)
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// addressExportRows flattens the address book, sorted by selector, contract and address.
+func addressExportRows(addresses map[uint64]map[string]DeployedContract) []ExportedAddress {
+	var rows []ExportedAddress
+	for selector, contracts := range addresses {
+		chain := ""
+		if c, ok := chainselectors.ChainBySelector(selector); ok {
+			chain = c.Name
+		}
+		for address, contract := range contracts {
+			rows = append(rows, ExportedAddress{
+				Selector: selector,
+				Chain:    chain,
+				Contract: contract.Type,
+				Address:  address,
+				Version:  contract.Version,
+			})
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Selector != rows[j].Selector {
+			return rows[i].Selector < rows[j].Selector
+		}
+		if rows[i].Contract != rows[j].Contract {
+			return rows[i].Contract < rows[j].Contract
+		}
+		return rows[i].Address < rows[j].Address
+	})
+	return rows
+}
+
+func formatAddresses(format string, rows []ExportedAddress) ([]byte, error) {
+	switch format {
+	case ADDRESS_EXPORT_FORMAT_JSON:
+		if rows == nil {
+			rows = []ExportedAddress{}
+		}
+		content, err := json.MarshalIndent(rows, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(content, '\n'), nil
+	case ADDRESS_EXPORT_FORMAT_CSV:
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		records := [][]string{addressExportColumns}
+		for _, row := range rows {
+			records = append(records, []string{strconv.FormatUint(row.Selector, 10), row.Chain, row.Contract, row.Address, row.Version})
+		}
+		if err := w.WriteAll(records); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case ADDRESS_EXPORT_FORMAT_ENV:
+		return formatAddressesEnv(rows), nil
+	}
+	return nil, fmt.Errorf("unknown address export format %q", format)
+}
+
+// formatAddressesEnv writes a NAME=address line per row. A chain with several contracts of the same
+// type gets _1, _2, ... suffixes after the first, in address order.
+func formatAddressesEnv(rows []ExportedAddress) []byte {
+	var buf bytes.Buffer
+	seen := make(map[string]int)
+	for _, row := range rows {
+		chain := row.Chain
+		if chain == "" {
+			chain = strconv.FormatUint(row.Selector, 10)
+		}
+		name := envVarName(chain + "_" + row.Contract)
+		if n := seen[name]; n > 0 {
+			seen[name]++
+			name = fmt.Sprintf("%s_%d", name, n)
+		} else {
+			seen[name] = 1
+		}
+		fmt.Fprintf(&buf, "%s=%s\n", name, row.Address)
+	}
+	return buf.Bytes()
+}
+
+// envVarName upper cases s and replaces everything but letters, digits and underscores, so the name
+// can be used unquoted in a shell. Names can't start with a digit, so those get a leading underscore.
+func envVarName(s string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToUpper(s) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteRune('_')
+		}
+	}
+	name := sb.String()
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	return name
+}
+
+func (o *Config) validateAddressExport() error {
+	if o.AddressExport == nil {
+		return nil
+	}
+	return o.AddressExport.Validate()
+}
@@ -0,0 +1,84 @@
+package ccip
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func testAddressBook() map[uint64]map[string]DeployedContract {
+	return map[uint64]map[string]DeployedContract{
+		12922642891491394802: {
+			"0x5FbDB2315678afecb367f032d93F642f64180aa3": {Type: "Router", Version: "1.2.0"},
+			"0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512": {Type: "OnRamp", Version: "1.6.0-dev"},
+		},
+		3379446385462418246: {
+			"0xCf7Ed3AccA5a467e9e704C703E8D87F634fB0Fc9": {Type: "BurnMintToken", Version: "1.0.0"},
+			"0x9fE46736679d2D9a65F0992F2272dE9f3c7fa6e0": {Type: "BurnMintToken", Version: "1.0.0"},
+			"0xDc64a140Aa3E981100a9becA4E685f962f0cF6C9": {Type: "Router", Version: "1.2.0"},
+		},
+		// not a known selector, so its env names are derived from the selector
+		42: {
+			"0x0165878A594ca255338adfa4d48449f69242Eb8F": {Type: "RMNRemote", Version: "1.6.0-dev"},
+		},
+	}
+}
+
+func TestExportAddresses(t *testing.T) {
+	dir := t.TempDir()
+	cfg := Config{AddressExport: &AddressExport{OutputDir: pointer.ToString(dir)}}
+	files, err := cfg.ExportAddresses(testAddressBook())
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		filepath.Join(dir, "addresses.json"),
+		filepath.Join(dir, "addresses.csv"),
+		filepath.Join(dir, "addresses.env"),
+	}, files)
+
+	for _, format := range []string{ADDRESS_EXPORT_FORMAT_JSON, ADDRESS_EXPORT_FORMAT_CSV} {
+		want, err := os.ReadFile(filepath.Join("testdata", "address_book.golden."+format))
+		require.NoError(t, err)
+		got, err := os.ReadFile(filepath.Join(dir, "addresses."+format))
+		require.NoError(t, err)
+		require.Equal(t, string(want), string(got), format)
+	}
+
+	env, err := os.ReadFile(filepath.Join(dir, "addresses.env"))
+	require.NoError(t, err)
+	require.Equal(t, `_42_RMNREMOTE=0x0165878A594ca255338adfa4d48449f69242Eb8F
+GETH_TESTNET_BURNMINTTOKEN=0x9fE46736679d2D9a65F0992F2272dE9f3c7fa6e0
+GETH_TESTNET_BURNMINTTOKEN_1=0xCf7Ed3AccA5a467e9e704C703E8D87F634fB0Fc9
+GETH_TESTNET_ROUTER=0xDc64a140Aa3E981100a9becA4E685f962f0cF6C9
+GETH_DEVNET_2_ONRAMP=0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512
+GETH_DEVNET_2_ROUTER=0x5FbDB2315678afecb367f032d93F642f64180aa3
+`, string(env))
+}
+
+func TestExportAddressesFormats(t *testing.T) {
+	dir := t.TempDir()
+	cfg := Config{AddressExport: &AddressExport{Formats: []string{ADDRESS_EXPORT_FORMAT_ENV, ADDRESS_EXPORT_FORMAT_CSV}, OutputDir: pointer.ToString(dir)}}
+	files, err := cfg.ExportAddresses(testAddressBook())
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(dir, "addresses.csv"), filepath.Join(dir, "addresses.env")}, files)
+	require.NoFileExists(t, filepath.Join(dir, "addresses.json"))
+
+	files, err = (&Config{}).ExportAddresses(testAddressBook())
+	require.NoError(t, err)
+	require.Empty(t, files)
+}
+
+func TestEnvVarName(t *testing.T) {
+	require.Equal(t, "ETHEREUM_TESTNET_SEPOLIA_ROUTER", envVarName("ethereum-testnet-sepolia_Router"))
+	require.Equal(t, "_1337_ONRAMP", envVarName("1337_OnRamp"))
+	require.Equal(t, "A_B_C", envVarName("a.b c"))
+}
+
+func TestValidateAddressExport(t *testing.T) {
+	cfg := Config{AddressExport: &AddressExport{Formats: []string{"yaml"}}}
+	require.ErrorContains(t, cfg.validateAddressExport(), `unknown format "yaml"`)
+	cfg.AddressExport.Formats = []string{ADDRESS_EXPORT_FORMAT_JSON}
+	require.NoError(t, cfg.validateAddressExport())
+}
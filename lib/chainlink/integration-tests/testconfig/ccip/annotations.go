@@ -0,0 +1,200 @@
+package ccip
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	ANNOTATION_TAG_TEST      = "ccip-test"
+	ANNOTATION_TAG_CHAOS     = "chaos"
+	ANNOTATION_TAG_THRESHOLD = "threshold-violation"
+
+	CHAOS_EVENT_NODE_RESTART       = "node-restart"
+	CHAOS_EVENT_CURSE              = "curse"
+	CHAOS_EVENT_UNCURSE            = "uncurse"
+	CHAOS_EVENT_GAS_SPIKE          = "gas-spike"
+	CHAOS_EVENT_FINALITY_VIOLATION = "finality-violation"
+
+	DEFAULT_ANNOTATION_ATTEMPTS        = 3
+	DEFAULT_ANNOTATION_INITIAL_BACKOFF = 500 * time.Millisecond
+	DEFAULT_ANNOTATION_TIMEOUT         = 10 * time.Second
+)
+
+func (o *Observability) GetAnnotateGrafana() bool {
+	if o == nil {
+		return false
+	}
+	return pointer.GetBool(o.AnnotateGrafana)
+}
+
+func (o *Observability) GetAnnotationTags() []string {
+	if o == nil {
+		return nil
+	}
+	return o.AnnotationTags
+}
+
+// grafanaAnnotation is the body of Grafana's POST /api/annotations.
+type grafanaAnnotation struct {
+	DashboardUID string   `json:"dashboardUID,omitempty"`
+	Time         int64    `json:"time"`
+	TimeEnd      int64    `json:"timeEnd,omitempty"`
+	Tags         []string `json:"tags"`
+	Text         string   `json:"text"`
+}
+
+// Annotator posts Grafana annotations for test phases, chaos events and threshold violations.
+// It is a no-op unless Observability.AnnotateGrafana is set and Grafana is configured,
+// and never returns errors: failures are retried with backoff and then logged.
+type Annotator struct {
+	url          string
+	token        Secret
+	dashboardUID string
+	tags         []string
+	client       *http.Client
+	attempts     int
+	backoff      time.Duration
+}
+
+func NewAnnotator(cfg *Observability) *Annotator {
+	if !cfg.GetAnnotateGrafana() || !cfg.GrafanaEnabled() {
+		return &Annotator{}
+	}
+	return &Annotator{
+		url:          strings.TrimSuffix(cfg.GetGrafanaURL(), "/") + "/api/annotations",
+		token:        cfg.GetGrafanaToken(),
+		dashboardUID: cfg.GetDashboardUID(),
+		tags:         cfg.GetAnnotationTags(),
+		client:       &http.Client{Timeout: DEFAULT_ANNOTATION_TIMEOUT},
+		attempts:     DEFAULT_ANNOTATION_ATTEMPTS,
+		backoff:      DEFAULT_ANNOTATION_INITIAL_BACKOFF,
+	}
+}
+
+// AttachLabels adds the run labels of cfg to the tags of every annotation, as key:value tags since
+// Grafana annotations have no labels. It must be called before the annotator is shared.
+func (a *Annotator) AttachLabels(cfg *Config) {
+	a.tags = append(a.tags, labelTags(cfg.Labels())...)
+}
+
+// Enabled returns false when the annotator drops every annotation.
+func (a *Annotator) Enabled() bool {
+	return a != nil && a.url != ""
+}
+
+func (a *Annotator) TestStarted(ctx context.Context, testName string) {
+	a.Annotate(ctx, time.Now(), fmt.Sprintf("test %s started", testName), ANNOTATION_TAG_TEST, "start")
+}
+
+func (a *Annotator) TestFinished(ctx context.Context, testName string, passed bool) {
+	result := "passed"
+	if !passed {
+		result = "failed"
+	}
+	a.Annotate(ctx, time.Now(), fmt.Sprintf("test %s %s", testName, result), ANNOTATION_TAG_TEST, "end", result)
+}
+
+// ChaosEvent annotates a chaos event such as CHAOS_EVENT_NODE_RESTART with a free form detail.
+func (a *Annotator) ChaosEvent(ctx context.Context, event, detail string) {
+	a.Annotate(ctx, time.Now(), fmt.Sprintf("%s: %s", event, detail), ANNOTATION_TAG_CHAOS, event)
+}
+
+func (a *Annotator) ThresholdViolations(ctx context.Context, violations ...ThresholdViolation) {
+	for _, v := range violations {
+		a.Annotate(ctx, time.Now(), v.String(), ANNOTATION_TAG_THRESHOLD, v.Threshold)
+	}
+}
+
+// StartAnnotator returns the annotator of the config with the run labels attached, after annotating the start
+// of the test. Failing to post that annotation is a start error of COMPONENT_ANNOTATIONS: it is returned if
+// annotations are required, otherwise the returned annotator is disabled and the component degraded.
+func (o *Config) StartAnnotator(ctx context.Context, testName string) (*Annotator, *DegradedComponent, error) {
+	a := NewAnnotator(o.Observability)
+	if !a.Enabled() {
+		return a, nil, nil
+	}
+	a.AttachLabels(o)
+	err := a.send(ctx, time.Now(), fmt.Sprintf("test %s started", testName), ANNOTATION_TAG_TEST, "start")
+	if err == nil {
+		return a, nil, nil
+	}
+	degraded, err := o.HandleStartError(COMPONENT_ANNOTATIONS, err)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &Annotator{}, degraded, nil
+}
+
+// Annotate posts a single annotation with the configured tags plus the given ones.
+func (a *Annotator) Annotate(ctx context.Context, at time.Time, text string, tags ...string) {
+	if !a.Enabled() {
+		return
+	}
+	if err := a.send(ctx, at, text, tags...); err != nil {
+		log.Warn().Err(err).Str("Text", text).Msg("failed to post Grafana annotation")
+	}
+}
+
+// send posts the annotation, retrying with backoff, and returns the last error.
+func (a *Annotator) send(ctx context.Context, at time.Time, text string, tags ...string) error {
+	body, err := json.Marshal(grafanaAnnotation{
+		DashboardUID: a.dashboardUID,
+		Time:         at.UnixMilli(),
+		Tags:         append(append([]string{}, a.tags...), tags...),
+		Text:         text,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode Grafana annotation: %w", err)
+	}
+	backoff := a.backoff
+	for attempt := 1; ; attempt++ {
+		retryable, err := a.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if !retryable || attempt >= a.attempts {
+			return fmt.Errorf("after %d attempts: %w", attempt, err)
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("gave up posting: %w", ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// post sends the annotation, returning whether a failure is worth retrying.
+func (a *Annotator) post(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	token, err := a.token.Value()
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retryable, fmt.Errorf("grafana responded with %s", resp.Status)
+}
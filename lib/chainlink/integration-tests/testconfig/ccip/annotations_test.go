@@ -0,0 +1,74 @@
+package ccip
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnotatorRetriesTransientFailures(t *testing.T) {
+	var calls atomic.Int32
+	var received grafanaAnnotation
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	token := Secret("token")
+	a := NewAnnotator(&Observability{
+		GrafanaURL:      pointer.ToString(server.URL),
+		GrafanaToken:    &token,
+		DashboardUID:    pointer.ToString("ccip"),
+		AnnotateGrafana: pointer.ToBool(true),
+		AnnotationTags:  []string{"soak"},
+	})
+	a.backoff = time.Millisecond
+	a.ChaosEvent(context.Background(), CHAOS_EVENT_NODE_RESTART, "node-1")
+
+	require.EqualValues(t, 2, calls.Load())
+	require.Equal(t, "ccip", received.DashboardUID)
+	require.Equal(t, []string{"soak", ANNOTATION_TAG_CHAOS, CHAOS_EVENT_NODE_RESTART}, received.Tags)
+}
+
+func TestAnnotatorNoopWithoutGrafana(t *testing.T) {
+	t.Setenv(E2E_CCIP_GRAFANA_URL, "")
+	a := NewAnnotator(&Observability{AnnotateGrafana: pointer.ToBool(true)})
+	require.False(t, a.Enabled())
+	// must not panic or block
+	a.TestStarted(context.Background(), "noop")
+}
+
+func TestStartAnnotatorCriticality(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	token := Secret("token")
+	cfg := &Config{Observability: &Observability{
+		GrafanaURL:      pointer.ToString(server.URL),
+		GrafanaToken:    &token,
+		AnnotateGrafana: pointer.ToBool(true),
+	}}
+	a, degraded, err := cfg.StartAnnotator(context.Background(), "optional")
+	require.NoError(t, err)
+	require.False(t, a.Enabled())
+	require.Equal(t, COMPONENT_ANNOTATIONS, degraded.Component)
+	require.Contains(t, degraded.Error, "401")
+
+	cfg.ComponentCriticality = map[string]string{COMPONENT_ANNOTATIONS: CRITICALITY_REQUIRED}
+	_, _, err = cfg.StartAnnotator(context.Background(), "required")
+	require.ErrorContains(t, err, "starting annotations")
+}
@@ -0,0 +1,278 @@
+package ccip
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	ARTIFACT_LOGS             = "logs"
+	ARTIFACT_CONFIG           = "config"
+	ARTIFACT_ADDRESSES        = "addresses"
+	ARTIFACT_CHAIN_STATE      = "chainState"
+	ARTIFACT_METRICS_SNAPSHOT = "metricsSnapshot"
+
+	DEFAULT_FAILURE_ARTIFACTS_DIR         = "failure-artifacts"
+	DEFAULT_FAILURE_ARTIFACTS_MAX_SIZE_MB = 512
+
+	// ARTIFACT_MANIFEST lists what was collected, truncated, skipped and which collectors failed
+	ARTIFACT_MANIFEST = "manifest.json"
+)
+
+// artifactKinds is the order artifacts are collected in, so the size cap drops the least useful ones.
+var artifactKinds = []string{ARTIFACT_CONFIG, ARTIFACT_ADDRESSES, ARTIFACT_LOGS, ARTIFACT_CHAIN_STATE, ARTIFACT_METRICS_SNAPSHOT}
+
+// FailureArtifacts configures the bundle written when a test fails.
+type FailureArtifacts struct {
+	Enabled   *bool   `toml:",omitempty"`
+	OutputDir *string `toml:",omitempty"`
+	// Include are the ARTIFACT_* kinds to collect, all of them when empty
+	Include   []string `toml:",omitempty"`
+	MaxSizeMB *int     `toml:",omitempty"`
+}
+
+func (f *FailureArtifacts) IsEnabled() bool {
+	return f != nil && pointer.GetBool(f.Enabled)
+}
+
+func (f *FailureArtifacts) GetOutputDir() string {
+	if f == nil || pointer.GetString(f.OutputDir) == "" {
+		return DEFAULT_FAILURE_ARTIFACTS_DIR
+	}
+	return *f.OutputDir
+}
+
+// GetInclude returns the artifact kinds to collect in collection order.
+func (f *FailureArtifacts) GetInclude() []string {
+	if f == nil || len(f.Include) == 0 {
+		return artifactKinds
+	}
+	var include []string
+	for _, kind := range artifactKinds {
+		if containsString(f.Include, kind) {
+			include = append(include, kind)
+		}
+	}
+	return include
+}
+
+func (f *FailureArtifacts) GetMaxSizeMB() int {
+	if f == nil || f.MaxSizeMB == nil {
+		return DEFAULT_FAILURE_ARTIFACTS_MAX_SIZE_MB
+	}
+	return *f.MaxSizeMB
+}
+
+func (f *FailureArtifacts) Validate() error {
+	for _, kind := range f.Include {
+		if !containsString(artifactKinds, kind) {
+			return fmt.Errorf("FailureArtifacts.Include contains unknown artifact %q, must be one of %s", kind, strings.Join(artifactKinds, ", "))
+		}
+	}
+	if f.MaxSizeMB != nil && *f.MaxSizeMB <= 0 {
+		return fmt.Errorf("FailureArtifacts.MaxSizeMB must be positive, got %d", *f.MaxSizeMB)
+	}
+	return nil
+}
+
+// Artifact is a single file of the bundle. Name is relative to the directory of its kind.
+type Artifact struct {
+	Name    string
+	Content []byte
+}
+
+// ArtifactCollector gathers the artifacts of one component, e.g. the logs of the nodes.
+type ArtifactCollector func(ctx context.Context) ([]Artifact, error)
+
+// ArtifactCollectorRegistry holds the collectors components registered, by artifact kind and name.
+type ArtifactCollectorRegistry struct {
+	mu         sync.RWMutex
+	collectors map[string]map[string]ArtifactCollector
+}
+
+// DefaultArtifactCollectors is the registry CollectArtifacts uses. Components register their
+// collectors with it once they're up, e.g. "logs"/"jd" once JD runs.
+var DefaultArtifactCollectors = NewArtifactCollectorRegistry()
+
+func NewArtifactCollectorRegistry() *ArtifactCollectorRegistry {
+	return &ArtifactCollectorRegistry{collectors: make(map[string]map[string]ArtifactCollector)}
+}
+
+// Register adds the collector under the kind, replacing a collector registered with the same name.
+func (r *ArtifactCollectorRegistry) Register(kind, name string, collector ArtifactCollector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.collectors[kind] == nil {
+		r.collectors[kind] = make(map[string]ArtifactCollector)
+	}
+	r.collectors[kind][name] = collector
+}
+
+func (r *ArtifactCollectorRegistry) Unregister(kind, name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.collectors[kind], name)
+}
+
+type namedCollector struct {
+	name      string
+	collector ArtifactCollector
+}
+
+func (r *ArtifactCollectorRegistry) get(kind string) []namedCollector {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	collectors := make([]namedCollector, 0, len(r.collectors[kind]))
+	for name, collector := range r.collectors[kind] {
+		collectors = append(collectors, namedCollector{name, collector})
+	}
+	sort.Slice(collectors, func(i, j int) bool { return collectors[i].name < collectors[j].name })
+	return collectors
+}
+
+// ArtifactManifest is written next to the artifacts to tell what the bundle does and doesn't contain.
+type ArtifactManifest struct {
+	Reason      string    `json:"reason"`
+	CollectedAt time.Time `json:"collectedAt"`
+	// Files are relative to the output dir
+	Files     []string          `json:"files"`
+	Truncated []string          `json:"truncated,omitempty"`
+	Skipped   []string          `json:"skipped,omitempty"`
+	Failed    map[string]string `json:"failed,omitempty"`
+}
+
+// CollectArtifacts writes the failure bundle to FailureArtifacts.OutputDir with the collectors of
+// DefaultArtifactCollectors. It's a no-op unless FailureArtifacts is enabled.
+func (o *Config) CollectArtifacts(ctx context.Context, reason string) error {
+	return o.collectArtifacts(ctx, DefaultArtifactCollectors, reason)
+}
+
+// collectArtifacts is best effort: a failing collector is recorded in the manifest and the returned
+// error, but every other collector still runs. Known secret values are redacted from every artifact and
+// once MaxSizeMB is reached the artifact is truncated and the rest are skipped.
+func (o *Config) collectArtifacts(ctx context.Context, registry *ArtifactCollectorRegistry, reason string) error {
+	if !o.FailureArtifacts.IsEnabled() {
+		return nil
+	}
+	dir := o.FailureArtifacts.GetOutputDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create failure artifacts dir: %w", err)
+	}
+	manifest := ArtifactManifest{Reason: reason, CollectedAt: time.Now().UTC(), Failed: make(map[string]string)}
+	redactor := newRedactor(o)
+	remaining := int64(o.FailureArtifacts.GetMaxSizeMB()) << 20
+	var errs []error
+	for _, kind := range o.FailureArtifacts.GetInclude() {
+		collectors := registry.get(kind)
+		if kind == ARTIFACT_CONFIG {
+			collectors = append([]namedCollector{{"resolved", o.collectConfigArtifact}}, collectors...)
+		}
+		for _, c := range collectors {
+			id := kind + "/" + c.name
+			if err := ctx.Err(); err != nil {
+				manifest.Skipped = append(manifest.Skipped, id)
+				continue
+			}
+			artifacts, err := c.collector(ctx)
+			if err != nil {
+				manifest.Failed[id] = redactor.Replace(err.Error())
+				errs = append(errs, fmt.Errorf("%s: %w", id, err))
+			}
+			for _, artifact := range artifacts {
+				file := filepath.Join(kind, c.name, filepath.Clean("/"+artifact.Name))
+				if remaining <= 0 {
+					manifest.Skipped = append(manifest.Skipped, file)
+					continue
+				}
+				content := []byte(redactor.Replace(string(artifact.Content)))
+				if int64(len(content)) > remaining {
+					content = content[:remaining]
+					manifest.Truncated = append(manifest.Truncated, file)
+				}
+				if err := writeArtifact(filepath.Join(dir, file), content); err != nil {
+					manifest.Failed[file] = err.Error()
+					errs = append(errs, fmt.Errorf("%s: %w", file, err))
+					continue
+				}
+				remaining -= int64(len(content))
+				manifest.Files = append(manifest.Files, file)
+			}
+		}
+	}
+	encoded, err := json.MarshalIndent(manifest, "", "  ")
+	if err == nil {
+		err = os.WriteFile(filepath.Join(dir, ARTIFACT_MANIFEST), encoded, 0o600)
+	}
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%s: %w", ARTIFACT_MANIFEST, err))
+	}
+	log.Info().Str("Dir", dir).Int("Files", len(manifest.Files)).Int("Failed", len(manifest.Failed)).Msg("Collected failure artifacts")
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to collect some failure artifacts: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// collectConfigArtifact is the built-in config collector, secrets are redacted by Secret's marshalling.
+func (o *Config) collectConfigArtifact(context.Context) ([]Artifact, error) {
+	content, err := toml.Marshal(o)
+	if err != nil {
+		return nil, err
+	}
+	return []Artifact{{Name: "ccip.toml", Content: content}}, nil
+}
+
+func writeArtifact(path string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0o600)
+}
+
+// newRedactor replaces the values of the config's secrets, including resolved references and the
+// secret env var fallbacks, with REDACTED_SECRET.
+func newRedactor(o *Config) *strings.Replacer {
+	secrets := make(map[string]Secret)
+	collectSecrets(reflect.ValueOf(o), "", secrets)
+	values := make(map[string]bool)
+	for _, secret := range secrets {
+		if !secret.IsReference() {
+			values[string(secret)] = true
+		} else if resolved, ok := secretCache.Load(string(secret)); ok {
+			values[resolved.(string)] = true
+		}
+	}
+	for _, envVar := range []string{E2E_CCIP_LOKI_BASIC_AUTH, E2E_CCIP_GRAFANA_TOKEN} {
+		values[os.Getenv(envVar)] = true
+	}
+	delete(values, "")
+	sorted := make([]string, 0, len(values))
+	for value := range values {
+		sorted = append(sorted, value)
+	}
+	// longest first, so a secret containing another one is replaced whole
+	sort.Slice(sorted, func(i, j int) bool {
+		if len(sorted[i]) != len(sorted[j]) {
+			return len(sorted[i]) > len(sorted[j])
+		}
+		return sorted[i] < sorted[j]
+	})
+	var pairs []string
+	for _, value := range sorted {
+		pairs = append(pairs, value, REDACTED_SECRET)
+	}
+	return strings.NewReplacer(pairs...)
+}
@@ -0,0 +1,93 @@
+package ccip
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func fakeCollector(artifacts ...Artifact) ArtifactCollector {
+	return func(context.Context) ([]Artifact, error) { return artifacts, nil }
+}
+
+func readManifest(t *testing.T, dir string) ArtifactManifest {
+	content, err := os.ReadFile(filepath.Join(dir, ARTIFACT_MANIFEST))
+	require.NoError(t, err)
+	var manifest ArtifactManifest
+	require.NoError(t, json.Unmarshal(content, &manifest))
+	return manifest
+}
+
+func TestCollectArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	token := Secret("grafana-token-value")
+	cfg := &Config{
+		Observability:    &Observability{GrafanaToken: &token},
+		FailureArtifacts: &FailureArtifacts{Enabled: pointer.ToBool(true), OutputDir: pointer.ToString(dir)},
+	}
+	registry := NewArtifactCollectorRegistry()
+	registry.Register(ARTIFACT_LOGS, "node-1", fakeCollector(Artifact{Name: "node.log", Content: []byte("using token grafana-token-value")}))
+	registry.Register(ARTIFACT_LOGS, "jd", func(context.Context) ([]Artifact, error) {
+		return nil, errors.New("container is gone")
+	})
+	registry.Register(ARTIFACT_ADDRESSES, "book", fakeCollector(Artifact{Name: "../../escape.json", Content: []byte("{}")}))
+
+	err := cfg.collectArtifacts(context.Background(), registry, "TestSmoke failed")
+	require.ErrorContains(t, err, "logs/jd: container is gone")
+
+	manifest := readManifest(t, dir)
+	require.Equal(t, "TestSmoke failed", manifest.Reason)
+	require.Equal(t, []string{"config/resolved/ccip.toml", "addresses/book/escape.json", "logs/node-1/node.log"}, manifest.Files)
+	require.Equal(t, map[string]string{"logs/jd": "container is gone"}, manifest.Failed)
+
+	nodeLog, err := os.ReadFile(filepath.Join(dir, "logs", "node-1", "node.log"))
+	require.NoError(t, err)
+	require.Equal(t, "using token "+REDACTED_SECRET, string(nodeLog))
+	config, err := os.ReadFile(filepath.Join(dir, "config", "resolved", "ccip.toml"))
+	require.NoError(t, err)
+	require.NotContains(t, string(config), "grafana-token-value")
+}
+
+func TestCollectArtifactsSizeCap(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &Config{FailureArtifacts: &FailureArtifacts{
+		Enabled:   pointer.ToBool(true),
+		OutputDir: pointer.ToString(dir),
+		Include:   []string{ARTIFACT_LOGS},
+		MaxSizeMB: pointer.ToInt(1),
+	}}
+	registry := NewArtifactCollectorRegistry()
+	big := []byte(strings.Repeat("x", 700<<10))
+	registry.Register(ARTIFACT_LOGS, "a", fakeCollector(Artifact{Name: "1.log", Content: big}, Artifact{Name: "2.log", Content: big}))
+	registry.Register(ARTIFACT_LOGS, "b", fakeCollector(Artifact{Name: "3.log", Content: big}))
+	registry.Register(ARTIFACT_CHAIN_STATE, "geth", fakeCollector(Artifact{Name: "blocks.json", Content: []byte("[]")}))
+
+	require.NoError(t, cfg.collectArtifacts(context.Background(), registry, "timeout"))
+	manifest := readManifest(t, dir)
+	require.Equal(t, []string{"logs/a/1.log", "logs/a/2.log"}, manifest.Files)
+	require.Equal(t, []string{"logs/a/2.log"}, manifest.Truncated)
+	require.Equal(t, []string{"logs/b/3.log"}, manifest.Skipped)
+	info, err := os.Stat(filepath.Join(dir, "logs", "a", "2.log"))
+	require.NoError(t, err)
+	require.EqualValues(t, 1<<20-700<<10, info.Size())
+}
+
+func TestCollectArtifactsDisabled(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "artifacts")
+	cfg := &Config{FailureArtifacts: &FailureArtifacts{OutputDir: pointer.ToString(dir)}}
+	require.NoError(t, cfg.collectArtifacts(context.Background(), NewArtifactCollectorRegistry(), "failed"))
+	require.NoDirExists(t, dir)
+}
+
+func TestFailureArtifactsValidate(t *testing.T) {
+	require.NoError(t, (&FailureArtifacts{Include: []string{ARTIFACT_LOGS, ARTIFACT_METRICS_SNAPSHOT}}).Validate())
+	require.ErrorContains(t, (&FailureArtifacts{Include: []string{"heapDump"}}).Validate(), `unknown artifact "heapDump"`)
+	require.ErrorContains(t, (&FailureArtifacts{MaxSizeMB: pointer.ToInt(0)}).Validate(), "MaxSizeMB must be positive")
+}
@@ -0,0 +1,129 @@
+package ccip
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	ASSERTION_SAMPLING_ALL    = "all"
+	ASSERTION_SAMPLING_SAMPLE = "sample"
+	// ASSERTION_SAMPLING_TAIL asserts the messages sent last on every lane
+	ASSERTION_SAMPLING_TAIL = "tail"
+
+	DEFAULT_ASSERTION_MIN_SAMPLED = 100
+	// MIN_MESSAGES_PER_LANE_TO_SAMPLE is the load below which every message is asserted, sampling
+	// saves too few RPC calls there to be worth the missed messages
+	MIN_MESSAGES_PER_LANE_TO_SAMPLE = 10000
+)
+
+var assertionSamplingModes = []string{ASSERTION_SAMPLING_ALL, ASSERTION_SAMPLING_SAMPLE, ASSERTION_SAMPLING_TAIL}
+
+// AssertionSampling limits which messages the assert phase checks one by one over RPC. The executed
+// total of every lane is always verified from the offramp events.
+type AssertionSampling struct {
+	Mode          *string  `toml:",omitempty"`
+	SampleRatePct *float64 `toml:",omitempty"`
+	// AlwaysAssertFailedSends asserts every message whose send reported an error, true unless set
+	AlwaysAssertFailedSends *bool `toml:",omitempty"`
+	// MinSampled is the number of messages asserted per lane at least
+	MinSampled *int `toml:",omitempty"`
+}
+
+// GetMode returns Mode, ASSERTION_SAMPLING_ALL unless set.
+func (a *AssertionSampling) GetMode() string {
+	if a == nil || pointer.GetString(a.Mode) == "" {
+		return ASSERTION_SAMPLING_ALL
+	}
+	return *a.Mode
+}
+
+func (a *AssertionSampling) GetAlwaysAssertFailedSends() bool {
+	return a == nil || a.AlwaysAssertFailedSends == nil || *a.AlwaysAssertFailedSends
+}
+
+func (a *AssertionSampling) GetMinSampled() int {
+	if a == nil || a.MinSampled == nil {
+		return DEFAULT_ASSERTION_MIN_SAMPLED
+	}
+	return *a.MinSampled
+}
+
+// LaneSample is which messages of a lane the assert phase checks one by one.
+type LaneSample struct {
+	mode          string
+	alwaysFailed  bool
+	first, stride int
+}
+
+// NewLaneSample returns the sample of a lane that sent total messages. Sampled messages are spread
+// evenly over the lane from a random offset, so at least the sample rate and MinSampled of them are
+// asserted. Pass a rand from Config.NewRand(RAND_COMPONENT_ASSERTIONS) to reproduce the sample.
+func (a *AssertionSampling) NewLaneSample(total int, rng *rand.Rand) LaneSample {
+	sample := LaneSample{mode: a.GetMode(), alwaysFailed: a.GetAlwaysAssertFailedSends(), stride: 1}
+	if sample.mode == ASSERTION_SAMPLING_ALL {
+		return sample
+	}
+	sampled := max(int(math.Ceil(float64(total)*pointer.GetFloat64(a.SampleRatePct)/100)), a.GetMinSampled())
+	if sampled >= total {
+		sample.mode = ASSERTION_SAMPLING_ALL
+		return sample
+	}
+	switch sample.mode {
+	case ASSERTION_SAMPLING_TAIL:
+		sample.first = total - sampled
+	case ASSERTION_SAMPLING_SAMPLE:
+		sample.stride = total / sampled
+		sample.first = rng.Intn(sample.stride)
+	}
+	return sample
+}
+
+// ShouldAssert returns whether the message with the 0 based index on its lane is asserted.
+func (s LaneSample) ShouldAssert(index int, sendFailed bool) bool {
+	if s.mode == ASSERTION_SAMPLING_ALL || (sendFailed && s.alwaysFailed) {
+		return true
+	}
+	return index >= s.first && (index-s.first)%s.stride == 0
+}
+
+// VerifyExecutedTotal compares the messages sent on a lane with the executions its offramp emitted,
+// which holds whether per message checks are sampled or not.
+func VerifyExecutedTotal(lane string, sent, executed int) error {
+	if executed < sent {
+		return fmt.Errorf("lane %s: %d messages were sent, the offramp executed only %d", lane, sent, executed)
+	}
+	return nil
+}
+
+func (o *Config) validateAssertionSampling() error {
+	a := o.AssertionSampling
+	if a == nil {
+		return nil
+	}
+	mode := a.GetMode()
+	if !containsString(assertionSamplingModes, mode) {
+		return fieldError("AssertionSampling.Mode", fmt.Errorf("unknown value %q", mode))
+	}
+	if mode == ASSERTION_SAMPLING_ALL {
+		if a.SampleRatePct != nil || a.MinSampled != nil {
+			return fmt.Errorf("AssertionSampling.SampleRatePct and MinSampled are not used by the %s mode", ASSERTION_SAMPLING_ALL)
+		}
+		return nil
+	}
+	if rate := pointer.GetFloat64(a.SampleRatePct); rate <= 0 || rate > 100 {
+		return fmt.Errorf("AssertionSampling.SampleRatePct must be in (0, 100] for the %s mode, got %g", mode, rate)
+	}
+	if a.GetMinSampled() < 1 {
+		return fmt.Errorf("AssertionSampling.MinSampled must be at least 1, got %d", a.GetMinSampled())
+	}
+	perLane := o.LoadProfile.GetMessagesPerSecond() * o.LoadProfile.GetTestDuration().Seconds()
+	if perLane < MIN_MESSAGES_PER_LANE_TO_SAMPLE {
+		return fmt.Errorf("AssertionSampling.Mode must be %s, LoadProfile sends %.0f messages per lane and sampling needs at least %d",
+			ASSERTION_SAMPLING_ALL, perLane, MIN_MESSAGES_PER_LANE_TO_SAMPLE)
+	}
+	return nil
+}
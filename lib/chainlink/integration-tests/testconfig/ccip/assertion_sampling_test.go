@@ -0,0 +1,79 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func countAsserted(sample LaneSample, total int) int {
+	asserted := 0
+	for i := 0; i < total; i++ {
+		if sample.ShouldAssert(i, false) {
+			asserted++
+		}
+	}
+	return asserted
+}
+
+func TestAssertionSampling(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(`
+RandomSeed = 42
+
+[LoadProfile]
+MessagesPerSecond = 10.0
+TestDuration = '1h'
+
+[AssertionSampling]
+Mode = 'sample'
+SampleRatePct = 1.0
+MinSampled = 500
+`), &cfg))
+	require.NoError(t, cfg.validateAssertionSampling())
+
+	sampling := cfg.AssertionSampling
+	sample := sampling.NewLaneSample(36000, cfg.NewRand(RAND_COMPONENT_ASSERTIONS))
+	require.GreaterOrEqual(t, countAsserted(sample, 36000), 500)
+	require.Less(t, countAsserted(sample, 36000), 520)
+	// failed sends are asserted whether they're sampled or not
+	for i := 0; i < 36000; i++ {
+		require.True(t, sample.ShouldAssert(i, true))
+	}
+	// the rate wins over MinSampled on larger lanes
+	require.GreaterOrEqual(t, countAsserted(sampling.NewLaneSample(100000, cfg.NewRand(RAND_COMPONENT_ASSERTIONS)), 100000), 1000)
+	// lanes smaller than the sample are asserted completely
+	require.Equal(t, 300, countAsserted(sampling.NewLaneSample(300, cfg.NewRand(RAND_COMPONENT_ASSERTIONS)), 300))
+
+	sampling.Mode = pointer.ToString(ASSERTION_SAMPLING_TAIL)
+	tail := sampling.NewLaneSample(36000, cfg.NewRand(RAND_COMPONENT_ASSERTIONS))
+	require.Equal(t, 500, countAsserted(tail, 36000))
+	require.False(t, tail.ShouldAssert(35499, false))
+	require.True(t, tail.ShouldAssert(35500, false))
+
+	var unset *AssertionSampling
+	require.Equal(t, 10, countAsserted(unset.NewLaneSample(10, nil), 10))
+
+	require.NoError(t, VerifyExecutedTotal("SIMULATED_1->SIMULATED_2", 36000, 36000))
+	require.EqualError(t, VerifyExecutedTotal("SIMULATED_1->SIMULATED_2", 36000, 35999),
+		"lane SIMULATED_1->SIMULATED_2: 36000 messages were sent, the offramp executed only 35999")
+
+	for _, tc := range []struct {
+		sampling string
+		err      string
+	}{
+		{"Mode = 'random'\n", `AssertionSampling.Mode: unknown value "random" (Which messages are asserted one by one; one of all, sample, tail)`},
+		{"Mode = 'all'\nSampleRatePct = 5.0\n", "AssertionSampling.SampleRatePct and MinSampled are not used by the all mode"},
+		{"Mode = 'sample'\n", "AssertionSampling.SampleRatePct must be in (0, 100] for the sample mode, got 0"},
+		{"Mode = 'tail'\nSampleRatePct = 150.0\n", "AssertionSampling.SampleRatePct must be in (0, 100] for the tail mode, got 150"},
+		{"Mode = 'sample'\nSampleRatePct = 5.0\nMinSampled = 0\n", "AssertionSampling.MinSampled must be at least 1, got 0"},
+		{"Mode = 'sample'\nSampleRatePct = 5.0\n[LoadProfile]\nMessagesPerSecond = 1.0\nTestDuration = '1h'\n",
+			"AssertionSampling.Mode must be all, LoadProfile sends 3600 messages per lane and sampling needs at least 10000"},
+	} {
+		var cfg Config
+		require.NoError(t, toml.Unmarshal([]byte("[AssertionSampling]\n"+tc.sampling), &cfg))
+		require.EqualError(t, cfg.validateAssertionSampling(), tc.err, tc.sampling)
+	}
+}
@@ -0,0 +1,122 @@
+package ccip
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	// ASSERTION_SOURCE_LOGS checks executions with the offramp's ExecutionStateChanged logs
+	ASSERTION_SOURCE_LOGS = "logs"
+	// ASSERTION_SOURCE_STATE reads the execution state from the offramp, slower but authoritative
+	ASSERTION_SOURCE_STATE = "state"
+	// ASSERTION_SOURCE_BOTH checks both and reports where they disagree, the state read wins
+	ASSERTION_SOURCE_BOTH = "both"
+
+	DEFAULT_ASSERTION_SOURCE = ASSERTION_SOURCE_LOGS
+)
+
+var assertionSources = []string{ASSERTION_SOURCE_LOGS, ASSERTION_SOURCE_STATE, ASSERTION_SOURCE_BOTH}
+
+// ChainAssertionSource overrides AssertionSource for the executions on a destination chain.
+type ChainAssertionSource struct {
+	Source *string `toml:",omitempty"`
+	// UnreliableLogs flags chains whose RPC providers lag on log indexing, they are asserted with state reads
+	UnreliableLogs *bool `toml:",omitempty"`
+}
+
+// GetAssertionSource returns how executions on the destination chain are asserted, state reads for
+// chains with unreliable logs unless set otherwise.
+func (o *Config) GetAssertionSource(destSelector uint64) string {
+	if chain := o.chainAssertionSource(destSelector); chain != nil {
+		if source := pointer.GetString(chain.Source); source != "" {
+			return source
+		}
+		if pointer.GetBool(chain.UnreliableLogs) {
+			return ASSERTION_SOURCE_STATE
+		}
+	}
+	if source := pointer.GetString(o.AssertionSource); source != "" {
+		return source
+	}
+	return DEFAULT_ASSERTION_SOURCE
+}
+
+func (o *Config) chainAssertionSource(selector uint64) *ChainAssertionSource {
+	for ref, chain := range o.AssertionSourcePerChain {
+		if resolved, err := o.ResolveChainSelector(ref); err == nil && resolved == selector {
+			return chain
+		}
+	}
+	return nil
+}
+
+// ExecutionCheck returns whether a message was executed according to one of the sources.
+type ExecutionCheck func(ctx context.Context) (bool, error)
+
+// AssertionVerdict is whether a message was executed, and what says so.
+type AssertionVerdict struct {
+	Executed bool
+	// Source is the ASSERTION_SOURCE_* the verdict is backed by
+	Source string
+	// Discrepancy describes how logs and state disagreed with ASSERTION_SOURCE_BOTH, empty if they agreed
+	Discrepancy string
+}
+
+// CheckExecuted asserts the execution of a message on the destination chain with the source configured
+// for it. Pass the verdict to Reporter.RecordVerdict.
+func (o *Config) CheckExecuted(ctx context.Context, destSelector uint64, fromLogs, fromState ExecutionCheck) (AssertionVerdict, error) {
+	source := o.GetAssertionSource(destSelector)
+	verdict := AssertionVerdict{Source: source}
+	var err error
+	switch source {
+	case ASSERTION_SOURCE_LOGS:
+		verdict.Executed, err = fromLogs(ctx)
+	case ASSERTION_SOURCE_STATE:
+		verdict.Executed, err = fromState(ctx)
+	case ASSERTION_SOURCE_BOTH:
+		var logged bool
+		if logged, err = fromLogs(ctx); err != nil {
+			return verdict, err
+		}
+		if verdict.Executed, err = fromState(ctx); err == nil && logged != verdict.Executed {
+			verdict.Discrepancy = fmt.Sprintf("logs report executed=%t, offramp state executed=%t", logged, verdict.Executed)
+		}
+	default:
+		return verdict, fmt.Errorf("unknown assertion source %q, expected one of %v", source, assertionSources)
+	}
+	return verdict, err
+}
+
+func (o *Config) validateAssertionSource() error {
+	if source := pointer.GetString(o.AssertionSource); source != "" && !containsString(assertionSources, source) {
+		return fmt.Errorf("AssertionSource must be one of %v, got %q", assertionSources, source)
+	}
+	refs := make([]string, 0, len(o.AssertionSourcePerChain))
+	for ref := range o.AssertionSourcePerChain {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	for _, ref := range refs {
+		field := "AssertionSourcePerChain." + ref
+		if _, err := o.ResolveChainSelector(ref); err != nil {
+			return fieldError(field, err)
+		}
+		chain := o.AssertionSourcePerChain[ref]
+		if chain == nil {
+			continue
+		}
+		source := pointer.GetString(chain.Source)
+		if source != "" && !containsString(assertionSources, source) {
+			return fmt.Errorf("%s.Source must be one of %v, got %q", field, assertionSources, source)
+		}
+		// logs can't be trusted on these chains, not even to cross-check
+		if pointer.GetBool(chain.UnreliableLogs) && source != "" && source != ASSERTION_SOURCE_STATE {
+			return fmt.Errorf("%s.Source must be %s, %s.UnreliableLogs is set", field, ASSERTION_SOURCE_STATE, field)
+		}
+	}
+	return nil
+}
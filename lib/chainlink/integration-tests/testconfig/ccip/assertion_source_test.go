@@ -0,0 +1,102 @@
+package ccip
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const assertionSourceTOML = `
+AssertionSource = 'both'
+
+[PrivateEthereumNetworks.SIMULATED_1.EthereumChainConfig]
+chain_id = 1337
+
+[PrivateEthereumNetworks.SIMULATED_2.EthereumChainConfig]
+chain_id = 2337
+
+[AssertionSourcePerChain.SIMULATED_2]
+UnreliableLogs = true
+
+[AssertionSourcePerChain.ethereum-testnet-sepolia]
+Source = 'logs'
+`
+
+func TestGetAssertionSource(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(assertionSourceTOML), &cfg))
+	require.NoError(t, cfg.validateAssertionSource())
+
+	require.Equal(t, ASSERTION_SOURCE_BOTH, cfg.GetAssertionSource(3379446385462418246))
+	// unreliable logs force state reads
+	require.Equal(t, ASSERTION_SOURCE_STATE, cfg.GetAssertionSource(12922642891491394802))
+	require.Equal(t, ASSERTION_SOURCE_LOGS, cfg.GetAssertionSource(16015286601757825753))
+	require.Equal(t, DEFAULT_ASSERTION_SOURCE, (&Config{}).GetAssertionSource(3379446385462418246))
+}
+
+func TestCheckExecuted(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(assertionSourceTOML), &cfg))
+	executed := func(context.Context) (bool, error) { return true, nil }
+	missing := func(context.Context) (bool, error) { return false, nil }
+	unused := func(context.Context) (bool, error) { return false, errors.New("source must not be read") }
+
+	// the log index lags behind, the state read wins
+	verdict, err := cfg.CheckExecuted(context.Background(), 3379446385462418246, missing, executed)
+	require.NoError(t, err)
+	require.Equal(t, AssertionVerdict{Executed: true, Source: ASSERTION_SOURCE_BOTH, Discrepancy: "logs report executed=false, offramp state executed=true"}, verdict)
+
+	verdict, err = cfg.CheckExecuted(context.Background(), 3379446385462418246, executed, executed)
+	require.NoError(t, err)
+	require.Equal(t, AssertionVerdict{Executed: true, Source: ASSERTION_SOURCE_BOTH}, verdict)
+
+	verdict, err = cfg.CheckExecuted(context.Background(), 12922642891491394802, unused, executed)
+	require.NoError(t, err)
+	require.Equal(t, AssertionVerdict{Executed: true, Source: ASSERTION_SOURCE_STATE}, verdict)
+
+	verdict, err = cfg.CheckExecuted(context.Background(), 16015286601757825753, missing, unused)
+	require.NoError(t, err)
+	require.Equal(t, AssertionVerdict{Source: ASSERTION_SOURCE_LOGS}, verdict)
+}
+
+func TestValidateAssertionSource(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "unknown source", content: "AssertionSource = 'receipts'", err: `AssertionSource must be one of [logs state both], got "receipts"`},
+		{name: "unknown chain", content: "[AssertionSourcePerChain.UNKNOWN]\nSource = 'state'", err: "AssertionSourcePerChain.UNKNOWN: chain UNKNOWN"},
+		{
+			name:    "logs on a chain with unreliable logs",
+			content: "[AssertionSourcePerChain.ethereum-testnet-sepolia]\nSource = 'both'\nUnreliableLogs = true",
+			err:     "AssertionSourcePerChain.ethereum-testnet-sepolia.Source must be state, AssertionSourcePerChain.ethereum-testnet-sepolia.UnreliableLogs is set",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte(tc.content), &cfg))
+			require.ErrorContains(t, cfg.validateAssertionSource(), tc.err)
+		})
+	}
+}
+
+func TestReporterRecordsVerdicts(t *testing.T) {
+	reporter := NewReporter(&Reporting{})
+	sentAt := time.Now()
+	for seqNr := uint64(1); seqNr <= 2; seqNr++ {
+		require.NoError(t, reporter.RecordMessageEvent(MessageEvent{Lane: "a->b", SeqNr: seqNr, Phase: MESSAGE_PHASE_SENT, At: sentAt}))
+		require.NoError(t, reporter.RecordMessageEvent(MessageEvent{Lane: "a->b", SeqNr: seqNr, Phase: MESSAGE_PHASE_EXECUTED, At: sentAt.Add(time.Minute)}))
+	}
+	reporter.RecordVerdict("a->b", 1, AssertionVerdict{Executed: true, Source: ASSERTION_SOURCE_BOTH})
+	reporter.RecordVerdict("a->b", 2, AssertionVerdict{Executed: true, Source: ASSERTION_SOURCE_BOTH, Discrepancy: "logs report executed=false, offramp state executed=true"})
+
+	report := reporter.Report()
+	require.False(t, report.Passed)
+	require.Equal(t, ReportCounts{Sent: 2, Executed: 2, Discrepancies: 1}, report.Totals)
+	require.Equal(t, "logs and offramp state disagreed on 1 of 2 messages", report.toJUnit().TestCases[0].Failure.Message)
+}
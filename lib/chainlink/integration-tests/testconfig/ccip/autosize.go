@@ -0,0 +1,148 @@
+package ccip
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	// AUTO_SIZE_MESSAGES_PER_SENDER is the message rate a single sequentially nonced sender account sustains
+	AUTO_SIZE_MESSAGES_PER_SENDER = 5.0
+	// AUTO_SIZE_RESOURCE_STEP is the message rate each step of extra resources is sized for
+	AUTO_SIZE_RESOURCE_STEP = 100.0
+)
+
+// SizingRecommendation is the environment SizeEnvironment recommends for a load profile.
+type SizingRecommendation struct {
+	NoOfPluginNodes        int
+	Node                   ResourceSizing
+	DB                     ResourceSizing
+	ChainClient            ResourceSizing
+	SenderAccountsPerChain int
+}
+
+type ResourceSizing struct {
+	CPU      float64
+	MemoryMB int
+}
+
+// SizingDecision is a field AutoSize filled in.
+type SizingDecision struct {
+	Field string
+	Value string
+}
+
+func (d SizingDecision) String() string {
+	return fmt.Sprintf("%s = %s", d.Field, d.Value)
+}
+
+// SizeEnvironment recommends an environment that sustains the profile's message rate without the
+// environment becoming the bottleneck:
+//   - 4 plugin nodes below 50 msg/s, 7 below 200 msg/s and 10 from there on, so that the DON tolerates
+//     1, 2 and 3 nodes falling behind under load
+//   - for every started 100 msg/s, an extra core and 1024MB per node on top of 1 core and 2048MB, half
+//     a core and 512MB per node database on top of 1 core and 1024MB, and a core and 1024MB per chain
+//     client on top of 2 cores and 4096MB
+//   - one sender account per 5 msg/s on each chain, and never fewer than LoadProfile.ConcurrentSenders
+func SizeEnvironment(profile LoadProfile) SizingRecommendation {
+	mps := profile.GetMessagesPerSecond()
+	steps := int(math.Ceil(mps / AUTO_SIZE_RESOURCE_STEP))
+	rec := SizingRecommendation{
+		NoOfPluginNodes:        10,
+		Node:                   ResourceSizing{CPU: float64(1 + steps), MemoryMB: 2048 + 1024*steps},
+		DB:                     ResourceSizing{CPU: 1 + 0.5*float64(steps), MemoryMB: 1024 + 512*steps},
+		ChainClient:            ResourceSizing{CPU: float64(2 + steps), MemoryMB: 4096 + 1024*steps},
+		SenderAccountsPerChain: int(math.Ceil(mps / AUTO_SIZE_MESSAGES_PER_SENDER)),
+	}
+	switch {
+	case mps < 50:
+		rec.NoOfPluginNodes = 4
+	case mps < 200:
+		rec.NoOfPluginNodes = 7
+	}
+	if senders := profile.GetConcurrentSenders(); rec.SenderAccountsPerChain < senders {
+		rec.SenderAccountsPerChain = senders
+	}
+	return rec
+}
+
+// ApplyAutoSize returns the config with the fields SizeEnvironment derives from the LoadProfile filled in,
+// if AutoSize is set. Only fields left unset are sized; NoOfPluginNodes also counts as set when DONConfig
+// is. The sized fields are reported by SizingDecisions. Applying it again returns the config as it is.
+func (o *Config) ApplyAutoSize() *Config {
+	if !pointer.GetBool(o.AutoSize) || o.sizingDecisions != nil {
+		return o
+	}
+	sized := mergeConfig(o, nil)
+	sized.sizingDecisions = sized.autoSize()
+	return sized
+}
+
+// SizingDecisions returns the fields ApplyAutoSize filled in, or would fill in if it hasn't been applied yet.
+func (o *Config) SizingDecisions() []SizingDecision {
+	if !pointer.GetBool(o.AutoSize) {
+		return nil
+	}
+	if o.sizingDecisions != nil {
+		return o.sizingDecisions
+	}
+	return mergeConfig(o, nil).autoSize()
+}
+
+// autoSize fills in the unset sized fields in place.
+func (o *Config) autoSize() []SizingDecision {
+	var profile LoadProfile
+	if o.LoadProfile != nil {
+		profile = *o.LoadProfile
+	}
+	rec := SizeEnvironment(profile)
+	decisions := []SizingDecision{}
+	if o.CLNode == nil {
+		o.CLNode = &NodeConfig{}
+	}
+	if o.CLNode.NoOfPluginNodes == nil && o.CLNode.DONConfig == nil {
+		o.CLNode.NoOfPluginNodes = pointer.ToInt(rec.NoOfPluginNodes)
+		decisions = append(decisions, SizingDecision{Field: "CLNode.NoOfPluginNodes", Value: strconv.Itoa(rec.NoOfPluginNodes)})
+	}
+	if o.Resources == nil {
+		o.Resources = &Resources{}
+	}
+	decisions = append(decisions, sizeResources("Resources.Node", &o.Resources.Node, rec.Node)...)
+	decisions = append(decisions, sizeResources("Resources.DB", &o.Resources.DB, rec.DB)...)
+	decisions = append(decisions, sizeResources("Resources.ChainClient", &o.Resources.ChainClient, rec.ChainClient)...)
+	if o.SenderConfig == nil {
+		o.SenderConfig = &SenderConfig{}
+	}
+	if o.SenderConfig.AccountsPerChain == nil {
+		o.SenderConfig.AccountsPerChain = pointer.ToInt(rec.SenderAccountsPerChain)
+		decisions = append(decisions, SizingDecision{Field: "SenderConfig.AccountsPerChain", Value: strconv.Itoa(rec.SenderAccountsPerChain)})
+	}
+	return decisions
+}
+
+func sizeResources(field string, requirements **ResourceRequirements, sizing ResourceSizing) []SizingDecision {
+	if *requirements == nil {
+		*requirements = &ResourceRequirements{}
+	}
+	r := *requirements
+	var decisions []SizingDecision
+	if r.CPU == nil {
+		r.CPU = pointer.ToFloat64(sizing.CPU)
+		decisions = append(decisions, SizingDecision{Field: field + ".CPU", Value: strconv.FormatFloat(sizing.CPU, 'f', -1, 64)})
+	}
+	if r.MemoryMB == nil {
+		r.MemoryMB = pointer.ToInt(sizing.MemoryMB)
+		decisions = append(decisions, SizingDecision{Field: field + ".MemoryMB", Value: strconv.Itoa(sizing.MemoryMB)})
+	}
+	return decisions
+}
+
+func (o *Config) validateAutoSize() error {
+	if pointer.GetBool(o.AutoSize) && o.LoadProfile.GetMessagesPerSecond() <= 0 {
+		return fmt.Errorf("AutoSize requires LoadProfile.MessagesPerSecond to size the environment for")
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSizeEnvironment(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		profile LoadProfile
+		want    SizingRecommendation
+	}{
+		{
+			name:    "light",
+			profile: LoadProfile{MessagesPerSecond: pointer.ToFloat64(10)},
+			want: SizingRecommendation{
+				NoOfPluginNodes:        4,
+				Node:                   ResourceSizing{CPU: 2, MemoryMB: 3072},
+				DB:                     ResourceSizing{CPU: 1.5, MemoryMB: 1536},
+				ChainClient:            ResourceSizing{CPU: 3, MemoryMB: 5120},
+				SenderAccountsPerChain: 2,
+			},
+		},
+		{
+			name:    "concurrent senders win over the message rate",
+			profile: LoadProfile{MessagesPerSecond: pointer.ToFloat64(100), ConcurrentSenders: pointer.ToInt(50)},
+			want: SizingRecommendation{
+				NoOfPluginNodes:        7,
+				Node:                   ResourceSizing{CPU: 2, MemoryMB: 3072},
+				DB:                     ResourceSizing{CPU: 1.5, MemoryMB: 1536},
+				ChainClient:            ResourceSizing{CPU: 3, MemoryMB: 5120},
+				SenderAccountsPerChain: 50,
+			},
+		},
+		{
+			name:    "heavy",
+			profile: LoadProfile{MessagesPerSecond: pointer.ToFloat64(500)},
+			want: SizingRecommendation{
+				NoOfPluginNodes:        10,
+				Node:                   ResourceSizing{CPU: 6, MemoryMB: 7168},
+				DB:                     ResourceSizing{CPU: 3.5, MemoryMB: 3584},
+				ChainClient:            ResourceSizing{CPU: 7, MemoryMB: 9216},
+				SenderAccountsPerChain: 100,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, SizeEnvironment(tc.profile))
+		})
+	}
+}
+
+func TestApplyAutoSize(t *testing.T) {
+	cfg := &Config{
+		AutoSize:    pointer.ToBool(true),
+		LoadProfile: &LoadProfile{MessagesPerSecond: pointer.ToFloat64(500)},
+		CLNode:      &NodeConfig{NoOfPluginNodes: pointer.ToInt(4)},
+		Resources:   &Resources{Node: &ResourceRequirements{MemoryMB: pointer.ToInt(16384)}},
+	}
+	require.NoError(t, cfg.validateAutoSize())
+	sized := cfg.ApplyAutoSize()
+
+	// fields set by the user are kept, the rest is sized
+	require.Equal(t, 4, sized.CLNode.GetNoOfPluginNodes())
+	require.Equal(t, 6.0, sized.Resources.Node.GetCPU())
+	require.Equal(t, 16384, sized.Resources.Node.GetMemoryMB())
+	require.Equal(t, 9216, sized.Resources.ChainClient.GetMemoryMB())
+	require.Equal(t, 100, sized.GetAccountsPerChain())
+	require.Nil(t, cfg.Resources.DB, "the original config must not be modified")
+
+	want := []SizingDecision{
+		{Field: "Resources.Node.CPU", Value: "6"},
+		{Field: "Resources.DB.CPU", Value: "3.5"},
+		{Field: "Resources.DB.MemoryMB", Value: "3584"},
+		{Field: "Resources.ChainClient.CPU", Value: "7"},
+		{Field: "Resources.ChainClient.MemoryMB", Value: "9216"},
+		{Field: "SenderConfig.AccountsPerChain", Value: "100"},
+	}
+	require.Equal(t, want, sized.SizingDecisions())
+	require.Equal(t, want, cfg.SizingDecisions(), "decisions are reported before AutoSize is applied too")
+	require.Same(t, sized, sized.ApplyAutoSize())
+
+	cfg.AutoSize = nil
+	require.Same(t, cfg, cfg.ApplyAutoSize())
+	require.Empty(t, cfg.SizingDecisions())
+
+	cfg = &Config{AutoSize: pointer.ToBool(true)}
+	require.EqualError(t, cfg.validateAutoSize(), "AutoSize requires LoadProfile.MessagesPerSecond to size the environment for")
+}
@@ -0,0 +1,84 @@
+package ccip
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AlekSi/pointer"
+)
+
+const DEFAULT_STANDBY_BOOTSTRAPS = 1
+
+// BootstrapFailover starts standby bootstrap nodes next to the active ones, kills the active ones at
+// KillActiveAt and promotes the standby ones at PromoteAfter, both measured from the start of the test.
+// Standby bootstraps are in the bootstrapper list of every node from the start, so the plugin nodes
+// fail over without a restart.
+type BootstrapFailover struct {
+	StandbyBootstraps *int      `toml:",omitempty"`
+	PromoteAfter      *Duration `toml:",omitempty"`
+	KillActiveAt      *Duration `toml:",omitempty"`
+}
+
+func (b *BootstrapFailover) GetStandbyBootstraps() int {
+	if b == nil {
+		return 0
+	}
+	if b.StandbyBootstraps == nil {
+		return DEFAULT_STANDBY_BOOTSTRAPS
+	}
+	return *b.StandbyBootstraps
+}
+
+func (b *BootstrapFailover) GetPromoteAfter() time.Duration {
+	if b == nil || b.PromoteAfter == nil {
+		return 0
+	}
+	return b.PromoteAfter.Duration
+}
+
+func (b *BootstrapFailover) GetKillActiveAt() time.Duration {
+	if b == nil || b.KillActiveAt == nil {
+		return 0
+	}
+	return b.KillActiveAt.Duration
+}
+
+// GetNoOfBootstrapContainers returns the number of bootstrap nodes to start, the active ones plus the
+// standby ones of BootstrapFailover.
+func (n *NodeConfig) GetNoOfBootstrapContainers() int {
+	if n == nil {
+		return 0
+	}
+	return pointer.GetInt(n.NoOfBootstraps) + n.BootstrapFailover.GetStandbyBootstraps()
+}
+
+// IsStandbyBootstrap returns whether the bootstrap node with the 0 based index is a standby one. Standby
+// bootstraps are started after the active ones.
+func (n *NodeConfig) IsStandbyBootstrap(index int) bool {
+	return n != nil && index >= pointer.GetInt(n.NoOfBootstraps) && index < n.GetNoOfBootstrapContainers()
+}
+
+func (o *Config) validateBootstrapFailover() error {
+	if o.CLNode == nil || o.CLNode.BootstrapFailover == nil {
+		return nil
+	}
+	failover := o.CLNode.BootstrapFailover
+	if pointer.GetInt(o.CLNode.NoOfBootstraps) < 1 {
+		return fmt.Errorf("CLNode.BootstrapFailover needs an active bootstrap to fail over from, CLNode.NoOfBootstraps must be at least 1")
+	}
+	if failover.GetStandbyBootstraps() < 1 {
+		return fmt.Errorf("CLNode.BootstrapFailover.StandbyBootstraps must be at least 1, got %d", failover.GetStandbyBootstraps())
+	}
+	if failover.GetKillActiveAt() <= 0 {
+		return fmt.Errorf("CLNode.BootstrapFailover.KillActiveAt must be set and be positive")
+	}
+	if failover.GetPromoteAfter() <= failover.GetKillActiveAt() {
+		return fmt.Errorf("CLNode.BootstrapFailover.PromoteAfter (%s) must be after KillActiveAt (%s)",
+			failover.GetPromoteAfter(), failover.GetKillActiveAt())
+	}
+	if failover.GetPromoteAfter() > o.Timeouts.GetOverallTestTimeout() {
+		return fmt.Errorf("CLNode.BootstrapFailover promotes after %s, which exceeds Timeouts.OverallTestTimeout (%s)",
+			failover.GetPromoteAfter(), o.Timeouts.GetOverallTestTimeout())
+	}
+	return nil
+}
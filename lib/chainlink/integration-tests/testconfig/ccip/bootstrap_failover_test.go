@@ -0,0 +1,58 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBootstrapFailover(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(`
+[CLNode]
+NoOfPluginNodes = 4
+NoOfBootstraps = 1
+MetricsPort = 6688
+
+[CLNode.BootstrapFailover]
+StandbyBootstraps = 2
+KillActiveAt = '5m'
+PromoteAfter = '7m'
+`), &cfg))
+	require.NoError(t, cfg.validateBootstrapFailover())
+	require.Equal(t, 3, cfg.CLNode.GetNoOfBootstrapContainers())
+	require.False(t, cfg.CLNode.IsStandbyBootstrap(0))
+	require.True(t, cfg.CLNode.IsStandbyBootstrap(1))
+	require.True(t, cfg.CLNode.IsStandbyBootstrap(2))
+	require.False(t, cfg.CLNode.IsStandbyBootstrap(3))
+
+	// standby bootstraps are scraped like the active ones
+	cfg.RecordContainerNames(SCRAPE_JOB_BOOTSTRAP, "cl-node-b1", "cl-node-b2", "cl-node-b3")
+	cfg.RecordContainerNames(SCRAPE_JOB_NODE, "cl-node-n1", "cl-node-n2", "cl-node-n3", "cl-node-n4")
+	targets, err := cfg.GenerateScrapeTargets()
+	require.NoError(t, err)
+	var instances []string
+	for _, target := range targets {
+		instances = append(instances, target.Instance)
+	}
+	require.Equal(t, []string{"cl-node-b1:6688", "cl-node-b2:6688", "cl-node-b3:6688", "cl-node-n1:6688", "cl-node-n2:6688", "cl-node-n3:6688", "cl-node-n4:6688"}, instances)
+
+	var unset *NodeConfig
+	require.Zero(t, unset.GetNoOfBootstrapContainers())
+
+	for _, tc := range []struct {
+		config string
+		err    string
+	}{
+		{"NoOfBootstraps = 0\n[CLNode.BootstrapFailover]\nKillActiveAt = '5m'\nPromoteAfter = '7m'\n", "CLNode.BootstrapFailover needs an active bootstrap to fail over from, CLNode.NoOfBootstraps must be at least 1"},
+		{"NoOfBootstraps = 1\n[CLNode.BootstrapFailover]\nStandbyBootstraps = 0\nKillActiveAt = '5m'\nPromoteAfter = '7m'\n", "CLNode.BootstrapFailover.StandbyBootstraps must be at least 1, got 0"},
+		{"NoOfBootstraps = 1\n[CLNode.BootstrapFailover]\nPromoteAfter = '7m'\n", "CLNode.BootstrapFailover.KillActiveAt must be set and be positive"},
+		{"NoOfBootstraps = 1\n[CLNode.BootstrapFailover]\nKillActiveAt = '5m'\nPromoteAfter = '5m'\n", "CLNode.BootstrapFailover.PromoteAfter (5m0s) must be after KillActiveAt (5m0s)"},
+		{"NoOfBootstraps = 1\n[CLNode.BootstrapFailover]\nKillActiveAt = '5m'\nPromoteAfter = '1h'\n", "CLNode.BootstrapFailover promotes after 1h0m0s, which exceeds Timeouts.OverallTestTimeout (30m0s)"},
+	} {
+		var cfg Config
+		require.NoError(t, toml.Unmarshal([]byte("[CLNode]\n"+tc.config), &cfg))
+		require.EqualError(t, cfg.validateBootstrapFailover(), tc.err, tc.config)
+	}
+}
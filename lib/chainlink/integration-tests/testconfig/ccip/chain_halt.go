@@ -0,0 +1,160 @@
+package ccip
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AlekSi/pointer"
+)
+
+// ChainHaltScenario stops block production on a private chain for a while, to verify that the lanes of
+// the chain stall and the other lanes keep committing and executing.
+type ChainHaltScenario struct {
+	// TargetChain is the network name or selector of the private network to halt
+	TargetChain *string `toml:",omitempty"`
+	// HaltAfter is measured from the start of the test
+	HaltAfter    *Duration `toml:",omitempty"`
+	HaltDuration *Duration `toml:",omitempty"`
+	// ExpectedUnaffectedLanes are the "source->dest" lanes that have to make progress during the halt
+	ExpectedUnaffectedLanes []string `toml:",omitempty"`
+}
+
+// ChainHalt is the resolved scenario, executed by Run and checked by Verify.
+type ChainHalt struct {
+	TargetSelector  uint64
+	HaltAfter       time.Duration
+	HaltDuration    time.Duration
+	UnaffectedLanes []ResolvedLane
+	schedule        *Schedule
+}
+
+// BlockProducer is the block production of the private chains, paused by pausing the chain's container
+// or by disabling the client's mining.
+type BlockProducer interface {
+	PauseBlockProduction(ctx context.Context, selector uint64) error
+	ResumeBlockProduction(ctx context.Context, selector uint64) error
+}
+
+// LaneHaltProgress is what a lane did while the chain was halted.
+type LaneHaltProgress struct {
+	Lane                ResolvedLane
+	CommittedDuringHalt int
+	ExecutedDuringHalt  int
+}
+
+// GetChainHalt resolves the chain halt scenario, returning false if none is configured.
+func (o *Config) GetChainHalt() (ChainHalt, bool, error) {
+	h := o.ChainHalt
+	if h == nil {
+		return ChainHalt{}, false, nil
+	}
+	selector, err := o.ResolveChainSelector(pointer.GetString(h.TargetChain))
+	if err != nil {
+		return ChainHalt{}, false, fieldError("ChainHalt.TargetChain", err)
+	}
+	halt := ChainHalt{TargetSelector: selector, schedule: o.Schedule}
+	if h.HaltAfter != nil {
+		halt.HaltAfter = h.HaltAfter.Duration
+	}
+	if h.HaltDuration != nil {
+		halt.HaltDuration = h.HaltDuration.Duration
+	}
+	for _, laneKey := range h.ExpectedUnaffectedLanes {
+		source, dest, err := ParseLaneKey(laneKey)
+		if err != nil {
+			return ChainHalt{}, false, fieldError("ChainHalt.ExpectedUnaffectedLanes", err)
+		}
+		lane := ResolvedLane{Source: source, Dest: dest}
+		if lane.SourceSelector, err = o.ResolveChainSelector(source); err != nil {
+			return ChainHalt{}, false, fieldError("ChainHalt.ExpectedUnaffectedLanes", err)
+		}
+		if lane.DestSelector, err = o.ResolveChainSelector(dest); err != nil {
+			return ChainHalt{}, false, fieldError("ChainHalt.ExpectedUnaffectedLanes", err)
+		}
+		halt.UnaffectedLanes = append(halt.UnaffectedLanes, lane)
+	}
+	return halt, true, nil
+}
+
+// Affects returns true if the lane sends from or to the halted chain.
+func (h ChainHalt) Affects(lane ResolvedLane) bool {
+	return lane.SourceSelector == h.TargetSelector || lane.DestSelector == h.TargetSelector
+}
+
+// Run waits until HaltAfter, measured from the call, pauses block production on the target chain and
+// resumes it HaltDuration later. A halt due in a pause window of the Schedule waits for the window to end.
+// Block production is resumed even if ctx is canceled while the chain is halted.
+func (h ChainHalt) Run(ctx context.Context, producer BlockProducer) error {
+	if err := sleepUntil(ctx, time.Now().Add(h.HaltAfter)); err != nil {
+		return err
+	}
+	if _, err := h.schedule.waitUnpaused(ctx, SCHEDULE_ACTION_CHAOS); err != nil {
+		return err
+	}
+	haltedAt := time.Now()
+	if err := producer.PauseBlockProduction(ctx, h.TargetSelector); err != nil {
+		return fmt.Errorf("halt chain %s: %w", chainName(h.TargetSelector), err)
+	}
+	waitErr := sleepUntil(ctx, haltedAt.Add(h.HaltDuration))
+	if err := producer.ResumeBlockProduction(context.WithoutCancel(ctx), h.TargetSelector); err != nil {
+		return fmt.Errorf("resume chain %s: %w", chainName(h.TargetSelector), err)
+	}
+	return waitErr
+}
+
+// Verify checks that the lanes of the halted chain neither committed nor executed anything during the
+// halt, and that every expected unaffected lane kept committing and executing.
+func (h ChainHalt) Verify(progress []LaneHaltProgress) error {
+	for _, lane := range progress {
+		if h.Affects(lane.Lane) && (lane.CommittedDuringHalt > 0 || lane.ExecutedDuringHalt > 0) {
+			return fmt.Errorf("lane %s of halted chain %s committed %d and executed %d messages during the halt",
+				lane.Lane.Key(), chainName(h.TargetSelector), lane.CommittedDuringHalt, lane.ExecutedDuringHalt)
+		}
+	}
+	for _, expected := range h.UnaffectedLanes {
+		progressed := false
+		for _, lane := range progress {
+			if lane.Lane.SourceSelector == expected.SourceSelector && lane.Lane.DestSelector == expected.DestSelector {
+				progressed = lane.CommittedDuringHalt > 0 && lane.ExecutedDuringHalt > 0
+				break
+			}
+		}
+		if !progressed {
+			return fmt.Errorf("lane %s stalled during the halt of chain %s, it's expected to be unaffected",
+				expected.Key(), chainName(h.TargetSelector))
+		}
+	}
+	return nil
+}
+
+func (o *Config) validateChainHalt() error {
+	h := o.ChainHalt
+	if h == nil {
+		return nil
+	}
+	if pointer.GetString(h.TargetChain) == "" {
+		return fmt.Errorf("ChainHalt.TargetChain must be set")
+	}
+	halt, _, err := o.GetChainHalt()
+	if err != nil {
+		return err
+	}
+	// live chains can't be halted on demand
+	if o.privateNetwork(halt.TargetSelector) == nil {
+		return fmt.Errorf("ChainHalt.TargetChain %s must be one of PrivateEthereumNetworks, live networks can't be halted", *h.TargetChain)
+	}
+	if halt.HaltDuration <= 0 {
+		return fmt.Errorf("ChainHalt.HaltDuration must be set and be positive")
+	}
+	if end := halt.HaltAfter + halt.HaltDuration; end > o.Timeouts.GetOverallTestTimeout() {
+		return fmt.Errorf("ChainHalt ends after %s, which exceeds Timeouts.OverallTestTimeout (%s)", end, o.Timeouts.GetOverallTestTimeout())
+	}
+	for i, lane := range halt.UnaffectedLanes {
+		if halt.Affects(lane) {
+			return fmt.Errorf("ChainHalt.ExpectedUnaffectedLanes contains %s, which sends from or to the halted chain %s",
+				h.ExpectedUnaffectedLanes[i], *h.TargetChain)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package ccip
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const chainHaltNetworks = `
+[PrivateEthereumNetworks.SIMULATED_1.EthereumChainConfig]
+chain_id = 1337
+
+[PrivateEthereumNetworks.SIMULATED_2.EthereumChainConfig]
+chain_id = 2337
+`
+
+const chainHaltTOML = chainHaltNetworks + `
+[ChainHalt]
+TargetChain = 'SIMULATED_1'
+HaltAfter = '5m'
+HaltDuration = '10m'
+ExpectedUnaffectedLanes = ['SIMULATED_2->ethereum-testnet-sepolia']
+`
+
+type fakeBlockProducer struct {
+	calls []string
+}
+
+func (f *fakeBlockProducer) PauseBlockProduction(_ context.Context, selector uint64) error {
+	f.calls = append(f.calls, "pause "+chainName(selector))
+	return nil
+}
+
+func (f *fakeBlockProducer) ResumeBlockProduction(_ context.Context, selector uint64) error {
+	f.calls = append(f.calls, "resume "+chainName(selector))
+	return nil
+}
+
+func TestChainHalt(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(chainHaltTOML), &cfg))
+	require.NoError(t, cfg.validateChainHalt())
+
+	halt, ok, err := cfg.GetChainHalt()
+	require.NoError(t, err)
+	require.True(t, ok)
+	halted := ResolvedLane{Source: "SIMULATED_2", Dest: "SIMULATED_1", SourceSelector: 12922642891491394802, DestSelector: 3379446385462418246}
+	unaffected := ResolvedLane{Source: "SIMULATED_2", Dest: "ethereum-testnet-sepolia", SourceSelector: 12922642891491394802, DestSelector: 16015286601757825753}
+	require.Equal(t, ChainHalt{
+		TargetSelector:  3379446385462418246,
+		HaltAfter:       5 * time.Minute,
+		HaltDuration:    10 * time.Minute,
+		UnaffectedLanes: []ResolvedLane{unaffected},
+	}, halt)
+
+	require.NoError(t, halt.Verify([]LaneHaltProgress{{Lane: halted}, {Lane: unaffected, CommittedDuringHalt: 3, ExecutedDuringHalt: 2}}))
+	require.EqualError(t, halt.Verify([]LaneHaltProgress{{Lane: halted, ExecutedDuringHalt: 1}, {Lane: unaffected, CommittedDuringHalt: 3, ExecutedDuringHalt: 2}}),
+		"lane SIMULATED_2->SIMULATED_1 of halted chain "+chainName(3379446385462418246)+" committed 0 and executed 1 messages during the halt")
+	require.ErrorContains(t, halt.Verify([]LaneHaltProgress{{Lane: halted}, {Lane: unaffected, CommittedDuringHalt: 3}}),
+		"lane SIMULATED_2->ethereum-testnet-sepolia stalled during the halt")
+
+	producer := &fakeBlockProducer{}
+	require.NoError(t, ChainHalt{TargetSelector: halt.TargetSelector, HaltDuration: time.Millisecond}.Run(context.Background(), producer))
+	require.Equal(t, []string{"pause " + chainName(halt.TargetSelector), "resume " + chainName(halt.TargetSelector)}, producer.calls)
+
+	// a halt due in a pause window waits for its end
+	producer = &fakeBlockProducer{}
+	paused := ChainHalt{TargetSelector: halt.TargetSelector, HaltDuration: time.Millisecond,
+		schedule: &Schedule{PauseWindows: []Window{{Start: "00:00", End: "00:00"}}}}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, paused.Run(ctx, producer), context.DeadlineExceeded)
+	require.Empty(t, producer.calls)
+}
+
+func TestValidateChainHalt(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		chain string
+		err   string
+	}{
+		{name: "live network", chain: "TargetChain = 'ethereum-testnet-sepolia'\nHaltDuration = '1m'", err: "ChainHalt.TargetChain ethereum-testnet-sepolia must be one of PrivateEthereumNetworks"},
+		{name: "no duration", chain: "TargetChain = 'SIMULATED_1'", err: "ChainHalt.HaltDuration must be set and be positive"},
+		{name: "past the test", chain: "TargetChain = 'SIMULATED_1'\nHaltAfter = '25m'\nHaltDuration = '10m'", err: "ChainHalt ends after 35m0s, which exceeds Timeouts.OverallTestTimeout (30m0s)"},
+		{
+			name:  "unaffected lane to the halted chain",
+			chain: "TargetChain = 'SIMULATED_1'\nHaltDuration = '1m'\nExpectedUnaffectedLanes = ['SIMULATED_2->SIMULATED_1']",
+			err:   "ChainHalt.ExpectedUnaffectedLanes contains SIMULATED_2->SIMULATED_1, which sends from or to the halted chain SIMULATED_1",
+		},
+		{name: "malformed lane", chain: "TargetChain = 'SIMULATED_1'\nExpectedUnaffectedLanes = ['SIMULATED_2']", err: "ChainHalt.ExpectedUnaffectedLanes: invalid lane key"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte(chainHaltNetworks+"\n[ChainHalt]\n"+tc.chain), &cfg))
+			require.ErrorContains(t, cfg.validateChainHalt(), tc.err)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package ccip
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/rs/zerolog/log"
+	chainselectors "github.com/smartcontractkit/chain-selectors"
+)
+
+// DEFAULT_CHAIN_ID_RANGE_* is the range chain-selectors reserves for test chains, so allocated chains
+// resolve to a selector and stay clear of the 1337-ish IDs local tooling defaults to.
+const (
+	DEFAULT_CHAIN_ID_RANGE_START int64 = 90000001
+	DEFAULT_CHAIN_ID_RANGE_END   int64 = 90000050
+)
+
+// ChainIDRange bounds the chain IDs allocated to private networks declared without one, inclusive.
+type ChainIDRange struct {
+	Start *int64 `toml:",omitempty"`
+	End   *int64 `toml:",omitempty"`
+}
+
+func (r *ChainIDRange) GetStart() int64 {
+	if r == nil || r.Start == nil {
+		return DEFAULT_CHAIN_ID_RANGE_START
+	}
+	return *r.Start
+}
+
+func (r *ChainIDRange) GetEnd() int64 {
+	if r == nil || r.End == nil {
+		return DEFAULT_CHAIN_ID_RANGE_END
+	}
+	return *r.End
+}
+
+// isLiveChainID returns true if chain-selectors registers the chain ID for a live chain. Its test chains
+// don't count, they are meant to be used by private networks.
+func isLiveChainID(chainID int64) bool {
+	if _, ok := chainselectors.EvmChainIdToChainSelector()[uint64(chainID)]; !ok {
+		return false
+	}
+	for _, testChainID := range chainselectors.TestChainIds() {
+		if testChainID == uint64(chainID) {
+			return false
+		}
+	}
+	return true
+}
+
+// AllocateChainIDs returns n chain IDs from start up to ChainIDRange.End, skipping IDs of live chains
+// registered in chain-selectors and IDs of the configured private networks.
+func (o *Config) AllocateChainIDs(n int, start int64) ([]int64, error) {
+	used := make(map[int64]bool)
+	for _, network := range o.PrivateEthereumNetworks {
+		if network != nil && network.EthereumChainConfig != nil && network.EthereumChainConfig.ChainID > 0 {
+			used[int64(network.EthereumChainConfig.ChainID)] = true
+		}
+	}
+	return allocateChainIDs(n, start, o.ChainIDRange.GetEnd(), func(chainID int64) bool {
+		return used[chainID] || isLiveChainID(chainID)
+	})
+}
+
+func allocateChainIDs(n int, start, end int64, skip func(chainID int64) bool) ([]int64, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("cannot allocate %d chain ids", n)
+	}
+	if start <= 0 {
+		return nil, fmt.Errorf("chain id range must start above 0, got %d", start)
+	}
+	chainIDs := make([]int64, 0, n)
+	for chainID := start; len(chainIDs) < n && chainID <= end; chainID++ {
+		if !skip(chainID) {
+			chainIDs = append(chainIDs, chainID)
+		}
+	}
+	if len(chainIDs) < n {
+		return nil, fmt.Errorf("only %d of %d chain ids are free between %d and %d", len(chainIDs), n, start, end)
+	}
+	return chainIDs, nil
+}
+
+// ApplyChainIDAllocation returns the config with a chain ID allocated to every private network declared
+// without one, in network name order. The IDs are written into the returned config, so a config saved
+// from it re-runs with the same chain IDs. Applying it again returns the config as it is.
+func (o *Config) ApplyChainIDAllocation() (*Config, error) {
+	var names []string
+	for name, network := range o.PrivateEthereumNetworks {
+		if network != nil && network.EthereumChainConfig != nil && network.EthereumChainConfig.ChainID == 0 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return o, nil
+	}
+	sort.Strings(names)
+	chainIDs, err := o.AllocateChainIDs(len(names), o.ChainIDRange.GetStart())
+	if err != nil {
+		return nil, fieldError("PrivateEthereumNetworks", err)
+	}
+	allocated := mergeConfig(o, nil)
+	for i, name := range names {
+		allocated.PrivateEthereumNetworks[name].EthereumChainConfig.ChainID = int(chainIDs[i])
+		log.Info().Str("Network", name).Int64("ChainID", chainIDs[i]).Msg("Allocated chain id to private network")
+	}
+	return allocated, nil
+}
+
+func (o *Config) validateChainIDRange() error {
+	if o.ChainIDRange == nil {
+		return nil
+	}
+	if start, end := o.ChainIDRange.GetStart(), o.ChainIDRange.GetEnd(); start <= 0 || end < start {
+		return fmt.Errorf("ChainIDRange must be a non empty range of positive chain ids, got %d to %d", start, end)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocateChainIDsSkips(t *testing.T) {
+	registered := map[int64]bool{101: true, 103: true, 104: true}
+	chainIDs, err := allocateChainIDs(3, 100, 110, func(chainID int64) bool { return registered[chainID] })
+	require.NoError(t, err)
+	require.Equal(t, []int64{100, 102, 105}, chainIDs)
+
+	_, err = allocateChainIDs(3, 100, 103, func(chainID int64) bool { return registered[chainID] })
+	require.ErrorContains(t, err, "only 2 of 3 chain ids are free between 100 and 103")
+}
+
+func TestAllocateChainIDs(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(`
+[PrivateEthereumNetworks.SIMULATED_1.EthereumChainConfig]
+chain_id = 90000002
+`), &cfg))
+	chainIDs, err := cfg.AllocateChainIDs(2, 90000001)
+	require.NoError(t, err)
+	require.Equal(t, []int64{90000001, 90000003}, chainIDs)
+
+	// 11155111 is sepolia
+	chainIDs, err = (&Config{ChainIDRange: &ChainIDRange{End: pointer.ToInt64(11155113)}}).AllocateChainIDs(2, 11155110)
+	require.NoError(t, err)
+	require.Equal(t, []int64{11155110, 11155112}, chainIDs)
+}
+
+func TestApplyChainIDAllocation(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(`
+[PrivateEthereumNetworks.SIMULATED_B.EthereumChainConfig]
+seconds_per_slot = 3
+
+[PrivateEthereumNetworks.SIMULATED_A.EthereumChainConfig]
+seconds_per_slot = 3
+
+[PrivateEthereumNetworks.SIMULATED_C.EthereumChainConfig]
+chain_id = 90000001
+`), &cfg))
+	allocated, err := cfg.ApplyChainIDAllocation()
+	require.NoError(t, err)
+	require.Equal(t, 90000002, allocated.PrivateEthereumNetworks["SIMULATED_A"].EthereumChainConfig.ChainID)
+	require.Equal(t, 90000003, allocated.PrivateEthereumNetworks["SIMULATED_B"].EthereumChainConfig.ChainID)
+	require.Zero(t, cfg.PrivateEthereumNetworks["SIMULATED_A"].EthereumChainConfig.ChainID)
+
+	_, err = allocated.ResolveChainSelector("SIMULATED_A")
+	require.NoError(t, err)
+	again, err := allocated.ApplyChainIDAllocation()
+	require.NoError(t, err)
+	require.Same(t, allocated, again)
+
+	cfg.ChainIDRange = &ChainIDRange{End: pointer.ToInt64(90000002)}
+	_, err = cfg.ApplyChainIDAllocation()
+	require.ErrorContains(t, err, "PrivateEthereumNetworks: only 1 of 2 chain ids are free")
+}
@@ -0,0 +1,198 @@
+package ccip
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/AlekSi/pointer"
+
+	ctfconfig "github.com/smartcontractkit/chainlink-testing-framework/lib/config"
+)
+
+const (
+	CHAIN_SEMANTICS_STANDARD   = "standard"
+	CHAIN_SEMANTICS_OPTIMISTIC = "optimistic"
+	CHAIN_SEMANTICS_ZK         = "zk"
+
+	// ROLLUP_POLLS_PER_FINALITY_LAG is how often a wait on a rollup polls at most while the hard
+	// finality lag passes, by default
+	ROLLUP_POLLS_PER_FINALITY_LAG = 20
+	ROLLUP_POLL_BACKOFF           = 1.5
+)
+
+var chainSemanticsTypes = []string{CHAIN_SEMANTICS_STANDARD, CHAIN_SEMANTICS_OPTIMISTIC, CHAIN_SEMANTICS_ZK}
+
+// ChainSemantics describes when blocks of a chain become final, for rollups where that happens long
+// after the block was produced.
+type ChainSemantics struct {
+	Type *string `toml:",omitempty"`
+	// SoftConfirmations is the number of blocks a zk rollup needs before a transaction counts as
+	// executed, ahead of its proof
+	SoftConfirmations *uint64 `toml:",omitempty"`
+	// HardFinalityLag is how long it takes a rollup block to become final on L1
+	HardFinalityLag *Duration `toml:",omitempty"`
+}
+
+// GetType returns Type, CHAIN_SEMANTICS_STANDARD unless set.
+func (c *ChainSemantics) GetType() string {
+	if c == nil || pointer.GetString(c.Type) == "" {
+		return CHAIN_SEMANTICS_STANDARD
+	}
+	return *c.Type
+}
+
+func (c *ChainSemantics) GetHardFinalityLag() time.Duration {
+	if c == nil || c.HardFinalityLag == nil {
+		return 0
+	}
+	return c.HardFinalityLag.Duration
+}
+
+// LaneWaitBudget is how long the assertions of a lane wait for a message to be committed and executed.
+type LaneWaitBudget struct {
+	Commit time.Duration
+	Exec   time.Duration
+}
+
+// GetChainSemantics returns the semantics of the chain, nil for standard chains.
+func (o *Config) GetChainSemantics(selector uint64) *ChainSemantics {
+	for ref, semantics := range o.ChainSemantics {
+		if resolved, err := o.ResolveChainSelector(ref); err == nil && resolved == selector {
+			return semantics
+		}
+	}
+	return nil
+}
+
+// privateNetwork returns the private network of the chain, nil for live networks.
+func (o *Config) privateNetwork(selector uint64) *ctfconfig.EthereumNetworkConfig {
+	for name, network := range o.PrivateEthereumNetworks {
+		if resolved, err := o.ResolveChainSelector(name); err == nil && resolved == selector {
+			return network
+		}
+	}
+	return nil
+}
+
+// EstimateChainFinalityTime estimates how long a block of the chain takes to become final. For rollups
+// that's the hard finality lag on top of the L2 finality, for zk rollups on top of the soft
+// confirmations. Block times are only known for private networks.
+func (o *Config) EstimateChainFinalityTime(selector uint64) time.Duration {
+	network := o.privateNetwork(selector)
+	semantics := o.GetChainSemantics(selector)
+	switch semantics.GetType() {
+	case CHAIN_SEMANTICS_OPTIMISTIC:
+		return EstimateFinalityTime(network) + semantics.GetHardFinalityLag()
+	case CHAIN_SEMANTICS_ZK:
+		return softConfirmationTime(network, semantics) + semantics.GetHardFinalityLag()
+	default:
+		return EstimateFinalityTime(network)
+	}
+}
+
+func softConfirmationTime(network *ctfconfig.EthereumNetworkConfig, semantics *ChainSemantics) time.Duration {
+	if network == nil || network.EthereumChainConfig == nil || semantics == nil {
+		return 0
+	}
+	slot := time.Duration(network.EthereumChainConfig.SecondsPerSlot) * time.Second
+	return time.Duration(pointer.GetUint64(semantics.SoftConfirmations)) * slot
+}
+
+// GetLaneWaitBudget returns how long to wait for messages from source to dest. Commit waits the hard
+// finality lag of a rollup source on top of the commit timeout, and exec the soft confirmations of a
+// zk dest on top of the exec timeout. With an adaptive LatencyModel the waits are estimated for the lane
+// instead, lanes it can't estimate wait the timeouts.
+func (o *Config) GetLaneWaitBudget(source, dest uint64) LaneWaitBudget {
+	if o.LatencyModel.IsAdaptive() {
+		if budget, ok := o.adaptiveLaneWaitBudget(source, dest); ok {
+			return budget
+		}
+	}
+	budget := LaneWaitBudget{
+		Commit: o.Timeouts.GetCommitTimeout() + o.GetChainSemantics(source).GetHardFinalityLag(),
+		Exec:   o.Timeouts.GetExecTimeout(),
+	}
+	if semantics := o.GetChainSemantics(dest); semantics.GetType() == CHAIN_SEMANTICS_ZK {
+		budget.Exec += softConfirmationTime(o.privateNetwork(dest), semantics)
+	}
+	return budget
+}
+
+// rollupPolling slows down the default polling of rollups, which wait for their hard finality lag.
+func rollupPolling(polling ResolvedPolling, semantics *ChainSemantics) ResolvedPolling {
+	lag := semantics.GetHardFinalityLag()
+	if lag == 0 {
+		return polling
+	}
+	if interval := lag / ROLLUP_POLLS_PER_FINALITY_LAG; interval > polling.MaxInterval {
+		polling.MaxInterval = interval
+	}
+	polling.Backoff = ROLLUP_POLL_BACKOFF
+	return polling
+}
+
+func (o *Config) validateChainSemantics() error {
+	refs := make([]string, 0, len(o.ChainSemantics))
+	for ref := range o.ChainSemantics {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	var selectors []uint64
+	for _, ref := range refs {
+		selector, err := o.ResolveChainSelector(ref)
+		if err != nil {
+			return fieldError("ChainSemantics."+ref, err)
+		}
+		selectors = append(selectors, selector)
+		semantics := o.ChainSemantics[ref]
+		if semantics == nil {
+			continue
+		}
+		switch semantics.GetType() {
+		case CHAIN_SEMANTICS_STANDARD:
+			if semantics.SoftConfirmations != nil || semantics.HardFinalityLag != nil {
+				return fmt.Errorf("ChainSemantics.%s: SoftConfirmations and HardFinalityLag are only used by %s and %s chains",
+					ref, CHAIN_SEMANTICS_OPTIMISTIC, CHAIN_SEMANTICS_ZK)
+			}
+		case CHAIN_SEMANTICS_OPTIMISTIC:
+			if semantics.SoftConfirmations != nil {
+				return fmt.Errorf("ChainSemantics.%s: SoftConfirmations is only used by %s chains", ref, CHAIN_SEMANTICS_ZK)
+			}
+		case CHAIN_SEMANTICS_ZK:
+			if pointer.GetUint64(semantics.SoftConfirmations) == 0 {
+				return fmt.Errorf("ChainSemantics.%s: SoftConfirmations must be set and be positive for %s chains", ref, CHAIN_SEMANTICS_ZK)
+			}
+		default:
+			return fmt.Errorf("ChainSemantics.%s: Type must be one of %v, got %q", ref, chainSemanticsTypes, semantics.GetType())
+		}
+		if semantics.GetType() != CHAIN_SEMANTICS_STANDARD && semantics.GetHardFinalityLag() <= 0 {
+			return fmt.Errorf("ChainSemantics.%s: HardFinalityLag must be set and be positive for %s chains", ref, semantics.GetType())
+		}
+	}
+	// the slowest lane has to fit into the test, from or to every chain with semantics
+	var slowestCommit, slowestExec time.Duration
+	for i, selector := range selectors {
+		for name := range o.PrivateEthereumNetworks {
+			other, err := o.ResolveChainSelector(name)
+			if err != nil || other == selector {
+				continue
+			}
+			minCommit := o.EstimateChainFinalityTime(selector) + DEFAULT_COMMIT_INTERVAL
+			if commit := o.GetLaneWaitBudget(selector, other).Commit; commit < minCommit {
+				return fmt.Errorf("Timeouts.CommitTimeout leaves %s to commit messages from %s, too short to reach finality, minimum is %s",
+					commit, refs[i], minCommit)
+			}
+			for _, budget := range []LaneWaitBudget{o.GetLaneWaitBudget(selector, other), o.GetLaneWaitBudget(other, selector)} {
+				slowestCommit = max(slowestCommit, budget.Commit)
+				slowestExec = max(slowestExec, budget.Exec)
+			}
+		}
+	}
+	needed := o.Timeouts.GetSetupTimeout() + slowestCommit + o.Timeouts.GetBlessTimeout() + slowestExec
+	if slowestCommit > 0 && o.Timeouts.GetOverallTestTimeout() < needed {
+		return fmt.Errorf("Timeouts.OverallTestTimeout (%s) is shorter than the slowest lane needs with its ChainSemantics (%s)",
+			o.Timeouts.GetOverallTestTimeout(), needed)
+	}
+	return nil
+}
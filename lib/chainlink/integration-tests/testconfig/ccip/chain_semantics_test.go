@@ -0,0 +1,64 @@
+package ccip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const chainSemanticsNetworks = `
+[PrivateEthereumNetworks.SIMULATED_1.EthereumChainConfig]
+chain_id = 1337
+seconds_per_slot = 2
+
+[PrivateEthereumNetworks.SIMULATED_2.EthereumChainConfig]
+chain_id = 2337
+seconds_per_slot = 3
+
+[Timeouts]
+OverallTestTimeout = '2h'
+`
+
+func TestChainSemantics(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(chainSemanticsNetworks+`
+[ChainSemantics.SIMULATED_1]
+Type = 'optimistic'
+HardFinalityLag = '20m'
+
+[ChainSemantics.SIMULATED_2]
+Type = 'zk'
+SoftConfirmations = 10
+HardFinalityLag = '40m'
+`), &cfg))
+	require.NoError(t, cfg.validateChainSemantics())
+
+	optimistic, zk := uint64(3379446385462418246), uint64(12922642891491394802)
+	require.Equal(t, 2*time.Second+20*time.Minute, cfg.EstimateChainFinalityTime(optimistic))
+	require.Equal(t, 30*time.Second+40*time.Minute, cfg.EstimateChainFinalityTime(zk))
+	require.Equal(t, LaneWaitBudget{Commit: 25 * time.Minute, Exec: 5*time.Minute + 30*time.Second}, cfg.GetLaneWaitBudget(optimistic, zk))
+	require.Equal(t, LaneWaitBudget{Commit: 45 * time.Minute, Exec: 5 * time.Minute}, cfg.GetLaneWaitBudget(zk, optimistic))
+
+	// rollups poll less often while their hard finality lag passes
+	require.Equal(t, ResolvedPolling{Interval: DEFAULT_POLL_INTERVAL, MaxInterval: 2 * time.Minute, Backoff: ROLLUP_POLL_BACKOFF}, cfg.GetPolling(zk))
+	require.Equal(t, defaultPolling, (&Config{}).GetPolling(zk))
+
+	for _, tc := range []struct {
+		semantics string
+		err       string
+	}{
+		{"Type = 'standard'\nHardFinalityLag = '1m'\n", "ChainSemantics.SIMULATED_1: SoftConfirmations and HardFinalityLag are only used by optimistic and zk chains"},
+		{"Type = 'optimistic'\nSoftConfirmations = 3\nHardFinalityLag = '1m'\n", "ChainSemantics.SIMULATED_1: SoftConfirmations is only used by zk chains"},
+		{"Type = 'optimistic'\n", "ChainSemantics.SIMULATED_1: HardFinalityLag must be set and be positive for optimistic chains"},
+		{"Type = 'zk'\nHardFinalityLag = '1m'\n", "ChainSemantics.SIMULATED_1: SoftConfirmations must be set and be positive for zk chains"},
+		{"Type = 'validium'\n", `ChainSemantics.SIMULATED_1: Type must be one of [standard optimistic zk], got "validium"`},
+		{"Type = 'zk'\nSoftConfirmations = 300\nHardFinalityLag = '1m'\n", "Timeouts.CommitTimeout leaves 6m0s to commit messages from SIMULATED_1, too short to reach finality, minimum is 11m2s"},
+		{"Type = 'optimistic'\nHardFinalityLag = '2h'\n", "Timeouts.OverallTestTimeout (2h0m0s) is shorter than the slowest lane needs with its ChainSemantics (2h25m0s)"},
+	} {
+		var cfg Config
+		require.NoError(t, toml.Unmarshal([]byte(chainSemanticsNetworks+"\n[ChainSemantics.SIMULATED_1]\n"+tc.semantics), &cfg))
+		require.EqualError(t, cfg.validateChainSemantics(), tc.err, tc.semantics)
+	}
+}
@@ -0,0 +1,240 @@
+package ccip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/AlekSi/pointer"
+
+	ctfconfig "github.com/smartcontractkit/chainlink-testing-framework/lib/config"
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/config/types"
+)
+
+const (
+	// SNAPSHOT_METHOD_VOLUME_COPY stops the node and copies its data volume
+	SNAPSHOT_METHOD_VOLUME_COPY = "volumeCopy"
+
+	DEFAULT_SNAPSHOT_AFTER_PHASE = PHASE_CONTRACTS
+	DEFAULT_SNAPSHOT_DIR         = "chain-snapshots"
+
+	// CHAIN_SNAPSHOT_MANIFEST is written next to the chain snapshots and records the config they were
+	// taken with
+	CHAIN_SNAPSHOT_MANIFEST = "snapshot.json"
+	// CHAIN_SNAPSHOT_VERSION is bumped whenever ChainSnapshotManifest changes incompatibly.
+	CHAIN_SNAPSHOT_VERSION = 1
+)
+
+// snapshotMethods is how the state of each execution layer client is captured, none of them implement
+// evm_snapshot. Eth2 networks are never supported, the consensus layer state would not match the
+// restored execution layer state.
+var snapshotMethods = map[types.ExecutionLayer]string{
+	types.ExecutionLayer_Geth:       SNAPSHOT_METHOD_VOLUME_COPY,
+	types.ExecutionLayer_Besu:       SNAPSHOT_METHOD_VOLUME_COPY,
+	types.ExecutionLayer_Nethermind: SNAPSHOT_METHOD_VOLUME_COPY,
+	types.ExecutionLayer_Erigon:     SNAPSHOT_METHOD_VOLUME_COPY,
+	types.ExecutionLayer_Reth:       SNAPSHOT_METHOD_VOLUME_COPY,
+}
+
+// ChainSnapshots captures the state of the private chains after a test phase, so later runs can restore
+// it instead of deploying the contracts again.
+type ChainSnapshots struct {
+	Enabled            *bool   `toml:",omitempty"`
+	SnapshotAfterPhase *string `toml:",omitempty"`
+	SnapshotDir        *string `toml:",omitempty"`
+	// RestoreOnStart restores the snapshots in SnapshotDir before the first phase instead of taking them
+	RestoreOnStart *bool `toml:",omitempty"`
+}
+
+func (c *ChainSnapshots) IsEnabled() bool {
+	return c != nil && pointer.GetBool(c.Enabled)
+}
+
+func (c *ChainSnapshots) GetSnapshotAfterPhase() string {
+	if c == nil || pointer.GetString(c.SnapshotAfterPhase) == "" {
+		return DEFAULT_SNAPSHOT_AFTER_PHASE
+	}
+	return *c.SnapshotAfterPhase
+}
+
+func (c *ChainSnapshots) GetSnapshotDir() string {
+	if c == nil || pointer.GetString(c.SnapshotDir) == "" {
+		return DEFAULT_SNAPSHOT_DIR
+	}
+	return *c.SnapshotDir
+}
+
+// ChainSnapshotter captures and restores the state of a chain of the environment.
+type ChainSnapshotter interface {
+	SnapshotChain(ctx context.Context, selector uint64, method, dir string) error
+	RestoreChain(ctx context.Context, selector uint64, method, dir string) error
+}
+
+// ChainSnapshotManifest records which chains were snapshotted how, and the config they belong to.
+type ChainSnapshotManifest struct {
+	Version     int    `json:"version"`
+	Fingerprint string `json:"fingerprint"`
+	// ConfigTOML is the fingerprinted config, kept to explain fingerprint mismatches
+	ConfigTOML string          `json:"configToml"`
+	Chains     []ChainSnapshot `json:"chains"`
+}
+
+type ChainSnapshot struct {
+	Selector uint64 `json:"selector"`
+	Network  string `json:"network"`
+	Method   string `json:"method"`
+}
+
+// snapshotMethod returns how the state of the private network can be captured, "" if it can't.
+func snapshotMethod(network *ctfconfig.EthereumNetworkConfig) string {
+	if network == nil {
+		return ""
+	}
+	if network.EthereumVersion != nil && *network.EthereumVersion == types.EthereumVersion_Eth2 {
+		return ""
+	}
+	client := types.ExecutionLayer_Geth
+	if network.ExecutionLayer != nil {
+		client = *network.ExecutionLayer
+	}
+	return snapshotMethods[client]
+}
+
+// GetChainSnapshots returns the private networks to snapshot sorted by name, with their method.
+func (o *Config) GetChainSnapshots() ([]ChainSnapshot, error) {
+	names := make([]string, 0, len(o.PrivateEthereumNetworks))
+	for name := range o.PrivateEthereumNetworks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	snapshots := make([]ChainSnapshot, 0, len(names))
+	for _, name := range names {
+		selector, err := o.ResolveChainSelector(name)
+		if err != nil {
+			return nil, fieldError("PrivateEthereumNetworks."+name, err)
+		}
+		snapshots = append(snapshots, ChainSnapshot{
+			Selector: selector,
+			Network:  name,
+			Method:   snapshotMethod(o.PrivateEthereumNetworks[name]),
+		})
+	}
+	return snapshots, nil
+}
+
+// SnapshotChains snapshots every private chain into its own directory below SnapshotDir and records
+// them with the config fingerprint in CHAIN_SNAPSHOT_MANIFEST.
+func (o *Config) SnapshotChains(ctx context.Context, snapshotter ChainSnapshotter) (*ChainSnapshotManifest, error) {
+	chains, err := o.GetChainSnapshots()
+	if err != nil {
+		return nil, err
+	}
+	dir := o.ChainSnapshots.GetSnapshotDir()
+	for _, chain := range chains {
+		if chain.Method == "" {
+			return nil, fmt.Errorf("chain %s does not support snapshots", chain.Network)
+		}
+		chainDir := filepath.Join(dir, strconv.FormatUint(chain.Selector, 10))
+		if err := os.MkdirAll(chainDir, 0o755); err != nil {
+			return nil, err
+		}
+		if err := snapshotter.SnapshotChain(ctx, chain.Selector, chain.Method, chainDir); err != nil {
+			return nil, fmt.Errorf("snapshot chain %s: %w", chain.Network, err)
+		}
+	}
+	content, err := o.fingerprintTOML()
+	if err != nil {
+		return nil, err
+	}
+	manifest := &ChainSnapshotManifest{
+		Version:    CHAIN_SNAPSHOT_VERSION,
+		ConfigTOML: string(content),
+		Chains:     chains,
+	}
+	if manifest.Fingerprint, err = o.Fingerprint(); err != nil {
+		return nil, err
+	}
+	encoded, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return manifest, os.WriteFile(filepath.Join(dir, CHAIN_SNAPSHOT_MANIFEST), encoded, 0o644)
+}
+
+// RestoreChains restores the chain snapshots in SnapshotDir, failing with a diff of the two configs if
+// they were taken with a config that deploys a different environment.
+func (o *Config) RestoreChains(ctx context.Context, snapshotter ChainSnapshotter) (*ChainSnapshotManifest, error) {
+	dir := o.ChainSnapshots.GetSnapshotDir()
+	path := filepath.Join(dir, CHAIN_SNAPSHOT_MANIFEST)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &ChainSnapshotManifest{}
+	if err := json.Unmarshal(content, manifest); err != nil {
+		return nil, fmt.Errorf("invalid chain snapshot manifest %s: %w", path, err)
+	}
+	if manifest.Version != CHAIN_SNAPSHOT_VERSION {
+		return nil, fmt.Errorf("chain snapshot manifest %s has version %d, expected %d", path, manifest.Version, CHAIN_SNAPSHOT_VERSION)
+	}
+	fingerprint, err := o.Fingerprint()
+	if err != nil {
+		return nil, err
+	}
+	if fingerprint != manifest.Fingerprint {
+		current, err := o.fingerprintTOML()
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("chain snapshots in %s were taken with a different config:\n%s", dir, diffLines(manifest.ConfigTOML, string(current)))
+	}
+	for _, chain := range manifest.Chains {
+		chainDir := filepath.Join(dir, strconv.FormatUint(chain.Selector, 10))
+		if err := snapshotter.RestoreChain(ctx, chain.Selector, chain.Method, chainDir); err != nil {
+			return nil, fmt.Errorf("restore chain %s: %w", chain.Network, err)
+		}
+	}
+	return manifest, nil
+}
+
+func (o *Config) validateChainSnapshots() error {
+	if !o.ChainSnapshots.IsEnabled() {
+		return nil
+	}
+	if phase := o.ChainSnapshots.GetSnapshotAfterPhase(); !containsString(AllPhases, phase) {
+		return fmt.Errorf("ChainSnapshots.SnapshotAfterPhase must be one of %v, got %q", AllPhases, phase)
+	}
+	// live chains are part of the test if the home or feed chain isn't a private network
+	if len(o.PrivateEthereumNetworks) == 0 {
+		return fmt.Errorf("ChainSnapshots can't snapshot live networks, the test has no PrivateEthereumNetworks")
+	}
+	for _, parse := range []func() (uint64, error){o.ParseHomeChainSelector, o.ParseFeedChainSelector} {
+		if selector, err := parse(); err == nil && o.privateNetwork(selector) == nil {
+			return fmt.Errorf("ChainSnapshots can't snapshot live networks, chain %s is not one of PrivateEthereumNetworks", chainName(selector))
+		}
+	}
+	chains, err := o.GetChainSnapshots()
+	if err != nil {
+		return err
+	}
+	for _, chain := range chains {
+		if chain.Method == "" {
+			return fmt.Errorf("ChainSnapshots: the client of PrivateEthereumNetworks.%s does not support snapshots, only eth1 networks run by %v do",
+				chain.Network, snapshotClients())
+		}
+	}
+	return nil
+}
+
+func snapshotClients() []string {
+	clients := make([]string, 0, len(snapshotMethods))
+	for client := range snapshotMethods {
+		clients = append(clients, string(client))
+	}
+	sort.Strings(clients)
+	return clients
+}
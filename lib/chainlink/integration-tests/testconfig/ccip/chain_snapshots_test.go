@@ -0,0 +1,109 @@
+package ccip
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const chainSnapshotsConfig = `
+HomeChainSelector = '3379446385462418246'
+
+[CLNode]
+NoOfPluginNodes = 4
+
+[ChainSnapshots]
+Enabled = true
+
+[PrivateEthereumNetworks.SIMULATED_1]
+ethereum_version = 'eth1'
+execution_layer = 'geth'
+
+[PrivateEthereumNetworks.SIMULATED_1.EthereumChainConfig]
+chain_id = 1337
+
+[PrivateEthereumNetworks.SIMULATED_2]
+ethereum_version = 'eth1'
+execution_layer = 'besu'
+
+[PrivateEthereumNetworks.SIMULATED_2.EthereumChainConfig]
+chain_id = 2337
+`
+
+type fakeChainSnapshotter struct {
+	calls []string
+}
+
+func (f *fakeChainSnapshotter) SnapshotChain(_ context.Context, selector uint64, method, dir string) error {
+	f.calls = append(f.calls, fmt.Sprintf("snapshot %d %s %s", selector, method, filepath.Base(dir)))
+	return nil
+}
+
+func (f *fakeChainSnapshotter) RestoreChain(_ context.Context, selector uint64, method, dir string) error {
+	f.calls = append(f.calls, fmt.Sprintf("restore %d %s %s", selector, method, filepath.Base(dir)))
+	return nil
+}
+
+func TestChainSnapshots(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(chainSnapshotsConfig), &cfg))
+	cfg.ChainSnapshots.SnapshotDir = pointer.ToString(t.TempDir())
+	require.NoError(t, cfg.validateChainSnapshots())
+	require.Equal(t, PHASE_CONTRACTS, cfg.ChainSnapshots.GetSnapshotAfterPhase())
+
+	snapshotter := &fakeChainSnapshotter{}
+	manifest, err := cfg.SnapshotChains(context.Background(), snapshotter)
+	require.NoError(t, err)
+	require.Equal(t, []ChainSnapshot{
+		{Selector: 3379446385462418246, Network: "SIMULATED_1", Method: SNAPSHOT_METHOD_VOLUME_COPY},
+		{Selector: 12922642891491394802, Network: "SIMULATED_2", Method: SNAPSHOT_METHOD_VOLUME_COPY},
+	}, manifest.Chains)
+
+	// restoring doesn't change the fingerprint, only the environment does
+	cfg.ChainSnapshots.RestoreOnStart = pointer.ToBool(true)
+	_, err = cfg.RestoreChains(context.Background(), snapshotter)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"snapshot 3379446385462418246 volumeCopy 3379446385462418246",
+		"snapshot 12922642891491394802 volumeCopy 12922642891491394802",
+		"restore 3379446385462418246 volumeCopy 3379446385462418246",
+		"restore 12922642891491394802 volumeCopy 12922642891491394802",
+	}, snapshotter.calls)
+
+	cfg.CLNode.NoOfPluginNodes = pointer.ToInt(7)
+	_, err = cfg.RestoreChains(context.Background(), snapshotter)
+	require.ErrorContains(t, err, "were taken with a different config")
+	require.ErrorContains(t, err, "- NoOfPluginNodes = 4")
+
+	for _, tc := range []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{"unknown phase", func(c *Config) { c.ChainSnapshots.SnapshotAfterPhase = pointer.ToString("teardown") },
+			`ChainSnapshots.SnapshotAfterPhase must be one of [infra contracts jobs traffic assert], got "teardown"`},
+		{"no private networks", func(c *Config) { c.PrivateEthereumNetworks = nil },
+			"ChainSnapshots can't snapshot live networks, the test has no PrivateEthereumNetworks"},
+		{"live home chain", func(c *Config) { c.HomeChainSelector = pointer.ToString("16015286601757825753") },
+			"ChainSnapshots can't snapshot live networks, chain ethereum-testnet-sepolia is not one of PrivateEthereumNetworks"},
+		{"eth2", func(c *Config) { *c.PrivateEthereumNetworks["SIMULATED_2"].EthereumVersion = "eth2" },
+			"ChainSnapshots: the client of PrivateEthereumNetworks.SIMULATED_2 does not support snapshots, only eth1 networks run by [besu erigon geth nethermind reth] do"},
+		{"disabled", func(c *Config) { c.ChainSnapshots.Enabled = nil; c.PrivateEthereumNetworks = nil }, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte(chainSnapshotsConfig), &cfg))
+			tc.modify(&cfg)
+			if tc.err == "" {
+				require.NoError(t, cfg.validateChainSnapshots())
+				return
+			}
+			require.EqualError(t, cfg.validateChainSnapshots(), tc.err)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package ccip
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	chainselectors "github.com/smartcontractkit/chain-selectors"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/blockchain"
+)
+
+// TOKEN_DEPLOY_MOCK is returned instead of a token address when a mock token has to be deployed.
+const TOKEN_DEPLOY_MOCK = "deployMock"
+
+// ChainTokens are the LINK and wrapped native token already deployed on a live chain, which are used
+// instead of deploying mocks.
+type ChainTokens struct {
+	LINK          *string `toml:",omitempty"`
+	WrappedNative *string `toml:",omitempty"`
+}
+
+// ChainTokenAddresses are the fee tokens of a chain, each either an address or TOKEN_DEPLOY_MOCK.
+type ChainTokenAddresses struct {
+	LINK          string
+	WrappedNative string
+}
+
+// GetChainTokens returns the LINK and wrapped native addresses of the chain, with TOKEN_DEPLOY_MOCK for those not configured.
+func (o *Config) GetChainTokens(selector uint64) ChainTokenAddresses {
+	addresses := ChainTokenAddresses{LINK: TOKEN_DEPLOY_MOCK, WrappedNative: TOKEN_DEPLOY_MOCK}
+	for ref, tokens := range o.ChainTokens {
+		if tokens == nil {
+			continue
+		}
+		if resolved, err := o.ResolveChainSelector(ref); err != nil || resolved != selector {
+			continue
+		}
+		if link := pointer.GetString(tokens.LINK); link != "" {
+			addresses.LINK = link
+		}
+		if wrappedNative := pointer.GetString(tokens.WrappedNative); wrappedNative != "" {
+			addresses.WrappedNative = wrappedNative
+		}
+	}
+	return addresses
+}
+
+func (o *Config) validateChainTokens() error {
+	refs := make([]string, 0, len(o.ChainTokens))
+	for ref := range o.ChainTokens {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	private := make(map[uint64]string)
+	for name := range o.PrivateEthereumNetworks {
+		if selector, err := o.ResolveChainSelector(name); err == nil {
+			private[selector] = name
+		}
+	}
+	for _, ref := range refs {
+		selector, err := o.ResolveChainSelector(ref)
+		if err != nil {
+			return fieldError("ChainTokens."+ref, err)
+		}
+		// private networks start empty, so their tokens are always mocks
+		if name, ok := private[selector]; ok {
+			return fmt.Errorf("ChainTokens.%s: %s is a private network, its LINK and wrapped native tokens are deployed as mocks", ref, name)
+		}
+		tokens := o.ChainTokens[ref]
+		if tokens == nil {
+			continue
+		}
+		family, err := chainselectors.GetSelectorFamily(selector)
+		if err != nil {
+			return fieldError("ChainTokens."+ref, err)
+		}
+		if tokens.LINK != nil {
+			if err := validateAddress(family, *tokens.LINK); err != nil {
+				return fieldError("ChainTokens."+ref+".LINK", err)
+			}
+		}
+		if tokens.WrappedNative != nil {
+			if err := validateAddress(family, *tokens.WrappedNative); err != nil {
+				return fieldError("ChainTokens."+ref+".WrappedNative", err)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateLiveNetworkChainTokens checks that every live network has its LINK and wrapped native token
+// configured in ChainTokens, as mocks can't stand in for them there.
+func (o *Config) ValidateLiveNetworkChainTokens(evmNetworks []blockchain.EVMNetwork) error {
+	var missing []string
+	for _, network := range evmNetworks {
+		if network.Simulated || network.ChainID <= 0 {
+			continue
+		}
+		selector, err := chainselectors.SelectorFromChainId(uint64(network.ChainID))
+		if err != nil {
+			return fmt.Errorf("network %s: %w", network.Name, err)
+		}
+		tokens := o.GetChainTokens(selector)
+		if tokens.LINK == TOKEN_DEPLOY_MOCK || tokens.WrappedNative == TOKEN_DEPLOY_MOCK {
+			missing = append(missing, network.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("ChainTokens.LINK and ChainTokens.WrappedNative must be set for live networks %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/blockchain"
+)
+
+func TestChainTokens(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(`
+[ChainTokens.ethereum-testnet-sepolia]
+LINK = '0x779877A7B0D9E8603169DdbD7836e478b4624789'
+WrappedNative = '0x097D90c9d3E0B50Ca60e1ae45F6A81010f9FB534'
+
+[ChainTokens.avalanche-testnet-fuji]
+LINK = '0x0b9d5D9136855f6FEc3c0993feE6E9CE8a297846'
+
+[PrivateEthereumNetworks.SIMULATED_1.EthereumChainConfig]
+chain_id = 1337
+`), &cfg))
+	require.NoError(t, cfg.validateChainTokens())
+
+	sepolia, err := cfg.ResolveChainSelector("ethereum-testnet-sepolia")
+	require.NoError(t, err)
+	require.Equal(t, ChainTokenAddresses{
+		LINK:          "0x779877A7B0D9E8603169DdbD7836e478b4624789",
+		WrappedNative: "0x097D90c9d3E0B50Ca60e1ae45F6A81010f9FB534",
+	}, cfg.GetChainTokens(sepolia))
+	simulated, err := cfg.ResolveChainSelector("SIMULATED_1")
+	require.NoError(t, err)
+	require.Equal(t, ChainTokenAddresses{LINK: TOKEN_DEPLOY_MOCK, WrappedNative: TOKEN_DEPLOY_MOCK}, cfg.GetChainTokens(simulated))
+
+	// fuji is missing its wrapped native token
+	networks := []blockchain.EVMNetwork{
+		{Name: "sepolia", ChainID: 11155111},
+		{Name: "fuji", ChainID: 43113},
+		{Name: "simulated", ChainID: 1337, Simulated: true},
+	}
+	require.EqualError(t, cfg.ValidateLiveNetworkChainTokens(networks), "ChainTokens.LINK and ChainTokens.WrappedNative must be set for live networks fuji")
+	require.NoError(t, cfg.ValidateLiveNetworkChainTokens(networks[:1]))
+}
+
+func TestValidateChainTokens(t *testing.T) {
+	for _, tc := range []struct {
+		tokens string
+		err    string
+	}{
+		{"[ChainTokens.SIMULATED_1]\nLINK = '0x779877A7B0D9E8603169DdbD7836e478b4624789'\n", "ChainTokens.SIMULATED_1: SIMULATED_1 is a private network"},
+		{"[ChainTokens.3379446385462418246]\nLINK = '0x779877A7B0D9E8603169DdbD7836e478b4624789'\n", "ChainTokens.3379446385462418246: SIMULATED_1 is a private network"},
+		{"[ChainTokens.ethereum-testnet-sepolia]\nLINK = '0x1234'\n", `ChainTokens.ethereum-testnet-sepolia.LINK: "0x1234" is not a 20 byte hex address`},
+		{"[ChainTokens.16423721717087811551]\nWrappedNative = '0x779877A7B0D9E8603169DdbD7836e478b4624789'\n", "ChainTokens.16423721717087811551.WrappedNative: \"0x779877A7B0D9E8603169DdbD7836e478b4624789\" is not a base58 address"},
+	} {
+		var cfg Config
+		require.NoError(t, toml.Unmarshal([]byte(tc.tokens+"[PrivateEthereumNetworks.SIMULATED_1.EthereumChainConfig]\nchain_id = 1337\n"), &cfg))
+		require.ErrorContains(t, cfg.validateChainTokens(), tc.err)
+	}
+}
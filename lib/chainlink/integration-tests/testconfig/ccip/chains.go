@@ -0,0 +1,73 @@
+package ccip
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	chainselectors "github.com/smartcontractkit/chain-selectors"
+
+	ctfconfig "github.com/smartcontractkit/chainlink-testing-framework/lib/config"
+)
+
+// ResolveChainSelector resolves a chain reference as written in the config to a chain selector.
+// A reference is either a chain selector, a PrivateEthereumNetworks key or a chain-selectors chain name.
+func (o *Config) ResolveChainSelector(ref string) (uint64, error) {
+	if selector, err := strconv.ParseUint(ref, 10, 64); err == nil {
+		if _, err := chainselectors.GetSelectorFamily(selector); err != nil {
+			return 0, withKind(ErrChainNotConfigured, fmt.Errorf("chain %s: %w", ref, err))
+		}
+		return selector, nil
+	}
+	if network, ok := o.PrivateEthereumNetworks[ref]; ok {
+		return privateNetworkSelector(ref, network)
+	}
+	chainID, err := chainselectors.ChainIdFromName(ref)
+	if err != nil {
+		return 0, withKind(ErrChainNotConfigured, fmt.Errorf("chain %s is neither a chain selector, a configured private network nor a known chain name", ref))
+	}
+	selector, err := chainselectors.SelectorFromChainId(chainID)
+	if err != nil {
+		return 0, withKind(ErrChainNotConfigured, fmt.Errorf("chain %s: %w", ref, err))
+	}
+	return selector, nil
+}
+
+func privateNetworkSelector(name string, network *ctfconfig.EthereumNetworkConfig) (uint64, error) {
+	if network == nil || network.EthereumChainConfig == nil || network.EthereumChainConfig.ChainID <= 0 {
+		return 0, withKind(ErrChainNotConfigured, fmt.Errorf("chain %s: private network has no chain id configured", name))
+	}
+	selector, err := chainselectors.SelectorFromChainId(uint64(network.EthereumChainConfig.ChainID))
+	if err != nil {
+		return 0, withKind(ErrChainNotConfigured, fmt.Errorf("chain %s: %w", name, err))
+	}
+	return selector, nil
+}
+
+// NetworksBySelector returns PrivateEthereumNetworks keyed by chain selector. The result is cached on the
+// config until Revalidate, call it before sharing the config between goroutines and don't modify it.
+func (o *Config) NetworksBySelector() (map[uint64]*ctfconfig.EthereumNetworkConfig, error) {
+	if o.networksBySelector != nil {
+		return o.networksBySelector, nil
+	}
+	names := make([]string, 0, len(o.PrivateEthereumNetworks))
+	for name := range o.PrivateEthereumNetworks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	networks := make(map[uint64]*ctfconfig.EthereumNetworkConfig, len(names))
+	nameOf := make(map[uint64]string, len(names))
+	for _, name := range names {
+		selector, err := privateNetworkSelector(name, o.PrivateEthereumNetworks[name])
+		if err != nil {
+			return nil, fieldError("PrivateEthereumNetworks."+name, err)
+		}
+		if other, ok := nameOf[selector]; ok {
+			return nil, fieldError("PrivateEthereumNetworks."+name, fmt.Errorf("chain %s resolves to selector %d like %s", name, selector, other))
+		}
+		networks[selector] = o.PrivateEthereumNetworks[name]
+		nameOf[selector] = name
+	}
+	o.networksBySelector = networks
+	return networks, nil
+}
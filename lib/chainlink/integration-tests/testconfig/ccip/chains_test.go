@@ -0,0 +1,71 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworksBySelector(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(`
+[PrivateEthereumNetworks.SIMULATED_1.EthereumChainConfig]
+chain_id = 1337
+
+[PrivateEthereumNetworks.ALLOCATED.EthereumChainConfig]
+chain_id = 90000001
+`), &cfg))
+	networks, err := cfg.NetworksBySelector()
+	require.NoError(t, err)
+	require.Len(t, networks, 2)
+	require.Same(t, cfg.PrivateEthereumNetworks["SIMULATED_1"], networks[3379446385462418246])
+	allocated, err := cfg.ResolveChainSelector("ALLOCATED")
+	require.NoError(t, err)
+	require.Same(t, cfg.PrivateEthereumNetworks["ALLOCATED"], networks[allocated])
+
+	// cached until Revalidate
+	delete(cfg.PrivateEthereumNetworks, "ALLOCATED")
+	networks, err = cfg.NetworksBySelector()
+	require.NoError(t, err)
+	require.Len(t, networks, 2)
+	require.NoError(t, cfg.Revalidate("PrivateEthereumNetworks"))
+	networks, err = cfg.NetworksBySelector()
+	require.NoError(t, err)
+	require.Len(t, networks, 1)
+
+	for _, tc := range []struct {
+		name     string
+		networks string
+		err      string
+		kind     error
+	}{
+		{
+			name:     "name typo without chain id",
+			networks: "[PrivateEthereumNetworks.SIMULATD_2.EthereumChainConfig]\nseconds_per_slot = 2",
+			err:      "PrivateEthereumNetworks.SIMULATD_2: chain SIMULATD_2: private network has no chain id configured",
+			kind:     ErrChainNotConfigured,
+		},
+		{
+			name:     "custom chain id unknown to chain-selectors",
+			networks: "[PrivateEthereumNetworks.CUSTOM.EthereumChainConfig]\nchain_id = 4242424242",
+			err:      "PrivateEthereumNetworks.CUSTOM: chain CUSTOM:",
+			kind:     ErrChainNotConfigured,
+		},
+		{
+			name:     "duplicate selector",
+			networks: "[PrivateEthereumNetworks.A.EthereumChainConfig]\nchain_id = 1337\n[PrivateEthereumNetworks.B.EthereumChainConfig]\nchain_id = 1337",
+			err:      "PrivateEthereumNetworks.B: chain B resolves to selector 3379446385462418246 like A",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte(tc.networks), &cfg))
+			_, err := cfg.NetworksBySelector()
+			require.ErrorContains(t, err, tc.err)
+			if tc.kind != nil {
+				require.ErrorIs(t, err, tc.kind)
+			}
+		})
+	}
+}
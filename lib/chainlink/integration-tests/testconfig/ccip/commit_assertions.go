@@ -0,0 +1,270 @@
+package ccip
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// offRampCommitReportAcceptedABI is the CommitReportAccepted event of the compiled-in OffRamp, used when
+// ABIOverrides doesn't override the OffRamp.
+const offRampCommitReportAcceptedABI = `[{"type":"event","name":"CommitReportAccepted","anonymous":false,"inputs":[
+{"name":"merkleRoots","type":"tuple[]","indexed":false,"components":[
+	{"name":"sourceChainSelector","type":"uint64"},
+	{"name":"onRampAddress","type":"bytes"},
+	{"name":"minSeqNr","type":"uint64"},
+	{"name":"maxSeqNr","type":"uint64"},
+	{"name":"merkleRoot","type":"bytes32"}]},
+{"name":"priceUpdates","type":"tuple","indexed":false,"components":[
+	{"name":"tokenPriceUpdates","type":"tuple[]","components":[
+		{"name":"sourceToken","type":"address"},
+		{"name":"usdPerToken","type":"uint224"}]},
+	{"name":"gasPriceUpdates","type":"tuple[]","components":[
+		{"name":"destChainSelector","type":"uint64"},
+		{"name":"usdPerUnitGas","type":"uint224"}]}]}]}]`
+
+// CommitAssertions are the commit batching expectations checked after a load test, e.g. that messages
+// sent under load are committed together rather than one report per message.
+type CommitAssertions struct {
+	// ExpectMinBatchSize is the smallest number of messages of a lane a commit report may cover, the
+	// last report of the lane excepted
+	ExpectMinBatchSize *int `toml:",omitempty"`
+	// ExpectMaxReports is the most commit reports covering messages of a lane
+	ExpectMaxReports *int `toml:",omitempty"`
+	// PerLane overrides the expectations of "source->dest" lanes
+	PerLane map[string]*CommitExpectations `toml:",omitempty"`
+}
+
+// CommitExpectations are the commit batching expectations of a lane, unset fields aren't checked.
+type CommitExpectations struct {
+	ExpectMinBatchSize *int `toml:",omitempty"`
+	ExpectMaxReports   *int `toml:",omitempty"`
+}
+
+// GetCommitExpectations returns the expectations of the lane, the fields of PerLane overriding the
+// defaults of CommitAssertions.
+func (o *Config) GetCommitExpectations(lane ResolvedLane) CommitExpectations {
+	if o.CommitAssertions == nil {
+		return CommitExpectations{}
+	}
+	expectations := CommitExpectations{
+		ExpectMinBatchSize: o.CommitAssertions.ExpectMinBatchSize,
+		ExpectMaxReports:   o.CommitAssertions.ExpectMaxReports,
+	}
+	// of several keys matching the lane, the later in sorted order wins, as it does for RateLimits
+	for _, laneKey := range matchingLaneKeys(lane, o.CommitAssertions.PerLane) {
+		perLane := o.CommitAssertions.PerLane[laneKey]
+		if perLane == nil {
+			continue
+		}
+		if perLane.ExpectMinBatchSize != nil {
+			expectations.ExpectMinBatchSize = perLane.ExpectMinBatchSize
+		}
+		if perLane.ExpectMaxReports != nil {
+			expectations.ExpectMaxReports = perLane.ExpectMaxReports
+		}
+	}
+	return expectations
+}
+
+// CommitReportEvent is a CommitReportAccepted event emitted by the OffRamp of the dest chain.
+type CommitReportEvent struct {
+	DestSelector uint64
+	BlockNumber  uint64
+	// MerkleRoots has one root per source chain the report commits messages of
+	MerkleRoots []CommitMerkleRoot
+}
+
+// CommitMerkleRoot is the range of sequence numbers of a source chain a commit report covers.
+type CommitMerkleRoot struct {
+	SourceSelector uint64
+	MinSeqNr       uint64
+	MaxSeqNr       uint64
+}
+
+func (r CommitMerkleRoot) BatchSize() int {
+	return int(r.MaxSeqNr - r.MinSeqNr + 1)
+}
+
+// CommitBatches is the batch size distribution observed on a lane.
+type CommitBatches struct {
+	Lane    string  `json:"lane"`
+	Reports int     `json:"reports"`
+	Min     int     `json:"min"`
+	Max     int     `json:"max"`
+	Mean    float64 `json:"mean"`
+	// Sizes counts the reports per batch size
+	Sizes map[int]int `json:"sizes"`
+}
+
+// ParseCommitReports decodes the CommitReportAccepted events among the logs of the OffRamp of the dest
+// chain, with the OffRamp of ABIOverrides when set. Other events are skipped.
+func (o *Config) ParseCommitReports(destSelector uint64, logs []types.Log) ([]CommitReportEvent, error) {
+	offRamp, err := o.GetABI("OffRamp")
+	if err != nil {
+		return nil, err
+	}
+	if offRamp == nil {
+		parsed, err := abi.JSON(strings.NewReader(offRampCommitReportAcceptedABI))
+		if err != nil {
+			return nil, err
+		}
+		offRamp = &parsed
+	}
+	event, ok := offRamp.Events["CommitReportAccepted"]
+	if !ok {
+		return nil, fmt.Errorf("OffRamp ABI has no CommitReportAccepted event")
+	}
+	var reports []CommitReportEvent
+	for _, log := range logs {
+		if len(log.Topics) == 0 || log.Topics[0] != event.ID {
+			continue
+		}
+		fields := make(map[string]interface{})
+		if err := event.Inputs.NonIndexed().UnpackIntoMap(fields, log.Data); err != nil {
+			return nil, fmt.Errorf("decode CommitReportAccepted of tx %s: %w", log.TxHash.Hex(), err)
+		}
+		roots, err := commitMerkleRoots(fields["merkleRoots"])
+		if err != nil {
+			return nil, fmt.Errorf("decode CommitReportAccepted of tx %s: %w", log.TxHash.Hex(), err)
+		}
+		reports = append(reports, CommitReportEvent{DestSelector: destSelector, BlockNumber: log.BlockNumber, MerkleRoots: roots})
+	}
+	return reports, nil
+}
+
+// commitMerkleRoots reads the sequence number ranges out of the merkleRoots the ABI decoded to a slice
+// of anonymous structs.
+func commitMerkleRoots(decoded interface{}) ([]CommitMerkleRoot, error) {
+	v := reflect.ValueOf(decoded)
+	if v.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("merkleRoots must be an array of tuples, got %T", decoded)
+	}
+	roots := make([]CommitMerkleRoot, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		elem := v.Index(i)
+		if elem.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("merkleRoots must be an array of tuples, got %T", decoded)
+		}
+		var root CommitMerkleRoot
+		for name, dst := range map[string]*uint64{"SourceChainSelector": &root.SourceSelector, "MinSeqNr": &root.MinSeqNr, "MaxSeqNr": &root.MaxSeqNr} {
+			field := elem.FieldByName(name)
+			if !field.IsValid() || field.Kind() != reflect.Uint64 {
+				return nil, fmt.Errorf("merkleRoots have no uint64 %s", name)
+			}
+			*dst = field.Uint()
+		}
+		roots = append(roots, root)
+	}
+	return roots, nil
+}
+
+// AssertCommitReports evaluates the commit expectations of each lane against the reports of its dest
+// chain, in block order. It returns the batch size distribution of the lanes with at least one report,
+// sorted by lane, and the expectations they missed.
+func (o *Config) AssertCommitReports(lanes []ResolvedLane, reports []CommitReportEvent) ([]CommitBatches, []ThresholdViolation) {
+	var distributions []CommitBatches
+	var violations []ThresholdViolation
+	for _, lane := range lanes {
+		var sizes []int
+		for _, report := range reports {
+			if report.DestSelector != lane.DestSelector {
+				continue
+			}
+			for _, root := range report.MerkleRoots {
+				if root.SourceSelector == lane.SourceSelector {
+					sizes = append(sizes, root.BatchSize())
+				}
+			}
+		}
+		expectations := o.GetCommitExpectations(lane)
+		if limit := expectations.ExpectMaxReports; limit != nil && len(sizes) > *limit {
+			violations = append(violations, ThresholdViolation{
+				Threshold: fmt.Sprintf("CommitAssertions.ExpectMaxReports %s", lane.Key()),
+				Limit:     fmt.Sprint(*limit),
+				Actual:    fmt.Sprint(len(sizes)),
+			})
+		}
+		if len(sizes) == 0 {
+			continue
+		}
+		if limit := expectations.ExpectMinBatchSize; limit != nil {
+			// the tail of the run may be committed on its own
+			for _, size := range sizes[:len(sizes)-1] {
+				if size < *limit {
+					violations = append(violations, ThresholdViolation{
+						Threshold: fmt.Sprintf("CommitAssertions.ExpectMinBatchSize %s", lane.Key()),
+						Limit:     fmt.Sprint(*limit),
+						Actual:    fmt.Sprint(size),
+					})
+					break
+				}
+			}
+		}
+		distribution := CommitBatches{Lane: lane.Key(), Reports: len(sizes), Min: sizes[0], Sizes: make(map[int]int)}
+		total := 0
+		for _, size := range sizes {
+			distribution.Min = min(distribution.Min, size)
+			distribution.Max = max(distribution.Max, size)
+			distribution.Sizes[size]++
+			total += size
+		}
+		distribution.Mean = float64(total) / float64(len(sizes))
+		distributions = append(distributions, distribution)
+	}
+	sort.Slice(distributions, func(i, j int) bool { return distributions[i].Lane < distributions[j].Lane })
+	return distributions, violations
+}
+
+func (o *Config) validateCommitAssertions() error {
+	if o.CommitAssertions == nil {
+		return nil
+	}
+	expectations := map[string]*CommitExpectations{
+		"CommitAssertions": {ExpectMinBatchSize: o.CommitAssertions.ExpectMinBatchSize, ExpectMaxReports: o.CommitAssertions.ExpectMaxReports},
+	}
+	for laneKey, perLane := range o.CommitAssertions.PerLane {
+		field := "CommitAssertions.PerLane." + laneKey
+		source, dest, err := ParseLaneKey(laneKey)
+		if err != nil {
+			return fieldError("CommitAssertions.PerLane", err)
+		}
+		for _, network := range []string{source, dest} {
+			if _, err := o.ResolveChainSelector(network); err != nil {
+				return fieldError(field, err)
+			}
+		}
+		if perLane != nil {
+			expectations[field] = perLane
+		}
+	}
+	fields := make([]string, 0, len(expectations))
+	for field := range expectations {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	maxBatch := o.ExecConfig.GetMaxMessagesPerBatch()
+	for _, field := range fields {
+		e := expectations[field]
+		if e.ExpectMaxReports != nil && *e.ExpectMaxReports < 1 {
+			return fmt.Errorf("%s.ExpectMaxReports must be at least 1, got %d", field, *e.ExpectMaxReports)
+		}
+		if e.ExpectMinBatchSize == nil {
+			continue
+		}
+		if o.LoadProfile == nil {
+			return fmt.Errorf("%s.ExpectMinBatchSize requires a LoadProfile, single messages are never batched", field)
+		}
+		if *e.ExpectMinBatchSize < 1 {
+			return fmt.Errorf("%s.ExpectMinBatchSize must be at least 1, got %d", field, *e.ExpectMinBatchSize)
+		}
+		if *e.ExpectMinBatchSize > maxBatch {
+			return fmt.Errorf("%s.ExpectMinBatchSize (%d) is above ExecConfig.MaxMessagesPerBatch (%d)", field, *e.ExpectMinBatchSize, maxBatch)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package ccip
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const commitAssertionsTOML = orderingNetworks + `
+[LoadProfile]
+TestDuration = '10m'
+
+[CommitAssertions]
+ExpectMinBatchSize = 5
+ExpectMaxReports = 3
+
+[CommitAssertions.PerLane.'SIMULATED_2->SIMULATED_1']
+ExpectMinBatchSize = 1
+`
+
+type testMerkleRoot struct {
+	SourceChainSelector uint64
+	OnRampAddress       []byte
+	MinSeqNr            uint64
+	MaxSeqNr            uint64
+	MerkleRoot          [32]byte
+}
+
+type testPriceUpdates struct {
+	TokenPriceUpdates []struct {
+		SourceToken common.Address
+		UsdPerToken *big.Int
+	}
+	GasPriceUpdates []struct {
+		DestChainSelector uint64
+		UsdPerUnitGas     *big.Int
+	}
+}
+
+func commitReportLog(t *testing.T, block uint64, roots ...testMerkleRoot) types.Log {
+	parsed, err := abi.JSON(strings.NewReader(offRampCommitReportAcceptedABI))
+	require.NoError(t, err)
+	event := parsed.Events["CommitReportAccepted"]
+	data, err := event.Inputs.NonIndexed().Pack(roots, testPriceUpdates{})
+	require.NoError(t, err)
+	return types.Log{Topics: []common.Hash{event.ID}, Data: data, BlockNumber: block}
+}
+
+func TestAssertCommitReports(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(commitAssertionsTOML), &cfg))
+	require.NoError(t, cfg.validateCommitAssertions())
+
+	const simulated1, simulated2 = 3379446385462418246, 12922642891491394802
+	logs := []types.Log{
+		commitReportLog(t, 10, testMerkleRoot{SourceChainSelector: simulated1, MinSeqNr: 1, MaxSeqNr: 8}),
+		{Topics: []common.Hash{{0x1}}, BlockNumber: 11},
+		commitReportLog(t, 12, testMerkleRoot{SourceChainSelector: simulated1, MinSeqNr: 9, MaxSeqNr: 11}),
+		commitReportLog(t, 13, testMerkleRoot{SourceChainSelector: simulated1, MinSeqNr: 12, MaxSeqNr: 12}),
+		commitReportLog(t, 14, testMerkleRoot{SourceChainSelector: simulated1, MinSeqNr: 13, MaxSeqNr: 20}),
+	}
+	reports, err := cfg.ParseCommitReports(simulated2, logs)
+	require.NoError(t, err)
+	require.Len(t, reports, 4)
+	require.Equal(t, CommitMerkleRoot{SourceSelector: simulated1, MinSeqNr: 9, MaxSeqNr: 11}, reports[1].MerkleRoots[0])
+	reports = append(reports, CommitReportEvent{DestSelector: simulated1, MerkleRoots: []CommitMerkleRoot{
+		{SourceSelector: simulated2, MinSeqNr: 1, MaxSeqNr: 1},
+		{SourceSelector: simulated2, MinSeqNr: 2, MaxSeqNr: 3},
+	}})
+
+	lanes := []ResolvedLane{
+		{Source: "SIMULATED_2", Dest: "SIMULATED_1", SourceSelector: simulated2, DestSelector: simulated1},
+		{Source: "SIMULATED_1", Dest: "SIMULATED_2", SourceSelector: simulated1, DestSelector: simulated2},
+	}
+	batches, violations := cfg.AssertCommitReports(lanes, reports)
+	require.Equal(t, []CommitBatches{
+		{Lane: "SIMULATED_1->SIMULATED_2", Reports: 4, Min: 1, Max: 8, Mean: 5, Sizes: map[int]int{1: 1, 3: 1, 8: 2}},
+		{Lane: "SIMULATED_2->SIMULATED_1", Reports: 2, Min: 1, Max: 2, Mean: 1.5, Sizes: map[int]int{1: 1, 2: 1}},
+	}, batches)
+	require.Equal(t, []ThresholdViolation{
+		{Threshold: "CommitAssertions.ExpectMaxReports SIMULATED_1->SIMULATED_2", Limit: "3", Actual: "4"},
+		{Threshold: "CommitAssertions.ExpectMinBatchSize SIMULATED_1->SIMULATED_2", Limit: "5", Actual: "3"},
+	}, violations)
+}
+
+func TestValidateCommitAssertions(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "no load profile", content: orderingNetworks + "[CommitAssertions]\nExpectMinBatchSize = 2", err: "CommitAssertions.ExpectMinBatchSize requires a LoadProfile"},
+		{name: "above max batch", content: orderingNetworks + "[LoadProfile]\n[ExecConfig]\nMaxMessagesPerBatch = 10\n[CommitAssertions.PerLane.'SIMULATED_1->SIMULATED_2']\nExpectMinBatchSize = 11", err: "CommitAssertions.PerLane.SIMULATED_1->SIMULATED_2.ExpectMinBatchSize (11) is above ExecConfig.MaxMessagesPerBatch (10)"},
+		{name: "no reports", content: orderingNetworks + "[CommitAssertions]\nExpectMaxReports = 0", err: "CommitAssertions.ExpectMaxReports must be at least 1, got 0"},
+		{name: "unknown chain", content: orderingNetworks + "[CommitAssertions.PerLane.'SIMULATED_1->nope']", err: "CommitAssertions.PerLane.SIMULATED_1->nope: "},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte(tc.content), &cfg))
+			require.ErrorContains(t, cfg.validateCommitAssertions(), tc.err)
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package ccip
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	"github.com/Masterminds/semver/v3"
+
+	ctfconfig "github.com/smartcontractkit/chainlink-testing-framework/lib/config"
+)
+
+const (
+	COMPAT_TESTED    = "tested"
+	COMPAT_KNOWN_BAD = "known-bad"
+	COMPAT_UNTESTED  = "untested"
+)
+
+// CompatRule matches a combination of component versions. Versions maps COMPONENT_NODES, COMPONENT_JD
+// and COMPONENT_RMN to semver constraints, components not listed match any version.
+type CompatRule struct {
+	Versions map[string]string
+	// Status is COMPAT_TESTED or COMPAT_KNOWN_BAD
+	Status string
+	// Reason tells what breaks, only shown for known bad combinations
+	Reason string
+}
+
+// ComponentCompatibility is the JD, node and RMN version matrix. A known bad rule wins over a tested
+// one matching the same combination, a combination no tested rule matches is reported as untested.
+// Add new releases here.
+var ComponentCompatibility = []CompatRule{
+	{
+		Versions: map[string]string{COMPONENT_JD: ">= 0.9.0", COMPONENT_NODES: "< 2.19.0"},
+		Status:   COMPAT_KNOWN_BAD,
+		Reason:   "JD proposes job specs the node only accepts from 2.19.0, proposals are rejected",
+	},
+	{
+		Versions: map[string]string{COMPONENT_RMN: ">= 0.7.0", COMPONENT_NODES: "< 2.20.0"},
+		Status:   COMPAT_KNOWN_BAD,
+		Reason:   "nodes before 2.20.0 can't read the RMN 0.7 observation format, commit reports never get RMN signatures",
+	},
+	{
+		Versions: map[string]string{COMPONENT_JD: ">= 0.9.0, < 1.0.0", COMPONENT_NODES: ">= 2.19.0", COMPONENT_RMN: ">= 0.6.0, < 0.7.0"},
+		Status:   COMPAT_TESTED,
+	},
+	{
+		Versions: map[string]string{COMPONENT_JD: ">= 0.9.0, < 1.0.0", COMPONENT_NODES: ">= 2.20.0", COMPONENT_RMN: ">= 0.7.0, < 0.8.0"},
+		Status:   COMPAT_TESTED,
+	},
+}
+
+// compatComponents are the components the matrix covers, in the order they're reported.
+var compatComponents = []string{COMPONENT_NODES, COMPONENT_JD, COMPONENT_RMN}
+
+// CompatWarning is a combination of component versions that is known bad or hasn't been tested.
+type CompatWarning struct {
+	Status  string
+	Message string
+}
+
+func (w CompatWarning) String() string {
+	return fmt.Sprintf("[%s] %s", w.Status, w.Message)
+}
+
+// GetNodeVersion returns the chainlink node image version, falling back to the env var the top level
+// config reads ChainlinkImage.Version from.
+func (n *NodeConfig) GetNodeVersion() string {
+	if n != nil && pointer.GetString(n.Version) != "" {
+		return *n.Version
+	}
+	return ctfconfig.MustReadEnvVar_String(ctfconfig.E2E_TEST_CHAINLINK_VERSION_ENV)
+}
+
+// GetComponentVersions returns the node, JD and RMN versions the run uses, keyed by the COMPONENT_*
+// constant and "" where neither the config nor the env sets one.
+func (o *Config) GetComponentVersions() map[string]string {
+	versions := map[string]string{
+		COMPONENT_NODES: o.CLNode.GetNodeVersion(),
+		COMPONENT_JD:    pointer.GetString(o.JobDistributorConfig.Version),
+		COMPONENT_RMN:   pointer.GetString(o.RMNConfig.AFNVersion),
+	}
+	if versions[COMPONENT_JD] == "" {
+		versions[COMPONENT_JD] = ctfconfig.MustReadEnvVar_String(E2E_JD_VERSION)
+	}
+	if versions[COMPONENT_RMN] == "" {
+		versions[COMPONENT_RMN] = ctfconfig.MustReadEnvVar_String(E2E_RMN_AFN2PROXY_VERSION)
+	}
+	return versions
+}
+
+// CheckCompatibility reports the known bad rules the resolved versions match, or an untested warning
+// if no tested rule does. Versions that aren't semver, like sha tags, can't be matched and are always
+// reported as untested.
+func (o *Config) CheckCompatibility() []CompatWarning {
+	return checkCompatibility(ComponentCompatibility, o.GetComponentVersions())
+}
+
+func checkCompatibility(rules []CompatRule, versions map[string]string) []CompatWarning {
+	var warnings []CompatWarning
+	parsed := make(map[string]*semver.Version, len(versions))
+	for _, component := range compatComponents {
+		version, err := semver.NewVersion(strings.TrimPrefix(versions[component], "v"))
+		if err != nil {
+			shown := versions[component]
+			if shown == "" {
+				shown = "unset"
+			}
+			warnings = append(warnings, CompatWarning{
+				Status:  COMPAT_UNTESTED,
+				Message: fmt.Sprintf("%s version %q is not a release version, its compatibility is unknown", component, shown),
+			})
+			continue
+		}
+		parsed[component] = version
+	}
+	if len(warnings) > 0 {
+		return warnings
+	}
+	combination := describeVersions(versions)
+	tested := false
+	for _, rule := range rules {
+		if !rule.matches(parsed) {
+			continue
+		}
+		switch rule.Status {
+		case COMPAT_KNOWN_BAD:
+			warnings = append(warnings, CompatWarning{
+				Status:  COMPAT_KNOWN_BAD,
+				Message: fmt.Sprintf("%s: %s", combination, rule.Reason),
+			})
+		case COMPAT_TESTED:
+			tested = true
+		}
+	}
+	if len(warnings) == 0 && !tested {
+		warnings = append(warnings, CompatWarning{
+			Status:  COMPAT_UNTESTED,
+			Message: fmt.Sprintf("%s has not been tested together", combination),
+		})
+	}
+	return warnings
+}
+
+func (r CompatRule) matches(versions map[string]*semver.Version) bool {
+	for component, constraint := range r.Versions {
+		c, err := semver.NewConstraint(constraint)
+		if err != nil || !c.Check(versions[component]) {
+			return false
+		}
+	}
+	return true
+}
+
+func describeVersions(versions map[string]string) string {
+	parts := make([]string, 0, len(compatComponents))
+	for _, component := range compatComponents {
+		parts = append(parts, component+" "+versions[component])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// validateCompatibility fails on known bad combinations if FailOnIncompatible is set. Untested
+// combinations stay warnings, sha tagged builds are expected to be untested.
+func (o *Config) validateCompatibility() error {
+	if !pointer.GetBool(o.FailOnIncompatible) {
+		return nil
+	}
+	var lines []string
+	for _, w := range o.CheckCompatibility() {
+		if w.Status == COMPAT_KNOWN_BAD {
+			lines = append(lines, w.String())
+		}
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	return fmt.Errorf("FailOnIncompatible is set and the component versions are known not to work together:\n%s", strings.Join(lines, "\n"))
+}
@@ -0,0 +1,84 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentCompatibility(t *testing.T) {
+	// one case per rule of ComponentCompatibility, in the same order
+	cases := []struct {
+		versions map[string]string
+		status   string
+	}{
+		{map[string]string{COMPONENT_NODES: "2.18.1", COMPONENT_JD: "0.9.0", COMPONENT_RMN: "0.6.0"}, COMPAT_KNOWN_BAD},
+		{map[string]string{COMPONENT_NODES: "2.19.0", COMPONENT_JD: "0.9.2", COMPONENT_RMN: "0.7.1"}, COMPAT_KNOWN_BAD},
+		{map[string]string{COMPONENT_NODES: "v2.19.3", COMPONENT_JD: "v0.9.2", COMPONENT_RMN: "0.6.4"}, COMPAT_TESTED},
+		{map[string]string{COMPONENT_NODES: "2.21.0", COMPONENT_JD: "0.12.0", COMPONENT_RMN: "0.7.0"}, COMPAT_TESTED},
+	}
+	require.Len(t, cases, len(ComponentCompatibility), "every rule needs a case")
+	for i, rule := range ComponentCompatibility {
+		require.Equal(t, rule.Status, cases[i].status, "rule %d", i)
+		warnings := checkCompatibility([]CompatRule{rule}, cases[i].versions)
+		if rule.Status == COMPAT_TESTED {
+			require.Empty(t, warnings, "rule %d", i)
+			continue
+		}
+		require.Len(t, warnings, 1, "rule %d", i)
+		require.Equal(t, COMPAT_KNOWN_BAD, warnings[0].Status)
+		require.Contains(t, warnings[0].Message, rule.Reason)
+	}
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	t.Setenv("E2E_TEST_CHAINLINK_VERSION", "")
+	t.Setenv(E2E_JD_VERSION, "")
+	t.Setenv(E2E_RMN_AFN2PROXY_VERSION, "")
+	cfg := &Config{
+		CLNode:               &NodeConfig{Version: pointer.ToString("2.20.0")},
+		JobDistributorConfig: JDConfig{Version: pointer.ToString("0.9.1")},
+		RMNConfig:            RMNConfig{AFNVersion: pointer.ToString("0.7.2")},
+	}
+	require.Empty(t, cfg.CheckCompatibility())
+
+	// versions come from env when the config doesn't set them
+	cfg.RMNConfig.AFNVersion = nil
+	t.Setenv(E2E_RMN_AFN2PROXY_VERSION, "0.8.0")
+	require.Equal(t, []CompatWarning{{
+		Status:  COMPAT_UNTESTED,
+		Message: "nodes 2.20.0, jd 0.9.1, rmn 0.8.0 has not been tested together",
+	}}, cfg.CheckCompatibility())
+
+	// sha tagged builds and unset versions can't be matched
+	cfg.CLNode.Version = pointer.ToString("a1b2c3d4e5f6")
+	t.Setenv(E2E_RMN_AFN2PROXY_VERSION, "")
+	require.Equal(t, []CompatWarning{
+		{Status: COMPAT_UNTESTED, Message: `nodes version "a1b2c3d4e5f6" is not a release version, its compatibility is unknown`},
+		{Status: COMPAT_UNTESTED, Message: `rmn version "unset" is not a release version, its compatibility is unknown`},
+	}, cfg.CheckCompatibility())
+
+	// a known bad combination is reported even if a tested rule matches it too
+	cfg.CLNode.Version = pointer.ToString("2.19.0")
+	cfg.RMNConfig.AFNVersion = pointer.ToString("0.7.0")
+	warnings := cfg.CheckCompatibility()
+	require.Len(t, warnings, 1)
+	require.Equal(t, COMPAT_KNOWN_BAD, warnings[0].Status)
+}
+
+func TestValidateCompatibility(t *testing.T) {
+	cfg := &Config{
+		CLNode:               &NodeConfig{Version: pointer.ToString("2.18.0")},
+		JobDistributorConfig: JDConfig{Version: pointer.ToString("0.9.0")},
+		RMNConfig:            RMNConfig{AFNVersion: pointer.ToString("0.6.0")},
+	}
+	require.NoError(t, cfg.validateCompatibility())
+
+	cfg.FailOnIncompatible = pointer.ToBool(true)
+	require.ErrorContains(t, cfg.validateCompatibility(), "proposals are rejected")
+
+	// untested versions stay warnings
+	cfg.CLNode.Version = pointer.ToString("a1b2c3d4e5f6")
+	require.NoError(t, cfg.validateCompatibility())
+}
@@ -0,0 +1,250 @@
+package ccip
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	TOKEN_SELECTION_ROUND_ROBIN = "roundRobin"
+	TOKEN_SELECTION_RANDOM      = "random"
+	TOKEN_SELECTION_FIXED_SET   = "fixedSet"
+
+	DEFAULT_TOKEN_SELECTION = TOKEN_SELECTION_ROUND_ROBIN
+	// DEFAULT_TOKENS_PER_MESSAGE is the single token messages carried before composition was configurable
+	DEFAULT_TOKENS_PER_MESSAGE uint16 = 1
+)
+
+var tokenSelectionStrategies = []string{TOKEN_SELECTION_ROUND_ROBIN, TOKEN_SELECTION_RANDOM, TOKEN_SELECTION_FIXED_SET}
+
+// TokensPerMessage is the range of the number of tokens a message carries, both ends included.
+type TokensPerMessage struct {
+	Min *uint16 `toml:",omitempty"`
+	Max *uint16 `toml:",omitempty"`
+}
+
+// MessageCompositionSettings configures which tokens messages carry and whether they carry data as well.
+type MessageCompositionSettings struct {
+	TokensPerMessage *TokensPerMessage `toml:",omitempty"`
+	// TokenSelectionStrategy is one of "roundRobin", "random" or "fixedSet"
+	TokenSelectionStrategy *string `toml:",omitempty"`
+	// FixedSet are the token symbols every message carries with "fixedSet", the first TokensPerMessage.Max
+	// configured tokens in symbol order when empty
+	FixedSet []string `toml:",omitempty"`
+	// IncludeDataWithTokens adds the Messages payload to messages carrying tokens, defaults to true
+	IncludeDataWithTokens *bool `toml:",omitempty"`
+}
+
+// MessageComposition holds the composition of messages on all lanes, with per lane overrides keyed by
+// "source->dest". Overrides apply over the settings.
+type MessageComposition struct {
+	MessageCompositionSettings
+	PerLane map[string]*MessageCompositionSettings `toml:",omitempty"`
+}
+
+// ResolvedMessageComposition is the composition of messages on a single lane, with every value set.
+type ResolvedMessageComposition struct {
+	MinTokens             uint16
+	MaxTokens             uint16
+	Strategy              string
+	FixedSet              []string
+	IncludeDataWithTokens bool
+}
+
+func (r ResolvedMessageComposition) apply(s *MessageCompositionSettings) ResolvedMessageComposition {
+	if s == nil {
+		return r
+	}
+	if s.TokensPerMessage != nil {
+		if s.TokensPerMessage.Min != nil {
+			r.MinTokens = *s.TokensPerMessage.Min
+		}
+		if s.TokensPerMessage.Max != nil {
+			r.MaxTokens = *s.TokensPerMessage.Max
+		}
+	}
+	if strategy := pointer.GetString(s.TokenSelectionStrategy); strategy != "" {
+		r.Strategy = strategy
+	}
+	if len(s.FixedSet) > 0 {
+		r.FixedSet = s.FixedSet
+	}
+	if s.IncludeDataWithTokens != nil {
+		r.IncludeDataWithTokens = *s.IncludeDataWithTokens
+	}
+	return r
+}
+
+// GetMessageComposition returns the composition of messages on the lane, a single round robin token
+// if any are configured plus the payload unless configured otherwise.
+func (o *Config) GetMessageComposition(lane ResolvedLane) ResolvedMessageComposition {
+	resolved := ResolvedMessageComposition{
+		Strategy:              DEFAULT_TOKEN_SELECTION,
+		IncludeDataWithTokens: true,
+	}
+	if len(o.Tokens) > 0 {
+		resolved.MinTokens, resolved.MaxTokens = DEFAULT_TOKENS_PER_MESSAGE, DEFAULT_TOKENS_PER_MESSAGE
+	}
+	if o.MessageComposition == nil {
+		return resolved
+	}
+	resolved = resolved.apply(&o.MessageComposition.MessageCompositionSettings)
+	if laneKey, ok := laneKeyFor(lane, o.MessageComposition.PerLane); ok {
+		return resolved.apply(o.MessageComposition.PerLane[laneKey])
+	}
+	return resolved
+}
+
+// ComposedMessage is the content of a single message, Tokens are token symbols.
+type ComposedMessage struct {
+	Tokens []string
+	Data   []byte
+}
+
+// ComposeMessage returns the content of the index-th message sent on the lane. rng picks the number
+// of tokens, the random tokens and the random payload, pass Config.NewRand(RAND_COMPONENT_PAYLOAD).
+func (o *Config) ComposeMessage(lane ResolvedLane, index int, rng *rand.Rand) (ComposedMessage, error) {
+	composition := o.GetMessageComposition(lane)
+	var msg ComposedMessage
+	count := int(composition.MinTokens)
+	if composition.MaxTokens > composition.MinTokens {
+		count += rng.Intn(int(composition.MaxTokens-composition.MinTokens) + 1)
+	}
+	if count > 0 {
+		tokens, err := o.selectTokens(composition, index, count, rng)
+		if err != nil {
+			return ComposedMessage{}, fieldError("MessageComposition", err)
+		}
+		msg.Tokens = tokens
+	}
+	if len(msg.Tokens) > 0 && !composition.IncludeDataWithTokens {
+		msg.Data = []byte{}
+		return msg, nil
+	}
+	data, err := o.Messages.BuildPayload(rng)
+	if err != nil {
+		return ComposedMessage{}, err
+	}
+	msg.Data = data
+	return msg, nil
+}
+
+func (o *Config) selectTokens(composition ResolvedMessageComposition, index, count int, rng *rand.Rand) ([]string, error) {
+	if composition.Strategy == TOKEN_SELECTION_FIXED_SET {
+		set := o.fixedTokenSet(composition)
+		if len(set) < count {
+			return nil, fmt.Errorf("fixed set has %d tokens, %d are needed", len(set), count)
+		}
+		return set[:count], nil
+	}
+	symbols := o.tokenSymbols()
+	if len(symbols) < count {
+		return nil, withKind(ErrTokenNotConfigured, fmt.Errorf("%d tokens are configured, %d are needed", len(symbols), count))
+	}
+	tokens := make([]string, 0, count)
+	switch composition.Strategy {
+	case TOKEN_SELECTION_ROUND_ROBIN:
+		for i := 0; i < count; i++ {
+			tokens = append(tokens, symbols[(index*count+i)%len(symbols)])
+		}
+	case TOKEN_SELECTION_RANDOM:
+		for _, i := range rng.Perm(len(symbols))[:count] {
+			tokens = append(tokens, symbols[i])
+		}
+	default:
+		return nil, fmt.Errorf("unknown token selection strategy %q", composition.Strategy)
+	}
+	return tokens, nil
+}
+
+// fixedTokenSet returns the tokens of the fixedSet strategy, the FixedSet or the first MaxTokens configured tokens.
+func (o *Config) fixedTokenSet(composition ResolvedMessageComposition) []string {
+	if len(composition.FixedSet) > 0 {
+		return composition.FixedSet
+	}
+	symbols := o.tokenSymbols()
+	if len(symbols) > int(composition.MaxTokens) {
+		symbols = symbols[:composition.MaxTokens]
+	}
+	return symbols
+}
+
+// tokenSymbols returns the configured tokens in symbol order. Tokens are deployed on every chain, so
+// they are the tokens available on every source chain.
+func (o *Config) tokenSymbols() []string {
+	symbols := make([]string, 0, len(o.Tokens))
+	for symbol := range o.Tokens {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+func (o *Config) validateMessageComposition() error {
+	if o.MessageComposition == nil {
+		return nil
+	}
+	if err := o.validateComposition("MessageComposition", o.GetMessageComposition(ResolvedLane{})); err != nil {
+		return err
+	}
+	laneKeys := make([]string, 0, len(o.MessageComposition.PerLane))
+	for laneKey := range o.MessageComposition.PerLane {
+		laneKeys = append(laneKeys, laneKey)
+	}
+	sort.Strings(laneKeys)
+	for _, laneKey := range laneKeys {
+		field := "MessageComposition.PerLane." + laneKey
+		source, dest, err := ParseLaneKey(laneKey)
+		if err != nil {
+			return fieldError("MessageComposition.PerLane", err)
+		}
+		sourceSelector, err := o.ResolveChainSelector(source)
+		if err != nil {
+			return fieldError(field, err)
+		}
+		destSelector, err := o.ResolveChainSelector(dest)
+		if err != nil {
+			return fieldError(field, err)
+		}
+		lane := ResolvedLane{Source: source, Dest: dest, SourceSelector: sourceSelector, DestSelector: destSelector}
+		if err := o.validateComposition(field, o.GetMessageComposition(lane)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *Config) validateComposition(field string, composition ResolvedMessageComposition) error {
+	if !containsString(tokenSelectionStrategies, composition.Strategy) {
+		return fmt.Errorf("%s.TokenSelectionStrategy %q is not one of %s", field, composition.Strategy, strings.Join(tokenSelectionStrategies, ", "))
+	}
+	if composition.MinTokens > composition.MaxTokens {
+		return fmt.Errorf("%s.TokensPerMessage: Min %d must not be greater than Max %d", field, composition.MinTokens, composition.MaxTokens)
+	}
+	if limit := o.MessageLimits.GetMaxNumberOfTokensPerMsg(); composition.MaxTokens > limit && !o.MessageLimits.IsOverLimitAllowed() {
+		return fmt.Errorf("%s.TokensPerMessage.Max (%d) exceeds MessageLimits.MaxNumberOfTokensPerMsg (%d); "+
+			"set MessageLimits.AllowOverLimitMessages = true if this is intended", field, composition.MaxTokens, limit)
+	}
+	for _, symbol := range composition.FixedSet {
+		if _, ok := o.Tokens[symbol]; !ok {
+			return withKind(ErrTokenNotConfigured, fmt.Errorf("%s.FixedSet: token %s is not configured in Tokens", field, symbol))
+		}
+	}
+	if composition.MaxTokens == 0 {
+		return nil
+	}
+	if composition.Strategy == TOKEN_SELECTION_FIXED_SET {
+		if set := o.fixedTokenSet(composition); len(set) < int(composition.MaxTokens) {
+			return withKind(ErrTokenNotConfigured, fmt.Errorf("%s: fixedSet needs %d tokens on the source chain, only %d are configured", field, composition.MaxTokens, len(set)))
+		}
+		return nil
+	}
+	if n := len(o.Tokens); n < int(composition.MaxTokens) {
+		return withKind(ErrTokenNotConfigured, fmt.Errorf("%s: messages carry up to %d distinct tokens, only %d are configured", field, composition.MaxTokens, n))
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package ccip
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const compositionTokens = `
+[Tokens.AAA]
+[Tokens.BBB]
+[Tokens.CCC]
+`
+
+func TestGetMessageComposition(t *testing.T) {
+	lane := ResolvedLane{Source: "geth-testnet", Dest: "geth-devnet-2", SourceSelector: 3379446385462418246, DestSelector: 12922642891491394802}
+
+	var cfg Config
+	require.Equal(t, ResolvedMessageComposition{Strategy: TOKEN_SELECTION_ROUND_ROBIN, IncludeDataWithTokens: true}, cfg.GetMessageComposition(lane))
+
+	require.NoError(t, toml.Unmarshal([]byte(`
+[MessageComposition]
+TokensPerMessage = { Min = 1, Max = 3 }
+TokenSelectionStrategy = 'random'
+
+[MessageComposition.PerLane.'geth-testnet->geth-devnet-2']
+TokensPerMessage = { Max = 2 }
+IncludeDataWithTokens = false
+`+compositionTokens), &cfg))
+	require.NoError(t, cfg.validateMessageComposition())
+	require.Equal(t, ResolvedMessageComposition{MinTokens: 1, MaxTokens: 2, Strategy: TOKEN_SELECTION_RANDOM}, cfg.GetMessageComposition(lane))
+	other := ResolvedLane{Source: "geth-devnet-2", Dest: "geth-testnet", SourceSelector: 12922642891491394802, DestSelector: 3379446385462418246}
+	require.Equal(t, ResolvedMessageComposition{MinTokens: 1, MaxTokens: 3, Strategy: TOKEN_SELECTION_RANDOM, IncludeDataWithTokens: true}, cfg.GetMessageComposition(other))
+}
+
+func TestComposeMessage(t *testing.T) {
+	lane := ResolvedLane{Source: "geth-testnet", Dest: "geth-devnet-2", SourceSelector: 3379446385462418246, DestSelector: 12922642891491394802}
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(`
+[MessageComposition]
+TokensPerMessage = { Min = 2, Max = 2 }
+`+compositionTokens), &cfg))
+	rng := rand.New(rand.NewSource(1))
+
+	var tokens [][]string
+	for i := 0; i < 3; i++ {
+		msg, err := cfg.ComposeMessage(lane, i, rng)
+		require.NoError(t, err)
+		require.Len(t, msg.Data, DEFAULT_RANDOM_PAYLOAD_BYTES)
+		tokens = append(tokens, msg.Tokens)
+	}
+	require.Equal(t, [][]string{{"AAA", "BBB"}, {"CCC", "AAA"}, {"BBB", "CCC"}}, tokens)
+
+	cfg.MessageComposition.TokenSelectionStrategy = pointer.ToString(TOKEN_SELECTION_FIXED_SET)
+	cfg.MessageComposition.FixedSet = []string{"CCC", "AAA"}
+	cfg.MessageComposition.IncludeDataWithTokens = pointer.ToBool(false)
+	for i := 0; i < 3; i++ {
+		msg, err := cfg.ComposeMessage(lane, i, rng)
+		require.NoError(t, err)
+		require.Equal(t, []string{"CCC", "AAA"}, msg.Tokens)
+		require.Empty(t, msg.Data)
+	}
+
+	cfg.MessageComposition.TokenSelectionStrategy = pointer.ToString(TOKEN_SELECTION_RANDOM)
+	minTokens, maxTokens := uint16(0), uint16(3)
+	cfg.MessageComposition.TokensPerMessage = &TokensPerMessage{Min: &minTokens, Max: &maxTokens}
+	seen := make(map[int]bool)
+	for i := 0; i < 50; i++ {
+		msg, err := cfg.ComposeMessage(lane, i, rng)
+		require.NoError(t, err)
+		seen[len(msg.Tokens)] = true
+		if len(msg.Tokens) == 0 {
+			require.Len(t, msg.Data, DEFAULT_RANDOM_PAYLOAD_BYTES)
+		}
+	}
+	require.Equal(t, map[int]bool{0: true, 1: true, 2: true, 3: true}, seen)
+}
+
+func TestValidateMessageComposition(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		composition string
+		err         string
+	}{
+		{"unknown strategy", "TokenSelectionStrategy = 'all'", `MessageComposition.TokenSelectionStrategy "all" is not one of`},
+		{"min above max", "TokensPerMessage = { Min = 3, Max = 2 }", "MessageComposition.TokensPerMessage: Min 3 must not be greater than Max 2"},
+		{"over limit", "TokensPerMessage = { Max = 11 }", "MessageComposition.TokensPerMessage.Max (11) exceeds MessageLimits.MaxNumberOfTokensPerMsg (10)"},
+		{"not enough tokens", "TokensPerMessage = { Max = 4 }", "MessageComposition: messages carry up to 4 distinct tokens, only 3 are configured"},
+		{"unknown fixed token", "TokenSelectionStrategy = 'fixedSet'\nFixedSet = ['DDD']", "MessageComposition.FixedSet: token DDD is not configured in Tokens"},
+		{"fixed set too small", "TokenSelectionStrategy = 'fixedSet'\nFixedSet = ['AAA']\nTokensPerMessage = { Max = 2 }", "MessageComposition: fixedSet needs 2 tokens on the source chain, only 1 are configured"},
+		{
+			"per lane",
+			"[MessageComposition.PerLane.'geth-testnet->geth-devnet-2']\nTokenSelectionStrategy = 'fixedSet'\nTokensPerMessage = { Max = 5 }",
+			"MessageComposition.PerLane.geth-testnet->geth-devnet-2: fixedSet needs 5 tokens on the source chain, only 3 are configured",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte("[MessageComposition]\n"+tc.composition+"\n"+compositionTokens), &cfg))
+			err := cfg.validateMessageComposition()
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte("[MessageComposition]\nTokensPerMessage = { Max = 11 }\n[MessageLimits]\nAllowOverLimitMessages = true\n"), &cfg))
+	require.ErrorIs(t, cfg.validateMessageComposition(), ErrTokenNotConfigured)
+}
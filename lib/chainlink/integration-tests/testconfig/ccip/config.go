@@ -3,9 +3,12 @@ package ccip
 import (
 	"fmt"
 	"math"
+	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/AlekSi/pointer"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	chainselectors "github.com/smartcontractkit/chain-selectors"
 
 	"github.com/smartcontractkit/chainlink-testing-framework/lib/blockchain"
@@ -34,12 +37,115 @@ var (
 )
 
 type Config struct {
-	PrivateEthereumNetworks map[string]*ctfconfig.EthereumNetworkConfig `toml:",omitempty"`
-	CLNode                  *NodeConfig                                 `toml:",omitempty"`
-	JobDistributorConfig    JDConfig                                    `toml:",omitempty"`
-	HomeChainSelector       *string                                     `toml:",omitempty"`
-	FeedChainSelector       *string                                     `toml:",omitempty"`
-	RMNConfig               RMNConfig                                   `toml:",omitempty"`
+	PrivateEthereumNetworks  map[string]*ctfconfig.EthereumNetworkConfig `toml:",omitempty"`
+	CLNode                   *NodeConfig                                 `toml:",omitempty"`
+	JobDistributorConfig     JDConfig                                    `toml:",omitempty"`
+	HomeChainSelector        *string                                     `toml:",omitempty"`
+	FeedChainSelector        *string                                     `toml:",omitempty"`
+	RMNConfig                RMNConfig                                   `toml:",omitempty"`
+	Tokens                   map[string]*TokenConfig                     `toml:",omitempty"`
+	PriceConfig              *PriceConfig                                `toml:",omitempty"`
+	RateLimits               *RateLimits                                 `toml:",omitempty"`
+	LoadProfile              *LoadProfile                                `toml:",omitempty"`
+	MessageLimits            *MessageLimits                              `toml:",omitempty"`
+	USDCMock                 *USDCMockConfig                             `toml:",omitempty"`
+	ExecutionScenario        *ExecutionScenario                          `toml:",omitempty"`
+	Timeouts                 *Timeouts                                   `toml:",omitempty" fingerprint:"ignore"`
+	Messages                 *Messages                                   `toml:",omitempty"`
+	HomeChainConfig          *HomeChainConfig                            `toml:",omitempty"`
+	ExtraArgs                *ExtraArgs                                  `toml:",omitempty"`
+	Thresholds               *Thresholds                                 `toml:",omitempty" fingerprint:"ignore"`
+	ExecConfig               *ExecConfig                                 `toml:",omitempty"`
+	GasSpikeScenario         *GasSpikeScenario                           `toml:",omitempty"`
+	Receivers                *Receivers                                  `toml:",omitempty"`
+	Observability            *Observability                              `toml:",omitempty" fingerprint:"ignore"`
+	Reporting                *Reporting                                  `toml:",omitempty" fingerprint:"ignore"`
+	Tracing                  *Tracing                                    `toml:",omitempty"`
+	Notifications            *Notifications                              `toml:",omitempty" fingerprint:"ignore"`
+	LogCollection            *LogCollection                              `toml:",omitempty" fingerprint:"ignore"`
+	SethConfig               *SethConfig                                 `toml:",omitempty"`
+	Profiling                *Profiling                                  `toml:",omitempty" fingerprint:"ignore"`
+	Runtime                  *string                                     `toml:",omitempty"`
+	K8sConfig                *K8sConfig                                  `toml:",omitempty"`
+	DockerConfig             *DockerConfig                               `toml:",omitempty"`
+	Lifecycle                *Lifecycle                                  `toml:",omitempty" fingerprint:"ignore"`
+	ExistingContracts        map[string]*ChainContracts                  `toml:",omitempty"`
+	DeployerConfig           map[string]*DeployerConfig                  `toml:",omitempty"`
+	Phases                   []string                                    `toml:",omitempty" fingerprint:"ignore"`
+	FailOnWarnings           *bool                                       `toml:",omitempty" fingerprint:"ignore"`
+	SuppressWarnings         []string                                    `toml:",omitempty" fingerprint:"ignore"`
+	PortRangeStart           *int                                        `toml:",omitempty"`
+	PortRangeEnd             *int                                        `toml:",omitempty"`
+	Mocks                    []*MockServiceConfig                        `toml:",omitempty"`
+	Preset                   *string                                     `toml:",omitempty"`
+	RetryPolicy              *RetryPolicy                                `toml:",omitempty"`
+	Explorer                 map[string]*ExplorerConfig                  `toml:",omitempty"`
+	SenderConfig             *SenderConfig                               `toml:",omitempty"`
+	GasStrategy              map[string]*GasStrategy                     `toml:",omitempty"`
+	ContractVersions         map[string]string                           `toml:",omitempty"`
+	DefaultContractVersion   *string                                     `toml:",omitempty"`
+	AllowMixedVersionLanes   *bool                                       `toml:",omitempty"`
+	JobSpecOverrides         *JobSpecOverrides                           `toml:",omitempty"`
+	HealthChecks             *HealthChecks                               `toml:",omitempty"`
+	FinalityViolation        *FinalityViolationScenario                  `toml:",omitempty"`
+	Resources                *Resources                                  `toml:",omitempty"`
+	AutoSize                 *bool                                       `toml:",omitempty"`
+	OrderingAssertions       map[string]string                           `toml:",omitempty"`
+	DefaultOrdering          *string                                     `toml:",omitempty"`
+	ChainTokens              map[string]*ChainTokens                     `toml:",omitempty"`
+	PluginLogging            *PluginLogging                              `toml:",omitempty"`
+	RandomSeed               *int64                                      `toml:",omitempty" fingerprint:"ignore"`
+	FailureArtifacts         *FailureArtifacts                           `toml:",omitempty" fingerprint:"ignore"`
+	ChainIDRange             *ChainIDRange                               `toml:",omitempty"`
+	WarmUp                   *WarmUp                                     `toml:",omitempty" fingerprint:"ignore"`
+	ConfigRollout            *ConfigRollout                              `toml:",omitempty"`
+	AddressExport            *AddressExport                              `toml:",omitempty" fingerprint:"ignore"`
+	Polling                  *PollingConfig                              `toml:",omitempty" fingerprint:"ignore"`
+	MessageComposition       *MessageComposition                         `toml:",omitempty"`
+	MaxBudget                map[string]string                           `toml:",omitempty" fingerprint:"ignore"`
+	ComponentCriticality     map[string]string                           `toml:",omitempty" fingerprint:"ignore"`
+	ConfigServer             *ConfigServer                               `toml:",omitempty" fingerprint:"ignore"`
+	FundingProfiles          map[string]*FundingProfile                  `toml:",omitempty"`
+	Schedule                 *Schedule                                   `toml:",omitempty" fingerprint:"ignore"`
+	DeploymentConfig         *DeploymentConfig                           `toml:",omitempty" fingerprint:"ignore"`
+	FailOnIncompatible       *bool                                       `toml:",omitempty" fingerprint:"ignore"`
+	Metadata                 map[string]string                           `toml:",omitempty" fingerprint:"ignore"`
+	RunID                    *string                                     `toml:",omitempty" fingerprint:"ignore"`
+	PriceManipulation        *PriceManipulationScenario                  `toml:",omitempty"`
+	ChainSemantics           map[string]*ChainSemantics                  `toml:",omitempty" fingerprint:"ignore"`
+	ChainSnapshots           *ChainSnapshots                             `toml:",omitempty" fingerprint:"ignore"`
+	AssertionSampling        *AssertionSampling                          `toml:",omitempty" fingerprint:"ignore"`
+	TransportPreferences     *TransportPreferences                       `toml:",omitempty" fingerprint:"ignore"`
+	StartupOrder             []string                                    `toml:",omitempty" fingerprint:"ignore"`
+	AssertionSource          *string                                     `toml:",omitempty" fingerprint:"ignore"`
+	AssertionSourcePerChain  map[string]*ChainAssertionSource            `toml:",omitempty" fingerprint:"ignore"`
+	LatencyModel             *LatencyModel                               `toml:",omitempty" fingerprint:"ignore"`
+	ChainHalt                *ChainHaltScenario                          `toml:",omitempty"`
+	Heartbeat                *Heartbeat                                  `toml:",omitempty" fingerprint:"ignore"`
+	ABIOverrides             map[string]string                           `toml:",omitempty" fingerprint:"ignore"`
+	Remediation              *Remediation                                `toml:",omitempty" fingerprint:"ignore"`
+	MidTestTokenOnboarding   []ScheduledToken                            `toml:",omitempty"`
+	CollectVersions          *bool                                       `toml:",omitempty" fingerprint:"ignore"`
+	CommitAssertions         *CommitAssertions                           `toml:",omitempty" fingerprint:"ignore"`
+	ConfirmationDepth        map[string]uint64                           `toml:",omitempty"`
+	DefaultConfirmationDepth *uint64                                     `toml:",omitempty"`
+	TeardownVerification     *TeardownVerification                       `toml:",omitempty" fingerprint:"ignore"`
+	RPCBudget                map[string]RPCBudgetConfig                  `toml:",omitempty" fingerprint:"ignore"`
+
+	// sizingDecisions are the fields filled in by ApplyAutoSize
+	sizingDecisions []SizingDecision
+	// networksBySelector caches NetworksBySelector
+	networksBySelector map[uint64]*ctfconfig.EthereumNetworkConfig
+	// containerNames are the container names per scrape job, see RecordContainerNames
+	containerNames map[string][]string
+	// deployedContracts is the address book of the environment, see RecordDeployedContracts
+	deployedContracts map[uint64]map[string]DeployedContract
+	// proposedJobs are the job IDs per node, see RecordProposedJobs
+	proposedJobs map[string][]string
+	// overrides is the stack of PushOverride
+	overrides []*pushedOverride
+	// abis caches the ABIOverrides parsed by GetABI
+	abis map[string]*abi.ABI
 }
 
 type RMNConfig struct {
@@ -48,6 +154,10 @@ type RMNConfig struct {
 	ProxyVersion *string `toml:",omitempty"`
 	AFNImage     *string `toml:",omitempty"`
 	AFNVersion   *string `toml:",omitempty"`
+	MetricsPort  *int    `toml:",omitempty"`
+
+	CurseConfig   *CurseConfig   `toml:",omitempty"`
+	CurseRecovery *CurseRecovery `toml:",omitempty"`
 }
 
 func (r *RMNConfig) GetProxyImage() string {
@@ -86,15 +196,36 @@ type NodeConfig struct {
 	NoOfPluginNodes *int                        `toml:",omitempty"`
 	NoOfBootstraps  *int                        `toml:",omitempty"`
 	ClientConfig    *nodeclient.ChainlinkConfig `toml:",omitempty"`
+	DONConfig       *DONConfig                  `toml:",omitempty"`
+	MetricsPort     *int                        `toml:",omitempty"`
+	EnablePprof     *bool                       `toml:",omitempty"`
+	// FundingAmounts is what every node is funded with on each chain
+	FundingAmounts *Funding `toml:",omitempty"`
+	// Version is the node image version, only read by CheckCompatibility. The image itself is set by the
+	// top level ChainlinkImage.
+	Version           *string            `toml:",omitempty" fingerprint:"ignore"`
+	BootstrapFailover *BootstrapFailover `toml:",omitempty"`
+}
+
+// GetNoOfPluginNodes returns NoOfPluginNodes, derived from DONConfig when it's not set.
+func (n *NodeConfig) GetNoOfPluginNodes() int {
+	if n == nil {
+		return 0
+	}
+	if n.NoOfPluginNodes == nil && n.DONConfig != nil {
+		return n.DONConfig.RequiredNodes()
+	}
+	return pointer.GetInt(n.NoOfPluginNodes)
 }
 
 type JDConfig struct {
-	Image     *string `toml:",omitempty"`
-	Version   *string `toml:",omitempty"`
-	DBName    *string `toml:",omitempty"`
-	DBVersion *string `toml:",omitempty"`
-	JDGRPC    *string `toml:",omitempty"`
-	JDWSRPC   *string `toml:",omitempty"`
+	Image       *string `toml:",omitempty"`
+	Version     *string `toml:",omitempty"`
+	DBName      *string `toml:",omitempty"`
+	DBVersion   *string `toml:",omitempty"`
+	JDGRPC      *string `toml:",omitempty"`
+	JDWSRPC     *string `toml:",omitempty"`
+	MetricsPort *int    `toml:",omitempty"`
 }
 
 // TODO: include all JD specific input in generic secret handling
@@ -114,6 +245,12 @@ func (o *JDConfig) GetJDWSRPC() string {
 	return wsrpc
 }
 
+// IsStartedByEnvironment returns true if the environment starts its own job distributor, which it
+// does unless both JDGRPC and JDWSRPC of an existing one are given.
+func (o *JDConfig) IsStartedByEnvironment() bool {
+	return o.GetJDGRPC() == "" || o.GetJDWSRPC() == ""
+}
+
 func (o *JDConfig) GetJDImage() string {
 	image := pointer.GetString(o.Image)
 	if image == "" {
@@ -147,37 +284,322 @@ func (o *JDConfig) GetJDDBVersion() string {
 }
 
 func (o *Config) Validate() error {
+	for _, rule := range validationRules {
+		if err := rule.validate(o); err != nil {
+			return invalidValue(err)
+		}
+	}
 	return nil
 }
 
-func (o *Config) GetHomeChainSelector(evmNetworks []blockchain.EVMNetwork) (uint64, error) {
-	homeChainSelector, err := strconv.ParseUint(pointer.GetString(o.HomeChainSelector), 10, 64)
-	if err != nil {
-		return 0, err
+// Revalidate re-runs only the checks of Validate reading any of the changed field paths, e.g.
+// "CLNode.NoOfPluginNodes", for a config changed in code after it was validated. Without paths it
+// runs Validate. It drops the networks cached by NetworksBySelector and the ABIs cached by GetABI.
+func (o *Config) Revalidate(changedPaths ...string) error {
+	o.networksBySelector = nil
+	o.abis = nil
+	if len(changedPaths) == 0 {
+		return o.Validate()
 	}
-	isValid, err := IsSelectorValid(homeChainSelector, evmNetworks)
-	if err != nil {
-		return 0, err
+	changed := make(map[string]bool, len(changedPaths))
+	for _, path := range changedPaths {
+		field := strings.FieldsFunc(path, func(r rune) bool { return r == '.' || r == '[' })
+		if len(field) == 0 {
+			return fmt.Errorf("Revalidate: empty field path")
+		}
+		if _, ok := reflect.TypeOf(Config{}).FieldByName(field[0]); !ok {
+			return fmt.Errorf("Revalidate: %q is not a Config field path", path)
+		}
+		changed[field[0]] = true
 	}
-	if !isValid {
-		return 0, ErrInvalidHomeChainSelector
+	for _, rule := range validationRules {
+		if !rule.reads(changed) {
+			continue
+		}
+		if err := rule.validate(o); err != nil {
+			return invalidValue(err)
+		}
+	}
+	return nil
+}
+
+// validationRule is one of the checks of Validate with the top level Config fields it reads, Revalidate
+// re-runs it when any of them changed. A rule without fields reads the whole config.
+type validationRule struct {
+	fields   []string
+	validate func(o *Config) error
+}
+
+func (r validationRule) reads(changed map[string]bool) bool {
+	if len(r.fields) == 0 {
+		return true
+	}
+	for _, field := range r.fields {
+		if changed[field] {
+			return true
+		}
 	}
-	return homeChainSelector, nil
+	return false
 }
 
+// validationRules are the checks of Validate in the order they run.
+var validationRules = []validationRule{
+	{[]string{"Tokens", "PrivateEthereumNetworks"}, func(o *Config) error {
+		for symbol, token := range o.Tokens {
+			if err := token.Validate(symbol, o.fundedGenesisAccounts()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}},
+	{[]string{"PriceConfig", "Tokens"}, func(o *Config) error {
+		if o.PriceConfig == nil {
+			return nil
+		}
+		return o.PriceConfig.Validate(o.Tokens)
+	}},
+	{[]string{"RateLimits", "Tokens", "PrivateEthereumNetworks"}, (*Config).validateRateLimits},
+	{[]string{"LoadProfile", "RateLimits", "Tokens", "PrivateEthereumNetworks"}, func(o *Config) error {
+		if o.LoadProfile == nil {
+			return nil
+		}
+		if err := o.LoadProfile.Validate(); err != nil {
+			return err
+		}
+		if o.RateLimits != nil {
+			o.RateLimits.warnIfExceededBy(o.LoadProfile, o.Tokens, o.rateLimitLanes())
+		}
+		return nil
+	}},
+	{[]string{"MessageLimits"}, func(o *Config) error {
+		if o.MessageLimits == nil {
+			return nil
+		}
+		return o.MessageLimits.Validate()
+	}},
+	{[]string{"MessageLimits", "LoadProfile"}, func(o *Config) error {
+		return o.MessageLimits.validateLoadProfile(o.LoadProfile)
+	}},
+	{[]string{"USDCMock", "Tokens"}, func(o *Config) error {
+		if o.USDCMock == nil {
+			return nil
+		}
+		return o.USDCMock.Validate(o.Tokens)
+	}},
+	{[]string{"ExecutionScenario"}, func(o *Config) error {
+		if o.ExecutionScenario == nil {
+			return nil
+		}
+		return o.ExecutionScenario.Validate()
+	}},
+	{[]string{"Timeouts", "PrivateEthereumNetworks"}, func(o *Config) error {
+		if o.Timeouts == nil {
+			return nil
+		}
+		return o.Timeouts.Validate(o.PrivateEthereumNetworks)
+	}},
+	{[]string{"Messages", "MessageLimits"}, func(o *Config) error {
+		if o.Messages == nil {
+			return nil
+		}
+		return o.Messages.Validate(o.MessageLimits)
+	}},
+	{[]string{"CLNode", "HomeChainConfig"}, func(o *Config) error {
+		if o.CLNode == nil || o.CLNode.DONConfig == nil {
+			return nil
+		}
+		return o.CLNode.DONConfig.Validate(o.CLNode.NoOfPluginNodes, o.HomeChainConfig)
+	}},
+	{[]string{"CLNode", "PrivateEthereumNetworks"}, func(o *Config) error {
+		if o.CLNode == nil || o.CLNode.ClientConfig == nil {
+			return nil
+		}
+		return o.ValidateClientConfigForCCIP(o.CLNode.ClientConfig, o.privateEVMNetworks())
+	}},
+	{[]string{"HomeChainConfig", "CLNode"}, func(o *Config) error {
+		if o.HomeChainConfig == nil {
+			return nil
+		}
+		return o.HomeChainConfig.Validate(o.donSizes())
+	}},
+	{[]string{"ExtraArgs", "PrivateEthereumNetworks"}, (*Config).validateExtraArgs},
+	{[]string{"PrivateEthereumNetworks", "RMNConfig", "Timeouts"}, (*Config).validateCurse},
+	{[]string{"Thresholds", "LoadProfile"}, func(o *Config) error {
+		if o.Thresholds == nil {
+			return nil
+		}
+		return o.Thresholds.Validate(o.LoadProfile)
+	}},
+	{[]string{"ExecConfig"}, func(o *Config) error {
+		if o.ExecConfig == nil {
+			return nil
+		}
+		return o.ExecConfig.Validate()
+	}},
+	{[]string{"GasSpikeScenario", "ExecConfig", "Timeouts", "PrivateEthereumNetworks"}, (*Config).validateGasSpike},
+	{[]string{"Receivers", "PrivateEthereumNetworks"}, (*Config).validateReceivers},
+	{[]string{"Observability"}, func(o *Config) error {
+		if o.Observability == nil {
+			return nil
+		}
+		return o.Observability.Validate()
+	}},
+	{[]string{"Reporting"}, func(o *Config) error {
+		if o.Reporting == nil {
+			return nil
+		}
+		return o.Reporting.Validate()
+	}},
+	{[]string{"Tracing"}, func(o *Config) error {
+		if o.Tracing == nil {
+			return nil
+		}
+		return o.Tracing.Validate()
+	}},
+	{[]string{"Notifications"}, func(o *Config) error {
+		if o.Notifications == nil {
+			return nil
+		}
+		return o.Notifications.Validate()
+	}},
+	{[]string{"LogCollection", "Observability"}, func(o *Config) error {
+		if o.LogCollection == nil {
+			return nil
+		}
+		return o.LogCollection.Validate(o.Observability)
+	}},
+	{[]string{"SethConfig", "PrivateEthereumNetworks"}, (*Config).validateSethConfig},
+	{[]string{"Profiling", "CLNode"}, func(o *Config) error {
+		if o.Profiling == nil {
+			return nil
+		}
+		return o.Profiling.Validate(o.CLNode)
+	}},
+	{[]string{"Runtime", "DockerConfig", "K8sConfig"}, (*Config).validateRuntime},
+	{[]string{"Lifecycle"}, (*Config).validateLifecycle},
+	{[]string{"ExistingContracts", "PrivateEthereumNetworks"}, (*Config).validateExistingContracts},
+	{[]string{"DeployerConfig", "PrivateEthereumNetworks"}, (*Config).validateDeployerConfig},
+	{[]string{"Phases", "ExistingContracts", "Lifecycle"}, (*Config).validatePhases},
+	{[]string{"Mocks"}, (*Config).validateMocks},
+	{[]string{"PortRangeStart", "PortRangeEnd", "CLNode", "JobDistributorConfig", "Mocks", "RMNConfig", "USDCMock", "ConfigServer", "Observability"}, (*Config).validatePorts},
+	{[]string{"Preset"}, (*Config).validatePreset},
+	{[]string{"RetryPolicy"}, (*Config).validateRetryPolicy},
+	{[]string{"Explorer", "PrivateEthereumNetworks"}, (*Config).validateExplorer},
+	{[]string{"SenderConfig", "LoadProfile", "FundingProfiles", "PrivateEthereumNetworks"}, (*Config).validateSenderConfig},
+	{[]string{"GasStrategy", "PrivateEthereumNetworks"}, (*Config).validateGasStrategy},
+	{[]string{"ContractVersions", "DefaultContractVersion", "AllowMixedVersionLanes", "PrivateEthereumNetworks"}, (*Config).validateContractVersions},
+	{[]string{"JobSpecOverrides"}, (*Config).validateJobSpecOverrides},
+	{[]string{"HealthChecks"}, (*Config).validateHealthChecks},
+	{[]string{"FinalityViolation", "RMNConfig", "PrivateEthereumNetworks"}, (*Config).validateFinalityViolation},
+	{[]string{"Resources"}, (*Config).validateResources},
+	{[]string{"AutoSize", "LoadProfile"}, (*Config).validateAutoSize},
+	{[]string{"OrderingAssertions", "DefaultOrdering", "ExtraArgs", "PrivateEthereumNetworks"}, (*Config).validateOrderingAssertions},
+	{[]string{"ChainTokens", "PrivateEthereumNetworks"}, (*Config).validateChainTokens},
+	{[]string{"PluginLogging"}, (*Config).validatePluginLogging},
+	{[]string{"FailureArtifacts"}, func(o *Config) error {
+		if o.FailureArtifacts == nil {
+			return nil
+		}
+		return o.FailureArtifacts.Validate()
+	}},
+	{[]string{"ChainIDRange"}, (*Config).validateChainIDRange},
+	{[]string{"WarmUp", "LoadProfile", "Timeouts"}, (*Config).validateWarmUp},
+	{[]string{"ConfigRollout", "HomeChainConfig", "LoadProfile", "Timeouts"}, (*Config).validateConfigRollout},
+	{[]string{"AddressExport"}, (*Config).validateAddressExport},
+	{[]string{"Polling", "PrivateEthereumNetworks", "ChainSemantics"}, (*Config).validatePolling},
+	{[]string{"MessageComposition", "MessageLimits", "Tokens", "PrivateEthereumNetworks"}, (*Config).validateMessageComposition},
+	{[]string{"MaxBudget", "ExtraArgs", "GasStrategy", "Heartbeat", "LoadProfile", "PriceConfig", "PrivateEthereumNetworks"}, (*Config).validateMaxBudget},
+	{[]string{"ComponentCriticality"}, (*Config).validateComponentCriticality},
+	{[]string{"ConfigServer"}, (*Config).validateConfigServer},
+	{[]string{"FundingProfiles", "CLNode", "SenderConfig", "DeployerConfig", "LoadProfile", "PrivateEthereumNetworks"}, (*Config).validateFunding},
+	{[]string{"Schedule", "LoadProfile", "Timeouts"}, (*Config).validateSchedule},
+	{[]string{"DeploymentConfig", "SenderConfig"}, (*Config).validateDeploymentConfig},
+	{[]string{"FailOnIncompatible", "CLNode", "JobDistributorConfig", "RMNConfig"}, (*Config).validateCompatibility},
+	{[]string{"Metadata", "RunID"}, (*Config).validateMetadata},
+	{[]string{"PriceManipulation", "PrivateEthereumNetworks", "Timeouts"}, (*Config).validatePriceManipulation},
+	{[]string{"ChainSemantics", "PrivateEthereumNetworks", "Timeouts", "LatencyModel", "RMNConfig"}, (*Config).validateChainSemantics},
+	{[]string{"CLNode", "Timeouts"}, (*Config).validateBootstrapFailover},
+	{[]string{"ChainSnapshots", "PrivateEthereumNetworks", "HomeChainSelector", "FeedChainSelector"}, (*Config).validateChainSnapshots},
+	{[]string{"AssertionSampling", "LoadProfile"}, (*Config).validateAssertionSampling},
+	{[]string{"TransportPreferences"}, (*Config).validateTransportPreferences},
+	{[]string{"Tokens", "PrivateEthereumNetworks", "USDCMock"}, (*Config).validatePoolTypes},
+	{[]string{"Tokens", "PrivateEthereumNetworks", "LoadProfile"}, (*Config).validateTokenDecimals},
+	{[]string{"StartupOrder", "RMNConfig"}, (*Config).validateStartupOrder},
+	{[]string{"AssertionSource", "AssertionSourcePerChain", "PrivateEthereumNetworks"}, (*Config).validateAssertionSource},
+	{[]string{"LatencyModel", "PrivateEthereumNetworks", "ChainSemantics", "RMNConfig"}, (*Config).validateLatencyModel},
+	{[]string{"ChainHalt", "PrivateEthereumNetworks", "Timeouts"}, (*Config).validateChainHalt},
+	{[]string{"Heartbeat", "PrivateEthereumNetworks"}, (*Config).validateHeartbeat},
+	{[]string{"ABIOverrides"}, (*Config).validateABIOverrides},
+	{[]string{"Remediation", "MessageLimits", "ExtraArgs", "PrivateEthereumNetworks"}, (*Config).validateRemediation},
+	{[]string{"MidTestTokenOnboarding", "Tokens", "USDCMock", "LoadProfile", "PrivateEthereumNetworks"}, (*Config).validateMidTestTokenOnboarding},
+	{[]string{"CommitAssertions", "LoadProfile", "ExecConfig", "PrivateEthereumNetworks"}, (*Config).validateCommitAssertions},
+	{[]string{"ConfirmationDepth", "DefaultConfirmationDepth", "PrivateEthereumNetworks"}, (*Config).validateConfirmationDepth},
+	{[]string{"TeardownVerification", "PrivateEthereumNetworks"}, (*Config).validateTeardownVerification},
+	{[]string{"RPCBudget", "LoadProfile", "AssertionSampling", "PrivateEthereumNetworks"}, (*Config).validateRPCBudget},
+	// the lint rules read the whole config
+	{nil, (*Config).validateLint},
+}
+
+// donSizes returns the number of plugin nodes serving each DON family.
+func (o *Config) donSizes() map[string]int {
+	sizes := make(map[string]int)
+	for _, family := range o.HomeChainConfig.GetDONFamilies() {
+		sizes[family] = o.CLNode.GetNoOfPluginNodes()
+	}
+	if o.CLNode != nil && o.CLNode.DONConfig != nil {
+		sizes[DON_FAMILY_COMMIT] = pointer.GetInt(o.CLNode.DONConfig.CommitNodes)
+		sizes[DON_FAMILY_EXEC] = pointer.GetInt(o.CLNode.DONConfig.ExecNodes)
+	}
+	return sizes
+}
+
+// GetHomeChainSelector returns HomeChainSelector, verifying it's one of evmNetworks.
+func (o *Config) GetHomeChainSelector(evmNetworks []blockchain.EVMNetwork) (uint64, error) {
+	return configuredChainSelector(o.HomeChainSelector, evmNetworks, ErrInvalidHomeChainSelector)
+}
+
+// GetFeedChainSelector returns FeedChainSelector, verifying it's one of evmNetworks.
 func (o *Config) GetFeedChainSelector(evmNetworks []blockchain.EVMNetwork) (uint64, error) {
-	feedChainSelector, err := strconv.ParseUint(pointer.GetString(o.FeedChainSelector), 10, 64)
+	return configuredChainSelector(o.FeedChainSelector, evmNetworks, ErrInvalidFeedChainSelector)
+}
+
+// ParseHomeChainSelector returns HomeChainSelector, only verifying it's a chain-selectors chain. It's
+// weaker than GetHomeChainSelector, the chain may not be part of the test.
+func (o *Config) ParseHomeChainSelector() (uint64, error) {
+	return parseChainSelector(o.HomeChainSelector, ErrInvalidHomeChainSelector)
+}
+
+// ParseFeedChainSelector returns FeedChainSelector, only verifying it's a chain-selectors chain. It's
+// weaker than GetFeedChainSelector, the chain may not be part of the test.
+func (o *Config) ParseFeedChainSelector() (uint64, error) {
+	return parseChainSelector(o.FeedChainSelector, ErrInvalidFeedChainSelector)
+}
+
+func configuredChainSelector(value *string, evmNetworks []blockchain.EVMNetwork, kind error) (uint64, error) {
+	selector, err := parseChainSelector(value, kind)
 	if err != nil {
 		return 0, err
 	}
-	isValid, err := IsSelectorValid(feedChainSelector, evmNetworks)
+	isValid, err := IsSelectorValid(selector, evmNetworks)
 	if err != nil {
-		return 0, err
+		return 0, withKind(kind, err)
 	}
 	if !isValid {
-		return 0, ErrInvalidFeedChainSelector
+		return 0, kind
+	}
+	return selector, nil
+}
+
+// parseChainSelector is the one parse path of the home and feed chain selectors.
+func parseChainSelector(value *string, kind error) (uint64, error) {
+	selector, err := strconv.ParseUint(pointer.GetString(value), 10, 64)
+	if err != nil {
+		return 0, withKind(kind, err)
+	}
+	if _, err := chainselectors.ChainIdFromSelector(selector); err != nil {
+		return 0, withKind(kind, err)
 	}
-	return feedChainSelector, nil
+	return selector, nil
 }
 
 func IsSelectorValid(selector uint64, evmNetworks []blockchain.EVMNetwork) (bool, error) {
@@ -0,0 +1,123 @@
+package ccip
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+)
+
+// DEFAULT_ROLLOUT_MAX_EXEC_GAP is the longest pause between two executions still counted as no downtime.
+const DEFAULT_ROLLOUT_MAX_EXEC_GAP = 2 * time.Minute
+
+// ConfigRollout sets a candidate plugin config in CCIPHome after the active one and promotes it while
+// messages are in flight, after either PromoteAfter or PromoteAfterMessages.
+type ConfigRollout struct {
+	CandidateOCRParams   *OCRParams `toml:",omitempty"`
+	PromoteAfter         *Duration  `toml:",omitempty"`
+	PromoteAfterMessages *int       `toml:",omitempty"`
+	// ExpectZeroDowntime fails the test if executions pause for longer than MaxExecGap around the promotion
+	ExpectZeroDowntime *bool     `toml:",omitempty"`
+	MaxExecGap         *Duration `toml:",omitempty"`
+}
+
+// ConfigRolloutPlan is the resolved rollout the orchestration helper executes.
+type ConfigRolloutPlan struct {
+	Active    ResolvedOCRParams
+	Candidate ResolvedOCRParams
+	// PromoteAfter is 0 when promotion is triggered by PromoteAfterMessages
+	PromoteAfter         time.Duration
+	PromoteAfterMessages int
+	ExpectZeroDowntime   bool
+	MaxExecGap           time.Duration
+}
+
+// GetConfigRollout returns the rollout plan, false if no rollout is configured.
+func (o *Config) GetConfigRollout() (ConfigRolloutPlan, bool) {
+	r := o.ConfigRollout
+	if r == nil {
+		return ConfigRolloutPlan{}, false
+	}
+	plan := ConfigRolloutPlan{
+		Active:               DefaultOCRParams,
+		Candidate:            r.CandidateOCRParams.Resolve(),
+		PromoteAfterMessages: pointer.GetInt(r.PromoteAfterMessages),
+		ExpectZeroDowntime:   pointer.GetBool(r.ExpectZeroDowntime),
+		MaxExecGap:           DEFAULT_ROLLOUT_MAX_EXEC_GAP,
+	}
+	if r.PromoteAfter != nil {
+		plan.PromoteAfter = r.PromoteAfter.Duration
+	}
+	if r.MaxExecGap != nil {
+		plan.MaxExecGap = r.MaxExecGap.Duration
+	}
+	return plan, true
+}
+
+// ShouldPromote returns true once the candidate is due for promotion, given the time since the candidate
+// was set and the number of messages sent since.
+func (p ConfigRolloutPlan) ShouldPromote(elapsed time.Duration, messagesSent int) bool {
+	if p.PromoteAfterMessages > 0 {
+		return messagesSent >= p.PromoteAfterMessages
+	}
+	return elapsed >= p.PromoteAfter
+}
+
+// CheckExecGaps returns an error listing every pause between two consecutive executions longer than
+// MaxExecGap, if ExpectZeroDowntime is set.
+func (p ConfigRolloutPlan) CheckExecGaps(executedAt []time.Time) error {
+	if !p.ExpectZeroDowntime || len(executedAt) < 2 {
+		return nil
+	}
+	sorted := append([]time.Time{}, executedAt...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+	var gaps []string
+	for i := 1; i < len(sorted); i++ {
+		if gap := sorted[i].Sub(sorted[i-1]); gap > p.MaxExecGap {
+			gaps = append(gaps, fmt.Sprintf("%s after %s", gap, sorted[i-1].UTC().Format(time.RFC3339)))
+		}
+	}
+	if len(gaps) > 0 {
+		return fmt.Errorf("expected zero downtime, but executions paused for longer than %s: %s", p.MaxExecGap, strings.Join(gaps, ", "))
+	}
+	return nil
+}
+
+func (o *Config) validateConfigRollout() error {
+	r := o.ConfigRollout
+	if r == nil {
+		return nil
+	}
+	if o.HomeChainConfig.IsCandidateConfigOnly() {
+		return fmt.Errorf("ConfigRollout promotes a candidate over an active config, it can't be combined with HomeChainConfig.CandidateConfigOnly")
+	}
+	if r.CandidateOCRParams == nil || r.CandidateOCRParams.Resolve() == DefaultOCRParams {
+		return fmt.Errorf("ConfigRollout.CandidateOCRParams must differ from the active OCR params, otherwise the rollout changes nothing")
+	}
+	if (r.PromoteAfter == nil) == (r.PromoteAfterMessages == nil) {
+		return fmt.Errorf("ConfigRollout requires exactly one of PromoteAfter or PromoteAfterMessages")
+	}
+	if r.PromoteAfterMessages != nil && *r.PromoteAfterMessages < 1 {
+		return fmt.Errorf("ConfigRollout.PromoteAfterMessages must be at least 1, got %d", *r.PromoteAfterMessages)
+	}
+	if r.MaxExecGap != nil && r.MaxExecGap.Duration <= 0 {
+		return fmt.Errorf("ConfigRollout.MaxExecGap must be positive")
+	}
+	promoteAfter := time.Duration(0)
+	if r.PromoteAfter != nil {
+		if r.PromoteAfter.Duration <= 0 {
+			return fmt.Errorf("ConfigRollout.PromoteAfter must be positive")
+		}
+		promoteAfter = r.PromoteAfter.Duration
+	} else if mps := o.LoadProfile.GetMessagesPerSecond(); mps > 0 {
+		promoteAfter = time.Duration(float64(*r.PromoteAfterMessages) / mps * float64(time.Second))
+	}
+	// messages sent around the promotion must still get executed before the test times out
+	if deadline := o.Timeouts.GetOverallTestTimeout() - o.Timeouts.GetExecTimeout(); promoteAfter > deadline {
+		return fmt.Errorf("ConfigRollout promotes after %s, but Timeouts.OverallTestTimeout leaves only %s before the last exec timeout",
+			promoteAfter, deadline)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package ccip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetConfigRollout(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(`
+[ConfigRollout]
+PromoteAfterMessages = 20
+ExpectZeroDowntime = true
+MaxExecGap = '90s'
+
+[ConfigRollout.CandidateOCRParams]
+DeltaRound = '5s'
+Rmax = 5
+`), &cfg))
+	require.NoError(t, cfg.validateConfigRollout())
+
+	plan, ok := cfg.GetConfigRollout()
+	require.True(t, ok)
+	require.Equal(t, DefaultOCRParams, plan.Active)
+	require.Equal(t, 5*time.Second, plan.Candidate.DeltaRound)
+	require.Equal(t, uint64(5), plan.Candidate.Rmax)
+	require.Equal(t, DefaultOCRParams.DeltaProgress, plan.Candidate.DeltaProgress)
+	require.False(t, plan.ShouldPromote(time.Hour, 19))
+	require.True(t, plan.ShouldPromote(0, 20))
+
+	_, ok = (&Config{}).GetConfigRollout()
+	require.False(t, ok)
+}
+
+func TestCheckExecGaps(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	executed := []time.Time{start.Add(3 * time.Minute), start, start.Add(30 * time.Second)}
+	plan := ConfigRolloutPlan{ExpectZeroDowntime: true, MaxExecGap: 2 * time.Minute}
+	require.EqualError(t, plan.CheckExecGaps(executed),
+		"expected zero downtime, but executions paused for longer than 2m0s: 2m30s after 2024-01-01T12:00:30Z")
+	plan.MaxExecGap = 3 * time.Minute
+	require.NoError(t, plan.CheckExecGaps(executed))
+	require.NoError(t, ConfigRolloutPlan{MaxExecGap: time.Second}.CheckExecGaps(executed))
+}
+
+func TestValidateConfigRollout(t *testing.T) {
+	candidate := &OCRParams{DeltaRound: &Duration{5 * time.Second}}
+	for _, tc := range []struct {
+		name    string
+		cfg     Config
+		wantErr string
+	}{
+		{
+			name: "identical params",
+			cfg: Config{ConfigRollout: &ConfigRollout{
+				CandidateOCRParams: &OCRParams{DeltaRound: &Duration{DefaultOCRParams.DeltaRound}},
+				PromoteAfter:       &Duration{Duration: time.Minute},
+			}},
+			wantErr: "CandidateOCRParams must differ from the active OCR params",
+		},
+		{
+			name:    "no trigger",
+			cfg:     Config{ConfigRollout: &ConfigRollout{CandidateOCRParams: candidate}},
+			wantErr: "exactly one of PromoteAfter or PromoteAfterMessages",
+		},
+		{
+			name: "candidate only",
+			cfg: Config{
+				HomeChainConfig: &HomeChainConfig{CandidateConfigOnly: pointer.ToBool(true)},
+				ConfigRollout:   &ConfigRollout{CandidateOCRParams: candidate, PromoteAfterMessages: pointer.ToInt(1)},
+			},
+			wantErr: "can't be combined with HomeChainConfig.CandidateConfigOnly",
+		},
+		{
+			name: "promotes too late",
+			cfg: Config{ConfigRollout: &ConfigRollout{
+				CandidateOCRParams: candidate,
+				PromoteAfter:       &Duration{Duration: 26 * time.Minute},
+			}},
+			wantErr: "ConfigRollout promotes after 26m0s, but Timeouts.OverallTestTimeout leaves only 25m0s",
+		},
+		{
+			name: "message count promotes too late",
+			cfg: Config{
+				LoadProfile:   &LoadProfile{MessagesPerSecond: pointer.ToFloat64(1)},
+				ConfigRollout: &ConfigRollout{CandidateOCRParams: candidate, PromoteAfterMessages: pointer.ToInt(1800)},
+			},
+			wantErr: "ConfigRollout promotes after 30m0s",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.ErrorContains(t, tc.cfg.validateConfigRollout(), tc.wantErr)
+		})
+	}
+}
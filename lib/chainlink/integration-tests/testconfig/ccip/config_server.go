@@ -0,0 +1,232 @@
+package ccip
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/blockchain"
+)
+
+const (
+	PORT_CLAIMANT_CONFIG_SERVER = "ConfigServer.Port"
+
+	// DEFAULT_CONFIG_SERVER_LISTEN_ADDRESS keeps the config server local to the host running the test
+	DEFAULT_CONFIG_SERVER_LISTEN_ADDRESS = "127.0.0.1"
+
+	CONFIG_SERVER_PATH_CONFIG      = "/config"
+	CONFIG_SERVER_PATH_RESOLUTION  = "/resolution"
+	CONFIG_SERVER_PATH_PLAN        = "/plan"
+	CONFIG_SERVER_PATH_FINGERPRINT = "/fingerprint"
+	CONFIG_SERVER_PATH_DIFF        = "/diff"
+
+	// MAX_CANDIDATE_CONFIG_BYTES caps the size of the config POSTed to CONFIG_SERVER_PATH_DIFF
+	MAX_CANDIDATE_CONFIG_BYTES = 1 << 20
+)
+
+// ConfigServer serves the config an environment runs with read-only over HTTP, so it can be looked up
+// while a long-running environment is up. Secrets are always redacted.
+type ConfigServer struct {
+	Enabled *bool `toml:",omitempty"`
+	// Port is assigned by the PortAllocator when unset
+	Port *int `toml:",omitempty"`
+	// ListenAddress is the IP the server binds to, set it to 0.0.0.0 to serve on every interface
+	ListenAddress *string `toml:",omitempty"`
+}
+
+func (c *ConfigServer) IsEnabled() bool {
+	return c != nil && pointer.GetBool(c.Enabled)
+}
+
+func (c *ConfigServer) GetListenAddress() string {
+	if c == nil || c.ListenAddress == nil {
+		return DEFAULT_CONFIG_SERVER_LISTEN_ADDRESS
+	}
+	return *c.ListenAddress
+}
+
+// ConfigResolution is what resolving the config filled in on top of what was configured.
+type ConfigResolution struct {
+	Preset   string           `json:"preset,omitempty"`
+	Sizing   []SizingDecision `json:"sizing"`
+	Warnings []Warning        `json:"warnings"`
+}
+
+// ConfigDiff compares a candidate config to the running one.
+type ConfigDiff struct {
+	// Lines are the TOML lines only in the running config prefixed with "-", and the ones only in the
+	// candidate prefixed with "+"
+	Lines []string `json:"lines"`
+	// FingerprintChanged is whether the candidate describes a different environment, rather than a
+	// different test on the same environment
+	FingerprintChanged bool `json:"fingerprintChanged"`
+}
+
+// GetConfigServerPort returns the configured ConfigServer port, or the one assigned by the PortAllocator.
+func (o *Config) GetConfigServerPort() (int, error) {
+	allocator, err := o.PortAllocator()
+	if err != nil {
+		return 0, err
+	}
+	return allocator.Allocate(PORT_CLAIMANT_CONFIG_SERVER)
+}
+
+// StartConfigServer starts serving ConfigServerHandler on the ConfigServer port. It returns nil if
+// ConfigServer is not enabled, callers close the returned server once the environment is gone.
+func (o *Config) StartConfigServer(evmNetworks []blockchain.EVMNetwork) (*http.Server, error) {
+	if !o.ConfigServer.IsEnabled() {
+		return nil, nil
+	}
+	port, err := o.GetConfigServerPort()
+	if err != nil {
+		return nil, err
+	}
+	port = o.HostPort(port)
+	handler, err := o.ConfigServerHandler(evmNetworks)
+	if err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("tcp", net.JoinHostPort(o.ConfigServer.GetListenAddress(), strconv.Itoa(port)))
+	if err != nil {
+		return nil, fieldError(PORT_CLAIMANT_CONFIG_SERVER, err)
+	}
+	server := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Warn().Err(err).Msg("Config server stopped")
+		}
+	}()
+	log.Info().Str("Address", listener.Addr().String()).Msg("Serving the config")
+	return server, nil
+}
+
+// ConfigServerHandler returns the handler serving the config with its preset and AutoSize applied:
+//   - GET /config the config as WireConfig JSON
+//   - GET /resolution the ConfigResolution
+//   - GET /plan the EnvironmentPlan
+//   - GET /fingerprint the Fingerprint
+//   - POST /diff the ConfigDiff against the TOML config in the request body
+//
+// Every response is JSON and passes the same redaction as failure artifacts.
+func (o *Config) ConfigServerHandler(evmNetworks []blockchain.EVMNetwork) (http.Handler, error) {
+	resolved, err := resolveForServing(o)
+	if err != nil {
+		return nil, err
+	}
+	redactor := newRedactor(resolved)
+	respond := func(w http.ResponseWriter, value any, err error) {
+		if err != nil {
+			http.Error(w, redactor.Replace(err.Error()), http.StatusInternalServerError)
+			return
+		}
+		content, err := json.Marshal(value)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(redactor.Replace(string(content))))
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+CONFIG_SERVER_PATH_CONFIG, func(w http.ResponseWriter, _ *http.Request) {
+		content, err := resolved.ToWire(WireOptions{})
+		respond(w, json.RawMessage(content), err)
+	})
+	mux.HandleFunc("GET "+CONFIG_SERVER_PATH_RESOLUTION, func(w http.ResponseWriter, _ *http.Request) {
+		respond(w, ConfigResolution{
+			Preset:   pointer.GetString(resolved.Preset),
+			Sizing:   resolved.SizingDecisions(),
+			Warnings: resolved.Lint(),
+		}, nil)
+	})
+	mux.HandleFunc("GET "+CONFIG_SERVER_PATH_PLAN, func(w http.ResponseWriter, _ *http.Request) {
+		plan, err := Plan(resolved, evmNetworks)
+		respond(w, plan, err)
+	})
+	mux.HandleFunc("GET "+CONFIG_SERVER_PATH_FINGERPRINT, func(w http.ResponseWriter, _ *http.Request) {
+		fingerprint, err := resolved.Fingerprint()
+		respond(w, map[string]string{"fingerprint": fingerprint}, err)
+	})
+	mux.HandleFunc("POST "+CONFIG_SERVER_PATH_DIFF, func(w http.ResponseWriter, r *http.Request) {
+		content, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MAX_CANDIDATE_CONFIG_BYTES))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		var candidate Config
+		if err := toml.Unmarshal(content, &candidate); err != nil {
+			http.Error(w, fmt.Sprintf("invalid candidate config: %s", err), http.StatusBadRequest)
+			return
+		}
+		diff, err := resolved.diffConfig(&candidate)
+		respond(w, diff, err)
+	})
+	return mux, nil
+}
+
+func resolveForServing(o *Config) (*Config, error) {
+	resolved, err := o.ApplyPreset()
+	if err != nil {
+		return nil, err
+	}
+	return resolved.ApplyAutoSize(), nil
+}
+
+// diffConfig compares the candidate, resolved the same way as the running config, to the config.
+func (o *Config) diffConfig(candidate *Config) (*ConfigDiff, error) {
+	resolved, err := resolveForServing(candidate)
+	if err != nil {
+		return nil, err
+	}
+	running, err := toml.Marshal(o)
+	if err != nil {
+		return nil, err
+	}
+	proposed, err := toml.Marshal(resolved)
+	if err != nil {
+		return nil, err
+	}
+	diff := &ConfigDiff{Lines: []string{}}
+	if changed := diffLines(string(running), string(proposed)); changed != "" {
+		diff.Lines = strings.Split(changed, "\n")
+	}
+	runningFingerprint, err := o.Fingerprint()
+	if err != nil {
+		return nil, err
+	}
+	proposedFingerprint, err := resolved.Fingerprint()
+	if err != nil {
+		return nil, err
+	}
+	diff.FingerprintChanged = runningFingerprint != proposedFingerprint
+	return diff, nil
+}
+
+func (o *Config) validateConfigServer() error {
+	if o.ConfigServer == nil {
+		return nil
+	}
+	if !o.ConfigServer.IsEnabled() {
+		if o.ConfigServer.Port != nil {
+			return fmt.Errorf("ConfigServer.Port only applies when Enabled is set")
+		}
+		if o.ConfigServer.ListenAddress != nil {
+			return fmt.Errorf("ConfigServer.ListenAddress only applies when Enabled is set")
+		}
+		return nil
+	}
+	if address := o.ConfigServer.GetListenAddress(); net.ParseIP(address) == nil {
+		return fmt.Errorf("ConfigServer.ListenAddress must be an IP address, got %q", address)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package ccip
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigServerDisabledByDefault(t *testing.T) {
+	var cfg Config
+	server, err := cfg.StartConfigServer(nil)
+	require.NoError(t, err)
+	require.Nil(t, server)
+
+	cfg.ConfigServer = &ConfigServer{Port: pointer.ToInt(8080)}
+	require.EqualError(t, cfg.validateConfigServer(), "ConfigServer.Port only applies when Enabled is set")
+
+	cfg.ConfigServer = &ConfigServer{ListenAddress: pointer.ToString("0.0.0.0")}
+	require.EqualError(t, cfg.validateConfigServer(), "ConfigServer.ListenAddress only applies when Enabled is set")
+
+	cfg.ConfigServer = &ConfigServer{Enabled: pointer.ToBool(true)}
+	require.NoError(t, cfg.validateConfigServer())
+	require.Equal(t, "127.0.0.1", cfg.ConfigServer.GetListenAddress())
+	port, err := cfg.GetConfigServerPort()
+	require.NoError(t, err)
+	// the ports of the JD the environment starts are drawn first
+	require.Equal(t, DEFAULT_PORT_RANGE_START+2, port)
+
+	cfg.ConfigServer.ListenAddress = pointer.ToString("localhost")
+	require.EqualError(t, cfg.validateConfigServer(), `ConfigServer.ListenAddress must be an IP address, got "localhost"`)
+	cfg.ConfigServer.ListenAddress = pointer.ToString("0.0.0.0")
+	require.NoError(t, cfg.validateConfigServer())
+}
+
+func TestConfigServerHandler(t *testing.T) {
+	for _, envVar := range []string{E2E_JD_IMAGE, E2E_JD_VERSION, E2E_JD_GRPC, E2E_JD_WSRPC,
+		E2E_RMN_RAGEPROXY_IMAGE, E2E_RMN_RAGEPROXY_VERSION, E2E_RMN_AFN2PROXY_IMAGE, E2E_RMN_AFN2PROXY_VERSION} {
+		t.Setenv(envVar, "")
+	}
+	cfg, networks := loadDefaultConfig(t)
+	secret := Secret("loki-basic-auth-value")
+	cfg.Observability = &Observability{LokiBasicAuth: &secret}
+	cfg.ConfigServer = &ConfigServer{Enabled: pointer.ToBool(true)}
+	handler, err := cfg.ConfigServerHandler(networks)
+	require.NoError(t, err)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	get := func(path string) []byte {
+		resp, err := http.Get(server.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+		require.NotContains(t, string(body), string(secret))
+		return body
+	}
+
+	wire := get(CONFIG_SERVER_PATH_CONFIG)
+	require.Contains(t, string(wire), `"LokiBasicAuth":"`+REDACTED_SECRET+`"`)
+	_, err = FromWire(wire)
+	require.NoError(t, err)
+
+	var resolution ConfigResolution
+	require.NoError(t, json.Unmarshal(get(CONFIG_SERVER_PATH_RESOLUTION), &resolution))
+
+	var plan EnvironmentPlan
+	require.NoError(t, json.Unmarshal(get(CONFIG_SERVER_PATH_PLAN), &plan))
+	require.Len(t, plan.Chains, len(networks))
+
+	var fingerprint map[string]string
+	require.NoError(t, json.Unmarshal(get(CONFIG_SERVER_PATH_FINGERPRINT), &fingerprint))
+	want, err := cfg.Fingerprint()
+	require.NoError(t, err)
+	require.Equal(t, want, fingerprint["fingerprint"])
+
+	diff := func(candidate *Config) ConfigDiff {
+		content, err := toml.Marshal(candidate)
+		require.NoError(t, err)
+		resp, err := http.Post(server.URL+CONFIG_SERVER_PATH_DIFF, "application/toml", strings.NewReader(string(content)))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var diff ConfigDiff
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&diff))
+		return diff
+	}
+
+	candidate := mergeConfig(cfg, nil)
+	candidate.ConfigServer = nil
+	// the candidate is sent with its secret redacted, which reads back as unset
+	require.Equal(t, ConfigDiff{Lines: []string{
+		"- LokiBasicAuth = '" + REDACTED_SECRET + "'", "- [ConfigServer]", "- Enabled = true", "- ", "+ LokiBasicAuth = ''",
+	}}, diff(candidate))
+
+	candidate.CLNode.NoOfPluginNodes = pointer.ToInt(8)
+	changed := diff(candidate)
+	require.True(t, changed.FingerprintChanged)
+	require.Contains(t, changed.Lines, "+ NoOfPluginNodes = 8")
+
+	resp, err := http.Post(server.URL+CONFIG_SERVER_PATH_DIFF, "application/toml", strings.NewReader("CLNode = 'not a table'"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,210 @@
+package ccip
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationRulesReadConfigFields(t *testing.T) {
+	for _, rule := range validationRules {
+		for _, field := range rule.fields {
+			_, ok := reflect.TypeOf(Config{}).FieldByName(field)
+			require.True(t, ok, "validation rule reads unknown Config field %s", field)
+		}
+	}
+}
+
+func TestRevalidate(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(`
+[CLNode]
+NoOfPluginNodes = 4
+
+[CLNode.DONConfig]
+CommitNodes = 4
+ExecNodes = 4
+Overlap = 4
+`), &cfg))
+	require.NoError(t, cfg.Validate())
+
+	// a subtest growing the cluster breaks the invariant between NoOfPluginNodes and the DON sizes
+	nodes := 5
+	cfg.CLNode.NoOfPluginNodes = &nodes
+	require.ErrorIs(t, cfg.Revalidate("CLNode.NoOfPluginNodes"), ErrInsufficientNodes)
+	require.ErrorIs(t, cfg.Revalidate(), ErrInsufficientNodes)
+	// checks not reading the changed fields don't run
+	require.NoError(t, cfg.Revalidate("Tokens", "Polling.Interval"))
+
+	cfg.CLNode.DONConfig.ExecNodes = &nodes
+	require.NoError(t, cfg.Revalidate("CLNode.DONConfig.ExecNodes"))
+
+	require.EqualError(t, cfg.Revalidate("CLNodes.NoOfPluginNodes"), `Revalidate: "CLNodes.NoOfPluginNodes" is not a Config field path`)
+}
+
+// revalidateBase is a valid config with the chains, rollup semantics and polling the changes of
+// TestRevalidateMatchesValidate break checks across fields with.
+const revalidateBase = `
+AllowMixedVersionLanes = true
+
+[PrivateEthereumNetworks.SIMULATED_1.EthereumChainConfig]
+chain_id = 1337
+seconds_per_slot = 2
+
+[PrivateEthereumNetworks.SIMULATED_2.EthereumChainConfig]
+chain_id = 2337
+seconds_per_slot = 3
+
+[CLNode]
+NoOfPluginNodes = 4
+Version = '2.18.0'
+
+[JobDistributorConfig]
+Version = '0.9.0'
+
+[HomeChainConfig]
+CandidateConfigOnly = true
+
+[Timeouts]
+OverallTestTimeout = '3h'
+
+[ChainSemantics.SIMULATED_1]
+Type = 'optimistic'
+HardFinalityLag = '1h'
+
+[Polling.PerChain.SIMULATED_1]
+Interval = '2m'
+
+[ContractVersions]
+SIMULATED_1 = '1.5.0'
+`
+
+// revalidateChanges changes every top level Config field, in a way Validate rejects where it
+// checks the field.
+var revalidateChanges = map[string]string{
+	"PrivateEthereumNetworks":  "[PrivateEthereumNetworks.SIMULATED_1.EthereumChainConfig]\nchain_id = 1337\nseconds_per_slot = 600",
+	"CLNode":                   "[CLNode]\nNoOfPluginNodes = 4\nNoOfBootstraps = 0\n[CLNode.BootstrapFailover]\nStandbyBootstraps = 1",
+	"JobDistributorConfig":     "[JobDistributorConfig]\nMetricsPort = 6688",
+	"HomeChainSelector":        "HomeChainSelector = 'unknown'",
+	"FeedChainSelector":        "FeedChainSelector = 'unknown'",
+	"RMNConfig":                "[RMNConfig]\nMetricsPort = 6688",
+	"Tokens":                   "[Tokens.LINK]\nDecimals = 40",
+	"PriceConfig":              "[PriceConfig]\nGasPriceDeviationPPB = 2000000000",
+	"RateLimits":               "[RateLimits.PerLane.SIMULATED_1]\nEnabled = true",
+	"LoadProfile":              "[LoadProfile]\nMessagesPerSecond = -1",
+	"MessageLimits":            "[MessageLimits]\nMaxDataBytes = 0",
+	"USDCMock":                 "[USDCMock]\nFailureRatePct = 101",
+	"ExecutionScenario":        "[ExecutionScenario]\nMode = 'sometimes'",
+	"Timeouts":                 "[Timeouts]\nOverallTestTimeout = '1h'",
+	"Messages":                 "[Messages]\nCountPerLane = 0",
+	"HomeChainConfig":          "[HomeChainConfig]\nDONFamilies = ['']",
+	"ExtraArgs":                "[ExtraArgs.Default]\nVersion = 'v9'",
+	"Thresholds":               "[Thresholds]\nMaxFailedMessagesPct = 1",
+	"ExecConfig":               "[ExecConfig]\nMaxMessagesPerBatch = 0",
+	"GasSpikeScenario":         "[GasSpikeScenario]\nSpikeMultiplier = 2",
+	"Receivers":                "[Receivers.Default]\nMode = 'unknown'",
+	"Observability":            "[Observability]\nLokiEndpoint = 'http://localhost:6688/loki/api/v1/push'",
+	"Reporting":                "[Reporting]\nFormat = 'pdf'",
+	"Tracing":                  "[Tracing]\nInjectIntoNodes = true",
+	"Notifications":            "[Notifications]\nNotifyOn = ['failure']",
+	"LogCollection":            "[LogCollection]\nTargets = ['loki']",
+	"SethConfig":               "[SethConfig.Default]\nGasBumpRetries = -1",
+	"Profiling":                "[Profiling]\nEnabled = true",
+	"Runtime":                  "Runtime = 'k8s'",
+	"K8sConfig":                "[K8sConfig]\nNamespace = 'ccip'",
+	"DockerConfig":             "[DockerConfig]\nPortOffset = -1",
+	"Lifecycle":                "[Lifecycle]\nTTL = '1h'",
+	"ExistingContracts":        "[ExistingContracts.SIMULATED_1]\nRouter = '0x01'",
+	"DeployerConfig":           "[DeployerConfig.SIMULATED_1]\nPrivateKey = '0x01'",
+	"Phases":                   "Phases = ['unknown']",
+	"FailOnWarnings":           "FailOnWarnings = true",
+	"SuppressWarnings":         "SuppressWarnings = ['unknown']",
+	"PortRangeStart":           "PortRangeStart = 30000",
+	"PortRangeEnd":             "PortRangeEnd = 100",
+	"Mocks":                    "[[Mocks]]\nType = 'http'",
+	"Preset":                   "Preset = 'unknown'",
+	"RetryPolicy":              "[RetryPolicy.PerTarget.unknown]\nMaxAttempts = 1",
+	"Explorer":                 "[Explorer.SIMULATED_1]\nVerifyContracts = true",
+	"SenderConfig":             "[SenderConfig]\nFundEachWith = '-1'",
+	"GasStrategy":              "[GasStrategy.SIMULATED_1]\nMode = 'unknown'",
+	"ContractVersions":         "[ContractVersions]\nSIMULATED_1 = '0.1.0'",
+	"DefaultContractVersion":   "DefaultContractVersion = '0.1.0'",
+	"AllowMixedVersionLanes":   "AllowMixedVersionLanes = false",
+	"JobSpecOverrides":         "[JobSpecOverrides]\nCommitTemplateFile = 'testdata/missing.tmpl'",
+	"HealthChecks":             "[HealthChecks.Node]\nSuccessThreshold = 0",
+	"FinalityViolation":        "[FinalityViolation]\nReorgDepthBeyondFinality = 1",
+	"Resources":                "[Resources.Node]\nCPU = -1",
+	"AutoSize":                 "AutoSize = true",
+	"OrderingAssertions":       "[OrderingAssertions]\n'SIMULATED_1->SIMULATED_2' = 'unknown'",
+	"DefaultOrdering":          "DefaultOrdering = 'unknown'",
+	"ChainTokens":              "[ChainTokens.SIMULATED_1]\nLINK = '0x00000000000000000000000000000000000000aa'",
+	"PluginLogging":            "[PluginLogging.PerNode.node-1]\nCommitLogLevel = 'loud'",
+	"RandomSeed":               "RandomSeed = 42",
+	"FailureArtifacts":         "[FailureArtifacts]\nMaxSizeMB = -1",
+	"ChainIDRange":             "[ChainIDRange]\nStart = 0",
+	"WarmUp":                   "[WarmUp]\nMessagesPerLane = 0",
+	"ConfigRollout":            "[ConfigRollout]\nPromoteAfterMessages = 1",
+	"AddressExport":            "[AddressExport]\nFormats = ['xml']",
+	"Polling":                  "[Polling]\nBackoff = 0.5",
+	"MessageComposition":       "[MessageComposition.PerLane.'SIMULATED_1->SIMULATED_2']\nTokenSelectionStrategy = 'unknown'",
+	"MaxBudget":                "[MaxBudget]\nSIMULATED_1 = 'a lot'",
+	"ComponentCriticality":     "[ComponentCriticality]\nunknown = 'critical'",
+	"ConfigServer":             "[ConfigServer]\nPort = 6688",
+	"FundingProfiles":          "[FundingProfiles.nodes.Native]\nSIMULATED_1 = '-1'",
+	"Schedule":                 "[[Schedule.PauseWindows]]\nDays = ['Someday']",
+	"DeploymentConfig":         "[DeploymentConfig]\nMaxConcurrentTxPerChain = 2",
+	"FailOnIncompatible":       "FailOnIncompatible = true",
+	"Metadata":                 "[Metadata]\n__name__ = 'ccip'",
+	"RunID":                    "RunID = '" + strings.Repeat("x", 2048) + "'",
+	"PriceManipulation":        "[PriceManipulation]\nMultiplier = 2",
+	"ChainSemantics":           "[ChainSemantics.SIMULATED_1]\nHardFinalityLag = '3h'",
+	"ChainSnapshots":           "[ChainSnapshots]\nEnabled = true\nSnapshotAfterPhase = 'unknown'",
+	"AssertionSampling":        "[AssertionSampling]\nMode = 'unknown'",
+	"TransportPreferences":     "[TransportPreferences.Default]\nQuery = 'carrier-pigeon'",
+	"StartupOrder":             "StartupOrder = ['unknown']",
+	"AssertionSource":          "AssertionSource = 'unknown'",
+	"AssertionSourcePerChain":  "[AssertionSourcePerChain.SIMULATED_1]\nSource = 'unknown'",
+	"LatencyModel":             "[LatencyModel]\nAdaptive = true\nSourceFinality = '1s'\nSafetyFactor = 1",
+	"ChainHalt":                "[ChainHalt]\nTargetChain = 'SIMULATED_3'",
+	"Heartbeat":                "[Heartbeat]\nEnabled = true\nInterval = '1s'",
+	"ABIOverrides":             "[ABIOverrides]\nUnknown = 'testdata/offramp_abi.json'",
+	"Remediation":              "[Remediation]\nMode = 'pray'",
+	"MidTestTokenOnboarding":   "[[MidTestTokenOnboarding]]\nSymbol = 'LATE'",
+	"CollectVersions":          "CollectVersions = true",
+	"CommitAssertions":         "[CommitAssertions]\nExpectMinBatchSize = 0",
+	"ConfirmationDepth":        "[ConfirmationDepth]\nSIMULATED_1 = 1000",
+	"DefaultConfirmationDepth": "DefaultConfirmationDepth = 1000",
+	"TeardownVerification":     "[TeardownVerification]\nSweepRemainingFunds = true",
+	"RPCBudget":                "[RPCBudget.SIMULATED_1]\nRequestsPerSecond = -1",
+}
+
+// TestRevalidateMatchesValidate changes every top level field of a valid config and checks that
+// revalidating only that field fails like Validate, so a check reading a field its validationRule
+// doesn't list can't be skipped by Revalidate.
+func TestRevalidateMatchesValidate(t *testing.T) {
+	for i := 0; i < reflect.TypeOf(Config{}).NumField(); i++ {
+		field := reflect.TypeOf(Config{}).Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		change, ok := revalidateChanges[field.Name]
+		require.True(t, ok, "revalidateChanges has no change of Config.%s, add one Validate rejects", field.Name)
+		t.Run(field.Name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte(revalidateBase), &cfg))
+			require.NoError(t, cfg.Validate())
+			require.NoError(t, toml.Unmarshal([]byte(change), &cfg))
+
+			want := cfg.Validate()
+			got := cfg.Revalidate(field.Name)
+			if want == nil {
+				require.NoError(t, got)
+				return
+			}
+			require.EqualError(t, got, want.Error())
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package ccip
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// DEFAULT_CONFIRMATION_DEPTH treats a transaction as done once it is mined
+	DEFAULT_CONFIRMATION_DEPTH uint64 = 1
+	// CONFIRMATION_POLL_INTERVAL is how often WaitForConfirmations polls the chain head
+	CONFIRMATION_POLL_INTERVAL = time.Second
+)
+
+// ChainHeadReader reads the latest block number of a chain, like ethclient.Client does.
+type ChainHeadReader interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// GetConfirmationDepth returns the number of blocks a transaction of the harness must be buried under,
+// its own block included, before it counts as done: the chain's ConfirmationDepth, else
+// DefaultConfirmationDepth, else DEFAULT_CONFIRMATION_DEPTH. 0 doesn't wait for the receipt at all.
+func (o *Config) GetConfirmationDepth(selector uint64) uint64 {
+	for ref, depth := range o.ConfirmationDepth {
+		if resolved, err := o.ResolveChainSelector(ref); err == nil && resolved == selector {
+			return depth
+		}
+	}
+	if o.DefaultConfirmationDepth != nil {
+		return *o.DefaultConfirmationDepth
+	}
+	return DEFAULT_CONFIRMATION_DEPTH
+}
+
+// WaitForConfirmations blocks until the transaction mined in minedIn has the confirmation depth of
+// the chain. Send helpers call it with the block of the receipt before treating a send as succeeded.
+func (o *Config) WaitForConfirmations(ctx context.Context, selector uint64, head ChainHeadReader, minedIn uint64) error {
+	depth := o.GetConfirmationDepth(selector)
+	if depth <= 1 {
+		return nil
+	}
+	for {
+		latest, err := head.BlockNumber(ctx)
+		if err != nil {
+			return fmt.Errorf("chain %s: read head for confirmations: %w", chainName(selector), err)
+		}
+		if latest >= minedIn && latest-minedIn+1 >= depth {
+			return nil
+		}
+		if err := sleepUntil(ctx, time.Now().Add(CONFIRMATION_POLL_INTERVAL)); err != nil {
+			return fmt.Errorf("chain %s: block %d isn't %d blocks deep yet: %w", chainName(selector), minedIn, depth, err)
+		}
+	}
+}
+
+// validateConfirmationDepth rejects depths beyond the finality depth of private networks, a final
+// block can't be reorged anyway. The finality depth of live networks isn't known to the config.
+func (o *Config) validateConfirmationDepth() error {
+	refs := make([]string, 0, len(o.ConfirmationDepth))
+	for ref := range o.ConfirmationDepth {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	for _, ref := range refs {
+		selector, err := o.ResolveChainSelector(ref)
+		if err != nil {
+			return fieldError("ConfirmationDepth."+ref, err)
+		}
+		if network := o.privateNetwork(selector); network != nil {
+			if depth, finality := o.ConfirmationDepth[ref], uint64(finalityDepth(network)); depth > finality {
+				return fmt.Errorf("ConfirmationDepth.%s (%d) is above the finality depth of the chain (%d)", ref, depth, finality)
+			}
+		}
+	}
+	if o.DefaultConfirmationDepth == nil {
+		return nil
+	}
+	names := make([]string, 0, len(o.PrivateEthereumNetworks))
+	for name := range o.PrivateEthereumNetworks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		selector, err := o.ResolveChainSelector(name)
+		// private networks without a chain id are reported by the rules checking them
+		if err != nil {
+			continue
+		}
+		if depth, finality := o.GetConfirmationDepth(selector), uint64(finalityDepth(o.PrivateEthereumNetworks[name])); depth > finality {
+			return fmt.Errorf("DefaultConfirmationDepth (%d) is above the finality depth of %s (%d), set ConfirmationDepth.%s", depth, name, finality, name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package ccip
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// SIMULATED_1 is final after 8 blocks, SIMULATED_2 after 1
+const confirmationDepthNetworks = finalityViolationNetworks + `
+[PrivateEthereumNetworks.SIMULATED_2.EthereumChainConfig]
+chain_id = 2337
+`
+
+type fakeChainHead struct {
+	heads []uint64
+}
+
+func (f *fakeChainHead) BlockNumber(context.Context) (uint64, error) {
+	head := f.heads[0]
+	if len(f.heads) > 1 {
+		f.heads = f.heads[1:]
+	}
+	return head, nil
+}
+
+func TestGetConfirmationDepth(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(`
+DefaultConfirmationDepth = 3
+
+[ConfirmationDepth]
+SIMULATED_2 = 0
+`+confirmationDepthNetworks), &cfg))
+	require.NoError(t, cfg.validateConfirmationDepth())
+	require.Equal(t, uint64(3), cfg.GetConfirmationDepth(3379446385462418246))
+	require.Equal(t, uint64(0), cfg.GetConfirmationDepth(12922642891491394802))
+	require.Equal(t, DEFAULT_CONFIRMATION_DEPTH, (&Config{}).GetConfirmationDepth(3379446385462418246))
+
+	// mined in block 10, the third confirmation is block 12
+	head := &fakeChainHead{heads: []uint64{10, 12}}
+	require.NoError(t, cfg.WaitForConfirmations(context.Background(), 3379446385462418246, head, 10))
+	require.Equal(t, []uint64{12}, head.heads)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, cfg.WaitForConfirmations(ctx, 3379446385462418246, &fakeChainHead{heads: []uint64{10}}, 10), context.DeadlineExceeded)
+}
+
+func TestValidateConfirmationDepth(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "above finality", content: "[ConfirmationDepth]\nSIMULATED_1 = 9", err: "ConfirmationDepth.SIMULATED_1 (9) is above the finality depth of the chain (8)"},
+		{name: "default above finality", content: "DefaultConfirmationDepth = 3", err: "DefaultConfirmationDepth (3) is above the finality depth of SIMULATED_2 (1), set ConfirmationDepth.SIMULATED_2"},
+		{name: "unknown chain", content: "[ConfirmationDepth]\nnope = 1", err: "ConfirmationDepth.nope: "},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte(tc.content+"\n"+confirmationDepthNetworks), &cfg))
+			require.ErrorContains(t, cfg.validateConfirmationDepth(), tc.err)
+		})
+	}
+}
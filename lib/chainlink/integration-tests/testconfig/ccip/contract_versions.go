@@ -0,0 +1,80 @@
+package ccip
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	CONTRACT_VERSION_1_5 = "1.5.0"
+	CONTRACT_VERSION_1_6 = "1.6.0"
+
+	DEFAULT_CONTRACT_VERSION = CONTRACT_VERSION_1_6
+)
+
+var supportedContractVersions = []string{CONTRACT_VERSION_1_5, CONTRACT_VERSION_1_6}
+
+// GetDefaultContractVersion returns the CCIP contract version of chains not listed in ContractVersions.
+func (o *Config) GetDefaultContractVersion() string {
+	if version := pointer.GetString(o.DefaultContractVersion); version != "" {
+		return version
+	}
+	return DEFAULT_CONTRACT_VERSION
+}
+
+// GetContractVersion returns the CCIP contract version deployed on the chain.
+func (o *Config) GetContractVersion(selector uint64) string {
+	for ref, version := range o.ContractVersions {
+		if resolved, err := o.ResolveChainSelector(ref); err == nil && resolved == selector {
+			return version
+		}
+	}
+	return o.GetDefaultContractVersion()
+}
+
+func (o *Config) validateContractVersions() error {
+	if !containsString(supportedContractVersions, o.GetDefaultContractVersion()) {
+		return fmt.Errorf("DefaultContractVersion %q is not one of %v", o.GetDefaultContractVersion(), supportedContractVersions)
+	}
+	refs := make([]string, 0, len(o.ContractVersions))
+	for ref := range o.ContractVersions {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	for _, ref := range refs {
+		if _, err := o.ResolveChainSelector(ref); err != nil {
+			return fieldError("ContractVersions."+ref, err)
+		}
+		if !containsString(supportedContractVersions, o.ContractVersions[ref]) {
+			return fmt.Errorf("ContractVersions.%s %q is not one of %v", ref, o.ContractVersions[ref], supportedContractVersions)
+		}
+	}
+	if pointer.GetBool(o.AllowMixedVersionLanes) {
+		return nil
+	}
+	// every pair of chains is a lane, so all chains must run the same version
+	chains := make([]string, 0, len(o.PrivateEthereumNetworks)+len(refs))
+	for name := range o.PrivateEthereumNetworks {
+		chains = append(chains, name)
+	}
+	sort.Strings(chains)
+	chains = append(chains, refs...)
+	versions := make(map[string]string)
+	for _, chain := range chains {
+		selector, err := o.ResolveChainSelector(chain)
+		if err != nil {
+			continue
+		}
+		version := o.GetContractVersion(selector)
+		for other, otherVersion := range versions {
+			if otherVersion != version {
+				return fmt.Errorf("lanes between %s (%s) and %s (%s) mix contract versions, set AllowMixedVersionLanes to deploy them",
+					other, otherVersion, chain, version)
+			}
+		}
+		versions[chain] = version
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const contractVersionsTestTOML = `
+[PrivateEthereumNetworks.SIMULATED_1.EthereumChainConfig]
+chain_id = 1337
+
+[PrivateEthereumNetworks.SIMULATED_2.EthereumChainConfig]
+chain_id = 2337
+
+[ContractVersions]
+SIMULATED_1 = '1.5.0'
+`
+
+func TestContractVersions(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(contractVersionsTestTOML), &cfg))
+	require.Equal(t, CONTRACT_VERSION_1_5, cfg.GetContractVersion(3379446385462418246))
+	require.Equal(t, DEFAULT_CONTRACT_VERSION, cfg.GetContractVersion(12922642891491394802))
+
+	require.ErrorContains(t, cfg.validateContractVersions(), "lanes between SIMULATED_1 (1.5.0) and SIMULATED_2 (1.6.0) mix contract versions")
+	allow := true
+	cfg.AllowMixedVersionLanes = &allow
+	require.NoError(t, cfg.validateContractVersions())
+
+	cfg.ContractVersions["SIMULATED_2"] = "1.4.0"
+	require.ErrorContains(t, cfg.validateContractVersions(), `ContractVersions.SIMULATED_2 "1.4.0" is not one of [1.5.0 1.6.0]`)
+}
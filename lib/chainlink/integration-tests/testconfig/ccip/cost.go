@@ -0,0 +1,296 @@
+package ccip
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	chainselectors "github.com/smartcontractkit/chain-selectors"
+)
+
+const (
+	// COST_CCIP_SEND_GAS and COST_CCIP_SEND_GAS_PER_TOKEN approximate the gas a ccipSend transaction uses on the source chain
+	COST_CCIP_SEND_GAS           uint64 = 250_000
+	COST_CCIP_SEND_GAS_PER_TOKEN uint64 = 50_000
+	// the fee inputs mirror DefaultFeeQuoterDestChainConfig in the ccip deployment changesets
+	COST_DEST_GAS_OVERHEAD         uint64 = 350_000
+	COST_DEST_GAS_PER_TOKEN        uint64 = 125_000
+	COST_DEST_GAS_PER_PAYLOAD_BYTE uint64 = 16
+	COST_GAS_MULTIPLIER_PERCENT           = 110
+	COST_NETWORK_FEE_USD_CENTS            = 1
+	COST_TOKEN_FEE_USD_CENTS              = 1
+
+	// the defaults are assumed when the config has no gas price or USD price to estimate with
+	DEFAULT_COST_GAS_PRICE_GWEI   = 30.0
+	DEFAULT_COST_LINK_PRICE_USD   = 20.0
+	DEFAULT_COST_NATIVE_PRICE_USD = 2000.0
+	COST_NATIVE_PRICE_SYMBOL      = "WETH"
+	COST_LINK_PRICE_SYMBOL        = "LINK"
+)
+
+// ether is 1e18, the wei in an ether and the juels in a LINK
+var ether = big.NewInt(1e18)
+
+// ChainCost is the native and LINK spent on a chain, in wei and juels.
+type ChainCost struct {
+	Selector uint64
+	Name     string
+	Messages int64
+	Native   *big.Int
+	LINK     *big.Int
+	// GasPriceAssumed is set when the chain's GasStrategy has no fixed price or fee cap to estimate
+	// with, and DEFAULT_COST_GAS_PRICE_GWEI was used instead
+	GasPriceAssumed bool
+}
+
+// CostEstimate is the spend LoadProfile is expected to cost on each chain, sorted by selector.
+type CostEstimate struct {
+	Chains []ChainCost
+}
+
+// Get returns the estimate of the chain.
+func (e *CostEstimate) Get(selector uint64) (ChainCost, bool) {
+	if e == nil {
+		return ChainCost{}, false
+	}
+	for _, chain := range e.Chains {
+		if chain.Selector == selector {
+			return chain, true
+		}
+	}
+	return ChainCost{}, false
+}
+
+// EstimateCost estimates what LoadProfile spends on each of the chains, sending on every lane between
+// them. The source chain pays for the ccipSend transactions in native, and the CCIP fee in LINK, which
+// is estimated from the destination's execution gas like the fee quoter computes it:
+//   - ccipSend uses 250k gas plus 50k per token, at the source chain's gas price
+//   - the fee is the ExtraArgs gas limit plus 350k overhead, 125k per token and 16 per payload byte, at
+//     the destination's gas price marked up 10%, plus a 1 cent network fee and 1 cent per token
+//   - gas prices come from the GasStrategy, its fixed price or fee cap, or are assumed to be 30 gwei
+//   - USD prices come from PriceConfig.InitialTokenPricesUSD LINK and WETH, or are assumed to be $20 and $2000
+//   - chains sending heartbeats pay 21k gas per Heartbeat.Interval of the test
+func (o *Config) EstimateCost(selectors []uint64) (*CostEstimate, error) {
+	messagesPerLane := int64(math.Ceil(o.LoadProfile.GetMessagesPerSecond() * o.LoadProfile.GetTestDuration().Seconds()))
+	tokens := uint64(o.LoadProfile.GetTokensPerMessage())
+	linkUSD, err := o.costPriceUSD(COST_LINK_PRICE_SYMBOL, DEFAULT_COST_LINK_PRICE_USD)
+	if err != nil {
+		return nil, err
+	}
+	nativeUSD, err := o.costPriceUSD(COST_NATIVE_PRICE_SYMBOL, DEFAULT_COST_NATIVE_PRICE_USD)
+	if err != nil {
+		return nil, err
+	}
+	sorted := append([]uint64{}, selectors...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	heartbeatChains, err := o.GetHeartbeatChains(sorted)
+	if err != nil {
+		return nil, err
+	}
+	heartbeats := make(map[uint64]bool, len(heartbeatChains))
+	for _, selector := range heartbeatChains {
+		heartbeats[selector] = true
+	}
+	estimate := &CostEstimate{}
+	for _, source := range sorted {
+		gasPrice, assumed := o.costGasPrice(source)
+		cost := ChainCost{
+			Selector:        source,
+			Name:            chainName(source),
+			Native:          new(big.Int),
+			LINK:            new(big.Int),
+			GasPriceAssumed: assumed,
+		}
+		for _, dest := range sorted {
+			if dest == source {
+				continue
+			}
+			cost.Messages += messagesPerLane
+			sendGas := COST_CCIP_SEND_GAS + tokens*COST_CCIP_SEND_GAS_PER_TOKEN
+			cost.Native.Add(cost.Native, new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(sendGas*uint64(messagesPerLane))))
+
+			extraArgs, err := o.GetExtraArgsForDest(dest)
+			if err != nil {
+				return nil, err
+			}
+			destGas := *extraArgs.GasLimit + COST_DEST_GAS_OVERHEAD + tokens*COST_DEST_GAS_PER_TOKEN +
+				uint64(o.LoadProfile.GetMessageSizeBytes())*COST_DEST_GAS_PER_PAYLOAD_BYTE
+			destGasPrice, destAssumed := o.costGasPrice(dest)
+			cost.GasPriceAssumed = cost.GasPriceAssumed || destAssumed
+			// fee in USD = destGas * destGasPrice / 1e18 * nativeUSD * multiplier + cents / 100
+			fee := new(big.Rat).SetInt(new(big.Int).Mul(destGasPrice, new(big.Int).SetUint64(destGas)))
+			fee.Mul(fee, new(big.Rat).SetFloat64(nativeUSD))
+			fee.Mul(fee, big.NewRat(COST_GAS_MULTIPLIER_PERCENT, 100))
+			fee.Quo(fee, new(big.Rat).SetInt(ether))
+			fee.Add(fee, big.NewRat(int64(COST_NETWORK_FEE_USD_CENTS+COST_TOKEN_FEE_USD_CENTS*int(tokens)), 100))
+			// in juels = feeUSD / linkUSD * 1e18
+			fee.Quo(fee, new(big.Rat).SetFloat64(linkUSD))
+			fee.Mul(fee, new(big.Rat).SetInt(new(big.Int).Mul(ether, big.NewInt(messagesPerLane))))
+			cost.LINK.Add(cost.LINK, new(big.Int).Quo(fee.Num(), fee.Denom()))
+		}
+		if heartbeats[source] {
+			heartbeatGas := new(big.Int).SetUint64(HEARTBEAT_GAS)
+			heartbeatGas.Mul(heartbeatGas, big.NewInt(o.heartbeatsPerChain()))
+			cost.Native.Add(cost.Native, heartbeatGas.Mul(heartbeatGas, gasPrice))
+		}
+		estimate.Chains = append(estimate.Chains, cost)
+	}
+	return estimate, nil
+}
+
+// costGasPrice returns the gas price in wei to estimate the chain's spend with.
+func (o *Config) costGasPrice(selector uint64) (*big.Int, bool) {
+	strategy := o.GetGasStrategy(selector)
+	gwei := DEFAULT_COST_GAS_PRICE_GWEI
+	assumed := true
+	switch {
+	case strategy.Mode == GAS_MODE_FIXED && strategy.FixedGasPriceGwei > 0:
+		gwei, assumed = strategy.FixedGasPriceGwei, false
+	case strategy.FeeCapGwei > 0:
+		gwei, assumed = strategy.FeeCapGwei, false
+	}
+	wei, _ := new(big.Float).Mul(big.NewFloat(gwei), big.NewFloat(1e9)).Int(nil)
+	return wei, assumed
+}
+
+func (o *Config) costPriceUSD(symbol string, fallback float64) (float64, error) {
+	price, ok, err := o.PriceConfig.GetInitialTokenPriceUSD(symbol)
+	if err != nil {
+		return 0, fieldError("PriceConfig.InitialTokenPricesUSD", err)
+	}
+	if !ok {
+		return fallback, nil
+	}
+	usd, _ := price.Float64()
+	if usd <= 0 {
+		return 0, fmt.Errorf("PriceConfig.InitialTokenPricesUSD.%s must be positive to estimate costs", symbol)
+	}
+	return usd, nil
+}
+
+func chainName(selector uint64) string {
+	if chain, ok := chainselectors.ChainBySelector(selector); ok {
+		return chain.Name
+	}
+	return fmt.Sprintf("%d", selector)
+}
+
+// formatAmount formats an 18 decimals amount in whole units, e.g. 1500000000000000000 as "1.5".
+func formatAmount(amount *big.Int) string {
+	if amount == nil {
+		return "0"
+	}
+	s := new(big.Rat).SetFrac(amount, ether).FloatString(18)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+func (e *CostEstimate) String() string {
+	var b strings.Builder
+	for _, chain := range e.Chains {
+		fmt.Fprintf(&b, "  %s messages=%d native=%s LINK=%s", chain.Name, chain.Messages, formatAmount(chain.Native), formatAmount(chain.LINK))
+		if chain.GasPriceAssumed {
+			fmt.Fprintf(&b, " (assuming %g gwei)", DEFAULT_COST_GAS_PRICE_GWEI)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// CostTracker adds up what a run actually spends, from the receipts of the transactions it sends. It is
+// safe for concurrent use.
+type CostTracker struct {
+	mu     sync.Mutex
+	chains map[uint64]*ChainCost
+}
+
+func NewCostTracker() *CostTracker {
+	return &CostTracker{chains: make(map[uint64]*ChainCost)}
+}
+
+// RecordReceipt adds a transaction sent on the chain. gasUsed and effectiveGasPrice come from its
+// receipt, linkFee is the CCIP fee paid in LINK, nil or zero for other transactions.
+func (c *CostTracker) RecordReceipt(selector uint64, gasUsed uint64, effectiveGasPrice *big.Int, linkFee *big.Int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	chain, ok := c.chains[selector]
+	if !ok {
+		chain = &ChainCost{Selector: selector, Name: chainName(selector), Native: new(big.Int), LINK: new(big.Int)}
+		c.chains[selector] = chain
+	}
+	if effectiveGasPrice != nil {
+		chain.Native.Add(chain.Native, new(big.Int).Mul(effectiveGasPrice, new(big.Int).SetUint64(gasUsed)))
+	}
+	if linkFee != nil && linkFee.Sign() > 0 {
+		chain.LINK.Add(chain.LINK, linkFee)
+		chain.Messages++
+	}
+}
+
+// Actuals returns the spend recorded so far, sorted by selector.
+func (c *CostTracker) Actuals() []ChainCost {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	actuals := make([]ChainCost, 0, len(c.chains))
+	for _, chain := range c.chains {
+		actual := *chain
+		actual.Native = new(big.Int).Set(chain.Native)
+		actual.LINK = new(big.Int).Set(chain.LINK)
+		actuals = append(actuals, actual)
+	}
+	sort.Slice(actuals, func(i, j int) bool { return actuals[i].Selector < actuals[j].Selector })
+	return actuals
+}
+
+// validateMaxBudget estimates the spend on the chains the config knows of, its private networks and the
+// budgeted chains, and fails if it exceeds the budget of any chain.
+func (o *Config) validateMaxBudget() error {
+	if len(o.MaxBudget) == 0 {
+		return nil
+	}
+	refs := make([]string, 0, len(o.MaxBudget))
+	for ref := range o.MaxBudget {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	budgets := make(map[uint64]*big.Int)
+	seen := make(map[uint64]bool)
+	var selectors []uint64
+	for _, ref := range refs {
+		selector, err := o.ResolveChainSelector(ref)
+		if err != nil {
+			return fieldError("MaxBudget."+ref, err)
+		}
+		budget, err := parseNativeAmount(o.MaxBudget[ref])
+		if err != nil {
+			return fieldError("MaxBudget."+ref, err)
+		}
+		budgets[selector] = budget
+		if !seen[selector] {
+			seen[selector] = true
+			selectors = append(selectors, selector)
+		}
+	}
+	for name := range o.PrivateEthereumNetworks {
+		if selector, err := o.ResolveChainSelector(name); err == nil && !seen[selector] {
+			seen[selector] = true
+			selectors = append(selectors, selector)
+		}
+	}
+	estimate, err := o.EstimateCost(selectors)
+	if err != nil {
+		return err
+	}
+	for _, ref := range refs {
+		selector, _ := o.ResolveChainSelector(ref)
+		cost, _ := estimate.Get(selector)
+		if budget := budgets[selector]; cost.Native.Cmp(budget) > 0 {
+			return fmt.Errorf("MaxBudget.%s: estimated native spend %s exceeds the budget of %s by %s",
+				ref, formatAmount(cost.Native), formatAmount(budget), formatAmount(new(big.Int).Sub(cost.Native, budget)))
+		}
+	}
+	return nil
+}
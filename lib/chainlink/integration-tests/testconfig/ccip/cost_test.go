@@ -0,0 +1,126 @@
+package ccip
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const costConfig = `
+[LoadProfile]
+MessagesPerSecond = 1.0
+TestDuration = '100s'
+
+[GasStrategy.geth-testnet]
+Mode = 'fixed'
+FixedGasPriceGwei = 10.0
+
+[GasStrategy.geth-devnet-2]
+Mode = 'fixed'
+FixedGasPriceGwei = 10.0
+`
+
+func TestEstimateCost(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(costConfig), &cfg))
+	estimate, err := cfg.EstimateCost([]uint64{12922642891491394802, 3379446385462418246})
+	require.NoError(t, err)
+	require.Len(t, estimate.Chains, 2)
+
+	// 100 messages * 250k gas * 10 gwei
+	testnet := estimate.Chains[0]
+	require.Equal(t, uint64(3379446385462418246), testnet.Selector)
+	require.Equal(t, "geth-testnet", testnet.Name)
+	require.Equal(t, int64(100), testnet.Messages)
+	require.Equal(t, "0.25", formatAmount(testnet.Native))
+	// 100 messages * ((200k + 350k) gas * 10 gwei * $2000 * 110% + $0.01) / $20
+	require.Equal(t, "60.55", formatAmount(testnet.LINK))
+	require.False(t, testnet.GasPriceAssumed)
+
+	require.Equal(t, "  geth-testnet messages=100 native=0.25 LINK=60.55\n  geth-devnet-2 messages=100 native=0.25 LINK=60.55\n", estimate.String())
+
+	// without a price the gas price is assumed, and prices come from PriceConfig
+	cfg = Config{}
+	require.NoError(t, toml.Unmarshal([]byte(`
+[LoadProfile]
+MessagesPerSecond = 1.0
+TestDuration = '100s'
+TokensPerMessage = 1
+
+[PriceConfig.InitialTokenPricesUSD]
+LINK = '10'
+`), &cfg))
+	estimate, err = cfg.EstimateCost([]uint64{12922642891491394802, 3379446385462418246})
+	require.NoError(t, err)
+	// 100 messages * 300k gas * 30 gwei
+	require.Equal(t, "0.9", formatAmount(estimate.Chains[0].Native))
+	// 100 messages * ((200k + 350k + 125k) gas * 30 gwei * $2000 * 110% + $0.02) / $10
+	require.Equal(t, "445.7", formatAmount(estimate.Chains[0].LINK))
+	require.True(t, estimate.Chains[0].GasPriceAssumed)
+}
+
+func TestValidateMaxBudget(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(costConfig+`
+[MaxBudget]
+geth-testnet = '0.2'
+
+[PrivateEthereumNetworks.SIMULATED_2.EthereumChainConfig]
+chain_id = 2337
+`), &cfg))
+	require.EqualError(t, cfg.validateMaxBudget(), "MaxBudget.geth-testnet: estimated native spend 0.25 exceeds the budget of 0.2 by 0.05")
+
+	cfg.MaxBudget["geth-testnet"] = "250000000gwei"
+	require.NoError(t, cfg.validateMaxBudget())
+
+	cfg.MaxBudget["geth-testnet"] = "lots"
+	require.ErrorContains(t, cfg.validateMaxBudget(), `MaxBudget.geth-testnet: "lots" is not a decimal amount`)
+}
+
+func TestRevalidateMaxBudgetOnHeartbeat(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(costConfig+`
+[MaxBudget]
+geth-testnet = '0.26'
+
+[PrivateEthereumNetworks.SIMULATED_2.EthereumChainConfig]
+chain_id = 2337
+`), &cfg))
+	require.NoError(t, cfg.validateMaxBudget())
+
+	// heartbeats are paid from the same budget
+	cfg.Heartbeat = &Heartbeat{Enabled: pointer.ToBool(true), Interval: &Duration{Duration: time.Second}}
+	require.ErrorContains(t, cfg.Revalidate("Heartbeat.Interval"), "MaxBudget.geth-testnet: estimated native spend")
+}
+
+func TestCostTracker(t *testing.T) {
+	tracker := NewCostTracker()
+	gwei := big.NewInt(1e9)
+	tracker.RecordReceipt(3379446385462418246, 200_000, gwei, big.NewInt(5e17))
+	tracker.RecordReceipt(3379446385462418246, 100_000, gwei, nil)
+	tracker.RecordReceipt(12922642891491394802, 50_000, gwei, big.NewInt(1e18))
+
+	actuals := tracker.Actuals()
+	require.Len(t, actuals, 2)
+	require.Equal(t, "0.0003", formatAmount(actuals[0].Native))
+	require.Equal(t, "0.5", formatAmount(actuals[0].LINK))
+	require.Equal(t, int64(1), actuals[0].Messages)
+
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(costConfig), &cfg))
+	estimate, err := cfg.EstimateCost([]uint64{3379446385462418246, 12922642891491394802, 4793464827907405086})
+	require.NoError(t, err)
+
+	reporter := NewReporter(nil)
+	reporter.TrackCosts(tracker, estimate)
+	costs := reporter.Report().Costs
+	require.Equal(t, []CostReport{
+		{Chain: "geth-testnet", Selector: 3379446385462418246, EstimatedNative: "0.5", EstimatedLINK: "242.1", ActualNative: "0.0003", ActualLINK: "0.5", ActualMessages: 1},
+		{Chain: "geth-devnet-3", Selector: 4793464827907405086, EstimatedNative: "1.5", EstimatedLINK: "121.1", ActualNative: "0", ActualLINK: "0"},
+		{Chain: "geth-devnet-2", Selector: 12922642891491394802, EstimatedNative: "0.5", EstimatedLINK: "242.1", ActualNative: "0.00005", ActualLINK: "1", ActualMessages: 1},
+	}, costs)
+}
@@ -0,0 +1,99 @@
+package ccip
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	COMPONENT_CHAINS        = "chains"
+	COMPONENT_NODES         = "nodes"
+	COMPONENT_JD            = "jd"
+	COMPONENT_RMN           = "rmn"
+	COMPONENT_OBSERVABILITY = "observability"
+	COMPONENT_MOCKS         = "mocks"
+	COMPONENT_ANNOTATIONS   = "annotations"
+
+	CRITICALITY_REQUIRED = "required"
+	CRITICALITY_OPTIONAL = "optional"
+)
+
+// defaultCriticality keeps everything the test itself runs on required, and lets the run go on without
+// what only helps looking into it.
+var defaultCriticality = map[string]string{
+	COMPONENT_CHAINS:        CRITICALITY_REQUIRED,
+	COMPONENT_NODES:         CRITICALITY_REQUIRED,
+	COMPONENT_JD:            CRITICALITY_REQUIRED,
+	COMPONENT_RMN:           CRITICALITY_REQUIRED,
+	COMPONENT_OBSERVABILITY: CRITICALITY_OPTIONAL,
+	COMPONENT_MOCKS:         CRITICALITY_OPTIONAL,
+	COMPONENT_ANNOTATIONS:   CRITICALITY_OPTIONAL,
+}
+
+// alwaysRequired are the components there is no test without.
+var alwaysRequired = []string{COMPONENT_CHAINS, COMPONENT_NODES}
+
+// IsRequired returns whether failing to start the component, one of the COMPONENT_* constants, fails
+// the run. Unknown components are required.
+func (o *Config) IsRequired(component string) bool {
+	if criticality, ok := o.getComponentCriticality(component); ok {
+		return criticality != CRITICALITY_OPTIONAL
+	}
+	if criticality, ok := defaultCriticality[component]; ok {
+		return criticality == CRITICALITY_REQUIRED
+	}
+	return true
+}
+
+func (o *Config) getComponentCriticality(component string) (string, bool) {
+	if o == nil {
+		return "", false
+	}
+	criticality, ok := o.ComponentCriticality[component]
+	return criticality, ok
+}
+
+// DegradedComponent is an optional component that failed to start, the run went on without it.
+type DegradedComponent struct {
+	Component string `json:"component"`
+	Error     string `json:"error"`
+}
+
+// HandleStartError returns the error a component failed to start with if the component is required.
+// The error of an optional component is logged as a warning and returned as a DegradedComponent instead.
+func (o *Config) HandleStartError(component string, err error) (*DegradedComponent, error) {
+	if o.IsRequired(component) {
+		return nil, fmt.Errorf("starting %s: %w", component, err)
+	}
+	log.Warn().Err(err).Str("Component", component).Msg("Optional component failed to start, continuing without it")
+	return &DegradedComponent{Component: component, Error: err.Error()}, nil
+}
+
+func (o *Config) validateComponentCriticality() error {
+	components := make([]string, 0, len(o.ComponentCriticality))
+	for component := range o.ComponentCriticality {
+		components = append(components, component)
+	}
+	sort.Strings(components)
+	for _, component := range components {
+		criticality := o.ComponentCriticality[component]
+		if _, ok := defaultCriticality[component]; !ok {
+			known := make([]string, 0, len(defaultCriticality))
+			for name := range defaultCriticality {
+				known = append(known, name)
+			}
+			sort.Strings(known)
+			return fmt.Errorf("ComponentCriticality.%s is not a known component, must be one of %s", component, strings.Join(known, ", "))
+		}
+		if criticality != CRITICALITY_REQUIRED && criticality != CRITICALITY_OPTIONAL {
+			return fmt.Errorf("ComponentCriticality.%s must be %q or %q, got %q", component, CRITICALITY_REQUIRED, CRITICALITY_OPTIONAL, criticality)
+		}
+		if criticality == CRITICALITY_OPTIONAL && containsString(alwaysRequired, component) {
+			return fmt.Errorf("ComponentCriticality.%s cannot be %s, the test can't run without it", component, CRITICALITY_OPTIONAL)
+		}
+	}
+	return nil
+}
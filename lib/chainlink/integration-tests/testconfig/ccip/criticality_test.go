@@ -0,0 +1,64 @@
+package ccip
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRequired(t *testing.T) {
+	var nilCfg *Config
+	require.True(t, nilCfg.IsRequired(COMPONENT_NODES))
+	require.False(t, nilCfg.IsRequired(COMPONENT_OBSERVABILITY))
+	require.True(t, nilCfg.IsRequired("unknown"))
+
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(`
+[ComponentCriticality]
+rmn = 'optional'
+observability = 'required'
+`), &cfg))
+	require.NoError(t, cfg.validateComponentCriticality())
+	require.False(t, cfg.IsRequired(COMPONENT_RMN))
+	require.True(t, cfg.IsRequired(COMPONENT_OBSERVABILITY))
+	require.False(t, cfg.IsRequired(COMPONENT_MOCKS))
+	require.True(t, cfg.IsRequired(COMPONENT_JD))
+}
+
+func TestHandleStartError(t *testing.T) {
+	var cfg Config
+	startErr := errors.New("connection refused")
+
+	degraded, err := cfg.HandleStartError(COMPONENT_JD, startErr)
+	require.Nil(t, degraded)
+	require.ErrorIs(t, err, startErr)
+	require.EqualError(t, err, "starting jd: connection refused")
+
+	degraded, err = cfg.HandleStartError(COMPONENT_OBSERVABILITY, startErr)
+	require.NoError(t, err)
+	require.Equal(t, &DegradedComponent{Component: COMPONENT_OBSERVABILITY, Error: "connection refused"}, degraded)
+
+	reporter := NewReporter(nil)
+	reporter.RecordDegradedComponents(*degraded)
+	require.Equal(t, []DegradedComponent{*degraded}, reporter.Report().DegradedComponents)
+}
+
+func TestValidateComponentCriticality(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		criticality map[string]string
+		err         string
+	}{
+		{"unknown component", map[string]string{"grafana": CRITICALITY_OPTIONAL}, "ComponentCriticality.grafana is not a known component"},
+		{"bad value", map[string]string{COMPONENT_RMN: "maybe"}, `ComponentCriticality.rmn must be "required" or "optional", got "maybe"`},
+		{"chains optional", map[string]string{COMPONENT_CHAINS: CRITICALITY_OPTIONAL}, "ComponentCriticality.chains cannot be optional"},
+		{"nodes optional", map[string]string{COMPONENT_NODES: CRITICALITY_OPTIONAL}, "ComponentCriticality.nodes cannot be optional"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := Config{ComponentCriticality: tc.criticality}
+			require.ErrorContains(t, cfg.validateComponentCriticality(), tc.err)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package ccip
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AlekSi/pointer"
+)
+
+const DEFAULT_EXPECT_DRAIN_WITHIN = 10 * time.Minute
+
+// CurseConfig configures RMN cursing of chains during the test.
+type CurseConfig struct {
+	// Chains to curse, by network name or selector
+	Chains     []string  `toml:",omitempty"`
+	CurseAfter *Duration `toml:",omitempty"`
+}
+
+// CurseRecovery configures uncursing and the expected drain of messages sent while the chains were cursed.
+type CurseRecovery struct {
+	UncurseAfter        *Duration `toml:",omitempty"`
+	MessagesDuringCurse *int      `toml:",omitempty"`
+	ExpectDrainWithin   *Duration `toml:",omitempty"`
+	// Chains to uncurse, by network name or selector; defaults to all cursed chains
+	Chains []string `toml:",omitempty"`
+}
+
+func (c *CurseConfig) IsEnabled() bool {
+	return c != nil && len(c.Chains) > 0
+}
+
+func (c *CurseConfig) GetCurseAfter() time.Duration {
+	if c == nil || c.CurseAfter == nil {
+		return 0
+	}
+	return c.CurseAfter.Duration
+}
+
+func (c *CurseRecovery) GetUncurseAfter() time.Duration {
+	if c == nil || c.UncurseAfter == nil {
+		return 0
+	}
+	return c.UncurseAfter.Duration
+}
+
+func (c *CurseRecovery) GetMessagesDuringCurse() int {
+	if c == nil {
+		return 0
+	}
+	return pointer.GetInt(c.MessagesDuringCurse)
+}
+
+func (c *CurseRecovery) GetExpectDrainWithin() time.Duration {
+	if c == nil || c.ExpectDrainWithin == nil {
+		return DEFAULT_EXPECT_DRAIN_WITHIN
+	}
+	return c.ExpectDrainWithin.Duration
+}
+
+// GetCursedChainSelectors resolves the chains to curse to chain selectors.
+func (o *Config) GetCursedChainSelectors() ([]uint64, error) {
+	if o.RMNConfig.CurseConfig == nil {
+		return nil, nil
+	}
+	return o.resolveChainSelectors(o.RMNConfig.CurseConfig.Chains)
+}
+
+// GetCurseRecoveryChainSelectors resolves the chains to uncurse to chain selectors.
+func (o *Config) GetCurseRecoveryChainSelectors() ([]uint64, error) {
+	if o.RMNConfig.CurseRecovery == nil {
+		return nil, nil
+	}
+	if len(o.RMNConfig.CurseRecovery.Chains) == 0 {
+		return o.GetCursedChainSelectors()
+	}
+	return o.resolveChainSelectors(o.RMNConfig.CurseRecovery.Chains)
+}
+
+func (o *Config) resolveChainSelectors(refs []string) ([]uint64, error) {
+	selectors := make([]uint64, 0, len(refs))
+	for _, ref := range refs {
+		selector, err := o.ResolveChainSelector(ref)
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, selector)
+	}
+	return selectors, nil
+}
+
+func (o *Config) validateCurse() error {
+	curse, recovery := o.RMNConfig.CurseConfig, o.RMNConfig.CurseRecovery
+	cursed, err := o.GetCursedChainSelectors()
+	if err != nil {
+		return fieldError("RMNConfig.CurseConfig.Chains", err)
+	}
+	if recovery == nil {
+		return nil
+	}
+	if !curse.IsEnabled() {
+		return fmt.Errorf("RMNConfig.CurseRecovery is set, but no chains are cursed in RMNConfig.CurseConfig")
+	}
+	recovered, err := o.GetCurseRecoveryChainSelectors()
+	if err != nil {
+		return fieldError("RMNConfig.CurseRecovery.Chains", err)
+	}
+	for i, selector := range recovered {
+		found := false
+		for _, c := range cursed {
+			found = found || c == selector
+		}
+		if !found {
+			return fmt.Errorf("RMNConfig.CurseRecovery.Chains: %s is not cursed in RMNConfig.CurseConfig.Chains", recovery.Chains[i])
+		}
+	}
+	if recovery.UncurseAfter == nil || recovery.UncurseAfter.Duration <= 0 {
+		return fmt.Errorf("RMNConfig.CurseRecovery.UncurseAfter must be set and be positive")
+	}
+	if recovery.MessagesDuringCurse != nil && *recovery.MessagesDuringCurse < 0 {
+		return fmt.Errorf("RMNConfig.CurseRecovery.MessagesDuringCurse cannot be negative")
+	}
+	if recovery.GetExpectDrainWithin() <= 0 {
+		return fmt.Errorf("RMNConfig.CurseRecovery.ExpectDrainWithin must be positive")
+	}
+	// the drain has to finish before the test times out
+	end := curse.GetCurseAfter() + recovery.GetUncurseAfter() + recovery.GetExpectDrainWithin()
+	if end > o.Timeouts.GetOverallTestTimeout() {
+		return fmt.Errorf("RMNConfig.CurseRecovery: curse, uncurse and drain take %s, which exceeds Timeouts.OverallTestTimeout (%s)",
+			end, o.Timeouts.GetOverallTestTimeout())
+	}
+	return nil
+}
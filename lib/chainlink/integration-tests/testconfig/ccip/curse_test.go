@@ -0,0 +1,113 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurseDefaults(t *testing.T) {
+	var curse *CurseConfig
+	require.False(t, curse.IsEnabled())
+	require.Zero(t, curse.GetCurseAfter())
+	require.False(t, (&CurseConfig{}).IsEnabled())
+
+	var recovery *CurseRecovery
+	require.Zero(t, recovery.GetUncurseAfter())
+	require.Zero(t, recovery.GetMessagesDuringCurse())
+	require.Equal(t, DEFAULT_EXPECT_DRAIN_WITHIN, recovery.GetExpectDrainWithin())
+
+	var cfg Config
+	cursed, err := cfg.GetCursedChainSelectors()
+	require.NoError(t, err)
+	require.Nil(t, cursed)
+	require.NoError(t, cfg.validateCurse())
+}
+
+func TestGetCurseRecoveryChainSelectors(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(`
+[RMNConfig.CurseConfig]
+Chains = ['ethereum-mainnet', '124615329519749607']
+[RMNConfig.CurseRecovery]
+UncurseAfter = '1m'
+`), &cfg))
+	cursed, err := cfg.GetCursedChainSelectors()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{ethereumSelector, solanaSelector}, cursed)
+	recovered, err := cfg.GetCurseRecoveryChainSelectors()
+	require.NoError(t, err)
+	require.Equal(t, cursed, recovered)
+
+	cfg.RMNConfig.CurseRecovery.Chains = []string{"124615329519749607"}
+	recovered, err = cfg.GetCurseRecoveryChainSelectors()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{solanaSelector}, recovered)
+}
+
+func TestValidateCurse(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "curse only", content: "[RMNConfig.CurseConfig]\nChains = ['ethereum-mainnet']"},
+		{name: "unknown cursed chain", content: "[RMNConfig.CurseConfig]\nChains = ['nowhere']", err: "RMNConfig.CurseConfig.Chains: chain nowhere is neither a chain selector"},
+		{
+			name:    "recovery",
+			content: "[RMNConfig.CurseConfig]\nChains = ['ethereum-mainnet']\nCurseAfter = '1m'\n[RMNConfig.CurseRecovery]\nChains = ['5009297550715157269']\nUncurseAfter = '1m'\nMessagesDuringCurse = 3",
+		},
+		{name: "recovery without a curse", content: "[RMNConfig.CurseRecovery]\nUncurseAfter = '1m'", err: "RMNConfig.CurseRecovery is set, but no chains are cursed in RMNConfig.CurseConfig"},
+		{
+			name:    "unknown recovered chain",
+			content: "[RMNConfig.CurseConfig]\nChains = ['ethereum-mainnet']\n[RMNConfig.CurseRecovery]\nChains = ['nowhere']\nUncurseAfter = '1m'",
+			err:     "RMNConfig.CurseRecovery.Chains: chain nowhere is neither a chain selector",
+		},
+		{
+			name:    "recovered chain not cursed",
+			content: "[RMNConfig.CurseConfig]\nChains = ['ethereum-mainnet']\n[RMNConfig.CurseRecovery]\nChains = ['124615329519749607']\nUncurseAfter = '1m'",
+			err:     "RMNConfig.CurseRecovery.Chains: 124615329519749607 is not cursed in RMNConfig.CurseConfig.Chains",
+		},
+		{
+			name:    "uncurse after not set",
+			content: "[RMNConfig.CurseConfig]\nChains = ['ethereum-mainnet']\n[RMNConfig.CurseRecovery]\nMessagesDuringCurse = 1",
+			err:     "RMNConfig.CurseRecovery.UncurseAfter must be set and be positive",
+		},
+		{
+			name:    "uncurse after zero",
+			content: "[RMNConfig.CurseConfig]\nChains = ['ethereum-mainnet']\n[RMNConfig.CurseRecovery]\nUncurseAfter = '0s'",
+			err:     "RMNConfig.CurseRecovery.UncurseAfter must be set and be positive",
+		},
+		{
+			name:    "negative messages during curse",
+			content: "[RMNConfig.CurseConfig]\nChains = ['ethereum-mainnet']\n[RMNConfig.CurseRecovery]\nUncurseAfter = '1m'\nMessagesDuringCurse = -1",
+			err:     "RMNConfig.CurseRecovery.MessagesDuringCurse cannot be negative",
+		},
+		{
+			name:    "zero drain",
+			content: "[RMNConfig.CurseConfig]\nChains = ['ethereum-mainnet']\n[RMNConfig.CurseRecovery]\nUncurseAfter = '1m'\nExpectDrainWithin = '0s'",
+			err:     "RMNConfig.CurseRecovery.ExpectDrainWithin must be positive",
+		},
+		{
+			name:    "drain after the test timeout",
+			content: "[RMNConfig.CurseConfig]\nChains = ['ethereum-mainnet']\nCurseAfter = '10m'\n[RMNConfig.CurseRecovery]\nUncurseAfter = '15m'",
+			err:     "RMNConfig.CurseRecovery: curse, uncurse and drain take 35m0s, which exceeds Timeouts.OverallTestTimeout (30m0s)",
+		},
+		{
+			name:    "drain within a longer test timeout",
+			content: "[RMNConfig.CurseConfig]\nChains = ['ethereum-mainnet']\nCurseAfter = '10m'\n[RMNConfig.CurseRecovery]\nUncurseAfter = '15m'\n[Timeouts]\nOverallTestTimeout = '1h'",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte(tc.content), &cfg))
+			err := cfg.validateCurse()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package ccip
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	chainselectors "github.com/smartcontractkit/chain-selectors"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/blockchain"
+)
+
+const (
+	OWNER_TYPE_EOA      = "eoa"
+	OWNER_TYPE_TIMELOCK = "timelock"
+	OWNER_TYPE_NONE     = "none"
+
+	DEFAULT_OWNER_TYPE = OWNER_TYPE_EOA
+)
+
+var (
+	privateKeyRegex     = regexp.MustCompile(`^(0x)?[0-9a-fA-F]{64}$`)
+	kmsKeyIDRegex       = regexp.MustCompile(`^(arn:aws:kms:[a-z0-9-]+:\d{12}:(key|alias)/[A-Za-z0-9/_-]+|[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})$`)
+	derivationPathRegex = regexp.MustCompile(`^m(/\d+'?)+$`)
+)
+
+// DeployerConfig selects the key contracts are deployed with on a chain and who ends up owning them.
+// Exactly one of PrivateKey, KMSKeyID and LedgerDerivationPath must be set.
+type DeployerConfig struct {
+	PrivateKey           *Secret `toml:",omitempty"`
+	KMSKeyID             *string `toml:",omitempty"`
+	LedgerDerivationPath *string `toml:",omitempty"`
+	OwnerType            *string `toml:",omitempty"`
+	// Funding tops up the deployer, only the amounts of its own chain apply
+	Funding *Funding `toml:",omitempty"`
+}
+
+func (d *DeployerConfig) GetOwnerType() string {
+	if d == nil || pointer.GetString(d.OwnerType) == "" {
+		return DEFAULT_OWNER_TYPE
+	}
+	return *d.OwnerType
+}
+
+// KeyRef returns a reference to the deployer key that is safe to log and persist.
+func (d *DeployerConfig) KeyRef(field string) string {
+	switch {
+	case d.PrivateKey != nil && d.PrivateKey.IsReference():
+		return string(*d.PrivateKey)
+	case d.PrivateKey != nil:
+		return field + ".PrivateKey"
+	case d.KMSKeyID != nil:
+		return "kms:" + *d.KMSKeyID
+	default:
+		return "ledger:" + pointer.GetString(d.LedgerDerivationPath)
+	}
+}
+
+// GetDeployerConfig returns the deployer of the chain, and the key it is configured under.
+func (o *Config) GetDeployerConfig(selector uint64) (*DeployerConfig, string, bool) {
+	for ref, deployer := range o.DeployerConfig {
+		if deployer == nil {
+			continue
+		}
+		if resolved, err := o.ResolveChainSelector(ref); err == nil && resolved == selector {
+			return deployer, ref, true
+		}
+	}
+	return nil, "", false
+}
+
+func (d *DeployerConfig) Validate(field string) error {
+	set := 0
+	if d.PrivateKey != nil {
+		set++
+		if !d.PrivateKey.IsReference() && !privateKeyRegex.MatchString(string(*d.PrivateKey)) {
+			// don't echo the value, it might be a slightly malformed real key
+			return fmt.Errorf("%s.PrivateKey is not a 32 byte hex private key", field)
+		}
+	}
+	if d.KMSKeyID != nil {
+		set++
+		if !kmsKeyIDRegex.MatchString(*d.KMSKeyID) {
+			return fmt.Errorf("%s.KMSKeyID %q is neither a KMS key ARN nor a key id", field, *d.KMSKeyID)
+		}
+	}
+	if d.LedgerDerivationPath != nil {
+		set++
+		if !derivationPathRegex.MatchString(*d.LedgerDerivationPath) {
+			return fmt.Errorf("%s.LedgerDerivationPath %q is not a BIP32 derivation path", field, *d.LedgerDerivationPath)
+		}
+	}
+	if set != 1 {
+		return fmt.Errorf("%s must set exactly one of PrivateKey, KMSKeyID and LedgerDerivationPath, got %d", field, set)
+	}
+	switch owner := d.GetOwnerType(); owner {
+	case OWNER_TYPE_EOA, OWNER_TYPE_TIMELOCK, OWNER_TYPE_NONE:
+	default:
+		return fmt.Errorf("%s.OwnerType must be one of %s, %s or %s, got %q", field, OWNER_TYPE_EOA, OWNER_TYPE_TIMELOCK, OWNER_TYPE_NONE, owner)
+	}
+	return nil
+}
+
+func (o *Config) validateDeployerConfig() error {
+	refs := make([]string, 0, len(o.DeployerConfig))
+	for ref := range o.DeployerConfig {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	seen := make(map[uint64]string)
+	for _, ref := range refs {
+		if o.DeployerConfig[ref] == nil {
+			continue
+		}
+		selector, err := o.ResolveChainSelector(ref)
+		if err != nil {
+			return fieldError("DeployerConfig."+ref, err)
+		}
+		// GetDeployerConfig would pick either of them
+		if other, ok := seen[selector]; ok {
+			return fmt.Errorf("DeployerConfig.%s and DeployerConfig.%s refer to the same chain", other, ref)
+		}
+		seen[selector] = ref
+		if err := o.DeployerConfig[ref].Validate("DeployerConfig." + ref); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateLiveNetworkDeployers refuses live networks without an explicit deployer, since
+// falling back to the network's default keys only makes sense on simulated chains.
+func (o *Config) ValidateLiveNetworkDeployers(evmNetworks []blockchain.EVMNetwork) error {
+	var missing []string
+	for _, network := range evmNetworks {
+		if network.Simulated || network.ChainID <= 0 {
+			continue
+		}
+		selector, err := chainselectors.SelectorFromChainId(uint64(network.ChainID))
+		if err != nil {
+			return fmt.Errorf("network %s: %w", network.Name, err)
+		}
+		if _, _, ok := o.GetDeployerConfig(selector); !ok {
+			missing = append(missing, network.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("DeployerConfig must be set for live networks %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const deployerPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+func TestDeployerConfigKeyRef(t *testing.T) {
+	var deployer *DeployerConfig
+	require.Equal(t, DEFAULT_OWNER_TYPE, deployer.GetOwnerType())
+
+	key := Secret(deployerPrivateKey)
+	ref := Secret("vault://ccip/deployer")
+	for _, tc := range []struct {
+		deployer DeployerConfig
+		ref      string
+	}{
+		{deployer: DeployerConfig{PrivateKey: &key}, ref: "DeployerConfig.SIMULATED_1.PrivateKey"},
+		{deployer: DeployerConfig{PrivateKey: &ref}, ref: "vault://ccip/deployer"},
+		{deployer: DeployerConfig{KMSKeyID: pointer.ToString("alias/ccip")}, ref: "kms:alias/ccip"},
+		{deployer: DeployerConfig{LedgerDerivationPath: pointer.ToString("m/44'/60'/0'/0/0")}, ref: "ledger:m/44'/60'/0'/0/0"},
+	} {
+		require.Equal(t, tc.ref, tc.deployer.KeyRef("DeployerConfig.SIMULATED_1"))
+	}
+}
+
+func TestGetDeployerConfig(t *testing.T) {
+	_, _, ok := (&Config{}).GetDeployerConfig(ethereumSelector)
+	require.False(t, ok)
+
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte("[DeployerConfig.ethereum-mainnet]\nKMSKeyID = 'alias/ccip'\nOwnerType = 'timelock'"), &cfg))
+	deployer, ref, ok := cfg.GetDeployerConfig(ethereumSelector)
+	require.True(t, ok)
+	require.Equal(t, "ethereum-mainnet", ref)
+	require.Equal(t, OWNER_TYPE_TIMELOCK, deployer.GetOwnerType())
+	_, _, ok = cfg.GetDeployerConfig(solanaSelector)
+	require.False(t, ok)
+}
+
+func TestValidateDeployerConfig(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "private key", content: "PrivateKey = '" + deployerPrivateKey + "'"},
+		{name: "0x private key", content: "PrivateKey = '0x" + deployerPrivateKey + "'\nOwnerType = 'none'"},
+		{name: "private key reference", content: "PrivateKey = 'awssm://ccip/deployer'"},
+		{name: "kms arn", content: "KMSKeyID = 'arn:aws:kms:us-east-1:123456789012:key/ccip-deployer'"},
+		{name: "kms key id", content: "KMSKeyID = '1234abcd-12ab-34cd-56ef-1234567890ab'"},
+		{name: "ledger", content: "LedgerDerivationPath = \"m/44'/60'/0'/0/0\"\nOwnerType = 'timelock'"},
+		{name: "malformed private key", content: "PrivateKey = '0x" + deployerPrivateKey[1:] + "'", err: "DeployerConfig.SIMULATED_1.PrivateKey is not a 32 byte hex private key"},
+		{name: "malformed kms key", content: "KMSKeyID = 'ccip'", err: `DeployerConfig.SIMULATED_1.KMSKeyID "ccip" is neither a KMS key ARN nor a key id`},
+		{name: "malformed derivation path", content: "LedgerDerivationPath = '44/60'", err: `DeployerConfig.SIMULATED_1.LedgerDerivationPath "44/60" is not a BIP32 derivation path`},
+		{name: "no key", content: "OwnerType = 'eoa'", err: "DeployerConfig.SIMULATED_1 must set exactly one of PrivateKey, KMSKeyID and LedgerDerivationPath, got 0"},
+		{
+			name:    "two keys",
+			content: "PrivateKey = '" + deployerPrivateKey + "'\nKMSKeyID = '1234abcd-12ab-34cd-56ef-1234567890ab'",
+			err:     "DeployerConfig.SIMULATED_1 must set exactly one of PrivateKey, KMSKeyID and LedgerDerivationPath, got 2",
+		},
+		{name: "unknown owner type", content: "KMSKeyID = '1234abcd-12ab-34cd-56ef-1234567890ab'\nOwnerType = 'multisig'", err: `DeployerConfig.SIMULATED_1.OwnerType must be one of eoa, timelock or none, got "multisig"`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var deployer DeployerConfig
+			require.NoError(t, toml.Unmarshal([]byte(tc.content), &deployer))
+			err := deployer.Validate("DeployerConfig.SIMULATED_1")
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestValidateDeployerConfigChains(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "not set"},
+		{name: "by name and by selector", content: "[DeployerConfig.ethereum-mainnet]\nKMSKeyID = '1234abcd-12ab-34cd-56ef-1234567890ab'\n[DeployerConfig.124615329519749607]\nKMSKeyID = '1234abcd-12ab-34cd-56ef-1234567890cd'"},
+		{name: "unknown chain", content: "[DeployerConfig.nowhere]\nKMSKeyID = '1234abcd-12ab-34cd-56ef-1234567890ab'", err: "DeployerConfig.nowhere: chain nowhere is neither a chain selector"},
+		{
+			name:    "same chain twice",
+			content: "[DeployerConfig.ethereum-mainnet]\nKMSKeyID = '1234abcd-12ab-34cd-56ef-1234567890ab'\n[DeployerConfig.5009297550715157269]\nKMSKeyID = '1234abcd-12ab-34cd-56ef-1234567890cd'",
+			err:     "DeployerConfig.5009297550715157269 and DeployerConfig.ethereum-mainnet refer to the same chain",
+		},
+		{name: "invalid deployer", content: "[DeployerConfig.ethereum-mainnet]\nOwnerType = 'eoa'", err: "DeployerConfig.ethereum-mainnet must set exactly one of PrivateKey, KMSKeyID and LedgerDerivationPath, got 0"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte(tc.content), &cfg))
+			err := cfg.validateDeployerConfig()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
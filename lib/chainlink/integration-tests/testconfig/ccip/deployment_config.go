@@ -0,0 +1,161 @@
+package ccip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	DEFAULT_MAX_CONCURRENT_CHAINS       = 1
+	DEFAULT_MAX_CONCURRENT_TX_PER_CHAIN = 1
+)
+
+// DeploymentConfig controls how contracts are deployed across chains. The defaults deploy one chain
+// after the other and stop at the first failure.
+type DeploymentConfig struct {
+	MaxConcurrentChains *int `toml:",omitempty"`
+	// MaxConcurrentTxPerChain is the number of deployment transactions a chain may have in flight, see ForEachTx
+	MaxConcurrentTxPerChain *int `toml:",omitempty"`
+	// ContinueOnChainFailure deploys the remaining chains after one failed, reporting every failed chain
+	ContinueOnChainFailure *bool `toml:",omitempty"`
+}
+
+func (d *DeploymentConfig) GetMaxConcurrentChains() int {
+	if d == nil || d.MaxConcurrentChains == nil {
+		return DEFAULT_MAX_CONCURRENT_CHAINS
+	}
+	return *d.MaxConcurrentChains
+}
+
+func (d *DeploymentConfig) GetMaxConcurrentTxPerChain() int {
+	if d == nil || d.MaxConcurrentTxPerChain == nil {
+		return DEFAULT_MAX_CONCURRENT_TX_PER_CHAIN
+	}
+	return *d.MaxConcurrentTxPerChain
+}
+
+func (d *DeploymentConfig) GetContinueOnChainFailure() bool {
+	return d != nil && pointer.GetBool(d.ContinueOnChainFailure)
+}
+
+// ForEachChain runs deploy for every chain, up to MaxConcurrentChains at a time. The first failure
+// cancels the context of the other chains and is returned, unless ContinueOnChainFailure is set, in
+// which case every chain is deployed and the failures of all of them are returned joined, by selector.
+func (d *DeploymentConfig) ForEachChain(ctx context.Context, selectors []uint64, deploy func(ctx context.Context, selector uint64) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	continueOnFailure := d.GetContinueOnChainFailure()
+	slots := make(chan struct{}, d.GetMaxConcurrentChains())
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		failures = make(map[uint64]error)
+		first    error
+	)
+	for _, selector := range selectors {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(selector uint64) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			if err := deploy(ctx, selector); err != nil {
+				err = fmt.Errorf("chain %s (%d): %w", chainName(selector), selector, err)
+				mu.Lock()
+				failures[selector] = err
+				if first == nil {
+					first = err
+				}
+				mu.Unlock()
+				if !continueOnFailure {
+					cancel()
+				}
+			}
+		}(selector)
+	}
+	wg.Wait()
+	if len(failures) == 0 {
+		return ctx.Err()
+	}
+	if !continueOnFailure {
+		// other chains may have failed only because the first failure cancelled them
+		return first
+	}
+	failed := make([]uint64, 0, len(failures))
+	for selector := range failures {
+		failed = append(failed, selector)
+	}
+	sort.Slice(failed, func(i, j int) bool { return failed[i] < failed[j] })
+	errs := make([]error, 0, len(failed))
+	for _, selector := range failed {
+		errs = append(errs, failures[selector])
+	}
+	return errors.Join(errs...)
+}
+
+// ForEachTx sends the n transactions of a chain, up to MaxConcurrentTxPerChain at a time. The first
+// failure cancels the context of the transactions not sent yet and is returned.
+func (d *DeploymentConfig) ForEachTx(ctx context.Context, n int, send func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	slots := make(chan struct{}, d.GetMaxConcurrentTxPerChain())
+	var (
+		wg    sync.WaitGroup
+		once  sync.Once
+		first error
+	)
+	for i := 0; i < n; i++ {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			if err := send(ctx, i); err != nil {
+				once.Do(func() {
+					first = fmt.Errorf("transaction %d: %w", i, err)
+					cancel()
+				})
+			}
+		}(i)
+	}
+	wg.Wait()
+	if first != nil {
+		return first
+	}
+	return ctx.Err()
+}
+
+func (o *Config) validateDeploymentConfig() error {
+	d := o.DeploymentConfig
+	if d == nil {
+		return nil
+	}
+	if chains := d.GetMaxConcurrentChains(); chains < 1 {
+		return fmt.Errorf("DeploymentConfig.MaxConcurrentChains must be at least 1, got %d", chains)
+	}
+	txs := d.GetMaxConcurrentTxPerChain()
+	if txs < 1 {
+		return fmt.Errorf("DeploymentConfig.MaxConcurrentTxPerChain must be at least 1, got %d", txs)
+	}
+	if strategy := o.SenderConfig.GetNonceStrategy(); txs > 1 && strategy != NONCE_STRATEGY_PARALLEL_PENDING {
+		return fmt.Errorf("DeploymentConfig.MaxConcurrentTxPerChain %d needs SenderConfig.NonceStrategy %s, %s nonces allow a single transaction in flight",
+			txs, NONCE_STRATEGY_PARALLEL_PENDING, strategy)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package ccip
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+var deploymentSelectors = []uint64{3379446385462418246, 12922642891491394802, 4793464827907405086}
+
+func TestForEachChainSerialByDefault(t *testing.T) {
+	var d *DeploymentConfig
+	var deployed []uint64
+	var inFlight, maxInFlight atomic.Int32
+	require.NoError(t, d.ForEachChain(context.Background(), deploymentSelectors, func(_ context.Context, selector uint64) error {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		if n > maxInFlight.Load() {
+			maxInFlight.Store(n)
+		}
+		deployed = append(deployed, selector)
+		return nil
+	}))
+	require.Equal(t, deploymentSelectors, deployed)
+	require.Equal(t, int32(1), maxInFlight.Load())
+
+	// the first failure stops the deployment
+	deployed = nil
+	err := d.ForEachChain(context.Background(), deploymentSelectors, func(_ context.Context, selector uint64) error {
+		deployed = append(deployed, selector)
+		if selector == 12922642891491394802 {
+			return errors.New("out of gas")
+		}
+		return nil
+	})
+	require.EqualError(t, err, "chain geth-devnet-2 (12922642891491394802): out of gas")
+	require.Equal(t, deploymentSelectors[:2], deployed)
+}
+
+func TestForEachChainConcurrently(t *testing.T) {
+	d := &DeploymentConfig{MaxConcurrentChains: pointer.ToInt(3)}
+	var inFlight atomic.Int32
+	release := make(chan struct{})
+	var once sync.Once
+	done := make(chan error)
+	go func() {
+		done <- d.ForEachChain(context.Background(), deploymentSelectors, func(ctx context.Context, _ uint64) error {
+			if inFlight.Add(1) == 3 {
+				once.Do(func() { close(release) })
+			}
+			select {
+			case <-release:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("chains were not deployed concurrently")
+	}
+}
+
+func TestForEachChainContinueOnFailure(t *testing.T) {
+	d := &DeploymentConfig{ContinueOnChainFailure: pointer.ToBool(true)}
+	var deployed []uint64
+	err := d.ForEachChain(context.Background(), deploymentSelectors, func(_ context.Context, selector uint64) error {
+		deployed = append(deployed, selector)
+		if selector != 3379446385462418246 {
+			return errors.New("out of gas")
+		}
+		return nil
+	})
+	require.Equal(t, deploymentSelectors, deployed)
+	require.EqualError(t, err, "chain geth-devnet-3 (4793464827907405086): out of gas\nchain geth-devnet-2 (12922642891491394802): out of gas")
+}
+
+func TestValidateDeploymentConfig(t *testing.T) {
+	cfg := Config{DeploymentConfig: &DeploymentConfig{MaxConcurrentChains: pointer.ToInt(0)}}
+	require.EqualError(t, cfg.validateDeploymentConfig(), "DeploymentConfig.MaxConcurrentChains must be at least 1, got 0")
+
+	cfg.DeploymentConfig = &DeploymentConfig{MaxConcurrentTxPerChain: pointer.ToInt(4)}
+	require.EqualError(t, cfg.validateDeploymentConfig(),
+		"DeploymentConfig.MaxConcurrentTxPerChain 4 needs SenderConfig.NonceStrategy parallelPending, sequential nonces allow a single transaction in flight")
+
+	cfg.SenderConfig = &SenderConfig{NonceStrategy: pointer.ToString(NONCE_STRATEGY_PARALLEL_PENDING)}
+	require.NoError(t, cfg.validateDeploymentConfig())
+}
+
+func TestForEachTxHonorsMaxConcurrentTxPerChain(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		d        *DeploymentConfig
+		expected int32
+	}{
+		{name: "default", d: nil, expected: 1},
+		{name: "parallel", d: &DeploymentConfig{MaxConcurrentTxPerChain: pointer.ToInt(3)}, expected: 3},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var inFlight, maxInFlight, sent atomic.Int32
+			var mu sync.Mutex
+			require.NoError(t, tc.d.ForEachTx(context.Background(), 9, func(_ context.Context, _ int) error {
+				n := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				mu.Lock()
+				if n > maxInFlight.Load() {
+					maxInFlight.Store(n)
+				}
+				mu.Unlock()
+				time.Sleep(10 * time.Millisecond)
+				sent.Add(1)
+				return nil
+			}))
+			require.Equal(t, int32(9), sent.Load())
+			require.LessOrEqual(t, maxInFlight.Load(), tc.expected)
+		})
+	}
+
+	var sent []int
+	err := (*DeploymentConfig)(nil).ForEachTx(context.Background(), 5, func(_ context.Context, i int) error {
+		sent = append(sent, i)
+		if i == 1 {
+			return errors.New("nonce too low")
+		}
+		return nil
+	})
+	require.EqualError(t, err, "transaction 1: nonce too low")
+	require.Equal(t, []int{0, 1}, sent)
+}
@@ -0,0 +1,175 @@
+package ccip
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/AlekSi/pointer"
+	chainselectors "github.com/smartcontractkit/chain-selectors"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/blockchain"
+)
+
+// DeploymentInput is everything deployment/environment needs from this config to build its environment.
+type DeploymentInput struct {
+	HomeChainSelector uint64
+	FeedChainSelector uint64
+	// Chains are sorted by selector
+	Chains          []DeploymentChain
+	NoOfPluginNodes int
+	NoOfBootstraps  int
+	JDGRPC          string
+	JDWSRPC         string
+	RMN             DeploymentRMN
+	// TokenPools are the pool types of every token on every lane between Chains, sorted by symbol and lane
+	TokenPools []DeploymentTokenPool
+}
+
+type DeploymentChain struct {
+	Name     string
+	Selector uint64
+	ChainID  uint64
+	WSURLs   []string
+	HTTPURLs []string
+	// DeployerKeyRef points at the key to deploy with, never the key itself. It is either
+	// "<network name>#<key index>" for the network's default keys or DeployerConfig.KeyRef
+	DeployerKeyRef string
+	GasStrategy    ResolvedGasStrategy
+	// ContractVersion is the CCIP contract version to deploy, one of the CONTRACT_VERSION_* constants
+	ContractVersion string
+	// Tokens are the LINK and wrapped native addresses to use, or TOKEN_DEPLOY_MOCK
+	Tokens ChainTokenAddresses
+	// ConfirmationDepth is the number of blocks deployment transactions must be buried under
+	ConfirmationDepth uint64
+}
+
+type DeploymentTokenPool struct {
+	Symbol         string
+	SourceSelector uint64
+	DestSelector   uint64
+	// PoolType is one of the POOL_TYPE_* constants
+	PoolType      string
+	GrantMintRole bool
+}
+
+type DeploymentRMN struct {
+	NoOfNodes int
+}
+
+// ToDeploymentInput resolves the config against the selected networks. It fails on the first
+// field it can't resolve rather than returning a partially filled DeploymentInput.
+func (o *Config) ToDeploymentInput(evmNetworks []blockchain.EVMNetwork) (DeploymentInput, error) {
+	homeChainSelector, err := o.GetHomeChainSelector(evmNetworks)
+	if err != nil {
+		return DeploymentInput{}, fieldError("HomeChainSelector", err)
+	}
+	feedChainSelector, err := o.GetFeedChainSelector(evmNetworks)
+	if err != nil {
+		return DeploymentInput{}, fieldError("FeedChainSelector", err)
+	}
+	input := DeploymentInput{
+		HomeChainSelector: homeChainSelector,
+		FeedChainSelector: feedChainSelector,
+		NoOfPluginNodes:   o.CLNode.GetNoOfPluginNodes(),
+		RMN:               DeploymentRMN{NoOfNodes: pointer.GetInt(o.RMNConfig.NoOfNodes)},
+	}
+	if o.CLNode != nil {
+		input.NoOfBootstraps = pointer.GetInt(o.CLNode.NoOfBootstraps)
+	}
+	if input.NoOfPluginNodes == 0 {
+		return DeploymentInput{}, withKind(ErrInsufficientNodes, fmt.Errorf("CLNode.NoOfPluginNodes must be set"))
+	}
+	if input.JDGRPC, err = stringOrRequiredEnv(o.JobDistributorConfig.JDGRPC, E2E_JD_GRPC); err != nil {
+		return DeploymentInput{}, fieldError("JobDistributorConfig.JDGRPC", err)
+	}
+	if input.JDWSRPC, err = stringOrRequiredEnv(o.JobDistributorConfig.JDWSRPC, E2E_JD_WSRPC); err != nil {
+		return DeploymentInput{}, fieldError("JobDistributorConfig.JDWSRPC", err)
+	}
+	if err := o.ValidateLiveNetworkDeployers(evmNetworks); err != nil {
+		return DeploymentInput{}, err
+	}
+	if err := o.ValidateGasStrategies(evmNetworks); err != nil {
+		return DeploymentInput{}, err
+	}
+	if err := o.ValidateLiveNetworkChainTokens(evmNetworks); err != nil {
+		return DeploymentInput{}, err
+	}
+	for _, network := range evmNetworks {
+		if network.ChainID <= 0 {
+			return DeploymentInput{}, fmt.Errorf("network %s: invalid chain id %d", network.Name, network.ChainID)
+		}
+		selector, err := chainselectors.SelectorFromChainId(uint64(network.ChainID))
+		if err != nil {
+			return DeploymentInput{}, fmt.Errorf("network %s: %w", network.Name, err)
+		}
+		if len(network.URLs) == 0 && len(network.HTTPURLs) == 0 {
+			return DeploymentInput{}, withKind(ErrInvalidEndpoint, fmt.Errorf("network %s: no RPC endpoints", network.Name))
+		}
+		keyRef := fmt.Sprintf("%s#0", network.Name)
+		if deployer, ref, ok := o.GetDeployerConfig(selector); ok {
+			keyRef = deployer.KeyRef("DeployerConfig." + ref)
+		} else if len(network.PrivateKeys) == 0 {
+			return DeploymentInput{}, fmt.Errorf("network %s: no deployer key", network.Name)
+		}
+		input.Chains = append(input.Chains, DeploymentChain{
+			Name:              network.Name,
+			Selector:          selector,
+			ChainID:           uint64(network.ChainID),
+			WSURLs:            network.URLs,
+			HTTPURLs:          network.HTTPURLs,
+			DeployerKeyRef:    keyRef,
+			GasStrategy:       o.GetGasStrategy(selector),
+			ContractVersion:   o.GetContractVersion(selector),
+			Tokens:            o.GetChainTokens(selector),
+			ConfirmationDepth: o.GetConfirmationDepth(selector),
+		})
+	}
+	sort.Slice(input.Chains, func(i, j int) bool { return input.Chains[i].Selector < input.Chains[j].Selector })
+	if input.TokenPools, err = o.deploymentTokenPools(input.Chains); err != nil {
+		return DeploymentInput{}, err
+	}
+	return input, nil
+}
+
+func (o *Config) deploymentTokenPools(chains []DeploymentChain) ([]DeploymentTokenPool, error) {
+	symbols := make([]string, 0, len(o.Tokens))
+	for symbol := range o.Tokens {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	var pools []DeploymentTokenPool
+	for _, symbol := range symbols {
+		for _, source := range chains {
+			for _, dest := range chains {
+				if source.Selector == dest.Selector {
+					continue
+				}
+				lane := ResolvedLane{Source: source.Name, Dest: dest.Name, SourceSelector: source.Selector, DestSelector: dest.Selector}
+				poolType, err := o.GetPoolType(symbol, lane)
+				if err != nil {
+					return nil, fieldError("Tokens."+symbol, err)
+				}
+				pools = append(pools, DeploymentTokenPool{
+					Symbol:         symbol,
+					SourceSelector: source.Selector,
+					DestSelector:   dest.Selector,
+					PoolType:       poolType,
+					GrantMintRole:  poolType == POOL_TYPE_BURN_MINT && o.Tokens[symbol].IsMintRoleGranted(),
+				})
+			}
+		}
+	}
+	return pools, nil
+}
+
+// stringOrRequiredEnv is like the JDConfig getters, but returns an error instead of panicking.
+func stringOrRequiredEnv(value *string, envVar string) (string, error) {
+	if v := pointer.GetString(value); v != "" {
+		return v, nil
+	}
+	if v := os.Getenv(envVar); v != "" {
+		return v, nil
+	}
+	return "", withKind(ErrMissingEnvVar, fmt.Errorf("not set and %s env var is empty", envVar))
+}
@@ -0,0 +1,82 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/blockchain"
+)
+
+func TestToDeploymentInput(t *testing.T) {
+	networks := []blockchain.EVMNetwork{
+		{Name: "chain-2337", ChainID: 2337, Simulated: true, URLs: []string{"ws://geth-2337:8546"}, PrivateKeys: []string{"key"}},
+		{Name: "chain-1337", ChainID: 1337, Simulated: true, HTTPURLs: []string{"http://geth-1337:8545"}, PrivateKeys: []string{"key"}},
+	}
+	cfg := &Config{
+		HomeChainSelector:    pointer.ToString("3379446385462418246"),
+		FeedChainSelector:    pointer.ToString("3379446385462418246"),
+		CLNode:               &NodeConfig{NoOfPluginNodes: pointer.ToInt(4), NoOfBootstraps: pointer.ToInt(1)},
+		JobDistributorConfig: JDConfig{JDGRPC: pointer.ToString("jd:42242"), JDWSRPC: pointer.ToString("jd:8080")},
+		RMNConfig:            RMNConfig{NoOfNodes: pointer.ToInt(2)},
+	}
+
+	input, err := cfg.ToDeploymentInput(networks)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3379446385462418246), input.HomeChainSelector)
+	require.Len(t, input.Chains, 2)
+	require.Equal(t, "chain-1337", input.Chains[0].Name)
+	require.Equal(t, "chain-1337#0", input.Chains[0].DeployerKeyRef)
+	require.Equal(t, 4, input.NoOfPluginNodes)
+	require.Equal(t, 2, input.RMN.NoOfNodes)
+	require.Empty(t, input.TokenPools)
+
+	cfg.Tokens = map[string]*TokenConfig{"TEST": {PoolTypePerLane: map[string]string{"chain-1337->chain-2337": POOL_TYPE_LOCK_RELEASE}}}
+	input, err = cfg.ToDeploymentInput(networks)
+	require.NoError(t, err)
+	require.Equal(t, []DeploymentTokenPool{
+		{Symbol: "TEST", SourceSelector: 3379446385462418246, DestSelector: 12922642891491394802, PoolType: POOL_TYPE_LOCK_RELEASE},
+		{Symbol: "TEST", SourceSelector: 12922642891491394802, DestSelector: 3379446385462418246, PoolType: POOL_TYPE_BURN_MINT, GrantMintRole: true},
+	}, input.TokenPools)
+
+	t.Setenv(E2E_JD_WSRPC, "")
+	cfg.JobDistributorConfig.JDWSRPC = nil
+	_, err = cfg.ToDeploymentInput(networks)
+	require.ErrorContains(t, err, "JobDistributorConfig.JDWSRPC")
+
+	cfg.JobDistributorConfig.JDWSRPC = pointer.ToString("jd:8080")
+	networks[0].PrivateKeys = nil
+	_, err = cfg.ToDeploymentInput(networks)
+	require.ErrorContains(t, err, "network chain-2337: no deployer key")
+}
+
+func TestToDeploymentInputLiveNetworkRequiresDeployer(t *testing.T) {
+	networks := []blockchain.EVMNetwork{
+		{Name: "sepolia", ChainID: 11155111, HTTPURLs: []string{"https://sepolia.example.com"}, PrivateKeys: []string{"key"}},
+	}
+	cfg := &Config{
+		HomeChainSelector:    pointer.ToString("16015286601757825753"),
+		FeedChainSelector:    pointer.ToString("16015286601757825753"),
+		CLNode:               &NodeConfig{NoOfPluginNodes: pointer.ToInt(4)},
+		JobDistributorConfig: JDConfig{JDGRPC: pointer.ToString("jd:42242"), JDWSRPC: pointer.ToString("jd:8080")},
+		ChainTokens: map[string]*ChainTokens{"ethereum-testnet-sepolia": {
+			LINK:          pointer.ToString("0x779877A7B0D9E8603169DdbD7836e478b4624789"),
+			WrappedNative: pointer.ToString("0x097D90c9d3E0B50Ca60e1ae45F6A81010f9FB534"),
+		}},
+	}
+	_, err := cfg.ToDeploymentInput(networks)
+	require.ErrorContains(t, err, "DeployerConfig must be set for live networks sepolia")
+
+	kms := "arn:aws:kms:us-east-1:123456789012:key/ccip-deployer"
+	cfg.DeployerConfig = map[string]*DeployerConfig{"ethereum-testnet-sepolia": {KMSKeyID: &kms}}
+	require.NoError(t, cfg.validateDeployerConfig())
+	input, err := cfg.ToDeploymentInput(networks)
+	require.NoError(t, err)
+	require.Equal(t, "kms:"+kms, input.Chains[0].DeployerKeyRef)
+	require.Equal(t, "0x779877A7B0D9E8603169DdbD7836e478b4624789", input.Chains[0].Tokens.LINK)
+
+	key := Secret("0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	cfg.DeployerConfig["ethereum-testnet-sepolia"].PrivateKey = &key
+	require.ErrorContains(t, cfg.validateDeployerConfig(), "exactly one of")
+}
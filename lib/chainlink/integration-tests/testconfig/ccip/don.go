@@ -0,0 +1,70 @@
+package ccip
+
+import (
+	"fmt"
+
+	"github.com/AlekSi/pointer"
+)
+
+// DONConfig sizes the commit and exec DONs independently. Nodes are assigned to the commit DON first,
+// the last Overlap commit nodes also serve the exec DON, and the remaining exec nodes follow.
+type DONConfig struct {
+	CommitNodes *int `toml:",omitempty"`
+	ExecNodes   *int `toml:",omitempty"`
+	// Overlap is how many nodes serve both DONs
+	Overlap *int `toml:",omitempty"`
+}
+
+// RequiredNodes returns the total number of plugin nodes needed to serve both DONs.
+func (d *DONConfig) RequiredNodes() int {
+	return pointer.GetInt(d.CommitNodes) + pointer.GetInt(d.ExecNodes) - pointer.GetInt(d.Overlap)
+}
+
+// CommitNodeIndexes returns the plugin node indexes serving the commit DON.
+func (d *DONConfig) CommitNodeIndexes() []int {
+	return indexRange(0, pointer.GetInt(d.CommitNodes))
+}
+
+// ExecNodeIndexes returns the plugin node indexes serving the exec DON.
+func (d *DONConfig) ExecNodeIndexes() []int {
+	start := pointer.GetInt(d.CommitNodes) - pointer.GetInt(d.Overlap)
+	return indexRange(start, start+pointer.GetInt(d.ExecNodes))
+}
+
+func indexRange(from, to int) []int {
+	indexes := make([]int, 0, to-from)
+	for i := from; i < to; i++ {
+		indexes = append(indexes, i)
+	}
+	return indexes
+}
+
+// Validate checks the DON sizes against the plugin node count, if one is set, and that each DON
+// can tolerate the faulty nodes configured for it in the home chain config.
+func (d *DONConfig) Validate(noOfPluginNodes *int, home *HomeChainConfig) error {
+	if d.CommitNodes == nil || d.ExecNodes == nil {
+		return fmt.Errorf("DONConfig.CommitNodes and DONConfig.ExecNodes must both be set")
+	}
+	overlap := pointer.GetInt(d.Overlap)
+	if overlap < 0 || overlap > *d.CommitNodes || overlap > *d.ExecNodes {
+		return fmt.Errorf("DONConfig.Overlap must be between 0 and the size of the smaller DON, got %d", overlap)
+	}
+	for _, don := range []struct {
+		family string
+		size   int
+	}{
+		{DON_FAMILY_COMMIT, *d.CommitNodes},
+		{DON_FAMILY_EXEC, *d.ExecNodes},
+	} {
+		family, size := don.family, don.size
+		f := home.GetF(family, size)
+		if f < 1 || size < 3*f+1 {
+			return withKind(ErrInsufficientNodes, fmt.Errorf("DONConfig: %s DON has %d nodes, which cannot tolerate f=%d faulty nodes (3f+1 nodes required, f >= 1)", family, size, f))
+		}
+	}
+	if noOfPluginNodes != nil && *noOfPluginNodes != d.RequiredNodes() {
+		return withKind(ErrInsufficientNodes, fmt.Errorf("DONConfig requires %d plugin nodes (%d commit + %d exec - %d overlap), but NoOfPluginNodes is %d",
+			d.RequiredNodes(), *d.CommitNodes, *d.ExecNodes, overlap, *noOfPluginNodes))
+	}
+	return nil
+}
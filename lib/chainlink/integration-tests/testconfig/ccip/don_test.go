@@ -0,0 +1,64 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDONConfigNodeIndexes(t *testing.T) {
+	var empty DONConfig
+	require.Zero(t, empty.RequiredNodes())
+	require.Empty(t, empty.CommitNodeIndexes())
+	require.Empty(t, empty.ExecNodeIndexes())
+
+	don := DONConfig{CommitNodes: pointer.ToInt(4), ExecNodes: pointer.ToInt(7), Overlap: pointer.ToInt(2)}
+	require.Equal(t, 9, don.RequiredNodes())
+	require.Equal(t, []int{0, 1, 2, 3}, don.CommitNodeIndexes())
+	require.Equal(t, []int{2, 3, 4, 5, 6, 7, 8}, don.ExecNodeIndexes())
+}
+
+func TestValidateDONConfig(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		content         string
+		noOfPluginNodes *int
+		home            *HomeChainConfig
+		err             string
+	}{
+		{name: "disjoint", content: "CommitNodes = 4\nExecNodes = 4", noOfPluginNodes: pointer.ToInt(8)},
+		{name: "overlapping", content: "CommitNodes = 4\nExecNodes = 7\nOverlap = 2", noOfPluginNodes: pointer.ToInt(9)},
+		{name: "fully overlapping", content: "CommitNodes = 4\nExecNodes = 4\nOverlap = 4"},
+		{name: "exec nodes missing", content: "CommitNodes = 4", err: "DONConfig.CommitNodes and DONConfig.ExecNodes must both be set"},
+		{name: "commit nodes missing", content: "ExecNodes = 4", err: "DONConfig.CommitNodes and DONConfig.ExecNodes must both be set"},
+		{name: "negative overlap", content: "CommitNodes = 4\nExecNodes = 4\nOverlap = -1", err: "DONConfig.Overlap must be between 0 and the size of the smaller DON, got -1"},
+		{name: "overlap larger than a DON", content: "CommitNodes = 4\nExecNodes = 7\nOverlap = 5", err: "DONConfig.Overlap must be between 0 and the size of the smaller DON, got 5"},
+		{name: "commit DON too small", content: "CommitNodes = 3\nExecNodes = 4", err: "DONConfig: commit DON has 3 nodes, which cannot tolerate f=0 faulty nodes (3f+1 nodes required, f >= 1)"},
+		{name: "both DONs too small", content: "CommitNodes = 2\nExecNodes = 3", err: "DONConfig: commit DON has 2 nodes, which cannot tolerate f=0 faulty nodes (3f+1 nodes required, f >= 1)"},
+		{
+			name:    "exec DON too small for its f",
+			content: "CommitNodes = 4\nExecNodes = 4",
+			home:    &HomeChainConfig{FPerDON: map[string]int{DON_FAMILY_EXEC: 2}},
+			err:     "DONConfig: exec DON has 4 nodes, which cannot tolerate f=2 faulty nodes (3f+1 nodes required, f >= 1)",
+		},
+		{
+			name:            "plugin node count mismatch",
+			content:         "CommitNodes = 4\nExecNodes = 7\nOverlap = 2",
+			noOfPluginNodes: pointer.ToInt(10),
+			err:             "DONConfig requires 9 plugin nodes (4 commit + 7 exec - 2 overlap), but NoOfPluginNodes is 10",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var don DONConfig
+			require.NoError(t, toml.Unmarshal([]byte(tc.content), &don))
+			err := don.Validate(tc.noOfPluginNodes, tc.home)
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package ccip
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/rs/zerolog/log"
+)
+
+// Duration is a non-negative duration written as "90s", "2m30s" or "1h" in TOML and JSON.
+// Bare integers are read as seconds for backwards compatibility, but are deprecated.
+type Duration struct {
+	time.Duration
+}
+
+// SignedDuration is a Duration that may be negative, for the few fields that need it.
+type SignedDuration struct {
+	time.Duration
+}
+
+func parseDuration(raw string, allowNegative bool) (time.Duration, error) {
+	raw = strings.Trim(strings.TrimSpace(raw), `"`)
+	if raw == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+	var d time.Duration
+	if seconds, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		log.Warn().Str("Value", raw).Msg("durations without unit are deprecated and read as seconds, add an explicit unit like \"s\"")
+		d = time.Duration(seconds) * time.Second
+	} else {
+		d, err = time.ParseDuration(raw)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q, expected a value like \"90s\", \"2m30s\" or \"1h\"", raw)
+		}
+	}
+	if d < 0 && !allowNegative {
+		return 0, fmt.Errorf("duration %q cannot be negative", raw)
+	}
+	return d, nil
+}
+
+func (d *Duration) UnmarshalText(text []byte) (err error) {
+	d.Duration, err = parseDuration(string(text), false)
+	return err
+}
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.Duration.String()), nil
+}
+
+// UnmarshalJSON accepts both strings and bare numbers of seconds.
+func (d *Duration) UnmarshalJSON(b []byte) (err error) {
+	d.Duration, err = parseDuration(string(b), false)
+	return err
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Duration.String())
+}
+
+func (d *SignedDuration) UnmarshalText(text []byte) (err error) {
+	d.Duration, err = parseDuration(string(text), true)
+	return err
+}
+
+func (d SignedDuration) MarshalText() ([]byte, error) {
+	return []byte(d.Duration.String()), nil
+}
+
+func (d *SignedDuration) UnmarshalJSON(b []byte) (err error) {
+	d.Duration, err = parseDuration(string(b), true)
+	return err
+}
+
+func (d SignedDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Duration.String())
+}
+
+// UnmarshalConfig decodes a CCIP config from TOML. Decoding errors, like invalid durations,
+// include the offending line so the field can be found.
+func UnmarshalConfig(content []byte) (*Config, error) {
+	cfg := &Config{}
+	if err := toml.Unmarshal(content, cfg); err != nil {
+		var decodeErr *toml.DecodeError
+		if errors.As(err, &decodeErr) {
+			return nil, fmt.Errorf("invalid CCIP config:\n%s", decodeErr.String())
+		}
+		return nil, fmt.Errorf("invalid CCIP config: %w", err)
+	}
+	return cfg, nil
+}
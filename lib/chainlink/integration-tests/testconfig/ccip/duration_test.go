@@ -0,0 +1,109 @@
+package ccip
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDurationParsing(t *testing.T) {
+	for _, tc := range []struct {
+		raw      string
+		expected time.Duration
+		err      string
+	}{
+		{raw: "90s", expected: 90 * time.Second},
+		{raw: "2m30s", expected: 150 * time.Second},
+		{raw: "1h", expected: time.Hour},
+		{raw: "0s", expected: 0},
+		{raw: "30", expected: 30 * time.Second},
+		{raw: "1.5h", expected: 90 * time.Minute},
+		{raw: "", err: "empty duration"},
+		{raw: "30 seconds", err: `invalid duration "30 seconds"`},
+		{raw: "-5s", err: `duration "-5s" cannot be negative`},
+		{raw: "-5", err: `duration "-5" cannot be negative`},
+	} {
+		t.Run(tc.raw, func(t *testing.T) {
+			var d Duration
+			err := d.UnmarshalText([]byte(tc.raw))
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, d.Duration)
+		})
+	}
+}
+
+func TestSignedDurationAllowsNegative(t *testing.T) {
+	var d SignedDuration
+	require.NoError(t, d.UnmarshalText([]byte("-5s")))
+	require.Equal(t, -5*time.Second, d.Duration)
+}
+
+func TestDurationJSON(t *testing.T) {
+	var v struct{ A, B *Duration }
+	require.NoError(t, json.Unmarshal([]byte(`{"A": "2m", "B": 45}`), &v))
+	require.Equal(t, 2*time.Minute, v.A.Duration)
+	require.Equal(t, 45*time.Second, v.B.Duration)
+	encoded, err := json.Marshal(v)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"A": "2m0s", "B": "45s"}`, string(encoded))
+	require.Error(t, json.Unmarshal([]byte(`{"A": "-1m"}`), &v))
+}
+
+func TestDurationTOMLRoundTrip(t *testing.T) {
+	cfg, err := UnmarshalConfig([]byte("[Timeouts]\nCommitTimeout = '2m30s'\nExecTimeout = 600\n"))
+	require.NoError(t, err)
+	require.Equal(t, 150*time.Second, cfg.Timeouts.GetCommitTimeout())
+	require.Equal(t, 10*time.Minute, cfg.Timeouts.GetExecTimeout())
+
+	encoded, err := toml.Marshal(cfg.Timeouts)
+	require.NoError(t, err)
+	require.Contains(t, string(encoded), "CommitTimeout = '2m30s'")
+}
+
+func TestUnmarshalConfigEchoesField(t *testing.T) {
+	_, err := UnmarshalConfig([]byte("[Timeouts]\nCommitTimeout = '5 minutes'\n"))
+	require.ErrorContains(t, err, "CommitTimeout = '5 minutes'")
+	require.ErrorContains(t, err, `invalid duration "5 minutes"`)
+
+	_, err = UnmarshalConfig([]byte("[RMNConfig.CurseConfig]\nCurseAfter = '-1m'\n"))
+	require.ErrorContains(t, err, "CurseAfter = '-1m'")
+	require.ErrorContains(t, err, "cannot be negative")
+}
+
+// TestDurationFields checks that duration fields take units and refuse negative values.
+func TestDurationFields(t *testing.T) {
+	for _, field := range []string{
+		"[Lifecycle]\nTTL",
+		"Runtime = 'k8s'\n[K8sConfig]\nTTL",
+		"[Notifications]\nMinInterval",
+		"[PriceConfig]\nPriceUpdateInterval",
+		"[PriceConfig]\nStalenessThreshold",
+		"[LoadProfile]\nTestDuration",
+		"[USDCMock]\nAttestationDelay",
+		"[ExecutionScenario]\nManualExecDelay",
+		"[ExecutionScenario]\nPermissionlessExecThreshold",
+		"[Thresholds]\nP95CommitLatency",
+		"[Thresholds]\nP95ExecLatency",
+		"[ConfigRollout]\nPromoteAfter",
+		"[LoadProfile]\nBurstInterval",
+		"[ChainSemantics.SIMULATED_1]\nHardFinalityLag",
+		"[CLNode.BootstrapFailover]\nPromoteAfter",
+		"[CLNode.BootstrapFailover]\nKillActiveAt",
+		"[Remediation]\nTriggerAfter",
+		"[[MidTestTokenOnboarding]]\nAt",
+	} {
+		t.Run(field, func(t *testing.T) {
+			_, err := UnmarshalConfig([]byte(field + " = '1h30m'\n"))
+			require.NoError(t, err)
+			_, err = UnmarshalConfig([]byte(field + " = '-1m'\n"))
+			require.ErrorContains(t, err, "cannot be negative")
+		})
+	}
+}
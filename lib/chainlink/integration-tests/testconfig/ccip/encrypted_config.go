@@ -0,0 +1,146 @@
+package ccip
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+const (
+	// E2E_CCIP_CONFIG_KEY is the AES-256 key of encrypted config files, hex or base64 encoded, or a
+	// secret reference resolving to one
+	E2E_CCIP_CONFIG_KEY = "E2E_CCIP_CONFIG_KEY"
+
+	ENCRYPTED_CONFIG_HEADER = "CCIP-ENCRYPTED-CONFIG"
+	// ENCRYPTED_CONFIG_VERSION is bumped whenever the envelope changes incompatibly
+	ENCRYPTED_CONFIG_VERSION = 1
+)
+
+// EncryptedConfigKey returns the key set through E2E_CCIP_CONFIG_KEY, resolving references with
+// DefaultSecretProviders.
+func EncryptedConfigKey() ([]byte, error) {
+	secret := Secret(os.Getenv(E2E_CCIP_CONFIG_KEY))
+	if secret == "" {
+		return nil, withKind(ErrMissingEnvVar, fmt.Errorf("%s env var is empty", E2E_CCIP_CONFIG_KEY))
+	}
+	value, err := secret.Value()
+	if err != nil {
+		return nil, fieldError(E2E_CCIP_CONFIG_KEY, err)
+	}
+	value = strings.TrimSpace(value)
+	if key, err := hex.DecodeString(value); err == nil {
+		return key, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be hex or base64 encoded", E2E_CCIP_CONFIG_KEY)
+	}
+	return key, nil
+}
+
+// SaveEncrypted writes the config with its secrets to path, encrypted with AES-256-GCM so it can be
+// shared. The file is a header line naming the envelope version followed by the base64 encoded nonce
+// and ciphertext.
+func (o *Config) SaveEncrypted(path string, key []byte) error {
+	content, err := o.marshalWithSecrets()
+	if err != nil {
+		return err
+	}
+	aead, err := newConfigCipher(key)
+	if err != nil {
+		return err
+	}
+	header := encryptedConfigHeader(ENCRYPTED_CONFIG_VERSION)
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	// the header is authenticated too, so the version can't be swapped
+	sealed := aead.Seal(nonce, nonce, content, []byte(header))
+	return os.WriteFile(path, []byte(header+"\n"+base64.StdEncoding.EncodeToString(sealed)+"\n"), 0o600)
+}
+
+// LoadEncryptedConfig reads a config written by SaveEncrypted. Errors matching ErrDecryptionFailed
+// mean the file isn't an encrypted config, the key is wrong or the file was tampered with, other errors
+// are about the decrypted config itself.
+func LoadEncryptedConfig(path string, key []byte) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newConfigCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	header, payload, _ := strings.Cut(string(content), "\n")
+	var version int
+	if _, err := fmt.Sscanf(header, ENCRYPTED_CONFIG_HEADER+" v%d", &version); err != nil {
+		return nil, withKind(ErrDecryptionFailed, fmt.Errorf("%s is not an encrypted config, missing %s header", path, ENCRYPTED_CONFIG_HEADER))
+	}
+	if version != ENCRYPTED_CONFIG_VERSION {
+		return nil, withKind(ErrDecryptionFailed, fmt.Errorf("%s is encrypted with version %d, only version %d is supported", path, version, ENCRYPTED_CONFIG_VERSION))
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(payload))
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return nil, withKind(ErrDecryptionFailed, fmt.Errorf("%s has a malformed payload", path))
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(header))
+	if err != nil {
+		// GCM can't tell a wrong key from a modified file
+		return nil, withKind(ErrDecryptionFailed, fmt.Errorf("failed to decrypt %s, wrong key or the file was modified", path))
+	}
+	return UnmarshalConfig(plaintext)
+}
+
+func encryptedConfigHeader(version int) string {
+	return fmt.Sprintf("%s v%d", ENCRYPTED_CONFIG_HEADER, version)
+}
+
+func newConfigCipher(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("encrypted config key must be 32 bytes, got %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// marshalWithSecrets encodes the config as TOML with the secrets it redacts otherwise.
+func (o *Config) marshalWithSecrets() ([]byte, error) {
+	return MarshalTOMLWithSecrets(o)
+}
+
+// MarshalTOMLWithSecrets encodes v as TOML keeping the values of the secrets Secret redacts otherwise. v is
+// the CCIP config or a config embedding it, e.g. the top level test config. Use it for configs that are read
+// back, like saved or base64 encoded test configs, never for anything displayed.
+func MarshalTOMLWithSecrets(v interface{}) ([]byte, error) {
+	content, err := toml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	secrets := make(map[string]Secret)
+	collectSecrets(reflect.ValueOf(v), "", secrets)
+	if len(secrets) == 0 {
+		return content, nil
+	}
+	var doc map[string]interface{}
+	if err := toml.NewDecoder(bytes.NewReader(content)).Decode(&doc); err != nil {
+		return nil, err
+	}
+	for path, secret := range secrets {
+		setJSONPath(doc, strings.Split(path, "."), string(secret))
+	}
+	return toml.Marshal(doc)
+}
@@ -0,0 +1,128 @@
+package ccip
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// testdata/config.encrypted is wireTestTOML saved with this key
+const encryptedConfigTestKey = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+
+func testConfigKey(t *testing.T, hexKey string) []byte {
+	t.Helper()
+	key, err := hex.DecodeString(hexKey)
+	require.NoError(t, err)
+	return key
+}
+
+func TestEncryptedConfigRoundTrip(t *testing.T) {
+	var original Config
+	require.NoError(t, toml.Unmarshal([]byte(wireTestTOML), &original))
+	key := testConfigKey(t, encryptedConfigTestKey)
+	path := filepath.Join(t.TempDir(), "ccip.toml.encrypted")
+
+	require.NoError(t, original.SaveEncrypted(path, key))
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, string(content), "grafana-token")
+
+	decoded, err := LoadEncryptedConfig(path, key)
+	require.NoError(t, err)
+	require.Equal(t, original, *decoded)
+	require.Equal(t, "grafana-token", secretValue(t, decoded.Observability.GetGrafanaToken()))
+}
+
+func TestMarshalTOMLWithSecrets(t *testing.T) {
+	var original Config
+	require.NoError(t, toml.Unmarshal([]byte(wireTestTOML), &original))
+	// the top level test config embeds the CCIP config
+	type testConfig struct {
+		CCIP *Config `toml:"CCIP"`
+	}
+	redacted, err := toml.Marshal(testConfig{CCIP: &original})
+	require.NoError(t, err)
+	require.NotContains(t, string(redacted), "grafana-token")
+
+	content, err := MarshalTOMLWithSecrets(testConfig{CCIP: &original})
+	require.NoError(t, err)
+	var decoded testConfig
+	require.NoError(t, toml.Unmarshal(content, &decoded))
+	require.Equal(t, original, *decoded.CCIP)
+	require.Equal(t, "grafana-token", secretValue(t, decoded.CCIP.Observability.GetGrafanaToken()))
+}
+
+func TestLoadEncryptedConfigFixture(t *testing.T) {
+	cfg, err := LoadEncryptedConfig("testdata/config.encrypted", testConfigKey(t, encryptedConfigTestKey))
+	require.NoError(t, err)
+	require.Equal(t, "grafana-token", secretValue(t, cfg.Observability.GetGrafanaToken()))
+
+	_, err = LoadEncryptedConfig("testdata/config.encrypted", bytes.Repeat([]byte{1}, 32))
+	require.ErrorIs(t, err, ErrDecryptionFailed)
+	require.ErrorContains(t, err, "wrong key or the file was modified")
+}
+
+func TestLoadEncryptedConfigErrors(t *testing.T) {
+	key := testConfigKey(t, encryptedConfigTestKey)
+	fixture, err := os.ReadFile("testdata/config.encrypted")
+	require.NoError(t, err)
+	header, payload, _ := bytes.Cut(fixture, []byte("\n"))
+	tampered := bytes.Clone(payload)
+	tampered[len(tampered)/2] ^= 'A' ^ 'B'
+
+	for _, tc := range []struct {
+		name    string
+		content []byte
+		err     string
+	}{
+		{name: "plain TOML", content: []byte(wireTestTOML), err: "missing CCIP-ENCRYPTED-CONFIG header"},
+		{name: "newer version", content: append([]byte("CCIP-ENCRYPTED-CONFIG v2\n"), payload...), err: "encrypted with version 2, only version 1 is supported"},
+		{name: "tampered payload", content: append(append(header, '\n'), tampered...), err: "wrong key or the file was modified"},
+		{name: "truncated payload", content: []byte("CCIP-ENCRYPTED-CONFIG v1\nAAAA\n"), err: "malformed payload"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.encrypted")
+			require.NoError(t, os.WriteFile(path, tc.content, 0o600))
+			_, err := LoadEncryptedConfig(path, key)
+			require.ErrorIs(t, err, ErrDecryptionFailed)
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+
+	// decrypted fine, but the config itself is broken
+	aead, err := newConfigCipher(key)
+	require.NoError(t, err)
+	nonce := make([]byte, aead.NonceSize())
+	sealed := aead.Seal(nonce, nonce, []byte("HomeChainSelector = "), []byte(encryptedConfigHeader(ENCRYPTED_CONFIG_VERSION)))
+	invalid := filepath.Join(t.TempDir(), "invalid.encrypted")
+	require.NoError(t, os.WriteFile(invalid, []byte(encryptedConfigHeader(ENCRYPTED_CONFIG_VERSION)+"\n"+base64.StdEncoding.EncodeToString(sealed)), 0o600))
+	_, err = LoadEncryptedConfig(invalid, key)
+	require.ErrorContains(t, err, "invalid CCIP config")
+	require.NotErrorIs(t, err, ErrDecryptionFailed)
+
+	_, err = LoadEncryptedConfig("testdata/config.encrypted", key[:16])
+	require.ErrorContains(t, err, "key must be 32 bytes, got 16")
+	require.NotErrorIs(t, err, ErrDecryptionFailed)
+}
+
+func TestEncryptedConfigKey(t *testing.T) {
+	t.Setenv(E2E_CCIP_CONFIG_KEY, "")
+	_, err := EncryptedConfigKey()
+	require.ErrorIs(t, err, ErrMissingEnvVar)
+
+	t.Setenv(E2E_CCIP_CONFIG_KEY, encryptedConfigTestKey)
+	key, err := EncryptedConfigKey()
+	require.NoError(t, err)
+	require.Equal(t, testConfigKey(t, encryptedConfigTestKey), key)
+
+	t.Setenv(E2E_CCIP_CONFIG_KEY, "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=")
+	key, err = EncryptedConfigKey()
+	require.NoError(t, err)
+	require.Equal(t, testConfigKey(t, encryptedConfigTestKey), key)
+}
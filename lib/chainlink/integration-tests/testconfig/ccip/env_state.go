@@ -0,0 +1,129 @@
+package ccip
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ENV_STATE_VERSION is bumped whenever EnvState changes incompatibly.
+const ENV_STATE_VERSION = 2
+
+// EnvState is what a later run needs to attach to a kept alive environment.
+// Credentials are only ever stored as secret provider references.
+type EnvState struct {
+	Version     int    `json:"version"`
+	Fingerprint string `json:"fingerprint"`
+	// ConfigTOML is the fingerprinted config, kept to explain fingerprint mismatches
+	ConfigTOML string `json:"configToml"`
+	// DockerNetwork is the network the containers of the environment run in
+	DockerNetwork string       `json:"dockerNetwork"`
+	Chains        []ChainState `json:"chains"`
+	// Contracts is the address book of the environment keyed by chain selector and address
+	Contracts map[uint64]map[string]DeployedContract `json:"contracts"`
+	Nodes     []NodeState                            `json:"nodes"`
+	// Jobs are the IDs of the jobs proposed to the nodes keyed by node ID
+	Jobs   map[string][]string `json:"jobs"`
+	JDGRPC string              `json:"jdGrpc"`
+	// JDWSRPC is the endpoint the nodes connect to the job distributor on
+	JDWSRPC string `json:"jdWsrpc"`
+}
+
+// ChainState are the RPCs of a chain the environment started, the internal ones are reachable from its
+// docker network.
+type ChainState struct {
+	ChainID          uint64   `json:"chainId"`
+	HTTPRPCs         []string `json:"httpRpcs"`
+	WSRPCs           []string `json:"wsRpcs"`
+	InternalHTTPRPCs []string `json:"internalHttpRpcs"`
+	InternalWSRPCs   []string `json:"internalWsRpcs"`
+}
+
+type NodeState struct {
+	// Name is the name of the node in the DON, e.g. bootstrap-1 or node-1
+	Name          string `json:"name"`
+	ContainerName string `json:"containerName"`
+	IsBootstrap   bool   `json:"isBootstrap"`
+	APIURL        string `json:"apiUrl"`
+	InternalIP    string `json:"internalIp"`
+	Email         string `json:"email"`
+	// PasswordRef is a secret provider reference, e.g. vault://ccip/env-1/node-1#password
+	PasswordRef string `json:"passwordRef"`
+}
+
+// SaveState writes the state of the environment created from this config as versioned JSON.
+func (o *Config) SaveState(path string, state EnvState) error {
+	for _, node := range state.Nodes {
+		if node.PasswordRef != "" && !Secret(node.PasswordRef).IsReference() {
+			return fmt.Errorf("node %s: PasswordRef must be a secret provider reference, not a literal password", node.Name)
+		}
+	}
+	content, err := o.fingerprintTOML()
+	if err != nil {
+		return err
+	}
+	state.Version = ENV_STATE_VERSION
+	state.ConfigTOML = string(content)
+	if state.Fingerprint, err = o.Fingerprint(); err != nil {
+		return err
+	}
+	encoded, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, encoded, 0o600)
+}
+
+// LoadState reads the environment state and checks it was created from an equivalent config,
+// failing with a diff of the two configs otherwise.
+func (o *Config) LoadState(path string) (*EnvState, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	state := &EnvState{}
+	if err := json.Unmarshal(content, state); err != nil {
+		return nil, fmt.Errorf("invalid environment state %s: %w", path, err)
+	}
+	if state.Version != ENV_STATE_VERSION {
+		return nil, fmt.Errorf("environment state %s has version %d, expected %d", path, state.Version, ENV_STATE_VERSION)
+	}
+	fingerprint, err := o.Fingerprint()
+	if err != nil {
+		return nil, err
+	}
+	if fingerprint != state.Fingerprint {
+		current, err := o.fingerprintTOML()
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("environment state %s was created from a different config:\n%s", path, diffLines(state.ConfigTOML, string(current)))
+	}
+	return state, nil
+}
+
+// RecordDeployedContracts records the address book of the environment, keyed by chain selector and address,
+// for the EnvState of a kept alive environment.
+func (o *Config) RecordDeployedContracts(contracts map[uint64]map[string]DeployedContract) {
+	o.deployedContracts = contracts
+}
+
+// DeployedContracts returns the address book recorded by RecordDeployedContracts.
+func (o *Config) DeployedContracts() map[uint64]map[string]DeployedContract {
+	return o.deployedContracts
+}
+
+// RecordProposedJobs records the IDs of the jobs proposed to the nodes, keyed by node ID, for the EnvState
+// of a kept alive environment.
+func (o *Config) RecordProposedJobs(jobs map[string][]string) {
+	o.proposedJobs = jobs
+}
+
+// ProposedJobs returns the job IDs recorded by RecordProposedJobs.
+func (o *Config) ProposedJobs() map[string][]string {
+	return o.proposedJobs
+}
@@ -0,0 +1,53 @@
+package ccip
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	cfg := &Config{
+		HomeChainSelector: pointer.ToString("3379446385462418246"),
+		CLNode:            &NodeConfig{NoOfPluginNodes: pointer.ToInt(4)},
+	}
+	state := EnvState{
+		DockerNetwork: "ccip-net",
+		Chains: []ChainState{{
+			ChainID:          1337,
+			HTTPRPCs:         []string{"http://127.0.0.1:8545"},
+			InternalHTTPRPCs: []string{"http://geth-1337:8545"},
+		}},
+		Contracts: map[uint64]map[string]DeployedContract{
+			3379446385462418246: {"0x0000000000000000000000000000000000000001": {Type: "Router", Version: "1.2.0"}},
+		},
+		Nodes: []NodeState{{
+			Name:          "node-1",
+			ContainerName: "cl-node-1a2b3c4d",
+			APIURL:        "http://node-1:6688",
+			PasswordRef:   "vault://ccip/node-1#password",
+		}},
+		Jobs:   map[string][]string{"node-id-1": {"job-1", "job-2"}},
+		JDGRPC: "jd:42242",
+	}
+	require.NoError(t, cfg.SaveState(path, state))
+
+	// fields that don't describe the environment don't invalidate the state
+	cfg.Phases = []string{PHASE_TRAFFIC, PHASE_ASSERT}
+	loaded, err := cfg.LoadState(path)
+	require.NoError(t, err)
+	require.Equal(t, state.Chains, loaded.Chains)
+	require.Equal(t, state.Contracts, loaded.Contracts)
+	require.Equal(t, state.Nodes, loaded.Nodes)
+	require.Equal(t, state.Jobs, loaded.Jobs)
+
+	cfg.CLNode.NoOfPluginNodes = pointer.ToInt(7)
+	_, err = cfg.LoadState(path)
+	require.ErrorContains(t, err, "- NoOfPluginNodes = 4")
+
+	state.Nodes[0].PasswordRef = "hunter2"
+	require.ErrorContains(t, cfg.SaveState(path, state), "must be a secret provider reference")
+}
@@ -0,0 +1,141 @@
+package ccip
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	// ENVIRONMENT_PORT_OFFSET_STEP is the distance between the host port ranges of two environments.
+	ENVIRONMENT_PORT_OFFSET_STEP = 1000
+	// ENVIRONMENT_PORT_OFFSET_SLOTS is the number of port offsets environments are hashed into, which keeps
+	// the default port range below 65535 with any offset.
+	ENVIRONMENT_PORT_OFFSET_SLOTS = 30
+)
+
+// Environments describes several isolated CCIP environments started by one test binary.
+// Every entry is merged over Defaults and namespaced by its key.
+type Environments struct {
+	Defaults     *Config            `toml:",omitempty"`
+	Environments map[string]*Config `toml:",omitempty"`
+}
+
+// EnvironmentNamespace keeps the containers, networks and host ports of an environment apart from the others.
+type EnvironmentNamespace struct {
+	Name            string
+	ContainerPrefix string
+	DockerNetwork   string
+	PortOffset      int
+}
+
+// Names returns the environment keys, sorted.
+func (e *Environments) Names() []string {
+	names := make([]string, 0, len(e.Environments))
+	for name := range e.Environments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Namespace derives the namespace of the environment from its key alone, so adding or removing an environment
+// doesn't move the others. Two keys may hash to the same PortOffset, Validate reports it and DockerConfig.PortOffset
+// resolves it.
+func (e *Environments) Namespace(name string) (EnvironmentNamespace, error) {
+	if _, ok := e.Environments[name]; !ok {
+		return EnvironmentNamespace{}, fmt.Errorf("environment %s is not configured, available: %v", name, e.Names())
+	}
+	return EnvironmentNamespace{
+		Name:            name,
+		ContainerPrefix: "ccip-" + name,
+		DockerNetwork:   "ccip-" + name,
+		PortOffset:      environmentPortOffset(name),
+	}, nil
+}
+
+// environmentPortOffset hashes the environment key into one of ENVIRONMENT_PORT_OFFSET_SLOTS offsets, never 0.
+func environmentPortOffset(name string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	return (int(h.Sum32()%ENVIRONMENT_PORT_OFFSET_SLOTS) + 1) * ENVIRONMENT_PORT_OFFSET_STEP
+}
+
+// Get returns the config of the named environment, merged over Defaults and namespaced.
+// Explicitly configured namespacing fields are kept.
+func (e *Environments) Get(name string) (*Config, error) {
+	ns, err := e.Namespace(name)
+	if err != nil {
+		return nil, err
+	}
+	cfg := mergeConfig(e.Defaults, e.Environments[name])
+	switch cfg.GetRuntime() {
+	case RUNTIME_K8S:
+		if cfg.K8sConfig == nil {
+			cfg.K8sConfig = &K8sConfig{}
+		}
+		if namespace := pointer.GetString(cfg.K8sConfig.Namespace); namespace != "" {
+			cfg.K8sConfig.Namespace = pointer.ToString(namespace + "-" + name)
+		}
+	default:
+		if cfg.DockerConfig == nil {
+			cfg.DockerConfig = &DockerConfig{}
+		}
+		if cfg.DockerConfig.ContainerPrefix == nil {
+			cfg.DockerConfig.ContainerPrefix = pointer.ToString(ns.ContainerPrefix)
+		}
+		if cfg.DockerConfig.NetworkName == nil && cfg.DockerConfig.ReuseNetwork == nil {
+			cfg.DockerConfig.NetworkName = pointer.ToString(ns.DockerNetwork)
+		}
+		if cfg.DockerConfig.PortOffset == nil {
+			cfg.DockerConfig.PortOffset = pointer.ToInt(ns.PortOffset)
+		}
+	}
+	return cfg, nil
+}
+
+// Validate validates every environment and that no two environments claim the same host port or chain id.
+func (e *Environments) Validate() error {
+	if len(e.Environments) == 0 {
+		return fmt.Errorf("Environments must contain at least one environment")
+	}
+	ports := make(map[int]string)
+	chainIDs := make(map[int64]string)
+	for _, name := range e.Names() {
+		cfg, err := e.Get(name)
+		if err != nil {
+			return err
+		}
+		if err := cfg.Validate(); err != nil {
+			return fieldError("Environments."+name, err)
+		}
+		offset := 0
+		if cfg.DockerConfig != nil {
+			offset = pointer.GetInt(cfg.DockerConfig.PortOffset)
+		}
+		allocator, err := cfg.PortAllocator()
+		if err != nil {
+			return fieldError("Environments."+name, err)
+		}
+		for _, claim := range allocator.Claims() {
+			hostPort := claim.Port + offset
+			if other, ok := ports[hostPort]; ok {
+				return withKind(ErrPortCollision, fmt.Errorf("Environments.%s: host port %d of %s is already used by environment %s", name, hostPort, claim.Claimant, other))
+			}
+			ports[hostPort] = name
+		}
+		for network, netCfg := range cfg.PrivateEthereumNetworks {
+			if netCfg == nil || netCfg.EthereumChainConfig == nil {
+				continue
+			}
+			chainID := int64(netCfg.EthereumChainConfig.ChainID)
+			if other, ok := chainIDs[chainID]; ok {
+				return fmt.Errorf("Environments.%s: chain id %d of %s is already used by environment %s", name, chainID, network, other)
+			}
+			chainIDs[chainID] = name
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const environmentsTestTOML = `
+[Defaults]
+HomeChainSelector = '3379446385462418246'
+
+[Defaults.CLNode]
+NoOfPluginNodes = 4
+NoOfBootstraps = 1
+
+[Defaults.PrivateEthereumNetworks.SIMULATED_1.EthereumChainConfig]
+chain_id = 1337
+
+[Environments.v15]
+
+[Environments.v16.CLNode]
+NoOfPluginNodes = 7
+
+[Environments.v16.PrivateEthereumNetworks.SIMULATED_1.EthereumChainConfig]
+chain_id = 2337
+`
+
+func TestEnvironmentsGet(t *testing.T) {
+	var envs Environments
+	require.NoError(t, toml.Unmarshal([]byte(environmentsTestTOML), &envs))
+
+	v15, err := envs.Get("v15")
+	require.NoError(t, err)
+	v16, err := envs.Get("v16")
+	require.NoError(t, err)
+
+	require.Equal(t, 4, v15.CLNode.GetNoOfPluginNodes())
+	require.Equal(t, 7, v16.CLNode.GetNoOfPluginNodes())
+	// defaults not overridden are inherited
+	require.Equal(t, 1, pointer.GetInt(v16.CLNode.NoOfBootstraps))
+	require.Equal(t, "3379446385462418246", *v16.HomeChainSelector)
+	// merging must not leak into the shared defaults
+	require.Equal(t, 4, envs.Defaults.CLNode.GetNoOfPluginNodes())
+	require.Equal(t, 1337, envs.Defaults.PrivateEthereumNetworks["SIMULATED_1"].EthereumChainConfig.ChainID)
+
+	require.Equal(t, &DockerRuntimeConfig{NetworkName: "ccip-v15", ContainerPrefix: "ccip-v15", PortOffset: 6000}, v15.GetRuntimeConfig())
+	require.Equal(t, &DockerRuntimeConfig{NetworkName: "ccip-v16", ContainerPrefix: "ccip-v16", PortOffset: 5000}, v16.GetRuntimeConfig())
+	require.Equal(t, 5000+NODE_HTTP_PORT, v16.HostPort(NODE_HTTP_PORT))
+
+	_, err = envs.Get("v17")
+	require.Error(t, err)
+}
+
+func TestEnvironmentsValidateCollisions(t *testing.T) {
+	var envs Environments
+	require.NoError(t, toml.Unmarshal([]byte(environmentsTestTOML), &envs))
+	require.NoError(t, envs.Validate())
+
+	envs.Environments["v16"].PrivateEthereumNetworks = nil
+	require.ErrorContains(t, envs.Validate(), "chain id 1337 of SIMULATED_1 is already used by environment v15")
+
+	envs.Environments["v16"].DockerConfig = &DockerConfig{PortOffset: pointer.ToInt(6000)}
+	envs.Environments["v16"].PrivateEthereumNetworks = envs.Defaults.PrivateEthereumNetworks
+	envs.Defaults.PrivateEthereumNetworks = nil
+	require.ErrorContains(t, envs.Validate(), "is already used by environment v15")
+}
+
+func TestEnvironmentsNamespaceIsStable(t *testing.T) {
+	var envs Environments
+	require.NoError(t, toml.Unmarshal([]byte(environmentsTestTOML), &envs))
+	before, err := envs.Namespace("v16")
+	require.NoError(t, err)
+
+	// adding an environment sorting before v16 leaves its namespace alone
+	envs.Environments["v14"] = &Config{}
+	after, err := envs.Namespace("v16")
+	require.NoError(t, err)
+	require.Equal(t, before, after)
+	require.Greater(t, after.PortOffset, 0)
+	require.LessOrEqual(t, after.PortOffset, ENVIRONMENT_PORT_OFFSET_SLOTS*ENVIRONMENT_PORT_OFFSET_STEP)
+}
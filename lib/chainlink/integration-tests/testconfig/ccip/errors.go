@@ -0,0 +1,99 @@
+// Package ccip is the CCIP section of the integration test config and the helpers resolving it.
+//
+// Errors of the following kinds can be told apart with errors.Is, whatever the field they were
+// reported for. errors.As with a *FieldError returns the field, when the error names one, and its
+// description and allowed values from the field registry.
+//   - ErrMissingEnvVar: a required value is neither configured nor set through its env var
+//   - ErrInvalidEndpoint: a URL or host:port is malformed, or a component has no usable endpoint
+//   - ErrInvalidPort: a port is outside of 1-65535
+//   - ErrPortCollision: two components claim the same port
+//   - ErrNoFreePort: the port range has no port left to assign
+//   - ErrChainNotConfigured: a chain reference resolves to no chain, or a chain is configured that
+//     isn't one of the networks
+//   - ErrInsufficientNodes: there are too few nodes for the DON sizes, fault tolerance or node indexes
+//   - ErrTokenNotConfigured: a token symbol is not configured in Tokens
+//   - ErrSecretUnresolved: a secret reference couldn't be resolved, in which case ErrUnknownSecretScheme
+//     tells if there is no provider for its scheme
+//   - ErrDecryptionFailed: an encrypted config couldn't be decrypted, because of a wrong key, a modified
+//     file or a file that isn't an encrypted config
+//   - ErrRPCBudgetExceeded: a request to a chain was failed because it is over the RPCBudget of the chain
+//   - ErrInvalidHomeChainSelector and ErrInvalidFeedChainSelector: the home or feed chain selector is
+//     missing or invalid
+//   - ErrInvalidValue: a value is malformed or outside of its allowed range, Validate reports every
+//     error that is none of the kinds above as one
+package ccip
+
+import (
+	"errors"
+)
+
+var (
+	ErrMissingEnvVar      = errors.New("missing env var")
+	ErrInvalidEndpoint    = errors.New("invalid endpoint")
+	ErrInvalidPort        = errors.New("invalid port")
+	ErrPortCollision      = errors.New("port collision")
+	ErrNoFreePort         = errors.New("no free port")
+	ErrChainNotConfigured = errors.New("chain not configured")
+	ErrInsufficientNodes  = errors.New("insufficient nodes")
+	ErrTokenNotConfigured = errors.New("token not configured")
+	ErrSecretUnresolved   = errors.New("secret unresolved")
+	ErrDecryptionFailed   = errors.New("decryption failed")
+	ErrRPCBudgetExceeded  = errors.New("RPC budget exceeded")
+	ErrInvalidValue       = errors.New("invalid value")
+)
+
+// FieldError is an error reported for a config field.
+type FieldError struct {
+	// Field is the path of the field in the CCIP config, e.g. JobDistributorConfig.JDGRPC
+	Field string
+	Err   error
+	// Doc is the description and allowed values of the field from the field registry, appended to the message
+	Doc string
+}
+
+func (e *FieldError) Error() string {
+	if e.Doc == "" {
+		return e.Field + ": " + e.Err.Error()
+	}
+	return e.Field + ": " + e.Err.Error() + " (" + e.Doc + ")"
+}
+
+func (e *FieldError) Unwrap() error { return e.Err }
+
+// kindError makes err match the catalog error kind with errors.Is, without changing its message.
+type kindError struct {
+	kind error
+	err  error
+}
+
+func (e *kindError) Error() string { return e.err.Error() }
+
+func (e *kindError) Unwrap() []error { return []error{e.kind, e.err} }
+
+// fieldError reports err for the field at path, e.g. "Tokens.LINK.PoolType", with the field's description
+// and allowed values when the field registry knows the path.
+func fieldError(path string, err error) error {
+	e := &FieldError{Field: path, Err: err}
+	if info, ok := lookupField(path); ok {
+		e.Doc = fieldDoc(info)
+	}
+	return e
+}
+
+func withKind(kind, err error) error {
+	return &kindError{kind: kind, err: err}
+}
+
+// invalidValue makes err an ErrInvalidValue, unless it already is of one of the catalog kinds.
+func invalidValue(err error) error {
+	for _, kind := range []error{
+		ErrMissingEnvVar, ErrInvalidEndpoint, ErrInvalidPort, ErrPortCollision, ErrNoFreePort, ErrChainNotConfigured,
+		ErrInsufficientNodes, ErrTokenNotConfigured, ErrSecretUnresolved, ErrUnknownSecretScheme, ErrDecryptionFailed,
+		ErrRPCBudgetExceeded, ErrInvalidHomeChainSelector, ErrInvalidFeedChainSelector, ErrInvalidValue,
+	} {
+		if errors.Is(err, kind) {
+			return err
+		}
+	}
+	return withKind(ErrInvalidValue, err)
+}
@@ -0,0 +1,201 @@
+package ccip
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/blockchain"
+)
+
+func TestErrorCatalog(t *testing.T) {
+	t.Setenv(E2E_JD_GRPC, "")
+	simulated1 := []blockchain.EVMNetwork{{Name: "SIMULATED_1", ChainID: 1337, HTTPURLs: []string{"http://geth:8545"}}}
+	grafanaURL := "ftp://grafana"
+	unregistered := Secret("awssm://ccip-webhook")
+
+	for _, tc := range []struct {
+		name  string
+		err   func() error
+		kind  error
+		field string
+	}{
+		{
+			name: "missing env var",
+			err: func() error {
+				_, err := (&Config{
+					HomeChainSelector: pointer.ToString("3379446385462418246"),
+					FeedChainSelector: pointer.ToString("3379446385462418246"),
+					CLNode:            &NodeConfig{NoOfPluginNodes: pointer.ToInt(4)},
+				}).ToDeploymentInput(simulated1)
+				return err
+			},
+			kind:  ErrMissingEnvVar,
+			field: "JobDistributorConfig.JDGRPC",
+		},
+		{
+			name:  "invalid endpoint",
+			err:   (&Observability{GrafanaURL: &grafanaURL}).Validate,
+			kind:  ErrInvalidEndpoint,
+			field: "Observability.GrafanaURL",
+		},
+		{
+			name:  "invalid port",
+			err:   (&Config{PortRangeStart: pointer.ToInt(0)}).validatePorts,
+			kind:  ErrInvalidPort,
+			field: "PortRangeStart",
+		},
+		{
+			name: "port collision",
+			err: (&Config{
+				JobDistributorConfig: JDConfig{JDGRPC: pointer.ToString("localhost:9933")},
+				USDCMock:             &USDCMockConfig{Enabled: pointer.ToBool(true), Port: pointer.ToInt(9933)},
+			}).validatePorts,
+			kind: ErrPortCollision,
+		},
+		{
+			name: "no free port",
+			err: func() error {
+				allocator := NewPortAllocator(30000, 30000)
+				_, err := allocator.Allocate("first")
+				require.NoError(t, err)
+				_, err = allocator.Allocate("second")
+				return err
+			},
+			kind: ErrNoFreePort,
+		},
+		{
+			name:  "chain not configured",
+			err:   (&Config{GasStrategy: map[string]*GasStrategy{"SIMULATED_9": {}}}).validateGasStrategy,
+			kind:  ErrChainNotConfigured,
+			field: "GasStrategy.SIMULATED_9",
+		},
+		{
+			name: "insufficient nodes",
+			err: func() error {
+				return (&DONConfig{CommitNodes: pointer.ToInt(2), ExecNodes: pointer.ToInt(4)}).Validate(nil, nil)
+			},
+			kind: ErrInsufficientNodes,
+		},
+		{
+			name: "token not configured",
+			err: func() error {
+				_, err := (&Config{}).GetTokenPoolType("USDC")
+				return err
+			},
+			kind: ErrTokenNotConfigured,
+		},
+		{
+			name: "secret unresolved",
+			err: func() error {
+				_, err := unregistered.Value()
+				return err
+			},
+			kind: ErrSecretUnresolved,
+		},
+		{
+			name: "unknown secret scheme",
+			err: func() error {
+				_, err := NewSecretProviderRegistry().Resolve("foo://x")
+				return err
+			},
+			kind: ErrUnknownSecretScheme,
+		},
+		{
+			name:  "invalid value",
+			err:   func() error { return (&PriceConfig{GasPriceDeviationPPB: pointer.ToUint64(0)}).Validate(nil) },
+			kind:  ErrInvalidValue,
+			field: "PriceConfig.GasPriceDeviationPPB",
+		},
+		{
+			name: "invalid value of any other kind",
+			err: func() error {
+				return (&Config{Tokens: map[string]*TokenConfig{"LINK": {PoolType: pointer.ToString("mintBurn")}}}).Validate()
+			},
+			kind: ErrInvalidValue,
+		},
+		{
+			name: "invalid home chain selector",
+			err: func() error {
+				_, err := (&Config{}).ToDeploymentInput(simulated1)
+				return err
+			},
+			kind:  ErrInvalidHomeChainSelector,
+			field: "HomeChainSelector",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.err()
+			require.ErrorIs(t, err, tc.kind)
+			var fieldErr *FieldError
+			if tc.field == "" {
+				return
+			}
+			require.True(t, errors.As(err, &fieldErr))
+			require.Equal(t, tc.field, fieldErr.Field)
+			require.ErrorIs(t, fieldErr.Err, tc.kind)
+		})
+	}
+}
+
+func TestErrorCatalogKeepsMessages(t *testing.T) {
+	_, err := (&Config{}).ResolveChainSelector("SIMULATED_9")
+	require.EqualError(t, err, "chain SIMULATED_9 is neither a chain selector, a configured private network nor a known chain name")
+	require.NotErrorIs(t, err, ErrTokenNotConfigured)
+}
+
+func TestSelectorSentinels(t *testing.T) {
+	cfg := &Config{HomeChainSelector: pointer.ToString("12922642891491394802"), FeedChainSelector: pointer.ToString("12922642891491394802")}
+	simulated1 := []blockchain.EVMNetwork{{Name: "SIMULATED_1", ChainID: 1337}}
+	_, err := cfg.GetHomeChainSelector(simulated1)
+	require.True(t, err == ErrInvalidHomeChainSelector)
+	_, err = cfg.GetFeedChainSelector(simulated1)
+	require.True(t, err == ErrInvalidFeedChainSelector)
+}
+
+func TestParseChainSelectors(t *testing.T) {
+	simulated1 := []blockchain.EVMNetwork{{Name: "SIMULATED_1", ChainID: 1337}}
+	for _, tc := range []struct {
+		name       string
+		selector   *string
+		parsed     uint64
+		parseErr   bool
+		configured bool
+	}{
+		{name: "nil", parseErr: true},
+		{name: "empty", selector: pointer.ToString(""), parseErr: true},
+		{name: "garbage", selector: pointer.ToString("geth-testnet"), parseErr: true},
+		{name: "unknown selector", selector: pointer.ToString("1234"), parseErr: true},
+		{name: "registered but not configured", selector: pointer.ToString("12922642891491394802"), parsed: 12922642891491394802},
+		{name: "valid", selector: pointer.ToString("3379446385462418246"), parsed: 3379446385462418246, configured: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &Config{HomeChainSelector: tc.selector, FeedChainSelector: tc.selector}
+			for _, c := range []struct {
+				parse func() (uint64, error)
+				get   func([]blockchain.EVMNetwork) (uint64, error)
+				kind  error
+			}{
+				{cfg.ParseHomeChainSelector, cfg.GetHomeChainSelector, ErrInvalidHomeChainSelector},
+				{cfg.ParseFeedChainSelector, cfg.GetFeedChainSelector, ErrInvalidFeedChainSelector},
+			} {
+				parsed, err := c.parse()
+				if tc.parseErr {
+					require.ErrorIs(t, err, c.kind)
+				} else {
+					require.NoError(t, err)
+					require.Equal(t, tc.parsed, parsed)
+				}
+				selector, err := c.get(simulated1)
+				if !tc.configured {
+					require.ErrorIs(t, err, c.kind)
+					continue
+				}
+				require.NoError(t, err)
+				require.Equal(t, tc.parsed, selector)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package ccip
+
+import (
+	"fmt"
+)
+
+// DEFAULT_MAX_MESSAGES_PER_BATCH is the largest batch of messages a commit report covers per source chain
+// unless MaxMessagesPerBatch is set
+const DEFAULT_MAX_MESSAGES_PER_BATCH = 256
+
+// ExecConfig holds the exec plugin parameters tests need to reason about.
+type ExecConfig struct {
+	// MaxGasPriceMultiplier caps the dest chain gas price the exec plugin is willing to pay, as a multiple
+	// of the gas price at the time the message was sent. Unset means execution is never delayed on cost.
+	MaxGasPriceMultiplier *float64 `toml:",omitempty"`
+	// MaxMessagesPerBatch is the largest batch of messages committed and executed together
+	MaxMessagesPerBatch *int `toml:",omitempty"`
+}
+
+// GetMaxGasPriceMultiplier returns the gas price cap multiplier, or false if execution is not capped.
+func (e *ExecConfig) GetMaxGasPriceMultiplier() (float64, bool) {
+	if e == nil || e.MaxGasPriceMultiplier == nil {
+		return 0, false
+	}
+	return *e.MaxGasPriceMultiplier, true
+}
+
+func (e *ExecConfig) GetMaxMessagesPerBatch() int {
+	if e == nil || e.MaxMessagesPerBatch == nil {
+		return DEFAULT_MAX_MESSAGES_PER_BATCH
+	}
+	return *e.MaxMessagesPerBatch
+}
+
+func (e *ExecConfig) Validate() error {
+	if e.MaxGasPriceMultiplier != nil && *e.MaxGasPriceMultiplier < 1 {
+		return fmt.Errorf("ExecConfig.MaxGasPriceMultiplier must be at least 1, got %f", *e.MaxGasPriceMultiplier)
+	}
+	if e.MaxMessagesPerBatch != nil && *e.MaxMessagesPerBatch < 1 {
+		return fmt.Errorf("ExecConfig.MaxMessagesPerBatch must be at least 1, got %d", *e.MaxMessagesPerBatch)
+	}
+	return nil
+}
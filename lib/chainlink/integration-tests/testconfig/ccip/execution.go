@@ -0,0 +1,91 @@
+package ccip
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	EXEC_MODE_SMART  = "smart"
+	EXEC_MODE_MANUAL = "manual"
+	EXEC_MODE_MIXED  = "mixed"
+
+	// DEFAULT_PERMISSIONLESS_EXEC_THRESHOLD mirrors the offramp dynamic config set by the ccip deployment changesets
+	DEFAULT_PERMISSIONLESS_EXEC_THRESHOLD = 24 * time.Hour
+	// DEFAULT_COMMIT_INTERVAL mirrors DeltaRound of the commit OCR instance
+	DEFAULT_COMMIT_INTERVAL = 2 * time.Second
+)
+
+// ExecutionScenario selects whether messages are executed by the DON (smart execution) or left for manual execution.
+type ExecutionScenario struct {
+	Mode *string `toml:",omitempty"`
+	// ManualExecDelay is how long the test waits after the message is committed before executing it manually
+	ManualExecDelay *Duration `toml:",omitempty"`
+	// PermissionlessExecThreshold is set on the offramp; after it passes anyone can execute the message
+	PermissionlessExecThreshold *Duration `toml:",omitempty"`
+	// ManualExecRatio is the fraction of messages left for manual execution in mixed mode
+	ManualExecRatio *float64 `toml:",omitempty"`
+}
+
+func (e *ExecutionScenario) GetMode() string {
+	if e == nil || pointer.GetString(e.Mode) == "" {
+		return EXEC_MODE_SMART
+	}
+	return *e.Mode
+}
+
+func (e *ExecutionScenario) GetManualExecDelay() time.Duration {
+	if e == nil || e.ManualExecDelay == nil {
+		return 0
+	}
+	return e.ManualExecDelay.Duration
+}
+
+func (e *ExecutionScenario) GetPermissionlessExecThreshold() time.Duration {
+	if e == nil || e.PermissionlessExecThreshold == nil {
+		return DEFAULT_PERMISSIONLESS_EXEC_THRESHOLD
+	}
+	return e.PermissionlessExecThreshold.Duration
+}
+
+// GetManualExecRatio returns the fraction of messages the load generator should leave for manual execution.
+func (e *ExecutionScenario) GetManualExecRatio() float64 {
+	switch e.GetMode() {
+	case EXEC_MODE_MANUAL:
+		return 1
+	case EXEC_MODE_MIXED:
+		return pointer.GetFloat64(e.ManualExecRatio)
+	default:
+		return 0
+	}
+}
+
+func (e *ExecutionScenario) Validate() error {
+	mode := e.GetMode()
+	switch mode {
+	case EXEC_MODE_SMART, EXEC_MODE_MANUAL, EXEC_MODE_MIXED:
+	default:
+		return fieldError("ExecutionScenario.Mode", fmt.Errorf("unknown value %q", mode))
+	}
+	if e.GetPermissionlessExecThreshold() <= DEFAULT_COMMIT_INTERVAL {
+		return fmt.Errorf("ExecutionScenario.PermissionlessExecThreshold (%s) must exceed the commit interval (%s)",
+			e.GetPermissionlessExecThreshold(), DEFAULT_COMMIT_INTERVAL)
+	}
+	if mode == EXEC_MODE_MIXED {
+		if e.ManualExecRatio == nil {
+			return fmt.Errorf("ExecutionScenario.ManualExecRatio must be set in %s mode", EXEC_MODE_MIXED)
+		}
+		if *e.ManualExecRatio <= 0 || *e.ManualExecRatio >= 1 {
+			return fmt.Errorf("ExecutionScenario.ManualExecRatio must be between 0 and 1 (exclusive), got %f", *e.ManualExecRatio)
+		}
+	} else if e.ManualExecRatio != nil {
+		return fmt.Errorf("ExecutionScenario.ManualExecRatio is only allowed in %s mode", EXEC_MODE_MIXED)
+	}
+	if mode != EXEC_MODE_SMART && e.GetManualExecDelay() < e.GetPermissionlessExecThreshold() {
+		return fmt.Errorf("ExecutionScenario.ManualExecDelay (%s) must be at least PermissionlessExecThreshold (%s), "+
+			"otherwise messages are still within the smart execution window", e.GetManualExecDelay(), e.GetPermissionlessExecThreshold())
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionScenarioDefaults(t *testing.T) {
+	var e *ExecutionScenario
+	require.Equal(t, EXEC_MODE_SMART, e.GetMode())
+	require.Zero(t, e.GetManualExecDelay())
+	require.Equal(t, DEFAULT_PERMISSIONLESS_EXEC_THRESHOLD, e.GetPermissionlessExecThreshold())
+	require.Zero(t, e.GetManualExecRatio())
+	require.NoError(t, (&ExecutionScenario{}).Validate())
+}
+
+func TestValidateExecutionScenario(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+		ratio   float64
+		err     string
+	}{
+		{name: "smart", content: "Mode = 'smart'"},
+		{name: "unknown mode", content: "Mode = 'eager'", err: `ExecutionScenario.Mode: unknown value "eager"`},
+		{name: "threshold at the commit interval", content: "PermissionlessExecThreshold = '2s'", err: "ExecutionScenario.PermissionlessExecThreshold (2s) must exceed the commit interval (2s)"},
+		{name: "manual", content: "Mode = 'manual'\nPermissionlessExecThreshold = '1m'\nManualExecDelay = '1m'", ratio: 1},
+		{name: "manual before the threshold", content: "Mode = 'manual'\nPermissionlessExecThreshold = '1m'\nManualExecDelay = '59s'", err: "ExecutionScenario.ManualExecDelay (59s) must be at least PermissionlessExecThreshold (1m0s), otherwise messages are still within the smart execution window"},
+		{name: "manual with the default threshold", content: "Mode = 'manual'", err: "ExecutionScenario.ManualExecDelay (0s) must be at least PermissionlessExecThreshold (24h0m0s)"},
+		{name: "mixed", content: "Mode = 'mixed'\nManualExecRatio = 0.25\nPermissionlessExecThreshold = '1m'\nManualExecDelay = '2m'", ratio: 0.25},
+		{name: "mixed without ratio", content: "Mode = 'mixed'", err: "ExecutionScenario.ManualExecRatio must be set in mixed mode"},
+		{name: "mixed with ratio 0", content: "Mode = 'mixed'\nManualExecRatio = 0.0", err: "ExecutionScenario.ManualExecRatio must be between 0 and 1 (exclusive), got 0.000000"},
+		{name: "mixed with ratio 1", content: "Mode = 'mixed'\nManualExecRatio = 1.0", err: "ExecutionScenario.ManualExecRatio must be between 0 and 1 (exclusive), got 1.000000"},
+		{name: "ratio outside mixed", content: "ManualExecRatio = 0.5", err: "ExecutionScenario.ManualExecRatio is only allowed in mixed mode"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var e ExecutionScenario
+			require.NoError(t, toml.Unmarshal([]byte(tc.content), &e))
+			err := e.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+				require.Equal(t, tc.ratio, e.GetManualExecRatio())
+				return
+			}
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package ccip
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/AlekSi/pointer"
+	chainselectors "github.com/smartcontractkit/chain-selectors"
+)
+
+var (
+	evmAddressRegex = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+	// solana addresses are 32 bytes, base58 encoded to 32-44 characters
+	solanaAddressRegex = regexp.MustCompile(`^[1-9A-HJ-NP-Za-km-z]{32,44}$`)
+)
+
+// ChainContracts are the addresses of CCIP contracts already deployed on a chain.
+type ChainContracts struct {
+	Router             *string `toml:",omitempty"`
+	OnRamp             *string `toml:",omitempty"`
+	OffRamp            *string `toml:",omitempty"`
+	FeeQuoter          *string `toml:",omitempty"`
+	TokenAdminRegistry *string `toml:",omitempty"`
+	RMNRemote          *string `toml:",omitempty"`
+	RMNProxy           *string `toml:",omitempty"`
+	NonceManager       *string `toml:",omitempty"`
+	// DeployMissing deploys the required contracts that are not listed instead of failing validation
+	DeployMissing *bool `toml:",omitempty"`
+}
+
+// contractAddress is a contract field of ChainContracts with its configured address.
+type contractAddress struct {
+	name    string
+	address *string
+}
+
+// required returns the contracts every chain needs, in field order.
+func (c ChainContracts) required() []contractAddress {
+	return []contractAddress{
+		{"Router", c.Router},
+		{"OnRamp", c.OnRamp},
+		{"OffRamp", c.OffRamp},
+		{"FeeQuoter", c.FeeQuoter},
+		{"TokenAdminRegistry", c.TokenAdminRegistry},
+		{"RMNRemote", c.RMNRemote},
+	}
+}
+
+func (c ChainContracts) all() []contractAddress {
+	return append(c.required(), contractAddress{"RMNProxy", c.RMNProxy}, contractAddress{"NonceManager", c.NonceManager})
+}
+
+// GetContracts returns the pre-deployed contracts of the chain, if any are configured.
+func (o *Config) GetContracts(selector uint64) (ChainContracts, bool) {
+	for ref, contracts := range o.ExistingContracts {
+		if contracts == nil {
+			continue
+		}
+		if resolved, err := o.ResolveChainSelector(ref); err == nil && resolved == selector {
+			return *contracts, true
+		}
+	}
+	return ChainContracts{}, false
+}
+
+func (o *Config) validateExistingContracts() error {
+	refs := make([]string, 0, len(o.ExistingContracts))
+	for ref := range o.ExistingContracts {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	seen := make(map[uint64]string)
+	for _, ref := range refs {
+		contracts := o.ExistingContracts[ref]
+		if contracts == nil {
+			continue
+		}
+		selector, err := o.ResolveChainSelector(ref)
+		if err != nil {
+			return fieldError("ExistingContracts."+ref, err)
+		}
+		if other, ok := seen[selector]; ok {
+			return fmt.Errorf("ExistingContracts.%s and ExistingContracts.%s refer to the same chain", other, ref)
+		}
+		seen[selector] = ref
+		family, err := chainselectors.GetSelectorFamily(selector)
+		if err != nil {
+			return fieldError("ExistingContracts."+ref, err)
+		}
+		for _, contract := range contracts.all() {
+			if contract.address == nil {
+				continue
+			}
+			if err := validateAddress(family, *contract.address); err != nil {
+				return fieldError("ExistingContracts."+ref+"."+contract.name, err)
+			}
+		}
+		if pointer.GetBool(contracts.DeployMissing) {
+			continue
+		}
+		var missing []string
+		for _, contract := range contracts.required() {
+			if pointer.GetString(contract.address) == "" {
+				missing = append(missing, contract.name)
+			}
+		}
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			return fmt.Errorf("ExistingContracts.%s is missing %v, list them or set DeployMissing = true", ref, missing)
+		}
+	}
+	return nil
+}
+
+// validateAddress checks the address is well formed for the chain family.
+func validateAddress(family, address string) error {
+	switch family {
+	case chainselectors.FamilyEVM:
+		if !evmAddressRegex.MatchString(address) {
+			return fmt.Errorf("%q is not a 20 byte hex address", address)
+		}
+	case chainselectors.FamilySolana:
+		if !solanaAddressRegex.MatchString(address) {
+			return fmt.Errorf("%q is not a base58 address", address)
+		}
+	default:
+		return fmt.Errorf("addresses of %s chains are not supported", family)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package ccip
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// evmContracts lists every required contract at a distinct, well formed address.
+func evmContracts(header string) string {
+	var b strings.Builder
+	b.WriteString(header + "\n")
+	for i, contract := range (ChainContracts{}).required() {
+		fmt.Fprintf(&b, "%s = '0x%040x'\n", contract.name, i+1)
+	}
+	return b.String()
+}
+
+func TestGetContracts(t *testing.T) {
+	_, ok := (&Config{}).GetContracts(ethereumSelector)
+	require.False(t, ok)
+
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(evmContracts("[ExistingContracts.ethereum-mainnet]")+"[ExistingContracts.124615329519749607]\nDeployMissing = true\n"), &cfg))
+	contracts, ok := cfg.GetContracts(ethereumSelector)
+	require.True(t, ok)
+	require.Equal(t, "0x0000000000000000000000000000000000000001", pointer.GetString(contracts.Router))
+	_, ok = cfg.GetContracts(solanaSelector)
+	require.True(t, ok)
+	_, ok = cfg.GetContracts(3379446385462418246)
+	require.False(t, ok)
+}
+
+func TestValidateExistingContracts(t *testing.T) {
+	const solana = "[ExistingContracts.124615329519749607]\nDeployMissing = true\n"
+	for _, tc := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "not set"},
+		{name: "every required contract", content: evmContracts("[ExistingContracts.ethereum-mainnet]")},
+		{name: "deploy missing", content: "[ExistingContracts.ethereum-mainnet]\nDeployMissing = true\nRouter = '0x0000000000000000000000000000000000000001'"},
+		{name: "solana address", content: solana + "Router = '11111111111111111111111111111111'"},
+		{name: "unknown chain", content: "[ExistingContracts.nowhere]\nDeployMissing = true", err: "ExistingContracts.nowhere: chain nowhere is neither a chain selector"},
+		{
+			name:    "same chain twice",
+			content: evmContracts("[ExistingContracts.ethereum-mainnet]") + evmContracts("[ExistingContracts.5009297550715157269]"),
+			err:     "ExistingContracts.5009297550715157269 and ExistingContracts.ethereum-mainnet refer to the same chain",
+		},
+		{
+			name:    "malformed evm address",
+			content: "[ExistingContracts.ethereum-mainnet]\nDeployMissing = true\nNonceManager = '0x01'",
+			err:     `ExistingContracts.ethereum-mainnet.NonceManager: "0x01" is not a 20 byte hex address`,
+		},
+		{
+			name:    "first malformed address in field order",
+			content: "[ExistingContracts.ethereum-mainnet]\nDeployMissing = true\nRMNProxy = '0x02'\nRouter = '0x01'",
+			err:     `ExistingContracts.ethereum-mainnet.Router: "0x01" is not a 20 byte hex address`,
+		},
+		{
+			name:    "evm address on solana",
+			content: solana + "Router = '0x0000000000000000000000000000000000000001'",
+			err:     `ExistingContracts.124615329519749607.Router: "0x0000000000000000000000000000000000000001" is not a base58 address`,
+		},
+		{
+			name:    "missing contracts",
+			content: "[ExistingContracts.ethereum-mainnet]\nRouter = '0x0000000000000000000000000000000000000001'",
+			err:     "ExistingContracts.ethereum-mainnet is missing [FeeQuoter OffRamp OnRamp RMNRemote TokenAdminRegistry], list them or set DeployMissing = true",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte(tc.content), &cfg))
+			err := cfg.validateExistingContracts()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+
+	require.EqualError(t, validateAddress("aptos", "0x01"), "addresses of aptos chains are not supported")
+}
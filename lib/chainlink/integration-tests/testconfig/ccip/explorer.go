@@ -0,0 +1,131 @@
+package ccip
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+)
+
+// ExplorerConfig configures the block explorer of a chain, used to verify deployed contracts
+// and to link transactions in the report.
+type ExplorerConfig struct {
+	APIURL          *string `toml:",omitempty"`
+	APIKey          *Secret `toml:",omitempty"`
+	BrowserURL      *string `toml:",omitempty"`
+	VerifyContracts *bool   `toml:",omitempty"`
+}
+
+// VerificationSettings is what contract verification on a chain needs.
+type VerificationSettings struct {
+	Enabled bool
+	APIURL  string
+	APIKey  Secret
+}
+
+// GetExplorer returns the explorer of the chain, and the key it is configured under.
+func (o *Config) GetExplorer(selector uint64) (*ExplorerConfig, string, bool) {
+	for ref, explorer := range o.Explorer {
+		if explorer == nil {
+			continue
+		}
+		if resolved, err := o.ResolveChainSelector(ref); err == nil && resolved == selector {
+			return explorer, ref, true
+		}
+	}
+	return nil, "", false
+}
+
+// GetVerificationSettings returns the contract verification settings of the chain, disabled when it has no explorer.
+func (o *Config) GetVerificationSettings(selector uint64) VerificationSettings {
+	explorer, _, ok := o.GetExplorer(selector)
+	if !ok || !pointer.GetBool(explorer.VerifyContracts) {
+		return VerificationSettings{}
+	}
+	settings := VerificationSettings{
+		Enabled: true,
+		APIURL:  pointer.GetString(explorer.APIURL),
+	}
+	if explorer.APIKey != nil {
+		settings.APIKey = *explorer.APIKey
+	}
+	return settings
+}
+
+// TxLink returns the explorer page of the transaction.
+func (o *Config) TxLink(selector uint64, txHash string) (string, error) {
+	explorer, _, ok := o.GetExplorer(selector)
+	if !ok || pointer.GetString(explorer.BrowserURL) == "" {
+		return "", fmt.Errorf("no Explorer.BrowserURL configured for chain %d", selector)
+	}
+	return strings.TrimSuffix(*explorer.BrowserURL, "/") + "/tx/" + txHash, nil
+}
+
+// laneTxLink links a transaction of a message on the given lane: sends happen on the source chain,
+// every later phase on the destination chain. Returns an empty string if the chain has no explorer.
+func (o *Config) laneTxLink(lane, phase, txHash string) string {
+	source, dest, err := ParseLaneKey(lane)
+	if err != nil {
+		return ""
+	}
+	chain := dest
+	if phase == MESSAGE_PHASE_SENT {
+		chain = source
+	}
+	selector, err := o.ResolveChainSelector(chain)
+	if err != nil {
+		return ""
+	}
+	link, err := o.TxLink(selector, txHash)
+	if err != nil {
+		return ""
+	}
+	return link
+}
+
+func (e *ExplorerConfig) Validate(field string) error {
+	for _, u := range []struct {
+		name  string
+		value *string
+	}{
+		{"APIURL", e.APIURL},
+		{"BrowserURL", e.BrowserURL},
+	} {
+		if u.value == nil {
+			continue
+		}
+		if err := validateURL(*u.value); err != nil {
+			return fieldError(field+"."+u.name, err)
+		}
+	}
+	if pointer.GetBool(e.VerifyContracts) {
+		if e.APIURL == nil {
+			return fmt.Errorf("%s.APIURL must be set when VerifyContracts is true", field)
+		}
+		if e.APIKey == nil || *e.APIKey == "" {
+			return fmt.Errorf("%s.APIKey must be set when VerifyContracts is true", field)
+		}
+	}
+	return nil
+}
+
+func (o *Config) validateExplorer() error {
+	refs := make([]string, 0, len(o.Explorer))
+	for ref := range o.Explorer {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	for _, ref := range refs {
+		if o.Explorer[ref] == nil {
+			continue
+		}
+		if _, err := o.ResolveChainSelector(ref); err != nil {
+			return fieldError("Explorer."+ref, err)
+		}
+		if err := o.Explorer[ref].Validate("Explorer." + ref); err != nil {
+			return err
+		}
+	}
+	return nil
+}
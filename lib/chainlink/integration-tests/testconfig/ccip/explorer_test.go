@@ -0,0 +1,63 @@
+package ccip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const explorerTestTOML = `
+[PrivateEthereumNetworks.SIMULATED_1.EthereumChainConfig]
+chain_id = 1337
+
+[Explorer.SIMULATED_1]
+BrowserURL = 'https://explorer.example.com/'
+
+[Explorer.ethereum-testnet-sepolia]
+APIURL = 'https://api-sepolia.etherscan.io/api'
+APIKey = 'etherscan-key'
+BrowserURL = 'https://sepolia.etherscan.io'
+VerifyContracts = true
+`
+
+func TestExplorer(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(explorerTestTOML), &cfg))
+	require.NoError(t, cfg.validateExplorer())
+
+	sepolia, err := cfg.ResolveChainSelector("ethereum-testnet-sepolia")
+	require.NoError(t, err)
+	settings := cfg.GetVerificationSettings(sepolia)
+	require.True(t, settings.Enabled)
+	require.Equal(t, "https://api-sepolia.etherscan.io/api", settings.APIURL)
+	require.Equal(t, "etherscan-key", secretValue(t, settings.APIKey))
+
+	simulated, err := cfg.ResolveChainSelector("SIMULATED_1")
+	require.NoError(t, err)
+	require.False(t, cfg.GetVerificationSettings(simulated).Enabled)
+	link, err := cfg.TxLink(simulated, "0xabc")
+	require.NoError(t, err)
+	require.Equal(t, "https://explorer.example.com/tx/0xabc", link)
+
+	_, err = cfg.TxLink(12922642891491394802, "0xabc")
+	require.ErrorContains(t, err, "no Explorer.BrowserURL configured for chain 12922642891491394802")
+
+	cfg.Explorer["ethereum-testnet-sepolia"].APIKey = nil
+	require.ErrorContains(t, cfg.validateExplorer(), "Explorer.ethereum-testnet-sepolia.APIKey must be set when VerifyContracts is true")
+}
+
+func TestReporterLinksTransactions(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(explorerTestTOML), &cfg))
+	r := NewReporter(&Reporting{})
+	r.LinkTransactions(&cfg)
+
+	require.NoError(t, r.RecordMessageEvent(MessageEvent{Lane: "SIMULATED_1->ethereum-testnet-sepolia", SeqNr: 1, Phase: MESSAGE_PHASE_SENT, At: time.Now(), TxHash: "0x01"}))
+	require.NoError(t, r.RecordMessageEvent(MessageEvent{Lane: "SIMULATED_1->ethereum-testnet-sepolia", SeqNr: 1, Phase: MESSAGE_PHASE_EXECUTED, At: time.Now(), TxHash: "0x02"}))
+	require.Equal(t, map[string]string{
+		MESSAGE_PHASE_SENT:     "https://explorer.example.com/tx/0x01",
+		MESSAGE_PHASE_EXECUTED: "https://sepolia.etherscan.io/tx/0x02",
+	}, r.messages["SIMULATED_1->ethereum-testnet-sepolia/1"].TxLinks)
+}
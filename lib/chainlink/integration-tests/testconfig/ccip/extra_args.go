@@ -0,0 +1,182 @@
+package ccip
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"github.com/AlekSi/pointer"
+	chainselectors "github.com/smartcontractkit/chain-selectors"
+)
+
+const (
+	EXTRA_ARGS_EVM_V1 = "evmV1"
+	EXTRA_ARGS_EVM_V2 = "evmV2"
+	EXTRA_ARGS_SVM_V1 = "svmV1"
+
+	DEFAULT_EXTRA_ARGS_GAS_LIMIT uint64 = 200_000
+)
+
+var (
+	// EVM_EXTRA_ARGS_V1_TAG and EVM_EXTRA_ARGS_V2_TAG of the Client library
+	evmExtraArgsV1Tag = []byte{0x97, 0xa6, 0x57, 0xc9}
+	evmExtraArgsV2Tag = []byte{0x18, 0x1d, 0xcf, 0x10}
+)
+
+// ExtraArgsConfig is the extraArgs passed to ccipSend for messages towards a destination chain.
+type ExtraArgsConfig struct {
+	GasLimit   *uint64 `toml:",omitempty"`
+	OutOfOrder *bool   `toml:",omitempty"`
+	// Version is one of "evmV1", "evmV2", "svmV1"
+	Version *string `toml:",omitempty"`
+}
+
+// ExtraArgs configures ccipSend extraArgs, with per destination overrides falling back to Default.
+type ExtraArgs struct {
+	Default *ExtraArgsConfig `toml:",omitempty"`
+	// PerDest is keyed by destination chain (network name or selector). When a chain is keyed both by
+	// name and by selector, the later in sorted order wins.
+	PerDest map[string]*ExtraArgsConfig `toml:",omitempty"`
+}
+
+func (e *ExtraArgsConfig) merge(override *ExtraArgsConfig) {
+	if override == nil {
+		return
+	}
+	if override.GasLimit != nil {
+		e.GasLimit = pointer.ToUint64(*override.GasLimit)
+	}
+	if override.OutOfOrder != nil {
+		e.OutOfOrder = pointer.ToBool(*override.OutOfOrder)
+	}
+	if override.Version != nil {
+		e.Version = pointer.ToString(*override.Version)
+	}
+}
+
+// destRefs returns the keys of PerDest, sorted.
+func (e *ExtraArgs) destRefs() []string {
+	refs := make([]string, 0, len(e.PerDest))
+	for ref := range e.PerDest {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	return refs
+}
+
+func defaultExtraArgsVersion(family string) string {
+	if family == chainselectors.FamilySolana {
+		return EXTRA_ARGS_SVM_V1
+	}
+	return EXTRA_ARGS_EVM_V2
+}
+
+// GetExtraArgsForDest returns the fully resolved extraArgs for messages towards the destination chain.
+func (o *Config) GetExtraArgsForDest(selector uint64) (ExtraArgsConfig, error) {
+	family, err := chainselectors.GetSelectorFamily(selector)
+	if err != nil {
+		return ExtraArgsConfig{}, err
+	}
+	resolved := ExtraArgsConfig{
+		GasLimit:   pointer.ToUint64(DEFAULT_EXTRA_ARGS_GAS_LIMIT),
+		OutOfOrder: pointer.ToBool(false),
+		Version:    pointer.ToString(defaultExtraArgsVersion(family)),
+	}
+	if o.ExtraArgs == nil {
+		return resolved, nil
+	}
+	resolved.merge(o.ExtraArgs.Default)
+	for _, ref := range o.ExtraArgs.destRefs() {
+		override := o.ExtraArgs.PerDest[ref]
+		destSelector, err := o.ResolveChainSelector(ref)
+		if err != nil {
+			return ExtraArgsConfig{}, fieldError("ExtraArgs.PerDest", err)
+		}
+		if destSelector == selector {
+			resolved.merge(override)
+		}
+	}
+	return resolved, nil
+}
+
+// Encode returns the extraArgs bytes of a resolved config, the version tag followed by the abi encoded
+// gas limit and, for evmV2, the out of order flag.
+func (e *ExtraArgsConfig) Encode() ([]byte, error) {
+	word := func(v uint64) []byte {
+		encoded := make([]byte, 32)
+		binary.BigEndian.PutUint64(encoded[24:], v)
+		return encoded
+	}
+	version := pointer.GetString(e.Version)
+	switch version {
+	case EXTRA_ARGS_EVM_V1:
+		return append(append([]byte{}, evmExtraArgsV1Tag...), word(pointer.GetUint64(e.GasLimit))...), nil
+	case EXTRA_ARGS_EVM_V2:
+		var outOfOrder uint64
+		if pointer.GetBool(e.OutOfOrder) {
+			outOfOrder = 1
+		}
+		encoded := append(append([]byte{}, evmExtraArgsV2Tag...), word(pointer.GetUint64(e.GasLimit))...)
+		return append(encoded, word(outOfOrder)...), nil
+	default:
+		return nil, fmt.Errorf("encoding %q extraArgs is not supported", version)
+	}
+}
+
+func (e *ExtraArgsConfig) validate(field, family string) error {
+	version := pointer.GetString(e.Version)
+	switch version {
+	case "":
+	case EXTRA_ARGS_EVM_V1:
+		if e.OutOfOrder != nil {
+			return fmt.Errorf("%s: OutOfOrder is not supported by %s extraArgs", field, EXTRA_ARGS_EVM_V1)
+		}
+	case EXTRA_ARGS_EVM_V2, EXTRA_ARGS_SVM_V1:
+	default:
+		return fmt.Errorf("%s: unknown extraArgs Version %q", field, version)
+	}
+	if family == "" || version == "" {
+		return nil
+	}
+	if (family == chainselectors.FamilyEVM) != (version != EXTRA_ARGS_SVM_V1) {
+		return fmt.Errorf("%s: extraArgs Version %s cannot be used for %s destination chains", field, version, family)
+	}
+	return nil
+}
+
+func (o *Config) validateExtraArgs() error {
+	if o.ExtraArgs == nil {
+		return nil
+	}
+	if o.ExtraArgs.Default != nil {
+		if err := o.ExtraArgs.Default.validate("ExtraArgs.Default", ""); err != nil {
+			return err
+		}
+	}
+	for _, ref := range o.ExtraArgs.destRefs() {
+		override := o.ExtraArgs.PerDest[ref]
+		selector, err := o.ResolveChainSelector(ref)
+		if err != nil {
+			return fieldError("ExtraArgs.PerDest", err)
+		}
+		family, err := chainselectors.GetSelectorFamily(selector)
+		if err != nil {
+			return fieldError("ExtraArgs.PerDest."+ref, err)
+		}
+		if override == nil {
+			continue
+		}
+		if err := override.validate("ExtraArgs.PerDest."+ref, family); err != nil {
+			return err
+		}
+		// the resolved args inherit from Default, e.g. a v1 override on top of a default OutOfOrder flag
+		resolved, err := o.GetExtraArgsForDest(selector)
+		if err != nil {
+			return err
+		}
+		if pointer.GetString(resolved.Version) == EXTRA_ARGS_EVM_V1 && pointer.GetBool(resolved.OutOfOrder) {
+			return fmt.Errorf("ExtraArgs.PerDest.%s: %s extraArgs cannot inherit OutOfOrder from ExtraArgs.Default", ref, EXTRA_ARGS_EVM_V1)
+		}
+	}
+	return nil
+}
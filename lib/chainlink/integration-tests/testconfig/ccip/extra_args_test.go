@@ -0,0 +1,101 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ethereumSelector uint64 = 5009297550715157269
+	solanaSelector   uint64 = 124615329519749607
+)
+
+func TestGetExtraArgsForDest(t *testing.T) {
+	var cfg Config
+	evm, err := cfg.GetExtraArgsForDest(ethereumSelector)
+	require.NoError(t, err)
+	require.Equal(t, ExtraArgsConfig{
+		GasLimit:   pointer.ToUint64(DEFAULT_EXTRA_ARGS_GAS_LIMIT),
+		OutOfOrder: pointer.ToBool(false),
+		Version:    pointer.ToString(EXTRA_ARGS_EVM_V2),
+	}, evm)
+	svm, err := cfg.GetExtraArgsForDest(solanaSelector)
+	require.NoError(t, err)
+	require.Equal(t, EXTRA_ARGS_SVM_V1, *svm.Version)
+
+	require.NoError(t, toml.Unmarshal([]byte(`
+[ExtraArgs.Default]
+GasLimit = 1
+OutOfOrder = true
+[ExtraArgs.PerDest.5009297550715157269]
+GasLimit = 2
+[ExtraArgs.PerDest.ethereum-mainnet]
+GasLimit = 3
+`), &cfg))
+	resolved, err := cfg.GetExtraArgsForDest(ethereumSelector)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), *resolved.GasLimit)
+	require.True(t, *resolved.OutOfOrder)
+	resolved, err = cfg.GetExtraArgsForDest(solanaSelector)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), *resolved.GasLimit)
+}
+
+func TestEncodeExtraArgs(t *testing.T) {
+	v1, err := (&ExtraArgsConfig{Version: pointer.ToString(EXTRA_ARGS_EVM_V1), GasLimit: pointer.ToUint64(0x0102)}).Encode()
+	require.NoError(t, err)
+	require.Len(t, v1, 4+32)
+	require.Equal(t, evmExtraArgsV1Tag, v1[:4])
+	require.Equal(t, []byte{0x01, 0x02}, v1[34:])
+
+	v2, err := (&ExtraArgsConfig{Version: pointer.ToString(EXTRA_ARGS_EVM_V2), GasLimit: pointer.ToUint64(1), OutOfOrder: pointer.ToBool(true)}).Encode()
+	require.NoError(t, err)
+	require.Len(t, v2, 4+2*32)
+	require.Equal(t, evmExtraArgsV2Tag, v2[:4])
+	require.Equal(t, byte(1), v2[35])
+	require.Equal(t, byte(1), v2[67])
+
+	_, err = (&ExtraArgsConfig{}).Encode()
+	require.EqualError(t, err, `encoding "" extraArgs is not supported`)
+}
+
+func TestValidateExtraArgs(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "not set"},
+		{name: "default evmV1", content: "[ExtraArgs.Default]\nVersion = 'evmV1'\nGasLimit = 1"},
+		{name: "unknown version", content: "[ExtraArgs.Default]\nVersion = 'v9'", err: `ExtraArgs.Default: unknown extraArgs Version "v9"`},
+		{name: "evmV1 out of order", content: "[ExtraArgs.Default]\nVersion = 'evmV1'\nOutOfOrder = false", err: "ExtraArgs.Default: OutOfOrder is not supported by evmV1 extraArgs"},
+		{name: "unknown destination", content: "[ExtraArgs.PerDest.nowhere]\nGasLimit = 1", err: "ExtraArgs.PerDest: chain nowhere is neither a chain selector, a configured private network nor a known chain name"},
+		{name: "svmV1 to solana", content: "[ExtraArgs.PerDest.124615329519749607]\nVersion = 'svmV1'"},
+		{name: "svmV1 to an EVM chain", content: "[ExtraArgs.PerDest.ethereum-mainnet]\nVersion = 'svmV1'", err: "ExtraArgs.PerDest.ethereum-mainnet: extraArgs Version svmV1 cannot be used for evm destination chains"},
+		{name: "evmV2 to solana", content: "[ExtraArgs.PerDest.124615329519749607]\nVersion = 'evmV2'", err: "ExtraArgs.PerDest.124615329519749607: extraArgs Version evmV2 cannot be used for solana destination chains"},
+		{
+			name:    "evmV1 inheriting out of order",
+			content: "[ExtraArgs.Default]\nOutOfOrder = true\n[ExtraArgs.PerDest.ethereum-mainnet]\nVersion = 'evmV1'",
+			err:     "ExtraArgs.PerDest.ethereum-mainnet: evmV1 extraArgs cannot inherit OutOfOrder from ExtraArgs.Default",
+		},
+		{
+			name:    "first invalid destination in sorted order",
+			content: "[ExtraArgs.PerDest.ethereum-mainnet]\nVersion = 'v9'\n[ExtraArgs.PerDest.5009297550715157269]\nVersion = 'v8'",
+			err:     `ExtraArgs.PerDest.5009297550715157269: unknown extraArgs Version "v8"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte(tc.content), &cfg))
+			err := cfg.validateExtraArgs()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
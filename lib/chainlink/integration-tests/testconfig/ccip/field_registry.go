@@ -0,0 +1,822 @@
+package ccip
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/AlekSi/pointer"
+)
+
+// FieldInfo describes a config field for tools rendering or generating the config without the Go types.
+type FieldInfo struct {
+	// Path is the dotted TOML path, * stands for map keys and [] for array entries
+	Path string `json:"path"`
+	// Type is one of string, int, uint, float, bool, duration, bigint or table, prefixed with [] for
+	// arrays and map[string] for maps
+	Type        string   `json:"type"`
+	Default     string   `json:"default,omitempty"`
+	Secret      bool     `json:"secret,omitempty"`
+	EnvVar      string   `json:"envVar,omitempty"`
+	Min         *float64 `json:"min,omitempty"`
+	Max         *float64 `json:"max,omitempty"`
+	Enum        []string `json:"enum,omitempty"`
+	Description string   `json:"description"`
+}
+
+// fieldAnnotation is what the struct tags don't tell about a field, keyed by struct and field name in
+// fieldAnnotations.
+type fieldAnnotation struct {
+	description string
+	def         string
+	envVar      string
+	min, max    *float64
+	enum        []string
+}
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	arrayIndexRegex     = regexp.MustCompile(`\[\d+\]`)
+
+	fieldsByPathOnce sync.Once
+	fieldsByPath     map[string]FieldInfo
+)
+
+// FieldRegistry returns every config field in declaration order, nested fields after their parent.
+func FieldRegistry() []FieldInfo {
+	var fields []FieldInfo
+	registerFields(reflect.TypeOf(Config{}), "", &fields)
+	return fields
+}
+
+func registerFields(t reflect.Type, prefix string, fields *[]FieldInfo) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		// embedded structs are flattened into their parent table
+		if f.Anonymous {
+			registerFields(f.Type, prefix, fields)
+			continue
+		}
+		name := strings.Split(f.Tag.Get("toml"), ",")[0]
+		if name == "" {
+			name = f.Name
+		}
+		a := fieldAnnotations[t.Name()+"."+f.Name]
+		*fields = append(*fields, FieldInfo{
+			Path:        prefix + name,
+			Type:        fieldType(f.Type),
+			Default:     a.def,
+			Secret:      leafType(f.Type) == reflect.TypeOf(Secret("")),
+			EnvVar:      a.envVar,
+			Min:         a.min,
+			Max:         a.max,
+			Enum:        a.enum,
+			Description: a.description,
+		})
+		if nested, suffix := nestedTable(f.Type); nested != nil {
+			registerFields(nested, prefix+name+suffix+".", fields)
+		}
+	}
+}
+
+// lookupField returns the registry entry of a concrete field path, with map keys like "Tokens.LINK"
+// and array indexes like "Mocks[0]". An entry of a map of another package's tables, like
+// "PrivateEthereumNetworks.SIMULATED_1", returns the map.
+func lookupField(path string) (FieldInfo, bool) {
+	fieldsByPathOnce.Do(func() {
+		fieldsByPath = make(map[string]FieldInfo)
+		for _, field := range FieldRegistry() {
+			fieldsByPath[field.Path] = field
+		}
+	})
+	segments := strings.Split(arrayIndexRegex.ReplaceAllString(path, "[]"), ".")
+	if field, ok := matchFieldPath("", segments); ok {
+		return field, true
+	}
+	if len(segments) < 2 {
+		return FieldInfo{}, false
+	}
+	parent, ok := matchFieldPath("", segments[:len(segments)-1])
+	if !ok || !strings.HasPrefix(parent.Type, "map[string]") {
+		return FieldInfo{}, false
+	}
+	return parent, true
+}
+
+// matchFieldPath matches the segments against the registry paths after prefix, each segment either as
+// a field name or as a map key.
+func matchFieldPath(prefix string, segments []string) (FieldInfo, bool) {
+	if len(segments) == 0 {
+		field, ok := fieldsByPath[strings.TrimSuffix(prefix, ".")]
+		return field, ok
+	}
+	if field, ok := matchFieldPath(prefix+segments[0]+".", segments[1:]); ok {
+		return field, true
+	}
+	key := "*"
+	// a map key with array indexes, like "Lanes.*[]"
+	if strings.HasSuffix(segments[0], "[]") {
+		key = "*" + segments[0][strings.Index(segments[0], "["):]
+	}
+	return matchFieldPath(prefix+key+".", segments[1:])
+}
+
+// fieldDoc is the description and allowed values of a field, as validation errors and ExampleTOML show it.
+func fieldDoc(field FieldInfo) string {
+	doc := field.Description
+	if allowed := allowedValues(field); allowed != "" {
+		doc += "; " + allowed
+	}
+	return doc
+}
+
+func allowedValues(field FieldInfo) string {
+	switch {
+	case len(field.Enum) > 0:
+		return "one of " + strings.Join(field.Enum, ", ")
+	case field.Min != nil && field.Max != nil:
+		return fmt.Sprintf("between %g and %g", *field.Min, *field.Max)
+	case field.Min != nil:
+		return fmt.Sprintf("at least %g", *field.Min)
+	case field.Max != nil:
+		return fmt.Sprintf("at most %g", *field.Max)
+	}
+	return ""
+}
+
+// ExampleTOML renders every field of the registry as a commented out example config, with its doc,
+// env var and default. Tables of maps and arrays are shown with a <key> placeholder.
+func ExampleTOML() string {
+	// the values of a table come before its nested tables
+	tables := []FieldInfo{{}}
+	values := make(map[string][]FieldInfo)
+	for _, field := range FieldRegistry() {
+		if strings.HasSuffix(field.Type, "table") {
+			tables = append(tables, field)
+			continue
+		}
+		parent := ""
+		if i := strings.LastIndex(field.Path, "."); i >= 0 {
+			parent = field.Path[:i]
+		}
+		for strings.HasSuffix(parent, ".*") || strings.HasSuffix(parent, "[]") {
+			parent = parent[:len(parent)-2]
+		}
+		values[parent] = append(values[parent], field)
+	}
+	var b strings.Builder
+	for _, table := range tables {
+		if table.Path != "" {
+			path := exampleTablePath(table.Path)
+			switch {
+			case strings.HasPrefix(table.Type, "map[string]"):
+				path = "[" + path + strings.Repeat(".<key>", strings.Count(table.Type, "map[string]")) + "]"
+			case strings.HasPrefix(table.Type, "[]"):
+				path = "[[" + path + "]]"
+			default:
+				path = "[" + path + "]"
+			}
+			fmt.Fprintf(&b, "\n# %s\n# %s\n", path, fieldDoc(table))
+		}
+		for _, field := range values[table.Path] {
+			fmt.Fprintf(&b, "# %s\n", fieldDoc(field))
+			if field.EnvVar != "" {
+				fmt.Fprintf(&b, "# read from %s when unset\n", field.EnvVar)
+			}
+			fmt.Fprintf(&b, "# %s = %s\n", field.Path[strings.LastIndex(field.Path, ".")+1:], exampleValue(field))
+		}
+	}
+	return b.String()
+}
+
+func exampleTablePath(path string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(path, ".*", ".<key>"), "[]", "")
+}
+
+func exampleValue(field FieldInfo) string {
+	switch {
+	case field.Default == "":
+		return "<" + field.Type + ">"
+	case field.Type == "string" || field.Type == "duration":
+		return "'" + field.Default + "'"
+	}
+	return field.Default
+}
+
+// leafType strips pointers, arrays and maps off t.
+func leafType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	return t
+}
+
+// nestedTable returns the struct of this package t holds, with the path suffix of its map keys and array
+// entries. Structs of other packages are described by their own docs and aren't expanded.
+func nestedTable(t reflect.Type) (reflect.Type, string) {
+	suffix := ""
+	for {
+		switch t.Kind() {
+		case reflect.Ptr:
+			t = t.Elem()
+			continue
+		case reflect.Slice:
+			suffix += "[]"
+			t = t.Elem()
+			continue
+		case reflect.Map:
+			suffix += ".*"
+			t = t.Elem()
+			continue
+		}
+		break
+	}
+	if t.Kind() != reflect.Struct || t.PkgPath() != reflect.TypeOf(Config{}).PkgPath() || isScalar(t) {
+		return nil, ""
+	}
+	return t, suffix
+}
+
+// isScalar returns true for types written as a single TOML value, like durations and big ints.
+func isScalar(t reflect.Type) bool {
+	return t == bigIntType || reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+func fieldType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return fieldType(t.Elem())
+	case reflect.Slice:
+		return "[]" + fieldType(t.Elem())
+	case reflect.Map:
+		return "map[string]" + fieldType(t.Elem())
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "int"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "uint"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	}
+	switch {
+	case t == bigIntType:
+		return "bigint"
+	case isScalar(t):
+		return "duration"
+	}
+	return "table"
+}
+
+var (
+	percentage = pointer.ToFloat64(100)
+	zero       = pointer.ToFloat64(0)
+	one        = pointer.ToFloat64(1)
+	maxPort    = pointer.ToFloat64(65535)
+	maxPPB     = pointer.ToFloat64(float64(MAX_DEVIATION_PPB - 1))
+)
+
+// fieldAnnotations holds the description and constraints of every field. TestFieldRegistryIsExhaustive
+// fails for fields missing here.
+var fieldAnnotations = map[string]fieldAnnotation{
+	"Config.PrivateEthereumNetworks":  {description: "Private networks started for the test, keyed by network name"},
+	"Config.CLNode":                   {description: "Chainlink nodes of the environment"},
+	"Config.JobDistributorConfig":     {description: "Job distributor the nodes are registered with"},
+	"Config.HomeChainSelector":        {description: "Selector of the chain CCIPHome is deployed on"},
+	"Config.FeedChainSelector":        {description: "Selector of the chain the price feeds are deployed on"},
+	"Config.RMNConfig":                {description: "RMN nodes and the curses they're tested with"},
+	"Config.Tokens":                   {description: "Tokens transferred by the test, keyed by symbol"},
+	"Config.PriceConfig":              {description: "Gas and token price updates of the commit plugin"},
+	"Config.RateLimits":               {description: "Token pool rate limits, by default, lane, token and both"},
+	"Config.LoadProfile":              {description: "Traffic sent on every lane during a load test"},
+	"Config.MessageLimits":            {description: "Limits of the messages the test sends"},
+	"Config.USDCMock":                 {description: "Mock CCTP attestation API for USDC transfers"},
+	"Config.ExecutionScenario":        {description: "How messages are executed, by the exec plugin or manually"},
+	"Config.Timeouts":                 {description: "Timeouts of the test phases and of the whole test"},
+	"Config.Messages":                 {description: "Messages sent by smoke tests"},
+	"Config.HomeChainConfig":          {description: "Plugin configuration in CCIPHome"},
+	"Config.ExtraArgs":                {description: "Extra args of the messages, by default and per destination chain"},
+	"Config.Thresholds":               {description: "Pass/fail criteria of a load test"},
+	"Config.ExecConfig":               {description: "Exec plugin settings"},
+	"Config.GasSpikeScenario":         {description: "Raises the gas price of a destination chain for a while"},
+	"Config.Receivers":                {description: "Receiver contract behaviour, by default and per lane"},
+	"Config.Observability":            {description: "Loki, Grafana and Prometheus integrations"},
+	"Config.Reporting":                {description: "Test report written at the end of a run"},
+	"Config.Tracing":                  {description: "OpenTelemetry tracing of the harness and the nodes"},
+	"Config.Notifications":            {description: "Webhook notifications about the run"},
+	"Config.LogCollection":            {description: "Where component logs are collected to"},
+	"Config.SethConfig":               {description: "Seth client settings, by default and per chain"},
+	"Config.Profiling":                {description: "pprof captures from plugin nodes"},
+	"Config.Runtime":                  {description: "Where the environment runs", def: DEFAULT_RUNTIME, enum: []string{RUNTIME_DOCKER, RUNTIME_K8S}},
+	"Config.K8sConfig":                {description: "Kubernetes settings, used when Runtime is k8s"},
+	"Config.DockerConfig":             {description: "Docker settings, used when Runtime is docker"},
+	"Config.Lifecycle":                {description: "Whether the environment outlives the test or an earlier one is reused"},
+	"Config.ExistingContracts":        {description: "Already deployed contracts to use, keyed by chain"},
+	"Config.DeployerConfig":           {description: "Key contracts are deployed with and their owner, keyed by chain"},
+	"Config.Phases":                   {description: "Test phases to run, all of them when empty", enum: AllPhases},
+	"Config.FailOnWarnings":           {description: "Fails validation if the config has lint warnings"},
+	"Config.SuppressWarnings":         {description: "Lint warning codes to ignore"},
+	"Config.PortRangeStart":           {description: "First host port handed out by the port allocator", def: fmt.Sprint(DEFAULT_PORT_RANGE_START), min: one, max: maxPort},
+	"Config.PortRangeEnd":             {description: "Last host port handed out by the port allocator", def: fmt.Sprint(DEFAULT_PORT_RANGE_END), min: one, max: maxPort},
+	"Config.Mocks":                    {description: "Mock HTTP services started alongside the environment"},
+	"Config.Preset":                   {description: "Named config the rest of the config is applied on top of", enum: []string{PRESET_SMOKE_2CHAIN, PRESET_LOAD_4CHAIN, PRESET_RMN_CURSE, PRESET_USDC_LANE}},
+	"Config.RetryPolicy":              {description: "Retries of RPC, JD and node API calls"},
+	"Config.Explorer":                 {description: "Block explorers, keyed by chain"},
+	"Config.SenderConfig":             {description: "Accounts the messages are sent from"},
+	"Config.GasStrategy":              {description: "How transactions are priced, keyed by chain"},
+	"Config.ContractVersions":         {description: "CCIP contract version per chain", enum: supportedContractVersions},
+	"Config.DefaultContractVersion":   {description: "CCIP contract version of chains not in ContractVersions", def: DEFAULT_CONTRACT_VERSION, enum: supportedContractVersions},
+	"Config.AllowMixedVersionLanes":   {description: "Allows lanes between chains on different contract versions"},
+	"Config.JobSpecOverrides":         {description: "Templates and plugin config overriding the generated job specs"},
+	"Config.HealthChecks":             {description: "Readiness checks of the components, per component"},
+	"Config.FinalityViolation":        {description: "Reorgs a source chain deeper than its finality"},
+	"Config.Resources":                {description: "CPU and memory of the containers"},
+	"Config.AutoSize":                 {description: "Sizes nodes, senders and timeouts from the load profile"},
+	"Config.OrderingAssertions":       {description: "Message ordering asserted per lane, keyed by source->dest", enum: orderingAssertions},
+	"Config.DefaultOrdering":          {description: "Message ordering asserted on lanes not in OrderingAssertions", def: DEFAULT_ORDERING_ASSERTION, enum: orderingAssertions},
+	"Config.ChainTokens":              {description: "LINK and wrapped native addresses of live chains, keyed by chain"},
+	"Config.PluginLogging":            {description: "Plugin log levels and telemetry, by default and per node"},
+	"Config.RandomSeed":               {description: "Seed of every random stream, generated and logged when unset"},
+	"Config.FailureArtifacts":         {description: "Bundle of logs and state written when a test fails"},
+	"Config.ChainIDRange":             {description: "Chain IDs allocated to private networks declared without one"},
+	"Config.WarmUp":                   {description: "Traffic sent before measurements start"},
+	"Config.ConfigRollout":            {description: "Candidate plugin config promoted while messages are in flight"},
+	"Config.AddressExport":            {description: "Files the deployed contract addresses are exported to"},
+	"Config.Polling":                  {description: "How assertions poll the chains, by default and per chain"},
+	"Config.MessageComposition":       {description: "Tokens every message carries, by default and per lane"},
+	"Config.MaxBudget":                {description: "Native amount the test may spend per chain, like \"1.5\" or \"500gwei\""},
+	"Config.ComponentCriticality":     {description: "Whether failing to start a component fails the run, keyed by component", enum: []string{CRITICALITY_REQUIRED, CRITICALITY_OPTIONAL}},
+	"Config.ConfigServer":             {description: "HTTP server exposing the resolved config during the run"},
+	"Config.FundingProfiles":          {description: "Named native and LINK amounts accounts are funded with"},
+	"Config.Schedule":                 {description: "Windows in which disruptive actions are paused"},
+	"Config.DeploymentConfig":         {description: "Concurrency of the contract deployment"},
+	"Config.FailOnIncompatible":       {description: "Fails validation on known bad node, JD and RMN version combinations"},
+	"Config.Metadata":                 {description: "Labels attached to the logs, metrics, reports and annotations of the run"},
+	"Config.RunID":                    {description: "ID of the run, generated when unset"},
+	"Config.PriceManipulation":        {description: "Makes the gas price of a destination chain stale or wrong for a while"},
+	"Config.ChainSemantics":           {description: "Finality of rollup chains, keyed by chain"},
+	"Config.ChainSnapshots":           {description: "Snapshots of the private chains taken after a phase and restored by later runs"},
+	"Config.AssertionSampling":        {description: "Which messages are asserted one by one at high message counts"},
+	"Config.TransportPreferences":     {description: "WS or HTTP per operation type, by default and per chain"},
+	"Config.StartupOrder":             {description: "Order the environment components start in, chains, nodes, jd, rmn when empty", enum: startupComponents},
+	"Config.AssertionSource":          {description: "Whether executions are asserted from logs, offramp state reads or both", def: DEFAULT_ASSERTION_SOURCE, enum: assertionSources},
+	"Config.AssertionSourcePerChain":  {description: "Assertion source keyed by destination chain"},
+	"Config.ChainHalt":                {description: "Stops block production on a private chain for a while"},
+	"Config.LatencyModel":             {description: "Expected latency of the lanes, optionally used instead of the flat timeouts"},
+	"Config.Heartbeat":                {description: "Periodic self-transfers telling a dead chain apart from a stuck CCIP"},
+	"Config.Remediation":              {description: "What happens to messages that get stuck"},
+	"Config.MidTestTokenOnboarding":   {description: "Tokens deployed and registered on live lanes during the test"},
+	"Config.CollectVersions":          {description: "Queries the versions and image digests of the components at runtime into the report"},
+	"Config.CommitAssertions":         {description: "Commit batching expectations checked after a load test"},
+	"Config.ConfirmationDepth":        {description: "Blocks a harness transaction must be buried under before it counts as done, keyed by chain"},
+	"Config.TeardownVerification":     {description: "Checks the run left no containers, volumes or funds behind after teardown"},
+	"Config.RPCBudget":                {description: "Budget of the RPC requests the harness makes, keyed by chain"},
+	"Config.DefaultConfirmationDepth": {description: "Confirmation depth of chains not in ConfirmationDepth, 0 doesn't wait for receipts", def: fmt.Sprint(DEFAULT_CONFIRMATION_DEPTH), min: zero},
+	"Config.ABIOverrides":             {description: "ABIs, inline JSON or a path, keyed by contract name, to decode events of unreleased contract builds with"},
+
+	"NodeConfig.NoOfPluginNodes":   {description: "Number of plugin nodes, derived from DONConfig when unset"},
+	"NodeConfig.NoOfBootstraps":    {description: "Number of bootstrap nodes", min: zero},
+	"NodeConfig.ClientConfig":      {description: "Node API client settings"},
+	"NodeConfig.DONConfig":         {description: "Sizes of separate commit and exec DONs"},
+	"NodeConfig.MetricsPort":       {description: "Port the nodes expose metrics on", min: one, max: maxPort},
+	"NodeConfig.EnablePprof":       {description: "Enables the pprof endpoints of the nodes"},
+	"NodeConfig.FundingAmounts":    {description: "What every node is funded with on each chain"},
+	"NodeConfig.Version":           {description: "Node image version, only used for the compatibility check", envVar: "E2E_TEST_CHAINLINK_VERSION"},
+	"NodeConfig.BootstrapFailover": {description: "Standby bootstrap nodes promoted after the active ones are killed"},
+
+	"DONConfig.CommitNodes": {description: "Number of nodes in the commit DON", min: one},
+	"DONConfig.ExecNodes":   {description: "Number of nodes in the exec DON", min: one},
+	"DONConfig.Overlap":     {description: "How many nodes serve both DONs", min: zero},
+
+	"Funding.Profile":       {description: "FundingProfiles entry the amounts are applied on top of"},
+	"FundingProfile.Native": {description: "Native amount per chain, like \"1.5\" or \"500gwei\""},
+	"FundingProfile.LINK":   {description: "LINK amount per chain, like \"10\""},
+
+	"BootstrapFailover.StandbyBootstraps": {description: "Number of standby bootstrap nodes", def: fmt.Sprint(DEFAULT_STANDBY_BOOTSTRAPS), min: one},
+	"BootstrapFailover.PromoteAfter":      {description: "When the standby bootstraps are promoted, from the start of the test"},
+	"BootstrapFailover.KillActiveAt":      {description: "When the active bootstraps are killed, from the start of the test"},
+
+	"JDConfig.Image":       {description: "Job distributor image", envVar: E2E_JD_IMAGE},
+	"JDConfig.Version":     {description: "Job distributor image version", envVar: E2E_JD_VERSION},
+	"JDConfig.DBName":      {description: "Name of the job distributor database", def: DEFAULT_DB_NAME},
+	"JDConfig.DBVersion":   {description: "Postgres version of the job distributor database", def: DEFAULT_DB_VERSION},
+	"JDConfig.JDGRPC":      {description: "gRPC address of an existing job distributor", envVar: E2E_JD_GRPC},
+	"JDConfig.JDWSRPC":     {description: "WSRPC address of an existing job distributor", envVar: E2E_JD_WSRPC},
+	"JDConfig.MetricsPort": {description: "Port the job distributor exposes metrics on", min: one, max: maxPort},
+
+	"RMNConfig.NoOfNodes":     {description: "Number of RMN nodes, 0 disables RMN", min: zero},
+	"RMNConfig.ProxyImage":    {description: "RMN rageproxy image", envVar: E2E_RMN_RAGEPROXY_IMAGE},
+	"RMNConfig.ProxyVersion":  {description: "RMN rageproxy image version", envVar: E2E_RMN_RAGEPROXY_VERSION},
+	"RMNConfig.AFNImage":      {description: "RMN afn2proxy image", envVar: E2E_RMN_AFN2PROXY_IMAGE},
+	"RMNConfig.AFNVersion":    {description: "RMN afn2proxy image version", envVar: E2E_RMN_AFN2PROXY_VERSION},
+	"RMNConfig.MetricsPort":   {description: "Port the RMN nodes expose metrics on", min: one, max: maxPort},
+	"RMNConfig.CurseConfig":   {description: "Chains cursed during the test"},
+	"RMNConfig.CurseRecovery": {description: "Uncursing and the drain of messages sent during the curse"},
+
+	"CurseConfig.Chains":     {description: "Chains to curse, by network name or selector"},
+	"CurseConfig.CurseAfter": {description: "When the chains are cursed, from the start of the traffic"},
+
+	"CurseRecovery.UncurseAfter":        {description: "When the chains are uncursed, from the curse"},
+	"CurseRecovery.MessagesDuringCurse": {description: "Messages sent while the chains are cursed", min: zero},
+	"CurseRecovery.ExpectDrainWithin":   {description: "How soon after the uncurse the messages sent during the curse must be executed", def: DEFAULT_EXPECT_DRAIN_WITHIN.String()},
+	"CurseRecovery.Chains":              {description: "Chains to uncurse, all cursed chains when empty"},
+
+	"TokenConfig.Decimals":         {description: "Decimals of the token"},
+	"TokenConfig.PoolType":         {description: "Token pool type", def: POOL_TYPE_BURN_MINT, enum: []string{POOL_TYPE_BURN_MINT, POOL_TYPE_LOCK_RELEASE, POOL_TYPE_USDC}},
+	"TokenConfig.CCTP":             {description: "Marks the token as CCTP backed, which requires a USDC token pool"},
+	"TokenConfig.InitialLiquidity": {description: "Liquidity provided to the token pool on each chain before traffic starts"},
+	"TokenConfig.TokenAdmin":       {description: "How the token is onboarded through the token admin registry"},
+	"TokenConfig.PoolTypePerLane":  {description: "Pool type keyed by source->dest, overriding PoolType", enum: []string{POOL_TYPE_BURN_MINT, POOL_TYPE_LOCK_RELEASE}},
+	"TokenConfig.GrantMintRole":    {description: "Grants the burn/mint pools mint and burn rights on the token, true unless set"},
+	"TokenConfig.DecimalsPerChain": {description: "Decimals of the token keyed by chain, overriding Decimals"},
+	"TokenConfig.RoundingMode":     {description: "What happens to amounts that don't convert exactly between decimals", def: DEFAULT_ROUNDING_MODE, enum: roundingModes},
+
+	"TokenAdminConfig.AdminMode":          {description: "When the token admin is registered", enum: []string{ADMIN_MODE_PRE_REGISTERED, ADMIN_MODE_SELF_SERVE_DURING_TEST, ADMIN_MODE_UNREGISTERED}},
+	"TokenAdminConfig.AdminKeyIndex":      {description: "Index of the genesis funded account acting as token admin", min: zero},
+	"TokenAdminConfig.RegistrationMethod": {description: "How the token admin is registered", def: REGISTRATION_METHOD_REGISTRY_MODULE, enum: []string{REGISTRATION_METHOD_REGISTRY_MODULE, REGISTRATION_METHOD_OWNER}},
+
+	"PriceConfig.GasPriceDeviationPPB":   {description: "Gas price change in parts per billion that triggers an update", def: fmt.Sprint(DEFAULT_GAS_PRICE_DEVIATION_PPB), min: one, max: maxPPB},
+	"PriceConfig.TokenPriceDeviationPPB": {description: "Token price change in parts per billion that triggers an update", def: fmt.Sprint(DEFAULT_TOKEN_PRICE_DEVIATION_PPB), min: one, max: maxPPB},
+	"PriceConfig.PriceUpdateInterval":    {description: "Interval of the price updates", def: DEFAULT_PRICE_UPDATE_INTERVAL.String()},
+	"PriceConfig.StalenessThreshold":     {description: "Age after which prices count as stale", def: DEFAULT_PRICE_STALENESS_THRESHOLD.String()},
+	"PriceConfig.InitialTokenPricesUSD":  {description: "Initial USD price per token symbol, like \"15.5\""},
+
+	"RateLimits.Default":      {description: "Rate limit of every token pool"},
+	"RateLimits.PerLane":      {description: "Rate limits keyed by source->dest"},
+	"RateLimits.PerToken":     {description: "Rate limits keyed by token symbol"},
+	"RateLimits.PerLaneToken": {description: "Rate limits keyed by source->dest and then by token symbol"},
+
+	"RateLimitConfig.Enabled":  {description: "Enables the rate limiter"},
+	"RateLimitConfig.Capacity": {description: "Bucket capacity in the token's smallest unit"},
+	"RateLimitConfig.Rate":     {description: "Refill rate per second in the token's smallest unit"},
+
+	"LoadProfile.MessagesPerSecond":     {description: "Mean rate of messages per lane", min: zero},
+	"LoadProfile.TestDuration":          {description: "How long traffic is sent"},
+	"LoadProfile.TokenAmountPerMessage": {description: "Amount of each token per message, in its smallest unit"},
+	"LoadProfile.MessageSizeBytes":      {description: "Payload size of every message"},
+	"LoadProfile.TokensPerMessage":      {description: "Number of tokens every message carries"},
+	"LoadProfile.ConcurrentSenders":     {description: "Goroutines sending messages per chain", def: "1", min: one},
+	"LoadProfile.Pattern":               {description: "How sends are spread over time", def: LOAD_PATTERN_CONSTANT, enum: loadPatterns},
+	"LoadProfile.BurstSize":             {description: "Messages sent back to back every BurstInterval by the burst pattern", min: one},
+	"LoadProfile.BurstInterval":         {description: "Interval of the bursts of the burst pattern"},
+	"LoadProfile.JitterPct":             {description: "Stretches or shrinks every delay randomly by up to this percentage", min: zero, max: percentage},
+
+	"MessageLimits.MaxDataBytes":            {description: "Largest payload a message may carry", min: one},
+	"MessageLimits.MaxPerMsgGasLimit":       {description: "Largest gas limit a message may ask for", min: one},
+	"MessageLimits.MaxNumberOfTokensPerMsg": {description: "Most tokens a message may carry"},
+	"MessageLimits.AllowOverLimitMessages":  {description: "Set by tests that send messages exceeding the limits on purpose"},
+
+	"USDCMockConfig.Enabled":                  {description: "Starts the mock attestation API"},
+	"USDCMockConfig.AttestationDelay":         {description: "How long attestations take", def: DEFAULT_USDC_MOCK_ATTESTATION_DELAY.String()},
+	"USDCMockConfig.FailureRatePct":           {description: "Percentage of attestation requests that fail", min: zero, max: percentage},
+	"USDCMockConfig.Port":                     {description: "Port of the mock, drawn from the port allocator when unset", min: one, max: maxPort},
+	"USDCMockConfig.FixedAttestationResponse": {description: "Response returned for every request, for deterministic replay tests"},
+	"USDCMockConfig.TokenSymbol":              {description: "Token in Tokens backed by CCTP", def: DEFAULT_USDC_TOKEN_SYMBOL},
+
+	"ExecutionScenario.Mode":                        {description: "Who executes the messages", enum: []string{EXEC_MODE_SMART, EXEC_MODE_MANUAL, EXEC_MODE_MIXED}},
+	"ExecutionScenario.ManualExecDelay":             {description: "How long after the commit messages are executed manually"},
+	"ExecutionScenario.PermissionlessExecThreshold": {description: "Offramp threshold after which anyone can execute a message", def: DEFAULT_PERMISSIONLESS_EXEC_THRESHOLD.String()},
+	"ExecutionScenario.ManualExecRatio":             {description: "Fraction of messages executed manually in mixed mode", min: zero, max: one},
+
+	"Timeouts.CommitTimeout":      {description: "How long to wait for a message to be committed", def: DEFAULT_COMMIT_TIMEOUT.String()},
+	"Timeouts.BlessTimeout":       {description: "How long to wait for a commit report to be blessed", def: DEFAULT_BLESS_TIMEOUT.String()},
+	"Timeouts.ExecTimeout":        {description: "How long to wait for a message to be executed", def: DEFAULT_EXEC_TIMEOUT.String()},
+	"Timeouts.SetupTimeout":       {description: "How long setting up the environment may take", def: DEFAULT_SETUP_TIMEOUT.String()},
+	"Timeouts.OverallTestTimeout": {description: "How long the whole test may take", def: DEFAULT_OVERALL_TEST_TIMEOUT.String()},
+	"Timeouts.ScaleFactor":        {description: "Multiplies every timeout, for slow environments", def: "1"},
+
+	"Messages.CountPerLane":     {description: "Messages sent per lane", def: fmt.Sprint(DEFAULT_MESSAGE_COUNT_PER_LANE), min: one},
+	"Messages.PayloadType":      {description: "Payload of the messages", enum: []string{PAYLOAD_TYPE_EMPTY, PAYLOAD_TYPE_RANDOM, PAYLOAD_TYPE_FIXED, PAYLOAD_TYPE_TOKENS_ONLY, PAYLOAD_TYPE_PROGRAMMATIC_RECEIVER}},
+	"Messages.FixedPayloadHex":  {description: "Hex payload, required iff PayloadType is fixed"},
+	"Messages.ReceiverGasLimit": {description: "Gas limit of the receiver call"},
+
+	"HomeChainConfig.DONFamilies":         {description: "Plugin DONs configured in CCIPHome", enum: []string{DON_FAMILY_COMMIT, DON_FAMILY_EXEC}},
+	"HomeChainConfig.CandidateConfigOnly": {description: "Sets the candidate config without promoting it"},
+	"HomeChainConfig.FPerDON":             {description: "Faulty nodes tolerated per DON family, (nodes-1)/3 by default", min: one},
+	"HomeChainConfig.CapabilityVersion":   {description: "Version of the CCIP capability", def: DEFAULT_CAPABILITY_VERSION, enum: SupportedCapabilityVersions},
+
+	"ExtraArgs.Default": {description: "Extra args of every message"},
+	"ExtraArgs.PerDest": {description: "Extra args keyed by destination chain"},
+
+	"ExtraArgsConfig.GasLimit":   {description: "Gas limit of the receiver call"},
+	"ExtraArgsConfig.OutOfOrder": {description: "Allows out of order execution"},
+	"ExtraArgsConfig.Version":    {description: "Extra args encoding", enum: []string{EXTRA_ARGS_EVM_V1, EXTRA_ARGS_EVM_V2, EXTRA_ARGS_SVM_V1}},
+
+	"Thresholds.MaxFailedMessagesPct":  {description: "Largest percentage of failed messages", min: zero, max: percentage},
+	"Thresholds.P95CommitLatency":      {description: "Largest 95th percentile commit latency"},
+	"Thresholds.P95ExecLatency":        {description: "Largest 95th percentile exec latency"},
+	"Thresholds.MaxManualExecRequired": {description: "Most messages that may need manual execution", min: zero},
+
+	"ExecConfig.MaxGasPriceMultiplier": {description: "Caps the dest gas price exec pays, as a multiple of the price at send time", min: one},
+	"ExecConfig.MaxMessagesPerBatch":   {description: "Largest batch of messages committed and executed together", def: fmt.Sprint(DEFAULT_MAX_MESSAGES_PER_BATCH), min: one},
+
+	"GasSpikeScenario.DestChain":        {description: "Network name or selector of the chain to spike"},
+	"GasSpikeScenario.SpikeMultiplier":  {description: "Multiplies the gas price during the spike"},
+	"GasSpikeScenario.SpikeDuration":    {description: "How long the spike lasts", def: DEFAULT_GAS_SPIKE_DURATION.String()},
+	"GasSpikeScenario.SpikeStartOffset": {description: "When the spike starts, from the start of the traffic"},
+	"GasSpikeScenario.ExpectedBehavior": {description: "What exec is expected to do during the spike", enum: []string{GAS_SPIKE_DELAY_UNTIL_NORMAL, GAS_SPIKE_EXECUTE_AT_HIGHER_COST}},
+
+	"Receivers.Default": {description: "Receiver of every lane"},
+	"Receivers.PerLane": {description: "Receivers keyed by source->dest, replacing Default entirely; lanes to the same destination must agree"},
+
+	"ReceiverConfig.Mode": {description: "Whether the receiver accepts or reverts every message", enum: []string{RECEIVER_MODE_NORMAL, RECEIVER_MODE_REVERT_ALL}},
+
+	"Observability.LokiEndpoint":          {description: "Loki push endpoint", envVar: E2E_CCIP_LOKI_ENDPOINT},
+	"Observability.LokiTenant":            {description: "Loki tenant", envVar: E2E_CCIP_LOKI_TENANT},
+	"Observability.LokiBasicAuth":         {description: "Loki basic auth", envVar: E2E_CCIP_LOKI_BASIC_AUTH},
+	"Observability.GrafanaURL":            {description: "Grafana base URL", envVar: E2E_CCIP_GRAFANA_URL},
+	"Observability.GrafanaToken":          {description: "Grafana API token", envVar: E2E_CCIP_GRAFANA_TOKEN},
+	"Observability.PrometheusPushgateway": {description: "Prometheus pushgateway URL", envVar: E2E_CCIP_PROMETHEUS_PUSHGATEWAY},
+	"Observability.DashboardUID":          {description: "UID of the Grafana dashboard linked in reports", envVar: E2E_CCIP_DASHBOARD_UID},
+	"Observability.AnnotateGrafana":       {description: "Annotates the Grafana dashboard with the test events"},
+	"Observability.AnnotationTags":        {description: "Tags added to every annotation"},
+
+	"Reporting.OutputDir":               {description: "Directory the report is written to", def: DEFAULT_REPORT_OUTPUT_DIR},
+	"Reporting.Format":                  {description: "Report format", def: DEFAULT_REPORT_FORMAT, enum: []string{REPORT_FORMAT_JSON, REPORT_FORMAT_JUNIT}},
+	"Reporting.IncludePerMessageDetail": {description: "Lists every message in the report"},
+
+	"Tracing.Enabled":           {description: "Enables tracing"},
+	"Tracing.CollectorEndpoint": {description: "OTLP gRPC collector, host:port or a http(s) URL"},
+	"Tracing.SamplingRatio":     {description: "Fraction of traces sampled", def: fmt.Sprint(DEFAULT_TRACING_SAMPLING_RATIO), min: zero, max: one},
+	"Tracing.InjectIntoNodes":   {description: "Points the nodes' own tracing at the same collector"},
+
+	"Notifications.WebhookURL":       {description: "Webhook the notifications are posted to"},
+	"Notifications.Channel":          {description: "Channel the notifications are posted in"},
+	"Notifications.NotifyOn":         {description: "Events that are notified", enum: []string{NOTIFY_ON_START, NOTIFY_ON_THRESHOLD_VIOLATION, NOTIFY_ON_FAILURE, NOTIFY_ON_COMPLETION, NOTIFY_ON_HEARTBEAT_MISSED}},
+	"Notifications.MentionOnFailure": {description: "Handles mentioned when the test fails"},
+	"Notifications.MinInterval":      {description: "Least time between two notifications of the same event", def: DEFAULT_NOTIFICATION_MIN_INTERVAL.String()},
+
+	"LogCollection.Targets":              {description: "Where logs are collected to", enum: []string{LOG_TARGET_LOKI, LOG_TARGET_FILE, LOG_TARGET_NONE}},
+	"LogCollection.FileDir":              {description: "Directory of the file target", def: DEFAULT_LOG_FILE_DIR},
+	"LogCollection.CollectOnFailureOnly": {description: "Only collects logs of failed tests"},
+	"LogCollection.Nodes":                {description: "Collects the node logs, true unless set"},
+	"LogCollection.JD":                   {description: "Collects the job distributor logs, true unless set"},
+	"LogCollection.RMN":                  {description: "Collects the RMN logs, true unless set"},
+	"LogCollection.Chains":               {description: "Collects the chain client logs, true unless set"},
+
+	"SethConfig.Default":  {description: "Seth settings of every chain"},
+	"SethConfig.PerChain": {description: "Seth settings keyed by network name, merged over Default"},
+
+	"SethSettings.TracingLevel":           {description: "Which transactions Seth traces", def: DEFAULT_SETH_TRACING_LEVEL, enum: []string{SETH_TRACING_LEVEL_NONE, SETH_TRACING_LEVEL_REVERTED, SETH_TRACING_LEVEL_ALL}},
+	"SethSettings.PendingNonceProtection": {description: "Waits for pending transactions before using a key again"},
+	"SethSettings.GasBumpRetries":         {description: "Times a stuck transaction's gas is bumped", def: fmt.Sprint(DEFAULT_SETH_GAS_BUMP_RETRIES), min: zero},
+	"SethSettings.EphemeralKeys":          {description: "Ephemeral keys Seth funds and sends from", min: zero},
+
+	"Profiling.Enabled":        {description: "Enables profiling"},
+	"Profiling.NodesToProfile": {description: "Zero based indexes of the plugin nodes to profile"},
+	"Profiling.CaptureAt":      {description: "Points of the load test profiles are captured at", enum: []string{PROFILE_AT_RAMP_UP_END, PROFILE_AT_STEADY_STATE, PROFILE_AT_SPIKE}},
+	"Profiling.ProfileTypes":   {description: "Profiles captured", enum: []string{PROFILE_TYPE_CPU, PROFILE_TYPE_HEAP, PROFILE_TYPE_GOROUTINE}},
+	"Profiling.OutputDir":      {description: "Directory the profiles are written to", def: DEFAULT_PROFILING_OUTPUT_DIR},
+
+	"K8sConfig.Namespace":          {description: "Namespace of the environment"},
+	"K8sConfig.ChartOverridesFile": {description: "Helm values file applied over the crib charts"},
+	"K8sConfig.StorageClass":       {description: "Storage class of the persistent volumes"},
+	"K8sConfig.NodeSelectorLabels": {description: "Node selector of the pods"},
+	"K8sConfig.TTL":                {description: "How long the namespace lives", def: DEFAULT_K8S_TTL.String()},
+
+	"DockerConfig.ReuseNetwork":    {description: "Existing docker network to start the containers in"},
+	"DockerConfig.NetworkName":     {description: "Name of the docker network to create"},
+	"DockerConfig.ContainerPrefix": {description: "Prefix of the container names"},
+	"DockerConfig.PortOffset":      {description: "Added to every exposed host port"},
+
+	"Lifecycle.KeepAlive":          {description: "Keeps the environment after the test"},
+	"Lifecycle.ReuseEnvironmentID": {description: "ID of a kept alive environment to run against"},
+	"Lifecycle.TTL":                {description: "How long a kept alive environment lives"},
+	"Lifecycle.TeardownOnFailure":  {description: "Tears failed environments down, true unless set"},
+	"Lifecycle.StateDir":           {description: "Where the state of kept alive environments is stored", def: DEFAULT_LIFECYCLE_STATE_DIR},
+
+	"ChainContracts.Router":             {description: "Router address"},
+	"ChainContracts.OnRamp":             {description: "OnRamp address"},
+	"ChainContracts.OffRamp":            {description: "OffRamp address"},
+	"ChainContracts.FeeQuoter":          {description: "FeeQuoter address"},
+	"ChainContracts.TokenAdminRegistry": {description: "TokenAdminRegistry address"},
+	"ChainContracts.RMNRemote":          {description: "RMNRemote address"},
+	"ChainContracts.RMNProxy":           {description: "RMNProxy address"},
+	"ChainContracts.NonceManager":       {description: "NonceManager address"},
+	"ChainContracts.DeployMissing":      {description: "Deploys the contracts not listed instead of failing validation"},
+
+	"DeployerConfig.PrivateKey":           {description: "Deployer private key, exclusive with KMSKeyID and LedgerDerivationPath"},
+	"DeployerConfig.KMSKeyID":             {description: "KMS key of the deployer"},
+	"DeployerConfig.LedgerDerivationPath": {description: "Ledger derivation path of the deployer"},
+	"DeployerConfig.OwnerType":            {description: "Who owns the deployed contracts", def: DEFAULT_OWNER_TYPE, enum: []string{OWNER_TYPE_EOA, OWNER_TYPE_TIMELOCK, OWNER_TYPE_NONE}},
+	"DeployerConfig.Funding":              {description: "Tops up the deployer, only the amounts of its own chain apply"},
+
+	"MockServiceConfig.Name":           {description: "Name of the mock and its container"},
+	"MockServiceConfig.Type":           {description: "Kind of service mocked", enum: mockTypes},
+	"MockServiceConfig.Port":           {description: "Port of the mock, drawn from the port allocator when unset", min: one, max: maxPort},
+	"MockServiceConfig.Responses":      {description: "Response body per request path"},
+	"MockServiceConfig.LatencyMs":      {description: "Delay of every response in milliseconds", min: zero},
+	"MockServiceConfig.FailureRatePct": {description: "Percentage of requests that fail", min: zero, max: percentage},
+
+	"RetryPolicy.PerTarget": {description: "Retry settings keyed by target", enum: []string{RETRY_TARGET_RPC, RETRY_TARGET_JD, RETRY_TARGET_NODE_API}},
+
+	"RetrySettings.MaxAttempts":       {description: "Attempts per call", def: fmt.Sprint(DEFAULT_RETRY_MAX_ATTEMPTS), min: one},
+	"RetrySettings.InitialBackoff":    {description: "Wait before the first retry", def: DEFAULT_RETRY_INITIAL_BACKOFF.String()},
+	"RetrySettings.MaxBackoff":        {description: "Longest wait between retries", def: DEFAULT_RETRY_MAX_BACKOFF.String()},
+	"RetrySettings.BackoffMultiplier": {description: "Multiplies the wait after every retry", def: fmt.Sprint(DEFAULT_RETRY_BACKOFF_MULTIPLIER), min: one},
+
+	"ExplorerConfig.APIURL":          {description: "Explorer API URL"},
+	"ExplorerConfig.APIKey":          {description: "Explorer API key"},
+	"ExplorerConfig.BrowserURL":      {description: "Explorer URL transactions are linked to in the report"},
+	"ExplorerConfig.VerifyContracts": {description: "Verifies the deployed contracts"},
+
+	"SenderConfig.AccountsPerChain":    {description: "Accounts messages are sent from per chain", min: one},
+	"SenderConfig.FundEachWith":        {description: "Native amount every account is funded with, like \"1.5\" or \"500gwei\""},
+	"SenderConfig.NonceStrategy":       {description: "How nonces are assigned", def: DEFAULT_NONCE_STRATEGY, enum: []string{NONCE_STRATEGY_SEQUENTIAL, NONCE_STRATEGY_PARALLEL_PENDING}},
+	"SenderConfig.RebalanceBelow":      {description: "Native balance below which an account is topped up"},
+	"SenderConfig.AllowSharedAccounts": {description: "Allows fewer accounts than concurrent senders"},
+	"SenderConfig.Funding":             {description: "Per chain funding of the accounts, FundEachWith overrides its native amounts"},
+
+	"GasStrategy.Mode":               {description: "How transactions are priced", def: DEFAULT_GAS_MODE, enum: []string{GAS_MODE_ESTIMATE, GAS_MODE_FIXED, GAS_MODE_ORACLE}},
+	"GasStrategy.FixedGasPriceGwei":  {description: "Gas price of the fixed mode"},
+	"GasStrategy.FeeCapGwei":         {description: "EIP-1559 fee cap"},
+	"GasStrategy.TipCapGwei":         {description: "EIP-1559 tip cap"},
+	"GasStrategy.EstimateMultiplier": {description: "Multiplies the estimated gas price", def: fmt.Sprint(DEFAULT_GAS_ESTIMATE_MULTIPLIER), min: one},
+
+	"JobSpecOverrides.CommitTemplateFile": {description: "text/template file the commit job specs are rendered from"},
+	"JobSpecOverrides.ExecTemplateFile":   {description: "text/template file the exec job specs are rendered from"},
+	"JobSpecOverrides.ExtraPluginConfig":  {description: "Merged into the pluginConfig of every generated spec"},
+
+	"HealthChecks.Node":  {description: "Readiness check of the nodes"},
+	"HealthChecks.JD":    {description: "Readiness check of the job distributor"},
+	"HealthChecks.RMN":   {description: "Readiness check of the RMN nodes"},
+	"HealthChecks.Chain": {description: "Readiness check of the chains"},
+
+	"HealthCheckSettings.Interval":         {description: "Interval of the checks"},
+	"HealthCheckSettings.Timeout":          {description: "How long the component may take to become ready"},
+	"HealthCheckSettings.SuccessThreshold": {description: "Consecutive successful checks to count as ready", min: one},
+
+	"FinalityViolationScenario.Chain":                    {description: "Private network to reorg"},
+	"FinalityViolationScenario.ReorgDepthBeyondFinality": {description: "Blocks reorged beyond the finality depth", def: fmt.Sprint(DEFAULT_REORG_DEPTH_BEYOND_FINALITY), min: one},
+	"FinalityViolationScenario.TriggerAfterMessages":     {description: "Messages sent from the chain before the reorg", def: fmt.Sprint(DEFAULT_TRIGGER_AFTER_MESSAGES), min: one},
+	"FinalityViolationScenario.ExpectedOutcome":          {description: "How the system is expected to react", enum: []string{FINALITY_VIOLATION_HALT, FINALITY_VIOLATION_CURSE, FINALITY_VIOLATION_ALERT_ONLY}},
+
+	"Resources.Node":        {description: "Resources of the node containers"},
+	"Resources.DB":          {description: "Resources of the database containers"},
+	"Resources.ChainClient": {description: "Resources of the chain client containers"},
+
+	"ResourceRequirements.CPU":      {description: "CPU in cores, fractions are allowed"},
+	"ResourceRequirements.MemoryMB": {description: "Memory in MB", min: one},
+
+	"ChainTokens.LINK":          {description: "LINK token address"},
+	"ChainTokens.WrappedNative": {description: "Wrapped native token address"},
+
+	"PluginLogging.PerNode": {description: "Plugin log settings keyed by node name"},
+
+	"PluginLogSettings.CommitLogLevel":        {description: "Log level of the commit plugin", enum: pluginLogLevels},
+	"PluginLogSettings.ExecLogLevel":          {description: "Log level of the exec plugin", enum: pluginLogLevels},
+	"PluginLogSettings.EnableCustomTelemetry": {description: "Sends the plugins' custom telemetry"},
+	"PluginLogSettings.TelemetryEndpoint":     {description: "OTLP gRPC collector of the telemetry, host:port or a http(s) URL"},
+
+	"FailureArtifacts.Enabled":   {description: "Writes the bundle when a test fails"},
+	"FailureArtifacts.OutputDir": {description: "Directory the bundle is written to", def: DEFAULT_FAILURE_ARTIFACTS_DIR},
+	"FailureArtifacts.Include":   {description: "Artifacts collected, all of them when empty", enum: artifactKinds},
+	"FailureArtifacts.MaxSizeMB": {description: "Largest size of the bundle", def: fmt.Sprint(DEFAULT_FAILURE_ARTIFACTS_MAX_SIZE_MB), min: one},
+
+	"ChainIDRange.Start": {description: "First chain ID allocated, inclusive", min: one},
+	"ChainIDRange.End":   {description: "Last chain ID allocated, inclusive", min: one},
+
+	"WarmUp.MessagesPerLane":    {description: "Warm-up messages per lane, exclusive with Duration", min: one},
+	"WarmUp.Duration":           {description: "How long warm-up lasts, exclusive with MessagesPerLane"},
+	"WarmUp.ExcludeFromResults": {description: "Keeps warm-up messages out of thresholds and report totals, true unless set"},
+	"WarmUp.AbortIfWarmUpFails": {description: "Fails the test if a warm-up message isn't executed"},
+
+	"ConfigRollout.CandidateOCRParams":   {description: "OCR parameters of the candidate config"},
+	"ConfigRollout.PromoteAfter":         {description: "When the candidate is promoted, from the start of the traffic"},
+	"ConfigRollout.PromoteAfterMessages": {description: "Messages sent before the candidate is promoted", min: one},
+	"ConfigRollout.ExpectZeroDowntime":   {description: "Fails the test if executions pause for longer than MaxExecGap around the promotion"},
+	"ConfigRollout.MaxExecGap":           {description: "Longest pause of executions around the promotion", def: DEFAULT_ROLLOUT_MAX_EXEC_GAP.String()},
+
+	"OCRParams.DeltaProgress":                           {description: "OCR DeltaProgress"},
+	"OCRParams.DeltaResend":                             {description: "OCR DeltaResend"},
+	"OCRParams.DeltaInitial":                            {description: "OCR DeltaInitial"},
+	"OCRParams.DeltaRound":                              {description: "OCR DeltaRound"},
+	"OCRParams.DeltaGrace":                              {description: "OCR DeltaGrace"},
+	"OCRParams.DeltaCertifiedCommitRequest":             {description: "OCR DeltaCertifiedCommitRequest"},
+	"OCRParams.DeltaStage":                              {description: "OCR DeltaStage"},
+	"OCRParams.Rmax":                                    {description: "OCR Rmax, the rounds per epoch"},
+	"OCRParams.MaxDurationQuery":                        {description: "OCR MaxDurationQuery"},
+	"OCRParams.MaxDurationObservation":                  {description: "OCR MaxDurationObservation"},
+	"OCRParams.MaxDurationShouldAcceptAttestedReport":   {description: "OCR MaxDurationShouldAcceptAttestedReport"},
+	"OCRParams.MaxDurationShouldTransmitAcceptedReport": {description: "OCR MaxDurationShouldTransmitAcceptedReport"},
+
+	"AddressExport.Formats":   {description: "Formats written, all of them when empty", enum: addressExportFormats},
+	"AddressExport.OutputDir": {description: "Directory the addresses are written to", def: DEFAULT_ADDRESS_EXPORT_DIR},
+
+	"PollingConfig.PerChain": {description: "Polling settings keyed by chain"},
+
+	"PollingSettings.Interval":                       {description: "First polling interval", def: DEFAULT_POLL_INTERVAL.String()},
+	"PollingSettings.MaxInterval":                    {description: "Longest polling interval"},
+	"PollingSettings.Backoff":                        {description: "Multiplies the interval after every poll", def: fmt.Sprint(DEFAULT_POLL_BACKOFF), min: one},
+	"PollingSettings.UseSubscriptionsWhereAvailable": {description: "Waits on WS log subscriptions, polling only at MaxInterval"},
+
+	"MessageComposition.PerLane": {description: "Composition keyed by source->dest"},
+
+	"MessageCompositionSettings.TokensPerMessage":       {description: "Range of the number of tokens per message"},
+	"MessageCompositionSettings.TokenSelectionStrategy": {description: "How the tokens of a message are picked", def: DEFAULT_TOKEN_SELECTION, enum: tokenSelectionStrategies},
+	"MessageCompositionSettings.FixedSet":               {description: "Token symbols every message carries with fixedSet"},
+	"MessageCompositionSettings.IncludeDataWithTokens":  {description: "Adds the Messages payload to messages carrying tokens, true unless set"},
+
+	"TokensPerMessage.Min": {description: "Fewest tokens per message"},
+	"TokensPerMessage.Max": {description: "Most tokens per message"},
+
+	"ConfigServer.Enabled":       {description: "Starts the config server"},
+	"ConfigServer.Port":          {description: "Port of the server, assigned by the port allocator when unset", min: one, max: maxPort},
+	"ConfigServer.ListenAddress": {description: "IP the server binds to, 0.0.0.0 serves on every interface", def: DEFAULT_CONFIG_SERVER_LISTEN_ADDRESS},
+
+	"Schedule.PauseWindows":    {description: "Recurring windows the actions are paused in"},
+	"Schedule.ActionsAffected": {description: "Actions paused, all of them when empty", enum: scheduleActions},
+
+	"Window.Days":     {description: "Weekdays the window starts on, like \"Sat\", every day when empty"},
+	"Window.Start":    {description: "Time of day the window starts, like \"22:00\""},
+	"Window.End":      {description: "Time of day the window ends, past midnight when at or before Start"},
+	"Window.Timezone": {description: "IANA zone of the times, UTC when empty"},
+
+	"DeploymentConfig.MaxConcurrentChains":     {description: "Chains deployed to at the same time", def: fmt.Sprint(DEFAULT_MAX_CONCURRENT_CHAINS), min: one},
+	"DeploymentConfig.MaxConcurrentTxPerChain": {description: "Deployment transactions in flight per chain", def: fmt.Sprint(DEFAULT_MAX_CONCURRENT_TX_PER_CHAIN), min: one},
+	"DeploymentConfig.ContinueOnChainFailure":  {description: "Deploys the remaining chains after one failed"},
+
+	"PriceManipulationScenario.TargetChain":  {description: "Network name or selector of the destination chain"},
+	"PriceManipulationScenario.Mode":         {description: "How the gas price is manipulated", enum: priceManipulationModes},
+	"PriceManipulationScenario.Multiplier":   {description: "Factor the gas price is over- or understated by"},
+	"PriceManipulationScenario.ApplyAfter":   {description: "When the manipulation starts, from the start of the test"},
+	"PriceManipulationScenario.RestoreAfter": {description: "When the manipulation ends, from the start of the test"},
+
+	"ChainSemantics.Type":              {description: "Finality model of the chain", def: CHAIN_SEMANTICS_STANDARD, enum: chainSemanticsTypes},
+	"ChainSemantics.SoftConfirmations": {description: "Blocks a zk rollup needs before a transaction counts as executed"},
+	"ChainSemantics.HardFinalityLag":   {description: "How long a rollup block takes to become final on L1"},
+
+	"ChainSnapshots.Enabled":            {description: "Enables the snapshots"},
+	"ChainSnapshots.SnapshotAfterPhase": {description: "Phase after which the snapshots are taken", def: DEFAULT_SNAPSHOT_AFTER_PHASE, enum: AllPhases},
+	"ChainSnapshots.SnapshotDir":        {description: "Directory of the snapshots", def: DEFAULT_SNAPSHOT_DIR},
+	"ChainSnapshots.RestoreOnStart":     {description: "Restores the snapshots before the first phase instead of taking them"},
+
+	"AssertionSampling.Mode":                    {description: "Which messages are asserted one by one", def: ASSERTION_SAMPLING_ALL, enum: assertionSamplingModes},
+	"AssertionSampling.SampleRatePct":           {description: "Percentage of messages asserted", min: zero, max: percentage},
+	"AssertionSampling.AlwaysAssertFailedSends": {description: "Asserts every message whose send failed, true unless set"},
+	"AssertionSampling.MinSampled":              {description: "Fewest messages asserted per lane", def: fmt.Sprint(DEFAULT_ASSERTION_MIN_SAMPLED), min: one},
+
+	"ChainAssertionSource.Source":         {description: "Assertion source of the chain", enum: assertionSources},
+	"ChainAssertionSource.UnreliableLogs": {description: "Flags RPC providers that lag on log indexing, which forces state reads"},
+
+	"TransportPreferences.Default": {description: "Transports of every chain"},
+	"TransportPreferences.Chains":  {description: "Transports keyed by network name or selector"},
+
+	"TransportPreference.Send":      {description: "Transport of sends", def: TRANSPORT_HTTP, enum: transports},
+	"TransportPreference.Query":     {description: "Transport of queries", def: TRANSPORT_WS, enum: transports},
+	"TransportPreference.Subscribe": {description: "Transport of subscriptions", def: TRANSPORT_WS, enum: []string{TRANSPORT_WS}},
+
+	"LatencyModel.Adaptive":       {description: "Waits the estimated latency times SafetyFactor on every lane instead of the timeouts"},
+	"LatencyModel.SafetyFactor":   {description: "Multiplies the estimated latency of a lane", def: fmt.Sprint(DEFAULT_LATENCY_SAFETY_FACTOR), min: one},
+	"LatencyModel.SourceFinality": {description: "Finality of the source chain, estimated for private networks unless set"},
+	"LatencyModel.CommitInterval": {description: "How often commit reports are sent", def: DEFAULT_COMMIT_INTERVAL.String()},
+	"LatencyModel.BlessingTime":   {description: "How long RMN takes to bless a commit report", def: DEFAULT_BLESSING_TIME.String()},
+	"LatencyModel.DestInclusion":  {description: "How long the execution takes to be included on the dest chain, one block of private networks unless set"},
+
+	"ChainHaltScenario.TargetChain":             {description: "Network name or selector of the private network to halt"},
+	"ChainHaltScenario.HaltAfter":               {description: "When block production stops, from the start of the test"},
+	"ChainHaltScenario.HaltDuration":            {description: "How long block production stays stopped"},
+	"ChainHaltScenario.ExpectedUnaffectedLanes": {description: "Lanes as source->dest that keep making progress during the halt"},
+
+	"Heartbeat.Enabled":          {description: "Sends heartbeats during the test"},
+	"Heartbeat.Interval":         {description: "Time between two heartbeats of a chain, at least the block time of the slowest chain", def: DEFAULT_HEARTBEAT_INTERVAL.String()},
+	"Heartbeat.Chains":           {description: "Network names or selectors to send heartbeats on, every chain of the test when empty"},
+	"Heartbeat.AlertAfterMissed": {description: "Heartbeats a chain misses in a row before it's notified", def: fmt.Sprint(DEFAULT_HEARTBEAT_ALERT_AFTER_MISSED), min: one},
+
+	"Remediation.Mode":                    {description: "How stuck messages are remediated", def: REMEDIATION_MODE_NONE, enum: remediationModes},
+	"Remediation.TriggerAfter":            {description: "How long past its expected latency a message is stuck", def: DEFAULT_REMEDIATION_TRIGGER_AFTER.String()},
+	"Remediation.MaxRemediationsPct":      {description: "Percentage of the sent messages that may be remediated", def: fmt.Sprint(DEFAULT_MAX_REMEDIATIONS_PCT), min: zero, max: percentage},
+	"Remediation.CountRemediatedAsFailed": {description: "Thresholds count remediated messages as failed", def: fmt.Sprint(DEFAULT_COUNT_REMEDIATED_AS_FAILED)},
+
+	"ScheduledToken.Symbol":              {description: "Symbol of the token, must not be one of Tokens"},
+	"ScheduledToken.Token":               {description: "Configuration of the token"},
+	"ScheduledToken.At":                  {description: "When the token is onboarded, from the start of the test"},
+	"ScheduledToken.Lanes":               {description: "Lanes as source->dest the pools of the token are registered on"},
+	"ScheduledToken.SendAfterOnboarding": {description: "Transfers of the token sent on each lane once it's onboarded", def: fmt.Sprint(DEFAULT_SEND_AFTER_ONBOARDING), min: zero},
+
+	"CommitAssertions.ExpectMinBatchSize": {description: "Fewest messages of a lane a commit report covers, the last report excepted", min: one},
+	"CommitAssertions.ExpectMaxReports":   {description: "Most commit reports covering messages of a lane", min: one},
+	"CommitAssertions.PerLane":            {description: "Expectations of \"source->dest\" lanes, overriding the defaults"},
+
+	"CommitExpectations.ExpectMinBatchSize": {description: "Fewest messages of the lane a commit report covers, the last report excepted", min: one},
+	"CommitExpectations.ExpectMaxReports":   {description: "Most commit reports covering messages of the lane", min: one},
+
+	"TeardownVerification.FailOnLeakedContainers": {description: "Fails the run if containers labeled with its RunID outlive teardown"},
+	"TeardownVerification.FailOnLeakedVolumes":    {description: "Fails the run if volumes labeled with its RunID outlive teardown"},
+	"TeardownVerification.SweepRemainingFunds":    {description: "Sweeps the balances of the sender, node and deployer accounts back after teardown"},
+	"TeardownVerification.SweepToAddress":         {description: "Address funds are swept back to, keyed by chain"},
+	"TeardownVerification.GasReserve":             {description: "Native amount left on every swept account", def: DEFAULT_SWEEP_GAS_RESERVE},
+	"TeardownVerification.SweepLiveDeployerKeys":  {description: "Live networks whose deployer key is swept too"},
+
+	"RPCBudgetConfig.RequestsPerSecond": {description: "Most requests per second, a second worth of them can be sent at once"},
+	"RPCBudgetConfig.DailyRequestCap":   {description: "Most requests per endpoint in 24 hours", min: one},
+	"RPCBudgetConfig.OnExceeded":        {description: "What a request over the budget does", def: RPC_BUDGET_ON_EXCEEDED_THROTTLE, enum: rpcBudgetOnExceeded},
+}
@@ -0,0 +1,127 @@
+package ccip
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFieldRegistryIsExhaustive fails for config fields added without an entry in fieldAnnotations.
+func TestFieldRegistryIsExhaustive(t *testing.T) {
+	for _, field := range FieldRegistry() {
+		require.NotEmpty(t, field.Description, "%s has no description in fieldAnnotations", field.Path)
+		require.NotEmpty(t, field.Type, field.Path)
+	}
+
+	keys := map[string]bool{}
+	collectAnnotationKeys(reflect.TypeOf(Config{}), keys)
+	for key := range fieldAnnotations {
+		require.True(t, keys[key], "fieldAnnotations has %s, which is not a config field", key)
+	}
+}
+
+func collectAnnotationKeys(t reflect.Type, keys map[string]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		switch {
+		case !f.IsExported():
+		case f.Anonymous:
+			collectAnnotationKeys(f.Type, keys)
+		default:
+			keys[t.Name()+"."+f.Name] = true
+			if nested, _ := nestedTable(f.Type); nested != nil {
+				collectAnnotationKeys(nested, keys)
+			}
+		}
+	}
+}
+
+func TestFieldRegistry(t *testing.T) {
+	fields := map[string]FieldInfo{}
+	for _, field := range FieldRegistry() {
+		require.NotContains(t, fields, field.Path)
+		fields[field.Path] = field
+	}
+
+	require.Equal(t, "duration", fields["Timeouts.CommitTimeout"].Type)
+	require.Equal(t, DEFAULT_COMMIT_TIMEOUT.String(), fields["Timeouts.CommitTimeout"].Default)
+	require.Equal(t, E2E_JD_IMAGE, fields["JobDistributorConfig.Image"].EnvVar)
+	require.True(t, fields["DeployerConfig.*.PrivateKey"].Secret)
+	require.Equal(t, "map[string]map[string]table", fields["RateLimits.PerLaneToken"].Type)
+	require.Contains(t, fields, "RateLimits.PerLaneToken.*.*.Capacity")
+	require.Equal(t, "bigint", fields["RateLimits.PerLaneToken.*.*.Capacity"].Type)
+	require.Contains(t, fields, "Mocks[].Name")
+	require.Equal(t, "[]table", fields["Mocks"].Type)
+	// embedded settings are flattened into their parent
+	require.Contains(t, fields, "RetryPolicy.MaxAttempts")
+	require.Equal(t, assertionSamplingModes, fields["AssertionSampling.Mode"].Enum)
+	require.Equal(t, 0.0, *fields["AssertionSampling.SampleRatePct"].Min)
+	require.Equal(t, 100.0, *fields["AssertionSampling.SampleRatePct"].Max)
+	// tables of other packages aren't expanded
+	require.Equal(t, "map[string]table", fields["PrivateEthereumNetworks"].Type)
+	require.NotContains(t, fields, "PrivateEthereumNetworks.*.ExecutionLayer")
+
+	encoded, err := json.Marshal(FieldRegistry())
+	require.NoError(t, err)
+	var decoded []FieldInfo
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	require.Equal(t, FieldRegistry(), decoded)
+}
+
+func TestFieldErrorDocs(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{
+			name:    "enum",
+			content: "[LoadProfile]\nMessagesPerSecond = 1.0\nTestDuration = '1h'\nPattern = 'square'",
+			err:     `LoadProfile.Pattern: unknown value "square" (How sends are spread over time; one of constant, burst, sine, poisson)`,
+		},
+		{
+			name:    "map entry",
+			content: "[GasStrategy.SIMULATED_9]\nMode = 'estimate'",
+			err:     "GasStrategy.SIMULATED_9: chain SIMULATED_9 is neither a chain selector, a configured private network nor a known chain name (How transactions are priced, keyed by chain)",
+		},
+		{
+			name:    "field of a map entry",
+			content: "[GasStrategy.SIMULATED_1]\nMode = 'auction'",
+			err:     `GasStrategy.SIMULATED_1.Mode: unknown value "auction" (How transactions are priced; one of estimate, fixed, oracle)`,
+		},
+		{
+			name:    "array entry",
+			content: "[LoadProfile]\nMessagesPerSecond = 1.0\nTestDuration = '1h'\n[[MidTestTokenOnboarding]]\nSymbol = 'NEW'\nAt = '1m'\nLanes = ['SIMULATED_1->nope']",
+			err:     "MidTestTokenOnboarding[0].Lanes: chain nope is neither a chain selector, a configured private network nor a known chain name (Lanes as source->dest the pools of the token are registered on)",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte(lintBaseTOML), &cfg))
+			require.NoError(t, toml.Unmarshal([]byte(tc.content), &cfg))
+			require.EqualError(t, cfg.Validate(), tc.err)
+		})
+	}
+
+	// fields the registry doesn't know are reported without docs
+	require.EqualError(t, fieldError("PrivateEthereumNetworks.SIMULATED_1.nope", errors.New("invalid")), "PrivateEthereumNetworks.SIMULATED_1.nope: invalid")
+}
+
+func TestExampleTOML(t *testing.T) {
+	example := ExampleTOML()
+	require.Contains(t, example, "# [LoadProfile]\n# Traffic sent on every lane during a load test\n")
+	require.Contains(t, example, "# How sends are spread over time; one of constant, burst, sine, poisson\n# Pattern = 'constant'\n")
+	require.Contains(t, example, "# [Tokens.<key>]\n")
+	require.Contains(t, example, "# [[MidTestTokenOnboarding]]\n")
+	require.Contains(t, example, "# [RateLimits.PerLaneToken.<key>.<key>]\n# Rate limits keyed by source->dest and then by token symbol\n# Enables the rate limiter\n")
+	require.Contains(t, example, "# read from "+E2E_JD_IMAGE+" when unset\n")
+
+	// every line is commented out, the example decodes to an empty config
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(example), &cfg))
+	require.Equal(t, Config{}, cfg)
+}
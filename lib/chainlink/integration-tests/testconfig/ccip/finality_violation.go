@@ -0,0 +1,109 @@
+package ccip
+
+import (
+	"fmt"
+
+	"github.com/AlekSi/pointer"
+
+	ctfconfig "github.com/smartcontractkit/chainlink-testing-framework/lib/config"
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/config/types"
+)
+
+const (
+	FINALITY_VIOLATION_HALT       = "halt"
+	FINALITY_VIOLATION_CURSE      = "curse"
+	FINALITY_VIOLATION_ALERT_ONLY = "alertOnly"
+
+	DEFAULT_REORG_DEPTH_BEYOND_FINALITY = 1
+	DEFAULT_TRIGGER_AFTER_MESSAGES      = 1
+)
+
+// FinalityViolationScenario reorgs a source chain deeper than its finality depth once some messages were
+// sent from it, to verify the offramp stops executing them and RMN reacts as expected.
+type FinalityViolationScenario struct {
+	// Chain is the name of the private network to reorg
+	Chain                    *string `toml:",omitempty"`
+	ReorgDepthBeyondFinality *int    `toml:",omitempty"`
+	TriggerAfterMessages     *int    `toml:",omitempty"`
+	ExpectedOutcome          *string `toml:",omitempty"`
+}
+
+// FinalityViolation is the resolved scenario, executed by the chaos driver and checked by the assert phase.
+type FinalityViolation struct {
+	ChainSelector uint64
+	// ReorgDepth is the number of blocks to reorg, finality depth included
+	ReorgDepth           int
+	TriggerAfterMessages int
+	ExpectedOutcome      string
+}
+
+// GetFinalityViolation resolves the finality violation scenario, returning false if none is configured.
+func (o *Config) GetFinalityViolation() (FinalityViolation, bool, error) {
+	f := o.FinalityViolation
+	if f == nil {
+		return FinalityViolation{}, false, nil
+	}
+	chain := pointer.GetString(f.Chain)
+	selector, err := o.ResolveChainSelector(chain)
+	if err != nil {
+		return FinalityViolation{}, false, fieldError("FinalityViolation.Chain", err)
+	}
+	beyondFinality := DEFAULT_REORG_DEPTH_BEYOND_FINALITY
+	if f.ReorgDepthBeyondFinality != nil {
+		beyondFinality = *f.ReorgDepthBeyondFinality
+	}
+	violation := FinalityViolation{
+		ChainSelector:        selector,
+		ReorgDepth:           finalityDepth(o.PrivateEthereumNetworks[chain]) + beyondFinality,
+		TriggerAfterMessages: DEFAULT_TRIGGER_AFTER_MESSAGES,
+		ExpectedOutcome:      pointer.GetString(f.ExpectedOutcome),
+	}
+	if f.TriggerAfterMessages != nil {
+		violation.TriggerAfterMessages = *f.TriggerAfterMessages
+	}
+	return violation, true, nil
+}
+
+// finalityDepth is the number of blocks after which a block on the private network is final.
+func finalityDepth(network *ctfconfig.EthereumNetworkConfig) int {
+	if network == nil || network.EthereumChainConfig == nil {
+		return ETH1_BLOCKS_TO_FINALITY
+	}
+	if network.EthereumVersion != nil && *network.EthereumVersion == types.EthereumVersion_Eth2 {
+		return ETH2_EPOCHS_TO_FINALITY * network.EthereumChainConfig.SlotsPerEpoch
+	}
+	return ETH1_BLOCKS_TO_FINALITY
+}
+
+func (o *Config) validateFinalityViolation() error {
+	f := o.FinalityViolation
+	if f == nil {
+		return nil
+	}
+	chain := pointer.GetString(f.Chain)
+	if chain == "" {
+		return fmt.Errorf("FinalityViolation.Chain must be set")
+	}
+	// live chains can't be reorged on demand
+	if _, ok := o.PrivateEthereumNetworks[chain]; !ok {
+		return fmt.Errorf("FinalityViolation.Chain %s must be one of PrivateEthereumNetworks, finality can't be violated on live networks", chain)
+	}
+	if f.ReorgDepthBeyondFinality != nil && *f.ReorgDepthBeyondFinality < 1 {
+		return fmt.Errorf("FinalityViolation.ReorgDepthBeyondFinality must be at least 1, got %d", *f.ReorgDepthBeyondFinality)
+	}
+	if f.TriggerAfterMessages != nil && *f.TriggerAfterMessages < 1 {
+		return fmt.Errorf("FinalityViolation.TriggerAfterMessages must be at least 1, got %d", *f.TriggerAfterMessages)
+	}
+	switch outcome := pointer.GetString(f.ExpectedOutcome); outcome {
+	case FINALITY_VIOLATION_CURSE:
+		if pointer.GetInt(o.RMNConfig.NoOfNodes) == 0 {
+			return fmt.Errorf("FinalityViolation.ExpectedOutcome is %s, but RMN is disabled, set RMNConfig.NoOfNodes", outcome)
+		}
+	case FINALITY_VIOLATION_HALT, FINALITY_VIOLATION_ALERT_ONLY:
+	default:
+		return fmt.Errorf("FinalityViolation.ExpectedOutcome must be one of %s, %s, %s, got %q",
+			FINALITY_VIOLATION_HALT, FINALITY_VIOLATION_CURSE, FINALITY_VIOLATION_ALERT_ONLY, outcome)
+	}
+	_, _, err := o.GetFinalityViolation()
+	return err
+}
@@ -0,0 +1,47 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const finalityViolationNetworks = `
+[PrivateEthereumNetworks.SIMULATED_1]
+ethereum_version = 'eth2'
+
+[PrivateEthereumNetworks.SIMULATED_1.EthereumChainConfig]
+chain_id = 1337
+slots_per_epoch = 4
+`
+
+func TestFinalityViolation(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(finalityViolationNetworks+`
+[FinalityViolation]
+Chain = 'SIMULATED_1'
+ReorgDepthBeyondFinality = 3
+ExpectedOutcome = 'halt'
+`), &cfg))
+	require.NoError(t, cfg.validateFinalityViolation())
+	violation, ok, err := cfg.GetFinalityViolation()
+	require.NoError(t, err)
+	require.True(t, ok)
+	// 2 epochs of 4 slots to finality, plus 3 blocks beyond it
+	require.Equal(t, FinalityViolation{ChainSelector: 3379446385462418246, ReorgDepth: 11, TriggerAfterMessages: 1, ExpectedOutcome: FINALITY_VIOLATION_HALT}, violation)
+
+	for _, tc := range []struct {
+		scenario string
+		err      string
+	}{
+		{"Chain = 'ethereum-testnet-sepolia'\nExpectedOutcome = 'halt'\n", "FinalityViolation.Chain ethereum-testnet-sepolia must be one of PrivateEthereumNetworks"},
+		{"Chain = 'SIMULATED_1'\nExpectedOutcome = 'curse'\n", "FinalityViolation.ExpectedOutcome is curse, but RMN is disabled"},
+		{"Chain = 'SIMULATED_1'\nExpectedOutcome = 'panic'\n", `FinalityViolation.ExpectedOutcome must be one of halt, curse, alertOnly, got "panic"`},
+		{"Chain = 'SIMULATED_1'\nExpectedOutcome = 'halt'\nReorgDepthBeyondFinality = 0\n", "FinalityViolation.ReorgDepthBeyondFinality must be at least 1"},
+	} {
+		var cfg Config
+		require.NoError(t, toml.Unmarshal([]byte(finalityViolationNetworks+"[FinalityViolation]\n"+tc.scenario), &cfg))
+		require.ErrorContains(t, cfg.validateFinalityViolation(), tc.err)
+	}
+}
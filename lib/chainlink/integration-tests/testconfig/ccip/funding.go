@@ -0,0 +1,193 @@
+package ccip
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	FUNDING_ACCOUNT_NODES    = "nodes"
+	FUNDING_ACCOUNT_SENDERS  = "senders"
+	FUNDING_ACCOUNT_DEPLOYER = "deployer"
+)
+
+// FundingProfile holds the amounts an account is funded with on each chain, keyed by chain name or
+// selector. Amounts are given like SenderConfig.FundEachWith, LINK amounts in LINK.
+type FundingProfile struct {
+	Native map[string]string `toml:",omitempty"`
+	LINK   map[string]string `toml:",omitempty"`
+}
+
+// Funding funds an account with the amounts of the FundingProfiles entry named by Profile, with its
+// own amounts applied on top.
+type Funding struct {
+	Profile *string `toml:",omitempty"`
+	FundingProfile
+}
+
+// ChainFunding is what an account is funded with on a chain, amounts in wei and juels. An amount that
+// isn't configured is nil.
+type ChainFunding struct {
+	Native *big.Int
+	LINK   *big.Int
+}
+
+// GetFunding resolves the funding configured at field per chain selector, the profile first and the
+// funding's own amounts on top. It returns nil without funding.
+func (o *Config) GetFunding(field string, funding *Funding) (map[uint64]ChainFunding, error) {
+	if funding == nil {
+		return nil, nil
+	}
+	resolved := make(map[uint64]ChainFunding)
+	if name := pointer.GetString(funding.Profile); name != "" {
+		profile, ok := o.FundingProfiles[name]
+		if !ok || profile == nil {
+			return nil, fieldError(field+".Profile", fmt.Errorf("funding profile %q is not defined in FundingProfiles", name))
+		}
+		if err := o.applyFundingProfile("FundingProfiles."+name, profile, resolved); err != nil {
+			return nil, err
+		}
+	}
+	if err := o.applyFundingProfile(field, &funding.FundingProfile, resolved); err != nil {
+		return nil, err
+	}
+	return resolved, nil
+}
+
+func (o *Config) applyFundingProfile(field string, profile *FundingProfile, resolved map[uint64]ChainFunding) error {
+	if err := o.applyFundingAmounts(field+".Native", profile.Native, resolved, func(f *ChainFunding, amount *big.Int) { f.Native = amount }); err != nil {
+		return err
+	}
+	return o.applyFundingAmounts(field+".LINK", profile.LINK, resolved, func(f *ChainFunding, amount *big.Int) { f.LINK = amount })
+}
+
+func (o *Config) applyFundingAmounts(field string, amounts map[string]string, resolved map[uint64]ChainFunding, set func(*ChainFunding, *big.Int)) error {
+	refs := make([]string, 0, len(amounts))
+	for ref := range amounts {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	for _, ref := range refs {
+		selector, err := o.ResolveChainSelector(ref)
+		if err != nil {
+			return fieldError(field+"."+ref, err)
+		}
+		amount, err := parseNativeAmount(amounts[ref])
+		if err != nil {
+			return fieldError(field+"."+ref, err)
+		}
+		funding := resolved[selector]
+		set(&funding, amount)
+		resolved[selector] = funding
+	}
+	return nil
+}
+
+// fundingByAccount resolves the funding of every account type that has one. Deployers are funded on
+// their own chain only.
+func (o *Config) fundingByAccount() (map[string]map[uint64]ChainFunding, error) {
+	byAccount := make(map[string]map[uint64]ChainFunding)
+	if o.CLNode != nil && o.CLNode.FundingAmounts != nil {
+		funding, err := o.GetFunding("CLNode.FundingAmounts", o.CLNode.FundingAmounts)
+		if err != nil {
+			return nil, err
+		}
+		byAccount[FUNDING_ACCOUNT_NODES] = funding
+	}
+	if o.SenderConfig != nil && o.SenderConfig.Funding != nil {
+		plan, err := o.GetSenderPlan()
+		if err != nil {
+			return nil, err
+		}
+		byAccount[FUNDING_ACCOUNT_SENDERS] = plan.Funding
+	}
+	refs := make([]string, 0, len(o.DeployerConfig))
+	for ref, deployer := range o.DeployerConfig {
+		if deployer != nil && deployer.Funding != nil {
+			refs = append(refs, ref)
+		}
+	}
+	sort.Strings(refs)
+	for _, ref := range refs {
+		field := "DeployerConfig." + ref + ".Funding"
+		funding, err := o.GetFunding(field, o.DeployerConfig[ref].Funding)
+		if err != nil {
+			return nil, err
+		}
+		selector, err := o.ResolveChainSelector(ref)
+		if err != nil {
+			return nil, fieldError("DeployerConfig."+ref, err)
+		}
+		if _, ok := byAccount[FUNDING_ACCOUNT_DEPLOYER]; !ok {
+			byAccount[FUNDING_ACCOUNT_DEPLOYER] = make(map[uint64]ChainFunding)
+		}
+		if chainFunding, ok := funding[selector]; ok {
+			byAccount[FUNDING_ACCOUNT_DEPLOYER][selector] = chainFunding
+		}
+	}
+	return byAccount, nil
+}
+
+// planFunding returns the funding table of the plan, by account type and then by chain selector. Chains
+// are named like the plan's chains.
+func (o *Config) planFunding(chains []PlanChain) ([]PlanFunding, error) {
+	byAccount, err := o.fundingByAccount()
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[uint64]string, len(chains))
+	for _, chain := range chains {
+		names[chain.Selector] = chain.Name
+	}
+	var table []PlanFunding
+	for _, account := range []string{FUNDING_ACCOUNT_NODES, FUNDING_ACCOUNT_SENDERS, FUNDING_ACCOUNT_DEPLOYER} {
+		funding := byAccount[account]
+		selectors := make([]uint64, 0, len(funding))
+		for selector := range funding {
+			selectors = append(selectors, selector)
+		}
+		sort.Slice(selectors, func(i, j int) bool { return selectors[i] < selectors[j] })
+		for _, selector := range selectors {
+			name, ok := names[selector]
+			if !ok {
+				name = chainName(selector)
+			}
+			table = append(table, PlanFunding{
+				Account:  account,
+				Chain:    name,
+				Selector: selector,
+				Native:   formatFundingAmount(funding[selector].Native),
+				LINK:     formatFundingAmount(funding[selector].LINK),
+			})
+		}
+	}
+	return table, nil
+}
+
+func formatFundingAmount(amount *big.Int) string {
+	if amount == nil {
+		return "-"
+	}
+	return formatAmount(amount)
+}
+
+func (o *Config) validateFunding() error {
+	names := make([]string, 0, len(o.FundingProfiles))
+	for name := range o.FundingProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if o.FundingProfiles[name] == nil {
+			continue
+		}
+		if err := o.applyFundingProfile("FundingProfiles."+name, o.FundingProfiles[name], make(map[uint64]ChainFunding)); err != nil {
+			return err
+		}
+	}
+	_, err := o.fundingByAccount()
+	return err
+}
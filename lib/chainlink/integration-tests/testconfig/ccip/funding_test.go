@@ -0,0 +1,99 @@
+package ccip
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const fundingConfig = `
+[FundingProfiles.standard.Native]
+geth-testnet = '10'
+geth-devnet-2 = '5'
+
+[FundingProfiles.standard.LINK]
+geth-testnet = '100'
+
+[CLNode.FundingAmounts]
+Profile = 'standard'
+
+[CLNode.FundingAmounts.Native]
+geth-devnet-2 = '20'
+
+[SenderConfig.Funding]
+Profile = 'standard'
+
+[DeployerConfig.geth-testnet]
+LedgerDerivationPath = "m/44'/60'/0'/0/0"
+
+[DeployerConfig.geth-testnet.Funding]
+Profile = 'standard'
+`
+
+func TestGetFunding(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(fundingConfig), &cfg))
+	require.NoError(t, cfg.validateFunding())
+
+	funding, err := cfg.GetFunding("CLNode.FundingAmounts", cfg.CLNode.FundingAmounts)
+	require.NoError(t, err)
+	require.Equal(t, map[uint64]ChainFunding{
+		3379446385462418246:  {Native: new(big.Int).Mul(big.NewInt(10), ether), LINK: new(big.Int).Mul(big.NewInt(100), ether)},
+		12922642891491394802: {Native: new(big.Int).Mul(big.NewInt(20), ether)},
+	}, funding)
+
+	// FundEachWith overrides the native amounts of the sender funding
+	cfg.SenderConfig.FundEachWith = pointer.ToString("1")
+	plan, err := cfg.GetSenderPlan()
+	require.NoError(t, err)
+	require.False(t, plan.UseGenesisAccounts)
+	require.Equal(t, ether, plan.Funding[12922642891491394802].Native)
+	require.Equal(t, new(big.Int).Mul(big.NewInt(100), ether), plan.Funding[3379446385462418246].LINK)
+
+	cfg.SenderConfig.FundEachWith = nil
+	cfg.SenderConfig.RebalanceBelow = pointer.ToString("6")
+	require.EqualError(t, cfg.validateSenderConfig(),
+		"SenderConfig.RebalanceBelow 6000000000000000000 wei must be less than the 5000000000000000000 wei SenderConfig.Funding funds senders with on geth-devnet-2")
+}
+
+func TestValidateFunding(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{"undefined profile", "[CLNode.FundingAmounts]\nProfile = 'large'", `CLNode.FundingAmounts.Profile: funding profile "large" is not defined in FundingProfiles`},
+		{"bad profile amount", "[FundingProfiles.small.LINK]\ngeth-testnet = 'ten'", `FundingProfiles.small.LINK.geth-testnet: "ten" is not a decimal amount`},
+		{"bad override", "[SenderConfig.Funding.Native]\ngeth-testnet = '-1'", `SenderConfig.Funding.Native.geth-testnet: "-1" cannot be negative`},
+		{"unknown chain", "[FundingProfiles.small.Native]\nnowhere = '1'", "FundingProfiles.small.Native.nowhere: chain nowhere is neither"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte(tc.config), &cfg))
+			require.ErrorContains(t, cfg.validateFunding(), tc.err)
+		})
+	}
+}
+
+func TestPlanFunding(t *testing.T) {
+	for _, envVar := range []string{E2E_JD_IMAGE, E2E_JD_VERSION, E2E_JD_GRPC, E2E_JD_WSRPC,
+		E2E_RMN_RAGEPROXY_IMAGE, E2E_RMN_RAGEPROXY_VERSION, E2E_RMN_AFN2PROXY_IMAGE, E2E_RMN_AFN2PROXY_VERSION} {
+		t.Setenv(envVar, "")
+	}
+	cfg, networks := loadDefaultConfig(t)
+	require.NoError(t, toml.Unmarshal([]byte(fundingConfig), cfg))
+	plan, err := Plan(cfg, networks)
+	require.NoError(t, err)
+	require.Contains(t, plan.String(), strings.Join([]string{
+		"Funding (5):",
+		"  nodes chain-1337 native=10 LINK=100",
+		"  nodes chain-2337 native=20 LINK=-",
+		"  senders chain-1337 native=10 LINK=100",
+		"  senders chain-2337 native=5 LINK=-",
+		"  deployer chain-1337 native=10 LINK=100",
+	}, "\n"))
+}
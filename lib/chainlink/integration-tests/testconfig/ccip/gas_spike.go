@@ -0,0 +1,104 @@
+package ccip
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	GAS_SPIKE_DELAY_UNTIL_NORMAL     = "delayUntilNormal"
+	GAS_SPIKE_EXECUTE_AT_HIGHER_COST = "executeAtHigherCost"
+	DEFAULT_GAS_SPIKE_DURATION       = 5 * time.Minute
+)
+
+// GasSpikeScenario raises the gas price on a destination chain for a while to verify exec keeps working.
+type GasSpikeScenario struct {
+	// DestChain is the network name or selector of the chain to spike
+	DestChain        *string   `toml:",omitempty"`
+	SpikeMultiplier  *float64  `toml:",omitempty"`
+	SpikeDuration    *Duration `toml:",omitempty"`
+	SpikeStartOffset *Duration `toml:",omitempty"`
+	ExpectedBehavior *string   `toml:",omitempty"`
+}
+
+// GasSpike is the resolved scenario the chaos driver executes.
+type GasSpike struct {
+	DestSelector     uint64
+	Multiplier       float64
+	StartOffset      time.Duration
+	Duration         time.Duration
+	ExpectedBehavior string
+}
+
+// GetGasSpike resolves the gas spike scenario, returning false if none is configured.
+func (o *Config) GetGasSpike() (GasSpike, bool, error) {
+	g := o.GasSpikeScenario
+	if g == nil {
+		return GasSpike{}, false, nil
+	}
+	selector, err := o.ResolveChainSelector(pointer.GetString(g.DestChain))
+	if err != nil {
+		return GasSpike{}, false, fieldError("GasSpikeScenario.DestChain", err)
+	}
+	spike := GasSpike{
+		DestSelector:     selector,
+		Multiplier:       pointer.GetFloat64(g.SpikeMultiplier),
+		Duration:         DEFAULT_GAS_SPIKE_DURATION,
+		ExpectedBehavior: pointer.GetString(g.ExpectedBehavior),
+	}
+	if g.SpikeDuration != nil {
+		spike.Duration = g.SpikeDuration.Duration
+	}
+	if g.SpikeStartOffset != nil {
+		spike.StartOffset = g.SpikeStartOffset.Duration
+	}
+	return spike, true, nil
+}
+
+func (o *Config) validateGasSpike() error {
+	g := o.GasSpikeScenario
+	if g == nil {
+		return nil
+	}
+	dest := pointer.GetString(g.DestChain)
+	if dest == "" {
+		return fmt.Errorf("GasSpikeScenario.DestChain must be set")
+	}
+	// gas prices can only be manipulated on chains we run ourselves
+	if _, ok := o.PrivateEthereumNetworks[dest]; !ok {
+		return fmt.Errorf("GasSpikeScenario.DestChain %s must be one of PrivateEthereumNetworks, gas spikes can't be realized on live networks", dest)
+	}
+	spike, _, err := o.GetGasSpike()
+	if err != nil {
+		return err
+	}
+	if spike.Multiplier <= 1 {
+		return fmt.Errorf("GasSpikeScenario.SpikeMultiplier must be greater than 1, got %f", spike.Multiplier)
+	}
+	if spike.Duration <= 0 || spike.StartOffset < 0 {
+		return fmt.Errorf("GasSpikeScenario.SpikeDuration must be positive and SpikeStartOffset cannot be negative")
+	}
+	if spike.StartOffset+spike.Duration > o.Timeouts.GetOverallTestTimeout() {
+		return fmt.Errorf("GasSpikeScenario ends after %s, which exceeds Timeouts.OverallTestTimeout (%s)",
+			spike.StartOffset+spike.Duration, o.Timeouts.GetOverallTestTimeout())
+	}
+	maxMultiplier, capped := o.ExecConfig.GetMaxGasPriceMultiplier()
+	switch spike.ExpectedBehavior {
+	case GAS_SPIKE_DELAY_UNTIL_NORMAL:
+		if !capped || maxMultiplier >= spike.Multiplier {
+			return fmt.Errorf("GasSpikeScenario.ExpectedBehavior is %s, but ExecConfig.MaxGasPriceMultiplier doesn't cap execution below the %.2fx spike",
+				GAS_SPIKE_DELAY_UNTIL_NORMAL, spike.Multiplier)
+		}
+	case GAS_SPIKE_EXECUTE_AT_HIGHER_COST:
+		if capped && maxMultiplier < spike.Multiplier {
+			return fmt.Errorf("GasSpikeScenario.ExpectedBehavior is %s, but ExecConfig.MaxGasPriceMultiplier (%.2f) is below the %.2fx spike",
+				GAS_SPIKE_EXECUTE_AT_HIGHER_COST, maxMultiplier, spike.Multiplier)
+		}
+	default:
+		return fmt.Errorf("GasSpikeScenario.ExpectedBehavior must be one of %s, %s, got %q",
+			GAS_SPIKE_DELAY_UNTIL_NORMAL, GAS_SPIKE_EXECUTE_AT_HIGHER_COST, spike.ExpectedBehavior)
+	}
+	return nil
+}
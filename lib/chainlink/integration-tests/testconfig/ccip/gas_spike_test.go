@@ -0,0 +1,94 @@
+package ccip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetGasSpike(t *testing.T) {
+	_, ok, err := (&Config{}).GetGasSpike()
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(`
+[GasSpikeScenario]
+DestChain = 'SIMULATED_2'
+SpikeMultiplier = 3.0
+ExpectedBehavior = 'executeAtHigherCost'
+`+orderingNetworks), &cfg))
+	spike, ok, err := cfg.GetGasSpike()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, GasSpike{
+		DestSelector:     12922642891491394802,
+		Multiplier:       3,
+		Duration:         DEFAULT_GAS_SPIKE_DURATION,
+		ExpectedBehavior: GAS_SPIKE_EXECUTE_AT_HIGHER_COST,
+	}, spike)
+}
+
+func TestValidateGasSpike(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "not set"},
+		{name: "execute at higher cost", content: "DestChain = 'SIMULATED_2'\nSpikeMultiplier = 3.0\nSpikeStartOffset = '1m'\nSpikeDuration = '2m'\nExpectedBehavior = 'executeAtHigherCost'"},
+		{name: "execute below the cap", content: "DestChain = 'SIMULATED_2'\nSpikeMultiplier = 3.0\nExpectedBehavior = 'executeAtHigherCost'\n[ExecConfig]\nMaxGasPriceMultiplier = 3.0"},
+		{name: "delay until normal", content: "DestChain = 'SIMULATED_2'\nSpikeMultiplier = 3.0\nExpectedBehavior = 'delayUntilNormal'\n[ExecConfig]\nMaxGasPriceMultiplier = 2.0"},
+		{name: "no dest chain", content: "SpikeMultiplier = 3.0", err: "GasSpikeScenario.DestChain must be set"},
+		{name: "live dest chain", content: "DestChain = 'ethereum-mainnet'", err: "GasSpikeScenario.DestChain ethereum-mainnet must be one of PrivateEthereumNetworks, gas spikes can't be realized on live networks"},
+		{name: "private network without chain id", content: "DestChain = 'SIMULATED_3'\n[PrivateEthereumNetworks.SIMULATED_3]", err: "GasSpikeScenario.DestChain: chain SIMULATED_3: private network has no chain id configured"},
+		{name: "multiplier not set", content: "DestChain = 'SIMULATED_2'", err: "GasSpikeScenario.SpikeMultiplier must be greater than 1, got 0.000000"},
+		{name: "multiplier of 1", content: "DestChain = 'SIMULATED_2'\nSpikeMultiplier = 1.0", err: "GasSpikeScenario.SpikeMultiplier must be greater than 1, got 1.000000"},
+		{name: "zero duration", content: "DestChain = 'SIMULATED_2'\nSpikeMultiplier = 3.0\nSpikeDuration = '0s'", err: "GasSpikeScenario.SpikeDuration must be positive and SpikeStartOffset cannot be negative"},
+		{name: "ends after the test timeout", content: "DestChain = 'SIMULATED_2'\nSpikeMultiplier = 3.0\nSpikeStartOffset = '28m'", err: "GasSpikeScenario ends after 33m0s, which exceeds Timeouts.OverallTestTimeout (30m0s)"},
+		{
+			name:    "delay until normal without a cap",
+			content: "DestChain = 'SIMULATED_2'\nSpikeMultiplier = 3.0\nExpectedBehavior = 'delayUntilNormal'",
+			err:     "GasSpikeScenario.ExpectedBehavior is delayUntilNormal, but ExecConfig.MaxGasPriceMultiplier doesn't cap execution below the 3.00x spike",
+		},
+		{
+			name:    "delay until normal with the cap at the spike",
+			content: "DestChain = 'SIMULATED_2'\nSpikeMultiplier = 3.0\nExpectedBehavior = 'delayUntilNormal'\n[ExecConfig]\nMaxGasPriceMultiplier = 3.0",
+			err:     "GasSpikeScenario.ExpectedBehavior is delayUntilNormal, but ExecConfig.MaxGasPriceMultiplier doesn't cap execution below the 3.00x spike",
+		},
+		{
+			name:    "execute above the cap",
+			content: "DestChain = 'SIMULATED_2'\nSpikeMultiplier = 3.0\nExpectedBehavior = 'executeAtHigherCost'\n[ExecConfig]\nMaxGasPriceMultiplier = 2.0",
+			err:     "GasSpikeScenario.ExpectedBehavior is executeAtHigherCost, but ExecConfig.MaxGasPriceMultiplier (2.00) is below the 3.00x spike",
+		},
+		{name: "no expected behavior", content: "DestChain = 'SIMULATED_2'\nSpikeMultiplier = 3.0", err: `GasSpikeScenario.ExpectedBehavior must be one of delayUntilNormal, executeAtHigherCost, got ""`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			content := orderingNetworks
+			if tc.content != "" {
+				content = "[GasSpikeScenario]\n" + tc.content + "\n" + orderingNetworks
+			}
+			require.NoError(t, toml.Unmarshal([]byte(content), &cfg))
+			err := cfg.validateGasSpike()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+
+	// decoding refuses negative durations already, configs built in code still go through validation
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(orderingNetworks), &cfg))
+	cfg.GasSpikeScenario = &GasSpikeScenario{
+		DestChain:        pointer.ToString("SIMULATED_2"),
+		SpikeMultiplier:  pointer.ToFloat64(3),
+		SpikeStartOffset: &Duration{-time.Second},
+	}
+	require.EqualError(t, cfg.validateGasSpike(), "GasSpikeScenario.SpikeDuration must be positive and SpikeStartOffset cannot be negative")
+}
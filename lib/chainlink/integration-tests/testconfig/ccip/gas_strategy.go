@@ -0,0 +1,142 @@
+package ccip
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/AlekSi/pointer"
+	chainselectors "github.com/smartcontractkit/chain-selectors"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/blockchain"
+)
+
+const (
+	GAS_MODE_ESTIMATE = "estimate"
+	GAS_MODE_FIXED    = "fixed"
+	GAS_MODE_ORACLE   = "oracle"
+
+	DEFAULT_GAS_MODE                = GAS_MODE_ESTIMATE
+	DEFAULT_GAS_ESTIMATE_MULTIPLIER = 1.0
+)
+
+// GasStrategy configures how the harness prices its own deployment and message-sending transactions on a chain.
+type GasStrategy struct {
+	Mode               *string  `toml:",omitempty"`
+	FixedGasPriceGwei  *float64 `toml:",omitempty"`
+	FeeCapGwei         *float64 `toml:",omitempty"`
+	TipCapGwei         *float64 `toml:",omitempty"`
+	EstimateMultiplier *float64 `toml:",omitempty"`
+}
+
+// ResolvedGasStrategy is the gas strategy of a single chain with every value set. The caps are 0 when not set.
+type ResolvedGasStrategy struct {
+	Mode               string
+	FixedGasPriceGwei  float64
+	FeeCapGwei         float64
+	TipCapGwei         float64
+	EstimateMultiplier float64
+}
+
+// GetGasStrategy returns the gas strategy of the chain, estimate-and-bump unless configured otherwise.
+func (o *Config) GetGasStrategy(selector uint64) ResolvedGasStrategy {
+	resolved := ResolvedGasStrategy{
+		Mode:               DEFAULT_GAS_MODE,
+		EstimateMultiplier: DEFAULT_GAS_ESTIMATE_MULTIPLIER,
+	}
+	strategy, _, ok := o.getGasStrategy(selector)
+	if !ok {
+		return resolved
+	}
+	if mode := pointer.GetString(strategy.Mode); mode != "" {
+		resolved.Mode = mode
+	}
+	if strategy.EstimateMultiplier != nil {
+		resolved.EstimateMultiplier = *strategy.EstimateMultiplier
+	}
+	resolved.FixedGasPriceGwei = pointer.GetFloat64(strategy.FixedGasPriceGwei)
+	resolved.FeeCapGwei = pointer.GetFloat64(strategy.FeeCapGwei)
+	resolved.TipCapGwei = pointer.GetFloat64(strategy.TipCapGwei)
+	return resolved
+}
+
+func (o *Config) getGasStrategy(selector uint64) (*GasStrategy, string, bool) {
+	for ref, strategy := range o.GasStrategy {
+		if strategy == nil {
+			continue
+		}
+		if resolved, err := o.ResolveChainSelector(ref); err == nil && resolved == selector {
+			return strategy, ref, true
+		}
+	}
+	return nil, "", false
+}
+
+func (g *GasStrategy) Validate(field string) error {
+	mode := pointer.GetString(g.Mode)
+	if mode == "" {
+		mode = DEFAULT_GAS_MODE
+	}
+	switch mode {
+	case GAS_MODE_ESTIMATE, GAS_MODE_FIXED, GAS_MODE_ORACLE:
+	default:
+		return fieldError(field+".Mode", fmt.Errorf("unknown value %q", mode))
+	}
+	if (mode == GAS_MODE_FIXED) != (g.FixedGasPriceGwei != nil) {
+		return fmt.Errorf("%s.FixedGasPriceGwei must be set if and only if Mode is %s", field, GAS_MODE_FIXED)
+	}
+	if g.FixedGasPriceGwei != nil && *g.FixedGasPriceGwei <= 0 {
+		return fmt.Errorf("%s.FixedGasPriceGwei must be positive", field)
+	}
+	if g.EstimateMultiplier != nil && *g.EstimateMultiplier < 1 {
+		return fmt.Errorf("%s.EstimateMultiplier must be at least 1, got %f", field, *g.EstimateMultiplier)
+	}
+	if g.FeeCapGwei != nil && *g.FeeCapGwei <= 0 {
+		return fmt.Errorf("%s.FeeCapGwei must be positive", field)
+	}
+	if g.TipCapGwei != nil && *g.TipCapGwei < 0 {
+		return fmt.Errorf("%s.TipCapGwei cannot be negative", field)
+	}
+	if g.FeeCapGwei != nil && g.TipCapGwei != nil && *g.TipCapGwei > *g.FeeCapGwei {
+		return fmt.Errorf("%s.TipCapGwei %f cannot exceed FeeCapGwei %f", field, *g.TipCapGwei, *g.FeeCapGwei)
+	}
+	return nil
+}
+
+func (o *Config) validateGasStrategy() error {
+	refs := make([]string, 0, len(o.GasStrategy))
+	for ref := range o.GasStrategy {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	for _, ref := range refs {
+		if o.GasStrategy[ref] == nil {
+			continue
+		}
+		if _, err := o.ResolveChainSelector(ref); err != nil {
+			return fieldError("GasStrategy."+ref, err)
+		}
+		if err := o.GasStrategy[ref].Validate("GasStrategy." + ref); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateGasStrategies cross-checks the gas strategies with the selected networks' fee model:
+// fee and tip caps only exist on chains supporting EIP-1559.
+func (o *Config) ValidateGasStrategies(evmNetworks []blockchain.EVMNetwork) error {
+	for _, network := range evmNetworks {
+		if network.ChainID <= 0 || network.SupportsEIP1559 {
+			continue
+		}
+		selector, err := chainselectors.SelectorFromChainId(uint64(network.ChainID))
+		if err != nil {
+			return fmt.Errorf("network %s: %w", network.Name, err)
+		}
+		strategy, ref, ok := o.getGasStrategy(selector)
+		if ok && (strategy.FeeCapGwei != nil || strategy.TipCapGwei != nil) {
+			return fmt.Errorf("GasStrategy.%s sets FeeCapGwei or TipCapGwei, but network %s doesn't support EIP-1559", ref, network.Name)
+		}
+	}
+	return nil
+}
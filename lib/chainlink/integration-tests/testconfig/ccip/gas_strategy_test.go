@@ -0,0 +1,52 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/blockchain"
+)
+
+func TestGasStrategy(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(`
+[GasStrategy.ethereum-testnet-sepolia-arbitrum-1]
+Mode = 'fixed'
+FixedGasPriceGwei = 0.1
+EstimateMultiplier = 1.5
+
+[GasStrategy.ethereum-testnet-sepolia]
+FeeCapGwei = 200.0
+TipCapGwei = 2.0
+`), &cfg))
+	require.NoError(t, cfg.validateGasStrategy())
+
+	arbitrum, err := cfg.ResolveChainSelector("ethereum-testnet-sepolia-arbitrum-1")
+	require.NoError(t, err)
+	require.Equal(t, ResolvedGasStrategy{Mode: GAS_MODE_FIXED, FixedGasPriceGwei: 0.1, EstimateMultiplier: 1.5}, cfg.GetGasStrategy(arbitrum))
+	require.Equal(t, ResolvedGasStrategy{Mode: GAS_MODE_ESTIMATE, EstimateMultiplier: 1}, cfg.GetGasStrategy(3379446385462418246))
+
+	// sepolia without EIP-1559 support can't honour the caps
+	networks := []blockchain.EVMNetwork{{Name: "sepolia", ChainID: 11155111}}
+	require.ErrorContains(t, cfg.ValidateGasStrategies(networks), "network sepolia doesn't support EIP-1559")
+	networks[0].SupportsEIP1559 = true
+	require.NoError(t, cfg.ValidateGasStrategies(networks))
+}
+
+func TestValidateGasStrategy(t *testing.T) {
+	for _, tc := range []struct {
+		strategy string
+		err      string
+	}{
+		{"Mode = 'fixed'\n", "FixedGasPriceGwei must be set if and only if Mode is fixed"},
+		{"Mode = 'oracle'\nFixedGasPriceGwei = 1.0\n", "FixedGasPriceGwei must be set if and only if Mode is fixed"},
+		{"EstimateMultiplier = 0.9\n", "EstimateMultiplier must be at least 1"},
+		{"FeeCapGwei = 1.0\nTipCapGwei = 2.0\n", "TipCapGwei 2.000000 cannot exceed FeeCapGwei 1.000000"},
+	} {
+		var cfg Config
+		require.NoError(t, toml.Unmarshal([]byte("[GasStrategy.SIMULATED_1]\n"+tc.strategy+"[PrivateEthereumNetworks.SIMULATED_1.EthereumChainConfig]\nchain_id = 1337\n"), &cfg))
+		require.ErrorContains(t, cfg.validateGasStrategy(), tc.err)
+	}
+}
@@ -0,0 +1,276 @@
+package ccip
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	HEALTH_COMPONENT_NODE  = "node"
+	HEALTH_COMPONENT_JD    = "jd"
+	HEALTH_COMPONENT_RMN   = "rmn"
+	HEALTH_COMPONENT_CHAIN = "chain"
+)
+
+// healthComponents is the order components are checked and reported in.
+var healthComponents = []string{HEALTH_COMPONENT_NODE, HEALTH_COMPONENT_JD, HEALTH_COMPONENT_RMN, HEALTH_COMPONENT_CHAIN}
+
+// defaultHealthChecks match the waits bring-up used before they were configurable: nodes polled /readyz
+// every second for 3m, JD was retried for about a minute, RMN containers used the testcontainers default
+// of 100ms for 60s and geth polled its logs every second for 2m.
+var defaultHealthChecks = map[string]ResolvedHealthCheck{
+	HEALTH_COMPONENT_NODE:  {Interval: time.Second, Timeout: 3 * time.Minute, SuccessThreshold: 1},
+	HEALTH_COMPONENT_JD:    {Interval: time.Second, Timeout: time.Minute, SuccessThreshold: 1},
+	HEALTH_COMPONENT_RMN:   {Interval: 100 * time.Millisecond, Timeout: time.Minute, SuccessThreshold: 1},
+	HEALTH_COMPONENT_CHAIN: {Interval: time.Second, Timeout: 2 * time.Minute, SuccessThreshold: 1},
+}
+
+// HealthCheckSettings configures how a component is polled until it's ready. SuccessThreshold is the
+// number of consecutive successful checks needed, a failed check starts the count over.
+type HealthCheckSettings struct {
+	Interval         *Duration `toml:",omitempty"`
+	Timeout          *Duration `toml:",omitempty"`
+	SuccessThreshold *int      `toml:",omitempty"`
+}
+
+// HealthChecks holds the health check settings of each component, applied over its defaults.
+type HealthChecks struct {
+	Node  *HealthCheckSettings `toml:",omitempty"`
+	JD    *HealthCheckSettings `toml:",omitempty"`
+	RMN   *HealthCheckSettings `toml:",omitempty"`
+	Chain *HealthCheckSettings `toml:",omitempty"`
+}
+
+// ResolvedHealthCheck is the health check of a single component, with every value set.
+type ResolvedHealthCheck struct {
+	Interval         time.Duration
+	Timeout          time.Duration
+	SuccessThreshold int
+}
+
+func (h *HealthChecks) settings(component string) *HealthCheckSettings {
+	if h == nil {
+		return nil
+	}
+	switch component {
+	case HEALTH_COMPONENT_NODE:
+		return h.Node
+	case HEALTH_COMPONENT_JD:
+		return h.JD
+	case HEALTH_COMPONENT_RMN:
+		return h.RMN
+	case HEALTH_COMPONENT_CHAIN:
+		return h.Chain
+	}
+	return nil
+}
+
+// GetHealthCheck returns the health check of the component, one of the HEALTH_COMPONENT_* constants.
+func (o *Config) GetHealthCheck(component string) ResolvedHealthCheck {
+	resolved := defaultHealthChecks[component]
+	s := o.HealthChecks.settings(component)
+	if s == nil {
+		return resolved
+	}
+	if s.Interval != nil {
+		resolved.Interval = s.Interval.Duration
+	}
+	if s.Timeout != nil {
+		resolved.Timeout = s.Timeout.Duration
+	}
+	if s.SuccessThreshold != nil {
+		resolved.SuccessThreshold = *s.SuccessThreshold
+	}
+	return resolved
+}
+
+func (o *Config) validateHealthChecks() error {
+	if o.HealthChecks == nil {
+		return nil
+	}
+	for _, component := range healthComponents {
+		check := o.GetHealthCheck(component)
+		if check.Interval <= 0 {
+			return fmt.Errorf("HealthChecks %s: Interval must be positive", component)
+		}
+		if check.Timeout < check.Interval {
+			return fmt.Errorf("HealthChecks %s: Timeout %s must not be shorter than Interval %s", component, check.Timeout, check.Interval)
+		}
+		if check.SuccessThreshold < 1 {
+			return fmt.Errorf("HealthChecks %s: SuccessThreshold must be at least 1, got %d", component, check.SuccessThreshold)
+		}
+	}
+	return nil
+}
+
+// ReadinessEndpoints are the resolved endpoints the readiness gate waits for.
+type ReadinessEndpoints struct {
+	// NodeAPIs are node base URLs, ready once GET /readyz succeeds
+	NodeAPIs []string
+	// JDGRPC is the JD gRPC host:port, ready once it accepts connections
+	JDGRPC string
+	// RMNProxies are RMN proxy host:ports, ready once they accept connections
+	RMNProxies []string
+	// ChainRPCs are chain HTTP RPC URLs, ready once they answer eth_blockNumber
+	ChainRPCs []string
+}
+
+// ComponentStatus is the outcome of waiting for a single endpoint.
+type ComponentStatus struct {
+	Component string
+	Endpoint  string
+	Ready     bool
+	Attempts  int
+	LastError string
+}
+
+// ReadinessError is returned by ReadinessGate when any endpoint didn't become ready in time.
+type ReadinessError struct {
+	Statuses []ComponentStatus
+}
+
+func (e *ReadinessError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("environment is not ready:")
+	for _, status := range e.Statuses {
+		if status.Ready {
+			fmt.Fprintf(&sb, "\n  %s %s: ready", status.Component, status.Endpoint)
+			continue
+		}
+		fmt.Fprintf(&sb, "\n  %s %s: not ready after %d attempts, last error: %s", status.Component, status.Endpoint, status.Attempts, status.LastError)
+	}
+	return sb.String()
+}
+
+type readinessTarget struct {
+	component string
+	endpoint  string
+	check     func(ctx context.Context, endpoint string) error
+}
+
+// ReadinessGate blocks until every endpoint passes its component's health check, or returns a
+// *ReadinessError with the status of every endpoint once any of them times out.
+func (o *Config) ReadinessGate(ctx context.Context, endpoints ReadinessEndpoints) error {
+	var targets []readinessTarget
+	for _, url := range endpoints.NodeAPIs {
+		targets = append(targets, readinessTarget{HEALTH_COMPONENT_NODE, url, checkNodeReady})
+	}
+	if endpoints.JDGRPC != "" {
+		targets = append(targets, readinessTarget{HEALTH_COMPONENT_JD, endpoints.JDGRPC, checkDial})
+	}
+	for _, addr := range endpoints.RMNProxies {
+		targets = append(targets, readinessTarget{HEALTH_COMPONENT_RMN, addr, checkDial})
+	}
+	for _, url := range endpoints.ChainRPCs {
+		targets = append(targets, readinessTarget{HEALTH_COMPONENT_CHAIN, url, checkChainRPC})
+	}
+	statuses := make([]ComponentStatus, len(targets))
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statuses[i] = waitReady(ctx, o.GetHealthCheck(target.component), target)
+		}()
+	}
+	wg.Wait()
+	for _, status := range statuses {
+		if !status.Ready {
+			return &ReadinessError{Statuses: statuses}
+		}
+	}
+	return nil
+}
+
+func waitReady(ctx context.Context, hc ResolvedHealthCheck, target readinessTarget) ComponentStatus {
+	status := ComponentStatus{Component: target.component, Endpoint: target.endpoint}
+	ctx, cancel := context.WithTimeout(ctx, hc.Timeout)
+	defer cancel()
+	ticker := time.NewTicker(hc.Interval)
+	defer ticker.Stop()
+	successes := 0
+	for {
+		status.Attempts++
+		attemptCtx, cancelAttempt := context.WithTimeout(ctx, hc.Interval)
+		err := target.check(attemptCtx, target.endpoint)
+		cancelAttempt()
+		if err == nil {
+			successes++
+			if successes >= hc.SuccessThreshold {
+				status.Ready = true
+				return status
+			}
+		} else {
+			successes = 0
+			status.LastError = err.Error()
+		}
+		select {
+		case <-ctx.Done():
+			if status.LastError == "" {
+				status.LastError = fmt.Sprintf("%d of %d consecutive checks passed", successes, hc.SuccessThreshold)
+			}
+			return status
+		case <-ticker.C:
+		}
+	}
+}
+
+func checkNodeReady(ctx context.Context, baseURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(baseURL, "/")+"/readyz", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET /readyz returned %s", resp.Status)
+	}
+	return nil
+}
+
+func checkDial(ctx context.Context, addr string) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func checkChainRPC(ctx context.Context, url string) error {
+	body := []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber","params":[]}`)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("eth_blockNumber returned %s", resp.Status)
+	}
+	var result struct {
+		Result string          `json:"result"`
+		Error  json.RawMessage `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("eth_blockNumber: %w", err)
+	}
+	if len(result.Error) > 0 || result.Result == "" {
+		return fmt.Errorf("eth_blockNumber failed: %s", result.Error)
+	}
+	return nil
+}
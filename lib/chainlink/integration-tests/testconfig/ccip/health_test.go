@@ -0,0 +1,92 @@
+package ccip
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetHealthCheck(t *testing.T) {
+	var cfg Config
+	require.Equal(t, defaultHealthChecks[HEALTH_COMPONENT_NODE], cfg.GetHealthCheck(HEALTH_COMPONENT_NODE))
+
+	require.NoError(t, toml.Unmarshal([]byte(`
+[HealthChecks.Node]
+Interval = '2s'
+SuccessThreshold = 3
+`), &cfg))
+	require.NoError(t, cfg.validateHealthChecks())
+	require.Equal(t, ResolvedHealthCheck{Interval: 2 * time.Second, Timeout: 3 * time.Minute, SuccessThreshold: 3}, cfg.GetHealthCheck(HEALTH_COMPONENT_NODE))
+	require.Equal(t, defaultHealthChecks[HEALTH_COMPONENT_RMN], cfg.GetHealthCheck(HEALTH_COMPONENT_RMN))
+
+	for _, tc := range []struct {
+		settings string
+		err      string
+	}{
+		{"[HealthChecks.JD]\nInterval = '0s'\n", "HealthChecks jd: Interval must be positive"},
+		{"[HealthChecks.Chain]\nInterval = '10s'\nTimeout = '5s'\n", "HealthChecks chain: Timeout 5s must not be shorter than Interval 10s"},
+		{"[HealthChecks.RMN]\nSuccessThreshold = 0\n", "HealthChecks rmn: SuccessThreshold must be at least 1, got 0"},
+	} {
+		var cfg Config
+		require.NoError(t, toml.Unmarshal([]byte(tc.settings), &cfg))
+		require.EqualError(t, cfg.validateHealthChecks(), tc.err)
+	}
+}
+
+func TestReadinessGate(t *testing.T) {
+	var nodeChecks atomic.Int32
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the node only becomes ready on the second check
+		if r.URL.Path != "/readyz" || nodeChecks.Add(1) < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer node.Close()
+	chain := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x10"}`))
+	}))
+	defer chain.Close()
+	jd, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer jd.Close()
+
+	fast := &HealthCheckSettings{Interval: &Duration{10 * time.Millisecond}, Timeout: &Duration{time.Second}}
+	cfg := Config{HealthChecks: &HealthChecks{Node: fast, JD: fast, RMN: fast, Chain: fast}}
+	endpoints := ReadinessEndpoints{
+		NodeAPIs:  []string{node.URL},
+		JDGRPC:    jd.Addr().String(),
+		ChainRPCs: []string{chain.URL},
+	}
+	require.NoError(t, cfg.ReadinessGate(context.Background(), endpoints))
+	require.EqualValues(t, 2, nodeChecks.Load())
+
+	// an RMN proxy nobody listens on times out and is reported alongside the ready components
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	rmn := closed.Addr().String()
+	require.NoError(t, closed.Close())
+	cfg.HealthChecks.RMN = &HealthCheckSettings{Interval: &Duration{10 * time.Millisecond}, Timeout: &Duration{50 * time.Millisecond}}
+	endpoints.RMNProxies = []string{rmn}
+	err = cfg.ReadinessGate(context.Background(), endpoints)
+	var readinessErr *ReadinessError
+	require.True(t, errors.As(err, &readinessErr))
+	require.Len(t, readinessErr.Statuses, 4)
+	require.True(t, readinessErr.Statuses[0].Ready)
+	require.True(t, readinessErr.Statuses[1].Ready)
+	rmnStatus := readinessErr.Statuses[2]
+	require.Equal(t, HEALTH_COMPONENT_RMN, rmnStatus.Component)
+	require.False(t, rmnStatus.Ready)
+	require.Greater(t, rmnStatus.Attempts, 1)
+	require.Contains(t, rmnStatus.LastError, "dial tcp "+rmn)
+	require.Contains(t, err.Error(), "rmn "+rmn+": not ready after")
+}
@@ -0,0 +1,188 @@
+package ccip
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	DEFAULT_HEARTBEAT_INTERVAL           = 5 * time.Minute
+	DEFAULT_HEARTBEAT_ALERT_AFTER_MISSED = 3
+	// HEARTBEAT_GAS is the gas of a plain self-transfer
+	HEARTBEAT_GAS uint64 = 21_000
+)
+
+// Heartbeat sends a tiny self-transfer from a sender account on each chain every Interval, so a dead
+// chain or RPC can be told apart from a stuck CCIP during long runs.
+type Heartbeat struct {
+	Enabled  *bool     `toml:",omitempty"`
+	Interval *Duration `toml:",omitempty"`
+	// Chains are the network names or selectors to send heartbeats on, every chain of the test if empty
+	Chains []string `toml:",omitempty"`
+	// AlertAfterMissed is the number of heartbeats in a row a chain has to miss before it's notified
+	AlertAfterMissed *int `toml:",omitempty"`
+}
+
+func (h *Heartbeat) IsEnabled() bool {
+	return h != nil && pointer.GetBool(h.Enabled)
+}
+
+func (h *Heartbeat) GetInterval() time.Duration {
+	if h == nil || h.Interval == nil {
+		return DEFAULT_HEARTBEAT_INTERVAL
+	}
+	return h.Interval.Duration
+}
+
+func (h *Heartbeat) GetAlertAfterMissed() int {
+	if h == nil || h.AlertAfterMissed == nil {
+		return DEFAULT_HEARTBEAT_ALERT_AFTER_MISSED
+	}
+	return *h.AlertAfterMissed
+}
+
+// GetHeartbeatChains returns the chains heartbeats are sent on out of the chains of the test, none if
+// heartbeats are disabled.
+func (o *Config) GetHeartbeatChains(selectors []uint64) ([]uint64, error) {
+	if !o.Heartbeat.IsEnabled() {
+		return nil, nil
+	}
+	if len(o.Heartbeat.Chains) == 0 {
+		return selectors, nil
+	}
+	chains := make([]uint64, 0, len(o.Heartbeat.Chains))
+	for _, ref := range o.Heartbeat.Chains {
+		selector, err := o.ResolveChainSelector(ref)
+		if err != nil {
+			return nil, fieldError("Heartbeat.Chains", err)
+		}
+		chains = append(chains, selector)
+	}
+	return chains, nil
+}
+
+// heartbeatsPerChain is the number of heartbeats a chain sends during LoadProfile.TestDuration.
+func (o *Config) heartbeatsPerChain() int64 {
+	return int64(math.Ceil(o.LoadProfile.GetTestDuration().Seconds() / o.Heartbeat.GetInterval().Seconds()))
+}
+
+// HeartbeatSender sends a heartbeat on a chain, a self-transfer of a sender account.
+type HeartbeatSender interface {
+	SendHeartbeat(ctx context.Context, selector uint64) error
+}
+
+// HeartbeatDriver sends the heartbeats, records them with the reporter and notifies chains that missed
+// AlertAfterMissed heartbeats in a row.
+type HeartbeatDriver struct {
+	interval   time.Duration
+	alertAfter int
+	chains     []uint64
+	sender     HeartbeatSender
+	reporter   *Reporter
+	notifier   *Notifier
+	missed     map[uint64]int
+}
+
+// NewHeartbeatDriver returns the driver of the heartbeats on the chains of the test, nil if heartbeats are
+// disabled. The reporter and notifier are optional.
+func (o *Config) NewHeartbeatDriver(selectors []uint64, sender HeartbeatSender, reporter *Reporter, notifier *Notifier) (*HeartbeatDriver, error) {
+	chains, err := o.GetHeartbeatChains(selectors)
+	if err != nil || len(chains) == 0 {
+		return nil, err
+	}
+	return &HeartbeatDriver{
+		interval:   o.Heartbeat.GetInterval(),
+		alertAfter: o.Heartbeat.GetAlertAfterMissed(),
+		chains:     chains,
+		sender:     sender,
+		reporter:   reporter,
+		notifier:   notifier,
+		missed:     make(map[uint64]int),
+	}, nil
+}
+
+// Run sends a heartbeat on every chain each interval until ctx is done.
+func (d *HeartbeatDriver) Run(ctx context.Context) error {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		d.beat(ctx)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *HeartbeatDriver) beat(ctx context.Context) {
+	for _, selector := range d.chains {
+		err := d.sender.SendHeartbeat(ctx, selector)
+		if d.reporter != nil {
+			d.reporter.RecordHeartbeat(chainName(selector), err)
+		}
+		if err == nil {
+			d.missed[selector] = 0
+			continue
+		}
+		d.missed[selector]++
+		// notified once per outage, when it reaches the threshold
+		if d.missed[selector] == d.alertAfter {
+			d.notifier.Notify(NOTIFY_ON_HEARTBEAT_MISSED, map[string]string{
+				"chain":  chainName(selector),
+				"missed": strconv.Itoa(d.missed[selector]),
+				"error":  err.Error(),
+			})
+		}
+	}
+}
+
+func (o *Config) validateHeartbeat() error {
+	h := o.Heartbeat
+	if h == nil {
+		return nil
+	}
+	if h.Interval != nil && h.Interval.Duration <= 0 {
+		return fmt.Errorf("Heartbeat.Interval must be positive, got %s", h.Interval.Duration)
+	}
+	if h.AlertAfterMissed != nil && *h.AlertAfterMissed < 1 {
+		return fmt.Errorf("Heartbeat.AlertAfterMissed must be at least 1, got %d", *h.AlertAfterMissed)
+	}
+	// block times are only known for private networks
+	var private []uint64
+	names := make(map[uint64]string)
+	for name := range o.PrivateEthereumNetworks {
+		if selector, err := o.ResolveChainSelector(name); err == nil {
+			private = append(private, selector)
+			names[selector] = name
+		}
+	}
+	sort.Slice(private, func(i, j int) bool { return names[private[i]] < names[private[j]] })
+	chains := private
+	if len(h.Chains) > 0 {
+		chains = nil
+		for _, ref := range h.Chains {
+			selector, err := o.ResolveChainSelector(ref)
+			if err != nil {
+				return fieldError("Heartbeat.Chains", err)
+			}
+			chains = append(chains, selector)
+		}
+	}
+	for _, selector := range chains {
+		network := o.privateNetwork(selector)
+		if network == nil || network.EthereumChainConfig == nil {
+			continue
+		}
+		if blockTime := time.Duration(network.EthereumChainConfig.SecondsPerSlot) * time.Second; h.GetInterval() < blockTime {
+			return fmt.Errorf("Heartbeat.Interval (%s) is shorter than the block time of %s (%s)", h.GetInterval(), names[selector], blockTime)
+		}
+	}
+	return nil
+}
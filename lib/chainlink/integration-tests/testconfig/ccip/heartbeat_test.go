@@ -0,0 +1,122 @@
+package ccip
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeHeartbeatSender struct {
+	down map[uint64]bool
+}
+
+func (s *fakeHeartbeatSender) SendHeartbeat(_ context.Context, selector uint64) error {
+	if s.down[selector] {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func TestHeartbeatDriver(t *testing.T) {
+	var received []webhookMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg webhookMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		received = append(received, msg)
+	}))
+	defer server.Close()
+
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(chainSemanticsNetworks+`
+[Heartbeat]
+Enabled = true
+AlertAfterMissed = 2
+
+[Notifications]
+WebhookURL = '`+server.URL+`'
+NotifyOn = ['heartbeatMissed']
+`), &cfg))
+	require.NoError(t, cfg.validateHeartbeat())
+	require.NoError(t, cfg.Notifications.Validate())
+
+	simulated1, simulated2 := uint64(3379446385462418246), uint64(12922642891491394802)
+	sender := &fakeHeartbeatSender{down: map[uint64]bool{simulated2: true}}
+	reporter := NewReporter(&Reporting{})
+	driver, err := cfg.NewHeartbeatDriver([]uint64{simulated1, simulated2}, sender, reporter, NewNotifier(cfg.Notifications))
+	require.NoError(t, err)
+
+	// notified once when the chain reaches AlertAfterMissed
+	for range 3 {
+		driver.beat(context.Background())
+	}
+	require.Len(t, received, 1)
+	require.Equal(t, "*CCIP test heartbeatMissed*\nchain: geth-devnet-2\nerror: connection refused\nmissed: 2", received[0].Text)
+
+	sender.down[simulated2] = false
+	driver.beat(context.Background())
+	require.Equal(t, []HeartbeatReport{
+		{Chain: "geth-devnet-2", Sent: 1, Missed: 3, MaxConsecutiveMissed: 3},
+		{Chain: "geth-testnet", Sent: 4},
+	}, reporter.Report().Heartbeats)
+
+	// only the configured chains send heartbeats
+	cfg.Heartbeat.Chains = []string{"SIMULATED_1"}
+	chains, err := cfg.GetHeartbeatChains([]uint64{simulated1, simulated2})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{simulated1}, chains)
+
+	cfg.Heartbeat.Enabled = nil
+	driver, err = cfg.NewHeartbeatDriver([]uint64{simulated1, simulated2}, sender, reporter, nil)
+	require.NoError(t, err)
+	require.Nil(t, driver)
+}
+
+func TestValidateHeartbeat(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "defaults", content: "[Heartbeat]\nEnabled = true"},
+		{name: "zero alert threshold", content: "[Heartbeat]\nAlertAfterMissed = 0", err: "Heartbeat.AlertAfterMissed must be at least 1, got 0"},
+		{
+			name:    "interval shorter than a block",
+			content: "[Heartbeat]\nInterval = '2s'",
+			err:     "Heartbeat.Interval (2s) is shorter than the block time of SIMULATED_2 (3s)",
+		},
+		{name: "faster chains only", content: "[Heartbeat]\nInterval = '2s'\nChains = ['SIMULATED_1']"},
+		{name: "unknown chain", content: "[Heartbeat]\nChains = ['nope']", err: "Heartbeat.Chains"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte(chainSemanticsNetworks+tc.content), &cfg))
+			err := cfg.validateHeartbeat()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestEstimateCostHeartbeat(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(costConfig+`
+[Heartbeat]
+Enabled = true
+Interval = '30s'
+Chains = ['geth-testnet']
+`), &cfg))
+	estimate, err := cfg.EstimateCost([]uint64{12922642891491394802, 3379446385462418246})
+	require.NoError(t, err)
+	// 100 messages * 250k gas * 10 gwei + 4 heartbeats * 21k gas * 10 gwei
+	require.Equal(t, "0.25084", formatAmount(estimate.Chains[0].Native))
+	require.Equal(t, "0.25", formatAmount(estimate.Chains[1].Native))
+}
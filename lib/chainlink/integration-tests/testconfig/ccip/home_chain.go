@@ -0,0 +1,104 @@
+package ccip
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	DON_FAMILY_COMMIT = "commit"
+	DON_FAMILY_EXEC   = "exec"
+
+	// DEFAULT_CAPABILITY_VERSION mirrors the CCIP capability version registered by the deployment changesets
+	DEFAULT_CAPABILITY_VERSION = "v1.0.0"
+)
+
+// SupportedCapabilityVersions lists the CCIP capability versions the home chain contracts know about.
+var SupportedCapabilityVersions = []string{DEFAULT_CAPABILITY_VERSION}
+
+// HomeChainConfig overrides the capability registry / CCIPHome parameters used when setting up the DONs on the home chain.
+type HomeChainConfig struct {
+	// DONFamilies are the plugin DONs configured in CCIPHome, defaults to commit and exec
+	DONFamilies []string `toml:",omitempty"`
+	// CandidateConfigOnly sets the candidate config without promoting it to active,
+	// so promotion tests can run the second phase themselves
+	CandidateConfigOnly *bool `toml:",omitempty"`
+	// FPerDON is the number of faulty nodes tolerated by each DON family, defaults to (nodes-1)/3
+	FPerDON           map[string]int `toml:",omitempty"`
+	CapabilityVersion *string        `toml:",omitempty"`
+}
+
+func (h *HomeChainConfig) GetDONFamilies() []string {
+	if h == nil || len(h.DONFamilies) == 0 {
+		return []string{DON_FAMILY_COMMIT, DON_FAMILY_EXEC}
+	}
+	return h.DONFamilies
+}
+
+func (h *HomeChainConfig) IsCandidateConfigOnly() bool {
+	return h != nil && pointer.GetBool(h.CandidateConfigOnly)
+}
+
+// GetF returns the fault tolerance of the DON family given the number of plugin nodes serving it.
+func (h *HomeChainConfig) GetF(family string, noOfNodes int) int {
+	if h != nil {
+		if f, ok := h.FPerDON[family]; ok {
+			return f
+		}
+	}
+	return (noOfNodes - 1) / 3
+}
+
+func (h *HomeChainConfig) GetCapabilityVersion() string {
+	if h == nil || pointer.GetString(h.CapabilityVersion) == "" {
+		return DEFAULT_CAPABILITY_VERSION
+	}
+	return *h.CapabilityVersion
+}
+
+// Validate checks the home chain config; donSizes holds the number of plugin nodes serving each DON family.
+func (h *HomeChainConfig) Validate(donSizes map[string]int) error {
+	families := make(map[string]struct{})
+	for _, family := range h.GetDONFamilies() {
+		if family == "" {
+			return fmt.Errorf("HomeChainConfig.DONFamilies cannot contain empty names")
+		}
+		if _, ok := families[family]; ok {
+			return fmt.Errorf("HomeChainConfig.DONFamilies contains %s more than once", family)
+		}
+		families[family] = struct{}{}
+	}
+	perDON := make([]string, 0, len(h.FPerDON))
+	for family := range h.FPerDON {
+		perDON = append(perDON, family)
+	}
+	sort.Strings(perDON)
+	for _, family := range perDON {
+		f := h.FPerDON[family]
+		if _, ok := families[family]; !ok {
+			return fmt.Errorf("HomeChainConfig.FPerDON has an entry for %s, which is not in DONFamilies", family)
+		}
+		if f < 1 {
+			return fmt.Errorf("HomeChainConfig.FPerDON.%s must be at least 1, got %d", family, f)
+		}
+		if size := donSizes[family]; size > 0 && size < 3*f+1 {
+			return withKind(ErrInsufficientNodes, fmt.Errorf("HomeChainConfig.FPerDON.%s = %d requires at least %d plugin nodes, but the DON has %d",
+				family, f, 3*f+1, size))
+		}
+	}
+	version := h.GetCapabilityVersion()
+	supported := false
+	for _, v := range SupportedCapabilityVersions {
+		if v == version {
+			supported = true
+		}
+	}
+	if !supported {
+		return fmt.Errorf("HomeChainConfig.CapabilityVersion %q is not one of the known versions: %s",
+			version, strings.Join(SupportedCapabilityVersions, ", "))
+	}
+	return nil
+}
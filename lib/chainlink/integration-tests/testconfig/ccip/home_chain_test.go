@@ -0,0 +1,54 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHomeChainConfigDefaults(t *testing.T) {
+	var home *HomeChainConfig
+	require.Equal(t, []string{DON_FAMILY_COMMIT, DON_FAMILY_EXEC}, home.GetDONFamilies())
+	require.False(t, home.IsCandidateConfigOnly())
+	require.Equal(t, 1, home.GetF(DON_FAMILY_COMMIT, 4))
+	require.Equal(t, 2, home.GetF(DON_FAMILY_EXEC, 7))
+	require.Equal(t, DEFAULT_CAPABILITY_VERSION, home.GetCapabilityVersion())
+	require.NoError(t, (&HomeChainConfig{}).Validate(nil))
+}
+
+func TestValidateHomeChainConfig(t *testing.T) {
+	donSizes := map[string]int{DON_FAMILY_COMMIT: 4, DON_FAMILY_EXEC: 7}
+	for _, tc := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "commit only", content: "DONFamilies = ['commit']\nCandidateConfigOnly = true"},
+		{name: "empty family", content: "DONFamilies = ['commit', '']", err: "HomeChainConfig.DONFamilies cannot contain empty names"},
+		{name: "duplicate family", content: "DONFamilies = ['exec', 'exec']", err: "HomeChainConfig.DONFamilies contains exec more than once"},
+		{name: "f of an unknown family", content: "DONFamilies = ['commit']\n[FPerDON]\nexec = 1", err: "HomeChainConfig.FPerDON has an entry for exec, which is not in DONFamilies"},
+		{name: "zero f", content: "[FPerDON]\ncommit = 0", err: "HomeChainConfig.FPerDON.commit must be at least 1, got 0"},
+		{name: "f the DON tolerates", content: "[FPerDON]\nexec = 2"},
+		{name: "f the DON is too small for", content: "[FPerDON]\ncommit = 2", err: "HomeChainConfig.FPerDON.commit = 2 requires at least 7 plugin nodes, but the DON has 4"},
+		{name: "unknown capability version", content: "CapabilityVersion = 'v2.0.0'", err: `HomeChainConfig.CapabilityVersion "v2.0.0" is not one of the known versions: v1.0.0`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var home HomeChainConfig
+			require.NoError(t, toml.Unmarshal([]byte(tc.content), &home))
+			err := home.Validate(donSizes)
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestValidateHomeChainConfigReportsFPerDONInOrder(t *testing.T) {
+	home := HomeChainConfig{FPerDON: map[string]int{DON_FAMILY_EXEC: 0, DON_FAMILY_COMMIT: 0}}
+	for i := 0; i < 10; i++ {
+		require.EqualError(t, home.Validate(nil), "HomeChainConfig.FPerDON.commit must be at least 1, got 0")
+	}
+}
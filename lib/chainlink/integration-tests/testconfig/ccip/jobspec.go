@@ -0,0 +1,181 @@
+package ccip
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/AlekSi/pointer"
+	"github.com/google/uuid"
+
+	"github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip/validate"
+)
+
+// JobSpecOverrides replaces or extends the CCIP job specs generated for the plugin nodes.
+type JobSpecOverrides struct {
+	// CommitTemplateFile and ExecTemplateFile are Go text/template files rendered with the JobSpecParams
+	// fields as keys, plus Type, SchemaVersion and Name. Referencing any other key fails the render
+	CommitTemplateFile *string `toml:",omitempty"`
+	ExecTemplateFile   *string `toml:",omitempty"`
+	// ExtraPluginConfig is merged into the pluginConfig of every generated spec, overriding keys already set there
+	ExtraPluginConfig map[string]string `toml:",omitempty"`
+}
+
+const (
+	JOB_SPEC_KIND_COMMIT = "commit"
+	JOB_SPEC_KIND_EXEC   = "exec"
+)
+
+// JobSpecParams are the spec args deployment uses to generate a node's CCIP job spec. ExternalJobID is only
+// read by templates, a random one is used if it's empty, generated specs always get a random one.
+type JobSpecParams struct {
+	P2PV2Bootstrappers     []string          `toml:"p2pV2Bootstrappers"`
+	CapabilityVersion      string            `toml:"capabilityVersion"`
+	CapabilityLabelledName string            `toml:"capabilityLabelledName"`
+	OCRKeyBundleIDs        map[string]string `toml:"ocrKeyBundleIDs"`
+	P2PKeyID               string            `toml:"p2pKeyID"`
+	RelayConfigs           map[string]any    `toml:"relayConfigs"`
+	PluginConfig           map[string]any    `toml:"pluginConfig"`
+	ExternalJobID          string            `toml:"-"`
+}
+
+// jobSpec is the spec deployment marshals, exposed to templates.
+type jobSpec struct {
+	JobSpecParams
+	Type          string `toml:"type"`
+	SchemaVersion uint64 `toml:"schemaVersion"`
+	Name          string `toml:"name"`
+	ExternalJobID string `toml:"externalJobID"`
+}
+
+// GetTemplateFile returns the template overriding the spec of the given kind, or "" if it is generated.
+func (j *JobSpecOverrides) GetTemplateFile(kind string) string {
+	if j == nil {
+		return ""
+	}
+	switch kind {
+	case JOB_SPEC_KIND_COMMIT:
+		return pointer.GetString(j.CommitTemplateFile)
+	case JOB_SPEC_KIND_EXEC:
+		return pointer.GetString(j.ExecTemplateFile)
+	}
+	return ""
+}
+
+// RenderJobSpec renders the job spec of the given kind. The CCIP job runs both the commit and the exec
+// plugin, so without a template both kinds render the spec deployment generates, with
+// validate.NewCCIPSpecToml, for the params.
+func (o *Config) RenderJobSpec(kind string, params JobSpecParams) (string, error) {
+	if kind != JOB_SPEC_KIND_COMMIT && kind != JOB_SPEC_KIND_EXEC {
+		return "", fmt.Errorf("job spec kind must be one of %s or %s, got %q", JOB_SPEC_KIND_COMMIT, JOB_SPEC_KIND_EXEC, kind)
+	}
+	if extra := o.JobSpecOverrides.getExtraPluginConfig(); len(extra) > 0 {
+		merged := make(map[string]any, len(params.PluginConfig)+len(extra))
+		for k, v := range params.PluginConfig {
+			merged[k] = v
+		}
+		for k, v := range extra {
+			merged[k] = v
+		}
+		params.PluginConfig = merged
+	}
+	file := o.JobSpecOverrides.GetTemplateFile(kind)
+	if file == "" {
+		return validate.NewCCIPSpecToml(params.specArgs())
+	}
+	if params.ExternalJobID == "" {
+		params.ExternalJobID = uuid.NewString()
+	}
+	spec := jobSpec{
+		JobSpecParams: params,
+		Type:          "ccip",
+		SchemaVersion: 1,
+		Name:          fmt.Sprintf("%s-%s", "ccip", params.ExternalJobID),
+		ExternalJobID: params.ExternalJobID,
+	}
+	tmpl, err := parseJobSpecTemplate(file)
+	if err != nil {
+		return "", fieldError("JobSpecOverrides."+jobSpecTemplateField(kind), err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, spec.templateData()); err != nil {
+		return "", fmt.Errorf("JobSpecOverrides.%s: rendering %s: %w", jobSpecTemplateField(kind), file, err)
+	}
+	return buf.String(), nil
+}
+
+func (p JobSpecParams) specArgs() validate.SpecArgs {
+	return validate.SpecArgs{
+		P2PV2Bootstrappers:     p.P2PV2Bootstrappers,
+		CapabilityVersion:      p.CapabilityVersion,
+		CapabilityLabelledName: p.CapabilityLabelledName,
+		OCRKeyBundleIDs:        p.OCRKeyBundleIDs,
+		P2PKeyID:               p.P2PKeyID,
+		RelayConfigs:           p.RelayConfigs,
+		PluginConfig:           p.PluginConfig,
+	}
+}
+
+// templateData exposes the spec as a map, so that a key missing from it fails the render instead of printing "<no value>".
+func (s jobSpec) templateData() map[string]any {
+	return map[string]any{
+		"P2PV2Bootstrappers":     s.P2PV2Bootstrappers,
+		"CapabilityVersion":      s.CapabilityVersion,
+		"CapabilityLabelledName": s.CapabilityLabelledName,
+		"OCRKeyBundleIDs":        s.OCRKeyBundleIDs,
+		"P2PKeyID":               s.P2PKeyID,
+		"RelayConfigs":           s.RelayConfigs,
+		"PluginConfig":           s.PluginConfig,
+		"ExternalJobID":          s.ExternalJobID,
+		"Type":                   s.Type,
+		"SchemaVersion":          s.SchemaVersion,
+		"Name":                   s.Name,
+	}
+}
+
+func (j *JobSpecOverrides) getExtraPluginConfig() map[string]string {
+	if j == nil {
+		return nil
+	}
+	return j.ExtraPluginConfig
+}
+
+func jobSpecTemplateField(kind string) string {
+	if kind == JOB_SPEC_KIND_COMMIT {
+		return "CommitTemplateFile"
+	}
+	return "ExecTemplateFile"
+}
+
+func parseJobSpecTemplate(file string) (*template.Template, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := template.New(filepath.Base(file)).Option("missingkey=error").Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", file, err)
+	}
+	return tmpl, nil
+}
+
+func (o *Config) validateJobSpecOverrides() error {
+	if o.JobSpecOverrides == nil {
+		return nil
+	}
+	for _, kind := range []string{JOB_SPEC_KIND_COMMIT, JOB_SPEC_KIND_EXEC} {
+		file := o.JobSpecOverrides.GetTemplateFile(kind)
+		if file == "" {
+			continue
+		}
+		if _, err := parseJobSpecTemplate(file); err != nil {
+			return fieldError("JobSpecOverrides."+jobSpecTemplateField(kind), err)
+		}
+	}
+	if _, ok := o.JobSpecOverrides.ExtraPluginConfig[""]; ok {
+		return fmt.Errorf("JobSpecOverrides.ExtraPluginConfig cannot have an empty key")
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package ccip
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func testJobSpecParams() JobSpecParams {
+	return JobSpecParams{
+		P2PV2Bootstrappers:     []string{"12D3KooWMWUKdoAc8ruZYPMjd7ycKBbrkqD4jAfpFKkNF4Lw8oaQ@bootstrap:6690"},
+		CapabilityVersion:      "v1.0.0",
+		CapabilityLabelledName: "ccip",
+		OCRKeyBundleIDs:        map[string]string{"evm": "7f9c9a1e2a0b5f1a3c4d6e8f0a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b"},
+		P2PKeyID:               "12D3KooWQsSs2t4ckMqmNdYmnJsEYRHvcUhBEv6tL5Hm8kMs6DJC",
+		PluginConfig:           map[string]any{},
+		ExternalJobID:          "5b1c6b0e-9f6a-4d7e-8a43-1f2b3c4d5e6f",
+	}
+}
+
+func TestRenderJobSpecDefault(t *testing.T) {
+	// the golden file is what deployment generates for the same spec args, with the job id of testJobSpecParams
+	want, err := os.ReadFile(filepath.Join("testdata", "job_spec.golden.toml"))
+	require.NoError(t, err)
+	var cfg Config
+	for _, kind := range []string{JOB_SPEC_KIND_COMMIT, JOB_SPEC_KIND_EXEC} {
+		got, err := cfg.RenderJobSpec(kind, testJobSpecParams())
+		require.NoError(t, err)
+		var spec struct {
+			ExternalJobID string `toml:"externalJobID"`
+		}
+		require.NoError(t, toml.Unmarshal([]byte(got), &spec))
+		require.NotEqual(t, testJobSpecParams().ExternalJobID, spec.ExternalJobID, "generated specs get a random job id")
+		require.Equal(t, string(want), strings.ReplaceAll(got, spec.ExternalJobID, testJobSpecParams().ExternalJobID))
+	}
+}
+
+func TestRenderJobSpecOverrides(t *testing.T) {
+	dir := t.TempDir()
+	commit := filepath.Join(dir, "commit.tmpl")
+	require.NoError(t, os.WriteFile(commit, []byte(`type = "{{ .Type }}"
+name = "{{ .Name }}-commit"
+p2pKeyID = "{{ .P2PKeyID }}"
+[pluginConfig]
+{{- range $k, $v := .PluginConfig }}
+{{ $k }} = "{{ $v }}"
+{{- end }}
+`), 0o600))
+	exec := filepath.Join(dir, "exec.tmpl")
+	require.NoError(t, os.WriteFile(exec, []byte(`name = "{{ .Name }}"
+batchGasLimit = {{ .PluginConfig.batchGasLimit }}
+`), 0o600))
+
+	cfg := Config{JobSpecOverrides: &JobSpecOverrides{
+		CommitTemplateFile: &commit,
+		ExtraPluginConfig:  map[string]string{"offchainConfigVersion": "2"},
+	}}
+	require.NoError(t, cfg.validateJobSpecOverrides())
+	params := testJobSpecParams()
+	params.PluginConfig = map[string]any{"offchainConfigVersion": "1", "inflightCacheExpiry": "10m"}
+	got, err := cfg.RenderJobSpec(JOB_SPEC_KIND_COMMIT, params)
+	require.NoError(t, err)
+	require.Equal(t, `type = "ccip"
+name = "ccip-5b1c6b0e-9f6a-4d7e-8a43-1f2b3c4d5e6f-commit"
+p2pKeyID = "12D3KooWQsSs2t4ckMqmNdYmnJsEYRHvcUhBEv6tL5Hm8kMs6DJC"
+[pluginConfig]
+inflightCacheExpiry = "10m"
+offchainConfigVersion = "2"
+`, got)
+	require.Equal(t, "1", params.PluginConfig["offchainConfigVersion"], "the caller's plugin config must not be modified")
+
+	// exec isn't overridden, so only the extra plugin config changes the generated spec
+	got, err = cfg.RenderJobSpec(JOB_SPEC_KIND_EXEC, testJobSpecParams())
+	require.NoError(t, err)
+	require.Contains(t, got, "[pluginConfig]\n  offchainConfigVersion = \"2\"\n")
+
+	cfg.JobSpecOverrides.ExecTemplateFile = &exec
+	_, err = cfg.RenderJobSpec(JOB_SPEC_KIND_EXEC, testJobSpecParams())
+	require.ErrorContains(t, err, "JobSpecOverrides.ExecTemplateFile: rendering "+exec)
+	require.ErrorContains(t, err, `exec.tmpl`)
+	require.ErrorContains(t, err, `map has no entry for key "batchGasLimit"`)
+
+	// without a job id the template gets a random one
+	cfg.JobSpecOverrides.ExecTemplateFile = nil
+	params = testJobSpecParams()
+	params.ExternalJobID = ""
+	got, err = cfg.RenderJobSpec(JOB_SPEC_KIND_COMMIT, params)
+	require.NoError(t, err)
+	require.Regexp(t, `name = "ccip-[0-9a-f-]{36}-commit"`, got)
+
+	_, err = cfg.RenderJobSpec("bootstrap", testJobSpecParams())
+	require.ErrorContains(t, err, `job spec kind must be one of commit or exec, got "bootstrap"`)
+}
+
+func TestValidateJobSpecOverrides(t *testing.T) {
+	broken := filepath.Join(t.TempDir(), "broken.tmpl")
+	require.NoError(t, os.WriteFile(broken, []byte("name = {{ .Name \n"), 0o600))
+	missing := filepath.Join(t.TempDir(), "missing.tmpl")
+
+	cfg := Config{JobSpecOverrides: &JobSpecOverrides{CommitTemplateFile: &broken}}
+	require.ErrorContains(t, cfg.validateJobSpecOverrides(), "JobSpecOverrides.CommitTemplateFile: parsing "+broken)
+	cfg = Config{JobSpecOverrides: &JobSpecOverrides{ExecTemplateFile: &missing}}
+	require.ErrorContains(t, cfg.validateJobSpecOverrides(), "JobSpecOverrides.ExecTemplateFile: open "+missing)
+	cfg = Config{JobSpecOverrides: &JobSpecOverrides{ExtraPluginConfig: map[string]string{"": "x"}}}
+	require.ErrorContains(t, cfg.validateJobSpecOverrides(), "JobSpecOverrides.ExtraPluginConfig cannot have an empty key")
+}
@@ -0,0 +1,91 @@
+package ccip
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LANE_KEY_SEPARATOR separates the source and destination chain in lane keys, e.g. "SIMULATED_1->SIMULATED_2"
+const LANE_KEY_SEPARATOR = "->"
+
+// ResolvedLane is a source->dest pair whose chain references have been resolved to chain selectors.
+// Source and Dest keep the chain references as written in the config (network name or selector).
+type ResolvedLane struct {
+	Source         string
+	Dest           string
+	SourceSelector uint64
+	DestSelector   uint64
+}
+
+func (l ResolvedLane) Key() string {
+	return LaneKey(l.Source, l.Dest)
+}
+
+// Matches returns true if the "source->dest" lane key refers to this lane, either by chain name or by selector.
+func (l ResolvedLane) Matches(laneKey string) bool {
+	source, dest, err := ParseLaneKey(laneKey)
+	if err != nil {
+		return false
+	}
+	return chainRefMatches(source, l.Source, l.SourceSelector) && chainRefMatches(dest, l.Dest, l.DestSelector)
+}
+
+func LaneKey(source, dest string) string {
+	return source + LANE_KEY_SEPARATOR + dest
+}
+
+// SelectorLane returns the lane between the chains of the selectors, referenced by their chain names. It's
+// how the harness names lanes it only knows the selectors of, like those of the smoke tests.
+func SelectorLane(source, dest uint64) ResolvedLane {
+	return ResolvedLane{Source: chainName(source), Dest: chainName(dest), SourceSelector: source, DestSelector: dest}
+}
+
+// ParseLaneKey splits a "source->dest" lane key into its chain references.
+func ParseLaneKey(laneKey string) (string, string, error) {
+	parts := strings.Split(laneKey, LANE_KEY_SEPARATOR)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("invalid lane key %q, expected format source%sdest", laneKey, LANE_KEY_SEPARATOR)
+	}
+	source, dest := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+	if source == "" || dest == "" {
+		return "", "", fmt.Errorf("invalid lane key %q, source and dest cannot be empty", laneKey)
+	}
+	if source == dest {
+		return "", "", fmt.Errorf("invalid lane key %q, source and dest must differ", laneKey)
+	}
+	return source, dest, nil
+}
+
+func chainRefMatches(ref, name string, selector uint64) bool {
+	if ref == name {
+		return true
+	}
+	if sel, err := strconv.ParseUint(ref, 10, 64); err == nil && selector != 0 {
+		return sel == selector
+	}
+	return false
+}
+
+// matchingLaneKeys returns the keys of perLane referring to the lane, sorted.
+func matchingLaneKeys[V any](lane ResolvedLane, perLane map[string]V) []string {
+	var laneKeys []string
+	for laneKey := range perLane {
+		if lane.Matches(laneKey) {
+			laneKeys = append(laneKeys, laneKey)
+		}
+	}
+	sort.Strings(laneKeys)
+	return laneKeys
+}
+
+// laneKeyFor returns the key of perLane referring to the lane. Of several keys matching the lane, e.g. by
+// name and by selector, the later in sorted order wins, as it does for RateLimits.
+func laneKeyFor[V any](lane ResolvedLane, perLane map[string]V) (string, bool) {
+	laneKeys := matchingLaneKeys(lane, perLane)
+	if len(laneKeys) == 0 {
+		return "", false
+	}
+	return laneKeys[len(laneKeys)-1], true
+}
@@ -0,0 +1,82 @@
+package ccip
+
+import (
+	"fmt"
+	"testing"
+
+	chainselectors "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLaneKey(t *testing.T) {
+	for _, tc := range []struct {
+		key    string
+		source string
+		dest   string
+		err    string
+	}{
+		{key: "SIMULATED_1->SIMULATED_2", source: "SIMULATED_1", dest: "SIMULATED_2"},
+		{key: " SIMULATED_1 -> 12922642891491394802 ", source: "SIMULATED_1", dest: "12922642891491394802"},
+		{key: "SIMULATED_1", err: `invalid lane key "SIMULATED_1", expected format source->dest`},
+		{key: "SIMULATED_1->SIMULATED_2->SIMULATED_3", err: `invalid lane key "SIMULATED_1->SIMULATED_2->SIMULATED_3", expected format source->dest`},
+		{key: "->SIMULATED_2", err: `invalid lane key "->SIMULATED_2", source and dest cannot be empty`},
+		{key: "SIMULATED_1-> ", err: `invalid lane key "SIMULATED_1-> ", source and dest cannot be empty`},
+		{key: "SIMULATED_1->SIMULATED_1", err: `invalid lane key "SIMULATED_1->SIMULATED_1", source and dest must differ`},
+	} {
+		t.Run(tc.key, func(t *testing.T) {
+			source, dest, err := ParseLaneKey(tc.key)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.source, source)
+			require.Equal(t, tc.dest, dest)
+		})
+	}
+}
+
+func TestResolvedLaneMatches(t *testing.T) {
+	lane := ResolvedLane{Source: "SIMULATED_1", Dest: "SIMULATED_2", SourceSelector: 3379446385462418246, DestSelector: 12922642891491394802}
+	require.Equal(t, "SIMULATED_1->SIMULATED_2", lane.Key())
+	for key, matches := range map[string]bool{
+		"SIMULATED_1->SIMULATED_2":                  true,
+		"3379446385462418246->SIMULATED_2":          true,
+		"SIMULATED_1->12922642891491394802":         true,
+		"3379446385462418246->12922642891491394802": true,
+		"SIMULATED_2->SIMULATED_1":                  false,
+		"SIMULATED_1->3379446385462418246":          false,
+		"SIMULATED_1->SIMULATED_3":                  false,
+		"SIMULATED_1":                               false,
+	} {
+		require.Equal(t, matches, lane.Matches(key), key)
+	}
+
+	// lanes resolved without selectors only match by name
+	unresolved := ResolvedLane{Source: "SIMULATED_1", Dest: "SIMULATED_2"}
+	require.True(t, unresolved.Matches("SIMULATED_1->SIMULATED_2"))
+	require.False(t, unresolved.Matches("0->SIMULATED_2"))
+}
+
+func TestSelectorLane(t *testing.T) {
+	lane := SelectorLane(chainselectors.GETH_TESTNET.Selector, chainselectors.GETH_DEVNET_2.Selector)
+	require.Equal(t, chainselectors.GETH_TESTNET.Name+"->"+chainselectors.GETH_DEVNET_2.Name, lane.Key())
+	require.True(t, lane.Matches(fmt.Sprintf("%d->%d", chainselectors.GETH_TESTNET.Selector, chainselectors.GETH_DEVNET_2.Selector)))
+}
+
+func TestLaneKeyFor(t *testing.T) {
+	lane := ResolvedLane{Source: "SIMULATED_1", Dest: "SIMULATED_2", SourceSelector: 3379446385462418246, DestSelector: 12922642891491394802}
+	perLane := map[string]int{
+		"SIMULATED_1->SIMULATED_2":                  1,
+		"3379446385462418246->12922642891491394802": 2,
+		"SIMULATED_2->SIMULATED_1":                  3,
+	}
+	require.Equal(t, []string{"3379446385462418246->12922642891491394802", "SIMULATED_1->SIMULATED_2"}, matchingLaneKeys(lane, perLane))
+	for i := 0; i < 20; i++ {
+		laneKey, ok := laneKeyFor(lane, perLane)
+		require.True(t, ok)
+		require.Equal(t, "SIMULATED_1->SIMULATED_2", laneKey)
+	}
+	_, ok := laneKeyFor(ResolvedLane{Source: "SIMULATED_1", Dest: "SIMULATED_3"}, perLane)
+	require.False(t, ok)
+}
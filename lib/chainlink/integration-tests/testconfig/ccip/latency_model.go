@@ -0,0 +1,149 @@
+package ccip
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	DEFAULT_LATENCY_SAFETY_FACTOR = 3.0
+	// DEFAULT_BLESSING_TIME is how long RMN takes to bless a commit report on a private network
+	DEFAULT_BLESSING_TIME = 10 * time.Second
+)
+
+// LatencyModel estimates how long a message takes on a lane from the finality of its source, the commit
+// interval, the RMN blessing and the inclusion of the execution on its dest. With Adaptive the lane
+// waits are the estimate times SafetyFactor, instead of the flat Timeouts.
+type LatencyModel struct {
+	Adaptive     *bool    `toml:",omitempty"`
+	SafetyFactor *float64 `toml:",omitempty"`
+	// SourceFinality overrides the finality estimated from the source chain, required for live networks
+	SourceFinality *Duration `toml:",omitempty"`
+	CommitInterval *Duration `toml:",omitempty"`
+	// BlessingTime is only added if RMN is enabled
+	BlessingTime *Duration `toml:",omitempty"`
+	// DestInclusion overrides the block time of the dest chain, required for live networks
+	DestInclusion *Duration `toml:",omitempty"`
+}
+
+func (l *LatencyModel) IsAdaptive() bool {
+	return l != nil && pointer.GetBool(l.Adaptive)
+}
+
+func (l *LatencyModel) GetSafetyFactor() float64 {
+	if l == nil || l.SafetyFactor == nil {
+		return DEFAULT_LATENCY_SAFETY_FACTOR
+	}
+	return *l.SafetyFactor
+}
+
+func (l *LatencyModel) GetCommitInterval() time.Duration {
+	if l == nil || l.CommitInterval == nil {
+		return DEFAULT_COMMIT_INTERVAL
+	}
+	return l.CommitInterval.Duration
+}
+
+func (l *LatencyModel) GetBlessingTime() time.Duration {
+	if l == nil || l.BlessingTime == nil {
+		return DEFAULT_BLESSING_TIME
+	}
+	return l.BlessingTime.Duration
+}
+
+// laneLatency is the estimate of a lane split into the waits for commit and exec.
+type laneLatency struct {
+	commit time.Duration
+	exec   time.Duration
+}
+
+func (o *Config) estimateLaneLatency(lane ResolvedLane) (laneLatency, error) {
+	finality := o.EstimateChainFinalityTime(lane.SourceSelector)
+	if o.LatencyModel != nil && o.LatencyModel.SourceFinality != nil {
+		finality = o.LatencyModel.SourceFinality.Duration
+	}
+	if finality <= 0 {
+		return laneLatency{}, fmt.Errorf("finality of %s is unknown, set LatencyModel.SourceFinality", lane.Source)
+	}
+	inclusion := o.estimateDestInclusion(lane.DestSelector)
+	if o.LatencyModel != nil && o.LatencyModel.DestInclusion != nil {
+		inclusion = o.LatencyModel.DestInclusion.Duration
+	}
+	if inclusion <= 0 {
+		return laneLatency{}, fmt.Errorf("block time of %s is unknown, set LatencyModel.DestInclusion", lane.Dest)
+	}
+	latency := laneLatency{commit: finality + o.LatencyModel.GetCommitInterval(), exec: inclusion}
+	if pointer.GetInt(o.RMNConfig.NoOfNodes) > 0 {
+		latency.exec += o.LatencyModel.GetBlessingTime()
+	}
+	return latency, nil
+}
+
+// estimateDestInclusion is one block of the private network, plus the soft confirmations of zk rollups.
+func (o *Config) estimateDestInclusion(selector uint64) time.Duration {
+	network := o.privateNetwork(selector)
+	if network == nil || network.EthereumChainConfig == nil {
+		return 0
+	}
+	inclusion := time.Duration(network.EthereumChainConfig.SecondsPerSlot) * time.Second
+	if semantics := o.GetChainSemantics(selector); semantics.GetType() == CHAIN_SEMANTICS_ZK {
+		inclusion += softConfirmationTime(network, semantics)
+	}
+	return inclusion
+}
+
+// ExpectedE2ELatency returns how long a message on the lane is expected to take from send to execution,
+// without the safety factor.
+func (o *Config) ExpectedE2ELatency(lane ResolvedLane) (time.Duration, error) {
+	latency, err := o.estimateLaneLatency(lane)
+	if err != nil {
+		return 0, err
+	}
+	return latency.commit + latency.exec, nil
+}
+
+// adaptiveLaneWaitBudget returns the lane waits of the latency model, false if the model can't estimate
+// the lane.
+func (o *Config) adaptiveLaneWaitBudget(source, dest uint64) (LaneWaitBudget, bool) {
+	latency, err := o.estimateLaneLatency(ResolvedLane{SourceSelector: source, DestSelector: dest})
+	if err != nil {
+		return LaneWaitBudget{}, false
+	}
+	factor := o.LatencyModel.GetSafetyFactor()
+	return LaneWaitBudget{
+		Commit: time.Duration(float64(latency.commit) * factor),
+		Exec:   time.Duration(float64(latency.exec) * factor),
+	}, true
+}
+
+func (o *Config) validateLatencyModel() error {
+	l := o.LatencyModel
+	if l == nil {
+		return nil
+	}
+	if l.SafetyFactor != nil && *l.SafetyFactor < 1 {
+		return fmt.Errorf("LatencyModel.SafetyFactor must be at least 1, got %f", *l.SafetyFactor)
+	}
+	for name, d := range map[string]*Duration{
+		"SourceFinality": l.SourceFinality,
+		"CommitInterval": l.CommitInterval,
+		"BlessingTime":   l.BlessingTime,
+		"DestInclusion":  l.DestInclusion,
+	} {
+		if d != nil && d.Duration <= 0 {
+			return fmt.Errorf("LatencyModel.%s must be positive, got %s", name, d.Duration)
+		}
+	}
+	if !l.IsAdaptive() {
+		return nil
+	}
+	// every lane between the private networks has to be estimated, live ones fall back to Timeouts
+	for _, lane := range o.privateNetworkLanes() {
+		if _, err := o.ExpectedE2ELatency(lane); err != nil {
+			return fmt.Errorf("LatencyModel.Adaptive is set but lane %s can't be estimated: %w", lane.Key(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package ccip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatencyModel(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(chainSemanticsNetworks+`
+[LatencyModel]
+Adaptive = true
+`), &cfg))
+	require.NoError(t, cfg.validateLatencyModel())
+	lane := ResolvedLane{Source: "SIMULATED_1", Dest: "SIMULATED_2", SourceSelector: 3379446385462418246, DestSelector: 12922642891491394802}
+
+	// 2s finality of the source, the commit interval and a 3s block on the dest
+	expected, err := cfg.ExpectedE2ELatency(lane)
+	require.NoError(t, err)
+	require.Equal(t, 7*time.Second, expected)
+	require.Equal(t, LaneWaitBudget{Commit: 12 * time.Second, Exec: 9 * time.Second}, cfg.GetLaneWaitBudget(lane.SourceSelector, lane.DestSelector))
+
+	cfg.RMNConfig.NoOfNodes = pointer.ToInt(2)
+	expected, err = cfg.ExpectedE2ELatency(lane)
+	require.NoError(t, err)
+	require.Equal(t, 7*time.Second+DEFAULT_BLESSING_TIME, expected)
+
+	// live networks fall back to the timeouts
+	live := uint64(16015286601757825753)
+	require.Equal(t, LaneWaitBudget{Commit: DEFAULT_COMMIT_TIMEOUT, Exec: DEFAULT_EXEC_TIMEOUT}, cfg.GetLaneWaitBudget(lane.SourceSelector, live))
+	_, err = cfg.ExpectedE2ELatency(ResolvedLane{Source: "sepolia", SourceSelector: live, DestSelector: lane.DestSelector})
+	require.ErrorContains(t, err, "finality of sepolia is unknown, set LatencyModel.SourceFinality")
+
+	cfg.LatencyModel.Adaptive = nil
+	require.Equal(t, LaneWaitBudget{Commit: DEFAULT_COMMIT_TIMEOUT, Exec: DEFAULT_EXEC_TIMEOUT}, cfg.GetLaneWaitBudget(lane.SourceSelector, lane.DestSelector))
+}
+
+func TestValidateLatencyModel(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "safety factor below 1", content: "[LatencyModel]\nSafetyFactor = 0.5", err: "LatencyModel.SafetyFactor must be at least 1, got 0.500000"},
+		{name: "zero component", content: "[LatencyModel]\nBlessingTime = '0s'", err: "LatencyModel.BlessingTime must be positive, got 0s"},
+		{
+			name:    "unknown block time",
+			content: "[LatencyModel]\nAdaptive = true\n[PrivateEthereumNetworks.SIMULATED_1.EthereumChainConfig]\nchain_id = 1337\nseconds_per_slot = 2\n[PrivateEthereumNetworks.SIMULATED_2.EthereumChainConfig]\nchain_id = 2337",
+			err:     "LatencyModel.Adaptive is set but lane SIMULATED_1->SIMULATED_2 can't be estimated: block time of SIMULATED_2 is unknown, set LatencyModel.DestInclusion",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte(tc.content), &cfg))
+			require.EqualError(t, cfg.validateLatencyModel(), tc.err)
+		})
+	}
+}
+
+func TestReporterExpectedLatency(t *testing.T) {
+	reporter := NewReporter(&Reporting{})
+	sentAt := time.Now()
+	require.NoError(t, reporter.RecordMessageEvent(MessageEvent{Lane: "a->b", SeqNr: 1, Phase: MESSAGE_PHASE_SENT, At: sentAt}))
+	reporter.ExpectLatency("a->b", 7*time.Second)
+	require.Equal(t, int64(7000), reporter.Report().Lanes[0].ExpectedE2ELatencyMs)
+}
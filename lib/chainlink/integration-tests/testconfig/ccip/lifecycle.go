@@ -0,0 +1,174 @@
+package ccip
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	"github.com/pelletier/go-toml/v2"
+)
+
+const DEFAULT_LIFECYCLE_STATE_DIR = ".ccip-environments"
+
+// Lifecycle controls whether the environment outlives the test and whether an earlier one is reused.
+type Lifecycle struct {
+	KeepAlive          *bool     `toml:",omitempty"`
+	ReuseEnvironmentID *string   `toml:",omitempty"`
+	TTL                *Duration `toml:",omitempty"`
+	// TeardownOnFailure defaults to true, set it to false to keep failed environments for debugging
+	TeardownOnFailure *bool `toml:",omitempty"`
+	// StateDir is where the EnvState of kept alive environments is stored for later reuse
+	StateDir *string `toml:",omitempty"`
+}
+
+func (l *Lifecycle) GetStateDir() string {
+	if l == nil || pointer.GetString(l.StateDir) == "" {
+		return DEFAULT_LIFECYCLE_STATE_DIR
+	}
+	return *l.StateDir
+}
+
+// ShouldTeardown returns whether the environment has to be removed after the test.
+// KeepAlive and reused environments are never torn down, failed tests follow TeardownOnFailure.
+func (l *Lifecycle) ShouldTeardown(testFailed bool) bool {
+	if l == nil {
+		return true
+	}
+	if pointer.GetBool(l.KeepAlive) || pointer.GetString(l.ReuseEnvironmentID) != "" {
+		return false
+	}
+	if testFailed && l.TeardownOnFailure != nil {
+		return *l.TeardownOnFailure
+	}
+	return true
+}
+
+// MayKeepEnvironment returns whether the environment outlives the test in at least one outcome, in which case
+// its containers can't be left to Ryuk and the caller tears it down according to ShouldTeardown.
+func (l *Lifecycle) MayKeepEnvironment() bool {
+	return !l.ShouldTeardown(true)
+}
+
+// StatePath returns where the EnvState of the environment is stored.
+func (l *Lifecycle) StatePath(environmentID string) string {
+	return filepath.Join(l.GetStateDir(), environmentID+".json")
+}
+
+// EnvironmentID returns the ID the environment is stored under: ReuseEnvironmentID for a reused
+// environment, otherwise derived from the Fingerprint so an equivalent config finds it again.
+func (o *Config) EnvironmentID() (string, error) {
+	if id := pointer.GetString(o.Lifecycle.getReuseEnvironmentID()); id != "" {
+		return id, nil
+	}
+	fingerprint, err := o.Fingerprint()
+	if err != nil {
+		return "", err
+	}
+	return "env-" + fingerprint[:12], nil
+}
+
+func (l *Lifecycle) getReuseEnvironmentID() *string {
+	if l == nil {
+		return nil
+	}
+	return l.ReuseEnvironmentID
+}
+
+// Fingerprint returns a hash of the config that identifies the environment it describes.
+// Config fields tagged `fingerprint:"ignore"` don't affect the environment and are left out.
+func (o *Config) Fingerprint() (string, error) {
+	content, err := o.fingerprintTOML()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func (o *Config) fingerprintTOML() ([]byte, error) {
+	c := *o
+	v := reflect.ValueOf(&c).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).Tag.Get("fingerprint") == "ignore" {
+			v.Field(i).Set(reflect.Zero(v.Field(i).Type()))
+		}
+	}
+	return toml.Marshal(c)
+}
+
+// SaveEnvironmentConfig stores the state of a kept alive environment, see SaveState, so a later run can reuse it.
+func (o *Config) SaveEnvironmentConfig(environmentID string, state EnvState) error {
+	return o.SaveState(o.Lifecycle.StatePath(environmentID), state)
+}
+
+// LoadReusedEnvironment returns the stored state of the environment set by ReuseEnvironmentID, failing
+// with a diff if it was created from a different config. It returns nil if no environment is reused.
+func (o *Config) LoadReusedEnvironment() (*EnvState, error) {
+	id := pointer.GetString(o.Lifecycle.getReuseEnvironmentID())
+	if id == "" {
+		return nil, nil
+	}
+	path := o.Lifecycle.StatePath(id)
+	state, err := o.LoadState(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("Lifecycle.ReuseEnvironmentID: no stored state for environment %s in %s", id, o.Lifecycle.GetStateDir())
+	}
+	if err != nil {
+		return nil, fieldError("Lifecycle.ReuseEnvironmentID", err)
+	}
+	return state, nil
+}
+
+func (o *Config) validateLifecycle() error {
+	l := o.Lifecycle
+	if l == nil {
+		return nil
+	}
+	if l.TTL != nil {
+		if !pointer.GetBool(l.KeepAlive) {
+			return fmt.Errorf("Lifecycle.TTL only applies when KeepAlive is set")
+		}
+		if l.TTL.Duration <= 0 {
+			return fmt.Errorf("Lifecycle.TTL must be positive")
+		}
+	}
+	// the stored state is only read by LoadReusedEnvironment, Validate doesn't touch the file system
+	if id := pointer.GetString(l.ReuseEnvironmentID); id != "" && (filepath.Base(id) != id || id == "." || id == "..") {
+		return fmt.Errorf("Lifecycle.ReuseEnvironmentID must be a plain environment ID, got %q", id)
+	}
+	return nil
+}
+
+// diffLines returns the lines only in old prefixed with "-" and the lines only in new prefixed with "+".
+func diffLines(old, new string) string {
+	oldLines := make(map[string]int)
+	for _, line := range strings.Split(old, "\n") {
+		oldLines[line]++
+	}
+	newLines := make(map[string]int)
+	for _, line := range strings.Split(new, "\n") {
+		newLines[line]++
+	}
+	var diff []string
+	for _, line := range strings.Split(old, "\n") {
+		if newLines[line] > 0 {
+			newLines[line]--
+			continue
+		}
+		diff = append(diff, "- "+line)
+	}
+	for _, line := range strings.Split(new, "\n") {
+		if oldLines[line] > 0 {
+			oldLines[line]--
+			continue
+		}
+		diff = append(diff, "+ "+line)
+	}
+	return strings.Join(diff, "\n")
+}
@@ -0,0 +1,69 @@
+package ccip
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldTeardown(t *testing.T) {
+	var unset *Lifecycle
+	require.True(t, unset.ShouldTeardown(true))
+	require.False(t, (&Lifecycle{KeepAlive: pointer.ToBool(true)}).ShouldTeardown(false))
+	require.False(t, (&Lifecycle{ReuseEnvironmentID: pointer.ToString("env")}).ShouldTeardown(false))
+	require.False(t, (&Lifecycle{TeardownOnFailure: pointer.ToBool(false)}).ShouldTeardown(true))
+	require.True(t, (&Lifecycle{TeardownOnFailure: pointer.ToBool(false)}).ShouldTeardown(false))
+}
+
+func TestReuseEnvironmentRequiresMatchingConfig(t *testing.T) {
+	dir := t.TempDir()
+	created := &Config{
+		HomeChainSelector: pointer.ToString("3379446385462418246"),
+		Lifecycle:         &Lifecycle{KeepAlive: pointer.ToBool(true), StateDir: pointer.ToString(dir)},
+	}
+	id, err := created.EnvironmentID()
+	require.NoError(t, err)
+	require.NoError(t, created.SaveEnvironmentConfig(id, EnvState{JDGRPC: "jd:42242"}))
+	require.FileExists(t, filepath.Join(dir, id+".json"))
+
+	reused := &Config{
+		HomeChainSelector: pointer.ToString("3379446385462418246"),
+		Lifecycle:         &Lifecycle{ReuseEnvironmentID: pointer.ToString(id), StateDir: pointer.ToString(dir)},
+	}
+	require.NoError(t, reused.validateLifecycle())
+	state, err := reused.LoadReusedEnvironment()
+	require.NoError(t, err)
+	require.Equal(t, "jd:42242", state.JDGRPC)
+	reusedID, err := reused.EnvironmentID()
+	require.NoError(t, err)
+	require.Equal(t, id, reusedID)
+
+	reused.HomeChainSelector = pointer.ToString("12922642891491394802")
+	// Validate stays pure, the mismatch is found when loading the environment
+	require.NoError(t, reused.validateLifecycle())
+	_, err = reused.LoadReusedEnvironment()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "- HomeChainSelector = '3379446385462418246'")
+	require.Contains(t, err.Error(), "+ HomeChainSelector = '12922642891491394802'")
+
+	reused.Lifecycle.ReuseEnvironmentID = pointer.ToString("missing")
+	_, err = reused.LoadReusedEnvironment()
+	require.ErrorContains(t, err, "no stored state for environment missing")
+
+	reused.Lifecycle.ReuseEnvironmentID = pointer.ToString("../missing")
+	require.ErrorContains(t, reused.validateLifecycle(), "must be a plain environment ID")
+
+	state, err = (&Config{}).LoadReusedEnvironment()
+	require.NoError(t, err)
+	require.Nil(t, state)
+}
+
+func TestMayKeepEnvironment(t *testing.T) {
+	var unset *Lifecycle
+	require.False(t, unset.MayKeepEnvironment())
+	require.True(t, (&Lifecycle{KeepAlive: pointer.ToBool(true)}).MayKeepEnvironment())
+	require.True(t, (&Lifecycle{TeardownOnFailure: pointer.ToBool(false)}).MayKeepEnvironment())
+	require.False(t, (&Lifecycle{TeardownOnFailure: pointer.ToBool(true)}).MayKeepEnvironment())
+}
@@ -0,0 +1,78 @@
+package ccip
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// defaults mirror DefaultFeeQuoterDestChainConfig in the ccip deployment changesets
+	DEFAULT_MAX_DATA_BYTES               uint32 = 256
+	DEFAULT_MAX_PER_MSG_GAS_LIMIT        uint64 = 3_000_000
+	DEFAULT_MAX_NUMBER_OF_TOKENS_PER_MSG uint16 = 10
+)
+
+// MessageLimits overrides the static per-message limits the onramp/fee quoter is deployed with.
+type MessageLimits struct {
+	MaxDataBytes            *uint32 `toml:",omitempty"`
+	MaxPerMsgGasLimit       *uint64 `toml:",omitempty"`
+	MaxNumberOfTokensPerMsg *uint16 `toml:",omitempty"`
+	// AllowOverLimitMessages must be set by tests that intentionally send messages exceeding the limits
+	AllowOverLimitMessages *bool `toml:",omitempty"`
+}
+
+func (m *MessageLimits) GetMaxDataBytes() uint32 {
+	if m == nil || m.MaxDataBytes == nil {
+		return DEFAULT_MAX_DATA_BYTES
+	}
+	return *m.MaxDataBytes
+}
+
+func (m *MessageLimits) GetMaxPerMsgGasLimit() uint64 {
+	if m == nil || m.MaxPerMsgGasLimit == nil {
+		return DEFAULT_MAX_PER_MSG_GAS_LIMIT
+	}
+	return *m.MaxPerMsgGasLimit
+}
+
+func (m *MessageLimits) GetMaxNumberOfTokensPerMsg() uint16 {
+	if m == nil || m.MaxNumberOfTokensPerMsg == nil {
+		return DEFAULT_MAX_NUMBER_OF_TOKENS_PER_MSG
+	}
+	return *m.MaxNumberOfTokensPerMsg
+}
+
+func (m *MessageLimits) IsOverLimitAllowed() bool {
+	return m != nil && m.AllowOverLimitMessages != nil && *m.AllowOverLimitMessages
+}
+
+func (m *MessageLimits) Validate() error {
+	if m.MaxDataBytes != nil && *m.MaxDataBytes == 0 {
+		return fieldError("MessageLimits.MaxDataBytes", withKind(ErrInvalidValue, fmt.Errorf("must be greater than 0")))
+	}
+	if m.MaxPerMsgGasLimit != nil && *m.MaxPerMsgGasLimit == 0 {
+		return fieldError("MessageLimits.MaxPerMsgGasLimit", withKind(ErrInvalidValue, fmt.Errorf("must be greater than 0")))
+	}
+	// the fee quoter stores the limit as a uint32
+	if m.GetMaxPerMsgGasLimit() > math.MaxUint32 {
+		return fieldError("MessageLimits.MaxPerMsgGasLimit", withKind(ErrInvalidValue, fmt.Errorf("must be at most %d, got %d", uint64(math.MaxUint32), m.GetMaxPerMsgGasLimit())))
+	}
+	return nil
+}
+
+// validateLoadProfile checks that the messages the load profile sends fit within the limits,
+// unless the test explicitly allows over-limit messages.
+func (m *MessageLimits) validateLoadProfile(profile *LoadProfile) error {
+	if profile == nil || m.IsOverLimitAllowed() {
+		return nil
+	}
+	if size := profile.GetMessageSizeBytes(); size > m.GetMaxDataBytes() {
+		return fieldError("LoadProfile.MessageSizeBytes", withKind(ErrInvalidValue, fmt.Errorf("%d exceeds MessageLimits.MaxDataBytes (%d); "+
+			"set MessageLimits.AllowOverLimitMessages = true if this is intended", size, m.GetMaxDataBytes())))
+	}
+	if tokens := profile.GetTokensPerMessage(); tokens > m.GetMaxNumberOfTokensPerMsg() {
+		return fieldError("LoadProfile.TokensPerMessage", withKind(ErrInvalidValue, fmt.Errorf("%d exceeds MessageLimits.MaxNumberOfTokensPerMsg (%d); "+
+			"set MessageLimits.AllowOverLimitMessages = true if this is intended", tokens, m.GetMaxNumberOfTokensPerMsg())))
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package ccip
+
+import (
+	"math"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageLimitsDefaults(t *testing.T) {
+	var unset *MessageLimits
+	require.Equal(t, DEFAULT_MAX_DATA_BYTES, unset.GetMaxDataBytes())
+	require.Equal(t, DEFAULT_MAX_PER_MSG_GAS_LIMIT, unset.GetMaxPerMsgGasLimit())
+	require.Equal(t, DEFAULT_MAX_NUMBER_OF_TOKENS_PER_MSG, unset.GetMaxNumberOfTokensPerMsg())
+	require.False(t, unset.IsOverLimitAllowed())
+	require.NoError(t, (&MessageLimits{}).Validate())
+}
+
+func TestMessageLimitsValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		limits *MessageLimits
+		err    string
+	}{
+		{name: "zero data bytes", limits: &MessageLimits{MaxDataBytes: pointer.ToUint32(0)}, err: "MessageLimits.MaxDataBytes: must be greater than 0"},
+		{name: "zero gas limit", limits: &MessageLimits{MaxPerMsgGasLimit: pointer.ToUint64(0)}, err: "MessageLimits.MaxPerMsgGasLimit: must be greater than 0"},
+		{name: "gas limit over uint32", limits: &MessageLimits{MaxPerMsgGasLimit: pointer.ToUint64(math.MaxUint32 + 1)}, err: "MessageLimits.MaxPerMsgGasLimit: must be at most 4294967295"},
+		{name: "gas limit at uint32", limits: &MessageLimits{MaxPerMsgGasLimit: pointer.ToUint64(math.MaxUint32)}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.limits.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
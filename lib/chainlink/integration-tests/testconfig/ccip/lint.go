@@ -0,0 +1,322 @@
+package ccip
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	WARNING_SEVERITY_INFO    = "info"
+	WARNING_SEVERITY_WARNING = "warning"
+
+	LINT_SINGLE_BOOTSTRAP       = "SINGLE_BOOTSTRAP"
+	LINT_NO_BOOTSTRAP           = "NO_BOOTSTRAP"
+	LINT_NODES_NOT_3F_PLUS_1    = "NODES_NOT_3F_PLUS_1"
+	LINT_LINK_WITHOUT_LIQUIDITY = "LINK_WITHOUT_LIQUIDITY"
+	LINT_VERBOSE_TRACING_SOAK   = "VERBOSE_TRACING_SOAK"
+	LINT_RMN_TOO_FEW_NODES      = "RMN_TOO_FEW_NODES"
+	LINT_RMN_UNPINNED_IMAGE     = "RMN_UNPINNED_IMAGE"
+	LINT_JD_UNPINNED_IMAGE      = "JD_UNPINNED_IMAGE"
+	LINT_JD_LOCALHOST_ON_K8S    = "JD_LOCALHOST_ON_K8S"
+	LINT_SINGLE_NETWORK         = "SINGLE_NETWORK"
+	LINT_HOME_CHAIN_NOT_PRIVATE = "HOME_CHAIN_NOT_PRIVATE"
+	LINT_LOAD_WITHOUT_DURATION  = "LOAD_WITHOUT_DURATION"
+	LINT_HIGH_LOAD_FEW_NODES    = "HIGH_LOAD_FEW_NODES"
+	LINT_AUTO_SIZED             = "AUTO_SIZED"
+	LINT_UNCONFIRMED_REORG      = "UNCONFIRMED_REORG"
+
+	// SOAK_TEST_DURATION is the test duration from which a load test counts as a soak test
+	SOAK_TEST_DURATION = 24 * time.Hour
+)
+
+// Warning is a legal but most likely unintended configuration found by Lint.
+type Warning struct {
+	Code     string
+	Field    string
+	Message  string
+	Severity string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("[%s] %s %s: %s", w.Severity, w.Code, w.Field, w.Message)
+}
+
+type lintRule func(o *Config) *Warning
+
+var lintRules = []lintRule{
+	lintSingleBootstrap,
+	lintNoBootstrap,
+	lintNodesNot3FPlus1,
+	lintLinkWithoutLiquidity,
+	lintVerboseTracingSoak,
+	lintRMNTooFewNodes,
+	lintRMNUnpinnedImage,
+	lintJDUnpinnedImage,
+	lintJDLocalhostOnK8s,
+	lintSingleNetwork,
+	lintHomeChainNotPrivate,
+	lintLoadWithoutDuration,
+	lintHighLoadFewNodes,
+	lintAutoSized,
+	lintUnconfirmedReorg,
+}
+
+// Lint returns the warnings of every rule not listed in SuppressWarnings, sorted by code.
+// Unlike Validate's errors, warnings only fail the config if FailOnWarnings is set.
+func (o *Config) Lint() []Warning {
+	var warnings []Warning
+	for _, rule := range lintRules {
+		w := rule(o)
+		if w == nil || containsString(o.SuppressWarnings, w.Code) {
+			continue
+		}
+		warnings = append(warnings, *w)
+	}
+	sort.Slice(warnings, func(i, j int) bool { return warnings[i].Code < warnings[j].Code })
+	return warnings
+}
+
+func (o *Config) validateLint() error {
+	if !pointer.GetBool(o.FailOnWarnings) {
+		return nil
+	}
+	warnings := o.Lint()
+	if len(warnings) == 0 {
+		return nil
+	}
+	lines := make([]string, 0, len(warnings))
+	for _, w := range warnings {
+		lines = append(lines, w.String())
+	}
+	return fmt.Errorf("FailOnWarnings is set and the config has %d warnings:\n%s", len(warnings), strings.Join(lines, "\n"))
+}
+
+func lintSingleBootstrap(o *Config) *Warning {
+	if o.CLNode == nil || pointer.GetInt(o.CLNode.NoOfBootstraps) != 1 || o.CLNode.GetNoOfPluginNodes() < 10 {
+		return nil
+	}
+	return &Warning{
+		Code:     LINT_SINGLE_BOOTSTRAP,
+		Field:    "CLNode.NoOfBootstraps",
+		Message:  fmt.Sprintf("a single bootstrap node serves %d plugin nodes and is a single point of failure", o.CLNode.GetNoOfPluginNodes()),
+		Severity: WARNING_SEVERITY_WARNING,
+	}
+}
+
+func lintNoBootstrap(o *Config) *Warning {
+	if o.CLNode == nil || o.CLNode.NoOfBootstraps == nil || *o.CLNode.NoOfBootstraps != 0 || o.CLNode.GetNoOfPluginNodes() == 0 {
+		return nil
+	}
+	return &Warning{
+		Code:     LINT_NO_BOOTSTRAP,
+		Field:    "CLNode.NoOfBootstraps",
+		Message:  "plugin nodes are configured without a bootstrap node, OCR peers won't find each other",
+		Severity: WARNING_SEVERITY_WARNING,
+	}
+}
+
+func lintNodesNot3FPlus1(o *Config) *Warning {
+	n := o.CLNode.GetNoOfPluginNodes()
+	if n < 4 || (n-1)%3 == 0 || (o.CLNode != nil && o.CLNode.DONConfig != nil) {
+		return nil
+	}
+	return &Warning{
+		Code:     LINT_NODES_NOT_3F_PLUS_1,
+		Field:    "CLNode.NoOfPluginNodes",
+		Message:  fmt.Sprintf("%d plugin nodes tolerate no more faults than %d, the extra nodes only add cost", n, 3*((n-1)/3)+1),
+		Severity: WARNING_SEVERITY_INFO,
+	}
+}
+
+func lintLinkWithoutLiquidity(o *Config) *Warning {
+	link, ok := o.Tokens["LINK"]
+	if !ok || link == nil || len(link.InitialLiquidity) > 0 {
+		return nil
+	}
+	return &Warning{
+		Code:     LINT_LINK_WITHOUT_LIQUIDITY,
+		Field:    "Tokens.LINK.InitialLiquidity",
+		Message:  "LINK is configured but no pool liquidity is, LINK transfers and LINK paid fees will fail",
+		Severity: WARNING_SEVERITY_WARNING,
+	}
+}
+
+func lintVerboseTracingSoak(o *Config) *Warning {
+	if o.LoadProfile.GetTestDuration() < SOAK_TEST_DURATION {
+		return nil
+	}
+	for name := range o.PrivateEthereumNetworks {
+		if o.GetSethConfig(name).TracingLevel == SETH_TRACING_LEVEL_ALL {
+			return &Warning{
+				Code:     LINT_VERBOSE_TRACING_SOAK,
+				Field:    "SethConfig",
+				Message:  fmt.Sprintf("tracing all transactions on %s during a %s soak test produces huge logs", name, o.LoadProfile.GetTestDuration()),
+				Severity: WARNING_SEVERITY_WARNING,
+			}
+		}
+	}
+	return nil
+}
+
+func lintRMNTooFewNodes(o *Config) *Warning {
+	n := pointer.GetInt(o.RMNConfig.NoOfNodes)
+	if n == 0 || n >= 4 {
+		return nil
+	}
+	return &Warning{
+		Code:     LINT_RMN_TOO_FEW_NODES,
+		Field:    "RMNConfig.NoOfNodes",
+		Message:  fmt.Sprintf("%d RMN nodes can't tolerate a faulty node", n),
+		Severity: WARNING_SEVERITY_INFO,
+	}
+}
+
+func lintRMNUnpinnedImage(o *Config) *Warning {
+	for _, f := range []struct {
+		name  string
+		value *string
+	}{
+		{"RMNConfig.ProxyVersion", o.RMNConfig.ProxyVersion},
+		{"RMNConfig.AFNVersion", o.RMNConfig.AFNVersion},
+	} {
+		if pointer.GetString(f.value) == "latest" {
+			return &Warning{
+				Code:     LINT_RMN_UNPINNED_IMAGE,
+				Field:    f.name,
+				Message:  "the latest tag makes runs unreproducible, pin a version",
+				Severity: WARNING_SEVERITY_INFO,
+			}
+		}
+	}
+	return nil
+}
+
+func lintJDUnpinnedImage(o *Config) *Warning {
+	if pointer.GetString(o.JobDistributorConfig.Version) != "latest" {
+		return nil
+	}
+	return &Warning{
+		Code:     LINT_JD_UNPINNED_IMAGE,
+		Field:    "JobDistributorConfig.Version",
+		Message:  "the latest tag makes runs unreproducible, pin a version",
+		Severity: WARNING_SEVERITY_INFO,
+	}
+}
+
+func lintJDLocalhostOnK8s(o *Config) *Warning {
+	if o.GetRuntime() != RUNTIME_K8S {
+		return nil
+	}
+	for _, f := range []struct {
+		name  string
+		value *string
+	}{
+		{"JobDistributorConfig.JDGRPC", o.JobDistributorConfig.JDGRPC},
+		{"JobDistributorConfig.JDWSRPC", o.JobDistributorConfig.JDWSRPC},
+	} {
+		e := pointer.GetString(f.value)
+		if strings.Contains(e, "localhost") || strings.Contains(e, "127.0.0.1") {
+			return &Warning{
+				Code:     LINT_JD_LOCALHOST_ON_K8S,
+				Field:    f.name,
+				Message:  fmt.Sprintf("%s is not reachable from nodes running in the cluster", e),
+				Severity: WARNING_SEVERITY_WARNING,
+			}
+		}
+	}
+	return nil
+}
+
+func lintSingleNetwork(o *Config) *Warning {
+	if len(o.PrivateEthereumNetworks) != 1 {
+		return nil
+	}
+	return &Warning{
+		Code:     LINT_SINGLE_NETWORK,
+		Field:    "PrivateEthereumNetworks",
+		Message:  "only one private network is configured, there are no lanes between private chains",
+		Severity: WARNING_SEVERITY_INFO,
+	}
+}
+
+func lintHomeChainNotPrivate(o *Config) *Warning {
+	home := pointer.GetString(o.HomeChainSelector)
+	if home == "" || len(o.PrivateEthereumNetworks) == 0 {
+		return nil
+	}
+	homeSelector, err := o.ResolveChainSelector(home)
+	if err != nil {
+		return nil
+	}
+	for name := range o.PrivateEthereumNetworks {
+		if selector, err := o.ResolveChainSelector(name); err == nil && selector == homeSelector {
+			return nil
+		}
+	}
+	return &Warning{
+		Code:     LINT_HOME_CHAIN_NOT_PRIVATE,
+		Field:    "HomeChainSelector",
+		Message:  fmt.Sprintf("home chain %s is not one of the private networks", home),
+		Severity: WARNING_SEVERITY_WARNING,
+	}
+}
+
+func lintLoadWithoutDuration(o *Config) *Warning {
+	if o.LoadProfile.GetMessagesPerSecond() == 0 || o.LoadProfile.GetTestDuration() > 0 {
+		return nil
+	}
+	return &Warning{
+		Code:     LINT_LOAD_WITHOUT_DURATION,
+		Field:    "LoadProfile.TestDuration",
+		Message:  "a message rate is set without a test duration, the load test runs until the overall test timeout",
+		Severity: WARNING_SEVERITY_WARNING,
+	}
+}
+
+func lintHighLoadFewNodes(o *Config) *Warning {
+	if o.LoadProfile.GetMessagesPerSecond() <= 10 || o.CLNode.GetNoOfPluginNodes() >= 4 {
+		return nil
+	}
+	return &Warning{
+		Code:     LINT_HIGH_LOAD_FEW_NODES,
+		Field:    "LoadProfile.MessagesPerSecond",
+		Message:  fmt.Sprintf("%.1f messages per second with %d plugin nodes measures the nodes rather than CCIP", o.LoadProfile.GetMessagesPerSecond(), o.CLNode.GetNoOfPluginNodes()),
+		Severity: WARNING_SEVERITY_INFO,
+	}
+}
+
+func lintAutoSized(o *Config) *Warning {
+	decisions := o.SizingDecisions()
+	if len(decisions) == 0 {
+		return nil
+	}
+	sized := make([]string, 0, len(decisions))
+	for _, decision := range decisions {
+		sized = append(sized, decision.String())
+	}
+	return &Warning{
+		Code:     LINT_AUTO_SIZED,
+		Field:    "AutoSize",
+		Message:  fmt.Sprintf("sized for %.1f messages per second: %s", o.LoadProfile.GetMessagesPerSecond(), strings.Join(sized, ", ")),
+		Severity: WARNING_SEVERITY_INFO,
+	}
+}
+
+// lintUnconfirmedReorg flags sends that don't wait for receipts on the chain FinalityViolation reorgs,
+// they count as succeeded even when the reorg drops them.
+func lintUnconfirmedReorg(o *Config) *Warning {
+	violation, ok, err := o.GetFinalityViolation()
+	if !ok || err != nil || o.GetConfirmationDepth(violation.ChainSelector) > 0 {
+		return nil
+	}
+	return &Warning{
+		Code:     LINT_UNCONFIRMED_REORG,
+		Field:    "ConfirmationDepth",
+		Message:  fmt.Sprintf("confirmation depth 0 on %s, which FinalityViolation reorgs, counts sends dropped by the reorg as succeeded", chainName(violation.ChainSelector)),
+		Severity: WARNING_SEVERITY_WARNING,
+	}
+}
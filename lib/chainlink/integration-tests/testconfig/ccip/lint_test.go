@@ -0,0 +1,92 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const lintBaseTOML = `
+HomeChainSelector = '3379446385462418246'
+
+[CLNode]
+NoOfPluginNodes = 4
+NoOfBootstraps = 1
+
+[PrivateEthereumNetworks.SIMULATED_1.EthereumChainConfig]
+chain_id = 1337
+
+[PrivateEthereumNetworks.SIMULATED_2.EthereumChainConfig]
+chain_id = 2337
+`
+
+func lintConfig(t *testing.T, extra string) *Config {
+	t.Helper()
+	var config Config
+	require.NoError(t, toml.Unmarshal([]byte(lintBaseTOML), &config))
+	require.NoError(t, toml.Unmarshal([]byte(extra), &config))
+	return &config
+}
+
+func lintCodes(warnings []Warning) []string {
+	codes := make([]string, 0, len(warnings))
+	for _, w := range warnings {
+		codes = append(codes, w.Code)
+	}
+	return codes
+}
+
+func TestLintBaseConfigHasNoWarnings(t *testing.T) {
+	require.Empty(t, lintConfig(t, "").Lint())
+}
+
+func TestLintRules(t *testing.T) {
+	tests := []struct {
+		code   string
+		extra  string
+		mutate func(*Config)
+	}{
+		{LINT_SINGLE_BOOTSTRAP, "[CLNode]\nNoOfPluginNodes = 10\n", nil},
+		{LINT_NO_BOOTSTRAP, "[CLNode]\nNoOfBootstraps = 0\n", nil},
+		{LINT_NODES_NOT_3F_PLUS_1, "[CLNode]\nNoOfPluginNodes = 5\n", nil},
+		{LINT_LINK_WITHOUT_LIQUIDITY, "[Tokens.LINK]\nDecimals = 18\n", nil},
+		{LINT_VERBOSE_TRACING_SOAK, "[LoadProfile]\nMessagesPerSecond = 1.0\nTestDuration = '48h'\n[SethConfig.Default]\nTracingLevel = 'ALL'\n", nil},
+		{LINT_RMN_TOO_FEW_NODES, "[RMNConfig]\nNoOfNodes = 2\n", nil},
+		{LINT_RMN_UNPINNED_IMAGE, "[RMNConfig]\nAFNVersion = 'latest'\n", nil},
+		{LINT_JD_UNPINNED_IMAGE, "[JobDistributorConfig]\nVersion = 'latest'\n", nil},
+		{LINT_JD_LOCALHOST_ON_K8S, "Runtime = 'k8s'\n[JobDistributorConfig]\nJDGRPC = 'localhost:14231'\n", nil},
+		{LINT_SINGLE_NETWORK, "", func(c *Config) { delete(c.PrivateEthereumNetworks, "SIMULATED_2") }},
+		{LINT_HOME_CHAIN_NOT_PRIVATE, "HomeChainSelector = '16015286601757825753'\n", nil},
+		{LINT_LOAD_WITHOUT_DURATION, "[LoadProfile]\nMessagesPerSecond = 1.0\n", nil},
+		{LINT_HIGH_LOAD_FEW_NODES, "[CLNode]\nNoOfPluginNodes = 1\n[LoadProfile]\nMessagesPerSecond = 20.0\nTestDuration = '1h'\n", nil},
+		{LINT_AUTO_SIZED, "AutoSize = true\n[LoadProfile]\nMessagesPerSecond = 20.0\nTestDuration = '1h'\n", nil},
+		{LINT_UNCONFIRMED_REORG, "[ConfirmationDepth]\nSIMULATED_1 = 0\n[FinalityViolation]\nChain = 'SIMULATED_1'\n", nil},
+	}
+	require.Len(t, tests, len(lintRules))
+	for _, tc := range tests {
+		t.Run(tc.code, func(t *testing.T) {
+			config := lintConfig(t, tc.extra)
+			if tc.mutate != nil {
+				tc.mutate(config)
+			}
+			warnings := config.Lint()
+			require.Contains(t, lintCodes(warnings), tc.code)
+
+			config.SuppressWarnings = []string{tc.code}
+			require.NotContains(t, lintCodes(config.Lint()), tc.code)
+		})
+	}
+}
+
+func TestLintFailOnWarnings(t *testing.T) {
+	config := lintConfig(t, "[RMNConfig]\nNoOfNodes = 2\n")
+	require.NoError(t, config.validateLint())
+
+	failOnWarnings := true
+	config.FailOnWarnings = &failOnWarnings
+	require.ErrorContains(t, config.validateLint(), LINT_RMN_TOO_FEW_NODES)
+
+	config.SuppressWarnings = []string{LINT_RMN_TOO_FEW_NODES}
+	require.NoError(t, config.validateLint())
+}
@@ -0,0 +1,255 @@
+package ccip
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"time"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	LOAD_PATTERN_CONSTANT = "constant"
+	LOAD_PATTERN_BURST    = "burst"
+	LOAD_PATTERN_SINE     = "sine"
+	LOAD_PATTERN_POISSON  = "poisson"
+
+	// SINE_LOAD_PERIOD is the period of the sine pattern, its rate swings by SINE_LOAD_AMPLITUDE around
+	// MessagesPerSecond
+	SINE_LOAD_PERIOD    = 10 * time.Minute
+	SINE_LOAD_AMPLITUDE = 0.5
+)
+
+var loadPatterns = []string{LOAD_PATTERN_CONSTANT, LOAD_PATTERN_BURST, LOAD_PATTERN_SINE, LOAD_PATTERN_POISSON}
+
+// LoadProfile describes the traffic sent on every lane during a load test.
+type LoadProfile struct {
+	MessagesPerSecond *float64  `toml:",omitempty"`
+	TestDuration      *Duration `toml:",omitempty"`
+	// TokenAmountPerMessage is the amount of each token transferred per message, in the token's smallest unit
+	TokenAmountPerMessage *big.Int `toml:",omitempty"`
+	MessageSizeBytes      *uint32  `toml:",omitempty"`
+	TokensPerMessage      *uint16  `toml:",omitempty"`
+	ConcurrentSenders     *int     `toml:",omitempty"`
+	// Pattern is how sends are spread over time, one of the LOAD_PATTERN_* constants. MessagesPerSecond
+	// is the mean rate of every pattern.
+	Pattern *string `toml:",omitempty"`
+	// BurstSize messages are sent back to back every BurstInterval by the burst pattern, the rest of
+	// MessagesPerSecond is sent at a constant rate in between
+	BurstSize     *int      `toml:",omitempty"`
+	BurstInterval *Duration `toml:",omitempty"`
+	// JitterPct randomly stretches or shrinks every delay by up to this percentage
+	JitterPct *float64 `toml:",omitempty"`
+}
+
+func (l *LoadProfile) GetMessagesPerSecond() float64 {
+	if l == nil || l.MessagesPerSecond == nil {
+		return 0
+	}
+	return *l.MessagesPerSecond
+}
+
+func (l *LoadProfile) GetTestDuration() time.Duration {
+	if l == nil || l.TestDuration == nil {
+		return 0
+	}
+	return l.TestDuration.Duration
+}
+
+func (l *LoadProfile) GetTokenAmountPerMessage() *big.Int {
+	if l == nil || l.TokenAmountPerMessage == nil {
+		return big.NewInt(0)
+	}
+	return new(big.Int).Set(l.TokenAmountPerMessage)
+}
+
+func (l *LoadProfile) GetMessageSizeBytes() uint32 {
+	if l == nil || l.MessageSizeBytes == nil {
+		return 0
+	}
+	return *l.MessageSizeBytes
+}
+
+func (l *LoadProfile) GetTokensPerMessage() uint16 {
+	if l == nil || l.TokensPerMessage == nil {
+		return 0
+	}
+	return *l.TokensPerMessage
+}
+
+// GetConcurrentSenders returns the number of goroutines sending messages per chain, 1 unless set.
+func (l *LoadProfile) GetConcurrentSenders() int {
+	if l == nil || l.ConcurrentSenders == nil {
+		return 1
+	}
+	return *l.ConcurrentSenders
+}
+
+// GetPattern returns Pattern, LOAD_PATTERN_CONSTANT unless set.
+func (l *LoadProfile) GetPattern() string {
+	if l == nil || pointer.GetString(l.Pattern) == "" {
+		return LOAD_PATTERN_CONSTANT
+	}
+	return *l.Pattern
+}
+
+func (l *LoadProfile) GetBurstInterval() time.Duration {
+	if l == nil || l.BurstInterval == nil {
+		return 0
+	}
+	return l.BurstInterval.Duration
+}
+
+// SendPacer spaces the sends of a sender according to the load profile's pattern.
+type SendPacer struct {
+	profile *LoadProfile
+	// rate is the sender's share of MessagesPerSecond, baseRate its share of what the burst pattern sends
+	// between bursts, and burstSize its share of BurstSize
+	rate      float64
+	baseRate  float64
+	burstSize int
+	// elapsed is the time of the last send since the first one
+	elapsed time.Duration
+	// nextBurst is when the next burst starts and burstLeft how many sends of the current one are left
+	nextBurst time.Duration
+	burstLeft int
+	// nextBase is when the next send at the base rate is due, zero until the first one is drawn
+	nextBase time.Duration
+}
+
+// NewSendPacer returns the pacer of the sender-th of the ConcurrentSenders senders of a chain, starting at
+// the beginning of the test. Every sender needs its own, each paces its share of MessagesPerSecond and
+// BurstSize so the senders together send at the configured rate.
+func (o *Config) NewSendPacer(sender int) *SendPacer {
+	senders := o.LoadProfile.GetConcurrentSenders()
+	rate := o.LoadProfile.GetMessagesPerSecond()
+	pacer := &SendPacer{profile: o.LoadProfile, rate: rate / float64(senders), baseRate: rate / float64(senders)}
+	if o.LoadProfile.GetPattern() == LOAD_PATTERN_BURST {
+		burstSize := pointer.GetInt(o.LoadProfile.BurstSize)
+		pacer.baseRate = (rate - float64(burstSize)/o.LoadProfile.GetBurstInterval().Seconds()) / float64(senders)
+		// the first senders send one more message per burst when it doesn't split evenly
+		pacer.burstSize = burstSize / senders
+		if sender < burstSize%senders {
+			pacer.burstSize++
+		}
+	}
+	return pacer
+}
+
+// NewSenderRand returns the random stream of the sender-th sender, which its pacer draws from. Every sender
+// has its own stream of RandomSeed, so the sends of a run can be reproduced however the senders are scheduled.
+func (o *Config) NewSenderRand(sender int) *rand.Rand {
+	return o.NewRand(fmt.Sprintf("%s/%d", RAND_COMPONENT_LOAD, sender))
+}
+
+// NextSendDelay returns how long to wait before the next send. rng drives the poisson pattern and the
+// jitter, pass the sender's Config.NewSenderRand.
+func (p *SendPacer) NextSendDelay(rng *rand.Rand) time.Duration {
+	rate := p.rate
+	var delay time.Duration
+	switch p.profile.GetPattern() {
+	case LOAD_PATTERN_POISSON:
+		delay = secondsToDuration(rng.ExpFloat64() / rate)
+	case LOAD_PATTERN_SINE:
+		phase := 2 * math.Pi * float64(p.elapsed%SINE_LOAD_PERIOD) / float64(SINE_LOAD_PERIOD)
+		delay = p.jitter(rng, secondsToDuration(1/(rate*(1+SINE_LOAD_AMPLITUDE*math.Sin(phase)))))
+	case LOAD_PATTERN_BURST:
+		delay = p.nextBurstDelay(rng)
+	default:
+		delay = p.jitter(rng, secondsToDuration(1/rate))
+	}
+	p.elapsed += delay
+	return delay
+}
+
+// nextBurstDelay sends the rest of the current burst right away, then whichever comes first of the
+// next burst and the next send at the base rate. The base sends keep their own schedule, so bursts
+// don't push them back. A sender without a share of the bursts only sends at the base rate.
+func (p *SendPacer) nextBurstDelay(rng *rand.Rand) time.Duration {
+	if p.burstLeft > 0 {
+		p.burstLeft--
+		return 0
+	}
+	if p.baseRate > 0 {
+		if p.nextBase == 0 {
+			p.nextBase = p.jitter(rng, secondsToDuration(1/p.baseRate))
+		}
+		if p.burstSize == 0 || p.nextBase < p.nextBurst {
+			delay := p.nextBase - p.elapsed
+			p.nextBase += p.jitter(rng, secondsToDuration(1/p.baseRate))
+			return delay
+		}
+	}
+	delay := p.nextBurst - p.elapsed
+	p.nextBurst += p.profile.GetBurstInterval()
+	p.burstLeft = p.burstSize - 1
+	return delay
+}
+
+func (p *SendPacer) jitter(rng *rand.Rand, delay time.Duration) time.Duration {
+	pct := pointer.GetFloat64(p.profile.JitterPct)
+	if pct == 0 {
+		return delay
+	}
+	return time.Duration(float64(delay) * (1 + pct/100*(2*rng.Float64()-1)))
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
+
+func (l *LoadProfile) Validate() error {
+	if l.MessagesPerSecond == nil || *l.MessagesPerSecond <= 0 {
+		return fmt.Errorf("LoadProfile.MessagesPerSecond must be set and be positive")
+	}
+	if l.TestDuration == nil || l.TestDuration.Duration <= 0 {
+		return fmt.Errorf("LoadProfile.TestDuration must be set and be positive")
+	}
+	if l.TokenAmountPerMessage != nil && l.TokenAmountPerMessage.Sign() < 0 {
+		return fmt.Errorf("LoadProfile.TokenAmountPerMessage cannot be negative")
+	}
+	if l.ConcurrentSenders != nil && *l.ConcurrentSenders < 1 {
+		return fmt.Errorf("LoadProfile.ConcurrentSenders must be at least 1, got %d", *l.ConcurrentSenders)
+	}
+	return l.validatePattern()
+}
+
+func (l *LoadProfile) validatePattern() error {
+	pattern := l.GetPattern()
+	if !containsString(loadPatterns, pattern) {
+		return fieldError("LoadProfile.Pattern", fmt.Errorf("unknown value %q", pattern))
+	}
+	if pattern != LOAD_PATTERN_BURST && (l.BurstSize != nil || l.BurstInterval != nil) {
+		return fmt.Errorf("LoadProfile.BurstSize and BurstInterval are only used by the %s pattern, not %s", LOAD_PATTERN_BURST, pattern)
+	}
+	if l.JitterPct != nil {
+		if pattern == LOAD_PATTERN_POISSON {
+			return fmt.Errorf("LoadProfile.JitterPct can't be used with the %s pattern, its delays are random already", LOAD_PATTERN_POISSON)
+		}
+		if *l.JitterPct < 0 || *l.JitterPct >= 100 {
+			return fmt.Errorf("LoadProfile.JitterPct must be in [0, 100), got %g", *l.JitterPct)
+		}
+	}
+	if pattern != LOAD_PATTERN_BURST {
+		return nil
+	}
+	if l.BurstSize == nil || *l.BurstSize < 1 {
+		return fmt.Errorf("LoadProfile.BurstSize must be set and be at least 1 for the %s pattern", LOAD_PATTERN_BURST)
+	}
+	if l.GetBurstInterval() <= 0 {
+		return fmt.Errorf("LoadProfile.BurstInterval must be set and be positive for the %s pattern", LOAD_PATTERN_BURST)
+	}
+	burstRate := float64(*l.BurstSize) / l.GetBurstInterval().Seconds()
+	if burstRate > *l.MessagesPerSecond {
+		return fmt.Errorf("LoadProfile bursts of %d every %s send %.2f messages per second, more than MessagesPerSecond %g",
+			*l.BurstSize, l.GetBurstInterval(), burstRate, *l.MessagesPerSecond)
+	}
+	// the senders split the bursts, one without a share and no base rate would never send
+	if senders := l.GetConcurrentSenders(); *l.BurstSize < senders && burstRate >= *l.MessagesPerSecond {
+		return fmt.Errorf("LoadProfile.BurstSize (%d) is less than ConcurrentSenders (%d) and the bursts are all of MessagesPerSecond, some senders would never send",
+			*l.BurstSize, senders)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package ccip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+// simulateHour returns the delays of every send of the sender-th sender within an hour of the profile.
+func simulateHour(profile *LoadProfile, seed int64, sender int) []time.Duration {
+	cfg := &Config{LoadProfile: profile, RandomSeed: &seed}
+	pacer, rng := cfg.NewSendPacer(sender), cfg.NewSenderRand(sender)
+	var delays []time.Duration
+	for elapsed := time.Duration(0); ; {
+		delay := pacer.NextSendDelay(rng)
+		if elapsed += delay; elapsed >= time.Hour {
+			return delays
+		}
+		delays = append(delays, delay)
+	}
+}
+
+func TestSendPacerMeanRate(t *testing.T) {
+	for _, profile := range []*LoadProfile{
+		{MessagesPerSecond: pointer.ToFloat64(2)},
+		{MessagesPerSecond: pointer.ToFloat64(2), JitterPct: pointer.ToFloat64(30)},
+		{MessagesPerSecond: pointer.ToFloat64(2), Pattern: pointer.ToString(LOAD_PATTERN_POISSON)},
+		{MessagesPerSecond: pointer.ToFloat64(2), Pattern: pointer.ToString(LOAD_PATTERN_SINE), JitterPct: pointer.ToFloat64(10)},
+		{MessagesPerSecond: pointer.ToFloat64(2), Pattern: pointer.ToString(LOAD_PATTERN_BURST),
+			BurstSize: pointer.ToInt(30), BurstInterval: &Duration{Duration: time.Minute}},
+		{MessagesPerSecond: pointer.ToFloat64(0.5), Pattern: pointer.ToString(LOAD_PATTERN_BURST),
+			BurstSize: pointer.ToInt(30), BurstInterval: &Duration{Duration: time.Minute}},
+	} {
+		require.NoError(t, profile.validatePattern())
+		delays := simulateHour(profile, 1, 0)
+		rate := float64(len(delays)) / time.Hour.Seconds()
+		require.InDelta(t, profile.GetMessagesPerSecond(), rate, profile.GetMessagesPerSecond()*0.05,
+			"%s pattern sent %.3f messages per second", profile.GetPattern(), rate)
+		require.Equal(t, delays, simulateHour(profile, 1, 0), "%s pattern is deterministic for a seed", profile.GetPattern())
+	}
+}
+
+func TestSendPacersSplitTheRate(t *testing.T) {
+	for _, profile := range []*LoadProfile{
+		{MessagesPerSecond: pointer.ToFloat64(3), ConcurrentSenders: pointer.ToInt(3)},
+		{MessagesPerSecond: pointer.ToFloat64(3), ConcurrentSenders: pointer.ToInt(4), Pattern: pointer.ToString(LOAD_PATTERN_POISSON)},
+		{MessagesPerSecond: pointer.ToFloat64(2), ConcurrentSenders: pointer.ToInt(3), Pattern: pointer.ToString(LOAD_PATTERN_BURST),
+			BurstSize: pointer.ToInt(30), BurstInterval: &Duration{Duration: time.Minute}},
+		// 4 messages per burst split 2, 1, 1 between the senders
+		{MessagesPerSecond: pointer.ToFloat64(0.5), ConcurrentSenders: pointer.ToInt(3), Pattern: pointer.ToString(LOAD_PATTERN_BURST),
+			BurstSize: pointer.ToInt(4), BurstInterval: &Duration{Duration: 10 * time.Second}},
+	} {
+		require.NoError(t, profile.validatePattern())
+		sent := 0
+		for sender := 0; sender < profile.GetConcurrentSenders(); sender++ {
+			sent += len(simulateHour(profile, 1, sender))
+		}
+		rate := float64(sent) / time.Hour.Seconds()
+		require.InDelta(t, profile.GetMessagesPerSecond(), rate, profile.GetMessagesPerSecond()*0.05,
+			"%d senders of the %s pattern sent %.3f messages per second together", profile.GetConcurrentSenders(), profile.GetPattern(), rate)
+	}
+}
+
+func TestSendPacersDrawFromSeededStreams(t *testing.T) {
+	profile := &LoadProfile{MessagesPerSecond: pointer.ToFloat64(2), Pattern: pointer.ToString(LOAD_PATTERN_POISSON)}
+	delays := func(cfg *Config, sender int) []time.Duration {
+		pacer, rng := cfg.NewSendPacer(sender), cfg.NewSenderRand(sender)
+		var delays []time.Duration
+		for i := 0; i < 5; i++ {
+			delays = append(delays, pacer.NextSendDelay(rng))
+		}
+		return delays
+	}
+	cfg := &Config{LoadProfile: profile, RandomSeed: pointer.ToInt64(42)}
+	require.Equal(t, delays(cfg, 0), delays(&Config{LoadProfile: profile, RandomSeed: pointer.ToInt64(42)}, 0))
+	require.NotEqual(t, delays(cfg, 0), delays(cfg, 1), "every sender has its own stream")
+	require.NotEqual(t, delays(cfg, 0), delays(&Config{LoadProfile: profile, RandomSeed: pointer.ToInt64(43)}, 0))
+}
+
+func TestSendPacerBursts(t *testing.T) {
+	profile := &LoadProfile{
+		MessagesPerSecond: pointer.ToFloat64(0.5),
+		Pattern:           pointer.ToString(LOAD_PATTERN_BURST),
+		BurstSize:         pointer.ToInt(3),
+		BurstInterval:     &Duration{Duration: 6 * time.Second},
+	}
+	cfg := &Config{LoadProfile: profile, RandomSeed: pointer.ToInt64(1)}
+	pacer, rng := cfg.NewSendPacer(0), cfg.NewSenderRand(0)
+	var delays []time.Duration
+	for i := 0; i < 6; i++ {
+		delays = append(delays, pacer.NextSendDelay(rng))
+	}
+	require.Equal(t, []time.Duration{0, 0, 0, 6 * time.Second, 0, 0}, delays)
+
+	// the sine pattern swings around the mean rate
+	sine := (&Config{LoadProfile: &LoadProfile{MessagesPerSecond: pointer.ToFloat64(1), Pattern: pointer.ToString(LOAD_PATTERN_SINE)}}).NewSendPacer(0)
+	first := sine.NextSendDelay(rng)
+	require.Equal(t, time.Second, first)
+	sine.elapsed = SINE_LOAD_PERIOD / 4
+	require.Less(t, sine.NextSendDelay(rng), first)
+}
+
+func TestLoadProfilePatternValidation(t *testing.T) {
+	valid := func() *LoadProfile {
+		return &LoadProfile{
+			MessagesPerSecond: pointer.ToFloat64(1),
+			TestDuration:      &Duration{Duration: time.Hour},
+		}
+	}
+	minute := &Duration{Duration: time.Minute}
+	for name, tc := range map[string]struct {
+		modify func(l *LoadProfile)
+		err    string
+	}{
+		"unknown pattern": {func(l *LoadProfile) { l.Pattern = pointer.ToString("square") }, `LoadProfile.Pattern: unknown value "square" (How sends are spread over time; one of constant, burst, sine, poisson)`},
+		"burst fields without burst": {func(l *LoadProfile) { l.BurstSize = pointer.ToInt(5) },
+			"LoadProfile.BurstSize and BurstInterval are only used by the burst pattern, not constant"},
+		"burst without size": {func(l *LoadProfile) {
+			l.Pattern = pointer.ToString(LOAD_PATTERN_BURST)
+			l.BurstInterval = minute
+		}, "LoadProfile.BurstSize must be set and be at least 1 for the burst pattern"},
+		"burst without interval": {func(l *LoadProfile) {
+			l.Pattern = pointer.ToString(LOAD_PATTERN_BURST)
+			l.BurstSize = pointer.ToInt(5)
+		}, "LoadProfile.BurstInterval must be set and be positive for the burst pattern"},
+		"bursts over the mean rate": {func(l *LoadProfile) {
+			l.Pattern = pointer.ToString(LOAD_PATTERN_BURST)
+			l.BurstSize = pointer.ToInt(120)
+			l.BurstInterval = minute
+		}, "LoadProfile bursts of 120 every 1m0s send 2.00 messages per second, more than MessagesPerSecond 1"},
+		"senders without a share of the bursts": {func(l *LoadProfile) {
+			l.Pattern = pointer.ToString(LOAD_PATTERN_BURST)
+			l.BurstSize = pointer.ToInt(60)
+			l.BurstInterval = minute
+			l.ConcurrentSenders = pointer.ToInt(100)
+		}, "LoadProfile.BurstSize (60) is less than ConcurrentSenders (100) and the bursts are all of MessagesPerSecond, some senders would never send"},
+		"jitter with poisson": {func(l *LoadProfile) {
+			l.Pattern = pointer.ToString(LOAD_PATTERN_POISSON)
+			l.JitterPct = pointer.ToFloat64(10)
+		}, "LoadProfile.JitterPct can't be used with the poisson pattern, its delays are random already"},
+		"jitter out of range": {func(l *LoadProfile) { l.JitterPct = pointer.ToFloat64(100) }, "LoadProfile.JitterPct must be in [0, 100), got 100"},
+	} {
+		l := valid()
+		tc.modify(l)
+		require.EqualError(t, l.Validate(), tc.err, name)
+	}
+	require.NoError(t, valid().Validate())
+}
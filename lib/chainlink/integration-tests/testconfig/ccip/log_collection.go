@@ -0,0 +1,232 @@
+package ccip
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	LOG_TARGET_LOKI = "loki"
+	LOG_TARGET_FILE = "file"
+	LOG_TARGET_NONE = "none"
+
+	LOG_COMPONENT_NODES  = "nodes"
+	LOG_COMPONENT_JD     = "jd"
+	LOG_COMPONENT_RMN    = "rmn"
+	LOG_COMPONENT_CHAINS = "chains"
+
+	DEFAULT_LOG_FILE_DIR = "logs"
+
+	LOKI_PUSH_PATH = "/loki/api/v1/push"
+)
+
+// LOG_COMPONENTS are the components whose logs can be collected
+var LOG_COMPONENTS = []string{LOG_COMPONENT_NODES, LOG_COMPONENT_JD, LOG_COMPONENT_RMN, LOG_COMPONENT_CHAINS}
+
+// LogCollection decides where container logs are shipped on teardown and for which components.
+// Environment teardown ships them with Collect.
+type LogCollection struct {
+	Targets              []string `toml:",omitempty"`
+	FileDir              *string  `toml:",omitempty"`
+	CollectOnFailureOnly *bool    `toml:",omitempty"`
+	// component toggles, all components are collected unless disabled
+	Nodes  *bool `toml:",omitempty"`
+	JD     *bool `toml:",omitempty"`
+	RMN    *bool `toml:",omitempty"`
+	Chains *bool `toml:",omitempty"`
+}
+
+// GetTargets returns the log targets, defaulting to file when nothing is configured.
+func (l *LogCollection) GetTargets() []string {
+	if l == nil || len(l.Targets) == 0 {
+		return []string{LOG_TARGET_FILE}
+	}
+	if len(l.Targets) == 1 && l.Targets[0] == LOG_TARGET_NONE {
+		return nil
+	}
+	return l.Targets
+}
+
+func (l *LogCollection) GetFileDir() string {
+	if l == nil || pointer.GetString(l.FileDir) == "" {
+		return DEFAULT_LOG_FILE_DIR
+	}
+	return *l.FileDir
+}
+
+func (l *LogCollection) GetCollectOnFailureOnly() bool {
+	if l == nil {
+		return false
+	}
+	return pointer.GetBool(l.CollectOnFailureOnly)
+}
+
+func (l *LogCollection) componentEnabled(component string) bool {
+	if l == nil {
+		l = &LogCollection{}
+	}
+	var toggle *bool
+	switch component {
+	case LOG_COMPONENT_NODES:
+		toggle = l.Nodes
+	case LOG_COMPONENT_JD:
+		toggle = l.JD
+	case LOG_COMPONENT_RMN:
+		toggle = l.RMN
+	case LOG_COMPONENT_CHAINS:
+		toggle = l.Chains
+	default:
+		return false
+	}
+	return toggle == nil || *toggle
+}
+
+// ShouldCollect returns true if logs of the component (LOG_COMPONENT_*) have to be collected
+// for a test that failed or passed.
+func (l *LogCollection) ShouldCollect(component string, testFailed bool) bool {
+	if len(l.GetTargets()) == 0 {
+		return false
+	}
+	if l.GetCollectOnFailureOnly() && !testFailed {
+		return false
+	}
+	return l.componentEnabled(component)
+}
+
+func (l *LogCollection) Validate(observability *Observability) error {
+	seen := make(map[string]bool)
+	for _, target := range l.Targets {
+		switch target {
+		case LOG_TARGET_LOKI:
+			if observability.GetLokiEndpoint() == "" {
+				return fmt.Errorf("LogCollection.Targets contains %s but Observability.LokiEndpoint is not set", LOG_TARGET_LOKI)
+			}
+		case LOG_TARGET_FILE:
+			if pointer.GetString(l.FileDir) == "" {
+				return fmt.Errorf("LogCollection.Targets contains %s but FileDir is not set", LOG_TARGET_FILE)
+			}
+		case LOG_TARGET_NONE:
+			if len(l.Targets) > 1 {
+				return fmt.Errorf("LogCollection.Targets cannot combine %s with other targets", LOG_TARGET_NONE)
+			}
+		default:
+			return fmt.Errorf("LogCollection.Targets contains unknown target %q", target)
+		}
+		if seen[target] {
+			return fmt.Errorf("LogCollection.Targets contains %s more than once", target)
+		}
+		seen[target] = true
+	}
+	return nil
+}
+
+// ContainerLogs returns the logs of the containers of a component (LOG_COMPONENT_*), keyed by container name.
+type ContainerLogs func(ctx context.Context, component string) (map[string][]byte, error)
+
+// Collect ships the logs of the components ShouldCollect returns true for to every target. Files are written to
+// FileDir/<test name>/<container>.log, Loki streams are labelled with the test, component and container.
+func (l *LogCollection) Collect(ctx context.Context, testName string, testFailed bool, observability *Observability, logs ContainerLogs) error {
+	var errs error
+	for _, component := range LOG_COMPONENTS {
+		if !l.ShouldCollect(component, testFailed) {
+			continue
+		}
+		containers, err := logs(ctx, component)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("%s logs: %w", component, err))
+			continue
+		}
+		for _, target := range l.GetTargets() {
+			switch target {
+			case LOG_TARGET_FILE:
+				err = writeLogFiles(filepath.Join(l.GetFileDir(), strings.ReplaceAll(testName, "/", "_")), containers)
+			case LOG_TARGET_LOKI:
+				err = pushLogsToLoki(ctx, observability, map[string]string{"test": testName, "component": component}, containers)
+			}
+			if err != nil {
+				errs = errors.Join(errs, fmt.Errorf("%s logs to %s: %w", component, target, err))
+			}
+		}
+	}
+	return errs
+}
+
+func writeLogFiles(dir string, containers map[string][]byte) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("LogCollection.FileDir %s cannot be created: %w", dir, err)
+	}
+	for name, logs := range containers {
+		if err := os.WriteFile(filepath.Join(dir, name+".log"), logs, 0o600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type lokiPush struct {
+	Streams []lokiStream `json:"streams"`
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// pushLogsToLoki pushes the log lines of each container as a stream of its own. Loki needs the lines of a
+// stream in order, they are timestamped one nanosecond apart from now.
+func pushLogsToLoki(ctx context.Context, observability *Observability, labels map[string]string, containers map[string][]byte) error {
+	push := lokiPush{}
+	now := time.Now().UnixNano()
+	for name, logs := range containers {
+		stream := lokiStream{Stream: map[string]string{"container": name}}
+		for k, v := range labels {
+			stream.Stream[k] = v
+		}
+		for i, line := range strings.Split(strings.TrimRight(string(logs), "\n"), "\n") {
+			stream.Values = append(stream.Values, [2]string{strconv.FormatInt(now+int64(i), 10), line})
+		}
+		push.Streams = append(push.Streams, stream)
+	}
+	body, err := json.Marshal(push)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(observability.GetLokiEndpoint(), "/")+LOKI_PUSH_PATH, bytes.NewReader(body))
+	if err != nil {
+		return withKind(ErrInvalidEndpoint, fmt.Errorf("Observability.LokiEndpoint: %w", err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if tenant := observability.GetLokiTenant(); tenant != "" {
+		req.Header.Set("X-Scope-OrgID", tenant)
+	}
+	if auth := observability.GetLokiBasicAuth(); auth != "" {
+		credentials, err := auth.Value()
+		if err != nil {
+			return fieldError("Observability.LokiBasicAuth", err)
+		}
+		user, password, _ := strings.Cut(credentials, ":")
+		req.SetBasicAuth(user, password)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("loki responded with %s", resp.Status)
+	}
+	return nil
+}
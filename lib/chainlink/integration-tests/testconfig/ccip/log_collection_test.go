@@ -0,0 +1,104 @@
+package ccip
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogCollectionDefaults(t *testing.T) {
+	var unset *LogCollection
+	require.Equal(t, []string{LOG_TARGET_FILE}, unset.GetTargets())
+	require.Equal(t, DEFAULT_LOG_FILE_DIR, unset.GetFileDir())
+	require.False(t, unset.GetCollectOnFailureOnly())
+	for _, component := range LOG_COMPONENTS {
+		require.True(t, unset.ShouldCollect(component, false))
+	}
+	require.False(t, unset.ShouldCollect("unknown", true))
+
+	none := &LogCollection{Targets: []string{LOG_TARGET_NONE}}
+	require.Empty(t, none.GetTargets())
+	require.False(t, none.ShouldCollect(LOG_COMPONENT_NODES, true))
+
+	onFailure := &LogCollection{CollectOnFailureOnly: pointer.ToBool(true), JD: pointer.ToBool(false)}
+	require.False(t, onFailure.ShouldCollect(LOG_COMPONENT_NODES, false))
+	require.True(t, onFailure.ShouldCollect(LOG_COMPONENT_NODES, true))
+	require.False(t, onFailure.ShouldCollect(LOG_COMPONENT_JD, true))
+}
+
+func TestLogCollectionValidate(t *testing.T) {
+	t.Setenv(E2E_CCIP_LOKI_ENDPOINT, "")
+	for _, tc := range []struct {
+		name          string
+		logs          *LogCollection
+		observability *Observability
+		err           string
+	}{
+		{name: "defaults", logs: &LogCollection{}},
+		{name: "file without dir", logs: &LogCollection{Targets: []string{LOG_TARGET_FILE}}, err: "contains file but FileDir is not set"},
+		{name: "loki without endpoint", logs: &LogCollection{Targets: []string{LOG_TARGET_LOKI}}, err: "Observability.LokiEndpoint is not set"},
+		{name: "loki", logs: &LogCollection{Targets: []string{LOG_TARGET_LOKI}}, observability: &Observability{LokiEndpoint: pointer.ToString("http://loki:3100")}},
+		{name: "none with others", logs: &LogCollection{Targets: []string{LOG_TARGET_NONE, LOG_TARGET_FILE}, FileDir: pointer.ToString("logs")}, err: "cannot combine none"},
+		{name: "unknown target", logs: &LogCollection{Targets: []string{"s3"}}, err: `unknown target "s3"`},
+		{name: "duplicate target", logs: &LogCollection{Targets: []string{LOG_TARGET_FILE, LOG_TARGET_FILE}, FileDir: pointer.ToString("logs")}, err: "contains file more than once"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.logs.Validate(tc.observability)
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestLogCollectionCollect(t *testing.T) {
+	var pushed []lokiPush
+	loki := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, LOKI_PUSH_PATH, r.URL.Path)
+		require.Equal(t, "tenant", r.Header.Get("X-Scope-OrgID"))
+		var push lokiPush
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&push))
+		pushed = append(pushed, push)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer loki.Close()
+
+	dir := t.TempDir()
+	logs := &LogCollection{
+		Targets: []string{LOG_TARGET_FILE, LOG_TARGET_LOKI},
+		FileDir: pointer.ToString(dir),
+		JD:      pointer.ToBool(false),
+	}
+	observability := &Observability{LokiEndpoint: pointer.ToString(loki.URL), LokiTenant: pointer.ToString("tenant")}
+	var requested []string
+	containerLogs := func(_ context.Context, component string) (map[string][]byte, error) {
+		requested = append(requested, component)
+		if component != LOG_COMPONENT_NODES {
+			return nil, nil
+		}
+		return map[string][]byte{"node-0": []byte("first\nsecond\n")}, nil
+	}
+	require.NoError(t, logs.Collect(context.Background(), "TestSmoke/lane", true, observability, containerLogs))
+
+	require.Equal(t, []string{LOG_COMPONENT_NODES, LOG_COMPONENT_RMN, LOG_COMPONENT_CHAINS}, requested)
+	content, err := os.ReadFile(filepath.Join(dir, "TestSmoke_lane", "node-0.log"))
+	require.NoError(t, err)
+	require.Equal(t, "first\nsecond\n", string(content))
+
+	require.Len(t, pushed, 3)
+	require.Len(t, pushed[0].Streams, 1)
+	stream := pushed[0].Streams[0]
+	require.Equal(t, map[string]string{"test": "TestSmoke/lane", "component": LOG_COMPONENT_NODES, "container": "node-0"}, stream.Stream)
+	require.Len(t, stream.Values, 2)
+	require.Equal(t, "first", stream.Values[0][1])
+	require.Equal(t, "second", stream.Values[1][1])
+}
@@ -0,0 +1,111 @@
+package ccip
+
+import (
+	"math/big"
+	"reflect"
+)
+
+var bigIntType = reflect.TypeOf(big.Int{})
+
+// mergeConfig returns a deep copy of defaults with every field set in override applied on top.
+// Structs and maps are merged recursively, all other values set in override replace the default.
+func mergeConfig(defaults, override *Config) *Config {
+	merged := &Config{}
+	if defaults != nil {
+		merged = deepCopyValue(reflect.ValueOf(defaults)).Interface().(*Config)
+	}
+	if override != nil {
+		overlayValue(reflect.ValueOf(merged).Elem(), deepCopyValue(reflect.ValueOf(override)).Elem())
+	}
+	// the networks and ABIs may have changed
+	merged.networksBySelector = nil
+	merged.abis = nil
+	return merged
+}
+
+// isLeafStruct returns true for structs that can't be merged field by field, like big.Int.
+func isLeafStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return true
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if !t.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+func overlayValue(dst, src reflect.Value) {
+	for i := 0; i < src.NumField(); i++ {
+		if !src.Type().Field(i).IsExported() || src.Field(i).IsZero() {
+			continue
+		}
+		sf, df := src.Field(i), dst.Field(i)
+		switch {
+		case sf.Kind() == reflect.Ptr && !df.IsNil() && !isLeafStruct(sf.Type().Elem()):
+			overlayValue(df.Elem(), sf.Elem())
+		case sf.Kind() == reflect.Map && !df.IsNil():
+			iter := sf.MapRange()
+			for iter.Next() {
+				existing := df.MapIndex(iter.Key())
+				if existing.IsValid() && !existing.IsNil() && iter.Value().Kind() == reflect.Ptr &&
+					!iter.Value().IsNil() && !isLeafStruct(iter.Value().Type().Elem()) {
+					overlayValue(existing.Elem(), iter.Value().Elem())
+					continue
+				}
+				df.SetMapIndex(iter.Key(), iter.Value())
+			}
+		case sf.Kind() == reflect.Struct && !isLeafStruct(sf.Type()):
+			overlayValue(df, sf)
+		default:
+			df.Set(sf)
+		}
+	}
+}
+
+// deepCopyValue copies pointers, maps and slices so the copy shares no mutable state with v.
+func deepCopyValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		if v.Type().Elem() == bigIntType {
+			return reflect.ValueOf(new(big.Int).Set(v.Interface().(*big.Int)))
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(deepCopyValue(v.Elem()))
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				c.Field(i).Set(deepCopyValue(v.Field(i)))
+			}
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), deepCopyValue(iter.Value()))
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopyValue(v.Index(i)))
+		}
+		return c
+	default:
+		return v
+	}
+}
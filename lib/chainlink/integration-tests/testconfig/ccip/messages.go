@@ -0,0 +1,179 @@
+package ccip
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	PAYLOAD_TYPE_EMPTY                 = "empty"
+	PAYLOAD_TYPE_RANDOM                = "random"
+	PAYLOAD_TYPE_FIXED                 = "fixed"
+	PAYLOAD_TYPE_TOKENS_ONLY           = "tokensOnly"
+	PAYLOAD_TYPE_PROGRAMMATIC_RECEIVER = "programmaticReceiver"
+
+	DEFAULT_MESSAGE_COUNT_PER_LANE = 1
+	DEFAULT_RANDOM_PAYLOAD_BYTES   = 32
+	// DEFAULT_RECEIVER_GAS_LIMIT mirrors DefaultTxGasLimit of the fee quoter dest chain config
+	DEFAULT_RECEIVER_GAS_LIMIT uint64 = 200_000
+)
+
+// Messages configures the messages smoke tests send on every lane.
+type Messages struct {
+	CountPerLane *int `toml:",omitempty"`
+	// PayloadType is one of "empty", "random", "fixed", "tokensOnly", "programmaticReceiver". "empty" and
+	// "tokensOnly" messages carry no data and don't call the receiver, "tokensOnly" and "programmaticReceiver"
+	// messages carry the tokens transferred on the lane.
+	PayloadType *string `toml:",omitempty"`
+	// FixedPayloadHex is required iff PayloadType is "fixed"
+	FixedPayloadHex *string `toml:",omitempty"`
+	// ReceiverGasLimit overrides the ExtraArgs gas limit of messages calling the receiver
+	ReceiverGasLimit *uint64 `toml:",omitempty"`
+}
+
+func (m *Messages) GetCountPerLane() int {
+	if m == nil || m.CountPerLane == nil {
+		return DEFAULT_MESSAGE_COUNT_PER_LANE
+	}
+	return *m.CountPerLane
+}
+
+func (m *Messages) GetPayloadType() string {
+	if m == nil || pointer.GetString(m.PayloadType) == "" {
+		return PAYLOAD_TYPE_RANDOM
+	}
+	return *m.PayloadType
+}
+
+func (m *Messages) GetReceiverGasLimit() uint64 {
+	if m == nil || m.ReceiverGasLimit == nil {
+		return DEFAULT_RECEIVER_GAS_LIMIT
+	}
+	return *m.ReceiverGasLimit
+}
+
+// callsReceiver reports whether messages of the payload type execute the receiver.
+func (m *Messages) callsReceiver() bool {
+	switch m.GetPayloadType() {
+	case PAYLOAD_TYPE_EMPTY, PAYLOAD_TYPE_TOKENS_ONLY:
+		return false
+	default:
+		return true
+	}
+}
+
+// carriesTokens reports whether messages of the payload type transfer tokens.
+func (m *Messages) carriesTokens() bool {
+	switch m.GetPayloadType() {
+	case PAYLOAD_TYPE_TOKENS_ONLY, PAYLOAD_TYPE_PROGRAMMATIC_RECEIVER:
+		return true
+	default:
+		return false
+	}
+}
+
+func (m *Messages) GetFixedPayload() ([]byte, error) {
+	if m == nil || m.FixedPayloadHex == nil {
+		return nil, fmt.Errorf("FixedPayloadHex is not set")
+	}
+	return hex.DecodeString(strings.TrimPrefix(*m.FixedPayloadHex, "0x"))
+}
+
+// BuildPayload returns the data of the next message according to the configured payload type.
+// rng is only used for random payloads, pass Config.NewRand(RAND_COMPONENT_PAYLOAD) so runs are reproducible.
+func (m *Messages) BuildPayload(rng *rand.Rand) ([]byte, error) {
+	switch m.GetPayloadType() {
+	case PAYLOAD_TYPE_EMPTY, PAYLOAD_TYPE_TOKENS_ONLY:
+		return []byte{}, nil
+	case PAYLOAD_TYPE_FIXED:
+		return m.GetFixedPayload()
+	case PAYLOAD_TYPE_RANDOM, PAYLOAD_TYPE_PROGRAMMATIC_RECEIVER:
+		payload := make([]byte, DEFAULT_RANDOM_PAYLOAD_BYTES)
+		_, _ = rng.Read(payload)
+		return payload, nil
+	default:
+		return nil, fmt.Errorf("unknown payload type %q", m.GetPayloadType())
+	}
+}
+
+// LaneMessage is a message smoke tests send on a lane.
+type LaneMessage struct {
+	Data []byte
+	// WithTokens is set when the message carries the tokens transferred on the lane
+	WithTokens bool
+	ExtraArgs  []byte
+}
+
+// BuildLaneMessages returns the Messages.CountPerLane messages sent towards the destination chain. Their
+// extraArgs are the ExtraArgs of the destination, with the ReceiverGasLimit if set, or a gas limit of 0 for
+// messages that don't call the receiver. rng is passed to BuildPayload.
+func (o *Config) BuildLaneMessages(dest uint64, rng *rand.Rand) ([]LaneMessage, error) {
+	extraArgs, err := o.GetExtraArgsForDest(dest)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case !o.Messages.callsReceiver():
+		extraArgs.GasLimit = pointer.ToUint64(0)
+	case o.Messages != nil && o.Messages.ReceiverGasLimit != nil:
+		extraArgs.GasLimit = pointer.ToUint64(*o.Messages.ReceiverGasLimit)
+	}
+	encoded, err := extraArgs.Encode()
+	if err != nil {
+		return nil, fieldError("ExtraArgs", err)
+	}
+	msgs := make([]LaneMessage, 0, o.Messages.GetCountPerLane())
+	for i := 0; i < o.Messages.GetCountPerLane(); i++ {
+		data, err := o.Messages.BuildPayload(rng)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, LaneMessage{Data: data, WithTokens: o.Messages.carriesTokens(), ExtraArgs: encoded})
+	}
+	return msgs, nil
+}
+
+// PayloadSize returns the size in bytes of the payloads BuildPayload produces.
+func (m *Messages) PayloadSize() (int, error) {
+	switch m.GetPayloadType() {
+	case PAYLOAD_TYPE_EMPTY, PAYLOAD_TYPE_TOKENS_ONLY:
+		return 0, nil
+	case PAYLOAD_TYPE_FIXED:
+		payload, err := m.GetFixedPayload()
+		return len(payload), err
+	default:
+		return DEFAULT_RANDOM_PAYLOAD_BYTES, nil
+	}
+}
+
+func (m *Messages) Validate(limits *MessageLimits) error {
+	if m.CountPerLane != nil && *m.CountPerLane < 1 {
+		return fmt.Errorf("Messages.CountPerLane must be greater than 0, got %d", *m.CountPerLane)
+	}
+	payloadType := m.GetPayloadType()
+	switch payloadType {
+	case PAYLOAD_TYPE_EMPTY, PAYLOAD_TYPE_RANDOM, PAYLOAD_TYPE_FIXED, PAYLOAD_TYPE_TOKENS_ONLY, PAYLOAD_TYPE_PROGRAMMATIC_RECEIVER:
+	default:
+		return fmt.Errorf("Messages.PayloadType %q is not one of %s", payloadType, strings.Join([]string{
+			PAYLOAD_TYPE_EMPTY, PAYLOAD_TYPE_RANDOM, PAYLOAD_TYPE_FIXED, PAYLOAD_TYPE_TOKENS_ONLY, PAYLOAD_TYPE_PROGRAMMATIC_RECEIVER,
+		}, ", "))
+	}
+	if (payloadType == PAYLOAD_TYPE_FIXED) != (m.FixedPayloadHex != nil) {
+		return fmt.Errorf("Messages.FixedPayloadHex must be set if and only if PayloadType is %q", PAYLOAD_TYPE_FIXED)
+	}
+	size, err := m.PayloadSize()
+	if err != nil {
+		return fmt.Errorf("Messages.FixedPayloadHex is not valid hex: %w", err)
+	}
+	if uint64(size) > uint64(limits.GetMaxDataBytes()) && !limits.IsOverLimitAllowed() {
+		return fmt.Errorf("Messages payload size (%d bytes) exceeds MessageLimits.MaxDataBytes (%d)", size, limits.GetMaxDataBytes())
+	}
+	if m.GetReceiverGasLimit() > limits.GetMaxPerMsgGasLimit() && !limits.IsOverLimitAllowed() {
+		return fmt.Errorf("Messages.ReceiverGasLimit (%d) exceeds MessageLimits.MaxPerMsgGasLimit (%d)", m.GetReceiverGasLimit(), limits.GetMaxPerMsgGasLimit())
+	}
+	return nil
+}
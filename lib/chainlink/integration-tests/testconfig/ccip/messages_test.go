@@ -0,0 +1,117 @@
+package ccip
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessagesDefaults(t *testing.T) {
+	var unset *Messages
+	require.Equal(t, DEFAULT_MESSAGE_COUNT_PER_LANE, unset.GetCountPerLane())
+	require.Equal(t, PAYLOAD_TYPE_RANDOM, unset.GetPayloadType())
+	require.Equal(t, DEFAULT_RECEIVER_GAS_LIMIT, unset.GetReceiverGasLimit())
+	require.NoError(t, (&Messages{}).Validate(nil))
+}
+
+func TestMessagesBuildPayload(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		messages *Messages
+		expected int
+	}{
+		{name: "empty", messages: &Messages{PayloadType: pointer.ToString(PAYLOAD_TYPE_EMPTY)}, expected: 0},
+		{name: "tokensOnly", messages: &Messages{PayloadType: pointer.ToString(PAYLOAD_TYPE_TOKENS_ONLY)}, expected: 0},
+		{name: "random", messages: &Messages{}, expected: DEFAULT_RANDOM_PAYLOAD_BYTES},
+		{name: "fixed", messages: &Messages{PayloadType: pointer.ToString(PAYLOAD_TYPE_FIXED), FixedPayloadHex: pointer.ToString("0xdeadbeef")}, expected: 4},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			payload, err := tc.messages.BuildPayload(rand.New(rand.NewSource(1)))
+			require.NoError(t, err)
+			require.Len(t, payload, tc.expected)
+			size, err := tc.messages.PayloadSize()
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, size)
+		})
+	}
+
+	random := &Messages{}
+	first, err := random.BuildPayload(rand.New(rand.NewSource(7)))
+	require.NoError(t, err)
+	second, err := random.BuildPayload(rand.New(rand.NewSource(7)))
+	require.NoError(t, err)
+	require.Equal(t, first, second, "the same seed must produce the same payload")
+}
+
+func TestMessagesValidate(t *testing.T) {
+	tiny := &MessageLimits{MaxDataBytes: pointer.ToUint32(4)}
+	for _, tc := range []struct {
+		name     string
+		messages *Messages
+		limits   *MessageLimits
+		err      string
+	}{
+		{name: "zero count", messages: &Messages{CountPerLane: pointer.ToInt(0)}, err: "CountPerLane must be greater than 0"},
+		{name: "unknown type", messages: &Messages{PayloadType: pointer.ToString("huge")}, err: `PayloadType "huge" is not one of`},
+		{name: "fixed without hex", messages: &Messages{PayloadType: pointer.ToString(PAYLOAD_TYPE_FIXED)}, err: "FixedPayloadHex must be set if and only if"},
+		{name: "hex without fixed", messages: &Messages{FixedPayloadHex: pointer.ToString("00")}, err: "FixedPayloadHex must be set if and only if"},
+		{name: "invalid hex", messages: &Messages{PayloadType: pointer.ToString(PAYLOAD_TYPE_FIXED), FixedPayloadHex: pointer.ToString("zz")}, err: "not valid hex"},
+		{name: "random over limit", messages: &Messages{}, limits: tiny, err: "payload size (32 bytes) exceeds MessageLimits.MaxDataBytes (4)"},
+		{name: "random over limit allowed", messages: &Messages{}, limits: &MessageLimits{MaxDataBytes: pointer.ToUint32(4), AllowOverLimitMessages: pointer.ToBool(true)}},
+		{name: "empty within tiny limit", messages: &Messages{PayloadType: pointer.ToString(PAYLOAD_TYPE_EMPTY)}, limits: &MessageLimits{MaxDataBytes: pointer.ToUint32(0)}},
+		{name: "tokensOnly within tiny limit", messages: &Messages{PayloadType: pointer.ToString(PAYLOAD_TYPE_TOKENS_ONLY)}, limits: &MessageLimits{MaxDataBytes: pointer.ToUint32(0)}},
+		{name: "fixed within limit", messages: &Messages{PayloadType: pointer.ToString(PAYLOAD_TYPE_FIXED), FixedPayloadHex: pointer.ToString("deadbeef")}, limits: tiny},
+		{name: "gas over limit", messages: &Messages{ReceiverGasLimit: pointer.ToUint64(DEFAULT_RECEIVER_GAS_LIMIT)}, limits: &MessageLimits{MaxPerMsgGasLimit: pointer.ToUint64(1)}, err: "ReceiverGasLimit (200000) exceeds"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.messages.Validate(tc.limits)
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestBuildLaneMessages(t *testing.T) {
+	const dest = 3379446385462418246 // geth-testnet
+	for _, tc := range []struct {
+		name       string
+		messages   *Messages
+		extraArgs  *ExtraArgs
+		count      int
+		dataLen    int
+		withTokens bool
+		gasLimit   uint64
+	}{
+		{name: "defaults", count: 1, dataLen: DEFAULT_RANDOM_PAYLOAD_BYTES, gasLimit: DEFAULT_EXTRA_ARGS_GAS_LIMIT},
+		{name: "count per lane", messages: &Messages{CountPerLane: pointer.ToInt(3)}, count: 3, dataLen: DEFAULT_RANDOM_PAYLOAD_BYTES, gasLimit: DEFAULT_EXTRA_ARGS_GAS_LIMIT},
+		{name: "empty", messages: &Messages{PayloadType: pointer.ToString(PAYLOAD_TYPE_EMPTY)}, count: 1},
+		{name: "tokensOnly", messages: &Messages{PayloadType: pointer.ToString(PAYLOAD_TYPE_TOKENS_ONLY)}, count: 1, withTokens: true},
+		{name: "programmaticReceiver", messages: &Messages{PayloadType: pointer.ToString(PAYLOAD_TYPE_PROGRAMMATIC_RECEIVER), ReceiverGasLimit: pointer.ToUint64(500_000)}, count: 1, dataLen: DEFAULT_RANDOM_PAYLOAD_BYTES, withTokens: true, gasLimit: 500_000},
+		{name: "receiver gas limit over extra args", messages: &Messages{ReceiverGasLimit: pointer.ToUint64(1)}, extraArgs: &ExtraArgs{Default: &ExtraArgsConfig{GasLimit: pointer.ToUint64(2)}}, count: 1, dataLen: DEFAULT_RANDOM_PAYLOAD_BYTES, gasLimit: 1},
+		{name: "extra args gas limit", extraArgs: &ExtraArgs{Default: &ExtraArgsConfig{GasLimit: pointer.ToUint64(2)}}, count: 1, dataLen: DEFAULT_RANDOM_PAYLOAD_BYTES, gasLimit: 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &Config{Messages: tc.messages, ExtraArgs: tc.extraArgs}
+			msgs, err := cfg.BuildLaneMessages(dest, rand.New(rand.NewSource(1)))
+			require.NoError(t, err)
+			require.Len(t, msgs, tc.count)
+			for _, msg := range msgs {
+				require.Len(t, msg.Data, tc.dataLen)
+				require.Equal(t, tc.withTokens, msg.WithTokens)
+				require.Len(t, msg.ExtraArgs, 4+2*32)
+				require.Equal(t, evmExtraArgsV2Tag, msg.ExtraArgs[:4])
+				require.Equal(t, tc.gasLimit, new(big.Int).SetBytes(msg.ExtraArgs[4:36]).Uint64())
+			}
+		})
+	}
+
+	cfg := &Config{ExtraArgs: &ExtraArgs{Default: &ExtraArgsConfig{Version: pointer.ToString(EXTRA_ARGS_SVM_V1)}}}
+	_, err := cfg.BuildLaneMessages(dest, rand.New(rand.NewSource(1)))
+	require.ErrorContains(t, err, `encoding "svmV1" extraArgs is not supported`)
+}
@@ -0,0 +1,91 @@
+package ccip
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	LABEL_RUN_ID             = "run_id"
+	LABEL_HOME_CHAIN         = "home_chain_selector"
+	LABEL_CONFIG_FINGERPRINT = "config_fingerprint"
+
+	// FINGERPRINT_LABEL_LENGTH is how much of the config fingerprint goes into LABEL_CONFIG_FINGERPRINT
+	FINGERPRINT_LABEL_LENGTH = 12
+	// MAX_LABELS_SIZE caps the summed length of all label keys and values, Loki rejects streams with
+	// large label sets
+	MAX_LABELS_SIZE = 1024
+)
+
+// labelKeyRegexp is the Prometheus label name syntax, names starting with __ are reserved.
+var labelKeyRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// derivedLabels are set by Labels and can't be used as Metadata keys.
+var derivedLabels = []string{LABEL_RUN_ID, LABEL_HOME_CHAIN, LABEL_CONFIG_FINGERPRINT}
+
+// GetRunID returns RunID, generating one from the current time the first time it's called when it
+// isn't set, so every later call and every integration sees the same ID.
+func (o *Config) GetRunID() string {
+	if pointer.GetString(o.RunID) == "" {
+		suffix := make([]byte, 4)
+		_, _ = rand.Read(suffix)
+		o.RunID = pointer.ToString(time.Now().UTC().Format("20060102-150405") + "-" + hex.EncodeToString(suffix))
+	}
+	return *o.RunID
+}
+
+// Labels returns the labels attached to the logs, metrics, reports and annotations of a run: the
+// Metadata plus the run ID, home chain and config fingerprint prefix.
+func (o *Config) Labels() map[string]string {
+	labels := make(map[string]string, len(o.Metadata)+len(derivedLabels))
+	for k, v := range o.Metadata {
+		labels[k] = v
+	}
+	labels[LABEL_RUN_ID] = o.GetRunID()
+	if home := pointer.GetString(o.HomeChainSelector); home != "" {
+		labels[LABEL_HOME_CHAIN] = home
+	}
+	if fingerprint, err := o.Fingerprint(); err == nil {
+		labels[LABEL_CONFIG_FINGERPRINT] = fingerprint[:FINGERPRINT_LABEL_LENGTH]
+	}
+	return labels
+}
+
+// labelTags formats labels as sorted key:value tags for integrations without key value labels.
+func labelTags(labels map[string]string) []string {
+	tags := make([]string, 0, len(labels))
+	for k, v := range labels {
+		tags = append(tags, k+":"+v)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+func (o *Config) validateMetadata() error {
+	keys := make([]string, 0, len(o.Metadata))
+	for k := range o.Metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	size := len(LABEL_RUN_ID) + len(pointer.GetString(o.RunID))
+	for _, k := range keys {
+		if !labelKeyRegexp.MatchString(k) || strings.HasPrefix(k, "__") {
+			return fmt.Errorf("Metadata key %q must match %s and not start with __", k, labelKeyRegexp)
+		}
+		if containsString(derivedLabels, k) {
+			return fmt.Errorf("Metadata key %q is set for every run and can't be overridden", k)
+		}
+		size += len(k) + len(o.Metadata[k])
+	}
+	if size > MAX_LABELS_SIZE {
+		return fmt.Errorf("Metadata and RunID add up to %d bytes of labels, at most %d are allowed", size, MAX_LABELS_SIZE)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package ccip
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabels(t *testing.T) {
+	cfg := &Config{
+		Metadata:          map[string]string{"team": "ccip", "ticket": "CCIP-1234"},
+		HomeChainSelector: pointer.ToString("3379446385462418246"),
+	}
+	runID := cfg.GetRunID()
+	require.Regexp(t, `^\d{8}-\d{6}-[0-9a-f]{8}$`, runID)
+	require.Equal(t, runID, cfg.GetRunID(), "the generated run ID is kept")
+
+	fingerprint, err := cfg.Fingerprint()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"team":                   "ccip",
+		"ticket":                 "CCIP-1234",
+		LABEL_RUN_ID:             runID,
+		LABEL_HOME_CHAIN:         "3379446385462418246",
+		LABEL_CONFIG_FINGERPRINT: fingerprint[:FINGERPRINT_LABEL_LENGTH],
+	}, cfg.Labels())
+
+	cfg.Metadata["scenario"] = "soak"
+	cfg.RunID = pointer.ToString("another-run")
+	again, err := cfg.Fingerprint()
+	require.NoError(t, err)
+	require.Equal(t, fingerprint, again, "labels don't change the fingerprint")
+}
+
+func TestLabelsInReports(t *testing.T) {
+	cfg := &Config{RunID: pointer.ToString("run-1"), Metadata: map[string]string{"team": "ccip"}}
+
+	r := newTestReporter(t, &Reporting{Format: pointer.ToString(REPORT_FORMAT_JUNIT)})
+	r.AttachLabels(cfg)
+	require.Equal(t, "run-1", r.Report().Labels[LABEL_RUN_ID])
+	content, err := r.Marshal()
+	require.NoError(t, err)
+	require.Contains(t, string(content), `<property name="run_id" value="run-1"></property>`)
+	require.Contains(t, string(content), `<property name="team" value="ccip"></property>`)
+
+	r = newTestReporter(t, &Reporting{})
+	r.AttachLabels(cfg)
+	content, err = r.Marshal()
+	require.NoError(t, err)
+	var report Report
+	require.NoError(t, json.Unmarshal(content, &report))
+	require.Equal(t, "ccip", report.Labels["team"])
+
+	token := Secret("token")
+	a := NewAnnotator(&Observability{
+		GrafanaURL:      pointer.ToString("http://grafana"),
+		GrafanaToken:    &token,
+		AnnotateGrafana: pointer.ToBool(true),
+		AnnotationTags:  []string{"soak"},
+	})
+	a.AttachLabels(cfg)
+	require.Contains(t, a.tags, "soak")
+	require.Contains(t, a.tags, "run_id:run-1")
+	require.Contains(t, a.tags, "team:ccip")
+}
+
+func TestValidateMetadata(t *testing.T) {
+	cfg := &Config{Metadata: map[string]string{"team": "ccip", "git_sha": "abc123"}}
+	require.NoError(t, cfg.validateMetadata())
+
+	for key, want := range map[string]string{
+		"scenario-name":  `Metadata key "scenario-name" must match`,
+		"1st":            `Metadata key "1st" must match`,
+		"__name__":       `Metadata key "__name__" must match`,
+		LABEL_RUN_ID:     `Metadata key "run_id" is set for every run`,
+		LABEL_HOME_CHAIN: `Metadata key "home_chain_selector" is set for every run`,
+	} {
+		cfg := &Config{Metadata: map[string]string{key: "x"}}
+		require.ErrorContains(t, cfg.validateMetadata(), want)
+	}
+
+	cfg = &Config{Metadata: map[string]string{"description": strings.Repeat("x", MAX_LABELS_SIZE)}}
+	require.ErrorContains(t, cfg.validateMetadata(), "bytes of labels, at most 1024 are allowed")
+}
@@ -0,0 +1,120 @@
+package ccip
+
+import (
+	"fmt"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	MOCK_TYPE_PRICE_API   = "price-api"
+	MOCK_TYPE_GAS_ORACLE  = "gas-oracle"
+	MOCK_TYPE_TOKEN_API   = "token-api"
+	MOCK_TYPE_ATTESTATION = "attestation"
+)
+
+var mockTypes = []string{MOCK_TYPE_PRICE_API, MOCK_TYPE_GAS_ORACLE, MOCK_TYPE_TOKEN_API, MOCK_TYPE_ATTESTATION}
+
+// MockServiceConfig configures a mock HTTP service started alongside the environment. The mock's
+// container is named after it, which is also the host nodes and contracts reach it under.
+type MockServiceConfig struct {
+	Name *string `toml:",omitempty"`
+	Type *string `toml:",omitempty"`
+	// Port is drawn from the PortAllocator when not set
+	Port *int `toml:",omitempty"`
+	// Responses maps request paths to the response body returned for them
+	Responses      map[string]string `toml:",omitempty"`
+	LatencyMs      *int              `toml:",omitempty"`
+	FailureRatePct *float64          `toml:",omitempty"`
+}
+
+func (m *MockServiceConfig) GetName() string {
+	return pointer.GetString(m.Name)
+}
+
+func (m *MockServiceConfig) GetType() string {
+	return pointer.GetString(m.Type)
+}
+
+func (m *MockServiceConfig) GetLatencyMs() int {
+	return pointer.GetInt(m.LatencyMs)
+}
+
+func (m *MockServiceConfig) GetFailureRatePct() float64 {
+	return pointer.GetFloat64(m.FailureRatePct)
+}
+
+// GetResponse returns the response configured for the request path.
+func (m *MockServiceConfig) GetResponse(path string) (string, bool) {
+	response, ok := m.Responses[path]
+	return response, ok
+}
+
+func (m *MockServiceConfig) portClaimant() string {
+	return fmt.Sprintf("Mocks.%s.Port", m.GetName())
+}
+
+func (m *MockServiceConfig) Validate(index int) error {
+	if m.GetName() == "" {
+		return fmt.Errorf("Mocks[%d].Name must be set", index)
+	}
+	if !containsString(mockTypes, m.GetType()) {
+		return fmt.Errorf("Mocks.%s.Type %q is not one of %v", m.GetName(), m.GetType(), mockTypes)
+	}
+	if m.Port != nil {
+		if err := validatePort(*m.Port); err != nil {
+			return fieldError("Mocks."+m.GetName()+".Port", err)
+		}
+	}
+	if m.GetLatencyMs() < 0 {
+		return fmt.Errorf("Mocks.%s.LatencyMs cannot be negative", m.GetName())
+	}
+	if m.GetFailureRatePct() < 0 || m.GetFailureRatePct() > 100 {
+		return fmt.Errorf("Mocks.%s.FailureRatePct must be between 0 and 100, got %f", m.GetName(), m.GetFailureRatePct())
+	}
+	return nil
+}
+
+// GetMock returns the mock service with the given name.
+func (o *Config) GetMock(name string) (*MockServiceConfig, bool) {
+	for _, mock := range o.Mocks {
+		if mock != nil && mock.GetName() == name {
+			return mock, true
+		}
+	}
+	return nil, false
+}
+
+// GetMockBaseURL returns the URL the named mock is reachable under, for injection into node TOML and contract constructor args.
+func (o *Config) GetMockBaseURL(name string) (string, error) {
+	mock, ok := o.GetMock(name)
+	if !ok {
+		return "", fmt.Errorf("mock %s is not configured in Mocks", name)
+	}
+	allocator, err := o.PortAllocator()
+	if err != nil {
+		return "", err
+	}
+	port, ok := allocator.Port(mock.portClaimant())
+	if !ok {
+		return "", fmt.Errorf("mock %s has no port assigned", name)
+	}
+	return fmt.Sprintf("http://%s:%d", name, port), nil
+}
+
+func (o *Config) validateMocks() error {
+	names := make(map[string]struct{}, len(o.Mocks))
+	for i, mock := range o.Mocks {
+		if mock == nil {
+			return fmt.Errorf("Mocks[%d] is empty", i)
+		}
+		if err := mock.Validate(i); err != nil {
+			return err
+		}
+		if _, ok := names[mock.GetName()]; ok {
+			return fmt.Errorf("Mocks.%s is configured more than once", mock.GetName())
+		}
+		names[mock.GetName()] = struct{}{}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const mocksTestTOML = `
+PortRangeStart = 30000
+PortRangeEnd = 30100
+
+[[Mocks]]
+Name = 'prices'
+Type = 'price-api'
+LatencyMs = 200
+
+[Mocks.Responses]
+'/v1/prices/LINK' = """
+{
+  "symbol": "LINK",
+  "price": "14.2"
+}
+"""
+
+[[Mocks]]
+Name = 'gas'
+Type = 'gas-oracle'
+Port = 30000
+`
+
+func TestMocksRoundTrip(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(mocksTestTOML), &cfg))
+	require.NoError(t, cfg.validateMocks())
+
+	response, ok := cfg.Mocks[0].GetResponse("/v1/prices/LINK")
+	require.True(t, ok)
+	require.JSONEq(t, `{"symbol": "LINK", "price": "14.2"}`, response)
+
+	content, err := toml.Marshal(cfg)
+	require.NoError(t, err)
+	var decoded Config
+	require.NoError(t, toml.Unmarshal(content, &decoded))
+	require.Equal(t, cfg.Mocks, decoded.Mocks)
+}
+
+func TestMocksBaseURL(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(mocksTestTOML), &cfg))
+
+	// the explicit port is registered before JD and then prices draw from the range
+	url, err := cfg.GetMockBaseURL("prices")
+	require.NoError(t, err)
+	require.Equal(t, "http://prices:30003", url)
+	url, err = cfg.GetMockBaseURL("gas")
+	require.NoError(t, err)
+	require.Equal(t, "http://gas:30000", url)
+
+	_, err = cfg.GetMockBaseURL("tokens")
+	require.ErrorContains(t, err, "mock tokens is not configured in Mocks")
+}
+
+func TestMocksValidate(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(mocksTestTOML), &cfg))
+
+	cfg.Mocks[1].Type = cfg.Mocks[0].Type
+	cfg.Mocks[1].Name = cfg.Mocks[0].Name
+	require.ErrorContains(t, cfg.validateMocks(), "Mocks.prices is configured more than once")
+
+	unknown := "oracle"
+	cfg.Mocks[1].Type = &unknown
+	require.ErrorContains(t, cfg.validateMocks(), `Mocks.prices.Type "oracle" is not one of`)
+}
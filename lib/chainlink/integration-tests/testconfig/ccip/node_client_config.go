@@ -0,0 +1,152 @@
+package ccip
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+
+	tomlv2 "github.com/pelletier/go-toml/v2"
+	chainselectors "github.com/smartcontractkit/chain-selectors"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/blockchain"
+
+	"github.com/smartcontractkit/chainlink/deployment/environment/nodeclient"
+)
+
+// ccipNodeConfig is the part of the core node config CCIP can't run without.
+type ccipNodeConfig struct {
+	OCR2 struct {
+		Enabled *bool
+	}
+	P2P struct {
+		V2 struct {
+			Enabled         *bool
+			ListenAddresses []string
+		}
+	}
+	Capabilities struct {
+		ExternalRegistry struct {
+			Address   *string
+			NetworkID *string
+			ChainID   *string
+		}
+	}
+	EVM []struct {
+		ChainID any
+		Enabled *bool
+	}
+}
+
+// ValidateClientConfigForCCIP checks the API client settings in CLNode.ClientConfig the CCIP deployment
+// needs to reach every node: the credentials to create keys and jobs with, a valid URL when one is set
+// and a chain selector for every chain, as the nodes are given an [[EVM]] block and keys per selector.
+// Every missing setting is its own error, naming the TOML key to add. Validate calls it when
+// ClientConfig is set.
+func (o *Config) ValidateClientConfigForCCIP(cfg *nodeclient.ChainlinkConfig, chains []blockchain.EVMNetwork) error {
+	if cfg == nil {
+		return nil
+	}
+	var errs []error
+	if cfg.Email == "" {
+		errs = append(errs, fmt.Errorf("CLNode.ClientConfig: Email is not set, add [CLNode.ClientConfig] Email"))
+	}
+	if cfg.Password == "" {
+		errs = append(errs, fmt.Errorf("CLNode.ClientConfig: Password is not set, add [CLNode.ClientConfig] Password"))
+	}
+	if cfg.URL != "" {
+		if u, err := url.Parse(cfg.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("CLNode.ClientConfig: URL %q is not an http(s) URL, set [CLNode.ClientConfig] URL = 'http://<host>:<port>'", cfg.URL))
+		}
+	}
+	for _, chain := range chains {
+		if _, err := chainselectors.SelectorFromChainId(uint64(chain.ChainID)); err != nil {
+			errs = append(errs, fmt.Errorf("CLNode.ClientConfig: chain %s (%d) has no chain selector, nodes can't be given keys for it", chain.Name, chain.ChainID))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateNodeConfigForCCIP checks the core node config TOML a node is started with for the settings
+// CCIP needs, which would otherwise only show as OCR never converging: OCR2 enabled, P2P.V2 listen
+// addresses, the capabilities registry when any chain runs 1.6 contracts and an enabled [[EVM]] block
+// for every chain. Every missing setting is its own error, naming the TOML key to add.
+//
+// The node config is only complete once the registry is deployed, so it's checked per node at bring-up
+// rather than in Validate.
+func (o *Config) ValidateNodeConfigForCCIP(nodeConfigTOML string, chains []blockchain.EVMNetwork) error {
+	var node ccipNodeConfig
+	if err := tomlv2.Unmarshal([]byte(nodeConfigTOML), &node); err != nil {
+		return fmt.Errorf("invalid node config: %w", err)
+	}
+	var errs []error
+	if node.OCR2.Enabled == nil || !*node.OCR2.Enabled {
+		errs = append(errs, fmt.Errorf("node config: OCR2.Enabled must be true, add [OCR2] Enabled = true"))
+	}
+	if node.P2P.V2.Enabled != nil && !*node.P2P.V2.Enabled {
+		errs = append(errs, fmt.Errorf("node config: P2P.V2.Enabled must be true, add [P2P.V2] Enabled = true"))
+	}
+	if len(node.P2P.V2.ListenAddresses) == 0 {
+		errs = append(errs, fmt.Errorf("node config: P2P.V2.ListenAddresses is not set, add [P2P.V2] ListenAddresses = ['0.0.0.0:%d']", NODE_P2P_PORT))
+	}
+	if o.anyChainOnVersion(chains, CONTRACT_VERSION_1_6) {
+		registry := node.Capabilities.ExternalRegistry
+		for _, setting := range []struct {
+			key   string
+			value *string
+		}{
+			{"Address", registry.Address},
+			{"NetworkID", registry.NetworkID},
+			{"ChainID", registry.ChainID},
+		} {
+			if setting.value == nil || *setting.value == "" {
+				errs = append(errs, fmt.Errorf("node config: Capabilities.ExternalRegistry.%s is required by %s contracts, add [Capabilities.ExternalRegistry] %s",
+					setting.key, CONTRACT_VERSION_1_6, setting.key))
+			}
+		}
+	}
+	enabled := make(map[string]bool)
+	for _, evm := range node.EVM {
+		if evm.ChainID != nil && (evm.Enabled == nil || *evm.Enabled) {
+			enabled[fmt.Sprint(evm.ChainID)] = true
+		}
+	}
+	for _, chain := range chains {
+		chainID := strconv.FormatInt(chain.ChainID, 10)
+		if !enabled[chainID] {
+			errs = append(errs, fmt.Errorf("node config: no enabled [[EVM]] block for chain %s, add [[EVM]] ChainID = '%s'", chain.Name, chainID))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (o *Config) anyChainOnVersion(chains []blockchain.EVMNetwork, version string) bool {
+	for _, chain := range chains {
+		selector, err := chainselectors.SelectorFromChainId(uint64(chain.ChainID))
+		if err != nil {
+			continue
+		}
+		if o.GetContractVersion(selector) == version {
+			return true
+		}
+	}
+	return false
+}
+
+// privateEVMNetworks returns PrivateEthereumNetworks as EVM networks, sorted by name. Networks without a
+// chain id are left out, NetworksBySelector reports them.
+func (o *Config) privateEVMNetworks() []blockchain.EVMNetwork {
+	names := make([]string, 0, len(o.PrivateEthereumNetworks))
+	for name, network := range o.PrivateEthereumNetworks {
+		if network != nil && network.EthereumChainConfig != nil && network.EthereumChainConfig.ChainID > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	chains := make([]blockchain.EVMNetwork, 0, len(names))
+	for _, name := range names {
+		chains = append(chains, blockchain.EVMNetwork{Name: name, ChainID: int64(o.PrivateEthereumNetworks[name].EthereumChainConfig.ChainID)})
+	}
+	return chains
+}
@@ -0,0 +1,67 @@
+package ccip
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/blockchain"
+
+	"github.com/smartcontractkit/chainlink/deployment/environment/nodeclient"
+)
+
+var clientConfigChains = []blockchain.EVMNetwork{
+	{Name: "geth-testnet", ChainID: 1337},
+	{Name: "geth-devnet-2", ChainID: 2337},
+}
+
+func TestValidateNodeConfigForCCIP(t *testing.T) {
+	good, err := os.ReadFile("testdata/node_config_good.toml")
+	require.NoError(t, err)
+	var cfg Config
+	require.NoError(t, cfg.ValidateNodeConfigForCCIP(string(good), clientConfigChains))
+
+	bad, err := os.ReadFile("testdata/node_config_bad.toml")
+	require.NoError(t, err)
+	err = cfg.ValidateNodeConfigForCCIP(string(bad), clientConfigChains)
+	require.Error(t, err)
+	require.Equal(t, []string{
+		"node config: OCR2.Enabled must be true, add [OCR2] Enabled = true",
+		"node config: P2P.V2.ListenAddresses is not set, add [P2P.V2] ListenAddresses = ['0.0.0.0:6690']",
+		"node config: Capabilities.ExternalRegistry.Address is required by 1.6.0 contracts, add [Capabilities.ExternalRegistry] Address",
+		"node config: Capabilities.ExternalRegistry.ChainID is required by 1.6.0 contracts, add [Capabilities.ExternalRegistry] ChainID",
+		"node config: no enabled [[EVM]] block for chain geth-devnet-2, add [[EVM]] ChainID = '2337'",
+	}, strings.Split(err.Error(), "\n"))
+
+	// 1.5 lanes don't use the capabilities registry
+	cfg.ContractVersions = map[string]string{"geth-testnet": CONTRACT_VERSION_1_5, "geth-devnet-2": CONTRACT_VERSION_1_5}
+	err = cfg.ValidateNodeConfigForCCIP(string(bad), clientConfigChains)
+	require.NotContains(t, err.Error(), "Capabilities.ExternalRegistry")
+
+	require.ErrorContains(t, cfg.ValidateNodeConfigForCCIP("[OCR2", clientConfigChains), "invalid node config")
+}
+
+func TestValidateClientConfigForCCIP(t *testing.T) {
+	var cfg Config
+	require.NoError(t, cfg.ValidateClientConfigForCCIP(nil, clientConfigChains))
+	require.NoError(t, cfg.ValidateClientConfigForCCIP(&nodeclient.ChainlinkConfig{
+		URL:      "http://node-1:6688",
+		Email:    "notreal@fakeemail.ch",
+		Password: "fj293fbBnlQ!f9vNs",
+	}, clientConfigChains))
+
+	err := cfg.ValidateClientConfigForCCIP(&nodeclient.ChainlinkConfig{URL: "node-1:6688"}, append(clientConfigChains, blockchain.EVMNetwork{Name: "unknown", ChainID: 424242424242}))
+	require.Error(t, err)
+	require.Equal(t, []string{
+		"CLNode.ClientConfig: Email is not set, add [CLNode.ClientConfig] Email",
+		"CLNode.ClientConfig: Password is not set, add [CLNode.ClientConfig] Password",
+		`CLNode.ClientConfig: URL "node-1:6688" is not an http(s) URL, set [CLNode.ClientConfig] URL = 'http://<host>:<port>'`,
+		"CLNode.ClientConfig: chain unknown (424242424242) has no chain selector, nodes can't be given keys for it",
+	}, strings.Split(err.Error(), "\n"))
+
+	// Validate checks the client config against the private networks
+	cfg.CLNode = &NodeConfig{ClientConfig: &nodeclient.ChainlinkConfig{Email: "notreal@fakeemail.ch"}}
+	require.ErrorContains(t, cfg.Validate(), "Password is not set")
+}
@@ -0,0 +1,100 @@
+package ccip
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	chainselectors "github.com/smartcontractkit/chain-selectors"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/blockchain"
+)
+
+// NodeEVMSettings are the settings of a network's [[EVM]] core node config derived from the test config.
+// Nil fields are left to the node's chain defaults.
+type NodeEVMSettings struct {
+	FinalityDepth      *uint32
+	FinalityTagEnabled *bool
+	GasEstimatorMode   *string
+	PriceDefaultGwei   *float64
+	FeeCapDefaultGwei  *float64
+	TipCapDefaultGwei  *float64
+	EIP1559DynamicFees *bool
+}
+
+// GetNodeEVMSettings returns the node settings derived from the network and its GasStrategy: the finality
+// depth, for private networks derived from their consensus unless set, and the gas estimator. The fixed
+// mode maps to the FixedPrice estimator, the caps to the default fee and tip caps. EstimateMultiplier has
+// no node equivalent and the node keeps its default estimator for the estimate and oracle modes.
+//
+// The settings go under the CommonChainConfigTOML and ChainConfigTOMLByChainID node configs, which win on
+// every field they set.
+func (o *Config) GetNodeEVMSettings(network blockchain.EVMNetwork) NodeEVMSettings {
+	settings := NodeEVMSettings{EIP1559DynamicFees: &network.SupportsEIP1559}
+	if depth := o.networkFinalityDepth(network); depth > 0 {
+		value := uint32(depth)
+		settings.FinalityDepth = &value
+	}
+	if network.FinalityTag {
+		settings.FinalityTagEnabled = &network.FinalityTag
+	}
+	selector, err := chainselectors.SelectorFromChainId(uint64(network.ChainID))
+	if err != nil {
+		return settings
+	}
+	gas := o.GetGasStrategy(selector)
+	if gas.Mode == GAS_MODE_FIXED {
+		mode, price, dynamic := "FixedPrice", gas.FixedGasPriceGwei, false
+		settings.GasEstimatorMode = &mode
+		settings.PriceDefaultGwei = &price
+		settings.EIP1559DynamicFees = &dynamic
+	}
+	if gas.FeeCapGwei > 0 {
+		settings.FeeCapDefaultGwei = &gas.FeeCapGwei
+	}
+	if gas.TipCapGwei > 0 {
+		settings.TipCapDefaultGwei = &gas.TipCapGwei
+	}
+	return settings
+}
+
+// networkFinalityDepth returns the network's finality depth, for private networks derived from their
+// consensus unless set. 0 leaves the node's chain default.
+func (o *Config) networkFinalityDepth(network blockchain.EVMNetwork) uint64 {
+	if network.FinalityDepth > 0 || network.FinalityTag {
+		return network.FinalityDepth
+	}
+	for _, private := range o.PrivateEthereumNetworks {
+		if private != nil && private.EthereumChainConfig != nil && int64(private.EthereumChainConfig.ChainID) == network.ChainID {
+			return uint64(finalityDepth(private))
+		}
+	}
+	return 0
+}
+
+// ValidateNodeChainConfigs refuses node chain configs, the keys of ChainConfigTOMLByChainID, for a chain
+// id that is not one of the networks. The node would otherwise be started without the config the test
+// meant it to have.
+func ValidateNodeChainConfigs(evmNetworks []blockchain.EVMNetwork, chainConfigByChainID map[string]string) error {
+	configured := make(map[string]bool, len(evmNetworks))
+	for _, network := range evmNetworks {
+		configured[strconv.FormatInt(network.ChainID, 10)] = true
+	}
+	var orphaned []string
+	for chainID := range chainConfigByChainID {
+		if !configured[chainID] {
+			orphaned = append(orphaned, chainID)
+		}
+	}
+	if len(orphaned) == 0 {
+		return nil
+	}
+	sort.Slice(orphaned, func(i, j int) bool {
+		if len(orphaned[i]) != len(orphaned[j]) {
+			return len(orphaned[i]) < len(orphaned[j])
+		}
+		return orphaned[i] < orphaned[j]
+	})
+	return withKind(ErrChainNotConfigured, fmt.Errorf("node config has EVM chain configs for chain ids that are not among the networks: %s", strings.Join(orphaned, ", ")))
+}
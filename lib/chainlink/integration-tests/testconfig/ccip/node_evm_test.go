@@ -0,0 +1,64 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/blockchain"
+)
+
+var nodeEVMNetworks = []blockchain.EVMNetwork{
+	{Name: "SIMULATED_1", ChainID: 1337, URLs: []string{"ws://geth-1:8546"}, HTTPURLs: []string{"http://geth-1:8545"}, Simulated: true, SupportsEIP1559: true},
+	{Name: "SIMULATED_2", ChainID: 2337, HTTPURLs: []string{"http://geth-2:8545", "http://geth-2b:8545"}, Simulated: true, FinalityDepth: 5},
+}
+
+func TestGetNodeEVMSettings(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(`
+[GasStrategy.SIMULATED_1]
+FeeCapGwei = 200
+TipCapGwei = 1.5
+
+[GasStrategy.SIMULATED_2]
+Mode = 'fixed'
+FixedGasPriceGwei = 20
+
+[PrivateEthereumNetworks.SIMULATED_1.EthereumChainConfig]
+chain_id = 1337
+
+[PrivateEthereumNetworks.SIMULATED_2.EthereumChainConfig]
+chain_id = 2337
+`), &cfg))
+	depth, feeCap, tipCap, enabled := uint32(ETH1_BLOCKS_TO_FINALITY), 200.0, 1.5, true
+	require.Equal(t, NodeEVMSettings{
+		FinalityDepth:      &depth,
+		FeeCapDefaultGwei:  &feeCap,
+		TipCapDefaultGwei:  &tipCap,
+		EIP1559DynamicFees: &enabled,
+	}, cfg.GetNodeEVMSettings(nodeEVMNetworks[0]))
+
+	depth, mode, price, disabled := uint32(5), "FixedPrice", 20.0, false
+	require.Equal(t, NodeEVMSettings{
+		FinalityDepth:      &depth,
+		GasEstimatorMode:   &mode,
+		PriceDefaultGwei:   &price,
+		EIP1559DynamicFees: &disabled,
+	}, cfg.GetNodeEVMSettings(nodeEVMNetworks[1]))
+}
+
+func TestGetNodeEVMSettingsDefaults(t *testing.T) {
+	settings := (&Config{}).GetNodeEVMSettings(blockchain.EVMNetwork{Name: "CUSTOM", ChainID: 90000001, FinalityTag: true})
+	require.Nil(t, settings.FinalityDepth)
+	require.True(t, *settings.FinalityTagEnabled)
+	require.False(t, *settings.EIP1559DynamicFees)
+	require.Nil(t, settings.GasEstimatorMode)
+}
+
+func TestValidateNodeChainConfigs(t *testing.T) {
+	require.NoError(t, ValidateNodeChainConfigs(nodeEVMNetworks, map[string]string{"2337": "FinalityDepth = 1"}))
+	err := ValidateNodeChainConfigs(nodeEVMNetworks, map[string]string{"1337": "", "90000001": "", "5": "", "10": ""})
+	require.ErrorIs(t, err, ErrChainNotConfigured)
+	require.EqualError(t, err, "node config has EVM chain configs for chain ids that are not among the networks: 5, 10, 90000001")
+}
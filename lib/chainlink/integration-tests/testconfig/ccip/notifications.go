@@ -0,0 +1,185 @@
+package ccip
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	NOTIFY_ON_START               = "start"
+	NOTIFY_ON_THRESHOLD_VIOLATION = "thresholdViolation"
+	NOTIFY_ON_FAILURE             = "failure"
+	NOTIFY_ON_COMPLETION          = "completion"
+	// NOTIFY_ON_HEARTBEAT_MISSED is sent when a chain misses Heartbeat.AlertAfterMissed heartbeats in a row
+	NOTIFY_ON_HEARTBEAT_MISSED = "heartbeatMissed"
+
+	DEFAULT_NOTIFICATION_MIN_INTERVAL = 15 * time.Minute
+	DEFAULT_NOTIFICATION_TIMEOUT      = 10 * time.Second
+)
+
+// Notifications configures a Slack compatible webhook notified about soak test events.
+type Notifications struct {
+	WebhookURL       *Secret  `toml:",omitempty"`
+	Channel          *string  `toml:",omitempty"`
+	NotifyOn         []string `toml:",omitempty"`
+	MentionOnFailure []string `toml:",omitempty"`
+	// MinInterval is the minimum time between two notifications of the same event,
+	// notifications in between are dropped and counted in the next one
+	MinInterval *Duration `toml:",omitempty"`
+}
+
+func (n *Notifications) IsEnabled() bool {
+	return n != nil && n.WebhookURL != nil && *n.WebhookURL != "" && len(n.NotifyOn) > 0
+}
+
+func (n *Notifications) GetMinInterval() time.Duration {
+	if n == nil || n.MinInterval == nil {
+		return DEFAULT_NOTIFICATION_MIN_INTERVAL
+	}
+	return n.MinInterval.Duration
+}
+
+func (n *Notifications) notifiesOn(event string) bool {
+	if !n.IsEnabled() {
+		return false
+	}
+	for _, e := range n.NotifyOn {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *Notifications) Validate() error {
+	for _, event := range n.NotifyOn {
+		switch event {
+		case NOTIFY_ON_START, NOTIFY_ON_THRESHOLD_VIOLATION, NOTIFY_ON_FAILURE, NOTIFY_ON_COMPLETION, NOTIFY_ON_HEARTBEAT_MISSED:
+		default:
+			return fmt.Errorf("Notifications.NotifyOn contains unknown event %q", event)
+		}
+	}
+	if n.WebhookURL != nil && *n.WebhookURL != "" && !n.WebhookURL.IsReference() {
+		// literal values always resolve; don't wrap the url.Parse error, it would leak the webhook
+		webhook, _ := n.WebhookURL.Value()
+		u, err := url.Parse(webhook)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return withKind(ErrInvalidEndpoint, fmt.Errorf("Notifications.WebhookURL is not a valid http(s) URL"))
+		}
+	}
+	if len(n.NotifyOn) > 0 && (n.WebhookURL == nil || *n.WebhookURL == "") {
+		return fmt.Errorf("Notifications.NotifyOn is set but WebhookURL is not")
+	}
+	if n.MinInterval != nil && n.MinInterval.Duration < 0 {
+		return fmt.Errorf("Notifications.MinInterval cannot be negative")
+	}
+	return nil
+}
+
+// Notifier posts soak test events to the configured webhook. It is inert when
+// Notifications is not configured and never fails the test, errors are only logged.
+type Notifier struct {
+	cfg    *Notifications
+	client *http.Client
+	now    func() time.Time
+
+	mu         sync.Mutex
+	lastSent   map[string]time.Time
+	suppressed map[string]int
+}
+
+func NewNotifier(cfg *Notifications) *Notifier {
+	return &Notifier{
+		cfg:        cfg,
+		client:     &http.Client{Timeout: DEFAULT_NOTIFICATION_TIMEOUT},
+		now:        time.Now,
+		lastSent:   make(map[string]time.Time),
+		suppressed: make(map[string]int),
+	}
+}
+
+// Notify posts the event with its payload rendered as "key: value" lines,
+// unless the event isn't in NotifyOn or the same event was sent less than MinInterval ago.
+func (n *Notifier) Notify(event string, payload map[string]string) {
+	if n == nil || !n.cfg.notifiesOn(event) {
+		return
+	}
+	suppressed, ok := n.allow(event)
+	if !ok {
+		return
+	}
+	body, err := json.Marshal(n.format(event, payload, suppressed))
+	if err != nil {
+		log.Warn().Err(err).Str("Event", event).Msg("failed to encode notification")
+		return
+	}
+	webhook, err := n.cfg.WebhookURL.Value()
+	if err != nil {
+		log.Warn().Err(err).Str("Event", event).Msg("failed to resolve the notification webhook")
+		return
+	}
+	resp, err := n.client.Post(webhook, "application/json", bytes.NewReader(body))
+	if err != nil {
+		// the error contains the URL, log only the event
+		log.Warn().Str("Event", event).Msg("failed to post notification")
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		log.Warn().Str("Event", event).Str("Status", resp.Status).Msg("notification webhook rejected the notification")
+	}
+}
+
+// allow applies the rate limit, returning how many notifications of the event were dropped since the last one sent.
+func (n *Notifier) allow(event string) (int, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	now := n.now()
+	if last, ok := n.lastSent[event]; ok && now.Sub(last) < n.cfg.GetMinInterval() {
+		n.suppressed[event]++
+		return 0, false
+	}
+	suppressed := n.suppressed[event]
+	n.lastSent[event] = now
+	n.suppressed[event] = 0
+	return suppressed, true
+}
+
+type webhookMessage struct {
+	Channel string `json:"channel,omitempty"`
+	Text    string `json:"text"`
+}
+
+func (n *Notifier) format(event string, payload map[string]string, suppressed int) webhookMessage {
+	var text strings.Builder
+	fmt.Fprintf(&text, "*CCIP test %s*", event)
+	if event == NOTIFY_ON_FAILURE && len(n.cfg.MentionOnFailure) > 0 {
+		mentions := make([]string, 0, len(n.cfg.MentionOnFailure))
+		for _, m := range n.cfg.MentionOnFailure {
+			mentions = append(mentions, "<@"+m+">")
+		}
+		text.WriteString(" " + strings.Join(mentions, " "))
+	}
+	keys := make([]string, 0, len(payload))
+	for k := range payload {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&text, "\n%s: %s", k, payload[k])
+	}
+	if suppressed > 0 {
+		fmt.Fprintf(&text, "\n(%d similar notifications suppressed)", suppressed)
+	}
+	return webhookMessage{Channel: pointer.GetString(n.cfg.Channel), Text: text.String()}
+}
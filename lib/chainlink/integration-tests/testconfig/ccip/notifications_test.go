@@ -0,0 +1,62 @@
+package ccip
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifierRateLimit(t *testing.T) {
+	var received []webhookMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg webhookMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		received = append(received, msg)
+	}))
+	defer server.Close()
+
+	webhook := Secret(server.URL)
+	cfg := &Notifications{
+		WebhookURL:       &webhook,
+		Channel:          pointer.ToString("#ccip-soak"),
+		NotifyOn:         []string{NOTIFY_ON_FAILURE},
+		MentionOnFailure: []string{"U123"},
+		MinInterval:      &Duration{Duration: time.Minute},
+	}
+	require.NoError(t, cfg.Validate())
+
+	now := time.Now()
+	n := NewNotifier(cfg)
+	n.now = func() time.Time { return now }
+
+	n.Notify(NOTIFY_ON_START, nil)
+	n.Notify(NOTIFY_ON_FAILURE, map[string]string{"lane": "a->b"})
+	n.Notify(NOTIFY_ON_FAILURE, map[string]string{"lane": "a->b"})
+	n.Notify(NOTIFY_ON_FAILURE, map[string]string{"lane": "a->b"})
+	now = now.Add(time.Minute)
+	n.Notify(NOTIFY_ON_FAILURE, map[string]string{"lane": "a->b"})
+
+	require.Len(t, received, 2)
+	require.Equal(t, "#ccip-soak", received[0].Channel)
+	require.Equal(t, "*CCIP test failure* <@U123>\nlane: a->b", received[0].Text)
+	require.Contains(t, received[1].Text, "(2 similar notifications suppressed)")
+}
+
+func TestNotificationsInertWhenUnconfigured(t *testing.T) {
+	var cfg *Notifications
+	require.False(t, cfg.IsEnabled())
+	NewNotifier(cfg).Notify(NOTIFY_ON_FAILURE, nil)
+}
+
+func TestNotifierSkipsUnresolvedWebhook(t *testing.T) {
+	webhook := Secret("vault://ccip/unregistered#webhook")
+	cfg := &Notifications{WebhookURL: &webhook, NotifyOn: []string{NOTIFY_ON_FAILURE}}
+	require.NoError(t, cfg.Validate())
+	// logged and skipped rather than failing the test
+	NewNotifier(cfg).Notify(NOTIFY_ON_FAILURE, nil)
+}
@@ -0,0 +1,179 @@
+package ccip
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	E2E_CCIP_LOKI_ENDPOINT          = "E2E_CCIP_LOKI_ENDPOINT"
+	E2E_CCIP_LOKI_TENANT            = "E2E_CCIP_LOKI_TENANT"
+	E2E_CCIP_LOKI_BASIC_AUTH        = "E2E_CCIP_LOKI_BASIC_AUTH"
+	E2E_CCIP_GRAFANA_URL            = "E2E_CCIP_GRAFANA_URL"
+	E2E_CCIP_GRAFANA_TOKEN          = "E2E_CCIP_GRAFANA_TOKEN"
+	E2E_CCIP_PROMETHEUS_PUSHGATEWAY = "E2E_CCIP_PROMETHEUS_PUSHGATEWAY"
+	E2E_CCIP_DASHBOARD_UID          = "E2E_CCIP_DASHBOARD_UID"
+
+	REDACTED_SECRET = "xxxxx"
+)
+
+// Secret is a string that is redacted whenever it is printed or serialized, configs that are read back
+// are written with MarshalTOMLWithSecrets instead. It is either a literal value or a reference like
+// "vault://path#key" resolved by a SecretProvider. Use Value to read the underlying string.
+type Secret string
+
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return REDACTED_SECRET
+}
+
+func (s Secret) GoString() string { return fmt.Sprintf("%q", s.String()) }
+
+func (s Secret) MarshalText() ([]byte, error) { return []byte(s.String()), nil }
+
+// UnmarshalText treats a redacted value as unset, so configs exported with redacted secrets can be read back.
+func (s *Secret) UnmarshalText(text []byte) error {
+	if string(text) == REDACTED_SECRET {
+		*s = ""
+		return nil
+	}
+	*s = Secret(text)
+	return nil
+}
+
+// Observability configures where test metrics, logs and annotations are pushed.
+// Every field falls back to its E2E_CCIP_* env var when unset.
+type Observability struct {
+	LokiEndpoint          *string `toml:",omitempty"`
+	LokiTenant            *string `toml:",omitempty"`
+	LokiBasicAuth         *Secret `toml:",omitempty"`
+	GrafanaURL            *string `toml:",omitempty"`
+	GrafanaToken          *Secret `toml:",omitempty"`
+	PrometheusPushgateway *string `toml:",omitempty"`
+	DashboardUID          *string `toml:",omitempty"`
+
+	AnnotateGrafana *bool    `toml:",omitempty"`
+	AnnotationTags  []string `toml:",omitempty"`
+}
+
+func stringOrEnv(value *string, envVar string) string {
+	if v := pointer.GetString(value); v != "" {
+		return v
+	}
+	return os.Getenv(envVar)
+}
+
+func secretOrEnv(value *Secret, envVar string) Secret {
+	if value != nil && *value != "" {
+		return *value
+	}
+	return Secret(os.Getenv(envVar))
+}
+
+func (o *Observability) GetLokiEndpoint() string {
+	if o == nil {
+		return os.Getenv(E2E_CCIP_LOKI_ENDPOINT)
+	}
+	return stringOrEnv(o.LokiEndpoint, E2E_CCIP_LOKI_ENDPOINT)
+}
+
+func (o *Observability) GetLokiTenant() string {
+	if o == nil {
+		return os.Getenv(E2E_CCIP_LOKI_TENANT)
+	}
+	return stringOrEnv(o.LokiTenant, E2E_CCIP_LOKI_TENANT)
+}
+
+func (o *Observability) GetLokiBasicAuth() Secret {
+	if o == nil {
+		return Secret(os.Getenv(E2E_CCIP_LOKI_BASIC_AUTH))
+	}
+	return secretOrEnv(o.LokiBasicAuth, E2E_CCIP_LOKI_BASIC_AUTH)
+}
+
+func (o *Observability) GetGrafanaURL() string {
+	if o == nil {
+		return os.Getenv(E2E_CCIP_GRAFANA_URL)
+	}
+	return stringOrEnv(o.GrafanaURL, E2E_CCIP_GRAFANA_URL)
+}
+
+func (o *Observability) GetGrafanaToken() Secret {
+	if o == nil {
+		return Secret(os.Getenv(E2E_CCIP_GRAFANA_TOKEN))
+	}
+	return secretOrEnv(o.GrafanaToken, E2E_CCIP_GRAFANA_TOKEN)
+}
+
+func (o *Observability) GetPrometheusPushgateway() string {
+	if o == nil {
+		return os.Getenv(E2E_CCIP_PROMETHEUS_PUSHGATEWAY)
+	}
+	return stringOrEnv(o.PrometheusPushgateway, E2E_CCIP_PROMETHEUS_PUSHGATEWAY)
+}
+
+func (o *Observability) GetDashboardUID() string {
+	if o == nil {
+		return os.Getenv(E2E_CCIP_DASHBOARD_UID)
+	}
+	return stringOrEnv(o.DashboardUID, E2E_CCIP_DASHBOARD_UID)
+}
+
+// Enabled returns true when logs can be pushed to Loki and Grafana is reachable,
+// which is the minimum the reporters need.
+func (o *Observability) Enabled() bool {
+	return o.GetLokiEndpoint() != "" && o.GetGrafanaURL() != ""
+}
+
+// GrafanaEnabled returns true when annotations can be posted to Grafana.
+func (o *Observability) GrafanaEnabled() bool {
+	return o.GetGrafanaURL() != "" && o.GetGrafanaToken() != ""
+}
+
+func (o *Observability) Validate() error {
+	for _, endpoint := range []struct {
+		name  string
+		value string
+	}{
+		{"LokiEndpoint", o.GetLokiEndpoint()},
+		{"GrafanaURL", o.GetGrafanaURL()},
+		{"PrometheusPushgateway", o.GetPrometheusPushgateway()},
+	} {
+		if endpoint.value == "" {
+			continue
+		}
+		if err := validateURL(endpoint.value); err != nil {
+			return fieldError("Observability."+endpoint.name, err)
+		}
+	}
+	if o.GetLokiBasicAuth() != "" && o.GetLokiEndpoint() == "" {
+		return fmt.Errorf("Observability.LokiBasicAuth is set but LokiEndpoint is not")
+	}
+	if o.GetGrafanaToken() != "" && o.GetGrafanaURL() == "" {
+		return fmt.Errorf("Observability.GrafanaToken is set but GrafanaURL is not")
+	}
+	if o.GetAnnotateGrafana() && o.GetDashboardUID() == "" {
+		return fmt.Errorf("Observability.AnnotateGrafana requires DashboardUID")
+	}
+	return nil
+}
+
+// validateURL checks that raw is an absolute http(s) URL.
+func validateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return withKind(ErrInvalidEndpoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return withKind(ErrInvalidEndpoint, fmt.Errorf("%q must use http or https scheme", raw))
+	}
+	if u.Host == "" {
+		return withKind(ErrInvalidEndpoint, fmt.Errorf("%q has no host", raw))
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package ccip
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObservabilitySecretsAreRedacted(t *testing.T) {
+	token := Secret("super-secret-token")
+	auth := Secret("user:password")
+	o := &Observability{
+		LokiEndpoint:  pointer.ToString("https://loki.example.com"),
+		LokiBasicAuth: &auth,
+		GrafanaURL:    pointer.ToString("https://grafana.example.com"),
+		GrafanaToken:  &token,
+	}
+	require.NoError(t, o.Validate())
+	require.True(t, o.Enabled())
+	require.Equal(t, "super-secret-token", secretValue(t, o.GetGrafanaToken()))
+
+	asJSON, err := json.Marshal(o)
+	require.NoError(t, err)
+	asTOML, err := toml.Marshal(o)
+	require.NoError(t, err)
+	for _, serialized := range []string{
+		string(asJSON),
+		string(asTOML),
+		fmt.Sprintf("%v", *o.GrafanaToken),
+		fmt.Sprintf("%+v", o.GetLokiBasicAuth()),
+		fmt.Sprintf("%#v", *o.GrafanaToken),
+	} {
+		require.NotContains(t, serialized, "super-secret-token")
+		require.NotContains(t, serialized, "password")
+	}
+
+	var decoded Observability
+	require.NoError(t, toml.Unmarshal([]byte(`GrafanaToken = "from-toml"`), &decoded))
+	require.Equal(t, "from-toml", secretValue(t, decoded.GetGrafanaToken()))
+}
+
+func TestObservabilityValidate(t *testing.T) {
+	t.Setenv(E2E_CCIP_LOKI_ENDPOINT, "")
+	t.Setenv(E2E_CCIP_GRAFANA_URL, "")
+	t.Setenv(E2E_CCIP_PROMETHEUS_PUSHGATEWAY, "")
+	require.False(t, (&Observability{}).Enabled())
+
+	t.Setenv(E2E_CCIP_GRAFANA_URL, "grafana.example.com")
+	require.Error(t, (&Observability{}).Validate())
+
+	t.Setenv(E2E_CCIP_GRAFANA_URL, "http://grafana:3000")
+	require.NoError(t, (&Observability{}).Validate())
+}
+
+func TestObservabilityValidateReportsEndpointsInOrder(t *testing.T) {
+	o := &Observability{
+		LokiEndpoint:          pointer.ToString("loki:3100"),
+		GrafanaURL:            pointer.ToString("grafana:3000"),
+		PrometheusPushgateway: pointer.ToString("pushgateway:9091"),
+	}
+	for i := 0; i < 20; i++ {
+		require.ErrorContains(t, o.Validate(), "Observability.LokiEndpoint")
+	}
+	o.LokiEndpoint = pointer.ToString("http://loki:3100")
+	require.ErrorContains(t, o.Validate(), "Observability.GrafanaURL")
+}
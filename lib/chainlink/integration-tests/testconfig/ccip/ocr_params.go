@@ -0,0 +1,86 @@
+package ccip
+
+import (
+	"time"
+)
+
+// OCRParams are the OCR3 parameters of a CCIP plugin config set in CCIPHome. Unset fields keep the
+// values deployment sets today, see DefaultOCRParams.
+type OCRParams struct {
+	DeltaProgress                           *Duration `toml:",omitempty"`
+	DeltaResend                             *Duration `toml:",omitempty"`
+	DeltaInitial                            *Duration `toml:",omitempty"`
+	DeltaRound                              *Duration `toml:",omitempty"`
+	DeltaGrace                              *Duration `toml:",omitempty"`
+	DeltaCertifiedCommitRequest             *Duration `toml:",omitempty"`
+	DeltaStage                              *Duration `toml:",omitempty"`
+	Rmax                                    *uint64   `toml:",omitempty"`
+	MaxDurationQuery                        *Duration `toml:",omitempty"`
+	MaxDurationObservation                  *Duration `toml:",omitempty"`
+	MaxDurationShouldAcceptAttestedReport   *Duration `toml:",omitempty"`
+	MaxDurationShouldTransmitAcceptedReport *Duration `toml:",omitempty"`
+}
+
+// ResolvedOCRParams are OCRParams with every value set.
+type ResolvedOCRParams struct {
+	DeltaProgress                           time.Duration
+	DeltaResend                             time.Duration
+	DeltaInitial                            time.Duration
+	DeltaRound                              time.Duration
+	DeltaGrace                              time.Duration
+	DeltaCertifiedCommitRequest             time.Duration
+	DeltaStage                              time.Duration
+	Rmax                                    uint64
+	MaxDurationQuery                        time.Duration
+	MaxDurationObservation                  time.Duration
+	MaxDurationShouldAcceptAttestedReport   time.Duration
+	MaxDurationShouldTransmitAcceptedReport time.Duration
+}
+
+// DefaultOCRParams mirror the parameters deployment hardcodes for the commit and exec plugins.
+var DefaultOCRParams = ResolvedOCRParams{
+	DeltaProgress:                           30 * time.Second,
+	DeltaResend:                             10 * time.Second,
+	DeltaInitial:                            20 * time.Second,
+	DeltaRound:                              2 * time.Second,
+	DeltaGrace:                              2 * time.Second,
+	DeltaCertifiedCommitRequest:             10 * time.Second,
+	DeltaStage:                              10 * time.Second,
+	Rmax:                                    3,
+	MaxDurationQuery:                        500 * time.Millisecond,
+	MaxDurationObservation:                  5 * time.Second,
+	MaxDurationShouldAcceptAttestedReport:   10 * time.Second,
+	MaxDurationShouldTransmitAcceptedReport: 10 * time.Second,
+}
+
+// Resolve applies the params over DefaultOCRParams.
+func (p *OCRParams) Resolve() ResolvedOCRParams {
+	resolved := DefaultOCRParams
+	if p == nil {
+		return resolved
+	}
+	for _, d := range []struct {
+		value  *Duration
+		target *time.Duration
+	}{
+		{p.DeltaProgress, &resolved.DeltaProgress},
+		{p.DeltaResend, &resolved.DeltaResend},
+		{p.DeltaInitial, &resolved.DeltaInitial},
+		{p.DeltaRound, &resolved.DeltaRound},
+		{p.DeltaGrace, &resolved.DeltaGrace},
+		{p.DeltaCertifiedCommitRequest, &resolved.DeltaCertifiedCommitRequest},
+		{p.DeltaStage, &resolved.DeltaStage},
+		{p.MaxDurationQuery, &resolved.MaxDurationQuery},
+		{p.MaxDurationObservation, &resolved.MaxDurationObservation},
+		{p.MaxDurationShouldAcceptAttestedReport, &resolved.MaxDurationShouldAcceptAttestedReport},
+		{p.MaxDurationShouldTransmitAcceptedReport, &resolved.MaxDurationShouldTransmitAcceptedReport},
+	} {
+		if d.value != nil {
+			*d.target = d.value.Duration
+		}
+	}
+	if p.Rmax != nil {
+		resolved.Rmax = *p.Rmax
+	}
+	return resolved
+}
@@ -0,0 +1,134 @@
+package ccip
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	ORDERING_STRICT_PER_SENDER = "strictPerSender"
+	ORDERING_NONE              = "none"
+
+	DEFAULT_ORDERING_ASSERTION = ORDERING_NONE
+)
+
+var orderingAssertions = []string{ORDERING_STRICT_PER_SENDER, ORDERING_NONE}
+
+// GetOrderingAssertion returns the ordering the assert phase checks on the lane, from OrderingAssertions
+// keyed by "source->dest" and falling back to DefaultOrdering.
+func (o *Config) GetOrderingAssertion(lane ResolvedLane) string {
+	if laneKey, ok := laneKeyFor(lane, o.OrderingAssertions); ok {
+		return o.OrderingAssertions[laneKey]
+	}
+	if v := pointer.GetString(o.DefaultOrdering); v != "" {
+		return v
+	}
+	return DEFAULT_ORDERING_ASSERTION
+}
+
+// ExecutedMessage is a message as observed on the destination chain, in execution order.
+type ExecutedMessage struct {
+	Sender string
+	Nonce  uint64
+	SeqNr  uint64
+}
+
+// CheckOrdering checks the messages executed on a lane, in execution order, against the lane's ordering assertion.
+func CheckOrdering(assertion string, executed []ExecutedMessage) error {
+	switch assertion {
+	case ORDERING_NONE:
+		return nil
+	case ORDERING_STRICT_PER_SENDER:
+	default:
+		return fmt.Errorf("unknown ordering assertion %q, expected one of %v", assertion, orderingAssertions)
+	}
+	last := make(map[string]ExecutedMessage)
+	for _, msg := range executed {
+		if prev, ok := last[msg.Sender]; ok && msg.Nonce <= prev.Nonce {
+			return fmt.Errorf("sender %s: message with nonce %d (seqNr %d) executed after nonce %d (seqNr %d)",
+				msg.Sender, msg.Nonce, msg.SeqNr, prev.Nonce, prev.SeqNr)
+		}
+		last[msg.Sender] = msg
+	}
+	return nil
+}
+
+func (o *Config) validateOrderingAssertions() error {
+	if v := pointer.GetString(o.DefaultOrdering); v != "" && !containsString(orderingAssertions, v) {
+		return fmt.Errorf("DefaultOrdering %q is not one of %v", v, orderingAssertions)
+	}
+	laneKeys := make([]string, 0, len(o.OrderingAssertions))
+	for laneKey := range o.OrderingAssertions {
+		laneKeys = append(laneKeys, laneKey)
+	}
+	sort.Strings(laneKeys)
+	// dests with an explicit assertion on some lane are left to those lanes
+	overridden := make(map[uint64]bool)
+	for _, laneKey := range laneKeys {
+		assertion := o.OrderingAssertions[laneKey]
+		if !containsString(orderingAssertions, assertion) {
+			return fmt.Errorf("OrderingAssertions.%s %q is not one of %v", laneKey, assertion, orderingAssertions)
+		}
+		_, dest, err := ParseLaneKey(laneKey)
+		if err != nil {
+			return fieldError("OrderingAssertions", err)
+		}
+		destSelector, err := o.ResolveChainSelector(dest)
+		if err != nil {
+			return fieldError("OrderingAssertions."+laneKey, err)
+		}
+		overridden[destSelector] = true
+		if assertion != ORDERING_STRICT_PER_SENDER {
+			continue
+		}
+		if field := o.outOfOrderField(destSelector); field != "" {
+			return fmt.Errorf("OrderingAssertions.%s is %s, but %s executes the lane out of order", laneKey, assertion, field)
+		}
+	}
+	if pointer.GetString(o.DefaultOrdering) != ORDERING_STRICT_PER_SENDER || o.ExtraArgs == nil {
+		return nil
+	}
+	if o.ExtraArgs.Default != nil && pointer.GetBool(o.ExtraArgs.Default.OutOfOrder) {
+		return fmt.Errorf("DefaultOrdering is %s, but ExtraArgs.Default.OutOfOrder executes lanes out of order", ORDERING_STRICT_PER_SENDER)
+	}
+	refs := make([]string, 0, len(o.ExtraArgs.PerDest))
+	for ref := range o.ExtraArgs.PerDest {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	for _, ref := range refs {
+		selector, err := o.ResolveChainSelector(ref)
+		if err != nil || overridden[selector] {
+			continue
+		}
+		if field := o.outOfOrderField(selector); field != "" {
+			return fmt.Errorf("DefaultOrdering is %s, but %s executes lanes towards %s out of order, set OrderingAssertions for them",
+				ORDERING_STRICT_PER_SENDER, field, ref)
+		}
+	}
+	return nil
+}
+
+// outOfOrderField returns the path of the field making messages towards the dest execute out of order, or "".
+func (o *Config) outOfOrderField(destSelector uint64) string {
+	if o.ExtraArgs == nil {
+		return ""
+	}
+	for ref, override := range o.ExtraArgs.PerDest {
+		if override == nil || override.OutOfOrder == nil {
+			continue
+		}
+		if selector, err := o.ResolveChainSelector(ref); err == nil && selector == destSelector {
+			if *override.OutOfOrder {
+				return fmt.Sprintf("ExtraArgs.PerDest.%s.OutOfOrder", ref)
+			}
+			return ""
+		}
+	}
+	if o.ExtraArgs.Default != nil && pointer.GetBool(o.ExtraArgs.Default.OutOfOrder) {
+		return "ExtraArgs.Default.OutOfOrder"
+	}
+	return ""
+}
@@ -0,0 +1,91 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const orderingNetworks = `
+[PrivateEthereumNetworks.SIMULATED_1.EthereumChainConfig]
+chain_id = 1337
+
+[PrivateEthereumNetworks.SIMULATED_2.EthereumChainConfig]
+chain_id = 2337
+`
+
+func TestGetOrderingAssertion(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(`
+DefaultOrdering = 'strictPerSender'
+
+[OrderingAssertions]
+'SIMULATED_1->SIMULATED_2' = 'none'
+
+[ExtraArgs.PerDest.SIMULATED_2]
+OutOfOrder = true
+`+orderingNetworks), &cfg))
+	require.NoError(t, cfg.validateOrderingAssertions())
+
+	toSimulated2 := ResolvedLane{Source: "SIMULATED_1", Dest: "SIMULATED_2", SourceSelector: 3379446385462418246, DestSelector: 12922642891491394802}
+	toSimulated1 := ResolvedLane{Source: "SIMULATED_2", Dest: "SIMULATED_1", SourceSelector: 12922642891491394802, DestSelector: 3379446385462418246}
+	require.Equal(t, ORDERING_NONE, cfg.GetOrderingAssertion(toSimulated2))
+	require.Equal(t, ORDERING_STRICT_PER_SENDER, cfg.GetOrderingAssertion(toSimulated1))
+	require.Equal(t, DEFAULT_ORDERING_ASSERTION, (&Config{}).GetOrderingAssertion(toSimulated1))
+}
+
+func TestValidateOrderingAssertions(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{
+			name:   "strict lane towards an out of order dest",
+			config: "[OrderingAssertions]\n'SIMULATED_1->SIMULATED_2' = 'strictPerSender'\n[ExtraArgs.PerDest.SIMULATED_2]\nOutOfOrder = true\n",
+			err:    "OrderingAssertions.SIMULATED_1->SIMULATED_2 is strictPerSender, but ExtraArgs.PerDest.SIMULATED_2.OutOfOrder executes the lane out of order",
+		},
+		{
+			name:   "strict lane inheriting out of order from the default",
+			config: "[OrderingAssertions]\n'SIMULATED_1->SIMULATED_2' = 'strictPerSender'\n[ExtraArgs.Default]\nOutOfOrder = true\n",
+			err:    "OrderingAssertions.SIMULATED_1->SIMULATED_2 is strictPerSender, but ExtraArgs.Default.OutOfOrder executes the lane out of order",
+		},
+		{
+			name:   "strict default with an out of order dest",
+			config: "DefaultOrdering = 'strictPerSender'\n[ExtraArgs.PerDest.SIMULATED_1]\nOutOfOrder = true\n",
+			err:    "DefaultOrdering is strictPerSender, but ExtraArgs.PerDest.SIMULATED_1.OutOfOrder executes lanes towards SIMULATED_1 out of order, set OrderingAssertions for them",
+		},
+		{
+			name:   "unknown assertion",
+			config: "[OrderingAssertions]\n'SIMULATED_1->SIMULATED_2' = 'global'\n",
+			err:    `OrderingAssertions.SIMULATED_1->SIMULATED_2 "global" is not one of [strictPerSender none]`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte(tc.config+orderingNetworks), &cfg))
+			require.EqualError(t, cfg.validateOrderingAssertions(), tc.err)
+		})
+	}
+}
+
+func TestCheckOrdering(t *testing.T) {
+	inOrder := []ExecutedMessage{
+		{Sender: "0xa", Nonce: 1, SeqNr: 1},
+		{Sender: "0xb", Nonce: 1, SeqNr: 2},
+		{Sender: "0xa", Nonce: 2, SeqNr: 3},
+		{Sender: "0xb", Nonce: 2, SeqNr: 4},
+	}
+	// senders interleaving is fine, only each sender's own messages must stay in order
+	interleaved := []ExecutedMessage{inOrder[1], inOrder[0], inOrder[3], inOrder[2]}
+	reordered := []ExecutedMessage{inOrder[0], inOrder[2], inOrder[1], inOrder[3]}
+	swapped := []ExecutedMessage{inOrder[2], inOrder[0], inOrder[1], inOrder[3]}
+
+	require.NoError(t, CheckOrdering(ORDERING_STRICT_PER_SENDER, inOrder))
+	require.NoError(t, CheckOrdering(ORDERING_STRICT_PER_SENDER, interleaved))
+	require.NoError(t, CheckOrdering(ORDERING_STRICT_PER_SENDER, reordered))
+	require.EqualError(t, CheckOrdering(ORDERING_STRICT_PER_SENDER, swapped), "sender 0xa: message with nonce 1 (seqNr 1) executed after nonce 2 (seqNr 3)")
+	require.NoError(t, CheckOrdering(ORDERING_NONE, swapped))
+	require.EqualError(t, CheckOrdering("global", inOrder), `unknown ordering assertion "global", expected one of [strictPerSender none]`)
+}
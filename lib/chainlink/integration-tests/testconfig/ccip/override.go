@@ -0,0 +1,72 @@
+package ccip
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+// overrideMu guards the override stacks of every config.
+var overrideMu sync.Mutex
+
+// pushedOverride is an entry of the override stack, the config as it was before the push.
+type pushedOverride struct {
+	saved *Config
+	// owner is the name of the test that pushed the override, empty for PushOverride
+	owner string
+}
+
+// PushOverride applies the fields set in override on top of the config, in place, and returns restore,
+// which brings the config back to its state before the push. Restoring an override also restores the ones
+// pushed after it, restoring twice does nothing. PushOverride is not safe for concurrent use, parallel
+// tests should push onto their own copy of the config, WithOverride rejects the ones that don't.
+func (o *Config) PushOverride(override *Config) (restore func()) {
+	overrideMu.Lock()
+	defer overrideMu.Unlock()
+	return o.pushOverride(override, "")
+}
+
+// WithOverride pushes the override for the duration of the test, it's restored once the test and its
+// subtests are done. It fails the test if the config holds an override of a test that is neither t nor
+// one of its parents, which happens when parallel tests share the config.
+func (o *Config) WithOverride(t testing.TB, override *Config) {
+	t.Helper()
+	overrideMu.Lock()
+	if owner, ok := o.foreignOverride(t.Name()); ok {
+		overrideMu.Unlock()
+		t.Fatalf("config is overridden by test %s, parallel tests must override their own copy of the config", owner)
+		return
+	}
+	restore := o.pushOverride(override, t.Name())
+	overrideMu.Unlock()
+	t.Cleanup(restore)
+}
+
+// foreignOverride returns the test owning the latest override, if it's neither test nor one of its parents.
+func (o *Config) foreignOverride(test string) (string, bool) {
+	if len(o.overrides) == 0 {
+		return "", false
+	}
+	owner := o.overrides[len(o.overrides)-1].owner
+	if owner == "" || owner == test || strings.HasPrefix(test, owner+"/") {
+		return "", false
+	}
+	return owner, true
+}
+
+func (o *Config) pushOverride(override *Config, owner string) func() {
+	pushed := &pushedOverride{saved: mergeConfig(o, nil), owner: owner}
+	depth := len(o.overrides)
+	merged := mergeConfig(o, override)
+	merged.overrides = append(o.overrides[:depth:depth], pushed)
+	*o = *merged
+	return func() {
+		overrideMu.Lock()
+		defer overrideMu.Unlock()
+		if len(o.overrides) <= depth || o.overrides[depth] != pushed {
+			return
+		}
+		// the saved config holds the stack as it was before the push
+		*o = *pushed.saved
+	}
+}
@@ -0,0 +1,75 @@
+package ccip
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushOverride(t *testing.T) {
+	cfg := &Config{CLNode: &NodeConfig{NoOfPluginNodes: pointer.ToInt(4), NoOfBootstraps: pointer.ToInt(1)}}
+
+	restoreNodes := cfg.PushOverride(&Config{CLNode: &NodeConfig{NoOfPluginNodes: pointer.ToInt(8)}})
+	require.Equal(t, 8, *cfg.CLNode.NoOfPluginNodes)
+	require.Equal(t, 1, *cfg.CLNode.NoOfBootstraps)
+
+	restoreRMN := cfg.PushOverride(&Config{RMNConfig: RMNConfig{NoOfNodes: pointer.ToInt(2)}})
+	require.Equal(t, 8, *cfg.CLNode.NoOfPluginNodes)
+	require.Equal(t, 2, *cfg.RMNConfig.NoOfNodes)
+
+	restoreRMN()
+	require.Nil(t, cfg.RMNConfig.NoOfNodes)
+	require.Equal(t, 8, *cfg.CLNode.NoOfPluginNodes)
+
+	// restoring an earlier override restores the later ones too, restoring again does nothing
+	restoreRMN = cfg.PushOverride(&Config{RMNConfig: RMNConfig{NoOfNodes: pointer.ToInt(2)}})
+	restoreNodes()
+	require.Equal(t, &Config{CLNode: &NodeConfig{NoOfPluginNodes: pointer.ToInt(4), NoOfBootstraps: pointer.ToInt(1)}}, cfg)
+	restoreRMN()
+	restoreNodes()
+	require.Equal(t, &Config{CLNode: &NodeConfig{NoOfPluginNodes: pointer.ToInt(4), NoOfBootstraps: pointer.ToInt(1)}}, cfg)
+}
+
+func TestWithOverride(t *testing.T) {
+	cfg := &Config{CLNode: &NodeConfig{NoOfPluginNodes: pointer.ToInt(4)}}
+	t.Run("override", func(t *testing.T) {
+		cfg.WithOverride(t, &Config{CLNode: &NodeConfig{NoOfPluginNodes: pointer.ToInt(8)}})
+		t.Run("nested", func(t *testing.T) {
+			cfg.WithOverride(t, &Config{CLNode: &NodeConfig{NoOfPluginNodes: pointer.ToInt(16)}})
+			require.Equal(t, 16, *cfg.CLNode.NoOfPluginNodes)
+		})
+		require.Equal(t, 8, *cfg.CLNode.NoOfPluginNodes)
+	})
+	require.Equal(t, 4, *cfg.CLNode.NoOfPluginNodes)
+}
+
+// fakeTB records what WithOverride does with a test, a sibling can't be run in parallel deterministically.
+type fakeTB struct {
+	testing.TB
+	name     string
+	failure  string
+	cleanups []func()
+}
+
+func (f *fakeTB) Name() string                      { return f.name }
+func (f *fakeTB) Helper()                           {}
+func (f *fakeTB) Cleanup(cleanup func())            { f.cleanups = append(f.cleanups, cleanup) }
+func (f *fakeTB) Fatalf(format string, args ...any) { f.failure = fmt.Sprintf(format, args...) }
+
+func TestWithOverrideRejectsParallelTests(t *testing.T) {
+	cfg := &Config{CLNode: &NodeConfig{NoOfPluginNodes: pointer.ToInt(4)}}
+	first := &fakeTB{name: "TestLanes/first"}
+	cfg.WithOverride(first, &Config{CLNode: &NodeConfig{NoOfPluginNodes: pointer.ToInt(8)}})
+	require.Empty(t, first.failure)
+
+	second := &fakeTB{name: "TestLanes/second"}
+	cfg.WithOverride(second, &Config{CLNode: &NodeConfig{NoOfPluginNodes: pointer.ToInt(16)}})
+	require.Equal(t, "config is overridden by test TestLanes/first, parallel tests must override their own copy of the config", second.failure)
+	require.Empty(t, second.cleanups)
+	require.Equal(t, 8, *cfg.CLNode.NoOfPluginNodes)
+
+	first.cleanups[0]()
+	require.Equal(t, 4, *cfg.CLNode.NoOfPluginNodes)
+}
@@ -0,0 +1,80 @@
+package ccip
+
+import (
+	"fmt"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	PHASE_INFRA     = "infra"
+	PHASE_CONTRACTS = "contracts"
+	PHASE_JOBS      = "jobs"
+	PHASE_TRAFFIC   = "traffic"
+	PHASE_ASSERT    = "assert"
+)
+
+// AllPhases lists the test phases in the order they run.
+var AllPhases = []string{PHASE_INFRA, PHASE_CONTRACTS, PHASE_JOBS, PHASE_TRAFFIC, PHASE_ASSERT}
+
+// phasePrerequisites lists the phases whose output a phase consumes.
+var phasePrerequisites = map[string][]string{
+	PHASE_CONTRACTS: {PHASE_INFRA},
+	PHASE_JOBS:      {PHASE_INFRA, PHASE_CONTRACTS},
+	PHASE_TRAFFIC:   {PHASE_INFRA, PHASE_CONTRACTS, PHASE_JOBS},
+	PHASE_ASSERT:    {PHASE_TRAFFIC},
+}
+
+// ShouldRun returns true if the phase is selected; all phases run when Phases is empty.
+func (o *Config) ShouldRun(phase string) bool {
+	return len(o.Phases) == 0 || containsString(o.Phases, phase)
+}
+
+// ReadsPersistedState returns true if a skipped phase's output has to be read from the
+// state of the reused environment instead of being produced by this run.
+func (o *Config) ReadsPersistedState() bool {
+	for _, phase := range AllPhases {
+		if !o.ShouldRun(phase) {
+			continue
+		}
+		for _, prerequisite := range phasePrerequisites[phase] {
+			if !o.ShouldRun(prerequisite) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (o *Config) validatePhases() error {
+	seen := make(map[string]bool)
+	for _, phase := range o.Phases {
+		if !containsString(AllPhases, phase) {
+			return fmt.Errorf("Phases contains unknown phase %q, must be one of %v", phase, AllPhases)
+		}
+		if seen[phase] {
+			return fmt.Errorf("Phases contains %s more than once", phase)
+		}
+		seen[phase] = true
+	}
+	reusing := o.Lifecycle != nil && pointer.GetString(o.Lifecycle.ReuseEnvironmentID) != ""
+	for _, phase := range AllPhases {
+		if !o.ShouldRun(phase) {
+			continue
+		}
+		for _, prerequisite := range phasePrerequisites[phase] {
+			if o.ShouldRun(prerequisite) || reusing {
+				continue
+			}
+			// contracts can also come from the address book instead of an earlier run
+			if prerequisite == PHASE_CONTRACTS && len(o.ExistingContracts) > 0 {
+				continue
+			}
+			if prerequisite == PHASE_CONTRACTS {
+				return fmt.Errorf("Phases runs %s without %s, which requires ExistingContracts or Lifecycle.ReuseEnvironmentID", phase, prerequisite)
+			}
+			return fmt.Errorf("Phases runs %s without %s, which requires Lifecycle.ReuseEnvironmentID", phase, prerequisite)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPhasesDefaults(t *testing.T) {
+	var cfg Config
+	for _, phase := range AllPhases {
+		require.True(t, cfg.ShouldRun(phase))
+	}
+	require.False(t, cfg.ReadsPersistedState())
+	require.NoError(t, cfg.validatePhases())
+}
+
+func TestReadsPersistedState(t *testing.T) {
+	for _, tc := range []struct {
+		phases []string
+		reads  bool
+	}{
+		{phases: []string{PHASE_INFRA}},
+		{phases: []string{PHASE_INFRA, PHASE_CONTRACTS}},
+		{phases: []string{PHASE_CONTRACTS}, reads: true},
+		{phases: []string{PHASE_TRAFFIC, PHASE_ASSERT}, reads: true},
+		{phases: []string{PHASE_ASSERT}, reads: true},
+	} {
+		cfg := Config{Phases: tc.phases}
+		require.Equal(t, tc.reads, cfg.ReadsPersistedState(), "%v", tc.phases)
+	}
+}
+
+func TestValidatePhases(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "all phases", content: "Phases = ['infra', 'contracts', 'jobs', 'traffic', 'assert']"},
+		{name: "infra only", content: "Phases = ['infra']"},
+		{name: "reused environment", content: "Phases = ['traffic', 'assert']\n[Lifecycle]\nReuseEnvironmentID = 'env-1'"},
+		{name: "jobs on existing contracts", content: "Phases = ['infra', 'jobs']\n[ExistingContracts.ethereum-mainnet]\nDeployMissing = true"},
+		{name: "unknown phase", content: "Phases = ['infra', 'cleanup']", err: `Phases contains unknown phase "cleanup", must be one of [infra contracts jobs traffic assert]`},
+		{name: "phase twice", content: "Phases = ['infra', 'infra']", err: "Phases contains infra more than once"},
+		{name: "contracts without infra", content: "Phases = ['contracts']", err: "Phases runs contracts without infra, which requires Lifecycle.ReuseEnvironmentID"},
+		{name: "jobs without contracts", content: "Phases = ['infra', 'jobs']", err: "Phases runs jobs without contracts, which requires ExistingContracts or Lifecycle.ReuseEnvironmentID"},
+		{name: "assert without traffic", content: "Phases = ['infra', 'contracts', 'jobs', 'assert']", err: "Phases runs assert without traffic, which requires Lifecycle.ReuseEnvironmentID"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte(tc.content), &cfg))
+			err := cfg.validatePhases()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
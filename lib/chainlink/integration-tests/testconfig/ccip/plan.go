@@ -0,0 +1,281 @@
+package ccip
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+	chainselectors "github.com/smartcontractkit/chain-selectors"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/blockchain"
+)
+
+// EnvironmentPlan is the resolved summary of what bringing up the environment described by a config would create.
+type EnvironmentPlan struct {
+	HomeChainSelector uint64
+	FeedChainSelector uint64
+	// Chains are sorted by selector
+	Chains []PlanChain
+	Nodes  PlanNodes
+	RMN    PlanRMN
+	JD     PlanJD
+	// Lanes are all source->dest pairs between the chains, sorted
+	Lanes []string
+	// WaitBudgets are how long the assertions of each lane wait, keyed by lane
+	WaitBudgets map[string]LaneWaitBudget
+	// Tokens are sorted by symbol
+	Tokens []PlanToken
+	// Containers is an estimate of the number of containers started locally
+	Containers int
+	// Ports are the host ports claimed by the config as assigned by its PortAllocator, sorted by port
+	Ports []PlanPort
+	// Sizing are the fields filled in by AutoSize
+	Sizing []SizingDecision
+	// Costs is the estimated spend of the LoadProfile, nil without one
+	Costs *CostEstimate
+	// Funding is what nodes, senders and deployers are funded with, sorted by account and chain selector
+	Funding []PlanFunding
+	// Startup are the stages the components are started in
+	Startup []StartupStage
+}
+
+type PlanChain struct {
+	Name            string
+	Selector        uint64
+	ChainID         int64
+	ClientType      string
+	Simulated       bool
+	ContractVersion string
+}
+
+type PlanNodes struct {
+	Bootstraps        int
+	StandbyBootstraps int
+	PluginNodes       int
+	// Image is not part of this config, callers holding the top level test config can fill it in
+	Image string
+}
+
+type PlanRMN struct {
+	Nodes      int
+	ProxyImage string
+	AFNImage   string
+}
+
+type PlanJD struct {
+	Image string
+	GRPC  string
+	WSRPC string
+}
+
+type PlanToken struct {
+	Symbol   string
+	Decimals uint8
+	PoolType string
+}
+
+// PlanFunding is what each account of a kind is funded with on a chain, amounts in whole units and "-"
+// where nothing is configured.
+type PlanFunding struct {
+	Account  string
+	Chain    string
+	Selector uint64
+	Native   string
+	LINK     string
+}
+
+type PlanPort struct {
+	Port     int
+	Claimant string
+}
+
+// Plan resolves the config against the selected networks without starting anything. Values read from
+// env vars that aren't set are shown as "<unset: ENV_VAR>" instead of failing, so a plan can be printed
+// for incomplete configs. AutoSize is applied if it hasn't been yet.
+func Plan(cfg *Config, evmNetworks []blockchain.EVMNetwork) (*EnvironmentPlan, error) {
+	cfg = cfg.ApplyAutoSize()
+	plan := &EnvironmentPlan{
+		Nodes: PlanNodes{PluginNodes: cfg.CLNode.GetNoOfPluginNodes()},
+		RMN: PlanRMN{
+			Nodes:      pointer.GetInt(cfg.RMNConfig.NoOfNodes),
+			ProxyImage: planImage(cfg.RMNConfig.ProxyImage, E2E_RMN_RAGEPROXY_IMAGE, cfg.RMNConfig.ProxyVersion, E2E_RMN_RAGEPROXY_VERSION),
+			AFNImage:   planImage(cfg.RMNConfig.AFNImage, E2E_RMN_AFN2PROXY_IMAGE, cfg.RMNConfig.AFNVersion, E2E_RMN_AFN2PROXY_VERSION),
+		},
+		JD: PlanJD{
+			Image: planImage(cfg.JobDistributorConfig.Image, E2E_JD_IMAGE, cfg.JobDistributorConfig.Version, E2E_JD_VERSION),
+			GRPC:  planValue(cfg.JobDistributorConfig.JDGRPC, E2E_JD_GRPC),
+			WSRPC: planValue(cfg.JobDistributorConfig.JDWSRPC, E2E_JD_WSRPC),
+		},
+	}
+	if cfg.CLNode != nil {
+		plan.Nodes.Bootstraps = pointer.GetInt(cfg.CLNode.NoOfBootstraps)
+		plan.Nodes.StandbyBootstraps = cfg.CLNode.BootstrapFailover.GetStandbyBootstraps()
+	}
+	var err error
+	if plan.HomeChainSelector, err = cfg.GetHomeChainSelector(evmNetworks); err != nil {
+		return nil, fieldError("HomeChainSelector", err)
+	}
+	if plan.FeedChainSelector, err = cfg.GetFeedChainSelector(evmNetworks); err != nil {
+		return nil, fieldError("FeedChainSelector", err)
+	}
+	for _, network := range evmNetworks {
+		if network.ChainID <= 0 {
+			return nil, fmt.Errorf("network %s: invalid chain id %d", network.Name, network.ChainID)
+		}
+		selector, err := chainselectors.SelectorFromChainId(uint64(network.ChainID))
+		if err != nil {
+			return nil, fmt.Errorf("network %s: %w", network.Name, err)
+		}
+		plan.Chains = append(plan.Chains, PlanChain{
+			Name:            network.Name,
+			Selector:        selector,
+			ChainID:         network.ChainID,
+			ClientType:      string(network.ClientImplementation),
+			Simulated:       network.Simulated,
+			ContractVersion: cfg.GetContractVersion(selector),
+		})
+	}
+	sort.Slice(plan.Chains, func(i, j int) bool { return plan.Chains[i].Selector < plan.Chains[j].Selector })
+	plan.WaitBudgets = make(map[string]LaneWaitBudget)
+	for _, source := range plan.Chains {
+		for _, dest := range plan.Chains {
+			if source.Selector != dest.Selector {
+				lane := LaneKey(source.Name, dest.Name)
+				plan.Lanes = append(plan.Lanes, lane)
+				plan.WaitBudgets[lane] = cfg.GetLaneWaitBudget(source.Selector, dest.Selector)
+			}
+		}
+	}
+	sort.Strings(plan.Lanes)
+	for symbol, token := range cfg.Tokens {
+		poolType, err := cfg.GetTokenPoolType(symbol)
+		if err != nil {
+			return nil, fieldError("Tokens."+symbol, err)
+		}
+		plan.Tokens = append(plan.Tokens, PlanToken{Symbol: symbol, Decimals: token.GetDecimals(), PoolType: poolType})
+	}
+	sort.Slice(plan.Tokens, func(i, j int) bool { return plan.Tokens[i].Symbol < plan.Tokens[j].Symbol })
+	plan.Containers = cfg.estimateContainers(plan)
+	if plan.Startup, err = cfg.ResolveStartupPlan(); err != nil {
+		return nil, fieldError("StartupOrder", err)
+	}
+	allocator, err := cfg.PortAllocator()
+	if err != nil {
+		return nil, err
+	}
+	plan.Ports = allocator.Claims()
+	plan.Sizing = cfg.SizingDecisions()
+	if plan.Funding, err = cfg.planFunding(plan.Chains); err != nil {
+		return nil, err
+	}
+	if cfg.LoadProfile != nil {
+		selectors := make([]uint64, 0, len(plan.Chains))
+		for _, chain := range plan.Chains {
+			selectors = append(selectors, chain.Selector)
+		}
+		if plan.Costs, err = cfg.EstimateCost(selectors); err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
+
+// estimateContainers counts a node and its database per node, one container per simulated chain,
+// the RMN proxy and AFN per RMN node, JD and its database, the USDC attestation mock and the configured mocks.
+func (o *Config) estimateContainers(plan *EnvironmentPlan) int {
+	containers := 2*(plan.Nodes.Bootstraps+plan.Nodes.StandbyBootstraps+plan.Nodes.PluginNodes) + 2*plan.RMN.Nodes + 2
+	for _, chain := range plan.Chains {
+		if chain.Simulated {
+			containers++
+		}
+	}
+	if o.USDCMock.IsEnabled() {
+		containers++
+	}
+	return containers + len(o.Mocks)
+}
+
+func (p *EnvironmentPlan) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Chains (%d):\n", len(p.Chains))
+	for _, chain := range p.Chains {
+		var roles []string
+		if chain.Selector == p.HomeChainSelector {
+			roles = append(roles, "home")
+		}
+		if chain.Selector == p.FeedChainSelector {
+			roles = append(roles, "feed")
+		}
+		kind := "live"
+		if chain.Simulated {
+			kind = "simulated"
+		}
+		fmt.Fprintf(&b, "  %s selector=%d chainID=%d client=%s contracts=%s %s", chain.Name, chain.Selector, chain.ChainID, chain.ClientType, chain.ContractVersion, kind)
+		if len(roles) > 0 {
+			fmt.Fprintf(&b, " [%s]", strings.Join(roles, ","))
+		}
+		b.WriteString("\n")
+	}
+	image := p.Nodes.Image
+	if image == "" {
+		image = "<from test config>"
+	}
+	fmt.Fprintf(&b, "Nodes: %d plugin + %d bootstrap", p.Nodes.PluginNodes, p.Nodes.Bootstraps)
+	if p.Nodes.StandbyBootstraps > 0 {
+		fmt.Fprintf(&b, " + %d standby bootstrap", p.Nodes.StandbyBootstraps)
+	}
+	fmt.Fprintf(&b, ", image=%s\n", image)
+	if p.RMN.Nodes > 0 {
+		fmt.Fprintf(&b, "RMN: %d nodes, proxy=%s afn=%s\n", p.RMN.Nodes, p.RMN.ProxyImage, p.RMN.AFNImage)
+	} else {
+		b.WriteString("RMN: disabled\n")
+	}
+	fmt.Fprintf(&b, "JD: image=%s grpc=%s wsrpc=%s\n", p.JD.Image, p.JD.GRPC, p.JD.WSRPC)
+	fmt.Fprintf(&b, "Lanes (%d):\n", len(p.Lanes))
+	for _, lane := range p.Lanes {
+		budget := p.WaitBudgets[lane]
+		fmt.Fprintf(&b, "  %s commit<=%s exec<=%s\n", lane, budget.Commit, budget.Exec)
+	}
+	fmt.Fprintf(&b, "Tokens (%d):\n", len(p.Tokens))
+	for _, token := range p.Tokens {
+		fmt.Fprintf(&b, "  %s decimals=%d pool=%s\n", token.Symbol, token.Decimals, token.PoolType)
+	}
+	fmt.Fprintf(&b, "Startup (%d stages):\n", len(p.Startup))
+	for i, stage := range p.Startup {
+		fmt.Fprintf(&b, "  %d. %s\n", i+1, strings.Join(stage.Components, ", "))
+	}
+	fmt.Fprintf(&b, "Containers: ~%d\n", p.Containers)
+	fmt.Fprintf(&b, "Ports (%d):\n", len(p.Ports))
+	for _, port := range p.Ports {
+		fmt.Fprintf(&b, "  %d %s\n", port.Port, port.Claimant)
+	}
+	if len(p.Sizing) > 0 {
+		fmt.Fprintf(&b, "AutoSize (%d):\n", len(p.Sizing))
+		for _, decision := range p.Sizing {
+			fmt.Fprintf(&b, "  %s\n", decision)
+		}
+	}
+	if len(p.Funding) > 0 {
+		fmt.Fprintf(&b, "Funding (%d):\n", len(p.Funding))
+		for _, funding := range p.Funding {
+			fmt.Fprintf(&b, "  %s %s native=%s LINK=%s\n", funding.Account, funding.Chain, funding.Native, funding.LINK)
+		}
+	}
+	if p.Costs != nil {
+		b.WriteString("Estimated cost:\n")
+		b.WriteString(p.Costs.String())
+	}
+	return b.String()
+}
+
+func planValue(value *string, envVar string) string {
+	if v := stringOrEnv(value, envVar); v != "" {
+		return v
+	}
+	return fmt.Sprintf("<unset: %s>", envVar)
+}
+
+func planImage(image *string, imageEnv string, version *string, versionEnv string) string {
+	return planValue(image, imageEnv) + ":" + planValue(version, versionEnv)
+}
@@ -0,0 +1,45 @@
+package ccip
+
+import (
+	"os"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/blockchain"
+)
+
+// loadDefaultConfig reads the CCIP section and the selected networks from the default ccip.toml
+func loadDefaultConfig(t *testing.T) (*Config, []blockchain.EVMNetwork) {
+	t.Helper()
+	content, err := os.ReadFile("ccip.toml")
+	require.NoError(t, err)
+	var defaults struct {
+		CCIP    *Config
+		Network struct {
+			SelectedNetworks []string                          `toml:"selected_networks"`
+			EVMNetworks      map[string]*blockchain.EVMNetwork `toml:"EVMNetworks"`
+		}
+	}
+	require.NoError(t, toml.Unmarshal(content, &defaults))
+	var networks []blockchain.EVMNetwork
+	for _, name := range defaults.Network.SelectedNetworks {
+		networks = append(networks, *defaults.Network.EVMNetworks[name])
+	}
+	return defaults.CCIP, networks
+}
+
+func TestPlanDefaultConfig(t *testing.T) {
+	for _, envVar := range []string{E2E_JD_IMAGE, E2E_JD_VERSION, E2E_JD_GRPC, E2E_JD_WSRPC,
+		E2E_RMN_RAGEPROXY_IMAGE, E2E_RMN_RAGEPROXY_VERSION, E2E_RMN_AFN2PROXY_IMAGE, E2E_RMN_AFN2PROXY_VERSION} {
+		t.Setenv(envVar, "")
+	}
+	cfg, networks := loadDefaultConfig(t)
+	plan, err := Plan(cfg, networks)
+	require.NoError(t, err)
+
+	want, err := os.ReadFile("testdata/plan.golden")
+	require.NoError(t, err)
+	require.Equal(t, string(want), plan.String())
+}
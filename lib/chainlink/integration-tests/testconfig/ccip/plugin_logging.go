@@ -0,0 +1,162 @@
+package ccip
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+)
+
+// pluginLogLevels are the levels accepted by the core node's [Log] block, from most to least verbose.
+var pluginLogLevels = []string{"debug", "info", "warn", "error", "crit", "panic", "fatal"}
+
+// PluginLogSettings configures commit and exec plugin logging and custom telemetry on the nodes.
+type PluginLogSettings struct {
+	CommitLogLevel        *string `toml:",omitempty"`
+	ExecLogLevel          *string `toml:",omitempty"`
+	EnableCustomTelemetry *bool   `toml:",omitempty"`
+	// TelemetryEndpoint is either host:port or a http(s) URL of an OTLP gRPC collector
+	TelemetryEndpoint *string `toml:",omitempty"`
+}
+
+// PluginLogging holds the plugin log settings of all nodes, with per node overrides keyed by node name,
+// e.g. "node-1" or "bootstrap-1". Overrides apply over the settings of all nodes field by field.
+type PluginLogging struct {
+	PluginLogSettings
+	PerNode map[string]*PluginLogSettings `toml:",omitempty"`
+}
+
+// NodePluginLogging holds the core node config generated from PluginLogging. The commit and exec plugins
+// run in the node's single CCIP job and share its logger, so the node logs at the more verbose of the two levels.
+type NodePluginLogging struct {
+	// LogLevel is empty if neither plugin level is set
+	LogLevel          string
+	TelemetryEnabled  bool
+	TelemetryEndpoint string
+	TelemetryInsecure bool
+}
+
+func (s *PluginLogSettings) apply(override *PluginLogSettings) PluginLogSettings {
+	resolved := *s
+	if override == nil {
+		return resolved
+	}
+	if override.CommitLogLevel != nil {
+		resolved.CommitLogLevel = override.CommitLogLevel
+	}
+	if override.ExecLogLevel != nil {
+		resolved.ExecLogLevel = override.ExecLogLevel
+	}
+	if override.EnableCustomTelemetry != nil {
+		resolved.EnableCustomTelemetry = override.EnableCustomTelemetry
+	}
+	if override.TelemetryEndpoint != nil {
+		resolved.TelemetryEndpoint = override.TelemetryEndpoint
+	}
+	return resolved
+}
+
+// GetNodePluginLogging returns the plugin logging of the named node, nil if PluginLogging isn't set.
+func (p *PluginLogging) GetNodePluginLogging(nodeName string) (*NodePluginLogging, error) {
+	if p == nil {
+		return nil, nil
+	}
+	settings := p.PluginLogSettings.apply(p.PerNode[nodeName])
+	logging := &NodePluginLogging{
+		LogLevel:         mostVerboseLogLevel(pointer.GetString(settings.CommitLogLevel), pointer.GetString(settings.ExecLogLevel)),
+		TelemetryEnabled: pointer.GetBool(settings.EnableCustomTelemetry),
+	}
+	if !logging.TelemetryEnabled {
+		return logging, nil
+	}
+	if pointer.GetString(settings.TelemetryEndpoint) == "" {
+		return nil, withKind(ErrInvalidEndpoint, fmt.Errorf("TelemetryEndpoint must be set when EnableCustomTelemetry is set"))
+	}
+	endpoint, insecure, err := parseCollectorEndpoint(*settings.TelemetryEndpoint)
+	if err != nil {
+		return nil, fieldError("TelemetryEndpoint", err)
+	}
+	logging.TelemetryEndpoint, logging.TelemetryInsecure = endpoint, insecure
+	return logging, nil
+}
+
+// TOML renders the [Log] and [Telemetry] blocks of the core node config.
+func (n *NodePluginLogging) TOML() string {
+	var b strings.Builder
+	if n.LogLevel != "" {
+		fmt.Fprintf(&b, "[Log]\nLevel = '%s'\n", n.LogLevel)
+	}
+	if n.TelemetryEnabled {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "[Telemetry]\nEnabled = true\nEndpoint = '%s'\nInsecureConnection = %t\n", n.TelemetryEndpoint, n.TelemetryInsecure)
+	}
+	return b.String()
+}
+
+func mostVerboseLogLevel(levels ...string) string {
+	verbosest := ""
+	for _, level := range levels {
+		if level == "" {
+			continue
+		}
+		if verbosest == "" || logLevelIndex(level) < logLevelIndex(verbosest) {
+			verbosest = level
+		}
+	}
+	return verbosest
+}
+
+func logLevelIndex(level string) int {
+	for i, l := range pluginLogLevels {
+		if l == level {
+			return i
+		}
+	}
+	return len(pluginLogLevels)
+}
+
+func (s *PluginLogSettings) validate(field string) error {
+	if s == nil {
+		return nil
+	}
+	if s.CommitLogLevel != nil && !containsString(pluginLogLevels, *s.CommitLogLevel) {
+		return fmt.Errorf("%s.CommitLogLevel %q is not one of %v", field, *s.CommitLogLevel, pluginLogLevels)
+	}
+	if s.ExecLogLevel != nil && !containsString(pluginLogLevels, *s.ExecLogLevel) {
+		return fmt.Errorf("%s.ExecLogLevel %q is not one of %v", field, *s.ExecLogLevel, pluginLogLevels)
+	}
+	return nil
+}
+
+func (o *Config) validatePluginLogging() error {
+	p := o.PluginLogging
+	if p == nil {
+		return nil
+	}
+	if err := p.PluginLogSettings.validate("PluginLogging"); err != nil {
+		return err
+	}
+	nodes := make([]string, 0, len(p.PerNode))
+	for node := range p.PerNode {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	for _, node := range nodes {
+		if err := p.PerNode[node].validate("PluginLogging.PerNode." + node); err != nil {
+			return err
+		}
+	}
+	// the telemetry settings are only complete once the overrides are applied
+	for _, node := range append([]string{""}, nodes...) {
+		if _, err := p.GetNodePluginLogging(node); err != nil {
+			if node == "" {
+				return fieldError("PluginLogging", err)
+			}
+			return fieldError("PluginLogging.PerNode."+node, err)
+		}
+	}
+	return nil
+}
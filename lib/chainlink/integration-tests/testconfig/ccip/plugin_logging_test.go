@@ -0,0 +1,60 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodePluginLoggingTOML(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(`
+[PluginLogging]
+CommitLogLevel = 'info'
+ExecLogLevel = 'warn'
+
+[PluginLogging.PerNode.node-2]
+ExecLogLevel = 'debug'
+EnableCustomTelemetry = true
+TelemetryEndpoint = 'https://otel-collector:4317'
+`), &cfg))
+	require.NoError(t, cfg.validatePluginLogging())
+
+	node1, err := cfg.PluginLogging.GetNodePluginLogging("node-1")
+	require.NoError(t, err)
+	require.Equal(t, "[Log]\nLevel = 'info'\n", node1.TOML())
+
+	node2, err := cfg.PluginLogging.GetNodePluginLogging("node-2")
+	require.NoError(t, err)
+	var generated map[string]map[string]any
+	require.NoError(t, toml.Unmarshal([]byte(node2.TOML()), &generated))
+	require.Equal(t, map[string]map[string]any{
+		"Log": {"Level": "debug"},
+		"Telemetry": {
+			"Enabled":            true,
+			"Endpoint":           "otel-collector:4317",
+			"InsecureConnection": false,
+		},
+	}, generated)
+
+	none, err := (*PluginLogging)(nil).GetNodePluginLogging("node-1")
+	require.NoError(t, err)
+	require.Nil(t, none)
+}
+
+func TestValidatePluginLogging(t *testing.T) {
+	for _, tc := range []struct {
+		logging string
+		err     string
+	}{
+		{"CommitLogLevel = 'trace'\n", `PluginLogging.CommitLogLevel "trace" is not one of [debug info warn error crit panic fatal]`},
+		{"[PluginLogging.PerNode.node-1]\nExecLogLevel = 'verbose'\n", `PluginLogging.PerNode.node-1.ExecLogLevel "verbose" is not one of`},
+		{"EnableCustomTelemetry = true\n", "PluginLogging: TelemetryEndpoint must be set when EnableCustomTelemetry is set"},
+		{"[PluginLogging.PerNode.node-1]\nEnableCustomTelemetry = true\nTelemetryEndpoint = 'otel-collector'\n", `PluginLogging.PerNode.node-1: TelemetryEndpoint: "otel-collector" is not a host:port`},
+	} {
+		var cfg Config
+		require.NoError(t, toml.Unmarshal([]byte("[PluginLogging]\n"+tc.logging), &cfg))
+		require.ErrorContains(t, cfg.validatePluginLogging(), tc.err)
+	}
+}
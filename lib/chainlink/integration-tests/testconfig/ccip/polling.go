@@ -0,0 +1,191 @@
+package ccip
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// DEFAULT_POLL_INTERVAL is the fixed interval the assertion helpers polled at before it was configurable
+	DEFAULT_POLL_INTERVAL = 2 * time.Second
+	DEFAULT_POLL_BACKOFF  = 1.0
+
+	MIN_POLL_INTERVAL = 100 * time.Millisecond
+)
+
+var defaultPolling = ResolvedPolling{
+	Interval:    DEFAULT_POLL_INTERVAL,
+	MaxInterval: DEFAULT_POLL_INTERVAL,
+	Backoff:     DEFAULT_POLL_BACKOFF,
+}
+
+// PollingSettings configures how on-chain assertions wait for a condition. The interval grows by
+// Backoff after every unsuccessful check, up to MaxInterval.
+type PollingSettings struct {
+	Interval    *Duration `toml:",omitempty"`
+	MaxInterval *Duration `toml:",omitempty"`
+	Backoff     *float64  `toml:",omitempty"`
+	// UseSubscriptionsWhereAvailable makes assertions wait on WS log subscriptions, polling only
+	// at MaxInterval as a safety net, and fall back to polling if the subscription fails
+	UseSubscriptionsWhereAvailable *bool `toml:",omitempty"`
+}
+
+// PollingConfig holds the polling settings of all chains, with per chain overrides keyed by chain
+// name or selector. Overrides apply over the settings.
+type PollingConfig struct {
+	PollingSettings
+	PerChain map[string]*PollingSettings `toml:",omitempty"`
+}
+
+// ResolvedPolling is the polling of a single chain, with every value set.
+type ResolvedPolling struct {
+	Interval         time.Duration
+	MaxInterval      time.Duration
+	Backoff          float64
+	UseSubscriptions bool
+}
+
+func (r ResolvedPolling) apply(s *PollingSettings) ResolvedPolling {
+	if s == nil {
+		return r
+	}
+	if s.Interval != nil {
+		r.Interval = s.Interval.Duration
+		if s.MaxInterval == nil && r.MaxInterval < r.Interval {
+			r.MaxInterval = r.Interval
+		}
+	}
+	if s.MaxInterval != nil {
+		r.MaxInterval = s.MaxInterval.Duration
+	}
+	if s.Backoff != nil {
+		r.Backoff = *s.Backoff
+	}
+	if s.UseSubscriptionsWhereAvailable != nil {
+		r.UseSubscriptions = *s.UseSubscriptionsWhereAvailable
+	}
+	return r
+}
+
+// Next returns the interval to wait after the given one.
+func (r ResolvedPolling) Next(interval time.Duration) time.Duration {
+	next := time.Duration(float64(interval) * r.Backoff)
+	if next > r.MaxInterval {
+		return r.MaxInterval
+	}
+	return next
+}
+
+// GetPolling returns the polling of the chain, a fixed 2s interval unless configured otherwise. Rollups
+// with a hard finality lag back off to a longer interval by default.
+func (o *Config) GetPolling(selector uint64) ResolvedPolling {
+	defaults := rollupPolling(defaultPolling, o.GetChainSemantics(selector))
+	if o.Polling == nil {
+		return defaults
+	}
+	resolved := defaults.apply(&o.Polling.PollingSettings)
+	for ref, settings := range o.Polling.PerChain {
+		if chain, err := o.ResolveChainSelector(ref); err == nil && chain == selector {
+			return resolved.apply(settings)
+		}
+	}
+	return resolved
+}
+
+// Subscription is the part of event.Subscription the wait needs, so generated Watch* bindings can be passed as is.
+type Subscription interface {
+	Err() <-chan error
+	Unsubscribe()
+}
+
+// SubscribeFunc subscribes to the logs the condition depends on, signalling notify for every log received.
+type SubscribeFunc func(ctx context.Context, notify chan<- struct{}) (Subscription, error)
+
+// WaitFor blocks until check returns true, check fails or ctx is done. With subscriptions enabled and
+// subscribe set, check runs whenever a log arrives and every MaxInterval in case one is missed. If
+// subscribing fails, or the subscription errors later, it falls back to polling.
+func (r ResolvedPolling) WaitFor(ctx context.Context, subscribe SubscribeFunc, check func(ctx context.Context) (bool, error)) error {
+	var (
+		notify  chan struct{}
+		subErrs <-chan error
+	)
+	interval := r.Interval
+	if r.UseSubscriptions && subscribe != nil {
+		notify = make(chan struct{}, 1)
+		sub, err := subscribe(ctx, notify)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to subscribe, falling back to polling")
+			notify = nil
+		} else {
+			defer sub.Unsubscribe()
+			subErrs = sub.Err()
+			interval = r.MaxInterval
+		}
+	}
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-subErrs:
+			log.Warn().Err(err).Msg("Subscription failed, falling back to polling")
+			notify, subErrs = nil, nil
+			interval = r.Interval
+		case <-notify:
+		case <-timer.C:
+		}
+		done, err := check(ctx)
+		if err != nil || done {
+			return err
+		}
+		timer.Reset(interval)
+		if notify == nil {
+			interval = r.Next(interval)
+		}
+	}
+}
+
+func (p *PollingSettings) Validate(field string) error {
+	if p.Interval != nil && p.Interval.Duration < MIN_POLL_INTERVAL {
+		return fmt.Errorf("%s.Interval must be at least %s, got %s", field, MIN_POLL_INTERVAL, p.Interval.Duration)
+	}
+	if p.MaxInterval != nil && p.MaxInterval.Duration < MIN_POLL_INTERVAL {
+		return fmt.Errorf("%s.MaxInterval must be at least %s, got %s", field, MIN_POLL_INTERVAL, p.MaxInterval.Duration)
+	}
+	if p.Backoff != nil && *p.Backoff < 1 {
+		return fmt.Errorf("%s.Backoff must be at least 1, got %f", field, *p.Backoff)
+	}
+	return nil
+}
+
+func (o *Config) validatePolling() error {
+	if o.Polling == nil {
+		return nil
+	}
+	if err := o.Polling.PollingSettings.Validate("Polling"); err != nil {
+		return err
+	}
+	if polling := defaultPolling.apply(&o.Polling.PollingSettings); polling.MaxInterval < polling.Interval {
+		return fmt.Errorf("Polling: MaxInterval %s must not be less than Interval %s", polling.MaxInterval, polling.Interval)
+	}
+	for ref, settings := range o.Polling.PerChain {
+		selector, err := o.ResolveChainSelector(ref)
+		if err != nil {
+			return fieldError("Polling.PerChain."+ref, err)
+		}
+		if settings == nil {
+			continue
+		}
+		if err := settings.Validate("Polling.PerChain." + ref); err != nil {
+			return err
+		}
+		if polling := o.GetPolling(selector); polling.MaxInterval < polling.Interval {
+			return fmt.Errorf("Polling.PerChain.%s: MaxInterval %s must not be less than Interval %s", ref, polling.MaxInterval, polling.Interval)
+		}
+	}
+	return nil
+}
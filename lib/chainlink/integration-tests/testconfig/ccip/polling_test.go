@@ -0,0 +1,136 @@
+package ccip
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPolling(t *testing.T) {
+	var cfg Config
+	require.Equal(t, ResolvedPolling{Interval: 2 * time.Second, MaxInterval: 2 * time.Second, Backoff: 1}, cfg.GetPolling(3379446385462418246))
+
+	require.NoError(t, toml.Unmarshal([]byte(`
+[Polling]
+Interval = '500ms'
+MaxInterval = '10s'
+Backoff = 1.5
+
+[Polling.PerChain.geth-devnet-2]
+Interval = '200ms'
+UseSubscriptionsWhereAvailable = true
+`), &cfg))
+	require.NoError(t, cfg.validatePolling())
+	require.Equal(t, ResolvedPolling{Interval: 500 * time.Millisecond, MaxInterval: 10 * time.Second, Backoff: 1.5}, cfg.GetPolling(3379446385462418246))
+	require.Equal(t, ResolvedPolling{Interval: 200 * time.Millisecond, MaxInterval: 10 * time.Second, Backoff: 1.5, UseSubscriptions: true}, cfg.GetPolling(12922642891491394802))
+
+	// a longer interval alone raises MaxInterval with it
+	cfg = Config{Polling: &PollingConfig{PollingSettings: PollingSettings{Interval: &Duration{5 * time.Second}}}}
+	require.Equal(t, 5*time.Second, cfg.GetPolling(3379446385462418246).MaxInterval)
+}
+
+func TestPollingNext(t *testing.T) {
+	polling := ResolvedPolling{Interval: time.Second, MaxInterval: 3 * time.Second, Backoff: 2}
+	require.Equal(t, 2*time.Second, polling.Next(time.Second))
+	require.Equal(t, 3*time.Second, polling.Next(2*time.Second))
+}
+
+func TestValidatePolling(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		polling string
+		err     string
+	}{
+		{"interval too short", "[Polling]\nInterval = '50ms'", "Polling.Interval must be at least 100ms, got 50ms"},
+		{"backoff below 1", "[Polling]\nBackoff = 0.5", "Polling.Backoff must be at least 1"},
+		{"max below interval", "[Polling]\nInterval = '1s'\nMaxInterval = '500ms'", "Polling: MaxInterval 500ms must not be less than Interval 1s"},
+		{"per chain interval too short", "[Polling.PerChain.geth-testnet]\nInterval = '10ms'", "Polling.PerChain.geth-testnet.Interval must be at least 100ms"},
+		{"unknown chain", "[Polling.PerChain.nope]\nInterval = '1s'", "Polling.PerChain.nope: chain nope is neither"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte(tc.polling), &cfg))
+			require.ErrorContains(t, cfg.validatePolling(), tc.err)
+		})
+	}
+}
+
+type testSubscription struct {
+	errs         chan error
+	unsubscribed atomic.Bool
+}
+
+func (s *testSubscription) Err() <-chan error { return s.errs }
+func (s *testSubscription) Unsubscribe()      { s.unsubscribed.Store(true) }
+
+func TestWaitForPolls(t *testing.T) {
+	polling := ResolvedPolling{Interval: time.Millisecond, MaxInterval: 4 * time.Millisecond, Backoff: 2}
+	var checks int
+	err := polling.WaitFor(context.Background(), nil, func(ctx context.Context) (bool, error) {
+		checks++
+		return checks == 5, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 5, checks)
+
+	checkErr := errors.New("rpc down")
+	err = polling.WaitFor(context.Background(), nil, func(ctx context.Context) (bool, error) { return false, checkErr })
+	require.ErrorIs(t, err, checkErr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err = polling.WaitFor(ctx, nil, func(ctx context.Context) (bool, error) { return false, nil })
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestWaitForSubscription(t *testing.T) {
+	// with an hour between safety-net polls only the subscription can make the condition pass
+	polling := ResolvedPolling{Interval: time.Millisecond, MaxInterval: time.Hour, Backoff: 1, UseSubscriptions: true}
+	sub := &testSubscription{errs: make(chan error, 1)}
+	var logs atomic.Int32
+	err := polling.WaitFor(context.Background(), func(ctx context.Context, notify chan<- struct{}) (Subscription, error) {
+		go func() {
+			logs.Store(1)
+			notify <- struct{}{}
+		}()
+		return sub, nil
+	}, func(ctx context.Context) (bool, error) {
+		return logs.Load() == 1, nil
+	})
+	require.NoError(t, err)
+	require.True(t, sub.unsubscribed.Load())
+}
+
+func TestWaitForSubscriptionFallback(t *testing.T) {
+	polling := ResolvedPolling{Interval: time.Millisecond, MaxInterval: time.Hour, Backoff: 1, UseSubscriptions: true}
+
+	// subscribing fails, so it polls at Interval
+	var checks int
+	err := polling.WaitFor(context.Background(), func(ctx context.Context, notify chan<- struct{}) (Subscription, error) {
+		return nil, errors.New("ws not available")
+	}, func(ctx context.Context) (bool, error) {
+		checks++
+		return checks == 3, nil
+	})
+	require.NoError(t, err)
+
+	// the subscription fails after the first check
+	sub := &testSubscription{errs: make(chan error, 1)}
+	checks = 0
+	err = polling.WaitFor(context.Background(), func(ctx context.Context, notify chan<- struct{}) (Subscription, error) {
+		return sub, nil
+	}, func(ctx context.Context) (bool, error) {
+		checks++
+		if checks == 1 {
+			sub.errs <- errors.New("connection reset")
+		}
+		return checks == 3, nil
+	})
+	require.NoError(t, err)
+	require.True(t, sub.unsubscribed.Load())
+}
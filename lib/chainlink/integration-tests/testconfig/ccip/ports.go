@@ -0,0 +1,278 @@
+package ccip
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	DEFAULT_PORT_RANGE_START = 20000
+	DEFAULT_PORT_RANGE_END   = 29999
+
+	PORT_CLAIMANT_NODE_HTTP     = "chainlink node HTTP port"
+	PORT_CLAIMANT_NODE_P2P      = "chainlink node P2P port"
+	PORT_CLAIMANT_NODE_METRICS  = "CLNode.MetricsPort"
+	PORT_CLAIMANT_JD_GRPC       = "JobDistributorConfig.JDGRPC"
+	PORT_CLAIMANT_JD_WSRPC      = "JobDistributorConfig.JDWSRPC"
+	PORT_CLAIMANT_JD_METRICS    = "JobDistributorConfig.MetricsPort"
+	PORT_CLAIMANT_RMN_METRICS   = "RMNConfig.MetricsPort"
+	PORT_CLAIMANT_RMN_LISTEN    = "RMN proxy listen port"
+	PORT_CLAIMANT_RMN_RAGEPROXY = "RMN rage proxy port"
+	PORT_CLAIMANT_USDC_MOCK     = "USDCMockConfig.Port"
+)
+
+// PortAllocator hands out host ports from a range. Explicitly configured ports are registered first,
+// after which Allocate assigns every other claimant the lowest free port of the range, so the same
+// config always results in the same port map.
+type PortAllocator struct {
+	start    int
+	end      int
+	claims   map[int]string
+	assigned map[string]int
+}
+
+func NewPortAllocator(start, end int) *PortAllocator {
+	return &PortAllocator{
+		start:    start,
+		end:      end,
+		claims:   make(map[int]string),
+		assigned: make(map[string]int),
+	}
+}
+
+// Register claims an explicitly configured port, failing if another claimant already holds it.
+func (p *PortAllocator) Register(claimant string, port int) error {
+	if err := validatePort(port); err != nil {
+		return fieldError(claimant, err)
+	}
+	if other, ok := p.claims[port]; ok && other != claimant {
+		return withKind(ErrPortCollision, fmt.Errorf("port %d is claimed by both %s and %s", port, other, claimant))
+	}
+	p.claims[port] = claimant
+	p.assigned[claimant] = port
+	return nil
+}
+
+// Allocate returns the port of the claimant, assigning it the lowest free port of the range on first use.
+func (p *PortAllocator) Allocate(claimant string) (int, error) {
+	if port, ok := p.assigned[claimant]; ok {
+		return port, nil
+	}
+	for port := p.start; port <= p.end; port++ {
+		if _, ok := p.claims[port]; !ok {
+			p.claims[port] = claimant
+			p.assigned[claimant] = port
+			return port, nil
+		}
+	}
+	return 0, withKind(ErrNoFreePort, fmt.Errorf("no free port left in range %d-%d for %s", p.start, p.end, claimant))
+}
+
+// Port returns the port assigned to the claimant, if any.
+func (p *PortAllocator) Port(claimant string) (int, bool) {
+	port, ok := p.assigned[claimant]
+	return port, ok
+}
+
+// Claims returns every claimed port, sorted by port.
+func (p *PortAllocator) Claims() []PlanPort {
+	claims := make([]PlanPort, 0, len(p.claims))
+	for port, claimant := range p.claims {
+		claims = append(claims, PlanPort{Port: port, Claimant: claimant})
+	}
+	sort.Slice(claims, func(i, j int) bool { return claims[i].Port < claims[j].Port })
+	return claims
+}
+
+type portClaim struct {
+	claimant string
+	port     *int
+}
+
+func (o *Config) GetPortRange() (int, int) {
+	start, end := DEFAULT_PORT_RANGE_START, DEFAULT_PORT_RANGE_END
+	if o.PortRangeStart != nil {
+		start = *o.PortRangeStart
+	}
+	if o.PortRangeEnd != nil {
+		end = *o.PortRangeEnd
+	}
+	return start, end
+}
+
+// PortAllocator returns the allocator holding every port claimed by the config: the ports of the nodes,
+// JD, RMN, the mocks and the config server, and of the observability endpoints running on this host.
+// It is rebuilt on each call, explicitly configured and fixed ports first in a fixed order, followed by
+// the ports drawn from the range: JD when the environment starts it, RMN when RMNConfig.NoOfNodes is set,
+// the USDC mock, the mocks and the config server. Chain RPC ports are published on ports docker picks,
+// they aren't claimed.
+func (o *Config) PortAllocator() (*PortAllocator, error) {
+	start, end := o.GetPortRange()
+	allocator := NewPortAllocator(start, end)
+	explicit := []portClaim{
+		{PORT_CLAIMANT_NODE_HTTP, pointer.ToInt(NODE_HTTP_PORT)},
+		{PORT_CLAIMANT_NODE_P2P, pointer.ToInt(NODE_P2P_PORT)},
+		{PORT_CLAIMANT_JD_METRICS, o.JobDistributorConfig.MetricsPort},
+		{PORT_CLAIMANT_RMN_METRICS, o.RMNConfig.MetricsPort},
+	}
+	if o.CLNode != nil && o.CLNode.MetricsPort != nil && *o.CLNode.MetricsPort != NODE_HTTP_PORT {
+		explicit = append(explicit, portClaim{PORT_CLAIMANT_NODE_METRICS, o.CLNode.MetricsPort})
+	}
+	for _, endpoint := range []struct {
+		claimant string
+		value    string
+		local    bool
+	}{
+		{PORT_CLAIMANT_JD_GRPC, pointer.GetString(o.JobDistributorConfig.JDGRPC), false},
+		{PORT_CLAIMANT_JD_WSRPC, pointer.GetString(o.JobDistributorConfig.JDWSRPC), false},
+		{"Observability.LokiEndpoint", o.Observability.GetLokiEndpoint(), true},
+		{"Observability.GrafanaURL", o.Observability.GetGrafanaURL(), true},
+		{"Observability.PrometheusPushgateway", o.Observability.GetPrometheusPushgateway(), true},
+	} {
+		if endpoint.local && !isLocalEndpoint(endpoint.value) {
+			continue
+		}
+		if port, ok := portFromEndpoint(endpoint.value); ok {
+			explicit = append(explicit, portClaim{endpoint.claimant, &port})
+		}
+	}
+	if o.USDCMock != nil {
+		explicit = append(explicit, portClaim{PORT_CLAIMANT_USDC_MOCK, o.USDCMock.Port})
+	}
+	if o.ConfigServer != nil {
+		explicit = append(explicit, portClaim{PORT_CLAIMANT_CONFIG_SERVER, o.ConfigServer.Port})
+	}
+	for _, mock := range o.Mocks {
+		if mock != nil {
+			explicit = append(explicit, portClaim{mock.portClaimant(), mock.Port})
+		}
+	}
+	for _, e := range explicit {
+		if e.port == nil {
+			continue
+		}
+		if err := allocator.Register(e.claimant, *e.port); err != nil {
+			return nil, err
+		}
+	}
+	var drawn []string
+	if o.JobDistributorConfig.IsStartedByEnvironment() {
+		drawn = append(drawn, PORT_CLAIMANT_JD_GRPC, PORT_CLAIMANT_JD_WSRPC)
+	}
+	if pointer.GetInt(o.RMNConfig.NoOfNodes) > 0 {
+		drawn = append(drawn, PORT_CLAIMANT_RMN_LISTEN, PORT_CLAIMANT_RMN_RAGEPROXY)
+	}
+	if o.USDCMock.IsEnabled() {
+		drawn = append(drawn, PORT_CLAIMANT_USDC_MOCK)
+	}
+	for _, claimant := range drawn {
+		if _, err := allocator.Allocate(claimant); err != nil {
+			return nil, err
+		}
+	}
+	// mocks are allocated in config order
+	for _, mock := range o.Mocks {
+		if mock == nil {
+			continue
+		}
+		if _, err := allocator.Allocate(mock.portClaimant()); err != nil {
+			return nil, err
+		}
+	}
+	if o.ConfigServer.IsEnabled() {
+		if _, err := allocator.Allocate(PORT_CLAIMANT_CONFIG_SERVER); err != nil {
+			return nil, err
+		}
+	}
+	return allocator, nil
+}
+
+// GetJDPorts returns the gRPC and WSRPC ports of the job distributor the environment starts, the
+// configured JDGRPC and JDWSRPC ports or the ones assigned by the PortAllocator.
+func (o *Config) GetJDPorts() (int, int, error) {
+	allocator, err := o.PortAllocator()
+	if err != nil {
+		return 0, 0, err
+	}
+	grpc, err := allocator.Allocate(PORT_CLAIMANT_JD_GRPC)
+	if err != nil {
+		return 0, 0, err
+	}
+	wsrpc, err := allocator.Allocate(PORT_CLAIMANT_JD_WSRPC)
+	return grpc, wsrpc, err
+}
+
+// GetRMNPorts returns the ports the RMN proxies listen on for the nodes and for their RMN, as assigned
+// by the PortAllocator.
+func (o *Config) GetRMNPorts() (int, int, error) {
+	allocator, err := o.PortAllocator()
+	if err != nil {
+		return 0, 0, err
+	}
+	listen, err := allocator.Allocate(PORT_CLAIMANT_RMN_LISTEN)
+	if err != nil {
+		return 0, 0, err
+	}
+	rageProxy, err := allocator.Allocate(PORT_CLAIMANT_RMN_RAGEPROXY)
+	return listen, rageProxy, err
+}
+
+// GetUSDCMockPort returns the configured USDC mock port, or the one assigned by the PortAllocator.
+func (o *Config) GetUSDCMockPort() (int, error) {
+	allocator, err := o.PortAllocator()
+	if err != nil {
+		return 0, err
+	}
+	return allocator.Allocate(PORT_CLAIMANT_USDC_MOCK)
+}
+
+func (o *Config) validatePorts() error {
+	start, end := o.GetPortRange()
+	if err := validatePort(start); err != nil {
+		return fieldError("PortRangeStart", err)
+	}
+	if err := validatePort(end); err != nil {
+		return fieldError("PortRangeEnd", err)
+	}
+	if start > end {
+		return fmt.Errorf("PortRangeStart %d must not be greater than PortRangeEnd %d", start, end)
+	}
+	_, err := o.PortAllocator()
+	return err
+}
+
+// isLocalEndpoint returns true if the endpoint is served on this host.
+func isLocalEndpoint(endpoint string) bool {
+	if idx := strings.Index(endpoint, "://"); idx >= 0 {
+		endpoint = endpoint[idx+3:]
+	}
+	host, _, err := net.SplitHostPort(strings.SplitN(endpoint, "/", 2)[0])
+	if err != nil {
+		return false
+	}
+	return host == "localhost" || host == "127.0.0.1" || host == "::1" || host == "0.0.0.0"
+}
+
+func portFromEndpoint(endpoint string) (int, bool) {
+	if endpoint == "" {
+		return 0, false
+	}
+	if idx := strings.Index(endpoint, "://"); idx >= 0 {
+		endpoint = endpoint[idx+3:]
+	}
+	endpoint = strings.SplitN(endpoint, "/", 2)[0]
+	_, rawPort, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return 0, false
+	}
+	port, err := strconv.Atoi(rawPort)
+	if err != nil {
+		return 0, false
+	}
+	return port, true
+}
@@ -0,0 +1,80 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPortAllocatorDeterministic(t *testing.T) {
+	cfg := &Config{
+		PortRangeStart: pointer.ToInt(30000),
+		PortRangeEnd:   pointer.ToInt(30010),
+		USDCMock:       &USDCMockConfig{Enabled: pointer.ToBool(true)},
+	}
+	// JD is started by the environment, its ports are drawn before the USDC mock's
+	grpc, wsrpc, err := cfg.GetJDPorts()
+	require.NoError(t, err)
+	require.Equal(t, []int{30000, 30001}, []int{grpc, wsrpc})
+	port, err := cfg.GetUSDCMockPort()
+	require.NoError(t, err)
+	require.Equal(t, 30002, port)
+
+	cfg.RMNConfig.NoOfNodes = pointer.ToInt(2)
+	listen, rageProxy, err := cfg.GetRMNPorts()
+	require.NoError(t, err)
+	require.Equal(t, []int{30002, 30003}, []int{listen, rageProxy})
+	port, err = cfg.GetUSDCMockPort()
+	require.NoError(t, err)
+	require.Equal(t, 30004, port)
+
+	cfg.USDCMock.Port = pointer.ToInt(30005)
+	port, err = cfg.GetUSDCMockPort()
+	require.NoError(t, err)
+	require.Equal(t, 30005, port)
+
+	cfg.ConfigServer = &ConfigServer{Enabled: pointer.ToBool(true)}
+	allocator, err := cfg.PortAllocator()
+	require.NoError(t, err)
+	again, err := cfg.PortAllocator()
+	require.NoError(t, err)
+	require.Equal(t, allocator.Claims(), again.Claims())
+}
+
+func TestPortAllocatorClaimsLocalObservability(t *testing.T) {
+	cfg := &Config{
+		Observability: &Observability{
+			LokiEndpoint: pointer.ToString("http://localhost:3100/loki/api/v1/push"),
+			GrafanaURL:   pointer.ToString("https://grafana.example.com:3000"),
+		},
+		RMNConfig: RMNConfig{MetricsPort: pointer.ToInt(3100)},
+	}
+	err := cfg.validatePorts()
+	require.ErrorIs(t, err, ErrPortCollision)
+	require.ErrorContains(t, err, "port 3100 is claimed by both RMNConfig.MetricsPort and Observability.LokiEndpoint")
+
+	// remote endpoints don't hold a port on this host
+	cfg.RMNConfig.MetricsPort = pointer.ToInt(3000)
+	require.NoError(t, cfg.validatePorts())
+}
+
+func TestPortAllocatorCollision(t *testing.T) {
+	cfg := &Config{
+		JobDistributorConfig: JDConfig{JDGRPC: pointer.ToString("localhost:9933")},
+		USDCMock:             &USDCMockConfig{Enabled: pointer.ToBool(true), Port: pointer.ToInt(9933)},
+	}
+	err := cfg.validatePorts()
+	require.ErrorContains(t, err, "port 9933 is claimed by both JobDistributorConfig.JDGRPC and USDCMockConfig.Port")
+}
+
+func TestPortAllocatorExhausted(t *testing.T) {
+	allocator := NewPortAllocator(30000, 30001)
+	require.NoError(t, allocator.Register("explicit", 30000))
+	port, err := allocator.Allocate("first")
+	require.NoError(t, err)
+	require.Equal(t, 30001, port)
+	_, err = allocator.Allocate("second")
+	require.ErrorContains(t, err, "no free port left in range 30000-30001 for second")
+	require.Equal(t, []PlanPort{{Port: 30000, Claimant: "explicit"}, {Port: 30001, Claimant: "first"}}, allocator.Claims())
+}
@@ -0,0 +1,142 @@
+package ccip
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/AlekSi/pointer"
+
+	ctfconfig "github.com/smartcontractkit/chainlink-testing-framework/lib/config"
+	ctfconfigtypes "github.com/smartcontractkit/chainlink-testing-framework/lib/config/types"
+)
+
+const (
+	PRESET_SMOKE_2CHAIN = "smoke-2chain"
+	PRESET_LOAD_4CHAIN  = "load-4chain"
+	PRESET_RMN_CURSE    = "rmn-curse"
+	PRESET_USDC_LANE    = "usdc-lane"
+)
+
+// presets return partial configs, each of which is valid on its own
+var presets = map[string]func() *Config{
+	PRESET_SMOKE_2CHAIN: smoke2ChainPreset,
+	PRESET_LOAD_4CHAIN:  load4ChainPreset,
+	PRESET_RMN_CURSE:    rmnCursePreset,
+	PRESET_USDC_LANE:    usdcLanePreset,
+}
+
+// ListPresets returns the names of all presets, sorted.
+func ListPresets() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Default returns the values a preset falls back to for fields it doesn't set.
+func Default() *Config {
+	return &Config{
+		CLNode: &NodeConfig{
+			NoOfPluginNodes: pointer.ToInt(4),
+			NoOfBootstraps:  pointer.ToInt(1),
+		},
+	}
+}
+
+// ApplyPreset returns the config with its Preset resolved. Precedence, from lowest to highest, is Default(),
+// the preset and then the config itself, so anything set in a config file wins over the preset. The files
+// loaded by testconfig.GetConfig, including ccip.toml, all count as the config itself. Configs without a
+// Preset are returned as they are, Default() only applies together with a preset.
+func (o *Config) ApplyPreset() (*Config, error) {
+	name := pointer.GetString(o.Preset)
+	if name == "" {
+		return o, nil
+	}
+	preset, ok := presets[name]
+	if !ok {
+		return nil, fmt.Errorf("Preset %q is not one of %v", name, ListPresets())
+	}
+	return mergeConfig(mergeConfig(Default(), preset()), o), nil
+}
+
+func (o *Config) validatePreset() error {
+	if name := pointer.GetString(o.Preset); name != "" {
+		if _, ok := presets[name]; !ok {
+			return fmt.Errorf("Preset %q is not one of %v", name, ListPresets())
+		}
+	}
+	return nil
+}
+
+func presetNetwork(chainID int) *ctfconfig.EthereumNetworkConfig {
+	ethereumVersion := ctfconfigtypes.EthereumVersion_Eth1
+	executionLayer := ctfconfigtypes.ExecutionLayer_Geth
+	return &ctfconfig.EthereumNetworkConfig{
+		EthereumVersion: &ethereumVersion,
+		ExecutionLayer:  &executionLayer,
+		EthereumChainConfig: &ctfconfig.EthereumChainConfig{
+			SecondsPerSlot: 3,
+			SlotsPerEpoch:  2,
+			GenesisDelay:   15,
+			ValidatorCount: 4,
+			ChainID:        chainID,
+			AddressesToFund: []string{
+				"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+				"0x70997970C51812dc3A010C7d01b50e0d17dc79C8",
+			},
+		},
+	}
+}
+
+// smoke2ChainPreset is the basic setup of two simulated chains, the same as the default ccip.toml.
+func smoke2ChainPreset() *Config {
+	return &Config{
+		PrivateEthereumNetworks: map[string]*ctfconfig.EthereumNetworkConfig{
+			"SIMULATED_1": presetNetwork(1337),
+			"SIMULATED_2": presetNetwork(2337),
+		},
+		HomeChainSelector: pointer.ToString("12922642891491394802"), // chain-2337
+		FeedChainSelector: pointer.ToString("3379446385462418246"),  // chain-1337
+	}
+}
+
+func load4ChainPreset() *Config {
+	cfg := smoke2ChainPreset()
+	cfg.PrivateEthereumNetworks["SIMULATED_3"] = presetNetwork(3337)
+	cfg.PrivateEthereumNetworks["SIMULATED_4"] = presetNetwork(1000)
+	cfg.CLNode = &NodeConfig{NoOfPluginNodes: pointer.ToInt(7)}
+	cfg.LoadProfile = &LoadProfile{
+		MessagesPerSecond: pointer.ToFloat64(1),
+		TestDuration:      &Duration{Duration: time.Hour},
+	}
+	cfg.Timeouts = &Timeouts{OverallTestTimeout: &Duration{Duration: 90 * time.Minute}}
+	return cfg
+}
+
+func rmnCursePreset() *Config {
+	cfg := smoke2ChainPreset()
+	cfg.RMNConfig = RMNConfig{
+		NoOfNodes: pointer.ToInt(4),
+		CurseConfig: &CurseConfig{
+			Chains:     []string{"SIMULATED_1"},
+			CurseAfter: &Duration{Duration: time.Minute},
+		},
+		CurseRecovery: &CurseRecovery{
+			UncurseAfter:        &Duration{Duration: 5 * time.Minute},
+			MessagesDuringCurse: pointer.ToInt(5),
+		},
+	}
+	return cfg
+}
+
+func usdcLanePreset() *Config {
+	cfg := smoke2ChainPreset()
+	cfg.Tokens = map[string]*TokenConfig{
+		DEFAULT_USDC_TOKEN_SYMBOL: {Decimals: pointer.ToUint8(6)},
+	}
+	cfg.USDCMock = &USDCMockConfig{Enabled: pointer.ToBool(true)}
+	return cfg
+}
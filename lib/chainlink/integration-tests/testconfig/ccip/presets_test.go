@@ -0,0 +1,53 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPresetsValidateStandalone(t *testing.T) {
+	require.Equal(t, []string{PRESET_LOAD_4CHAIN, PRESET_RMN_CURSE, PRESET_SMOKE_2CHAIN, PRESET_USDC_LANE}, ListPresets())
+	for _, name := range ListPresets() {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := (&Config{Preset: pointer.ToString(name)}).ApplyPreset()
+			require.NoError(t, err)
+			require.NoError(t, cfg.Validate())
+			require.Equal(t, name, pointer.GetString(cfg.Preset))
+		})
+	}
+}
+
+func TestPresetPrecedence(t *testing.T) {
+	// Default sets 4 plugin nodes and 1 bootstrap, load-4chain overrides the plugin nodes with 7
+	cfg, err := (&Config{Preset: pointer.ToString(PRESET_LOAD_4CHAIN)}).ApplyPreset()
+	require.NoError(t, err)
+	require.Equal(t, 7, cfg.CLNode.GetNoOfPluginNodes())
+	require.Equal(t, 1, pointer.GetInt(cfg.CLNode.NoOfBootstraps))
+	require.Len(t, cfg.PrivateEthereumNetworks, 4)
+
+	// the user's config wins over both
+	user := &Config{
+		Preset:      pointer.ToString(PRESET_LOAD_4CHAIN),
+		CLNode:      &NodeConfig{NoOfPluginNodes: pointer.ToInt(10), NoOfBootstraps: pointer.ToInt(2)},
+		LoadProfile: &LoadProfile{MessagesPerSecond: pointer.ToFloat64(5)},
+	}
+	cfg, err = user.ApplyPreset()
+	require.NoError(t, err)
+	require.Equal(t, 10, cfg.CLNode.GetNoOfPluginNodes())
+	require.Equal(t, 2, pointer.GetInt(cfg.CLNode.NoOfBootstraps))
+	require.Equal(t, 5.0, cfg.LoadProfile.GetMessagesPerSecond())
+	require.Equal(t, load4ChainPreset().LoadProfile.GetTestDuration(), cfg.LoadProfile.GetTestDuration())
+	// applying a preset must not modify the user's config
+	require.Nil(t, user.PrivateEthereumNetworks)
+
+	// without a preset neither the preset nor Default apply
+	plain := &Config{}
+	cfg, err = plain.ApplyPreset()
+	require.NoError(t, err)
+	require.Nil(t, cfg.CLNode)
+
+	_, err = (&Config{Preset: pointer.ToString("soak")}).ApplyPreset()
+	require.ErrorContains(t, err, `Preset "soak" is not one of`)
+}
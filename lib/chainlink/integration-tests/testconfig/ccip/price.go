@@ -0,0 +1,111 @@
+package ccip
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const (
+	// defaults mirror the values hardcoded in the ccip deployment changesets, but for the token price
+	// deviation, which they set to 100% and the deviations are bounded below
+	DEFAULT_GAS_PRICE_DEVIATION_PPB   uint64 = 1000
+	DEFAULT_TOKEN_PRICE_DEVIATION_PPB uint64 = MAX_DEVIATION_PPB - 1
+	DEFAULT_PRICE_UPDATE_INTERVAL            = 30 * time.Minute
+	DEFAULT_PRICE_STALENESS_THRESHOLD        = 24 * time.Hour
+
+	// MAX_DEVIATION_PPB is 100% expressed in parts per billion, the deviations must be below it
+	MAX_DEVIATION_PPB uint64 = 1e9
+)
+
+// PriceConfig controls how the fee quoter / price registry is updated during the test.
+type PriceConfig struct {
+	GasPriceDeviationPPB   *uint64   `toml:",omitempty"`
+	TokenPriceDeviationPPB *uint64   `toml:",omitempty"`
+	PriceUpdateInterval    *Duration `toml:",omitempty"`
+	StalenessThreshold     *Duration `toml:",omitempty"`
+	// InitialTokenPricesUSD is keyed by token symbol, values are decimal USD prices e.g. "15.5"
+	InitialTokenPricesUSD map[string]string `toml:",omitempty"`
+}
+
+func (p *PriceConfig) GetGasPriceDeviationPPB() uint64 {
+	if p == nil || p.GasPriceDeviationPPB == nil {
+		return DEFAULT_GAS_PRICE_DEVIATION_PPB
+	}
+	return *p.GasPriceDeviationPPB
+}
+
+func (p *PriceConfig) GetTokenPriceDeviationPPB() uint64 {
+	if p == nil || p.TokenPriceDeviationPPB == nil {
+		return DEFAULT_TOKEN_PRICE_DEVIATION_PPB
+	}
+	return *p.TokenPriceDeviationPPB
+}
+
+func (p *PriceConfig) GetPriceUpdateInterval() time.Duration {
+	if p == nil || p.PriceUpdateInterval == nil {
+		return DEFAULT_PRICE_UPDATE_INTERVAL
+	}
+	return p.PriceUpdateInterval.Duration
+}
+
+func (p *PriceConfig) GetStalenessThreshold() time.Duration {
+	if p == nil || p.StalenessThreshold == nil {
+		return DEFAULT_PRICE_STALENESS_THRESHOLD
+	}
+	return p.StalenessThreshold.Duration
+}
+
+// GetInitialTokenPriceUSD returns the configured initial USD price for the token symbol,
+// or false if none is configured.
+func (p *PriceConfig) GetInitialTokenPriceUSD(symbol string) (*big.Float, bool, error) {
+	if p == nil {
+		return nil, false, nil
+	}
+	raw, ok := p.InitialTokenPricesUSD[symbol]
+	if !ok {
+		return nil, false, nil
+	}
+	price, err := parseUSDPrice(raw)
+	if err != nil {
+		return nil, false, fmt.Errorf("initial price for token %s: %w", symbol, err)
+	}
+	return price, true, nil
+}
+
+func (p *PriceConfig) Validate(tokens map[string]*TokenConfig) error {
+	// the commit plugin rejects zero deviations
+	if p.GasPriceDeviationPPB != nil && (*p.GasPriceDeviationPPB == 0 || *p.GasPriceDeviationPPB >= MAX_DEVIATION_PPB) {
+		return fieldError("PriceConfig.GasPriceDeviationPPB", withKind(ErrInvalidValue, fmt.Errorf("must be positive and below %d, got %d", MAX_DEVIATION_PPB, *p.GasPriceDeviationPPB)))
+	}
+	if p.TokenPriceDeviationPPB != nil && (*p.TokenPriceDeviationPPB == 0 || *p.TokenPriceDeviationPPB >= MAX_DEVIATION_PPB) {
+		return fieldError("PriceConfig.TokenPriceDeviationPPB", withKind(ErrInvalidValue, fmt.Errorf("must be positive and below %d, got %d", MAX_DEVIATION_PPB, *p.TokenPriceDeviationPPB)))
+	}
+	if p.PriceUpdateInterval != nil && p.PriceUpdateInterval.Duration <= 0 {
+		return fieldError("PriceConfig.PriceUpdateInterval", withKind(ErrInvalidValue, fmt.Errorf("must be positive, got %s", p.PriceUpdateInterval.Duration)))
+	}
+	if p.GetStalenessThreshold() <= p.GetPriceUpdateInterval() {
+		return fieldError("PriceConfig.StalenessThreshold", withKind(ErrInvalidValue, fmt.Errorf("must be greater than PriceUpdateInterval (%s), got %s",
+			p.GetPriceUpdateInterval(), p.GetStalenessThreshold())))
+	}
+	for symbol, raw := range p.InitialTokenPricesUSD {
+		if _, ok := tokens[symbol]; !ok {
+			return fieldError("PriceConfig.InitialTokenPricesUSD", withKind(ErrTokenNotConfigured, fmt.Errorf("has a price for token %s, which is not configured in Tokens", symbol)))
+		}
+		if _, err := parseUSDPrice(raw); err != nil {
+			return fieldError("PriceConfig.InitialTokenPricesUSD", withKind(ErrInvalidValue, fmt.Errorf("token %s: %w", symbol, err)))
+		}
+	}
+	return nil
+}
+
+func parseUSDPrice(raw string) (*big.Float, error) {
+	price, ok := new(big.Float).SetString(raw)
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal price %q", raw)
+	}
+	if price.Sign() <= 0 {
+		return nil, fmt.Errorf("price must be positive, got %q", raw)
+	}
+	return price, nil
+}
@@ -0,0 +1,169 @@
+package ccip
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	// PRICE_MANIPULATION_STALE halts the gas price updates of the target chain
+	PRICE_MANIPULATION_STALE = "stale"
+	// PRICE_MANIPULATION_OVERSTATE and PRICE_MANIPULATION_UNDERSTATE report the target chain's gas
+	// price multiplied or divided by Multiplier
+	PRICE_MANIPULATION_OVERSTATE  = "overstate"
+	PRICE_MANIPULATION_UNDERSTATE = "understate"
+)
+
+var priceManipulationModes = []string{PRICE_MANIPULATION_STALE, PRICE_MANIPULATION_OVERSTATE, PRICE_MANIPULATION_UNDERSTATE}
+
+// PriceManipulationScenario makes the gas price the fee quoters report for a destination chain stale or
+// wrong for a while, to verify how exec cost calculation copes.
+type PriceManipulationScenario struct {
+	// TargetChain is the network name or selector of the destination chain whose gas price is manipulated
+	TargetChain *string  `toml:",omitempty"`
+	Mode        *string  `toml:",omitempty"`
+	Multiplier  *float64 `toml:",omitempty"`
+	// ApplyAfter and RestoreAfter are measured from the start of the test
+	ApplyAfter   *Duration `toml:",omitempty"`
+	RestoreAfter *Duration `toml:",omitempty"`
+}
+
+// PriceManipulation is the resolved scenario, executed by Run.
+type PriceManipulation struct {
+	TargetSelector uint64
+	Mode           string
+	// Multiplier is 1 for PRICE_MANIPULATION_STALE
+	Multiplier   float64
+	ApplyAfter   time.Duration
+	RestoreAfter time.Duration
+	schedule     *Schedule
+}
+
+// GasPriceFeed is the price update mechanism of the environment, as seen by the price manipulation.
+type GasPriceFeed interface {
+	// HaltGasPriceUpdates stops updating the gas price of the chain
+	HaltGasPriceUpdates(ctx context.Context, selector uint64) error
+	// SkewGasPriceUpdates keeps updating the gas price of the chain, multiplied by multiplier
+	SkewGasPriceUpdates(ctx context.Context, selector uint64, multiplier float64) error
+	// RestoreGasPriceUpdates goes back to updating the real gas price of the chain
+	RestoreGasPriceUpdates(ctx context.Context, selector uint64) error
+}
+
+// GetPriceManipulation resolves the price manipulation scenario, returning false if none is configured.
+func (o *Config) GetPriceManipulation() (PriceManipulation, bool, error) {
+	p := o.PriceManipulation
+	if p == nil {
+		return PriceManipulation{}, false, nil
+	}
+	selector, err := o.ResolveChainSelector(pointer.GetString(p.TargetChain))
+	if err != nil {
+		return PriceManipulation{}, false, fieldError("PriceManipulation.TargetChain", err)
+	}
+	manipulation := PriceManipulation{
+		TargetSelector: selector,
+		Mode:           pointer.GetString(p.Mode),
+		Multiplier:     1,
+		schedule:       o.Schedule,
+	}
+	switch manipulation.Mode {
+	case PRICE_MANIPULATION_OVERSTATE:
+		manipulation.Multiplier = pointer.GetFloat64(p.Multiplier)
+	case PRICE_MANIPULATION_UNDERSTATE:
+		if m := pointer.GetFloat64(p.Multiplier); m != 0 {
+			manipulation.Multiplier = 1 / m
+		}
+	}
+	if p.ApplyAfter != nil {
+		manipulation.ApplyAfter = p.ApplyAfter.Duration
+	}
+	if p.RestoreAfter != nil {
+		manipulation.RestoreAfter = p.RestoreAfter.Duration
+	}
+	return manipulation, true, nil
+}
+
+// Run waits until ApplyAfter, manipulates the gas price updates of the target chain and restores them
+// at RestoreAfter, both measured from the call. A manipulation due in a pause window of the Schedule
+// waits for the window to end, and is restored as much later. The updates are restored even if ctx is
+// canceled while they are manipulated.
+func (m PriceManipulation) Run(ctx context.Context, feed GasPriceFeed) error {
+	start := time.Now()
+	if err := sleepUntil(ctx, start.Add(m.ApplyAfter)); err != nil {
+		return err
+	}
+	paused, err := m.schedule.waitUnpaused(ctx, SCHEDULE_ACTION_GAS_SPIKES)
+	if err != nil {
+		return err
+	}
+	if m.Mode == PRICE_MANIPULATION_STALE {
+		err = feed.HaltGasPriceUpdates(ctx, m.TargetSelector)
+	} else {
+		err = feed.SkewGasPriceUpdates(ctx, m.TargetSelector, m.Multiplier)
+	}
+	if err != nil {
+		return fmt.Errorf("%s gas price of chain %s: %w", m.Mode, chainName(m.TargetSelector), err)
+	}
+	waitErr := sleepUntil(ctx, start.Add(m.RestoreAfter+paused))
+	if err := feed.RestoreGasPriceUpdates(context.WithoutCancel(ctx), m.TargetSelector); err != nil {
+		return fmt.Errorf("restore gas price of chain %s: %w", chainName(m.TargetSelector), err)
+	}
+	return waitErr
+}
+
+func sleepUntil(ctx context.Context, at time.Time) error {
+	timer := time.NewTimer(time.Until(at))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (o *Config) validatePriceManipulation() error {
+	p := o.PriceManipulation
+	if p == nil {
+		return nil
+	}
+	if pointer.GetString(p.TargetChain) == "" {
+		return fmt.Errorf("PriceManipulation.TargetChain must be set")
+	}
+	manipulation, _, err := o.GetPriceManipulation()
+	if err != nil {
+		return err
+	}
+	// every configured chain is the destination of the lanes from all others
+	configured := false
+	for name := range o.PrivateEthereumNetworks {
+		if selector, err := o.ResolveChainSelector(name); err == nil && selector == manipulation.TargetSelector {
+			configured = true
+		}
+	}
+	if !configured {
+		return withKind(ErrChainNotConfigured, fmt.Errorf("PriceManipulation.TargetChain %s is not a destination chain of the test", *p.TargetChain))
+	}
+	switch manipulation.Mode {
+	case PRICE_MANIPULATION_STALE:
+		if p.Multiplier != nil {
+			return fmt.Errorf("PriceManipulation.Multiplier is not used by the %s mode", PRICE_MANIPULATION_STALE)
+		}
+	case PRICE_MANIPULATION_OVERSTATE, PRICE_MANIPULATION_UNDERSTATE:
+		if pointer.GetFloat64(p.Multiplier) <= 1 {
+			return fmt.Errorf("PriceManipulation.Multiplier must be set and be greater than 1 for the %s mode", manipulation.Mode)
+		}
+	default:
+		return fmt.Errorf("PriceManipulation.Mode must be one of %v, got %q", priceManipulationModes, manipulation.Mode)
+	}
+	if manipulation.ApplyAfter < 0 || manipulation.RestoreAfter <= manipulation.ApplyAfter {
+		return fmt.Errorf("PriceManipulation.RestoreAfter (%s) must be after ApplyAfter (%s)", manipulation.RestoreAfter, manipulation.ApplyAfter)
+	}
+	if manipulation.RestoreAfter > o.Timeouts.GetOverallTestTimeout() {
+		return fmt.Errorf("PriceManipulation is restored after %s, which exceeds Timeouts.OverallTestTimeout (%s)",
+			manipulation.RestoreAfter, o.Timeouts.GetOverallTestTimeout())
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package ccip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const priceManipulationNetworks = `
+[PrivateEthereumNetworks.SIMULATED_1.EthereumChainConfig]
+chain_id = 1337
+
+[PrivateEthereumNetworks.SIMULATED_2.EthereumChainConfig]
+chain_id = 2337
+`
+
+func TestPriceManipulation(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(priceManipulationNetworks+`
+[PriceManipulation]
+TargetChain = 'SIMULATED_2'
+Mode = 'understate'
+Multiplier = 4.0
+ApplyAfter = '5m'
+RestoreAfter = '15m'
+`), &cfg))
+	require.NoError(t, cfg.validatePriceManipulation())
+	manipulation, ok, err := cfg.GetPriceManipulation()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, PriceManipulation{
+		TargetSelector: 12922642891491394802,
+		Mode:           PRICE_MANIPULATION_UNDERSTATE,
+		Multiplier:     0.25,
+		ApplyAfter:     5 * time.Minute,
+		RestoreAfter:   15 * time.Minute,
+	}, manipulation)
+
+	for _, tc := range []struct {
+		scenario string
+		err      string
+	}{
+		{"Mode = 'stale'\nRestoreAfter = '10m'\n", "PriceManipulation.TargetChain must be set"},
+		{"TargetChain = 'ethereum-testnet-sepolia'\nMode = 'stale'\nRestoreAfter = '10m'\n", "PriceManipulation.TargetChain ethereum-testnet-sepolia is not a destination chain of the test"},
+		{"TargetChain = 'SIMULATED_1'\nMode = 'overstate'\nRestoreAfter = '10m'\n", "PriceManipulation.Multiplier must be set and be greater than 1 for the overstate mode"},
+		{"TargetChain = 'SIMULATED_1'\nMode = 'stale'\nMultiplier = 2.0\nRestoreAfter = '10m'\n", "PriceManipulation.Multiplier is not used by the stale mode"},
+		{"TargetChain = 'SIMULATED_1'\nMode = 'garbage'\nRestoreAfter = '10m'\n", `PriceManipulation.Mode must be one of [stale overstate understate], got "garbage"`},
+		{"TargetChain = 'SIMULATED_1'\nMode = 'stale'\nApplyAfter = '10m'\nRestoreAfter = '10m'\n", "PriceManipulation.RestoreAfter (10m0s) must be after ApplyAfter (10m0s)"},
+		{"TargetChain = 'SIMULATED_1'\nMode = 'stale'\nRestoreAfter = '2h'\n", "PriceManipulation is restored after 2h0m0s, which exceeds Timeouts.OverallTestTimeout (30m0s)"},
+	} {
+		var cfg Config
+		require.NoError(t, toml.Unmarshal([]byte(priceManipulationNetworks+"\n[PriceManipulation]\n"+tc.scenario), &cfg))
+		require.EqualError(t, cfg.validatePriceManipulation(), tc.err, tc.scenario)
+	}
+}
+
+type fakeGasPriceFeed struct {
+	calls      []string
+	restoreErr error
+}
+
+func (f *fakeGasPriceFeed) HaltGasPriceUpdates(_ context.Context, selector uint64) error {
+	f.calls = append(f.calls, fmt.Sprintf("halt %d", selector))
+	return nil
+}
+
+func (f *fakeGasPriceFeed) SkewGasPriceUpdates(_ context.Context, selector uint64, multiplier float64) error {
+	f.calls = append(f.calls, fmt.Sprintf("skew %d %g", selector, multiplier))
+	return nil
+}
+
+func (f *fakeGasPriceFeed) RestoreGasPriceUpdates(_ context.Context, selector uint64) error {
+	f.calls = append(f.calls, fmt.Sprintf("restore %d", selector))
+	return f.restoreErr
+}
+
+func TestPriceManipulationRun(t *testing.T) {
+	feed := &fakeGasPriceFeed{}
+	m := PriceManipulation{TargetSelector: 3379446385462418246, Mode: PRICE_MANIPULATION_OVERSTATE, Multiplier: 10, RestoreAfter: time.Millisecond}
+	require.NoError(t, m.Run(context.Background(), feed))
+	require.Equal(t, []string{"skew 3379446385462418246 10", "restore 3379446385462418246"}, feed.calls)
+
+	// canceled while stale, the updates are still restored
+	feed = &fakeGasPriceFeed{restoreErr: errors.New("rpc down")}
+	m = PriceManipulation{TargetSelector: 3379446385462418246, Mode: PRICE_MANIPULATION_STALE, Multiplier: 1, RestoreAfter: time.Hour}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.EqualError(t, m.Run(ctx, feed), "restore gas price of chain geth-testnet: rpc down")
+	require.Equal(t, []string{"halt 3379446385462418246", "restore 3379446385462418246"}, feed.calls)
+
+	// only the gas spikes of the schedule pause it
+	feed = &fakeGasPriceFeed{}
+	m = PriceManipulation{TargetSelector: 3379446385462418246, Mode: PRICE_MANIPULATION_STALE, Multiplier: 1, RestoreAfter: time.Millisecond,
+		schedule: &Schedule{PauseWindows: []Window{{Start: "00:00", End: "00:00"}}, ActionsAffected: []string{SCHEDULE_ACTION_CHAOS}}}
+	require.NoError(t, m.Run(context.Background(), feed))
+	require.Equal(t, []string{"halt 3379446385462418246", "restore 3379446385462418246"}, feed.calls)
+	m.schedule.ActionsAffected = nil
+	feed = &fakeGasPriceFeed{}
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, m.Run(ctx, feed), context.DeadlineExceeded)
+	require.Empty(t, feed.calls)
+}
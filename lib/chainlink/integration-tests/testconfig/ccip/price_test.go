@@ -0,0 +1,61 @@
+package ccip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriceConfigDefaults(t *testing.T) {
+	var p *PriceConfig
+	require.Equal(t, DEFAULT_GAS_PRICE_DEVIATION_PPB, p.GetGasPriceDeviationPPB())
+	require.Equal(t, DEFAULT_TOKEN_PRICE_DEVIATION_PPB, p.GetTokenPriceDeviationPPB())
+	require.Equal(t, DEFAULT_PRICE_UPDATE_INTERVAL, p.GetPriceUpdateInterval())
+	require.Equal(t, DEFAULT_PRICE_STALENESS_THRESHOLD, p.GetStalenessThreshold())
+	_, ok, err := p.GetInitialTokenPriceUSD("LINK")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestValidatePriceConfig(t *testing.T) {
+	tokens := map[string]*TokenConfig{"LINK": {}}
+	for _, tc := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "defaults set explicitly", content: `
+GasPriceDeviationPPB = 1000
+TokenPriceDeviationPPB = 999_999_999
+`},
+		{name: "100% gas price deviation", content: "GasPriceDeviationPPB = 1_000_000_000", err: "PriceConfig.GasPriceDeviationPPB: must be positive and below 1000000000, got 1000000000 (Gas price change in parts per billion that triggers an update; between 1 and 9.99999999e+08)"},
+		{name: "zero gas price deviation", content: "GasPriceDeviationPPB = 0", err: "PriceConfig.GasPriceDeviationPPB: must be positive and below 1000000000, got 0 (Gas price change in parts per billion that triggers an update; between 1 and 9.99999999e+08)"},
+		{name: "token price deviation above 100%", content: "TokenPriceDeviationPPB = 1_000_000_001", err: "PriceConfig.TokenPriceDeviationPPB: must be positive and below 1000000000, got 1000000001 (Token price change in parts per billion that triggers an update; between 1 and 9.99999999e+08)"},
+		{name: "zero update interval", content: "PriceUpdateInterval = '0s'", err: "PriceConfig.PriceUpdateInterval: must be positive, got 0s (Interval of the price updates)"},
+		{name: "staleness below the update interval", content: "PriceUpdateInterval = '1h'\nStalenessThreshold = '1h'", err: "PriceConfig.StalenessThreshold: must be greater than PriceUpdateInterval (1h0m0s), got 1h0m0s (Age after which prices count as stale)"},
+		{name: "price of an unknown token", content: "[InitialTokenPricesUSD]\nWETH = '3000'", err: `PriceConfig.InitialTokenPricesUSD: has a price for token WETH, which is not configured in Tokens (Initial USD price per token symbol, like "15.5")`},
+		{name: "negative price", content: "[InitialTokenPricesUSD]\nLINK = '-1'", err: `PriceConfig.InitialTokenPricesUSD: token LINK: price must be positive, got "-1" (Initial USD price per token symbol, like "15.5")`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var p PriceConfig
+			require.NoError(t, toml.Unmarshal([]byte(tc.content), &p))
+			err := p.Validate(tokens)
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestGetInitialTokenPriceUSD(t *testing.T) {
+	p := &PriceConfig{InitialTokenPricesUSD: map[string]string{"LINK": "15.5"}}
+	price, ok, err := p.GetInitialTokenPriceUSD("LINK")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "15.5", price.Text('f', -1))
+	require.Equal(t, 30*time.Minute, p.GetPriceUpdateInterval())
+}
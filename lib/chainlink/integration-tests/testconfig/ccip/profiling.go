@@ -0,0 +1,171 @@
+package ccip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	PROFILE_AT_RAMP_UP_END  = "rampUpEnd"
+	PROFILE_AT_STEADY_STATE = "steadyState"
+	PROFILE_AT_SPIKE        = "spike"
+
+	PROFILE_TYPE_CPU       = "cpu"
+	PROFILE_TYPE_HEAP      = "heap"
+	PROFILE_TYPE_GOROUTINE = "goroutine"
+
+	DEFAULT_PROFILING_OUTPUT_DIR = "profiles"
+	// DEFAULT_CPU_PROFILE_DURATION is how long cpu profiles are sampled for
+	DEFAULT_CPU_PROFILE_DURATION = 10 * time.Second
+
+	// NODE_PPROF_PATH is where the node API serves the pprof profiles, heap is only served by dev builds
+	NODE_PPROF_PATH = "/v2/debug/pprof/"
+)
+
+// Profiling configures pprof captures from selected plugin nodes at points of a load test.
+type Profiling struct {
+	Enabled *bool `toml:",omitempty"`
+	// NodesToProfile are zero based plugin node indexes
+	NodesToProfile []int    `toml:",omitempty"`
+	CaptureAt      []string `toml:",omitempty"`
+	ProfileTypes   []string `toml:",omitempty"`
+	OutputDir      *string  `toml:",omitempty"`
+}
+
+// ProfileCapture is a single profile the harness has to fetch.
+type ProfileCapture struct {
+	NodeIndex   int
+	CaptureAt   string
+	ProfileType string
+	OutputPath  string
+}
+
+func (p *Profiling) IsEnabled() bool {
+	return p != nil && pointer.GetBool(p.Enabled)
+}
+
+func (p *Profiling) GetOutputDir() string {
+	if p == nil || pointer.GetString(p.OutputDir) == "" {
+		return DEFAULT_PROFILING_OUTPUT_DIR
+	}
+	return *p.OutputDir
+}
+
+// GetCaptures returns the profiles to capture when the test reaches the given point,
+// nil when profiling is disabled or nothing is captured at that point.
+func (p *Profiling) GetCaptures(captureAt string) []ProfileCapture {
+	if !p.IsEnabled() || !containsString(p.CaptureAt, captureAt) {
+		return nil
+	}
+	var captures []ProfileCapture
+	for _, node := range p.NodesToProfile {
+		for _, profileType := range p.ProfileTypes {
+			captures = append(captures, ProfileCapture{
+				NodeIndex:   node,
+				CaptureAt:   captureAt,
+				ProfileType: profileType,
+				OutputPath:  filepath.Join(p.GetOutputDir(), fmt.Sprintf("node-%d-%s-%s.pprof", node, captureAt, profileType)),
+			})
+		}
+	}
+	return captures
+}
+
+// ProfileFetcher fetches a profile of a plugin node (zero based index) from NodePprofPath on its API.
+// duration is how long cpu profiles are sampled for, 0 for the other types.
+type ProfileFetcher func(ctx context.Context, nodeIndex int, profileType string, duration time.Duration) ([]byte, error)
+
+// NodePprofPath returns the path of the node API serving the profile type (PROFILE_TYPE_*).
+func NodePprofPath(profileType string) string {
+	if profileType == PROFILE_TYPE_CPU {
+		return NODE_PPROF_PATH + "profile"
+	}
+	return NODE_PPROF_PATH + profileType
+}
+
+// Capture fetches the profiles to capture at the point concurrently and writes each to its OutputPath.
+// Load tests call it when they reach each PROFILE_AT_* point, it does nothing unless the point is configured.
+func (p *Profiling) Capture(ctx context.Context, captureAt string, fetch ProfileFetcher) error {
+	captures := p.GetCaptures(captureAt)
+	if len(captures) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(p.GetOutputDir(), 0o755); err != nil {
+		return fmt.Errorf("Profiling.OutputDir %s cannot be created: %w", p.GetOutputDir(), err)
+	}
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs error
+	)
+	for _, capture := range captures {
+		wg.Add(1)
+		go func(capture ProfileCapture) {
+			defer wg.Done()
+			var duration time.Duration
+			if capture.ProfileType == PROFILE_TYPE_CPU {
+				duration = DEFAULT_CPU_PROFILE_DURATION
+			}
+			profile, err := fetch(ctx, capture.NodeIndex, capture.ProfileType, duration)
+			if err == nil {
+				err = os.WriteFile(capture.OutputPath, profile, 0o600)
+			}
+			if err != nil {
+				mu.Lock()
+				errs = errors.Join(errs, fmt.Errorf("%s profile of node %d at %s: %w", capture.ProfileType, capture.NodeIndex, captureAt, err))
+				mu.Unlock()
+			}
+		}(capture)
+	}
+	wg.Wait()
+	return errs
+}
+
+func (p *Profiling) Validate(node *NodeConfig) error {
+	if !p.IsEnabled() {
+		return nil
+	}
+	if len(p.NodesToProfile) == 0 || len(p.CaptureAt) == 0 || len(p.ProfileTypes) == 0 {
+		return fmt.Errorf("Profiling requires NodesToProfile, CaptureAt and ProfileTypes when enabled")
+	}
+	if node == nil || !pointer.GetBool(node.EnablePprof) {
+		return fmt.Errorf("Profiling requires CLNode.EnablePprof")
+	}
+	noOfNodes := node.GetNoOfPluginNodes()
+	for _, idx := range p.NodesToProfile {
+		if idx < 0 || idx >= noOfNodes {
+			return withKind(ErrInsufficientNodes, fmt.Errorf("Profiling.NodesToProfile contains %d, but there are only %d plugin nodes", idx, noOfNodes))
+		}
+	}
+	for _, at := range p.CaptureAt {
+		switch at {
+		case PROFILE_AT_RAMP_UP_END, PROFILE_AT_STEADY_STATE, PROFILE_AT_SPIKE:
+		default:
+			return fmt.Errorf("Profiling.CaptureAt contains unknown point %q", at)
+		}
+	}
+	for _, profileType := range p.ProfileTypes {
+		switch profileType {
+		case PROFILE_TYPE_CPU, PROFILE_TYPE_HEAP, PROFILE_TYPE_GOROUTINE:
+		default:
+			return fmt.Errorf("Profiling.ProfileTypes contains unknown type %q", profileType)
+		}
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
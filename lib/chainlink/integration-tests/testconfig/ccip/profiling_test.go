@@ -0,0 +1,105 @@
+package ccip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfilingDefaults(t *testing.T) {
+	var unset *Profiling
+	require.False(t, unset.IsEnabled())
+	require.Equal(t, DEFAULT_PROFILING_OUTPUT_DIR, unset.GetOutputDir())
+	require.Nil(t, unset.GetCaptures(PROFILE_AT_STEADY_STATE))
+	require.NoError(t, unset.Validate(nil))
+	require.NoError(t, unset.Capture(context.Background(), PROFILE_AT_STEADY_STATE, nil))
+
+	require.Equal(t, "/v2/debug/pprof/profile", NodePprofPath(PROFILE_TYPE_CPU))
+	require.Equal(t, "/v2/debug/pprof/heap", NodePprofPath(PROFILE_TYPE_HEAP))
+}
+
+func TestProfilingValidate(t *testing.T) {
+	enabled := func() *Profiling {
+		return &Profiling{
+			Enabled:        pointer.ToBool(true),
+			NodesToProfile: []int{0, 3},
+			CaptureAt:      []string{PROFILE_AT_RAMP_UP_END},
+			ProfileTypes:   []string{PROFILE_TYPE_CPU},
+		}
+	}
+	node := &NodeConfig{NoOfPluginNodes: pointer.ToInt(4), EnablePprof: pointer.ToBool(true)}
+	for _, tc := range []struct {
+		name      string
+		profiling func(p *Profiling)
+		node      *NodeConfig
+		err       string
+	}{
+		{name: "valid", profiling: func(*Profiling) {}, node: node},
+		{name: "disabled", profiling: func(p *Profiling) { p.Enabled = pointer.ToBool(false); p.NodesToProfile = nil }},
+		{name: "nothing to capture", profiling: func(p *Profiling) { p.CaptureAt = nil }, node: node, err: "requires NodesToProfile, CaptureAt and ProfileTypes"},
+		{name: "pprof disabled", profiling: func(*Profiling) {}, node: &NodeConfig{NoOfPluginNodes: pointer.ToInt(4)}, err: "requires CLNode.EnablePprof"},
+		{name: "node out of range", profiling: func(p *Profiling) { p.NodesToProfile = []int{4} }, node: node, err: "contains 4, but there are only 4 plugin nodes"},
+		{name: "negative node", profiling: func(p *Profiling) { p.NodesToProfile = []int{-1} }, node: node, err: "contains -1"},
+		{name: "unknown point", profiling: func(p *Profiling) { p.CaptureAt = []string{"teardown"} }, node: node, err: `unknown point "teardown"`},
+		{name: "unknown type", profiling: func(p *Profiling) { p.ProfileTypes = []string{"block"} }, node: node, err: `unknown type "block"`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			profiling := enabled()
+			tc.profiling(profiling)
+			err := profiling.Validate(tc.node)
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+	require.ErrorIs(t, (&Profiling{Enabled: pointer.ToBool(true), NodesToProfile: []int{9}, CaptureAt: []string{PROFILE_AT_SPIKE}, ProfileTypes: []string{PROFILE_TYPE_HEAP}}).Validate(node), ErrInsufficientNodes)
+}
+
+func TestProfilingCapture(t *testing.T) {
+	dir := t.TempDir()
+	profiling := &Profiling{
+		Enabled:        pointer.ToBool(true),
+		NodesToProfile: []int{0, 2},
+		CaptureAt:      []string{PROFILE_AT_STEADY_STATE},
+		ProfileTypes:   []string{PROFILE_TYPE_CPU, PROFILE_TYPE_GOROUTINE},
+		OutputDir:      pointer.ToString(dir),
+	}
+	require.Empty(t, profiling.GetCaptures(PROFILE_AT_SPIKE))
+	require.Len(t, profiling.GetCaptures(PROFILE_AT_STEADY_STATE), 4)
+
+	var (
+		mu        sync.Mutex
+		durations = make(map[string]time.Duration)
+	)
+	fetch := func(_ context.Context, nodeIndex int, profileType string, duration time.Duration) ([]byte, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		durations[fmt.Sprintf("%d-%s", nodeIndex, profileType)] = duration
+		if nodeIndex == 2 && profileType == PROFILE_TYPE_GOROUTINE {
+			return nil, errors.New("unreachable")
+		}
+		return []byte(profileType), nil
+	}
+	err := profiling.Capture(context.Background(), PROFILE_AT_STEADY_STATE, fetch)
+	require.ErrorContains(t, err, "goroutine profile of node 2 at steadyState: unreachable")
+	require.Equal(t, map[string]time.Duration{
+		"0-cpu": DEFAULT_CPU_PROFILE_DURATION, "0-goroutine": 0, "2-cpu": DEFAULT_CPU_PROFILE_DURATION, "2-goroutine": 0,
+	}, durations)
+
+	content, err := os.ReadFile(filepath.Join(dir, "node-0-steadyState-cpu.pprof"))
+	require.NoError(t, err)
+	require.Equal(t, PROFILE_TYPE_CPU, string(content))
+	require.NoFileExists(t, filepath.Join(dir, "node-2-steadyState-goroutine.pprof"))
+
+	require.NoError(t, profiling.Capture(context.Background(), PROFILE_AT_SPIKE, nil))
+}
@@ -0,0 +1,56 @@
+package ccip
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// RAND_COMPONENT_* name the random streams of the harness. Each stream is derived from RandomSeed
+// and its name only, so adding a component never shifts the streams of the others.
+const (
+	RAND_COMPONENT_PAYLOAD    = "payload"
+	RAND_COMPONENT_LOAD       = "load"
+	RAND_COMPONENT_ASSERTIONS = "assertions"
+	RAND_COMPONENT_USDC_MOCK  = "usdcMock"
+)
+
+var randomSeedMu sync.Mutex
+
+// GetRandomSeed returns RandomSeed. When it's unset a seed is generated, logged and stored in RandomSeed,
+// so every consumer and any config saved afterwards use the same one, and a failing run can be
+// reproduced by setting it.
+func (o *Config) GetRandomSeed() int64 {
+	randomSeedMu.Lock()
+	defer randomSeedMu.Unlock()
+	if o.RandomSeed == nil {
+		seed := time.Now().UnixNano()
+		o.RandomSeed = &seed
+		log.Info().Int64("RandomSeed", seed).Msg("RandomSeed is not set, generated one, set it to reproduce this run")
+	}
+	return *o.RandomSeed
+}
+
+// NewRand returns a random stream for the component, seeded from RandomSeed.
+func (o *Config) NewRand(component string) *rand.Rand {
+	return rand.New(rand.NewSource(deriveSeed(o.GetRandomSeed(), component))) // #nosec G404 - test randomness has to be reproducible
+}
+
+// NewLaneRand returns a random stream for the component on the lane between the chains, seeded from RandomSeed
+// and the lane, so what a lane draws doesn't depend on the order the lanes are walked in.
+func (o *Config) NewLaneRand(component string, source, dest uint64) *rand.Rand {
+	return o.NewRand(component + "/" + LaneKey(strconv.FormatUint(source, 10), strconv.FormatUint(dest, 10)))
+}
+
+// deriveSeed hashes the master seed together with the component name into the component's seed.
+func deriveSeed(seed int64, component string) int64 {
+	h := sha256.New()
+	_ = binary.Write(h, binary.BigEndian, seed)
+	h.Write([]byte(component))
+	return int64(binary.BigEndian.Uint64(h.Sum(nil)[:8]))
+}
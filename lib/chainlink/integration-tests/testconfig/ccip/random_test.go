@@ -0,0 +1,52 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+// lanePayloads draws the payloads the smoke tests send on each lane, walking the lanes in the given order.
+func lanePayloads(t *testing.T, cfg *Config, lanes [][2]uint64) map[[2]uint64][][]byte {
+	messages := &Messages{PayloadType: pointer.ToString(PAYLOAD_TYPE_RANDOM)}
+	payloads := make(map[[2]uint64][][]byte)
+	for _, lane := range lanes {
+		rng := cfg.NewLaneRand(RAND_COMPONENT_PAYLOAD, lane[0], lane[1])
+		for i := 0; i < 3; i++ {
+			payload, err := messages.BuildPayload(rng)
+			require.NoError(t, err)
+			payloads[lane] = append(payloads[lane], payload)
+		}
+	}
+	return payloads
+}
+
+func TestNewLaneRandIsReproducible(t *testing.T) {
+	lanes := [][2]uint64{{1, 2}, {2, 1}, {1, 3}}
+	reversed := [][2]uint64{{1, 3}, {2, 1}, {1, 2}}
+	payloads := lanePayloads(t, &Config{RandomSeed: pointer.ToInt64(42)}, lanes)
+	// the lanes are walked in map order, which must not change what a lane sends
+	require.Equal(t, payloads, lanePayloads(t, &Config{RandomSeed: pointer.ToInt64(42)}, reversed))
+	require.NotEqual(t, payloads[[2]uint64{1, 2}], payloads[[2]uint64{2, 1}])
+
+	require.NotEqual(t, payloads, lanePayloads(t, &Config{RandomSeed: pointer.ToInt64(43)}, lanes))
+}
+
+func TestNewRandStreamsAreIndependent(t *testing.T) {
+	cfg := &Config{RandomSeed: pointer.ToInt64(42)}
+	before := cfg.NewRand(RAND_COMPONENT_ASSERTIONS).Int63()
+	// drawing from another component, new or existing, must not shift the assertions stream
+	cfg.NewRand("someNewComponent").Int63()
+	cfg.NewRand(RAND_COMPONENT_USDC_MOCK).Int63()
+	cfg.NewLaneRand(RAND_COMPONENT_PAYLOAD, 1, 2).Int63()
+	require.Equal(t, before, cfg.NewRand(RAND_COMPONENT_ASSERTIONS).Int63())
+	require.NotEqual(t, before, cfg.NewRand(RAND_COMPONENT_USDC_MOCK).Int63())
+}
+
+func TestGetRandomSeedGeneratesOnce(t *testing.T) {
+	var cfg Config
+	seed := cfg.GetRandomSeed()
+	require.NotNil(t, cfg.RandomSeed)
+	require.Equal(t, seed, cfg.GetRandomSeed())
+}
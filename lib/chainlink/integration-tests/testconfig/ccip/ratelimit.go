@@ -0,0 +1,239 @@
+package ccip
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/rs/zerolog/log"
+)
+
+// RateLimitConfig is a (partial) token bucket rate limiter setting as written in the config.
+// Unset fields are inherited from less specific entries.
+type RateLimitConfig struct {
+	Enabled  *bool    `toml:",omitempty"`
+	Capacity *big.Int `toml:",omitempty"`
+	Rate     *big.Int `toml:",omitempty"`
+}
+
+// RateLimiterConfig is the fully resolved rate limiter setting for a lane and token.
+type RateLimiterConfig struct {
+	IsEnabled bool
+	Capacity  *big.Int
+	Rate      *big.Int
+}
+
+// RateLimits configures token pool and onramp rate limiters. The most specific match wins:
+// PerLaneToken, then PerToken, then PerLane, then Default. Of several lane keys matching a lane, e.g. by
+// name and by selector, the later in sorted order wins. Rate limiters are disabled when nothing is configured.
+type RateLimits struct {
+	Default *RateLimitConfig `toml:",omitempty"`
+	// PerLane is keyed by "source->dest"
+	PerLane map[string]*RateLimitConfig `toml:",omitempty"`
+	// PerToken is keyed by token symbol
+	PerToken map[string]*RateLimitConfig `toml:",omitempty"`
+	// PerLaneToken is keyed by "source->dest" and then by token symbol
+	PerLaneToken map[string]map[string]*RateLimitConfig `toml:",omitempty"`
+}
+
+func (r *RateLimitConfig) applyTo(resolved *RateLimiterConfig) {
+	if r == nil {
+		return
+	}
+	if r.Enabled != nil {
+		resolved.IsEnabled = *r.Enabled
+	}
+	if r.Capacity != nil {
+		resolved.Capacity = new(big.Int).Set(r.Capacity)
+	}
+	if r.Rate != nil {
+		resolved.Rate = new(big.Int).Set(r.Rate)
+	}
+}
+
+// GetRateLimit resolves the rate limiter for the given token on the given lane.
+func (o *Config) GetRateLimit(lane ResolvedLane, token string) (RateLimiterConfig, error) {
+	if len(o.Tokens) > 0 {
+		if _, ok := o.Tokens[token]; !ok {
+			return RateLimiterConfig{}, withKind(ErrTokenNotConfigured, fmt.Errorf("token %s is not configured in Tokens", token))
+		}
+	}
+	return o.RateLimits.resolve(lane, token), nil
+}
+
+func (r *RateLimits) resolve(lane ResolvedLane, token string) RateLimiterConfig {
+	resolved := RateLimiterConfig{
+		Capacity: big.NewInt(0),
+		Rate:     big.NewInt(0),
+	}
+	if r == nil {
+		return resolved
+	}
+	r.Default.applyTo(&resolved)
+	for _, laneKey := range matchingLaneKeys(lane, r.PerLane) {
+		r.PerLane[laneKey].applyTo(&resolved)
+	}
+	r.PerToken[token].applyTo(&resolved)
+	for _, laneKey := range matchingLaneKeys(lane, r.PerLaneToken) {
+		r.PerLaneToken[laneKey][token].applyTo(&resolved)
+	}
+	return resolved
+}
+
+func (r RateLimiterConfig) validate(field string) error {
+	if r.Rate.Cmp(r.Capacity) > 0 {
+		return fmt.Errorf("%s: rate limiter Rate (%s) cannot be greater than Capacity (%s)", field, r.Rate, r.Capacity)
+	}
+	if r.IsEnabled && r.Capacity.Sign() <= 0 {
+		return fmt.Errorf("%s: enabled rate limiter must have a positive Capacity", field)
+	}
+	return nil
+}
+
+func (r *RateLimits) Validate(tokens map[string]*TokenConfig) error {
+	for laneKey := range r.PerLane {
+		if _, _, err := ParseLaneKey(laneKey); err != nil {
+			return fieldError("RateLimits.PerLane", err)
+		}
+	}
+	for laneKey := range r.PerLaneToken {
+		if _, _, err := ParseLaneKey(laneKey); err != nil {
+			return fieldError("RateLimits.PerLaneToken", err)
+		}
+	}
+	// a token that isn't part of the test can't be rate limited
+	if len(tokens) > 0 {
+		for symbol := range r.PerToken {
+			if _, ok := tokens[symbol]; !ok {
+				return withKind(ErrTokenNotConfigured, fmt.Errorf("RateLimits.PerToken: token %s is not configured in Tokens", symbol))
+			}
+		}
+		for laneKey, perToken := range r.PerLaneToken {
+			for symbol := range perToken {
+				if _, ok := tokens[symbol]; !ok {
+					return withKind(ErrTokenNotConfigured, fmt.Errorf("RateLimits.PerLaneToken.%s: token %s is not configured in Tokens", laneKey, symbol))
+				}
+			}
+		}
+	}
+
+	// validate the limiters off the configured lanes, the lanes are validated by validateRateLimits
+	return r.validateLanes(tokens, []ResolvedLane{{}})
+}
+
+// validateLanes validates every combination that can be resolved on the lanes, since fields are inherited
+// across entries. The lane without chains is the limiter off the configured lanes, Default and PerToken
+// alone, the empty symbol the limiter of a token without a PerToken entry.
+func (r *RateLimits) validateLanes(tokens map[string]*TokenConfig, lanes []ResolvedLane) error {
+	for _, symbol := range append([]string{""}, r.symbols(tokens)...) {
+		field := "RateLimits"
+		if symbol != "" {
+			field = fmt.Sprintf("RateLimits for token %s", symbol)
+		}
+		for _, lane := range lanes {
+			laneField := field
+			if lane != (ResolvedLane{}) {
+				laneField = fmt.Sprintf("%s on lane %s", field, lane.Key())
+			}
+			if err := r.resolve(lane, symbol).validate(laneField); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateRateLimits validates RateLimits and the limiter resolved on every lane it may apply to, i.e. merged
+// from all the lane keys referring to the lane, by name and by selector.
+func (o *Config) validateRateLimits() error {
+	if o.RateLimits == nil {
+		return nil
+	}
+	if err := o.RateLimits.Validate(o.Tokens); err != nil {
+		return err
+	}
+	return o.RateLimits.validateLanes(o.Tokens, o.rateLimitLanes())
+}
+
+// rateLimitLanes returns the lanes the rate limits are checked on, every pair of private networks and the
+// lanes of the RateLimits lane keys. The chains of a lane key that don't resolve are only matched by name.
+func (o *Config) rateLimitLanes() []ResolvedLane {
+	lanes := o.privateNetworkLanes()
+	for _, laneKey := range o.RateLimits.laneKeys() {
+		source, dest, err := ParseLaneKey(laneKey)
+		if err != nil {
+			continue
+		}
+		sourceSelector, _ := o.ResolveChainSelector(source)
+		destSelector, _ := o.ResolveChainSelector(dest)
+		lanes = append(lanes, ResolvedLane{Source: source, Dest: dest, SourceSelector: sourceSelector, DestSelector: destSelector})
+	}
+	return lanes
+}
+
+// warnIfExceededBy logs a warning when the load profile would trivially exhaust a configured rate limiter.
+// This is sometimes intended (e.g. rate limit tests), so it's not a validation error.
+func (r *RateLimits) warnIfExceededBy(profile *LoadProfile, tokens map[string]*TokenConfig, lanes []ResolvedLane) {
+	amount := profile.GetTokenAmountPerMessage()
+	if amount.Sign() == 0 {
+		return
+	}
+	// tokens per second = messages per second * amount per message
+	demand, _ := new(big.Float).Mul(big.NewFloat(profile.GetMessagesPerSecond()), new(big.Float).SetInt(amount)).Int(nil)
+	check := func(field string, limiter RateLimiterConfig) {
+		if limiter.IsEnabled && demand.Cmp(limiter.Rate) > 0 {
+			log.Warn().
+				Str("RateLimiter", field).
+				Str("Demand", demand.String()).
+				Str("Rate", limiter.Rate.String()).
+				Msg("LoadProfile token throughput exceeds the configured rate limiter refill rate, transfers will be rate limited")
+		}
+	}
+	for _, symbol := range r.symbols(tokens) {
+		check(fmt.Sprintf("token %s", symbol), r.resolve(ResolvedLane{}, symbol))
+		for _, lane := range lanes {
+			check(fmt.Sprintf("token %s on lane %s", symbol, lane.Key()), r.resolve(lane, symbol))
+		}
+	}
+}
+
+// laneKeys returns the lane keys of PerLane and PerLaneToken, sorted.
+func (r *RateLimits) laneKeys() []string {
+	seen := make(map[string]struct{})
+	for laneKey := range r.PerLane {
+		seen[laneKey] = struct{}{}
+	}
+	for laneKey := range r.PerLaneToken {
+		seen[laneKey] = struct{}{}
+	}
+	keys := make([]string, 0, len(seen))
+	for laneKey := range seen {
+		keys = append(keys, laneKey)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (r *RateLimits) symbols(tokens map[string]*TokenConfig) []string {
+	seen := make(map[string]struct{})
+	var symbols []string
+	add := func(symbol string) {
+		if _, ok := seen[symbol]; !ok {
+			seen[symbol] = struct{}{}
+			symbols = append(symbols, symbol)
+		}
+	}
+	for symbol := range tokens {
+		add(symbol)
+	}
+	for symbol := range r.PerToken {
+		add(symbol)
+	}
+	for _, perToken := range r.PerLaneToken {
+		for symbol := range perToken {
+			add(symbol)
+		}
+	}
+	sort.Strings(symbols)
+	return symbols
+}
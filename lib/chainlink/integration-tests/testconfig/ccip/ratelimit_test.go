@@ -0,0 +1,135 @@
+package ccip
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+var rateLimitLane = ResolvedLane{Source: "SIMULATED_1", Dest: "SIMULATED_2", SourceSelector: 3379446385462418246, DestSelector: 12922642891491394802}
+
+func rateLimits(t *testing.T, content string) *RateLimits {
+	t.Helper()
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(content), &cfg))
+	return cfg.RateLimits
+}
+
+func TestGetRateLimitResolutionOrder(t *testing.T) {
+	cfg := &Config{RateLimits: rateLimits(t, `
+[RateLimits.Default]
+Enabled = true
+Capacity = 100
+Rate = 10
+
+[RateLimits.PerLane."SIMULATED_1->SIMULATED_2"]
+Capacity = 200
+
+[RateLimits.PerToken.LINK]
+Rate = 20
+
+[RateLimits.PerLaneToken."SIMULATED_1->SIMULATED_2".LINK]
+Enabled = false
+`)}
+	limiter, err := cfg.GetRateLimit(rateLimitLane, "LINK")
+	require.NoError(t, err)
+	require.Equal(t, RateLimiterConfig{IsEnabled: false, Capacity: big.NewInt(200), Rate: big.NewInt(20)}, limiter)
+
+	limiter, err = cfg.GetRateLimit(rateLimitLane, "WETH")
+	require.NoError(t, err)
+	require.Equal(t, RateLimiterConfig{IsEnabled: true, Capacity: big.NewInt(200), Rate: big.NewInt(10)}, limiter)
+
+	limiter, err = cfg.GetRateLimit(ResolvedLane{Source: "SIMULATED_2", Dest: "SIMULATED_1"}, "LINK")
+	require.NoError(t, err)
+	require.Equal(t, RateLimiterConfig{IsEnabled: true, Capacity: big.NewInt(100), Rate: big.NewInt(20)}, limiter)
+}
+
+func TestGetRateLimitDefaults(t *testing.T) {
+	limiter, err := (&Config{}).GetRateLimit(rateLimitLane, "LINK")
+	require.NoError(t, err)
+	require.Equal(t, RateLimiterConfig{Capacity: big.NewInt(0), Rate: big.NewInt(0)}, limiter)
+
+	_, err = (&Config{Tokens: map[string]*TokenConfig{"WETH": {}}}).GetRateLimit(rateLimitLane, "LINK")
+	require.ErrorIs(t, err, ErrTokenNotConfigured)
+}
+
+func TestGetRateLimitIsDeterministic(t *testing.T) {
+	// both keys match the lane, by name and by selector, the later in sorted order wins
+	cfg := &Config{RateLimits: rateLimits(t, `
+[RateLimits.PerLane."SIMULATED_1->SIMULATED_2"]
+Capacity = 200
+
+[RateLimits.PerLane."3379446385462418246->12922642891491394802"]
+Capacity = 300
+`)}
+	for i := 0; i < 20; i++ {
+		limiter, err := cfg.GetRateLimit(rateLimitLane, "LINK")
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(200), limiter.Capacity)
+	}
+}
+
+func TestValidateRateLimits(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "valid", content: `
+[RateLimits.Default]
+Enabled = true
+Capacity = 100
+Rate = 10
+`},
+		{name: "default rate above capacity without tokens", content: `
+[RateLimits.Default]
+Capacity = 10
+Rate = 20
+`, err: "RateLimits: rate limiter Rate (20) cannot be greater than Capacity (10)"},
+		{name: "enabled per lane without capacity and tokens", content: `
+[RateLimits.PerLane."SIMULATED_1->SIMULATED_2"]
+Enabled = true
+`, err: "RateLimits on lane SIMULATED_1->SIMULATED_2: enabled rate limiter must have a positive Capacity"},
+		{name: "inherited capacity below the token rate", content: `
+[RateLimits.Default]
+Capacity = 10
+
+[RateLimits.PerToken.LINK]
+Rate = 20
+`, err: "RateLimits for token LINK: rate limiter Rate (20) cannot be greater than Capacity (10)"},
+		{name: "invalid lane key", content: `
+[RateLimits.PerLane."SIMULATED_1"]
+Capacity = 10
+`, err: "RateLimits.PerLane: invalid lane key"},
+		{name: "name and selector keys merged into an invalid limiter", content: `
+[PrivateEthereumNetworks.SIMULATED_1.EthereumChainConfig]
+chain_id = 1337
+
+[PrivateEthereumNetworks.SIMULATED_2.EthereumChainConfig]
+chain_id = 2337
+
+[RateLimits.PerLane."SIMULATED_1->SIMULATED_2"]
+Capacity = 5
+
+[RateLimits.PerLane."3379446385462418246->12922642891491394802"]
+Capacity = 100
+Rate = 10
+`, err: "RateLimits on lane SIMULATED_1->SIMULATED_2: rate limiter Rate (10) cannot be greater than Capacity (5)"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte(tc.content), &cfg))
+			err := cfg.validateRateLimits()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+
+	err := rateLimits(t, "[RateLimits.PerToken.LINK]\nCapacity = 1").Validate(map[string]*TokenConfig{"WETH": {}})
+	require.ErrorIs(t, err, ErrTokenNotConfigured)
+}
@@ -0,0 +1,108 @@
+package ccip
+
+import (
+	"fmt"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	RECEIVER_MODE_NORMAL     = "normal"
+	RECEIVER_MODE_REVERT_ALL = "revertAll"
+)
+
+// ReceiverConfig selects the behavior of the receiver contract deployed on the destination chain. The
+// deployment deploys a MaybeRevertMessageReceiver, which either accepts or reverts every message.
+type ReceiverConfig struct {
+	Mode *string `toml:",omitempty"`
+}
+
+// Receivers configures receiver behavior globally, with per lane overrides.
+type Receivers struct {
+	Default *ReceiverConfig `toml:",omitempty"`
+	// PerLane is keyed by "source->dest", entries replace Default entirely. The receiver is deployed once per
+	// destination chain, so the lanes to the same destination must agree.
+	PerLane map[string]*ReceiverConfig `toml:",omitempty"`
+}
+
+func (r *ReceiverConfig) GetMode() string {
+	if r == nil || pointer.GetString(r.Mode) == "" {
+		return RECEIVER_MODE_NORMAL
+	}
+	return *r.Mode
+}
+
+// ShouldRevert returns true if the receiver reverts the messages it receives.
+func (r *ReceiverConfig) ShouldRevert() bool {
+	return r.GetMode() == RECEIVER_MODE_REVERT_ALL
+}
+
+// GetReceiverConfig returns the receiver behavior for the lane.
+func (o *Config) GetReceiverConfig(lane ResolvedLane) *ReceiverConfig {
+	if o.Receivers == nil {
+		return nil
+	}
+	if laneKey, ok := laneKeyFor(lane, o.Receivers.PerLane); ok {
+		return o.Receivers.PerLane[laneKey]
+	}
+	return o.Receivers.Default
+}
+
+// GetReceiverConfigForDest returns the receiver behavior of the receiver deployed on the destination chain.
+func (o *Config) GetReceiverConfigForDest(destSelector uint64) (*ReceiverConfig, error) {
+	if o.Receivers == nil {
+		return nil, nil
+	}
+	for laneKey, receiver := range o.Receivers.PerLane {
+		_, dest, err := ParseLaneKey(laneKey)
+		if err != nil {
+			return nil, fieldError("Receivers.PerLane", err)
+		}
+		selector, err := o.ResolveChainSelector(dest)
+		if err != nil {
+			return nil, fieldError("Receivers.PerLane."+laneKey, err)
+		}
+		if selector == destSelector {
+			return receiver, nil
+		}
+	}
+	return o.Receivers.Default, nil
+}
+
+func (r *ReceiverConfig) validate(field string) error {
+	switch mode := r.GetMode(); mode {
+	case RECEIVER_MODE_NORMAL, RECEIVER_MODE_REVERT_ALL:
+	default:
+		return fmt.Errorf("%s.Mode %q is not a known receiver mode", field, mode)
+	}
+	return nil
+}
+
+func (o *Config) validateReceivers() error {
+	if o.Receivers == nil {
+		return nil
+	}
+	if err := o.Receivers.Default.validate("Receivers.Default"); err != nil {
+		return err
+	}
+	laneByDest := make(map[uint64]string)
+	for laneKey, receiver := range o.Receivers.PerLane {
+		if err := receiver.validate("Receivers.PerLane." + laneKey); err != nil {
+			return err
+		}
+		_, dest, err := ParseLaneKey(laneKey)
+		if err != nil {
+			return fieldError("Receivers.PerLane", err)
+		}
+		destSelector, err := o.ResolveChainSelector(dest)
+		if err != nil {
+			return fieldError("Receivers.PerLane."+laneKey, err)
+		}
+		if other, ok := laneByDest[destSelector]; ok && o.Receivers.PerLane[other].GetMode() != receiver.GetMode() {
+			return fmt.Errorf("Receivers.PerLane.%s and Receivers.PerLane.%s set different modes, the lanes share the receiver of chain %s",
+				other, laneKey, dest)
+		}
+		laneByDest[destSelector] = laneKey
+	}
+	return nil
+}
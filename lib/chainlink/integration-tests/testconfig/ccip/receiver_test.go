@@ -0,0 +1,70 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReceiverConfig(t *testing.T) {
+	var unset *ReceiverConfig
+	require.Equal(t, RECEIVER_MODE_NORMAL, unset.GetMode())
+	require.False(t, unset.ShouldRevert())
+
+	var cfg Config
+	lane := ResolvedLane{Source: "geth-testnet", Dest: "geth-devnet-2", SourceSelector: 3379446385462418246, DestSelector: 12922642891491394802}
+	require.Nil(t, cfg.GetReceiverConfig(lane))
+	receiver, err := cfg.GetReceiverConfigForDest(lane.DestSelector)
+	require.NoError(t, err)
+	require.Nil(t, receiver)
+
+	require.NoError(t, toml.Unmarshal([]byte(`
+[Receivers.Default]
+Mode = 'normal'
+
+[Receivers.PerLane.'geth-testnet->geth-devnet-2']
+Mode = 'revertAll'
+`), &cfg))
+	require.NoError(t, cfg.validateReceivers())
+	require.True(t, cfg.GetReceiverConfig(lane).ShouldRevert())
+	receiver, err = cfg.GetReceiverConfigForDest(lane.DestSelector)
+	require.NoError(t, err)
+	require.True(t, receiver.ShouldRevert())
+	receiver, err = cfg.GetReceiverConfigForDest(lane.SourceSelector)
+	require.NoError(t, err)
+	require.False(t, receiver.ShouldRevert())
+}
+
+func TestValidateReceivers(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "unknown default mode", content: "[Receivers.Default]\nMode = 'gasBurner'", err: `Receivers.Default.Mode "gasBurner" is not a known receiver mode`},
+		{name: "unknown lane mode", content: "[Receivers.PerLane.'geth-testnet->geth-devnet-2']\nMode = 'reentrant'", err: "is not a known receiver mode"},
+		{name: "invalid lane key", content: "[Receivers.PerLane.'geth-testnet']\nMode = 'normal'", err: "invalid lane key"},
+		{name: "unknown dest", content: "[Receivers.PerLane.'geth-testnet->nowhere']\nMode = 'normal'", err: "chain nowhere is neither a chain selector"},
+		{
+			name:    "lanes to the same dest disagree",
+			content: "[Receivers.PerLane.'geth-testnet->geth-devnet-2']\nMode = 'revertAll'\n[Receivers.PerLane.'ethereum-testnet-sepolia->geth-devnet-2']\nMode = 'normal'",
+			err:     "the lanes share the receiver of chain geth-devnet-2",
+		},
+		{
+			name:    "lanes to the same dest agree",
+			content: "[Receivers.PerLane.'geth-testnet->geth-devnet-2']\nMode = 'revertAll'\n[Receivers.PerLane.'ethereum-testnet-sepolia->geth-devnet-2']\nMode = 'revertAll'",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte(tc.content), &cfg))
+			err := cfg.validateReceivers()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package ccip
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// REMEDIATION_MODE_NONE leaves stuck messages alone, they fail the run at the end
+	REMEDIATION_MODE_NONE = "none"
+	// REMEDIATION_MODE_MANUAL_EXEC manually executes stuck messages with the gas limit they were sent with
+	REMEDIATION_MODE_MANUAL_EXEC = "manualExec"
+	// REMEDIATION_MODE_GAS_BUMP manually executes stuck messages with a bumped gas limit
+	REMEDIATION_MODE_GAS_BUMP = "gasBump"
+
+	DEFAULT_REMEDIATION_TRIGGER_AFTER  = 10 * time.Minute
+	DEFAULT_MAX_REMEDIATIONS_PCT       = 1.0
+	DEFAULT_COUNT_REMEDIATED_AS_FAILED = true
+	// REMEDIATION_GAS_BUMP_FACTOR multiplies the gas limit of the lane in gasBump mode, up to
+	// MessageLimits.MaxPerMsgGasLimit
+	REMEDIATION_GAS_BUMP_FACTOR = 2
+)
+
+var remediationModes = []string{REMEDIATION_MODE_NONE, REMEDIATION_MODE_MANUAL_EXEC, REMEDIATION_MODE_GAS_BUMP}
+
+// Remediation configures what happens to messages that are stuck, e.g. out of gas or rate limited.
+type Remediation struct {
+	Mode *string `toml:",omitempty"`
+	// TriggerAfter is how long past its expected latency a message is considered stuck
+	TriggerAfter *Duration `toml:",omitempty"`
+	// MaxRemediationsPct is the percentage of the sent messages that may be remediated
+	MaxRemediationsPct *float64 `toml:",omitempty"`
+	// CountRemediatedAsFailed makes thresholds count remediated messages as failed
+	CountRemediatedAsFailed *bool `toml:",omitempty"`
+}
+
+func (r *Remediation) GetMode() string {
+	if r == nil || pointer.GetString(r.Mode) == "" {
+		return REMEDIATION_MODE_NONE
+	}
+	return *r.Mode
+}
+
+func (r *Remediation) GetTriggerAfter() time.Duration {
+	if r == nil || r.TriggerAfter == nil {
+		return DEFAULT_REMEDIATION_TRIGGER_AFTER
+	}
+	return r.TriggerAfter.Duration
+}
+
+func (r *Remediation) GetMaxRemediationsPct() float64 {
+	if r == nil || r.MaxRemediationsPct == nil {
+		return DEFAULT_MAX_REMEDIATIONS_PCT
+	}
+	return *r.MaxRemediationsPct
+}
+
+func (r *Remediation) GetCountRemediatedAsFailed() bool {
+	if r == nil || r.CountRemediatedAsFailed == nil {
+		return DEFAULT_COUNT_REMEDIATED_AS_FAILED
+	}
+	return *r.CountRemediatedAsFailed
+}
+
+// StuckMessage is a message that was sent but not executed.
+type StuckMessage struct {
+	Lane      ResolvedLane
+	SeqNr     uint64
+	MessageID string
+	SentAt    time.Time
+}
+
+// ManualExecutor manually executes messages on the offramp of their dest chain. A non-zero gasLimit
+// overrides the gas limit the message was sent with.
+type ManualExecutor interface {
+	ManuallyExecute(ctx context.Context, msg StuckMessage, gasLimit uint64) error
+}
+
+// RemediationDriver remediates stuck messages as configured by Remediation, and records the remediated
+// messages with the reporter. It is not safe for concurrent use.
+type RemediationDriver struct {
+	cfg        *Config
+	executor   ManualExecutor
+	reporter   *Reporter
+	now        func() time.Time
+	remediated int
+}
+
+// NewRemediationDriver returns the remediation driver, nil if Remediation.Mode is none.
+func (o *Config) NewRemediationDriver(executor ManualExecutor, reporter *Reporter) *RemediationDriver {
+	if o.Remediation.GetMode() == REMEDIATION_MODE_NONE {
+		return nil
+	}
+	reporter.ApplyRemediation(o.Remediation)
+	return &RemediationDriver{cfg: o, executor: executor, reporter: reporter, now: time.Now}
+}
+
+// Remediate remediates the message if it's stuck for TriggerAfter past the expected latency of its lane,
+// returning false if it's not stuck yet. It fails without remediating once MaxRemediationsPct of the sent
+// messages were remediated.
+func (d *RemediationDriver) Remediate(ctx context.Context, msg StuckMessage) (bool, error) {
+	if d.now().Before(msg.SentAt.Add(d.expectedLatency(msg.Lane) + d.cfg.Remediation.GetTriggerAfter())) {
+		return false, nil
+	}
+	sent := d.reporter.Results().TotalMessages
+	if maxPct := d.cfg.Remediation.GetMaxRemediationsPct(); float64(d.remediated+1) > maxPct/100*float64(sent) {
+		return false, fmt.Errorf("remediating message %s on lane %s would exceed Remediation.MaxRemediationsPct (%.2f%% of %d sent messages)",
+			msg.MessageID, msg.Lane.Key(), maxPct, sent)
+	}
+	mode := d.cfg.Remediation.GetMode()
+	var gasLimit uint64
+	if mode == REMEDIATION_MODE_GAS_BUMP {
+		extraArgs, err := d.cfg.GetExtraArgsForDest(msg.Lane.DestSelector)
+		if err != nil {
+			return false, err
+		}
+		gasLimit = min(*extraArgs.GasLimit*REMEDIATION_GAS_BUMP_FACTOR, d.cfg.MessageLimits.GetMaxPerMsgGasLimit())
+	}
+	log.Info().Str("MessageID", msg.MessageID).Str("Lane", msg.Lane.Key()).Uint64("SeqNr", msg.SeqNr).
+		Str("Mode", mode).Uint64("GasLimit", gasLimit).Msg("Remediating stuck message")
+	if err := d.executor.ManuallyExecute(ctx, msg, gasLimit); err != nil {
+		log.Warn().Err(err).Str("MessageID", msg.MessageID).Str("Lane", msg.Lane.Key()).Msg("Failed to remediate stuck message")
+		return false, fmt.Errorf("remediate message %s on lane %s: %w", msg.MessageID, msg.Lane.Key(), err)
+	}
+	d.remediated++
+	d.reporter.RecordRemediation(msg.Lane.Key(), msg.SeqNr, mode)
+	return true, nil
+}
+
+// expectedLatency is the latency the LatencyModel expects on the lane, or its whole wait budget if the
+// lane can't be estimated.
+func (d *RemediationDriver) expectedLatency(lane ResolvedLane) time.Duration {
+	if expected, err := d.cfg.ExpectedE2ELatency(lane); err == nil {
+		return expected
+	}
+	budget := d.cfg.GetLaneWaitBudget(lane.SourceSelector, lane.DestSelector)
+	return budget.Commit + budget.Exec
+}
+
+func (o *Config) validateRemediation() error {
+	r := o.Remediation
+	if r == nil {
+		return nil
+	}
+	mode := r.GetMode()
+	if !containsString(remediationModes, mode) {
+		return fmt.Errorf("Remediation.Mode must be one of %v, got %q", remediationModes, mode)
+	}
+	if r.GetTriggerAfter() <= 0 {
+		return fmt.Errorf("Remediation.TriggerAfter must be positive, got %s", r.GetTriggerAfter())
+	}
+	if pct := r.GetMaxRemediationsPct(); pct <= 0 || pct > 100 {
+		return fmt.Errorf("Remediation.MaxRemediationsPct must be in (0, 100], got %f", pct)
+	}
+	if mode != REMEDIATION_MODE_GAS_BUMP {
+		return nil
+	}
+	// bumping the gas of a lane already at the limit would be rejected by the offramp
+	maxGasLimit := o.MessageLimits.GetMaxPerMsgGasLimit()
+	for _, lane := range o.privateNetworkLanes() {
+		extraArgs, err := o.GetExtraArgsForDest(lane.DestSelector)
+		if err != nil {
+			return err
+		}
+		if *extraArgs.GasLimit >= maxGasLimit {
+			return fmt.Errorf("Remediation.Mode %s can't bump the gas of lane %s, its gas limit (%d) is already at MessageLimits.MaxPerMsgGasLimit (%d)",
+				REMEDIATION_MODE_GAS_BUMP, lane.Key(), *extraArgs.GasLimit, maxGasLimit)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package ccip
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeManualExecutor struct {
+	gasLimits []uint64
+	err       error
+}
+
+func (e *fakeManualExecutor) ManuallyExecute(_ context.Context, _ StuckMessage, gasLimit uint64) error {
+	e.gasLimits = append(e.gasLimits, gasLimit)
+	return e.err
+}
+
+func TestRemediationDriver(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(chainSemanticsNetworks+`
+[Remediation]
+Mode = 'gasBump'
+TriggerAfter = '1m'
+MaxRemediationsPct = 50.0
+CountRemediatedAsFailed = false
+
+[LatencyModel]
+Adaptive = true
+`), &cfg))
+	require.NoError(t, cfg.validateRemediation())
+
+	reporter := NewReporter(&Reporting{})
+	executor := &fakeManualExecutor{}
+	driver := cfg.NewRemediationDriver(executor, reporter)
+	require.NotNil(t, driver)
+	sentAt := time.Now()
+	driver.now = func() time.Time { return sentAt.Add(time.Minute) }
+	lane := ResolvedLane{Source: "SIMULATED_1", Dest: "SIMULATED_2", SourceSelector: 3379446385462418246, DestSelector: 12922642891491394802}
+	for seqNr := uint64(1); seqNr <= 3; seqNr++ {
+		require.NoError(t, reporter.RecordMessageEvent(MessageEvent{Lane: lane.Key(), SeqNr: seqNr, Phase: MESSAGE_PHASE_SENT, At: sentAt}))
+	}
+
+	// stuck only after the expected 7s latency of the lane and TriggerAfter
+	stuck := StuckMessage{Lane: lane, SeqNr: 1, MessageID: "0x01", SentAt: sentAt}
+	remediated, err := driver.Remediate(context.Background(), stuck)
+	require.NoError(t, err)
+	require.False(t, remediated)
+
+	driver.now = func() time.Time { return sentAt.Add(time.Minute + 7*time.Second) }
+	remediated, err = driver.Remediate(context.Background(), stuck)
+	require.NoError(t, err)
+	require.True(t, remediated)
+	require.Equal(t, []uint64{2 * DEFAULT_EXTRA_ARGS_GAS_LIMIT}, executor.gasLimits)
+	require.NoError(t, reporter.RecordMessageEvent(MessageEvent{Lane: lane.Key(), SeqNr: 1, Phase: MESSAGE_PHASE_EXECUTED, At: driver.now()}))
+
+	// a second remediation would exceed 50% of the 3 sent messages
+	_, err = driver.Remediate(context.Background(), StuckMessage{Lane: lane, SeqNr: 2, MessageID: "0x02", SentAt: sentAt})
+	require.EqualError(t, err, "remediating message 0x02 on lane SIMULATED_1->SIMULATED_2 would exceed Remediation.MaxRemediationsPct (50.00% of 3 sent messages)")
+
+	report := reporter.Report()
+	require.Equal(t, 1, report.Totals.Executed)
+	require.Equal(t, 1, report.Totals.Remediated)
+	results := reporter.Results()
+	require.Equal(t, 1, results.ManualExecRequired)
+	require.Equal(t, 0, results.FailedMessages)
+
+	reporter.ApplyRemediation(&Remediation{})
+	require.Equal(t, 1, reporter.Results().FailedMessages)
+
+	executor.err = errors.New("execution reverted")
+	driver.cfg.Remediation.MaxRemediationsPct = pointer.ToFloat64(100)
+	_, err = driver.Remediate(context.Background(), StuckMessage{Lane: lane, SeqNr: 3, MessageID: "0x03", SentAt: sentAt})
+	require.EqualError(t, err, "remediate message 0x03 on lane SIMULATED_1->SIMULATED_2: execution reverted")
+
+	cfg.Remediation = nil
+	require.Nil(t, cfg.NewRemediationDriver(executor, reporter))
+}
+
+func TestValidateRemediation(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "defaults", content: "[Remediation]\nMode = 'gasBump'"},
+		{name: "unknown mode", content: "[Remediation]\nMode = 'retry'", err: `Remediation.Mode must be one of [none manualExec gasBump], got "retry"`},
+		{name: "zero pct", content: "[Remediation]\nMaxRemediationsPct = 0.0", err: "Remediation.MaxRemediationsPct must be in (0, 100], got 0.000000"},
+		{
+			name:    "gas already at the maximum",
+			content: "[Remediation]\nMode = 'gasBump'\n[MessageLimits]\nMaxPerMsgGasLimit = 500000\n[ExtraArgs.PerDest.SIMULATED_2]\nGasLimit = 500000",
+			err:     "Remediation.Mode gasBump can't bump the gas of lane SIMULATED_1->SIMULATED_2, its gas limit (500000) is already at MessageLimits.MaxPerMsgGasLimit (500000)",
+		},
+		{name: "manual exec at the maximum", content: "[Remediation]\nMode = 'manualExec'\n[MessageLimits]\nMaxPerMsgGasLimit = 200000"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte(chainSemanticsNetworks+tc.content), &cfg))
+			err := cfg.validateRemediation()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
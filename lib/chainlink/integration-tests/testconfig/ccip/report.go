@@ -0,0 +1,131 @@
+package ccip
+
+import (
+	"time"
+)
+
+const (
+	// REPORT_SCHEMA_VERSION must be bumped on any breaking change to the JSON report, dashboards parse it
+	REPORT_SCHEMA_VERSION = 1
+
+	MESSAGE_PHASE_SENT      = "sent"
+	MESSAGE_PHASE_COMMITTED = "committed"
+	MESSAGE_PHASE_BLESSED   = "blessed"
+	MESSAGE_PHASE_EXECUTED  = "executed"
+	MESSAGE_PHASE_FAILED    = "failed"
+)
+
+// MessageEvent is a single phase transition of a message, fed into the Reporter by the test.
+type MessageEvent struct {
+	Lane      string
+	SeqNr     uint64
+	MessageID string
+	Phase     string
+	At        time.Time
+	// Error is set for MESSAGE_PHASE_FAILED
+	Error string
+	// TxHash is the transaction of the phase, linked in the report when the chain has an Explorer
+	TxHash string
+	// WarmUp marks the message as warm-up traffic, it's enough to set it on the sent event
+	WarmUp bool
+}
+
+// Report is the JSON report schema. Fields must only be added, never renamed or removed,
+// without bumping REPORT_SCHEMA_VERSION.
+type Report struct {
+	SchemaVersion       int                  `json:"schemaVersion"`
+	StartedAt           time.Time            `json:"startedAt"`
+	FinishedAt          time.Time            `json:"finishedAt"`
+	Passed              bool                 `json:"passed"`
+	Totals              ReportCounts         `json:"totals"`
+	Lanes               []LaneReport         `json:"lanes"`
+	ThresholdViolations []ThresholdViolation `json:"thresholdViolations"`
+	Messages            []MessageReport      `json:"messages,omitempty"`
+	// WarmUp counts the warm-up messages, which are left out of Totals and Lanes when excluded from results
+	WarmUp *ReportCounts `json:"warmUp,omitempty"`
+	// Costs are the estimated and actual spend per chain, when the reporter tracks costs
+	Costs []CostReport `json:"costs,omitempty"`
+	// DegradedComponents are the optional components the run went on without
+	DegradedComponents []DegradedComponent `json:"degradedComponents,omitempty"`
+	// Labels are the run labels, see Config.Labels
+	Labels map[string]string `json:"labels,omitempty"`
+	// Heartbeats are the heartbeats sent per chain, sorted by chain, when Heartbeat is enabled
+	Heartbeats []HeartbeatReport `json:"heartbeats,omitempty"`
+	// Versions are the versions the components ran, when CollectVersions is set
+	Versions *VersionReport `json:"versions,omitempty"`
+	// CommitBatches is the commit batch size distribution per lane, when CommitAssertions are evaluated
+	CommitBatches []CommitBatches `json:"commitBatches,omitempty"`
+	// Teardown is what TeardownVerification found leaked and swept
+	Teardown *TeardownReport `json:"teardown,omitempty"`
+	// RPCUsage are the RPC requests made per chain, sorted by chain, when chains have an RPCBudget
+	RPCUsage []RPCUsage `json:"rpcUsage,omitempty"`
+}
+
+// HeartbeatReport counts the heartbeats of a chain.
+type HeartbeatReport struct {
+	Chain  string `json:"chain"`
+	Sent   int    `json:"sent"`
+	Missed int    `json:"missed"`
+	// MaxConsecutiveMissed is the longest outage of the chain, in heartbeats
+	MaxConsecutiveMissed int `json:"maxConsecutiveMissed"`
+}
+
+// CostReport is the spend on a chain, amounts are in whole native and LINK units. Estimated amounts
+// are empty for chains that weren't estimated.
+type CostReport struct {
+	Chain           string `json:"chain"`
+	Selector        uint64 `json:"selector"`
+	EstimatedNative string `json:"estimatedNative,omitempty"`
+	EstimatedLINK   string `json:"estimatedLink,omitempty"`
+	ActualNative    string `json:"actualNative"`
+	ActualLINK      string `json:"actualLink"`
+	ActualMessages  int64  `json:"actualMessages"`
+}
+
+type ReportCounts struct {
+	Sent      int `json:"sent"`
+	Committed int `json:"committed"`
+	Blessed   int `json:"blessed"`
+	Executed  int `json:"executed"`
+	Failed    int `json:"failed"`
+	// Discrepancies are messages whose logs and offramp state disagreed, a failure class of their own
+	Discrepancies int `json:"discrepancies,omitempty"`
+	// Remediated are the executed messages that were remediated, the others were executed organically
+	Remediated int `json:"remediated,omitempty"`
+}
+
+type LaneReport struct {
+	Lane      string                    `json:"lane"`
+	Counts    ReportCounts              `json:"counts"`
+	Latencies map[string]LatencySummary `json:"latencies"`
+	// ExpectedE2ELatencyMs is the latency the LatencyModel expects on the lane, to compare the executed
+	// latencies against
+	ExpectedE2ELatencyMs int64 `json:"expectedE2ELatencyMs,omitempty"`
+}
+
+// LatencySummary is measured from the time the message was sent.
+type LatencySummary struct {
+	Count int   `json:"count"`
+	P50Ms int64 `json:"p50Ms"`
+	P95Ms int64 `json:"p95Ms"`
+	MaxMs int64 `json:"maxMs"`
+}
+
+type MessageReport struct {
+	Lane        string     `json:"lane"`
+	SeqNr       uint64     `json:"seqNr"`
+	MessageID   string     `json:"messageId"`
+	SentAt      *time.Time `json:"sentAt,omitempty"`
+	CommittedAt *time.Time `json:"committedAt,omitempty"`
+	BlessedAt   *time.Time `json:"blessedAt,omitempty"`
+	ExecutedAt  *time.Time `json:"executedAt,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	// TxLinks are the explorer links of the message's transactions, keyed by phase
+	TxLinks map[string]string `json:"txLinks,omitempty"`
+	WarmUp  bool              `json:"warmUp,omitempty"`
+	// AssertionSource is the ASSERTION_SOURCE_* the execution verdict is backed by
+	AssertionSource string `json:"assertionSource,omitempty"`
+	Discrepancy     string `json:"discrepancy,omitempty"`
+	// Remediation is the Remediation.Mode the stuck message was remediated with
+	Remediation string `json:"remediation,omitempty"`
+}
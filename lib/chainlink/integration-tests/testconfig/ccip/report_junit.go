@@ -0,0 +1,81 @@
+package ccip
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"time"
+)
+
+type junitTestSuite struct {
+	XMLName    xml.Name         `xml:"testsuite"`
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Timestamp  string           `xml:"timestamp,attr"`
+	Time       string           `xml:"time,attr"`
+	Properties *junitProperties `xml:"properties,omitempty"`
+	TestCases  []junitTestCase  `xml:"testcase"`
+}
+
+type junitProperties struct {
+	Properties []junitProperty `xml:"property"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+}
+
+// toJUnit reports every lane and every threshold violation as a test case.
+func (r Report) toJUnit() junitTestSuite {
+	suite := junitTestSuite{
+		Name:      "ccip",
+		Timestamp: r.StartedAt.Format(time.RFC3339),
+		Time:      fmt.Sprintf("%.3f", r.FinishedAt.Sub(r.StartedAt).Seconds()),
+	}
+	if len(r.Labels) > 0 {
+		suite.Properties = &junitProperties{}
+		names := make([]string, 0, len(r.Labels))
+		for name := range r.Labels {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			suite.Properties.Properties = append(suite.Properties.Properties, junitProperty{Name: name, Value: r.Labels[name]})
+		}
+	}
+	for _, lane := range r.Lanes {
+		tc := junitTestCase{Name: lane.Lane, ClassName: "ccip.lanes"}
+		if lane.Counts.Failed > 0 {
+			tc.Failure = &junitFailure{Message: fmt.Sprintf("%d of %d messages failed", lane.Counts.Failed, lane.Counts.Sent)}
+		} else if lane.Counts.Discrepancies > 0 {
+			tc.Failure = &junitFailure{Message: fmt.Sprintf("logs and offramp state disagreed on %d of %d messages", lane.Counts.Discrepancies, lane.Counts.Sent)}
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+	for _, violation := range r.ThresholdViolations {
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			Name:      violation.Threshold,
+			ClassName: "ccip.thresholds",
+			Failure:   &junitFailure{Message: violation.String()},
+		})
+	}
+	suite.Tests = len(suite.TestCases)
+	for _, tc := range suite.TestCases {
+		if tc.Failure != nil {
+			suite.Failures++
+		}
+	}
+	return suite
+}
@@ -0,0 +1,475 @@
+package ccip
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Reporter collects message events and threshold violations during a test
+// and serializes them according to the Reporting config. It is safe for concurrent use.
+type Reporter struct {
+	cfg        *Reporting
+	now        func() time.Time
+	startedAt  time.Time
+	mu         sync.Mutex
+	messages   map[string]*MessageReport
+	order      []string
+	violations []ThresholdViolation
+	txLink     func(lane, phase, txHash string) string
+	// excludeWarmUp leaves warm-up messages out of Results and the report totals
+	excludeWarmUp bool
+	costs         *CostTracker
+	costEstimate  *CostEstimate
+	degraded      []DegradedComponent
+	labels        map[string]string
+	expected      map[string]time.Duration
+	heartbeats    map[string]*heartbeatCounts
+	// countRemediatedAsFailed makes Results count remediated messages as failed
+	countRemediatedAsFailed bool
+	versions                *VersionReport
+	commitBatches           []CommitBatches
+	teardown                *TeardownReport
+	rpcUsage                []RPCUsage
+}
+
+type heartbeatCounts struct {
+	HeartbeatReport
+	consecutiveMissed int
+}
+
+func NewReporter(cfg *Reporting) *Reporter {
+	r := &Reporter{
+		cfg:      cfg,
+		now:      time.Now,
+		messages: make(map[string]*MessageReport),
+	}
+	r.startedAt = r.now()
+	return r
+}
+
+// LinkTransactions makes the reporter link the transactions of recorded events to the explorers configured in cfg.
+func (r *Reporter) LinkTransactions(cfg *Config) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.txLink = cfg.laneTxLink
+}
+
+// AttachLabels makes the report carry the run labels of cfg.
+func (r *Reporter) AttachLabels(cfg *Config) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.labels = cfg.Labels()
+}
+
+// ApplyWarmUp makes the reporter leave warm-up messages out of Results and the report totals, if the
+// warm-up is configured to be excluded from results.
+func (r *Reporter) ApplyWarmUp(w *WarmUp) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.excludeWarmUp = w.GetExcludeFromResults()
+}
+
+// ApplyRemediation makes Results count remediated messages as requiring manual execution, and as
+// failed if Remediation.CountRemediatedAsFailed is set.
+func (r *Reporter) ApplyRemediation(remediation *Remediation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.countRemediatedAsFailed = remediation.GetCountRemediatedAsFailed()
+}
+
+// TrackCosts makes the report include the actual spend recorded by the tracker, next to the estimate
+// if there is one.
+func (r *Reporter) TrackCosts(tracker *CostTracker, estimate *CostEstimate) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.costs = tracker
+	r.costEstimate = estimate
+}
+
+// VerifyWarmUp returns an error if any warm-up message failed or wasn't executed yet. The load generator
+// calls it once warm-up is over when WarmUp.AbortIfWarmUpFails is set.
+func (r *Reporter) VerifyWarmUp() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	total, failed := 0, 0
+	for _, msg := range r.messages {
+		if !msg.WarmUp {
+			continue
+		}
+		total++
+		if msg.Error != "" || msg.ExecutedAt == nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d warm-up messages failed or weren't executed", failed, total)
+	}
+	return nil
+}
+
+// RecordMessageEvent records a phase transition, messages are identified by lane and sequence number.
+func (r *Reporter) RecordMessageEvent(event MessageEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := fmt.Sprintf("%s/%d", event.Lane, event.SeqNr)
+	msg, ok := r.messages[key]
+	if !ok {
+		msg = &MessageReport{Lane: event.Lane, SeqNr: event.SeqNr}
+		r.messages[key] = msg
+		r.order = append(r.order, key)
+	}
+	if event.MessageID != "" {
+		msg.MessageID = event.MessageID
+	}
+	if event.WarmUp {
+		msg.WarmUp = true
+	}
+	at := event.At
+	switch event.Phase {
+	case MESSAGE_PHASE_SENT:
+		msg.SentAt = &at
+	case MESSAGE_PHASE_COMMITTED:
+		msg.CommittedAt = &at
+	case MESSAGE_PHASE_BLESSED:
+		msg.BlessedAt = &at
+	case MESSAGE_PHASE_EXECUTED:
+		msg.ExecutedAt = &at
+	case MESSAGE_PHASE_FAILED:
+		msg.Error = event.Error
+	default:
+		return fmt.Errorf("unknown message phase %q", event.Phase)
+	}
+	if event.TxHash != "" && r.txLink != nil {
+		if link := r.txLink(event.Lane, event.Phase, event.TxHash); link != "" {
+			if msg.TxLinks == nil {
+				msg.TxLinks = make(map[string]string)
+			}
+			msg.TxLinks[event.Phase] = link
+		}
+	}
+	return nil
+}
+
+// RecordVerdict records the assertion of a message's execution, typically from Config.CheckExecuted.
+func (r *Reporter) RecordVerdict(lane string, seqNr uint64, verdict AssertionVerdict) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := fmt.Sprintf("%s/%d", lane, seqNr)
+	msg, ok := r.messages[key]
+	if !ok {
+		msg = &MessageReport{Lane: lane, SeqNr: seqNr}
+		r.messages[key] = msg
+		r.order = append(r.order, key)
+	}
+	msg.AssertionSource = verdict.Source
+	msg.Discrepancy = verdict.Discrepancy
+}
+
+// RecordRemediation records that a stuck message was remediated, typically by RemediationDriver.
+func (r *Reporter) RecordRemediation(lane string, seqNr uint64, mode string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := fmt.Sprintf("%s/%d", lane, seqNr)
+	msg, ok := r.messages[key]
+	if !ok {
+		msg = &MessageReport{Lane: lane, SeqNr: seqNr}
+		r.messages[key] = msg
+		r.order = append(r.order, key)
+	}
+	msg.Remediation = mode
+}
+
+// ExpectLatency makes the report of the lane carry its expected latency, typically from
+// Config.ExpectedE2ELatency.
+func (r *Reporter) ExpectLatency(lane string, expected time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.expected == nil {
+		r.expected = make(map[string]time.Duration)
+	}
+	r.expected[lane] = expected
+}
+
+// RecordHeartbeat records a heartbeat sent on the chain, missed if err is set. See HeartbeatDriver.
+func (r *Reporter) RecordHeartbeat(chain string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.heartbeats == nil {
+		r.heartbeats = make(map[string]*heartbeatCounts)
+	}
+	counts, ok := r.heartbeats[chain]
+	if !ok {
+		counts = &heartbeatCounts{HeartbeatReport: HeartbeatReport{Chain: chain}}
+		r.heartbeats[chain] = counts
+	}
+	if err == nil {
+		counts.Sent++
+		counts.consecutiveMissed = 0
+		return
+	}
+	counts.Missed++
+	counts.consecutiveMissed++
+	counts.MaxConsecutiveMissed = max(counts.MaxConsecutiveMissed, counts.consecutiveMissed)
+}
+
+// RecordVersions makes the report carry the versions collected by VersionCollector.
+func (r *Reporter) RecordVersions(versions *VersionReport) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.versions = versions
+}
+
+// RecordCommitBatches makes the report carry the batch size distributions of Config.AssertCommitReports.
+func (r *Reporter) RecordCommitBatches(batches ...CommitBatches) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.commitBatches = append(r.commitBatches, batches...)
+}
+
+// RecordTeardown makes the report carry the outcome of TeardownVerifier.Verify.
+func (r *Reporter) RecordTeardown(teardown *TeardownReport) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.teardown = teardown
+}
+
+// RecordRPCUsage makes the report carry the requests counted by RPCBudgets.Usage.
+func (r *Reporter) RecordRPCUsage(usage ...RPCUsage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rpcUsage = append(r.rpcUsage, usage...)
+}
+
+// RecordDegradedComponents adds optional components that failed to start, typically from Config.HandleStartError.
+func (r *Reporter) RecordDegradedComponents(degraded ...DegradedComponent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.degraded = append(r.degraded, degraded...)
+}
+
+// RecordThresholdViolations adds violations, typically the result of Thresholds.EvaluateThresholds.
+func (r *Reporter) RecordThresholdViolations(violations ...ThresholdViolation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.violations = append(r.violations, violations...)
+}
+
+// Results returns the recorded messages in the form Thresholds.EvaluateThresholds expects.
+func (r *Reporter) Results() LoadTestResults {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var results LoadTestResults
+	for _, msg := range r.messages {
+		if msg.SentAt == nil || (msg.WarmUp && r.excludeWarmUp) {
+			continue
+		}
+		results.TotalMessages++
+		if msg.Remediation != "" {
+			results.ManualExecRequired++
+		}
+		if msg.Error != "" || (msg.Remediation != "" && r.countRemediatedAsFailed) {
+			results.FailedMessages++
+		}
+		if msg.CommittedAt != nil {
+			results.CommitLatencies = append(results.CommitLatencies, msg.CommittedAt.Sub(*msg.SentAt))
+		}
+		if msg.ExecutedAt != nil {
+			results.ExecLatencies = append(results.ExecLatencies, msg.ExecutedAt.Sub(*msg.SentAt))
+		}
+	}
+	return results
+}
+
+// Report builds the report from everything recorded so far.
+func (r *Reporter) Report() Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	report := Report{
+		SchemaVersion:       REPORT_SCHEMA_VERSION,
+		StartedAt:           r.startedAt.UTC(),
+		FinishedAt:          r.now().UTC(),
+		Lanes:               []LaneReport{},
+		ThresholdViolations: append([]ThresholdViolation{}, r.violations...),
+	}
+	lanes := make(map[string]*LaneReport)
+	latencies := make(map[string]map[string][]time.Duration)
+	for _, key := range r.order {
+		msg := r.messages[key]
+		if r.cfg.GetIncludePerMessageDetail() {
+			report.Messages = append(report.Messages, *msg)
+		}
+		if msg.WarmUp {
+			if report.WarmUp == nil {
+				report.WarmUp = &ReportCounts{}
+			}
+			countMessage(report.WarmUp, msg)
+			if r.excludeWarmUp {
+				continue
+			}
+		}
+		lane, ok := lanes[msg.Lane]
+		if !ok {
+			lane = &LaneReport{Lane: msg.Lane, Latencies: map[string]LatencySummary{}}
+			lanes[msg.Lane] = lane
+			latencies[msg.Lane] = make(map[string][]time.Duration)
+		}
+		countMessage(&lane.Counts, msg)
+		countMessage(&report.Totals, msg)
+		if msg.SentAt != nil {
+			for phase, at := range map[string]*time.Time{
+				MESSAGE_PHASE_COMMITTED: msg.CommittedAt,
+				MESSAGE_PHASE_BLESSED:   msg.BlessedAt,
+				MESSAGE_PHASE_EXECUTED:  msg.ExecutedAt,
+			} {
+				if at != nil {
+					latencies[msg.Lane][phase] = append(latencies[msg.Lane][phase], at.Sub(*msg.SentAt))
+				}
+			}
+		}
+	}
+	for name, lane := range lanes {
+		lane.ExpectedE2ELatencyMs = r.expected[name].Milliseconds()
+		for phase, durations := range latencies[name] {
+			lane.Latencies[phase] = LatencySummary{
+				Count: len(durations),
+				P50Ms: Percentile(durations, 50).Milliseconds(),
+				P95Ms: Percentile(durations, 95).Milliseconds(),
+				MaxMs: Percentile(durations, 100).Milliseconds(),
+			}
+		}
+		report.Lanes = append(report.Lanes, *lane)
+	}
+	sort.Slice(report.Lanes, func(i, j int) bool { return report.Lanes[i].Lane < report.Lanes[j].Lane })
+	sort.Slice(report.Messages, func(i, j int) bool {
+		if report.Messages[i].Lane != report.Messages[j].Lane {
+			return report.Messages[i].Lane < report.Messages[j].Lane
+		}
+		return report.Messages[i].SeqNr < report.Messages[j].SeqNr
+	})
+	report.Passed = report.Totals.Failed == 0 && report.Totals.Discrepancies == 0 && len(report.ThresholdViolations) == 0
+	if r.costs != nil {
+		report.Costs = r.costReports()
+	}
+	report.DegradedComponents = append(report.DegradedComponents, r.degraded...)
+	if len(r.labels) > 0 {
+		report.Labels = make(map[string]string, len(r.labels))
+		for k, v := range r.labels {
+			report.Labels[k] = v
+		}
+	}
+	for _, counts := range r.heartbeats {
+		report.Heartbeats = append(report.Heartbeats, counts.HeartbeatReport)
+	}
+	sort.Slice(report.Heartbeats, func(i, j int) bool { return report.Heartbeats[i].Chain < report.Heartbeats[j].Chain })
+	report.Versions = r.versions
+	report.CommitBatches = append(report.CommitBatches, r.commitBatches...)
+	report.Teardown = r.teardown
+	report.RPCUsage = append(report.RPCUsage, r.rpcUsage...)
+	return report
+}
+
+// costReports merges the actual spend with the estimate, sorted by selector.
+func (r *Reporter) costReports() []CostReport {
+	reports := []CostReport{}
+	actuals := r.costs.Actuals()
+	seen := make(map[uint64]bool)
+	for _, actual := range actuals {
+		seen[actual.Selector] = true
+		report := CostReport{
+			Chain:          actual.Name,
+			Selector:       actual.Selector,
+			ActualNative:   formatAmount(actual.Native),
+			ActualLINK:     formatAmount(actual.LINK),
+			ActualMessages: actual.Messages,
+		}
+		if estimate, ok := r.costEstimate.Get(actual.Selector); ok {
+			report.EstimatedNative, report.EstimatedLINK = formatAmount(estimate.Native), formatAmount(estimate.LINK)
+		}
+		reports = append(reports, report)
+	}
+	if r.costEstimate != nil {
+		for _, estimate := range r.costEstimate.Chains {
+			if seen[estimate.Selector] {
+				continue
+			}
+			reports = append(reports, CostReport{
+				Chain:           estimate.Name,
+				Selector:        estimate.Selector,
+				EstimatedNative: formatAmount(estimate.Native),
+				EstimatedLINK:   formatAmount(estimate.LINK),
+				ActualNative:    "0",
+				ActualLINK:      "0",
+			})
+		}
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Selector < reports[j].Selector })
+	return reports
+}
+
+func countMessage(counts *ReportCounts, msg *MessageReport) {
+	if msg.SentAt != nil {
+		counts.Sent++
+	}
+	if msg.CommittedAt != nil {
+		counts.Committed++
+	}
+	if msg.BlessedAt != nil {
+		counts.Blessed++
+	}
+	if msg.ExecutedAt != nil {
+		counts.Executed++
+		if msg.Remediation != "" {
+			counts.Remediated++
+		}
+	}
+	if msg.Error != "" {
+		counts.Failed++
+	}
+	if msg.Discrepancy != "" {
+		counts.Discrepancies++
+	}
+}
+
+// Marshal serializes the report in the configured format.
+func (r *Reporter) Marshal() ([]byte, error) {
+	report := r.Report()
+	var (
+		content []byte
+		err     error
+	)
+	switch r.cfg.GetFormat() {
+	case REPORT_FORMAT_JUNIT:
+		content, err = xml.MarshalIndent(report.toJUnit(), "", "  ")
+		content = append([]byte(xml.Header), content...)
+	default:
+		// lane keys contain "->", keep them readable instead of HTML escaped
+		buf := &bytes.Buffer{}
+		encoder := json.NewEncoder(buf)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(report)
+		return buf.Bytes(), err
+	}
+	if err != nil {
+		return nil, err
+	}
+	return append(content, '\n'), nil
+}
+
+// Write serializes the report and writes it to Reporting.GetReportPath.
+func (r *Reporter) Write() error {
+	content, err := r.Marshal()
+	if err != nil {
+		return err
+	}
+	if err := r.cfg.PrepareOutputDir(); err != nil {
+		return err
+	}
+	return os.WriteFile(r.cfg.GetReportPath(), content, 0o600)
+}
@@ -0,0 +1,80 @@
+package ccip
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	REPORT_FORMAT_JSON  = "json"
+	REPORT_FORMAT_JUNIT = "junit"
+
+	DEFAULT_REPORT_OUTPUT_DIR = "."
+	DEFAULT_REPORT_FORMAT     = REPORT_FORMAT_JSON
+)
+
+// Reporting configures the machine-readable summary written at the end of a test.
+type Reporting struct {
+	OutputDir               *string `toml:",omitempty"`
+	Format                  *string `toml:",omitempty"`
+	IncludePerMessageDetail *bool   `toml:",omitempty"`
+}
+
+func (r *Reporting) GetOutputDir() string {
+	if r == nil || pointer.GetString(r.OutputDir) == "" {
+		return DEFAULT_REPORT_OUTPUT_DIR
+	}
+	return *r.OutputDir
+}
+
+func (r *Reporting) GetFormat() string {
+	if r == nil || pointer.GetString(r.Format) == "" {
+		return DEFAULT_REPORT_FORMAT
+	}
+	return *r.Format
+}
+
+func (r *Reporting) GetIncludePerMessageDetail() bool {
+	if r == nil {
+		return false
+	}
+	return pointer.GetBool(r.IncludePerMessageDetail)
+}
+
+// GetReportPath returns the file the report is written to.
+func (r *Reporting) GetReportPath() string {
+	name := "ccip-report.json"
+	if r.GetFormat() == REPORT_FORMAT_JUNIT {
+		name = "ccip-report.xml"
+	}
+	return filepath.Join(r.GetOutputDir(), name)
+}
+
+// Validate checks the format. Whether the output dir is writable is only checked when the report is written,
+// see PrepareOutputDir.
+func (r *Reporting) Validate() error {
+	switch r.GetFormat() {
+	case REPORT_FORMAT_JSON, REPORT_FORMAT_JUNIT:
+	default:
+		return fmt.Errorf("Reporting.Format must be one of %s or %s, got %q", REPORT_FORMAT_JSON, REPORT_FORMAT_JUNIT, r.GetFormat())
+	}
+	return nil
+}
+
+// PrepareOutputDir creates the output dir if needed and checks it is writable. Write calls it, tests can
+// call it at setup to fail before the run rather than after.
+func (r *Reporting) PrepareOutputDir() error {
+	dir := r.GetOutputDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("Reporting.OutputDir %s cannot be created: %w", dir, err)
+	}
+	probe, err := os.CreateTemp(dir, ".ccip-report-probe-*")
+	if err != nil {
+		return fmt.Errorf("Reporting.OutputDir %s is not writable: %w", dir, err)
+	}
+	_ = probe.Close()
+	return os.Remove(probe.Name())
+}
@@ -0,0 +1,67 @@
+package ccip
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestReporter(t *testing.T, cfg *Reporting) *Reporter {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	r := NewReporter(cfg)
+	r.startedAt = start
+	r.now = func() time.Time { return start.Add(10 * time.Minute) }
+
+	for _, event := range []MessageEvent{
+		{Lane: "SIMULATED_1->SIMULATED_2", SeqNr: 1, MessageID: "0x01", Phase: MESSAGE_PHASE_SENT, At: start},
+		{Lane: "SIMULATED_1->SIMULATED_2", SeqNr: 1, Phase: MESSAGE_PHASE_COMMITTED, At: start.Add(30 * time.Second)},
+		{Lane: "SIMULATED_1->SIMULATED_2", SeqNr: 1, Phase: MESSAGE_PHASE_BLESSED, At: start.Add(40 * time.Second)},
+		{Lane: "SIMULATED_1->SIMULATED_2", SeqNr: 1, Phase: MESSAGE_PHASE_EXECUTED, At: start.Add(time.Minute)},
+		{Lane: "SIMULATED_2->SIMULATED_1", SeqNr: 1, MessageID: "0x02", Phase: MESSAGE_PHASE_SENT, At: start.Add(time.Second)},
+		{Lane: "SIMULATED_2->SIMULATED_1", SeqNr: 1, Phase: MESSAGE_PHASE_FAILED, At: start.Add(5 * time.Minute), Error: "execution reverted"},
+	} {
+		require.NoError(t, r.RecordMessageEvent(event))
+	}
+	r.RecordThresholdViolations(ThresholdViolation{Threshold: THRESHOLD_MAX_FAILED_MESSAGES_PCT, Limit: "1.00%", Actual: "50.00%"})
+	return r
+}
+
+func TestReporterJSONGolden(t *testing.T) {
+	cfg := &Reporting{OutputDir: pointer.ToString(t.TempDir()), IncludePerMessageDetail: pointer.ToBool(true)}
+	require.NoError(t, cfg.Validate())
+	r := newTestReporter(t, cfg)
+	require.NoError(t, r.Write())
+
+	got, err := os.ReadFile(cfg.GetReportPath())
+	require.NoError(t, err)
+	want, err := os.ReadFile(filepath.Join("testdata", "report.golden.json"))
+	require.NoError(t, err)
+	require.Equal(t, string(want), string(got))
+}
+
+func TestReporterJUnit(t *testing.T) {
+	cfg := &Reporting{OutputDir: pointer.ToString(t.TempDir()), Format: pointer.ToString(REPORT_FORMAT_JUNIT)}
+	content, err := newTestReporter(t, cfg).Marshal()
+	require.NoError(t, err)
+	require.Contains(t, string(content), `<testsuite name="ccip" tests="3" failures="2"`)
+}
+
+func TestReportingValidate(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "nested", "dir")
+	cfg := &Reporting{OutputDir: pointer.ToString(dir)}
+	require.NoError(t, cfg.Validate())
+	// Validate doesn't touch the file system, the dir is created when the report is written
+	require.NoDirExists(t, dir)
+	require.NoError(t, cfg.PrepareOutputDir())
+	require.DirExists(t, dir)
+	require.Error(t, (&Reporting{Format: pointer.ToString("xml")}).Validate())
+
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+	err := NewReporter(&Reporting{OutputDir: pointer.ToString(filepath.Join(file, "dir"))}).Write()
+	require.ErrorContains(t, err, "cannot be created")
+}
@@ -0,0 +1,59 @@
+package ccip
+
+import "fmt"
+
+// Resources sets the CPU and memory of the containers started for the environment. Unset values
+// keep the runtime's defaults.
+type Resources struct {
+	Node        *ResourceRequirements `toml:",omitempty"`
+	DB          *ResourceRequirements `toml:",omitempty"`
+	ChainClient *ResourceRequirements `toml:",omitempty"`
+}
+
+type ResourceRequirements struct {
+	// CPU is in cores, fractions are allowed
+	CPU      *float64 `toml:",omitempty"`
+	MemoryMB *int     `toml:",omitempty"`
+}
+
+// GetCPU returns the CPU cores, or 0 for the runtime's default.
+func (r *ResourceRequirements) GetCPU() float64 {
+	if r == nil || r.CPU == nil {
+		return 0
+	}
+	return *r.CPU
+}
+
+// GetMemoryMB returns the memory in MB, or 0 for the runtime's default.
+func (r *ResourceRequirements) GetMemoryMB() int {
+	if r == nil || r.MemoryMB == nil {
+		return 0
+	}
+	return *r.MemoryMB
+}
+
+func (r *ResourceRequirements) validate(field string) error {
+	if r == nil {
+		return nil
+	}
+	if r.CPU != nil && *r.CPU <= 0 {
+		return fmt.Errorf("%s.CPU must be positive, got %f", field, *r.CPU)
+	}
+	if r.MemoryMB != nil && *r.MemoryMB <= 0 {
+		return fmt.Errorf("%s.MemoryMB must be positive, got %d", field, *r.MemoryMB)
+	}
+	return nil
+}
+
+func (o *Config) validateResources() error {
+	if o.Resources == nil {
+		return nil
+	}
+	if err := o.Resources.Node.validate("Resources.Node"); err != nil {
+		return err
+	}
+	if err := o.Resources.DB.validate("Resources.DB"); err != nil {
+		return err
+	}
+	return o.Resources.ChainClient.validate("Resources.ChainClient")
+}
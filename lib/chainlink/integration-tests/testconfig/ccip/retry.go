@@ -0,0 +1,146 @@
+package ccip
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	RETRY_TARGET_RPC      = "rpc"
+	RETRY_TARGET_JD       = "jd"
+	RETRY_TARGET_NODE_API = "nodeAPI"
+
+	DEFAULT_RETRY_MAX_ATTEMPTS       = 1
+	DEFAULT_RETRY_INITIAL_BACKOFF    = time.Second
+	DEFAULT_RETRY_MAX_BACKOFF        = 30 * time.Second
+	DEFAULT_RETRY_BACKOFF_MULTIPLIER = 2.0
+)
+
+// defaultRetryPolicies reproduce what the harness did before retries were configurable: RPC and node API
+// calls weren't retried, while waiting for nodes to connect to JD followed a fibonacci backoff starting
+// at 1s for up to a minute, which the exponential policy below approximates.
+var defaultRetryPolicies = map[string]ResolvedRetryPolicy{
+	RETRY_TARGET_RPC:      {MaxAttempts: 1, InitialBackoff: DEFAULT_RETRY_INITIAL_BACKOFF, MaxBackoff: DEFAULT_RETRY_MAX_BACKOFF, BackoffMultiplier: DEFAULT_RETRY_BACKOFF_MULTIPLIER},
+	RETRY_TARGET_JD:       {MaxAttempts: 9, InitialBackoff: time.Second, MaxBackoff: 21 * time.Second, BackoffMultiplier: 1.6},
+	RETRY_TARGET_NODE_API: {MaxAttempts: 1, InitialBackoff: DEFAULT_RETRY_INITIAL_BACKOFF, MaxBackoff: DEFAULT_RETRY_MAX_BACKOFF, BackoffMultiplier: DEFAULT_RETRY_BACKOFF_MULTIPLIER},
+}
+
+// RetrySettings configures retries with exponential backoff.
+type RetrySettings struct {
+	MaxAttempts       *int      `toml:",omitempty"`
+	InitialBackoff    *Duration `toml:",omitempty"`
+	MaxBackoff        *Duration `toml:",omitempty"`
+	BackoffMultiplier *float64  `toml:",omitempty"`
+}
+
+// RetryPolicy holds the retry settings of all targets, with per target overrides keyed by
+// target name. Settings apply over the target's defaults, overrides apply over the settings.
+type RetryPolicy struct {
+	RetrySettings
+	PerTarget map[string]*RetrySettings `toml:",omitempty"`
+}
+
+// ResolvedRetryPolicy is the retry policy of a single target, with every value set.
+type ResolvedRetryPolicy struct {
+	MaxAttempts       int
+	InitialBackoff    time.Duration
+	MaxBackoff        time.Duration
+	BackoffMultiplier float64
+}
+
+func (r ResolvedRetryPolicy) apply(s *RetrySettings) ResolvedRetryPolicy {
+	if s == nil {
+		return r
+	}
+	if s.MaxAttempts != nil {
+		r.MaxAttempts = *s.MaxAttempts
+	}
+	if s.InitialBackoff != nil {
+		r.InitialBackoff = s.InitialBackoff.Duration
+	}
+	if s.MaxBackoff != nil {
+		r.MaxBackoff = s.MaxBackoff.Duration
+	}
+	if s.BackoffMultiplier != nil {
+		r.BackoffMultiplier = *s.BackoffMultiplier
+	}
+	return r
+}
+
+// Backoff returns how long to wait after the given failed attempt, starting at 1.
+func (r ResolvedRetryPolicy) Backoff(attempt int) time.Duration {
+	backoff := float64(r.InitialBackoff)
+	for i := 1; i < attempt && backoff < float64(r.MaxBackoff); i++ {
+		backoff *= r.BackoffMultiplier
+	}
+	if backoff > float64(r.MaxBackoff) {
+		return r.MaxBackoff
+	}
+	return time.Duration(backoff)
+}
+
+// Do calls fn until it succeeds, MaxAttempts is reached or ctx is done, returning the last error.
+func (r ResolvedRetryPolicy) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = fn(ctx); err == nil || attempt >= r.MaxAttempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w, last error: %w", ctx.Err(), err)
+		case <-time.After(r.Backoff(attempt)):
+		}
+	}
+}
+
+// GetRetryPolicy returns the retry policy of the target, one of the RETRY_TARGET_* constants.
+func (o *Config) GetRetryPolicy(target string) ResolvedRetryPolicy {
+	resolved, ok := defaultRetryPolicies[target]
+	if !ok {
+		resolved = ResolvedRetryPolicy{
+			MaxAttempts:       DEFAULT_RETRY_MAX_ATTEMPTS,
+			InitialBackoff:    DEFAULT_RETRY_INITIAL_BACKOFF,
+			MaxBackoff:        DEFAULT_RETRY_MAX_BACKOFF,
+			BackoffMultiplier: DEFAULT_RETRY_BACKOFF_MULTIPLIER,
+		}
+	}
+	if o.RetryPolicy == nil {
+		return resolved
+	}
+	return resolved.apply(&o.RetryPolicy.RetrySettings).apply(o.RetryPolicy.PerTarget[target])
+}
+
+func (o *Config) validateRetryPolicy() error {
+	if o.RetryPolicy == nil {
+		return nil
+	}
+	targets := make([]string, 0, len(defaultRetryPolicies))
+	for target := range defaultRetryPolicies {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+	for target := range o.RetryPolicy.PerTarget {
+		if _, ok := defaultRetryPolicies[target]; !ok {
+			return fmt.Errorf("RetryPolicy.PerTarget.%s is not one of %v", target, targets)
+		}
+	}
+	for _, target := range targets {
+		policy := o.GetRetryPolicy(target)
+		if policy.MaxAttempts < 1 {
+			return fmt.Errorf("RetryPolicy %s: MaxAttempts must be at least 1, got %d", target, policy.MaxAttempts)
+		}
+		if policy.InitialBackoff <= 0 {
+			return fmt.Errorf("RetryPolicy %s: InitialBackoff must be positive", target)
+		}
+		if policy.MaxBackoff < policy.InitialBackoff {
+			return fmt.Errorf("RetryPolicy %s: MaxBackoff %s must not be less than InitialBackoff %s", target, policy.MaxBackoff, policy.InitialBackoff)
+		}
+		if policy.BackoffMultiplier < 1 {
+			return fmt.Errorf("RetryPolicy %s: BackoffMultiplier must be at least 1, got %f", target, policy.BackoffMultiplier)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package ccip
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRetryPolicy(t *testing.T) {
+	var cfg Config
+	// without a RetryPolicy every target keeps its previous behaviour
+	require.Equal(t, 1, cfg.GetRetryPolicy(RETRY_TARGET_RPC).MaxAttempts)
+	require.Equal(t, defaultRetryPolicies[RETRY_TARGET_JD], cfg.GetRetryPolicy(RETRY_TARGET_JD))
+
+	require.NoError(t, toml.Unmarshal([]byte(`
+[RetryPolicy]
+MaxAttempts = 3
+InitialBackoff = '500ms'
+
+[RetryPolicy.PerTarget.jd]
+MaxAttempts = 20
+`), &cfg))
+	require.NoError(t, cfg.validateRetryPolicy())
+
+	rpc := cfg.GetRetryPolicy(RETRY_TARGET_RPC)
+	require.Equal(t, 3, rpc.MaxAttempts)
+	require.Equal(t, 500*time.Millisecond, rpc.InitialBackoff)
+	require.Equal(t, DEFAULT_RETRY_MAX_BACKOFF, rpc.MaxBackoff)
+
+	jd := cfg.GetRetryPolicy(RETRY_TARGET_JD)
+	require.Equal(t, 20, jd.MaxAttempts)
+	require.Equal(t, 500*time.Millisecond, jd.InitialBackoff)
+	require.Equal(t, 21*time.Second, jd.MaxBackoff)
+}
+
+func TestValidateRetryPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		config string
+		err    string
+	}{
+		{"[RetryPolicy]\nBackoffMultiplier = 0.5\n", "BackoffMultiplier must be at least 1"},
+		{"[RetryPolicy.PerTarget.rpc]\nInitialBackoff = '1m'\nMaxBackoff = '10s'\n", "RetryPolicy rpc: MaxBackoff 10s must not be less than InitialBackoff 1m0s"},
+		{"[RetryPolicy.PerTarget.grpc]\nMaxAttempts = 2\n", "RetryPolicy.PerTarget.grpc is not one of [jd nodeAPI rpc]"},
+	} {
+		var cfg Config
+		require.NoError(t, toml.Unmarshal([]byte(tc.config), &cfg))
+		require.ErrorContains(t, cfg.validateRetryPolicy(), tc.err)
+	}
+}
+
+func TestRetryPolicyDo(t *testing.T) {
+	policy := ResolvedRetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond, BackoffMultiplier: 4}
+	require.Equal(t, time.Millisecond, policy.Backoff(1))
+	require.Equal(t, 2*time.Millisecond, policy.Backoff(2))
+
+	calls := 0
+	err := policy.Do(context.Background(), func(context.Context) error {
+		calls++
+		return errors.New("unavailable")
+	})
+	require.EqualError(t, err, "unavailable")
+	require.Equal(t, 3, calls)
+}
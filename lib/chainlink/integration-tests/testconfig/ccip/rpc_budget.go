@@ -0,0 +1,428 @@
+package ccip
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	// RPC_BUDGET_ON_EXCEEDED_THROTTLE waits for the budget to allow the request
+	RPC_BUDGET_ON_EXCEEDED_THROTTLE = "throttle"
+	// RPC_BUDGET_ON_EXCEEDED_FAIL_TEST fails the request with ErrRPCBudgetExceeded
+	RPC_BUDGET_ON_EXCEEDED_FAIL_TEST = "failTest"
+	// RPC_BUDGET_ON_EXCEEDED_SWITCH_ENDPOINT moves on to the next endpoint of the transport with budget
+	// left, every endpoint having a budget of its own, and throttles when none has
+	RPC_BUDGET_ON_EXCEEDED_SWITCH_ENDPOINT = "switchEndpoint"
+
+	// RPC_BUDGET_WINDOW is the window DailyRequestCap is counted over, from the first request to an endpoint
+	RPC_BUDGET_WINDOW = 24 * time.Hour
+
+	// RPC_CALLS_PER_SEND is the requests a send takes at least: nonce, gas price, gas estimate and the
+	// transaction itself
+	RPC_CALLS_PER_SEND = 4
+	// RPC_CALLS_PER_ASSERTION is the requests the assertion of a message takes at least: the execution
+	// state change logs and the receipt of the execution
+	RPC_CALLS_PER_ASSERTION = 2
+
+	RPC_METRICS_JOB = "ccip-e2e-rpc"
+)
+
+var rpcBudgetOnExceeded = []string{RPC_BUDGET_ON_EXCEEDED_THROTTLE, RPC_BUDGET_ON_EXCEEDED_FAIL_TEST, RPC_BUDGET_ON_EXCEEDED_SWITCH_ENDPOINT}
+
+// RPCBudgetConfig is the budget of the RPC requests the harness makes to a chain, to stay within the
+// quota of paid providers. Unset limits aren't enforced.
+type RPCBudgetConfig struct {
+	RequestsPerSecond *float64 `toml:",omitempty"`
+	DailyRequestCap   *int64   `toml:",omitempty"`
+	// OnExceeded is one of the RPC_BUDGET_ON_EXCEEDED_* constants, throttle unless set
+	OnExceeded *string `toml:",omitempty"`
+}
+
+func (b RPCBudgetConfig) GetOnExceeded() string {
+	if pointer.GetString(b.OnExceeded) == "" {
+		return RPC_BUDGET_ON_EXCEEDED_THROTTLE
+	}
+	return *b.OnExceeded
+}
+
+// GetRPCBudget returns the RPCBudget of the chain, false if it has none.
+func (o *Config) GetRPCBudget(selector uint64) (RPCBudgetConfig, bool) {
+	for ref, budget := range o.RPCBudget {
+		if resolved, err := o.ResolveChainSelector(ref); err == nil && resolved == selector {
+			return budget, true
+		}
+	}
+	return RPCBudgetConfig{}, false
+}
+
+// RPCUsage counts the RPC requests the harness made to a chain.
+type RPCUsage struct {
+	Chain    string `json:"chain"`
+	Requests int64  `json:"requests"`
+	// Throttled counts the times a request waited for the budget
+	Throttled int64 `json:"throttled,omitempty"`
+	// Rejected counts the requests failed because the budget was exceeded
+	Rejected         int64 `json:"rejected,omitempty"`
+	EndpointSwitches int64 `json:"endpointSwitches,omitempty"`
+}
+
+// RPCBudgets enforces the RPCBudget of every chain. The client factory wraps the HTTP transport of
+// the clients it builds with RoundTripper, WS clients call Acquire on the Limiter of their chain before
+// every request and redial when the endpoint it returns changes.
+type RPCBudgets struct {
+	cfg *Config
+	now func() time.Time
+	// sleep waits until the given time, unless the context is done first
+	sleep func(ctx context.Context, at time.Time) error
+
+	mu       sync.Mutex
+	limiters map[uint64]*RPCLimiter
+}
+
+// NewRPCBudgets returns the budgets of the run, nil if RPCBudget is empty.
+func (o *Config) NewRPCBudgets() *RPCBudgets {
+	if len(o.RPCBudget) == 0 {
+		return nil
+	}
+	return &RPCBudgets{cfg: o, now: time.Now, sleep: sleepUntil, limiters: make(map[uint64]*RPCLimiter)}
+}
+
+// Limiter returns the limiter shared by the clients of the chain, nil if the chain has no budget.
+func (b *RPCBudgets) Limiter(selector uint64) *RPCLimiter {
+	if b == nil {
+		return nil
+	}
+	budget, ok := b.cfg.GetRPCBudget(selector)
+	if !ok {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if limiter, ok := b.limiters[selector]; ok {
+		return limiter
+	}
+	limiter := &RPCLimiter{
+		budget:  budget,
+		now:     b.now,
+		sleep:   b.sleep,
+		buckets: make(map[string]*rpcBucket),
+		usage:   RPCUsage{Chain: chainName(selector)},
+	}
+	b.limiters[selector] = limiter
+	return limiter
+}
+
+// RoundTripper wraps next so every request to the chain is within its budget. urls are the endpoints
+// of the transport as returned by Config.ResolveTransport, the first one being the one the client
+// was built with. next is returned as is if the chain has no budget, http.DefaultTransport is used if nil.
+func (b *RPCBudgets) RoundTripper(selector uint64, urls []string, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	limiter := b.Limiter(selector)
+	if limiter == nil {
+		return next
+	}
+	return &rpcBudgetTransport{limiter: limiter, urls: urls, next: next}
+}
+
+// Usage returns the requests made to every budgeted chain so far, sorted by chain.
+func (b *RPCBudgets) Usage() []RPCUsage {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	usage := make([]RPCUsage, 0, len(b.limiters))
+	for _, limiter := range b.limiters {
+		usage = append(usage, limiter.Usage())
+	}
+	sort.Slice(usage, func(i, j int) bool { return usage[i].Chain < usage[j].Chain })
+	return usage
+}
+
+// WriteMetrics writes the Usage counters in the Prometheus text format, labeled by chain.
+func (b *RPCBudgets) WriteMetrics(w io.Writer) error {
+	usage := b.Usage()
+	for _, metric := range []struct {
+		name, help string
+		value      func(RPCUsage) int64
+	}{
+		{"ccip_e2e_rpc_requests_total", "RPC requests made by the harness", func(u RPCUsage) int64 { return u.Requests }},
+		{"ccip_e2e_rpc_throttled_total", "Times an RPC request waited for the budget", func(u RPCUsage) int64 { return u.Throttled }},
+		{"ccip_e2e_rpc_rejected_total", "RPC requests failed because the budget was exceeded", func(u RPCUsage) int64 { return u.Rejected }},
+		{"ccip_e2e_rpc_endpoint_switches_total", "Switches to another RPC endpoint because the budget was exceeded", func(u RPCUsage) int64 { return u.EndpointSwitches }},
+	} {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", metric.name, metric.help, metric.name); err != nil {
+			return err
+		}
+		for _, u := range usage {
+			if _, err := fmt.Fprintf(w, "%s{chain=%q} %d\n", metric.name, u.Chain, metric.value(u)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// PushMetrics pushes the WriteMetrics counters to the Prometheus pushgateway of Observability, grouped
+// by the RunID. It does nothing if no pushgateway is configured.
+func (b *RPCBudgets) PushMetrics(ctx context.Context, client *http.Client) error {
+	if b == nil {
+		return nil
+	}
+	gateway := b.cfg.Observability.GetPrometheusPushgateway()
+	if gateway == "" {
+		return nil
+	}
+	var body strings.Builder
+	if err := b.WriteMetrics(&body); err != nil {
+		return err
+	}
+	target := fmt.Sprintf("%s/metrics/job/%s/run_id/%s", strings.TrimSuffix(gateway, "/"), RPC_METRICS_JOB, url.PathEscape(b.cfg.GetRunID()))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, target, strings.NewReader(body.String()))
+	if err != nil {
+		return withKind(ErrInvalidEndpoint, fmt.Errorf("Observability.PrometheusPushgateway: %w", err))
+	}
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4")
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("push RPC metrics: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("push RPC metrics: pushgateway returned %s", resp.Status)
+	}
+	return nil
+}
+
+// RPCLimiter is the token bucket and daily counter of the RPCBudget of a chain, per endpoint.
+type RPCLimiter struct {
+	budget RPCBudgetConfig
+	now    func() time.Time
+	sleep  func(ctx context.Context, at time.Time) error
+
+	mu sync.Mutex
+	// current is the endpoint requests go to while switching endpoints
+	current string
+	buckets map[string]*rpcBucket
+	usage   RPCUsage
+}
+
+type rpcBucket struct {
+	tokens      float64
+	last        time.Time
+	windowStart time.Time
+	count       int64
+}
+
+// Acquire waits until the budget allows a request to one of urls, the endpoints of a transport, and
+// returns the endpoint to send it to. It is the first one unless the budget switches endpoints.
+func (l *RPCLimiter) Acquire(ctx context.Context, urls []string) (string, error) {
+	if len(urls) == 0 {
+		return "", withKind(ErrInvalidEndpoint, fmt.Errorf("no RPC endpoints to acquire a request to"))
+	}
+	if l == nil {
+		return urls[0], nil
+	}
+	for {
+		endpoint, retryAt, err := l.take(urls)
+		if err != nil || endpoint != "" {
+			return endpoint, err
+		}
+		if err := l.sleep(ctx, retryAt); err != nil {
+			return "", fmt.Errorf("chain %s: waiting for RPCBudget: %w", l.usage.Chain, err)
+		}
+	}
+}
+
+// take spends a request of the budget of an endpoint, or returns when to try again if the request
+// is throttled.
+func (l *RPCLimiter) take(urls []string) (string, time.Time, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	onExceeded := l.budget.GetOnExceeded()
+	first := 0
+	for i, endpoint := range urls {
+		if endpoint == l.current {
+			first = i
+		}
+	}
+	candidates := 1
+	if onExceeded == RPC_BUDGET_ON_EXCEEDED_SWITCH_ENDPOINT {
+		candidates = len(urls)
+	}
+	// endpoints below the rate are retried first, the new window of capped ones can be a day away
+	var rateRetryAt, capRetryAt time.Time
+	capped := 0
+	for n := 0; n < candidates; n++ {
+		i := (first + n) % len(urls)
+		bucket := l.bucket(urls[i], now)
+		if limit := l.budget.DailyRequestCap; limit != nil && bucket.count >= *limit {
+			capped++
+			capRetryAt = earliest(capRetryAt, bucket.windowStart.Add(RPC_BUDGET_WINDOW))
+			continue
+		}
+		if rps := pointer.GetFloat64(l.budget.RequestsPerSecond); rps > 0 && bucket.tokens < 1 {
+			rateRetryAt = earliest(rateRetryAt, now.Add(time.Duration(math.Ceil((1-bucket.tokens)/rps*float64(time.Second)))))
+			continue
+		}
+		if l.budget.RequestsPerSecond != nil {
+			bucket.tokens--
+		}
+		bucket.count++
+		l.usage.Requests++
+		if n > 0 {
+			l.usage.EndpointSwitches++
+		}
+		l.current = urls[i]
+		return urls[i], time.Time{}, nil
+	}
+	switch {
+	case onExceeded == RPC_BUDGET_ON_EXCEEDED_FAIL_TEST:
+		l.usage.Rejected++
+		return "", time.Time{}, withKind(ErrRPCBudgetExceeded, fmt.Errorf("chain %s: request is over the RPCBudget", l.usage.Chain))
+	case onExceeded == RPC_BUDGET_ON_EXCEEDED_SWITCH_ENDPOINT && capped == candidates:
+		l.usage.Rejected++
+		return "", time.Time{}, withKind(ErrRPCBudgetExceeded, fmt.Errorf("chain %s: every endpoint reached RPCBudget.DailyRequestCap", l.usage.Chain))
+	}
+	l.usage.Throttled++
+	if rateRetryAt.IsZero() {
+		return "", capRetryAt, nil
+	}
+	return "", rateRetryAt, nil
+}
+
+func earliest(current, at time.Time) time.Time {
+	if current.IsZero() || at.Before(current) {
+		return at
+	}
+	return current
+}
+
+// bucket returns the refilled bucket of the endpoint, starting a new window once the last one is over.
+func (l *RPCLimiter) bucket(endpoint string, now time.Time) *rpcBucket {
+	rps := pointer.GetFloat64(l.budget.RequestsPerSecond)
+	// a second worth of requests can be sent at once
+	burst := max(1, rps)
+	bucket, ok := l.buckets[endpoint]
+	if !ok {
+		bucket = &rpcBucket{tokens: burst, last: now, windowStart: now}
+		l.buckets[endpoint] = bucket
+	}
+	if !now.Before(bucket.windowStart.Add(RPC_BUDGET_WINDOW)) {
+		bucket.windowStart, bucket.count = now, 0
+	}
+	bucket.tokens = min(burst, bucket.tokens+now.Sub(bucket.last).Seconds()*rps)
+	bucket.last = now
+	return bucket
+}
+
+// Usage returns the requests made to the chain so far.
+func (l *RPCLimiter) Usage() RPCUsage {
+	if l == nil {
+		return RPCUsage{}
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.usage
+}
+
+type rpcBudgetTransport struct {
+	limiter *RPCLimiter
+	urls    []string
+	next    http.RoundTripper
+}
+
+func (t *rpcBudgetTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint, err := t.limiter.Acquire(req.Context(), t.urls)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint == req.URL.String() {
+		return t.next.RoundTrip(req)
+	}
+	target, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, withKind(ErrInvalidEndpoint, err)
+	}
+	switched := req.Clone(req.Context())
+	switched.URL = target
+	switched.Host = ""
+	return t.next.RoundTrip(switched)
+}
+
+// validateRPCBudget rejects budgets the LoadProfile can't be run within: sending at MessagesPerSecond
+// takes RPC_CALLS_PER_SEND requests per message, and asserting the messages AssertionSampling picks
+// RPC_CALLS_PER_ASSERTION more. Every budgeted chain is assumed to be the source and dest of one lane
+// at least. Switching endpoints spreads requests over endpoints the config doesn't know of, so only
+// their settings are checked.
+func (o *Config) validateRPCBudget() error {
+	refs := make([]string, 0, len(o.RPCBudget))
+	for ref := range o.RPCBudget {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	for _, ref := range refs {
+		field := "RPCBudget." + ref
+		budget := o.RPCBudget[ref]
+		selector, err := o.ResolveChainSelector(ref)
+		if err != nil {
+			return fieldError(field, err)
+		}
+		onExceeded := budget.GetOnExceeded()
+		if !containsString(rpcBudgetOnExceeded, onExceeded) {
+			return fieldError(field+".OnExceeded", fmt.Errorf("unknown value %q", onExceeded))
+		}
+		if onExceeded == RPC_BUDGET_ON_EXCEEDED_SWITCH_ENDPOINT && o.privateNetwork(selector) != nil {
+			return fmt.Errorf("%s.OnExceeded can't be %s, %s is a private network with a single endpoint", field, onExceeded, ref)
+		}
+		if rps := budget.RequestsPerSecond; rps != nil && *rps <= 0 {
+			return fmt.Errorf("%s.RequestsPerSecond must be above 0, got %g", field, *rps)
+		}
+		if limit := budget.DailyRequestCap; limit != nil && *limit < 1 {
+			return fmt.Errorf("%s.DailyRequestCap must be at least 1, got %d", field, *limit)
+		}
+		if o.LoadProfile == nil || onExceeded == RPC_BUDGET_ON_EXCEEDED_SWITCH_ENDPOINT {
+			continue
+		}
+		rate := o.LoadProfile.GetMessagesPerSecond()
+		if rps := budget.RequestsPerSecond; rps != nil && rate*RPC_CALLS_PER_SEND > *rps {
+			return fmt.Errorf("%s.RequestsPerSecond (%g) is below the %g requests per second LoadProfile sends at %g messages per second",
+				field, *rps, rate*RPC_CALLS_PER_SEND, rate)
+		}
+		if limit := budget.DailyRequestCap; limit != nil {
+			if required := o.dailyRPCRequests(); required > float64(*limit) {
+				return fmt.Errorf("%s.DailyRequestCap (%d) is below the %.0f requests LoadProfile and AssertionSampling make in a day",
+					field, *limit, required)
+			}
+		}
+	}
+	return nil
+}
+
+// dailyRPCRequests estimates the requests made to a chain in the first day of the test, sending
+// and asserting the messages of one lane.
+func (o *Config) dailyRPCRequests() float64 {
+	duration := min(o.LoadProfile.GetTestDuration(), RPC_BUDGET_WINDOW)
+	messages := math.Ceil(o.LoadProfile.GetMessagesPerSecond() * duration.Seconds())
+	asserted := messages
+	if a := o.AssertionSampling; a.GetMode() != ASSERTION_SAMPLING_ALL {
+		asserted = min(messages, max(math.Ceil(messages*pointer.GetFloat64(a.SampleRatePct)/100), float64(a.GetMinSampled())))
+	}
+	return messages*RPC_CALLS_PER_SEND + asserted*RPC_CALLS_PER_ASSERTION
+}
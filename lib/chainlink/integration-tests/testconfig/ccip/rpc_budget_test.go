@@ -0,0 +1,183 @@
+package ccip
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is the clock of RPCBudgets in tests, sleeping advances it.
+type fakeClock struct {
+	now    time.Time
+	sleeps []time.Time
+}
+
+func (c *fakeClock) install(b *RPCBudgets) {
+	b.now = func() time.Time { return c.now }
+	b.sleep = func(_ context.Context, at time.Time) error {
+		c.sleeps = append(c.sleeps, at)
+		c.now = at
+		return nil
+	}
+}
+
+func rpcBudgets(t *testing.T, content string) (*RPCBudgets, *fakeClock) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(content+"\n"+orderingNetworks), &cfg))
+	require.NoError(t, cfg.validateRPCBudget())
+	budgets := cfg.NewRPCBudgets()
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	clock.install(budgets)
+	return budgets, clock
+}
+
+func TestRPCBudgetThrottle(t *testing.T) {
+	budgets, clock := rpcBudgets(t, `
+[RPCBudget.SIMULATED_1]
+RequestsPerSecond = 2
+DailyRequestCap = 5
+`)
+	require.Nil(t, budgets.Limiter(12922642891491394802))
+	limiter := budgets.Limiter(3379446385462418246)
+	urls := []string{"http://a"}
+	start := clock.now
+	for i := 0; i < 4; i++ {
+		endpoint, err := limiter.Acquire(context.Background(), urls)
+		require.NoError(t, err)
+		require.Equal(t, "http://a", endpoint)
+	}
+	// a burst of 2, then one request every 500ms
+	require.Equal(t, []time.Time{start.Add(500 * time.Millisecond), start.Add(time.Second)}, clock.sleeps)
+
+	// the fifth request is the last of the day
+	_, err := limiter.Acquire(context.Background(), urls)
+	require.NoError(t, err)
+	_, err = limiter.Acquire(context.Background(), urls)
+	require.NoError(t, err)
+	require.Equal(t, start.Add(RPC_BUDGET_WINDOW), clock.now)
+	require.Equal(t, []RPCUsage{{Chain: "geth-testnet", Requests: 6, Throttled: 4}}, budgets.Usage())
+}
+
+func TestRPCBudgetFailTest(t *testing.T) {
+	budgets, _ := rpcBudgets(t, `
+[RPCBudget.SIMULATED_1]
+RequestsPerSecond = 1
+OnExceeded = "failTest"
+`)
+	limiter := budgets.Limiter(3379446385462418246)
+	_, err := limiter.Acquire(context.Background(), []string{"http://a"})
+	require.NoError(t, err)
+	_, err = limiter.Acquire(context.Background(), []string{"http://a"})
+	require.ErrorIs(t, err, ErrRPCBudgetExceeded)
+	require.Equal(t, RPCUsage{Chain: "geth-testnet", Requests: 1, Rejected: 1}, limiter.Usage())
+}
+
+func TestRPCBudgetSwitchEndpoint(t *testing.T) {
+	var hits []string
+	servers := make([]*httptest.Server, 2)
+	urls := make([]string, 2)
+	for i, name := range []string{"primary", "failover"} {
+		name := name
+		servers[i] = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits = append(hits, name)
+		}))
+		defer servers[i].Close()
+		urls[i] = servers[i].URL
+	}
+	budgets, _ := rpcBudgets(t, `
+[RPCBudget.16015286601757825753]
+DailyRequestCap = 2
+OnExceeded = "switchEndpoint"
+`)
+	client := &http.Client{Transport: budgets.RoundTripper(16015286601757825753, urls, nil)}
+	for i := 0; i < 4; i++ {
+		resp, err := client.Post(urls[0], "application/json", strings.NewReader(`{}`))
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+	require.Equal(t, []string{"primary", "primary", "failover", "failover"}, hits)
+	_, err := client.Post(urls[0], "application/json", strings.NewReader(`{}`))
+	require.ErrorIs(t, err, ErrRPCBudgetExceeded)
+
+	usage := budgets.Usage()
+	require.Len(t, usage, 1)
+	require.Equal(t, RPCUsage{Chain: usage[0].Chain, Requests: 4, Rejected: 1, EndpointSwitches: 1}, usage[0])
+
+	var metrics strings.Builder
+	require.NoError(t, budgets.WriteMetrics(&metrics))
+	require.Contains(t, metrics.String(), "# TYPE ccip_e2e_rpc_requests_total counter\nccip_e2e_rpc_requests_total{chain=\""+usage[0].Chain+"\"} 4\n")
+
+	reporter := NewReporter(nil)
+	reporter.RecordRPCUsage(usage...)
+	require.Equal(t, usage, reporter.Report().RPCUsage)
+}
+
+func TestRPCBudgetPushMetrics(t *testing.T) {
+	var path, body string
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPut, r.Method)
+		path = r.URL.Path
+		content, _ := io.ReadAll(r.Body)
+		body = string(content)
+	}))
+	defer gateway.Close()
+	budgets, _ := rpcBudgets(t, `
+RunID = "run-1"
+
+[Observability]
+PrometheusPushgateway = "`+gateway.URL+`"
+
+[RPCBudget.SIMULATED_1]
+RequestsPerSecond = 10
+`)
+	_, err := budgets.Limiter(3379446385462418246).Acquire(context.Background(), []string{"ws://a"})
+	require.NoError(t, err)
+	require.NoError(t, budgets.PushMetrics(context.Background(), nil))
+	require.Equal(t, "/metrics/job/ccip-e2e-rpc/run_id/run-1", path)
+	require.Contains(t, body, `ccip_e2e_rpc_requests_total{chain="geth-testnet"} 1`)
+}
+
+func TestValidateRPCBudget(t *testing.T) {
+	const load = `
+[LoadProfile]
+MessagesPerSecond = 1
+TestDuration = "1h"
+`
+	for _, tc := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "within budget", content: "[RPCBudget.SIMULATED_1]\nRequestsPerSecond = 4\nDailyRequestCap = 21600\n" + load},
+		{name: "unknown chain", content: "[RPCBudget.nope]\nRequestsPerSecond = 1", err: "RPCBudget.nope: "},
+		{name: "unknown mode", content: "[RPCBudget.SIMULATED_1]\nOnExceeded = \"drop\"", err: `RPCBudget.SIMULATED_1.OnExceeded: unknown value "drop"`},
+		{name: "switch on private network", content: "[RPCBudget.SIMULATED_1]\nOnExceeded = \"switchEndpoint\"", err: "SIMULATED_1 is a private network with a single endpoint"},
+		{name: "zero rate", content: "[RPCBudget.SIMULATED_1]\nRequestsPerSecond = 0", err: "RPCBudget.SIMULATED_1.RequestsPerSecond must be above 0, got 0"},
+		{name: "rate below load", content: "[RPCBudget.SIMULATED_1]\nRequestsPerSecond = 3\n" + load, err: "RPCBudget.SIMULATED_1.RequestsPerSecond (3) is below the 4 requests per second LoadProfile sends at 1 messages per second"},
+		{name: "cap below load", content: "[RPCBudget.SIMULATED_1]\nDailyRequestCap = 21599\n" + load, err: "RPCBudget.SIMULATED_1.DailyRequestCap (21599) is below the 21600 requests LoadProfile and AssertionSampling make in a day"},
+		{name: "sampling fits the cap", content: "[RPCBudget.SIMULATED_1]\nDailyRequestCap = 15120\n" + load + `
+[AssertionSampling]
+Mode = "sample"
+SampleRatePct = 10
+`},
+		{name: "switching isn't checked against the load", content: "[RPCBudget.16015286601757825753]\nRequestsPerSecond = 1\nOnExceeded = \"switchEndpoint\"\n" + load},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte(tc.content+"\n"+orderingNetworks), &cfg))
+			err := cfg.validateRPCBudget()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package ccip
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ChainClient is the client of a chain the client factory builds, it has the methods of the deployment
+// OnchainClient.
+type ChainClient interface {
+	bind.ContractBackend
+	bind.DeployBackend
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+}
+
+// DialChainClient is the client factory of the chain clients built from this config. urls are the
+// endpoints of a transport as returned by Config.ResolveTransport. The requests of the client are within
+// the RPCBudget of the chain: HTTP clients send them through RoundTripper, WS clients acquire each of
+// them from the Limiter of the chain and redial when it switches endpoints.
+func (b *RPCBudgets) DialChainClient(ctx context.Context, selector uint64, urls []string) (ChainClient, error) {
+	if len(urls) == 0 {
+		return nil, withKind(ErrInvalidEndpoint, fmt.Errorf("chain %s: no RPC endpoints to dial", chainName(selector)))
+	}
+	limiter := b.Limiter(selector)
+	switch {
+	case limiter == nil:
+		return ethclient.DialContext(ctx, urls[0])
+	case strings.HasPrefix(urls[0], "http"):
+		client, err := rpc.DialOptions(ctx, urls[0], rpc.WithHTTPClient(&http.Client{Transport: b.RoundTripper(selector, urls, nil)}))
+		if err != nil {
+			return nil, err
+		}
+		return ethclient.NewClient(client), nil
+	}
+	client := &budgetedWSClient{limiter: limiter, urls: urls, dial: ethclient.DialContext, clients: make(map[string]*ethclient.Client)}
+	// dial the first endpoint right away, so a wrong URL fails here rather than at the first request
+	if _, err := client.dialed(ctx, urls[0]); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// budgetedWSClient sends every request to the endpoint the Limiter of the chain acquired it for,
+// dialing each endpoint once.
+type budgetedWSClient struct {
+	limiter *RPCLimiter
+	urls    []string
+	dial    func(ctx context.Context, url string) (*ethclient.Client, error)
+
+	mu      sync.Mutex
+	clients map[string]*ethclient.Client
+}
+
+func (c *budgetedWSClient) client(ctx context.Context) (*ethclient.Client, error) {
+	endpoint, err := c.limiter.Acquire(ctx, c.urls)
+	if err != nil {
+		return nil, err
+	}
+	return c.dialed(ctx, endpoint)
+}
+
+func (c *budgetedWSClient) dialed(ctx context.Context, endpoint string) (*ethclient.Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if client, ok := c.clients[endpoint]; ok {
+		return client, nil
+	}
+	client, err := c.dial(ctx, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("chain %s: dial %s: %w", c.limiter.usage.Chain, endpoint, err)
+	}
+	c.clients[endpoint] = client
+	return client, nil
+}
+
+func (c *budgetedWSClient) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.CodeAt(ctx, contract, blockNumber)
+}
+
+func (c *budgetedWSClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.CallContract(ctx, call, blockNumber)
+}
+
+func (c *budgetedWSClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.HeaderByNumber(ctx, number)
+}
+
+func (c *budgetedWSClient) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.PendingCodeAt(ctx, account)
+}
+
+func (c *budgetedWSClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return client.PendingNonceAt(ctx, account)
+}
+
+func (c *budgetedWSClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.SuggestGasPrice(ctx)
+}
+
+func (c *budgetedWSClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.SuggestGasTipCap(ctx)
+}
+
+func (c *budgetedWSClient) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return client.EstimateGas(ctx, call)
+}
+
+func (c *budgetedWSClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	client, err := c.client(ctx)
+	if err != nil {
+		return err
+	}
+	return client.SendTransaction(ctx, tx)
+}
+
+func (c *budgetedWSClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.FilterLogs(ctx, q)
+}
+
+// SubscribeFilterLogs counts as a single request, the logs are pushed over the subscription.
+func (c *budgetedWSClient) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.SubscribeFilterLogs(ctx, q, ch)
+}
+
+func (c *budgetedWSClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.TransactionReceipt(ctx, txHash)
+}
+
+func (c *budgetedWSClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.BalanceAt(ctx, account, blockNumber)
+}
+
+func (c *budgetedWSClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return client.NonceAt(ctx, account, blockNumber)
+}
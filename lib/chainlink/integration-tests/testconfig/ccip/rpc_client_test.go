@@ -0,0 +1,89 @@
+package ccip
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/stretchr/testify/require"
+)
+
+// nonceServer is a JSON-RPC endpoint answering every request with a nonce of 5, counting the requests.
+func nonceServer(t *testing.T, name string, hits *[]string) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		*hits = append(*hits, name)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":` + string(req.ID) + `,"result":"0x5"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestDialChainClientWithoutBudget(t *testing.T) {
+	var hits []string
+	url := nonceServer(t, "primary", &hits)
+	var budgets *RPCBudgets
+	client, err := budgets.DialChainClient(context.Background(), 3379446385462418246, []string{url})
+	require.NoError(t, err)
+	nonce, err := client.NonceAt(context.Background(), common.Address{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), nonce)
+
+	_, err = budgets.DialChainClient(context.Background(), 3379446385462418246, nil)
+	require.ErrorIs(t, err, ErrInvalidEndpoint)
+}
+
+func TestDialChainClientHTTPWithinBudget(t *testing.T) {
+	var hits []string
+	url := nonceServer(t, "primary", &hits)
+	budgets, _ := rpcBudgets(t, `
+[RPCBudget.SIMULATED_1]
+DailyRequestCap = 1
+OnExceeded = "failTest"
+`)
+	client, err := budgets.DialChainClient(context.Background(), 3379446385462418246, []string{url})
+	require.NoError(t, err)
+	_, err = client.NonceAt(context.Background(), common.Address{}, nil)
+	require.NoError(t, err)
+	_, err = client.NonceAt(context.Background(), common.Address{}, nil)
+	require.ErrorIs(t, err, ErrRPCBudgetExceeded)
+	require.Equal(t, []string{"primary"}, hits)
+	require.Equal(t, []RPCUsage{{Chain: "geth-testnet", Requests: 1, Rejected: 1}}, budgets.Usage())
+}
+
+func TestBudgetedWSClientRedialsOnSwitch(t *testing.T) {
+	var hits []string
+	urls := []string{nonceServer(t, "primary", &hits), nonceServer(t, "failover", &hits)}
+	budgets, _ := rpcBudgets(t, `
+[RPCBudget.16015286601757825753]
+DailyRequestCap = 1
+OnExceeded = "switchEndpoint"
+`)
+	var dialed []string
+	client := &budgetedWSClient{
+		limiter: budgets.Limiter(16015286601757825753),
+		urls:    urls,
+		dial: func(ctx context.Context, url string) (*ethclient.Client, error) {
+			dialed = append(dialed, url)
+			return ethclient.DialContext(ctx, url)
+		},
+		clients: make(map[string]*ethclient.Client),
+	}
+	for i := 0; i < 2; i++ {
+		nonce, err := client.PendingNonceAt(context.Background(), common.Address{})
+		require.NoError(t, err)
+		require.Equal(t, uint64(5), nonce)
+	}
+	_, err := client.PendingNonceAt(context.Background(), common.Address{})
+	require.ErrorIs(t, err, ErrRPCBudgetExceeded)
+	require.Equal(t, []string{"primary", "failover"}, hits)
+	require.Equal(t, urls, dialed)
+}
@@ -0,0 +1,140 @@
+package ccip
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	RUNTIME_DOCKER = "docker"
+	RUNTIME_K8S    = "k8s"
+
+	DEFAULT_RUNTIME = RUNTIME_DOCKER
+	DEFAULT_K8S_TTL = 12 * time.Hour
+)
+
+// K8sConfig holds the crib settings used when Runtime is k8s.
+type K8sConfig struct {
+	Namespace          *string           `toml:",omitempty"`
+	ChartOverridesFile *string           `toml:",omitempty"`
+	StorageClass       *string           `toml:",omitempty"`
+	NodeSelectorLabels map[string]string `toml:",omitempty"`
+	TTL                *Duration         `toml:",omitempty"`
+}
+
+// DockerConfig holds the settings used when Runtime is docker.
+type DockerConfig struct {
+	// ReuseNetwork is the name of an existing docker network to start the containers in
+	ReuseNetwork *string `toml:",omitempty"`
+	// NetworkName is the name of the docker network to create
+	NetworkName     *string `toml:",omitempty"`
+	ContainerPrefix *string `toml:",omitempty"`
+	// PortOffset is added to every host port exposed by the environment
+	PortOffset *int `toml:",omitempty"`
+}
+
+// RuntimeConfig is what environment bring-up switches on; it is either
+// a *DockerRuntimeConfig or a *K8sRuntimeConfig.
+type RuntimeConfig interface {
+	Runtime() string
+}
+
+type DockerRuntimeConfig struct {
+	ReuseNetwork    string
+	NetworkName     string
+	ContainerPrefix string
+	PortOffset      int
+}
+
+func (d *DockerRuntimeConfig) Runtime() string { return RUNTIME_DOCKER }
+
+type K8sRuntimeConfig struct {
+	Namespace          string
+	ChartOverridesFile string
+	StorageClass       string
+	NodeSelectorLabels map[string]string
+	TTL                time.Duration
+}
+
+func (k *K8sRuntimeConfig) Runtime() string { return RUNTIME_K8S }
+
+func (o *Config) GetRuntime() string {
+	if pointer.GetString(o.Runtime) == "" {
+		return DEFAULT_RUNTIME
+	}
+	return *o.Runtime
+}
+
+// GetRuntimeConfig returns the settings of the selected runtime.
+func (o *Config) GetRuntimeConfig() RuntimeConfig {
+	if o.GetRuntime() == RUNTIME_K8S {
+		k8s := o.K8sConfig
+		if k8s == nil {
+			k8s = &K8sConfig{}
+		}
+		ttl := DEFAULT_K8S_TTL
+		if k8s.TTL != nil {
+			ttl = k8s.TTL.Duration
+		}
+		return &K8sRuntimeConfig{
+			Namespace:          pointer.GetString(k8s.Namespace),
+			ChartOverridesFile: pointer.GetString(k8s.ChartOverridesFile),
+			StorageClass:       pointer.GetString(k8s.StorageClass),
+			NodeSelectorLabels: k8s.NodeSelectorLabels,
+			TTL:                ttl,
+		}
+	}
+	docker := &DockerRuntimeConfig{}
+	if o.DockerConfig != nil {
+		docker.ReuseNetwork = pointer.GetString(o.DockerConfig.ReuseNetwork)
+		docker.NetworkName = pointer.GetString(o.DockerConfig.NetworkName)
+		docker.ContainerPrefix = pointer.GetString(o.DockerConfig.ContainerPrefix)
+		docker.PortOffset = pointer.GetInt(o.DockerConfig.PortOffset)
+	}
+	return docker
+}
+
+// HostPort returns the host port a port claimed in the PortAllocator is exposed on, shifted by
+// DockerConfig.PortOffset so environments started side by side don't collide.
+func (o *Config) HostPort(port int) int {
+	if docker, ok := o.GetRuntimeConfig().(*DockerRuntimeConfig); ok {
+		return port + docker.PortOffset
+	}
+	return port
+}
+
+func (o *Config) validateRuntime() error {
+	switch o.GetRuntime() {
+	case RUNTIME_DOCKER:
+		if o.K8sConfig != nil {
+			return fmt.Errorf("K8sConfig cannot be set when Runtime is %s", RUNTIME_DOCKER)
+		}
+		if o.DockerConfig != nil && o.DockerConfig.ReuseNetwork != nil && o.DockerConfig.NetworkName != nil {
+			return fmt.Errorf("DockerConfig.ReuseNetwork and DockerConfig.NetworkName are mutually exclusive")
+		}
+		if o.DockerConfig != nil && pointer.GetInt(o.DockerConfig.PortOffset) < 0 {
+			return fmt.Errorf("DockerConfig.PortOffset cannot be negative")
+		}
+	case RUNTIME_K8S:
+		if o.DockerConfig != nil {
+			return fmt.Errorf("DockerConfig cannot be set when Runtime is %s", RUNTIME_K8S)
+		}
+		if o.K8sConfig == nil || pointer.GetString(o.K8sConfig.Namespace) == "" {
+			return fmt.Errorf("K8sConfig.Namespace must be set when Runtime is %s", RUNTIME_K8S)
+		}
+		if file := pointer.GetString(o.K8sConfig.ChartOverridesFile); file != "" {
+			if _, err := os.Stat(file); err != nil {
+				return fmt.Errorf("K8sConfig.ChartOverridesFile: %w", err)
+			}
+		}
+		if o.K8sConfig.TTL != nil && o.K8sConfig.TTL.Duration <= 0 {
+			return fmt.Errorf("K8sConfig.TTL must be positive")
+		}
+	default:
+		return fmt.Errorf("Runtime must be one of %s or %s, got %q", RUNTIME_DOCKER, RUNTIME_K8S, o.GetRuntime())
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package ccip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRuntimeConfig(t *testing.T) {
+	var cfg Config
+	require.Equal(t, DEFAULT_RUNTIME, cfg.GetRuntime())
+	require.Equal(t, &DockerRuntimeConfig{}, cfg.GetRuntimeConfig())
+	require.Equal(t, 8545, cfg.HostPort(8545))
+
+	require.NoError(t, toml.Unmarshal([]byte("[DockerConfig]\nNetworkName = 'ccip'\nContainerPrefix = 'lane'\nPortOffset = 100"), &cfg))
+	require.Equal(t, &DockerRuntimeConfig{NetworkName: "ccip", ContainerPrefix: "lane", PortOffset: 100}, cfg.GetRuntimeConfig())
+	require.Equal(t, 8645, cfg.HostPort(8545))
+
+	cfg = Config{}
+	require.NoError(t, toml.Unmarshal([]byte("Runtime = 'k8s'"), &cfg))
+	require.Equal(t, &K8sRuntimeConfig{TTL: DEFAULT_K8S_TTL}, cfg.GetRuntimeConfig())
+	require.NoError(t, toml.Unmarshal([]byte("[K8sConfig]\nNamespace = 'ccip'\nTTL = '1h'\n[K8sConfig.NodeSelectorLabels]\npool = 'ccip'"), &cfg))
+	require.Equal(t, &K8sRuntimeConfig{Namespace: "ccip", NodeSelectorLabels: map[string]string{"pool": "ccip"}, TTL: time.Hour}, cfg.GetRuntimeConfig())
+	require.Equal(t, 8545, cfg.HostPort(8545))
+}
+
+func TestValidateRuntime(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "default"},
+		{name: "docker", content: "Runtime = 'docker'\n[DockerConfig]\nReuseNetwork = 'ccip'\nPortOffset = 0"},
+		{name: "k8s", content: "Runtime = 'k8s'\n[K8sConfig]\nNamespace = 'ccip'\nChartOverridesFile = 'runtime.go'\nTTL = '1h'"},
+		{name: "unknown runtime", content: "Runtime = 'nomad'", err: `Runtime must be one of docker or k8s, got "nomad"`},
+		{name: "k8s config with docker", content: "[K8sConfig]\nNamespace = 'ccip'", err: "K8sConfig cannot be set when Runtime is docker"},
+		{name: "reused and created network", content: "[DockerConfig]\nReuseNetwork = 'ccip'\nNetworkName = 'ccip'", err: "DockerConfig.ReuseNetwork and DockerConfig.NetworkName are mutually exclusive"},
+		{name: "negative port offset", content: "[DockerConfig]\nPortOffset = -1", err: "DockerConfig.PortOffset cannot be negative"},
+		{name: "docker config with k8s", content: "Runtime = 'k8s'\n[DockerConfig]\nPortOffset = 1\n[K8sConfig]\nNamespace = 'ccip'", err: "DockerConfig cannot be set when Runtime is k8s"},
+		{name: "k8s without config", content: "Runtime = 'k8s'", err: "K8sConfig.Namespace must be set when Runtime is k8s"},
+		{name: "k8s without namespace", content: "Runtime = 'k8s'\n[K8sConfig]\nStorageClass = 'gp3'", err: "K8sConfig.Namespace must be set when Runtime is k8s"},
+		{name: "missing chart overrides", content: "Runtime = 'k8s'\n[K8sConfig]\nNamespace = 'ccip'\nChartOverridesFile = 'missing.yaml'", err: "K8sConfig.ChartOverridesFile: stat missing.yaml: no such file or directory"},
+		{name: "zero ttl", content: "Runtime = 'k8s'\n[K8sConfig]\nNamespace = 'ccip'\nTTL = '0s'", err: "K8sConfig.TTL must be positive"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte(tc.content), &cfg))
+			err := cfg.validateRuntime()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
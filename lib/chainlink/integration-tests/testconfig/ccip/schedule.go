@@ -0,0 +1,217 @@
+package ccip
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// SCHEDULE_ACTION_CHAOS is the ChainHalt
+	SCHEDULE_ACTION_CHAOS = "chaos"
+	// SCHEDULE_ACTION_GAS_SPIKES is the PriceManipulation of the gas prices
+	SCHEDULE_ACTION_GAS_SPIKES = "gasSpikes"
+
+	// SCHEDULE_TIME_LAYOUT is the layout of Window.Start and Window.End
+	SCHEDULE_TIME_LAYOUT = "15:04"
+)
+
+var scheduleActions = []string{SCHEDULE_ACTION_CHAOS, SCHEDULE_ACTION_GAS_SPIKES}
+
+// Schedule pauses disruptive actions of long-running tests during maintenance windows, so they don't
+// page anyone for expected noise.
+type Schedule struct {
+	PauseWindows []Window `toml:",omitempty"`
+	// ActionsAffected are the paused actions, "chaos" and "gasSpikes", all of them when empty
+	ActionsAffected []string `toml:",omitempty"`
+}
+
+// Window is a recurring period of time. Start and End are times of day like "22:00", a window ending
+// at or before its start runs past midnight into the next day.
+type Window struct {
+	// Days are the weekdays the window starts on, like "Sat" or "Saturday", every day when empty
+	Days  []string `toml:",omitempty"`
+	Start string   `toml:",omitempty"`
+	End   string   `toml:",omitempty"`
+	// Timezone is an IANA zone like "Europe/Berlin" the times are in, UTC when empty
+	Timezone string `toml:",omitempty"`
+}
+
+// IsPaused returns whether the action, one of the SCHEDULE_ACTION_* constants, must not run at the
+// given time. Drivers of scheduled actions check it before each action.
+func (s *Schedule) IsPaused(action string, at time.Time) bool {
+	if s == nil {
+		return false
+	}
+	if len(s.ActionsAffected) > 0 && !containsString(s.ActionsAffected, action) {
+		return false
+	}
+	for _, window := range s.parseWindows() {
+		if window.contains(at) {
+			return true
+		}
+	}
+	return false
+}
+
+// waitUnpaused blocks while the action is paused, checking again at every minute as windows are set to
+// the minute. It returns how long it waited.
+func (s *Schedule) waitUnpaused(ctx context.Context, action string) (time.Duration, error) {
+	start := time.Now()
+	for s.IsPaused(action, time.Now()) {
+		if err := sleepUntil(ctx, time.Now().Truncate(time.Minute).Add(time.Minute)); err != nil {
+			return time.Since(start), err
+		}
+	}
+	return time.Since(start), nil
+}
+
+// scheduleWindow is a Window with its times parsed.
+type scheduleWindow struct {
+	loc   *time.Location
+	start time.Time
+	end   time.Time
+	days  map[time.Weekday]bool
+}
+
+func (w Window) parse() (scheduleWindow, error) {
+	var parsed scheduleWindow
+	var err error
+	if parsed.loc, err = time.LoadLocation(w.Timezone); err != nil {
+		return scheduleWindow{}, err
+	}
+	if parsed.start, err = time.Parse(SCHEDULE_TIME_LAYOUT, w.Start); err != nil {
+		return scheduleWindow{}, err
+	}
+	if parsed.end, err = time.Parse(SCHEDULE_TIME_LAYOUT, w.End); err != nil {
+		return scheduleWindow{}, err
+	}
+	if parsed.days, err = parseWeekdays(w.Days); err != nil {
+		return scheduleWindow{}, err
+	}
+	return parsed, nil
+}
+
+// parseWindows returns the valid windows, validation reports the others.
+func (s *Schedule) parseWindows() []scheduleWindow {
+	windows := make([]scheduleWindow, 0, len(s.PauseWindows))
+	for _, window := range s.PauseWindows {
+		if parsed, err := window.parse(); err == nil {
+			windows = append(windows, parsed)
+		}
+	}
+	return windows
+}
+
+func (w scheduleWindow) contains(at time.Time) bool {
+	local := at.In(w.loc)
+	// the window containing the time starts on the same day or, crossing midnight, on the day before
+	for _, offset := range []int{0, -1} {
+		day := time.Date(local.Year(), local.Month(), local.Day()+offset, 0, 0, 0, 0, w.loc)
+		if len(w.days) > 0 && !w.days[day.Weekday()] {
+			continue
+		}
+		from := time.Date(day.Year(), day.Month(), day.Day(), w.start.Hour(), w.start.Minute(), 0, 0, w.loc)
+		to := time.Date(day.Year(), day.Month(), day.Day(), w.end.Hour(), w.end.Minute(), 0, 0, w.loc)
+		if !to.After(from) {
+			to = time.Date(day.Year(), day.Month(), day.Day()+1, w.end.Hour(), w.end.Minute(), 0, 0, w.loc)
+		}
+		if !local.Before(from) && local.Before(to) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseWeekdays(names []string) (map[time.Weekday]bool, error) {
+	days := make(map[time.Weekday]bool, len(names))
+	for _, name := range names {
+		found := false
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			if strings.EqualFold(name, day.String()) || strings.EqualFold(name, day.String()[:3]) {
+				days[day], found = true, true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown weekday %q", name)
+		}
+	}
+	return days, nil
+}
+
+func (o *Config) validateSchedule() error {
+	s := o.Schedule
+	if s == nil {
+		return nil
+	}
+	for _, action := range s.ActionsAffected {
+		if !containsString(scheduleActions, action) {
+			return fmt.Errorf("Schedule.ActionsAffected: %q is not one of %s", action, strings.Join(scheduleActions, ", "))
+		}
+	}
+	for i, window := range s.PauseWindows {
+		field := fmt.Sprintf("Schedule.PauseWindows.%d", i)
+		if _, err := time.LoadLocation(window.Timezone); err != nil {
+			return fieldError(field+".Timezone", err)
+		}
+		for _, t := range []struct {
+			name  string
+			value string
+		}{{"Start", window.Start}, {"End", window.End}} {
+			if _, err := time.Parse(SCHEDULE_TIME_LAYOUT, t.value); err != nil {
+				return fmt.Errorf("%s.%s %q is not a time of day like \"22:30\"", field, t.name, t.value)
+			}
+		}
+		if _, err := parseWeekdays(window.Days); err != nil {
+			return fieldError(field+".Days", err)
+		}
+	}
+	if len(s.PauseWindows) == 0 {
+		return nil
+	}
+	duration := o.LoadProfile.GetTestDuration()
+	if duration == 0 {
+		duration = o.Timeouts.GetOverallTestTimeout()
+	}
+	if longest := s.longestPause(); longest >= duration {
+		return fmt.Errorf("Schedule.PauseWindows pause actions for up to %s at a stretch, a test of %s starting then would run without them", longest, duration)
+	}
+	return nil
+}
+
+// longestPause returns the longest stretch of paused time over a week, to the minute.
+func (s *Schedule) longestPause() time.Duration {
+	const week = 7 * 24 * time.Hour
+	windows := s.parseWindows()
+	// any week will do, DST changes in it only move windows by an hour
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	paused := make([]bool, 0, week/time.Minute)
+	for at := from; at.Before(from.Add(week)); at = at.Add(time.Minute) {
+		inWindow := false
+		for _, window := range windows {
+			if window.contains(at) {
+				inWindow = true
+				break
+			}
+		}
+		paused = append(paused, inWindow)
+	}
+	longest, current := 0, 0
+	// twice around the week for stretches wrapping its end
+	for i := 0; i < 2*len(paused); i++ {
+		if !paused[i%len(paused)] {
+			current = 0
+			continue
+		}
+		current++
+		if current > longest {
+			longest = current
+		}
+	}
+	if longest > len(paused) {
+		longest = len(paused)
+	}
+	return time.Duration(longest) * time.Minute
+}
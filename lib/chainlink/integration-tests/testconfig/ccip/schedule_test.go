@@ -0,0 +1,82 @@
+package ccip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPaused(t *testing.T) {
+	var nilSchedule *Schedule
+	require.False(t, nilSchedule.IsPaused(SCHEDULE_ACTION_CHAOS, time.Now()))
+
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(`
+[LoadProfile]
+TestDuration = '72h'
+
+[Schedule]
+ActionsAffected = ['chaos']
+
+[[Schedule.PauseWindows]]
+Days = ['Sat']
+Start = '22:00'
+End = '06:00'
+Timezone = 'Europe/Berlin'
+`), &cfg))
+	require.NoError(t, cfg.validateSchedule())
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		at     time.Time
+		paused bool
+	}{
+		{time.Date(2024, time.January, 6, 21, 59, 0, 0, berlin), false},
+		{time.Date(2024, time.January, 6, 22, 0, 0, 0, berlin), true},
+		// past midnight, into Sunday
+		{time.Date(2024, time.January, 7, 5, 59, 0, 0, berlin), true},
+		{time.Date(2024, time.January, 7, 6, 0, 0, 0, berlin), false},
+		// the window starts on Saturdays only
+		{time.Date(2024, time.January, 5, 23, 0, 0, 0, berlin), false},
+		{time.Date(2024, time.January, 8, 1, 0, 0, 0, berlin), false},
+		// 22:30 in Berlin
+		{time.Date(2024, time.January, 6, 21, 30, 0, 0, time.UTC), true},
+		// 22:30 in Berlin during summer time
+		{time.Date(2024, time.July, 6, 20, 30, 0, 0, time.UTC), true},
+	} {
+		require.Equal(t, tc.paused, cfg.Schedule.IsPaused(SCHEDULE_ACTION_CHAOS, tc.at), tc.at.String())
+	}
+	require.False(t, cfg.Schedule.IsPaused(SCHEDULE_ACTION_GAS_SPIKES, time.Date(2024, time.January, 6, 23, 0, 0, 0, berlin)))
+}
+
+func TestValidateSchedule(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		schedule string
+		err      string
+	}{
+		{"unknown action", "ActionsAffected = ['reboots']", `Schedule.ActionsAffected: "reboots" is not one of chaos, gasSpikes`},
+		{"malformed time", "[[Schedule.PauseWindows]]\nStart = '25:00'\nEnd = '06:00'", `Schedule.PauseWindows.0.Start "25:00" is not a time of day like "22:30"`},
+		{"unknown weekday", "[[Schedule.PauseWindows]]\nDays = ['Funday']\nStart = '22:00'\nEnd = '06:00'", `Schedule.PauseWindows.0.Days: unknown weekday "Funday"`},
+		{"unknown timezone", "[[Schedule.PauseWindows]]\nStart = '22:00'\nEnd = '06:00'\nTimezone = 'Mars/Olympus'", "Schedule.PauseWindows.0.Timezone"},
+		{
+			"covers the test",
+			"[[Schedule.PauseWindows]]\nDays = ['Sat']\nStart = '00:00'\nEnd = '00:00'\n[[Schedule.PauseWindows]]\nDays = ['Sun']\nStart = '00:00'\nEnd = '00:00'",
+			"Schedule.PauseWindows pause actions for up to 48h0m0s at a stretch, a test of 4h0m0s starting then would run without them",
+		},
+		{"always paused", "[[Schedule.PauseWindows]]\nStart = '00:00'\nEnd = '00:00'", "Schedule.PauseWindows pause actions for up to 168h0m0s at a stretch"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte("[LoadProfile]\nTestDuration = '4h'\n[Schedule]\n"+tc.schedule), &cfg))
+			require.ErrorContains(t, cfg.validateSchedule(), tc.err)
+		})
+	}
+
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte("[LoadProfile]\nTestDuration = '72h'\n[[Schedule.PauseWindows]]\nDays = ['Sat', 'Sun']\nStart = '00:00'\nEnd = '00:00'"), &cfg))
+	require.NoError(t, cfg.validateSchedule())
+}
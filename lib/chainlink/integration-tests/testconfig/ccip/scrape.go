@@ -0,0 +1,197 @@
+package ccip
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	SCRAPE_JOB_BOOTSTRAP       = "chainlink-bootstrap"
+	SCRAPE_JOB_NODE            = "chainlink-node"
+	SCRAPE_JOB_JOB_DISTRIBUTOR = "job-distributor"
+	SCRAPE_JOB_RMN             = "rmn"
+)
+
+// ScrapeTarget is a single Prometheus scrape target with its labels.
+type ScrapeTarget struct {
+	Job      string
+	Instance string
+	Labels   map[string]string
+}
+
+// fileSDEntry is the Prometheus file_sd_configs JSON format.
+type fileSDEntry struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels"`
+}
+
+// GetMetricsPort returns the port nodes expose /metrics on, the HTTP port unless overridden.
+func (n *NodeConfig) GetMetricsPort() int {
+	if n == nil || n.MetricsPort == nil {
+		return NODE_HTTP_PORT
+	}
+	return *n.MetricsPort
+}
+
+// RecordContainerNames records the names of the containers the environment started for a scrape job,
+// in index order. Node, JD and RMN container names have a random suffix, GenerateScrapeTargets names
+// their targets after the recorded ones.
+func (o *Config) RecordContainerNames(job string, names ...string) {
+	if o.containerNames == nil {
+		o.containerNames = make(map[string][]string)
+	}
+	o.containerNames[job] = append([]string(nil), names...)
+}
+
+// scrapeHosts returns the recorded container names of the job, one per container field configures.
+func (o *Config) scrapeHosts(job, field string, count int) ([]string, error) {
+	hosts := o.containerNames[job]
+	if len(hosts) != count {
+		return nil, fmt.Errorf("%s: %d %s containers were recorded, expected %d, call RecordContainerNames once they are started", field, len(hosts), job, count)
+	}
+	return hosts, nil
+}
+
+// GenerateScrapeTargets returns the scrape targets of all components enabled in the config.
+// Nodes are always scraped, JD and RMN only when their MetricsPort is set. Targets are named after
+// the containers recorded with RecordContainerNames.
+// Targets are sorted by job and instance so the output is deterministic.
+func (o *Config) GenerateScrapeTargets() ([]ScrapeTarget, error) {
+	chainLabels, err := o.scrapeChainLabels()
+	if err != nil {
+		return nil, err
+	}
+	runLabels := o.Labels()
+	for k, v := range runLabels {
+		chainLabels[k] = v
+	}
+	// metrics ports are taken from the PortAllocator, which already refused invalid and colliding ones
+	allocator, err := o.PortAllocator()
+	if err != nil {
+		return nil, err
+	}
+	var targets []ScrapeTarget
+	if o.CLNode != nil {
+		port, ok := allocator.Port(PORT_CLAIMANT_NODE_METRICS)
+		if !ok {
+			port = NODE_HTTP_PORT
+		}
+		bootstraps, err := o.scrapeHosts(SCRAPE_JOB_BOOTSTRAP, "CLNode.NoOfBootstraps", o.CLNode.GetNoOfBootstrapContainers())
+		if err != nil {
+			return nil, err
+		}
+		for i, host := range bootstraps {
+			targets = append(targets, newScrapeTarget(SCRAPE_JOB_BOOTSTRAP, host, i, port, chainLabels))
+		}
+		nodes, err := o.scrapeHosts(SCRAPE_JOB_NODE, "CLNode.NoOfPluginNodes", o.CLNode.GetNoOfPluginNodes())
+		if err != nil {
+			return nil, err
+		}
+		for i, host := range nodes {
+			targets = append(targets, newScrapeTarget(SCRAPE_JOB_NODE, host, i, port, chainLabels))
+		}
+	}
+	if port, ok := allocator.Port(PORT_CLAIMANT_JD_METRICS); ok {
+		hosts, err := o.scrapeHosts(SCRAPE_JOB_JOB_DISTRIBUTOR, "JobDistributorConfig.MetricsPort", 1)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, ScrapeTarget{
+			Job:      SCRAPE_JOB_JOB_DISTRIBUTOR,
+			Instance: fmt.Sprintf("%s:%d", hosts[0], port),
+			Labels:   runLabels,
+		})
+	}
+	if port, ok := allocator.Port(PORT_CLAIMANT_RMN_METRICS); ok {
+		hosts, err := o.scrapeHosts(SCRAPE_JOB_RMN, "RMNConfig.NoOfNodes", pointer.GetInt(o.RMNConfig.NoOfNodes))
+		if err != nil {
+			return nil, err
+		}
+		for i, host := range hosts {
+			targets = append(targets, newScrapeTarget(SCRAPE_JOB_RMN, host, i, port, chainLabels))
+		}
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].Job != targets[j].Job {
+			return targets[i].Job < targets[j].Job
+		}
+		return targets[i].Instance < targets[j].Instance
+	})
+	return targets, nil
+}
+
+func newScrapeTarget(job, host string, index, port int, chainLabels map[string]string) ScrapeTarget {
+	labels := map[string]string{"index": strconv.Itoa(index)}
+	for k, v := range chainLabels {
+		labels[k] = v
+	}
+	return ScrapeTarget{
+		Job:      job,
+		Instance: fmt.Sprintf("%s:%d", host, port),
+		Labels:   labels,
+	}
+}
+
+// scrapeChainLabels returns the labels describing the chains nodes and RMN are connected to.
+func (o *Config) scrapeChainLabels() (map[string]string, error) {
+	labels := make(map[string]string)
+	var selectors []uint64
+	for name := range o.PrivateEthereumNetworks {
+		selector, err := o.ResolveChainSelector(name)
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, selector)
+	}
+	if len(selectors) > 0 {
+		sort.Slice(selectors, func(i, j int) bool { return selectors[i] < selectors[j] })
+		formatted := make([]string, 0, len(selectors))
+		for _, selector := range selectors {
+			formatted = append(formatted, strconv.FormatUint(selector, 10))
+		}
+		labels["chain_selectors"] = strings.Join(formatted, ",")
+	}
+	if home := pointer.GetString(o.HomeChainSelector); home != "" {
+		labels["home_chain_selector"] = home
+	}
+	return labels, nil
+}
+
+// WritePrometheusFileSD writes the scrape targets to path in the Prometheus file_sd JSON format.
+func (o *Config) WritePrometheusFileSD(path string) error {
+	targets, err := o.GenerateScrapeTargets()
+	if err != nil {
+		return err
+	}
+	content, err := marshalFileSD(targets)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0o600)
+}
+
+func marshalFileSD(targets []ScrapeTarget) ([]byte, error) {
+	entries := make([]fileSDEntry, 0, len(targets))
+	for _, target := range targets {
+		labels := map[string]string{"job": target.Job}
+		for k, v := range target.Labels {
+			labels[k] = v
+		}
+		entries = append(entries, fileSDEntry{
+			Targets: []string{target.Instance},
+			Labels:  labels,
+		})
+	}
+	// encoding/json sorts map keys, so the output only depends on the target order
+	content, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(content, '\n'), nil
+}
@@ -0,0 +1,75 @@
+package ccip
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+
+	ctfconfig "github.com/smartcontractkit/chainlink-testing-framework/lib/config"
+)
+
+func TestWritePrometheusFileSD(t *testing.T) {
+	cfg := &Config{
+		PrivateEthereumNetworks: map[string]*ctfconfig.EthereumNetworkConfig{
+			"SIMULATED_2": {EthereumChainConfig: &ctfconfig.EthereumChainConfig{ChainID: 2337}},
+			"SIMULATED_1": {EthereumChainConfig: &ctfconfig.EthereumChainConfig{ChainID: 1337}},
+		},
+		HomeChainSelector: pointer.ToString("3379446385462418246"),
+		RunID:             pointer.ToString("20240101-000000-cafe"),
+		Metadata:          map[string]string{"team": "ccip"},
+		CLNode: &NodeConfig{
+			NoOfPluginNodes: pointer.ToInt(2),
+			NoOfBootstraps:  pointer.ToInt(1),
+		},
+		JobDistributorConfig: JDConfig{MetricsPort: pointer.ToInt(8080)},
+		RMNConfig:            RMNConfig{NoOfNodes: pointer.ToInt(1), MetricsPort: pointer.ToInt(9090)},
+	}
+	// the names test_env and devenv give the containers
+	cfg.RecordContainerNames(SCRAPE_JOB_BOOTSTRAP, "cl-node-1f2e3d4c")
+	cfg.RecordContainerNames(SCRAPE_JOB_NODE, "cl-node-5a6b7c8d", "cl-node-9e0f1a2b")
+	cfg.RecordContainerNames(SCRAPE_JOB_RMN, "rmn_0-3c4d5e6f")
+	cfg.RecordContainerNames(SCRAPE_JOB_JOB_DISTRIBUTOR, "job-distributor-7a8b9c0d")
+	path := filepath.Join(t.TempDir(), "targets.json")
+	require.NoError(t, cfg.WritePrometheusFileSD(path))
+
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	// the fingerprint changes with every new config field, keep it out of the golden file
+	fingerprint, err := cfg.Fingerprint()
+	require.NoError(t, err)
+	got = bytes.ReplaceAll(got, []byte(fingerprint[:FINGERPRINT_LABEL_LENGTH]), []byte("<fingerprint>"))
+	want, err := os.ReadFile(filepath.Join("testdata", "scrape_targets.golden.json"))
+	require.NoError(t, err)
+	require.Equal(t, string(want), string(got))
+}
+
+func TestGenerateScrapeTargetsOnlyEnabledComponents(t *testing.T) {
+	cfg := &Config{
+		CLNode:    &NodeConfig{NoOfPluginNodes: pointer.ToInt(1)},
+		RMNConfig: RMNConfig{NoOfNodes: pointer.ToInt(2)},
+	}
+	cfg.RecordContainerNames(SCRAPE_JOB_NODE, "cl-node-5a6b7c8d")
+	targets, err := cfg.GenerateScrapeTargets()
+	require.NoError(t, err)
+	require.Len(t, targets, 1)
+	require.Equal(t, SCRAPE_JOB_NODE, targets[0].Job)
+	require.Equal(t, "cl-node-5a6b7c8d:6688", targets[0].Instance)
+}
+
+func TestGenerateScrapeTargetsNeedsContainerNames(t *testing.T) {
+	cfg := &Config{
+		CLNode:    &NodeConfig{NoOfPluginNodes: pointer.ToInt(1)},
+		RMNConfig: RMNConfig{NoOfNodes: pointer.ToInt(2), MetricsPort: pointer.ToInt(9090)},
+	}
+	_, err := cfg.GenerateScrapeTargets()
+	require.EqualError(t, err, "CLNode.NoOfPluginNodes: 0 chainlink-node containers were recorded, expected 1, call RecordContainerNames once they are started")
+
+	cfg.RecordContainerNames(SCRAPE_JOB_NODE, "cl-node-5a6b7c8d")
+	cfg.RecordContainerNames(SCRAPE_JOB_RMN, "rmn_0-3c4d5e6f")
+	_, err = cfg.GenerateScrapeTargets()
+	require.EqualError(t, err, "RMNConfig.NoOfNodes: 1 rmn containers were recorded, expected 2, call RecordContainerNames once they are started")
+}
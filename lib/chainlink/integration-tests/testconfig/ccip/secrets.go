@@ -0,0 +1,116 @@
+package ccip
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	SECRET_SCHEME_VAULT = "vault"
+	SECRET_SCHEME_AWSSM = "awssm"
+)
+
+var ErrUnknownSecretScheme = fmt.Errorf("no secret provider registered for scheme")
+
+// SecretProvider resolves secret references such as "vault://path#key" or "awssm://name".
+type SecretProvider interface {
+	Resolve(ref string) (string, error)
+}
+
+// SecretProviderRegistry is a SecretProvider dispatching references to the provider registered for their scheme.
+type SecretProviderRegistry struct {
+	mu        sync.RWMutex
+	providers map[string]SecretProvider
+}
+
+// DefaultSecretProviders is used when secrets are resolved lazily by Secret.Value.
+var DefaultSecretProviders = NewSecretProviderRegistry()
+
+func NewSecretProviderRegistry() *SecretProviderRegistry {
+	return &SecretProviderRegistry{providers: make(map[string]SecretProvider)}
+}
+
+func (r *SecretProviderRegistry) Register(scheme string, provider SecretProvider) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.providers[scheme] = provider
+}
+
+func (r *SecretProviderRegistry) Resolve(ref string) (string, error) {
+	scheme, _, ok := strings.Cut(ref, "://")
+	if !ok {
+		return "", fmt.Errorf("%q is not a secret reference", ref)
+	}
+	r.mu.RLock()
+	provider, ok := r.providers[scheme]
+	r.mu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("%w %s", ErrUnknownSecretScheme, scheme)
+	}
+	return provider.Resolve(ref)
+}
+
+// secretCache holds resolved references, so every reference is resolved at most once.
+var secretCache sync.Map
+
+// IsReference returns true if the secret is a reference to be resolved by a SecretProvider
+// rather than a literal value.
+func (s Secret) IsReference() bool {
+	scheme, _, ok := strings.Cut(string(s), "://")
+	return ok && (scheme == SECRET_SCHEME_VAULT || scheme == SECRET_SCHEME_AWSSM)
+}
+
+// Value returns the literal value or resolves the reference with DefaultSecretProviders. It fails with
+// ErrSecretUnresolved if the reference can't be resolved, call Config.ResolveSecrets first to surface
+// such errors before the test starts.
+func (s Secret) Value() (string, error) {
+	if !s.IsReference() {
+		return string(s), nil
+	}
+	if value, ok := secretCache.Load(string(s)); ok {
+		return value.(string), nil
+	}
+	return resolveSecret(DefaultSecretProviders, s)
+}
+
+func resolveSecret(provider SecretProvider, s Secret) (string, error) {
+	value, err := provider.Resolve(string(s))
+	if err != nil {
+		// the reference itself is not secret and helps to find the misconfigured field
+		return "", withKind(ErrSecretUnresolved, fmt.Errorf("failed to resolve secret %s: %w", string(s), err))
+	}
+	secretCache.Store(string(s), value)
+	return value, nil
+}
+
+// ResolveSecrets resolves every secret reference in the config with the provider upfront,
+// so later getters don't fail. It returns an error naming every field that couldn't be resolved.
+func (o *Config) ResolveSecrets(ctx context.Context, provider SecretProvider) error {
+	secrets := make(map[string]Secret)
+	collectSecrets(reflect.ValueOf(o), "", secrets)
+	paths := make([]string, 0, len(secrets))
+	for path := range secrets {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	var failed []string
+	for _, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !secrets[path].IsReference() {
+			continue
+		}
+		if _, err := resolveSecret(provider, secrets[path]); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %s", path, err))
+		}
+	}
+	if len(failed) > 0 {
+		return withKind(ErrSecretUnresolved, fmt.Errorf("unresolved secrets:\n%s", strings.Join(failed, "\n")))
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package ccip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+type inMemorySecretProvider map[string]string
+
+func (p inMemorySecretProvider) Resolve(ref string) (string, error) {
+	if ref == "vault://broken#key" {
+		return "", errors.New("vault sealed")
+	}
+	value, ok := p[ref]
+	if !ok {
+		return "", fmt.Errorf("secret %s not found", ref)
+	}
+	return value, nil
+}
+
+func TestResolveSecrets(t *testing.T) {
+	provider := inMemorySecretProvider{"vault://ccip/grafana#token": "resolved-token"}
+	registry := NewSecretProviderRegistry()
+	registry.Register(SECRET_SCHEME_VAULT, provider)
+
+	token := Secret("vault://ccip/grafana#token")
+	literal := Secret("literal-auth")
+	cfg := &Config{Observability: &Observability{
+		GrafanaURL:    pointer.ToString("https://grafana.example.com"),
+		GrafanaToken:  &token,
+		LokiBasicAuth: &literal,
+	}}
+	require.NoError(t, cfg.ResolveSecrets(context.Background(), registry))
+	// resolved values are cached for the lazy getters
+	require.Equal(t, "resolved-token", secretValue(t, cfg.Observability.GetGrafanaToken()))
+	require.Equal(t, "literal-auth", secretValue(t, cfg.Observability.GetLokiBasicAuth()))
+}
+
+func TestResolveSecretsErrors(t *testing.T) {
+	registry := NewSecretProviderRegistry()
+	registry.Register(SECRET_SCHEME_VAULT, inMemorySecretProvider{})
+
+	missing := Secret("vault://ccip/missing#key")
+	broken := Secret("vault://broken#key")
+	unregistered := Secret("awssm://ccip-webhook")
+	cfg := &Config{
+		Observability: &Observability{GrafanaToken: &missing, LokiBasicAuth: &broken},
+		Notifications: &Notifications{WebhookURL: &unregistered},
+	}
+	err := cfg.ResolveSecrets(context.Background(), registry)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Observability.GrafanaToken: failed to resolve secret vault://ccip/missing#key")
+	require.Contains(t, err.Error(), "Observability.LokiBasicAuth: failed to resolve secret vault://broken#key: vault sealed")
+	require.Contains(t, err.Error(), "Notifications.WebhookURL")
+
+	_, err = unregistered.Value()
+	require.ErrorIs(t, err, ErrUnknownSecretScheme)
+}
+
+func secretValue(t *testing.T, s Secret) string {
+	t.Helper()
+	value, err := s.Value()
+	require.NoError(t, err)
+	return value
+}
@@ -0,0 +1,186 @@
+package ccip
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	NONCE_STRATEGY_SEQUENTIAL       = "sequential"
+	NONCE_STRATEGY_PARALLEL_PENDING = "parallelPending"
+
+	DEFAULT_NONCE_STRATEGY = NONCE_STRATEGY_SEQUENTIAL
+)
+
+// SenderConfig controls the pool of accounts load tests send messages from. Without FundEachWith the
+// senders are the genesis-funded accounts, otherwise fresh accounts are funded from the first of them.
+type SenderConfig struct {
+	AccountsPerChain *int `toml:",omitempty"`
+	// FundEachWith and RebalanceBelow are native token amounts, either in ether like "1.5" or with a unit like "500gwei" or "1000wei"
+	FundEachWith   *string `toml:",omitempty"`
+	NonceStrategy  *string `toml:",omitempty"`
+	RebalanceBelow *string `toml:",omitempty"`
+	// AllowSharedAccounts permits fewer accounts than LoadProfile.ConcurrentSenders, sharing accounts between senders
+	AllowSharedAccounts *bool `toml:",omitempty"`
+	// Funding funds fresh accounts per chain like FundEachWith does on every chain, FundEachWith overrides
+	// its native amounts when both are set
+	Funding *Funding `toml:",omitempty"`
+}
+
+// SenderPlan is the resolved sender account pool the load generator consumes.
+type SenderPlan struct {
+	AccountsPerChain   int
+	UseGenesisAccounts bool
+	// FundEachWith is nil when UseGenesisAccounts is set
+	FundEachWith  *big.Int
+	NonceStrategy string
+	// RebalanceBelow is nil when senders aren't topped up during the test
+	RebalanceBelow *big.Int
+	// Funding is what each sender is funded with per chain selector, nil without SenderConfig.Funding
+	Funding map[uint64]ChainFunding
+}
+
+// GetAccountsPerChain defaults to one account per concurrent sender.
+func (o *Config) GetAccountsPerChain() int {
+	if o.SenderConfig != nil && o.SenderConfig.AccountsPerChain != nil {
+		return *o.SenderConfig.AccountsPerChain
+	}
+	return o.LoadProfile.GetConcurrentSenders()
+}
+
+func (s *SenderConfig) GetNonceStrategy() string {
+	if s == nil || pointer.GetString(s.NonceStrategy) == "" {
+		return DEFAULT_NONCE_STRATEGY
+	}
+	return *s.NonceStrategy
+}
+
+func (s *SenderConfig) GetFundEachWith() (*big.Int, error) {
+	if s == nil || s.FundEachWith == nil {
+		return nil, nil
+	}
+	return parseNativeAmount(*s.FundEachWith)
+}
+
+func (s *SenderConfig) GetRebalanceBelow() (*big.Int, error) {
+	if s == nil || s.RebalanceBelow == nil {
+		return nil, nil
+	}
+	return parseNativeAmount(*s.RebalanceBelow)
+}
+
+// GetSenderPlan resolves the sender account pool.
+func (o *Config) GetSenderPlan() (SenderPlan, error) {
+	plan := SenderPlan{
+		AccountsPerChain: o.GetAccountsPerChain(),
+		NonceStrategy:    o.SenderConfig.GetNonceStrategy(),
+	}
+	var err error
+	if plan.FundEachWith, err = o.SenderConfig.GetFundEachWith(); err != nil {
+		return SenderPlan{}, fieldError("SenderConfig.FundEachWith", err)
+	}
+	if plan.RebalanceBelow, err = o.SenderConfig.GetRebalanceBelow(); err != nil {
+		return SenderPlan{}, fieldError("SenderConfig.RebalanceBelow", err)
+	}
+	if o.SenderConfig != nil {
+		if plan.Funding, err = o.GetFunding("SenderConfig.Funding", o.SenderConfig.Funding); err != nil {
+			return SenderPlan{}, err
+		}
+	}
+	if plan.FundEachWith != nil {
+		for selector, funding := range plan.Funding {
+			funding.Native = plan.FundEachWith
+			plan.Funding[selector] = funding
+		}
+	}
+	plan.UseGenesisAccounts = plan.FundEachWith == nil && plan.Funding == nil
+	return plan, nil
+}
+
+// parseNativeAmount parses an amount in ether, or in the unit it is suffixed with, to wei.
+func parseNativeAmount(raw string) (*big.Int, error) {
+	value, decimals := strings.TrimSpace(raw), 18
+	for _, unit := range []struct {
+		suffix   string
+		decimals int
+	}{
+		{"gwei", 9},
+		{"wei", 0},
+		{"ether", 18},
+		{"eth", 18},
+	} {
+		if strings.HasSuffix(value, unit.suffix) {
+			value, decimals = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix)), unit.decimals
+			break
+		}
+	}
+	amount, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a decimal amount", raw)
+	}
+	if amount.Sign() < 0 {
+		return nil, fmt.Errorf("%q cannot be negative", raw)
+	}
+	amount.Mul(amount, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)))
+	if !amount.IsInt() {
+		return nil, fmt.Errorf("%q has more decimals than a wei amount", raw)
+	}
+	return amount.Num(), nil
+}
+
+func (o *Config) validateSenderConfig() error {
+	if o.SenderConfig == nil {
+		return nil
+	}
+	switch strategy := o.SenderConfig.GetNonceStrategy(); strategy {
+	case NONCE_STRATEGY_SEQUENTIAL, NONCE_STRATEGY_PARALLEL_PENDING:
+	default:
+		return fmt.Errorf("SenderConfig.NonceStrategy must be one of %s or %s, got %q", NONCE_STRATEGY_SEQUENTIAL, NONCE_STRATEGY_PARALLEL_PENDING, strategy)
+	}
+	plan, err := o.GetSenderPlan()
+	if err != nil {
+		return err
+	}
+	if plan.AccountsPerChain < 1 {
+		return fmt.Errorf("SenderConfig.AccountsPerChain must be at least 1, got %d", plan.AccountsPerChain)
+	}
+	if senders := o.LoadProfile.GetConcurrentSenders(); plan.AccountsPerChain < senders && !pointer.GetBool(o.SenderConfig.AllowSharedAccounts) {
+		return fmt.Errorf("SenderConfig.AccountsPerChain %d is less than LoadProfile.ConcurrentSenders %d, set SenderConfig.AllowSharedAccounts to share accounts between senders",
+			plan.AccountsPerChain, senders)
+	}
+	if plan.RebalanceBelow != nil {
+		if plan.UseGenesisAccounts {
+			return fmt.Errorf("SenderConfig.RebalanceBelow requires SenderConfig.FundEachWith or SenderConfig.Funding")
+		}
+		if plan.FundEachWith != nil && plan.RebalanceBelow.Cmp(plan.FundEachWith) >= 0 {
+			return fmt.Errorf("SenderConfig.RebalanceBelow %s wei must be less than SenderConfig.FundEachWith %s wei", plan.RebalanceBelow, plan.FundEachWith)
+		}
+		selectors := make([]uint64, 0, len(plan.Funding))
+		for selector := range plan.Funding {
+			selectors = append(selectors, selector)
+		}
+		sort.Slice(selectors, func(i, j int) bool { return selectors[i] < selectors[j] })
+		for _, selector := range selectors {
+			if native := plan.Funding[selector].Native; native != nil && plan.RebalanceBelow.Cmp(native) >= 0 {
+				return fmt.Errorf("SenderConfig.RebalanceBelow %s wei must be less than the %s wei SenderConfig.Funding funds senders with on %s",
+					plan.RebalanceBelow, native, chainName(selector))
+			}
+		}
+	}
+	if len(o.PrivateEthereumNetworks) == 0 {
+		return nil
+	}
+	funded := o.fundedGenesisAccounts()
+	if plan.UseGenesisAccounts && plan.AccountsPerChain > funded {
+		return fmt.Errorf("SenderConfig.AccountsPerChain %d exceeds the %d genesis-funded accounts, set SenderConfig.FundEachWith to fund additional accounts",
+			plan.AccountsPerChain, funded)
+	}
+	if !plan.UseGenesisAccounts && funded == 0 {
+		return fmt.Errorf("SenderConfig.FundEachWith requires a genesis-funded account to fund senders from")
+	}
+	return nil
+}
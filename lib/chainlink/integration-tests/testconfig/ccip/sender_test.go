@@ -0,0 +1,71 @@
+package ccip
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNativeAmount(t *testing.T) {
+	for raw, want := range map[string]string{
+		"1.5":     "1500000000000000000",
+		"2 ether": "2000000000000000000",
+		"500gwei": "500000000000",
+		"1000wei": "1000",
+	} {
+		amount, err := parseNativeAmount(raw)
+		require.NoError(t, err, raw)
+		require.Equal(t, want, amount.String(), raw)
+	}
+	_, err := parseNativeAmount("0.5wei")
+	require.ErrorContains(t, err, "has more decimals than a wei amount")
+	_, err = parseNativeAmount("lots")
+	require.ErrorContains(t, err, "is not a decimal amount")
+}
+
+const senderTestTOML = `
+[PrivateEthereumNetworks.SIMULATED_1.EthereumChainConfig]
+chain_id = 1337
+addresses_to_fund = ["0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"]
+
+[LoadProfile]
+ConcurrentSenders = 8
+`
+
+func TestSenderPlan(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(senderTestTOML), &cfg))
+	require.NoError(t, toml.Unmarshal([]byte(`
+[SenderConfig]
+FundEachWith = '10'
+RebalanceBelow = '2.5'
+NonceStrategy = 'parallelPending'
+`), &cfg))
+	require.NoError(t, cfg.validateSenderConfig())
+
+	plan, err := cfg.GetSenderPlan()
+	require.NoError(t, err)
+	require.Equal(t, 8, plan.AccountsPerChain)
+	require.False(t, plan.UseGenesisAccounts)
+	require.Equal(t, 0, plan.FundEachWith.Cmp(new(big.Int).Mul(big.NewInt(10), big.NewInt(1e18))))
+	require.Equal(t, NONCE_STRATEGY_PARALLEL_PENDING, plan.NonceStrategy)
+}
+
+func TestValidateSenderConfig(t *testing.T) {
+	for _, tc := range []struct {
+		config string
+		err    string
+	}{
+		{"[SenderConfig]\nAccountsPerChain = 4\nFundEachWith = '1'\n", "SenderConfig.AccountsPerChain 4 is less than LoadProfile.ConcurrentSenders 8"},
+		{"[SenderConfig]\nAccountsPerChain = 4\nAllowSharedAccounts = true\n", "SenderConfig.AccountsPerChain 4 exceeds the 2 genesis-funded accounts"},
+		{"[SenderConfig]\nFundEachWith = '1'\nRebalanceBelow = '2'\n", "SenderConfig.RebalanceBelow 2000000000000000000 wei must be less than"},
+		{"[SenderConfig]\nFundEachWith = '1'\nNonceStrategy = 'random'\n", `SenderConfig.NonceStrategy must be one of sequential or parallelPending, got "random"`},
+	} {
+		var cfg Config
+		require.NoError(t, toml.Unmarshal([]byte(senderTestTOML), &cfg))
+		require.NoError(t, toml.Unmarshal([]byte(tc.config), &cfg))
+		require.ErrorContains(t, cfg.validateSenderConfig(), tc.err)
+	}
+}
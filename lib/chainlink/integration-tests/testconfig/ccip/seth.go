@@ -0,0 +1,146 @@
+package ccip
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	// SETH_TRACING_LEVEL_* are the tracing levels understood by Seth
+	SETH_TRACING_LEVEL_NONE     = "NONE"
+	SETH_TRACING_LEVEL_REVERTED = "REVERTED"
+	SETH_TRACING_LEVEL_ALL      = "ALL"
+
+	DEFAULT_SETH_TRACING_LEVEL    = SETH_TRACING_LEVEL_REVERTED
+	DEFAULT_SETH_GAS_BUMP_RETRIES = 10
+)
+
+// SethSettings is the subset of Seth client settings CCIP tests tune.
+type SethSettings struct {
+	TracingLevel           *string `toml:",omitempty"`
+	PendingNonceProtection *bool   `toml:",omitempty"`
+	GasBumpRetries         *int    `toml:",omitempty"`
+	EphemeralKeys          *int    `toml:",omitempty"`
+}
+
+// SethConfig holds the Seth settings for all networks, with per network overrides
+// keyed by network name. Overrides are merged field by field over Default.
+type SethConfig struct {
+	Default  *SethSettings            `toml:",omitempty"`
+	PerChain map[string]*SethSettings `toml:",omitempty"`
+}
+
+// ResolvedSethConfig is the Seth client configuration for a single network, with every value set.
+type ResolvedSethConfig struct {
+	TracingLevel           string
+	PendingNonceProtection bool
+	GasBumpRetries         int
+	EphemeralKeys          int
+}
+
+func (s *SethSettings) merge(override *SethSettings) *SethSettings {
+	merged := SethSettings{}
+	if s != nil {
+		merged = *s
+	}
+	if override == nil {
+		return &merged
+	}
+	if override.TracingLevel != nil {
+		merged.TracingLevel = override.TracingLevel
+	}
+	if override.PendingNonceProtection != nil {
+		merged.PendingNonceProtection = override.PendingNonceProtection
+	}
+	if override.GasBumpRetries != nil {
+		merged.GasBumpRetries = override.GasBumpRetries
+	}
+	if override.EphemeralKeys != nil {
+		merged.EphemeralKeys = override.EphemeralKeys
+	}
+	return &merged
+}
+
+func (s *SethSettings) resolve() ResolvedSethConfig {
+	resolved := ResolvedSethConfig{
+		TracingLevel:   DEFAULT_SETH_TRACING_LEVEL,
+		GasBumpRetries: DEFAULT_SETH_GAS_BUMP_RETRIES,
+	}
+	if s == nil {
+		return resolved
+	}
+	if level := pointer.GetString(s.TracingLevel); level != "" {
+		resolved.TracingLevel = level
+	}
+	if s.GasBumpRetries != nil {
+		resolved.GasBumpRetries = *s.GasBumpRetries
+	}
+	resolved.PendingNonceProtection = pointer.GetBool(s.PendingNonceProtection)
+	resolved.EphemeralKeys = pointer.GetInt(s.EphemeralKeys)
+	return resolved
+}
+
+// GetSethConfig returns the Seth client configuration for the named network.
+func (o *Config) GetSethConfig(networkName string) ResolvedSethConfig {
+	if o.SethConfig == nil {
+		return (*SethSettings)(nil).resolve()
+	}
+	return o.SethConfig.Default.merge(o.SethConfig.PerChain[networkName]).resolve()
+}
+
+func (o *Config) validateSethConfig() error {
+	if o.SethConfig == nil {
+		return nil
+	}
+	if err := o.SethConfig.Default.validate("SethConfig.Default"); err != nil {
+		return err
+	}
+	names := make([]string, 0, len(o.SethConfig.PerChain))
+	for name := range o.SethConfig.PerChain {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := o.SethConfig.PerChain[name].validate("SethConfig.PerChain." + name); err != nil {
+			return err
+		}
+	}
+	// ephemeral keys are backed by genesis funded accounts, so only private networks can be checked
+	names = names[:0]
+	for name := range o.PrivateEthereumNetworks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		network := o.PrivateEthereumNetworks[name]
+		if network == nil || network.EthereumChainConfig == nil {
+			continue
+		}
+		keys := o.GetSethConfig(name).EphemeralKeys
+		if funded := len(network.EthereumChainConfig.AddressesToFund); keys > funded {
+			return fmt.Errorf("SethConfig: network %s uses %d ephemeral keys but only %d genesis accounts are funded", name, keys, funded)
+		}
+	}
+	return nil
+}
+
+func (s *SethSettings) validate(field string) error {
+	if s == nil {
+		return nil
+	}
+	switch level := pointer.GetString(s.TracingLevel); level {
+	case "", SETH_TRACING_LEVEL_NONE, SETH_TRACING_LEVEL_REVERTED, SETH_TRACING_LEVEL_ALL:
+	default:
+		return fmt.Errorf("%s.TracingLevel %q is not one of %s, %s, %s", field, level,
+			SETH_TRACING_LEVEL_NONE, SETH_TRACING_LEVEL_REVERTED, SETH_TRACING_LEVEL_ALL)
+	}
+	if s.GasBumpRetries != nil && *s.GasBumpRetries < 0 {
+		return fmt.Errorf("%s.GasBumpRetries cannot be negative", field)
+	}
+	if s.EphemeralKeys != nil && *s.EphemeralKeys < 0 {
+		return fmt.Errorf("%s.EphemeralKeys cannot be negative", field)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSethConfig(t *testing.T) {
+	defaults := ResolvedSethConfig{TracingLevel: DEFAULT_SETH_TRACING_LEVEL, GasBumpRetries: DEFAULT_SETH_GAS_BUMP_RETRIES}
+	require.Equal(t, defaults, (&Config{}).GetSethConfig("SIMULATED_1"))
+	require.Equal(t, defaults, (&Config{SethConfig: &SethConfig{}}).GetSethConfig("SIMULATED_1"))
+
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(`
+[SethConfig.Default]
+TracingLevel = 'ALL'
+GasBumpRetries = 3
+
+[SethConfig.PerChain.SIMULATED_2]
+GasBumpRetries = 0
+PendingNonceProtection = true
+EphemeralKeys = 2
+`), &cfg))
+	require.Equal(t, ResolvedSethConfig{TracingLevel: SETH_TRACING_LEVEL_ALL, GasBumpRetries: 3}, cfg.GetSethConfig("SIMULATED_1"))
+	require.Equal(t, ResolvedSethConfig{TracingLevel: SETH_TRACING_LEVEL_ALL, PendingNonceProtection: true, EphemeralKeys: 2}, cfg.GetSethConfig("SIMULATED_2"))
+	require.Equal(t, 3, *cfg.SethConfig.Default.GasBumpRetries, "merging overrides must not modify Default")
+}
+
+func TestValidateSethConfig(t *testing.T) {
+	const networks = `
+[PrivateEthereumNetworks.SIMULATED_1.EthereumChainConfig]
+chain_id = 1337
+addresses_to_fund = ["0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"]
+
+[PrivateEthereumNetworks.SIMULATED_2.EthereumChainConfig]
+chain_id = 2337
+addresses_to_fund = ["0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"]
+`
+	for _, tc := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "not set"},
+		{name: "every setting", content: "[SethConfig.Default]\nTracingLevel = 'NONE'\nGasBumpRetries = 0\nEphemeralKeys = 1\n[SethConfig.PerChain.SIMULATED_2]\nEphemeralKeys = 2"},
+		{name: "unknown tracing level", content: "[SethConfig.Default]\nTracingLevel = 'SOME'", err: `SethConfig.Default.TracingLevel "SOME" is not one of NONE, REVERTED, ALL`},
+		{name: "negative gas bump retries", content: "[SethConfig.Default]\nGasBumpRetries = -1", err: "SethConfig.Default.GasBumpRetries cannot be negative"},
+		{name: "negative ephemeral keys", content: "[SethConfig.PerChain.SIMULATED_1]\nEphemeralKeys = -1", err: "SethConfig.PerChain.SIMULATED_1.EphemeralKeys cannot be negative"},
+		{
+			name:    "first invalid override in sorted order",
+			content: "[SethConfig.PerChain.SIMULATED_2]\nGasBumpRetries = -1\n[SethConfig.PerChain.SIMULATED_1]\nTracingLevel = 'SOME'",
+			err:     `SethConfig.PerChain.SIMULATED_1.TracingLevel "SOME" is not one of NONE, REVERTED, ALL`,
+		},
+		{name: "more ephemeral keys than funded accounts", content: "[SethConfig.PerChain.SIMULATED_1]\nEphemeralKeys = 2", err: "SethConfig: network SIMULATED_1 uses 2 ephemeral keys but only 1 genesis accounts are funded"},
+		{
+			name:    "first underfunded network in sorted order",
+			content: "[SethConfig.Default]\nEphemeralKeys = 3",
+			err:     "SethConfig: network SIMULATED_1 uses 3 ephemeral keys but only 1 genesis accounts are funded",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte(tc.content+networks), &cfg))
+			err := cfg.validateSethConfig()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
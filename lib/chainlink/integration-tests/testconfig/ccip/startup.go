@@ -0,0 +1,103 @@
+package ccip
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+)
+
+// startupComponents are the components StartupOrder orders, in the order they start when it's unset.
+var startupComponents = []string{COMPONENT_CHAINS, COMPONENT_NODES, COMPONENT_JD, COMPONENT_RMN}
+
+// startupPrerequisites lists the components a component can't start without.
+var startupPrerequisites = map[string][]string{
+	COMPONENT_NODES: {COMPONENT_CHAINS},
+	COMPONENT_RMN:   {COMPONENT_CHAINS},
+}
+
+// StartupStage is a group of components that don't depend on each other, started once every earlier
+// stage is up.
+type StartupStage struct {
+	Components []string
+}
+
+// ResolveStartupPlan returns the stages the environment is brought up in. Components listed in
+// StartupOrder start one after the other in that order, the others as soon as their prerequisites are
+// up. Without StartupOrder every component starts after the one before it in startupComponents.
+func (o *Config) ResolveStartupPlan() ([]StartupStage, error) {
+	order := o.StartupOrder
+	if len(order) == 0 {
+		order = startupComponents
+	}
+	after := make(map[string][]string, len(startupComponents))
+	for component, prerequisites := range startupPrerequisites {
+		after[component] = append(after[component], prerequisites...)
+	}
+	for i, component := range order {
+		if !containsString(startupComponents, component) {
+			return nil, fmt.Errorf("StartupOrder contains unknown component %q, must be one of %v", component, startupComponents)
+		}
+		if containsString(order[:i], component) {
+			return nil, fmt.Errorf("StartupOrder contains %s more than once", component)
+		}
+		if i > 0 {
+			after[component] = append(after[component], order[i-1])
+		}
+	}
+
+	stageOf := make(map[string]int, len(startupComponents))
+	var visit func(component string, path []string) error
+	visit = func(component string, path []string) error {
+		if _, ok := stageOf[component]; ok {
+			return nil
+		}
+		if containsString(path, component) {
+			return fmt.Errorf("StartupOrder has a cycle, %s would each have to start after the next", strings.Join(append(path, component), " -> "))
+		}
+		stage := 0
+		for _, dependency := range after[component] {
+			if err := visit(dependency, append(path, component)); err != nil {
+				return err
+			}
+			stage = max(stage, stageOf[dependency]+1)
+		}
+		stageOf[component] = stage
+		return nil
+	}
+	stages := make([]StartupStage, len(startupComponents))
+	for _, component := range startupComponents {
+		if err := visit(component, nil); err != nil {
+			return nil, err
+		}
+		// RMN is ordered like the others but not started without nodes
+		if component == COMPONENT_RMN && pointer.GetInt(o.RMNConfig.NoOfNodes) == 0 {
+			continue
+		}
+		stages[stageOf[component]].Components = append(stages[stageOf[component]].Components, component)
+	}
+	started := stages[:0]
+	for _, stage := range stages {
+		if len(stage.Components) > 0 {
+			sort.Strings(stage.Components)
+			started = append(started, stage)
+		}
+	}
+	return started, nil
+}
+
+// StartupStageOf returns the index of the stage the component starts in, -1 if it's in none of them.
+func StartupStageOf(stages []StartupStage, component string) int {
+	for i, stage := range stages {
+		if containsString(stage.Components, component) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (o *Config) validateStartupOrder() error {
+	_, err := o.ResolveStartupPlan()
+	return err
+}
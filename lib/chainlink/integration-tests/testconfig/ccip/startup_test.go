@@ -0,0 +1,81 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveStartupPlan(t *testing.T) {
+	withRMN := RMNConfig{NoOfNodes: pointer.ToInt(2)}
+	for _, tc := range []struct {
+		name   string
+		order  []string
+		rmn    RMNConfig
+		stages [][]string
+		err    string
+	}{
+		{
+			name:   "default order",
+			rmn:    withRMN,
+			stages: [][]string{{COMPONENT_CHAINS}, {COMPONENT_NODES}, {COMPONENT_JD}, {COMPONENT_RMN}},
+		},
+		{
+			name:   "disabled RMN is not started",
+			stages: [][]string{{COMPONENT_CHAINS}, {COMPONENT_NODES}, {COMPONENT_JD}},
+		},
+		{
+			// unlisted components start as soon as their prerequisites are up
+			name:   "JD first for external node registration",
+			order:  []string{COMPONENT_JD, COMPONENT_CHAINS},
+			rmn:    withRMN,
+			stages: [][]string{{COMPONENT_JD}, {COMPONENT_CHAINS}, {COMPONENT_NODES, COMPONENT_RMN}},
+		},
+		{
+			name:   "RMN last",
+			order:  []string{COMPONENT_NODES, COMPONENT_RMN},
+			rmn:    withRMN,
+			stages: [][]string{{COMPONENT_CHAINS, COMPONENT_JD}, {COMPONENT_NODES}, {COMPONENT_RMN}},
+		},
+		{
+			name:  "nodes before chains",
+			order: []string{COMPONENT_NODES, COMPONENT_JD, COMPONENT_CHAINS},
+			err:   "StartupOrder has a cycle, chains -> jd -> nodes -> chains would each have to start after the next",
+		},
+		{
+			name:  "unknown component",
+			order: []string{COMPONENT_CHAINS, "explorer"},
+			err:   `StartupOrder contains unknown component "explorer"`,
+		},
+		{
+			name:  "duplicate component",
+			order: []string{COMPONENT_CHAINS, COMPONENT_JD, COMPONENT_CHAINS},
+			err:   "StartupOrder contains chains more than once",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &Config{StartupOrder: tc.order, RMNConfig: tc.rmn}
+			stages, err := cfg.ResolveStartupPlan()
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				require.ErrorContains(t, cfg.validateStartupOrder(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			var components [][]string
+			for _, stage := range stages {
+				components = append(components, stage.Components)
+			}
+			require.Equal(t, tc.stages, components)
+		})
+	}
+}
+
+func TestStartupStageOf(t *testing.T) {
+	stages, err := (&Config{StartupOrder: []string{COMPONENT_JD, COMPONENT_CHAINS}}).ResolveStartupPlan()
+	require.NoError(t, err)
+	require.Equal(t, 0, StartupStageOf(stages, COMPONENT_JD))
+	require.Equal(t, 1, StartupStageOf(stages, COMPONENT_CHAINS))
+	require.Equal(t, -1, StartupStageOf(stages, COMPONENT_RMN))
+}
@@ -0,0 +1,261 @@
+package ccip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	RESOURCE_KIND_CONTAINER = "container"
+	RESOURCE_KIND_VOLUME    = "volume"
+
+	ACCOUNT_ROLE_SENDER   = "sender"
+	ACCOUNT_ROLE_NODE     = "node"
+	ACCOUNT_ROLE_DEPLOYER = "deployer"
+
+	// DEFAULT_SWEEP_GAS_RESERVE is left on swept accounts to pay for the sweep itself
+	DEFAULT_SWEEP_GAS_RESERVE = "0.001"
+)
+
+// TeardownVerification checks after teardown that the run left nothing behind: containers and volumes
+// labeled with its RunID, and funds on the accounts it used.
+type TeardownVerification struct {
+	FailOnLeakedContainers *bool `toml:",omitempty"`
+	FailOnLeakedVolumes    *bool `toml:",omitempty"`
+	SweepRemainingFunds    *bool `toml:",omitempty"`
+	// SweepToAddress is the address funds are swept back to, keyed by chain. Chains without one aren't swept
+	SweepToAddress map[string]string `toml:",omitempty"`
+	// GasReserve is the native amount left on every swept account, like "0.001" or "500000gwei"
+	GasReserve *string `toml:",omitempty"`
+	// SweepLiveDeployerKeys are the live networks whose deployer key is swept, never swept otherwise
+	SweepLiveDeployerKeys []string `toml:",omitempty"`
+}
+
+func (t *TeardownVerification) GetGasReserve() (*big.Int, error) {
+	if t == nil || t.GasReserve == nil {
+		return parseNativeAmount(DEFAULT_SWEEP_GAS_RESERVE)
+	}
+	return parseNativeAmount(*t.GasReserve)
+}
+
+// LabeledResource is a docker resource carrying the labels of a run.
+type LabeledResource struct {
+	// Kind is one of the RESOURCE_KIND_* constants
+	Kind string
+	Name string
+}
+
+// ResourceCleaner lists and removes the docker resources of a run.
+type ResourceCleaner interface {
+	ListLabeled(ctx context.Context, labels map[string]string) ([]LabeledResource, error)
+	Remove(ctx context.Context, resource LabeledResource) error
+}
+
+// FundedAccount is an account the run sent from, one of the ACCOUNT_ROLE_* roles.
+type FundedAccount struct {
+	ChainSelector uint64
+	Address       string
+	Role          string
+	Balance       *big.Int
+}
+
+// FundSweeper lists the accounts of the run and transfers their funds.
+type FundSweeper interface {
+	ListFundedAccounts(ctx context.Context, selector uint64) ([]FundedAccount, error)
+	Transfer(ctx context.Context, from FundedAccount, to string, amount *big.Int) (txHash string, err error)
+}
+
+// TeardownReport is what the teardown verification found and cleaned.
+type TeardownReport struct {
+	Leaked []LeakedResource `json:"leaked,omitempty"`
+	Sweeps []SweepReport    `json:"sweeps,omitempty"`
+}
+
+// LeakedResource is a resource still around after teardown, removed by the verification unless Error is set.
+type LeakedResource struct {
+	Kind  string `json:"kind"`
+	Name  string `json:"name"`
+	Error string `json:"error,omitempty"`
+}
+
+// SweepReport is the sweep of one account, Amount is in ether. Skipped accounts have a Reason.
+type SweepReport struct {
+	Chain   string `json:"chain"`
+	Address string `json:"address"`
+	Role    string `json:"role"`
+	Amount  string `json:"amount,omitempty"`
+	TxHash  string `json:"txHash,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// TeardownVerifier runs the TeardownVerification of the config.
+type TeardownVerifier struct {
+	cfg       *Config
+	resources ResourceCleaner
+	funds     FundSweeper
+}
+
+// NewTeardownVerifier returns the verifier to run once the environment is torn down, nil unless
+// TeardownVerification is set. funds may be nil when SweepRemainingFunds isn't set.
+func (o *Config) NewTeardownVerifier(resources ResourceCleaner, funds FundSweeper) *TeardownVerifier {
+	if o.TeardownVerification == nil {
+		return nil
+	}
+	return &TeardownVerifier{cfg: o, resources: resources, funds: funds}
+}
+
+// Verify removes the leaked resources of the run and sweeps its accounts. Everything is attempted and
+// reported, the error lists the leaks FailOnLeakedContainers and FailOnLeakedVolumes fail on.
+func (v *TeardownVerifier) Verify(ctx context.Context) (*TeardownReport, error) {
+	if v == nil {
+		return nil, nil
+	}
+	t := v.cfg.TeardownVerification
+	report := &TeardownReport{}
+	resources, err := v.resources.ListLabeled(ctx, map[string]string{LABEL_RUN_ID: v.cfg.GetRunID()})
+	if err != nil {
+		return nil, fmt.Errorf("list resources of run %s: %w", v.cfg.GetRunID(), err)
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].Kind != resources[j].Kind {
+			return resources[i].Kind < resources[j].Kind
+		}
+		return resources[i].Name < resources[j].Name
+	})
+	var failures []string
+	for _, resource := range resources {
+		leaked := LeakedResource{Kind: resource.Kind, Name: resource.Name}
+		if err := v.resources.Remove(ctx, resource); err != nil {
+			leaked.Error = err.Error()
+		}
+		report.Leaked = append(report.Leaked, leaked)
+		if (resource.Kind == RESOURCE_KIND_CONTAINER && pointer.GetBool(t.FailOnLeakedContainers)) ||
+			(resource.Kind == RESOURCE_KIND_VOLUME && pointer.GetBool(t.FailOnLeakedVolumes)) {
+			failures = append(failures, resource.Kind+" "+resource.Name)
+		}
+	}
+	if pointer.GetBool(t.SweepRemainingFunds) {
+		if report.Sweeps, err = v.sweep(ctx); err != nil {
+			return report, err
+		}
+	}
+	if len(failures) > 0 {
+		return report, fmt.Errorf("run %s leaked %s", v.cfg.GetRunID(), strings.Join(failures, ", "))
+	}
+	return report, nil
+}
+
+// sweep transfers the balances above the gas reserve to SweepToAddress, chain by chain in selector order.
+func (v *TeardownVerifier) sweep(ctx context.Context) ([]SweepReport, error) {
+	t := v.cfg.TeardownVerification
+	if v.funds == nil {
+		return nil, errors.New("TeardownVerification.SweepRemainingFunds is set, but no FundSweeper was given")
+	}
+	reserve, err := t.GetGasReserve()
+	if err != nil {
+		return nil, fieldError("TeardownVerification.GasReserve", err)
+	}
+	destinations := make(map[uint64]string, len(t.SweepToAddress))
+	selectors := make([]uint64, 0, len(t.SweepToAddress))
+	for ref, address := range t.SweepToAddress {
+		selector, err := v.cfg.ResolveChainSelector(ref)
+		if err != nil {
+			return nil, fieldError("TeardownVerification.SweepToAddress."+ref, err)
+		}
+		destinations[selector] = address
+		selectors = append(selectors, selector)
+	}
+	sort.Slice(selectors, func(i, j int) bool { return selectors[i] < selectors[j] })
+	var sweeps []SweepReport
+	for _, selector := range selectors {
+		accounts, err := v.funds.ListFundedAccounts(ctx, selector)
+		if err != nil {
+			return sweeps, fmt.Errorf("chain %s: list funded accounts: %w", chainName(selector), err)
+		}
+		sort.Slice(accounts, func(i, j int) bool {
+			if accounts[i].Role != accounts[j].Role {
+				return accounts[i].Role < accounts[j].Role
+			}
+			return accounts[i].Address < accounts[j].Address
+		})
+		for _, account := range accounts {
+			sweep := SweepReport{Chain: chainName(selector), Address: account.Address, Role: account.Role}
+			amount := new(big.Int)
+			if account.Balance != nil {
+				amount.Sub(account.Balance, reserve)
+			}
+			switch {
+			case account.Role == ACCOUNT_ROLE_DEPLOYER && !v.sweepsDeployer(selector):
+				sweep.Reason = "deployer key of a live network not in SweepLiveDeployerKeys"
+			case strings.EqualFold(account.Address, destinations[selector]):
+				sweep.Reason = "account is the sweep address"
+			case amount.Sign() <= 0:
+				sweep.Reason = "balance within the gas reserve"
+			default:
+				sweep.Amount = formatAmount(amount)
+				if sweep.TxHash, err = v.funds.Transfer(ctx, account, destinations[selector], amount); err != nil {
+					sweep.Error = err.Error()
+				}
+			}
+			sweeps = append(sweeps, sweep)
+		}
+	}
+	return sweeps, nil
+}
+
+// sweepsDeployer returns whether the deployer key of the chain may be swept, always on private networks.
+func (v *TeardownVerifier) sweepsDeployer(selector uint64) bool {
+	if v.cfg.privateNetwork(selector) != nil {
+		return true
+	}
+	for _, ref := range v.cfg.TeardownVerification.SweepLiveDeployerKeys {
+		if resolved, err := v.cfg.ResolveChainSelector(ref); err == nil && resolved == selector {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *Config) validateTeardownVerification() error {
+	t := o.TeardownVerification
+	if t == nil {
+		return nil
+	}
+	if _, err := t.GetGasReserve(); err != nil {
+		return fieldError("TeardownVerification.GasReserve", err)
+	}
+	if pointer.GetBool(t.SweepRemainingFunds) && len(t.SweepToAddress) == 0 {
+		return fmt.Errorf("TeardownVerification.SweepRemainingFunds is set, but SweepToAddress has no chain to sweep to")
+	}
+	refs := make([]string, 0, len(t.SweepToAddress))
+	for ref := range t.SweepToAddress {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	for _, ref := range refs {
+		field := "TeardownVerification.SweepToAddress." + ref
+		if _, err := o.ResolveChainSelector(ref); err != nil {
+			return fieldError(field, err)
+		}
+		if !evmAddressRegex.MatchString(t.SweepToAddress[ref]) {
+			return fmt.Errorf("%s %q is not an EVM address", field, t.SweepToAddress[ref])
+		}
+	}
+	for _, ref := range t.SweepLiveDeployerKeys {
+		selector, err := o.ResolveChainSelector(ref)
+		if err != nil {
+			return fieldError("TeardownVerification.SweepLiveDeployerKeys", err)
+		}
+		if o.privateNetwork(selector) != nil {
+			return fmt.Errorf("TeardownVerification.SweepLiveDeployerKeys: %s is a private network, its deployer is always swept", ref)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package ccip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const sepoliaSelector = 16015286601757825753
+
+type fakeResourceCleaner struct {
+	labels    map[string]string
+	resources []LabeledResource
+	removed   []string
+}
+
+func (f *fakeResourceCleaner) ListLabeled(_ context.Context, labels map[string]string) ([]LabeledResource, error) {
+	f.labels = labels
+	return f.resources, nil
+}
+
+func (f *fakeResourceCleaner) Remove(_ context.Context, resource LabeledResource) error {
+	if resource.Name == "stuck" {
+		return errors.New("volume is in use")
+	}
+	f.removed = append(f.removed, resource.Name)
+	return nil
+}
+
+type fakeFundSweeper struct {
+	accounts  map[uint64][]FundedAccount
+	transfers []string
+}
+
+func (f *fakeFundSweeper) ListFundedAccounts(_ context.Context, selector uint64) ([]FundedAccount, error) {
+	return f.accounts[selector], nil
+}
+
+func (f *fakeFundSweeper) Transfer(_ context.Context, from FundedAccount, to string, amount *big.Int) (string, error) {
+	f.transfers = append(f.transfers, fmt.Sprintf("%s -> %s %s", from.Address, to, formatAmount(amount)))
+	return "0xtx", nil
+}
+
+func TestTeardownVerification(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(`
+RunID = 'run-1'
+
+[TeardownVerification]
+FailOnLeakedVolumes = true
+SweepRemainingFunds = true
+GasReserve = '0.01'
+
+[TeardownVerification.SweepToAddress]
+SIMULATED_1 = '0x00000000000000000000000000000000000000aa'
+16015286601757825753 = '0x00000000000000000000000000000000000000bb'
+`+orderingNetworks), &cfg))
+	require.NoError(t, cfg.validateTeardownVerification())
+
+	resources := &fakeResourceCleaner{resources: []LabeledResource{
+		{Kind: RESOURCE_KIND_VOLUME, Name: "stuck"},
+		{Kind: RESOURCE_KIND_CONTAINER, Name: "node-1"},
+	}}
+	ether := func(amount string) *big.Int {
+		wei, err := parseNativeAmount(amount)
+		require.NoError(t, err)
+		return wei
+	}
+	funds := &fakeFundSweeper{accounts: map[uint64][]FundedAccount{
+		3379446385462418246: {
+			{Address: "0x01", Role: ACCOUNT_ROLE_SENDER, Balance: ether("1.5")},
+			{Address: "0x02", Role: ACCOUNT_ROLE_SENDER, Balance: ether("0.005")},
+			{Address: "0x03", Role: ACCOUNT_ROLE_DEPLOYER, Balance: ether("2")},
+		},
+		sepoliaSelector: {
+			{Address: "0x04", Role: ACCOUNT_ROLE_DEPLOYER, Balance: ether("10")},
+			{Address: "0x05", Role: ACCOUNT_ROLE_NODE, Balance: ether("0.51")},
+		},
+	}}
+	report, err := cfg.NewTeardownVerifier(resources, funds).Verify(context.Background())
+	require.EqualError(t, err, "run run-1 leaked volume stuck")
+	require.Equal(t, map[string]string{LABEL_RUN_ID: "run-1"}, resources.labels)
+	require.Equal(t, []string{"node-1"}, resources.removed)
+	require.Equal(t, []LeakedResource{
+		{Kind: RESOURCE_KIND_CONTAINER, Name: "node-1"},
+		{Kind: RESOURCE_KIND_VOLUME, Name: "stuck", Error: "volume is in use"},
+	}, report.Leaked)
+	require.Equal(t, []string{
+		"0x03 -> 0x00000000000000000000000000000000000000aa 1.99",
+		"0x01 -> 0x00000000000000000000000000000000000000aa 1.49",
+		"0x05 -> 0x00000000000000000000000000000000000000bb 0.5",
+	}, funds.transfers)
+	require.Equal(t, "deployer key of a live network not in SweepLiveDeployerKeys", report.Sweeps[3].Reason)
+	require.Equal(t, "balance within the gas reserve", report.Sweeps[2].Reason)
+
+	cfg.TeardownVerification = nil
+	require.Nil(t, cfg.NewTeardownVerifier(resources, funds))
+}
+
+func TestValidateTeardownVerification(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "nowhere to sweep", content: "SweepRemainingFunds = true", err: "TeardownVerification.SweepRemainingFunds is set, but SweepToAddress has no chain to sweep to"},
+		{name: "invalid address", content: "[TeardownVerification.SweepToAddress]\nSIMULATED_1 = '0x1'", err: `TeardownVerification.SweepToAddress.SIMULATED_1 "0x1" is not an EVM address`},
+		{name: "invalid reserve", content: "GasReserve = 'lots'", err: "TeardownVerification.GasReserve: "},
+		{name: "private deployer", content: "SweepLiveDeployerKeys = ['SIMULATED_2']", err: "TeardownVerification.SweepLiveDeployerKeys: SIMULATED_2 is a private network"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte("[TeardownVerification]\n"+tc.content+"\n"+orderingNetworks), &cfg))
+			require.ErrorContains(t, cfg.validateTeardownVerification(), tc.err)
+		})
+	}
+}
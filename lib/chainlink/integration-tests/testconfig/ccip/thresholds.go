@@ -0,0 +1,123 @@
+package ccip
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	THRESHOLD_MAX_FAILED_MESSAGES_PCT  = "MaxFailedMessagesPct"
+	THRESHOLD_P95_COMMIT_LATENCY       = "P95CommitLatency"
+	THRESHOLD_P95_EXEC_LATENCY         = "P95ExecLatency"
+	THRESHOLD_MAX_MANUAL_EXEC_REQUIRED = "MaxManualExecRequired"
+)
+
+// Thresholds are the pass/fail criteria evaluated at the end of a load test.
+type Thresholds struct {
+	MaxFailedMessagesPct  *float64  `toml:",omitempty"`
+	P95CommitLatency      *Duration `toml:",omitempty"`
+	P95ExecLatency        *Duration `toml:",omitempty"`
+	MaxManualExecRequired *int      `toml:",omitempty"`
+}
+
+// LoadTestResults is what the load test observed, as needed to evaluate Thresholds.
+type LoadTestResults struct {
+	TotalMessages      int
+	FailedMessages     int
+	CommitLatencies    []time.Duration
+	ExecLatencies      []time.Duration
+	ManualExecRequired int
+}
+
+// ThresholdViolation describes a single threshold the test run did not meet.
+type ThresholdViolation struct {
+	Threshold string `json:"threshold"`
+	Limit     string `json:"limit"`
+	Actual    string `json:"actual"`
+}
+
+func (v ThresholdViolation) String() string {
+	return fmt.Sprintf("%s: expected at most %s, got %s", v.Threshold, v.Limit, v.Actual)
+}
+
+func (t *Thresholds) Validate(profile *LoadProfile) error {
+	if profile == nil {
+		return fmt.Errorf("Thresholds can only be set when a LoadProfile is configured")
+	}
+	if t.MaxFailedMessagesPct != nil && (*t.MaxFailedMessagesPct < 0 || *t.MaxFailedMessagesPct > 100) {
+		return fmt.Errorf("Thresholds.MaxFailedMessagesPct must be between 0 and 100, got %f", *t.MaxFailedMessagesPct)
+	}
+	if t.P95CommitLatency != nil && t.P95CommitLatency.Duration <= 0 {
+		return fmt.Errorf("Thresholds.P95CommitLatency must be positive")
+	}
+	if t.P95ExecLatency != nil && t.P95ExecLatency.Duration <= 0 {
+		return fmt.Errorf("Thresholds.P95ExecLatency must be positive")
+	}
+	if t.MaxManualExecRequired != nil && *t.MaxManualExecRequired < 0 {
+		return fmt.Errorf("Thresholds.MaxManualExecRequired cannot be negative")
+	}
+	return nil
+}
+
+// EvaluateThresholds returns every threshold the results violate; unset thresholds are not evaluated.
+func (t *Thresholds) EvaluateThresholds(results LoadTestResults) []ThresholdViolation {
+	if t == nil {
+		return nil
+	}
+	var violations []ThresholdViolation
+	if t.MaxFailedMessagesPct != nil && results.TotalMessages > 0 {
+		failedPct := float64(results.FailedMessages) * 100 / float64(results.TotalMessages)
+		if failedPct > *t.MaxFailedMessagesPct {
+			violations = append(violations, ThresholdViolation{
+				Threshold: THRESHOLD_MAX_FAILED_MESSAGES_PCT,
+				Limit:     fmt.Sprintf("%.2f%%", *t.MaxFailedMessagesPct),
+				Actual:    fmt.Sprintf("%.2f%%", failedPct),
+			})
+		}
+	}
+	if t.P95CommitLatency != nil && len(results.CommitLatencies) > 0 {
+		if p95 := Percentile(results.CommitLatencies, 95); p95 > t.P95CommitLatency.Duration {
+			violations = append(violations, ThresholdViolation{
+				Threshold: THRESHOLD_P95_COMMIT_LATENCY,
+				Limit:     t.P95CommitLatency.Duration.String(),
+				Actual:    p95.String(),
+			})
+		}
+	}
+	if t.P95ExecLatency != nil && len(results.ExecLatencies) > 0 {
+		if p95 := Percentile(results.ExecLatencies, 95); p95 > t.P95ExecLatency.Duration {
+			violations = append(violations, ThresholdViolation{
+				Threshold: THRESHOLD_P95_EXEC_LATENCY,
+				Limit:     t.P95ExecLatency.Duration.String(),
+				Actual:    p95.String(),
+			})
+		}
+	}
+	if t.MaxManualExecRequired != nil && results.ManualExecRequired > *t.MaxManualExecRequired {
+		violations = append(violations, ThresholdViolation{
+			Threshold: THRESHOLD_MAX_MANUAL_EXEC_REQUIRED,
+			Limit:     fmt.Sprint(*t.MaxManualExecRequired),
+			Actual:    fmt.Sprint(results.ManualExecRequired),
+		})
+	}
+	return violations
+}
+
+// Percentile returns the p-th percentile (nearest rank) of the durations.
+func Percentile(durations []time.Duration, p float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := int(p/100*float64(len(sorted)) + 0.999999)
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
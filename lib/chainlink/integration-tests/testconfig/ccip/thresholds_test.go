@@ -0,0 +1,47 @@
+package ccip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateThresholds(t *testing.T) {
+	thresholds := &Thresholds{
+		MaxFailedMessagesPct:  pointer.ToFloat64(1),
+		P95CommitLatency:      &Duration{Duration: time.Minute},
+		P95ExecLatency:        &Duration{Duration: 2 * time.Minute},
+		MaxManualExecRequired: pointer.ToInt(0),
+	}
+	latencies := func(d ...time.Duration) []time.Duration { return d }
+
+	violations := thresholds.EvaluateThresholds(LoadTestResults{
+		TotalMessages:   100,
+		FailedMessages:  1,
+		CommitLatencies: latencies(10*time.Second, 50*time.Second),
+		ExecLatencies:   latencies(time.Minute),
+	})
+	require.Empty(t, violations)
+
+	violations = thresholds.EvaluateThresholds(LoadTestResults{
+		TotalMessages:      100,
+		FailedMessages:     2,
+		CommitLatencies:    latencies(10*time.Second, 2*time.Minute),
+		ExecLatencies:      latencies(time.Minute),
+		ManualExecRequired: 3,
+	})
+	require.Len(t, violations, 3)
+	require.Equal(t, THRESHOLD_MAX_FAILED_MESSAGES_PCT, violations[0].Threshold)
+	require.Equal(t, "2.00%", violations[0].Actual)
+	require.Equal(t, THRESHOLD_P95_COMMIT_LATENCY, violations[1].Threshold)
+	require.Equal(t, "2m0s", violations[1].Actual)
+	require.Equal(t, THRESHOLD_MAX_MANUAL_EXEC_REQUIRED, violations[2].Threshold)
+}
+
+func TestThresholdsValidate(t *testing.T) {
+	thresholds := &Thresholds{MaxFailedMessagesPct: pointer.ToFloat64(101)}
+	require.ErrorContains(t, thresholds.Validate(nil), "LoadProfile")
+	require.ErrorContains(t, thresholds.Validate(&LoadProfile{}), "between 0 and 100")
+}
@@ -0,0 +1,148 @@
+package ccip
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	ctfconfig "github.com/smartcontractkit/chainlink-testing-framework/lib/config"
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/config/types"
+)
+
+const (
+	// defaults mirror the waits hardcoded in the ccip test assertions
+	DEFAULT_COMMIT_TIMEOUT       = 5 * time.Minute
+	DEFAULT_BLESS_TIMEOUT        = 5 * time.Minute
+	DEFAULT_EXEC_TIMEOUT         = 5 * time.Minute
+	DEFAULT_SETUP_TIMEOUT        = 10 * time.Minute
+	DEFAULT_OVERALL_TEST_TIMEOUT = 30 * time.Minute
+
+	// number of epochs after which a block is finalized on eth2 networks
+	ETH2_EPOCHS_TO_FINALITY = 2
+	// number of blocks after which a block is considered final on eth1 networks
+	ETH1_BLOCKS_TO_FINALITY = 1
+)
+
+// Timeouts configures how long a CCIP test waits for each phase. All getters apply ScaleFactor,
+// which can be raised for slow environments without touching the individual values.
+type Timeouts struct {
+	CommitTimeout      *Duration `toml:",omitempty"`
+	BlessTimeout       *Duration `toml:",omitempty"`
+	ExecTimeout        *Duration `toml:",omitempty"`
+	SetupTimeout       *Duration `toml:",omitempty"`
+	OverallTestTimeout *Duration `toml:",omitempty"`
+	ScaleFactor        *float64  `toml:",omitempty"`
+}
+
+func (t *Timeouts) GetScaleFactor() float64 {
+	if t == nil || t.ScaleFactor == nil {
+		return 1
+	}
+	return *t.ScaleFactor
+}
+
+func (t *Timeouts) scaled(d *Duration, def time.Duration) time.Duration {
+	value := def
+	if d != nil {
+		value = d.Duration
+	}
+	return time.Duration(float64(value) * t.GetScaleFactor())
+}
+
+func (t *Timeouts) GetCommitTimeout() time.Duration {
+	if t == nil {
+		return DEFAULT_COMMIT_TIMEOUT
+	}
+	return t.scaled(t.CommitTimeout, DEFAULT_COMMIT_TIMEOUT)
+}
+
+func (t *Timeouts) GetBlessTimeout() time.Duration {
+	if t == nil {
+		return DEFAULT_BLESS_TIMEOUT
+	}
+	return t.scaled(t.BlessTimeout, DEFAULT_BLESS_TIMEOUT)
+}
+
+func (t *Timeouts) GetExecTimeout() time.Duration {
+	if t == nil {
+		return DEFAULT_EXEC_TIMEOUT
+	}
+	return t.scaled(t.ExecTimeout, DEFAULT_EXEC_TIMEOUT)
+}
+
+func (t *Timeouts) GetSetupTimeout() time.Duration {
+	if t == nil {
+		return DEFAULT_SETUP_TIMEOUT
+	}
+	return t.scaled(t.SetupTimeout, DEFAULT_SETUP_TIMEOUT)
+}
+
+func (t *Timeouts) GetOverallTestTimeout() time.Duration {
+	if t == nil {
+		return DEFAULT_OVERALL_TEST_TIMEOUT
+	}
+	return t.scaled(t.OverallTestTimeout, DEFAULT_OVERALL_TEST_TIMEOUT)
+}
+
+// Validate checks the timeouts are consistent with each other and long enough for the slowest
+// private network to reach finality, which has to happen before a message can be committed.
+func (t *Timeouts) Validate(networks map[string]*ctfconfig.EthereumNetworkConfig) error {
+	if t.ScaleFactor != nil && *t.ScaleFactor <= 0 {
+		return fmt.Errorf("Timeouts.ScaleFactor must be positive, got %f", *t.ScaleFactor)
+	}
+	for _, timeout := range []struct {
+		name  string
+		value *Duration
+	}{
+		{"CommitTimeout", t.CommitTimeout},
+		{"BlessTimeout", t.BlessTimeout},
+		{"ExecTimeout", t.ExecTimeout},
+		{"SetupTimeout", t.SetupTimeout},
+		{"OverallTestTimeout", t.OverallTestTimeout},
+	} {
+		if timeout.value != nil && timeout.value.Duration <= 0 {
+			return fmt.Errorf("Timeouts.%s must be positive, got %s", timeout.name, timeout.value.Duration)
+		}
+	}
+	phases := t.GetSetupTimeout() + t.GetCommitTimeout() + t.GetBlessTimeout() + t.GetExecTimeout()
+	if t.GetOverallTestTimeout() < phases {
+		return fmt.Errorf("Timeouts.OverallTestTimeout (%s) must be at least the sum of the phase timeouts (%s)",
+			t.GetOverallTestTimeout(), phases)
+	}
+	if slowest, finality := slowestFinality(networks); finality > 0 {
+		minCommit := finality + DEFAULT_COMMIT_INTERVAL
+		if t.GetCommitTimeout() < minCommit {
+			return fmt.Errorf("Timeouts.CommitTimeout (%s) is too short for network %s to reach finality, minimum is %s",
+				t.GetCommitTimeout(), slowest, minCommit)
+		}
+	}
+	return nil
+}
+
+// EstimateFinalityTime estimates how long it takes for a block on the private network to become final.
+func EstimateFinalityTime(network *ctfconfig.EthereumNetworkConfig) time.Duration {
+	if network == nil || network.EthereumChainConfig == nil {
+		return 0
+	}
+	slot := time.Duration(network.EthereumChainConfig.SecondsPerSlot) * time.Second
+	if network.EthereumVersion != nil && *network.EthereumVersion == types.EthereumVersion_Eth2 {
+		return ETH2_EPOCHS_TO_FINALITY * time.Duration(network.EthereumChainConfig.SlotsPerEpoch) * slot
+	}
+	return ETH1_BLOCKS_TO_FINALITY * slot
+}
+
+func slowestFinality(networks map[string]*ctfconfig.EthereumNetworkConfig) (string, time.Duration) {
+	names := make([]string, 0, len(networks))
+	for name := range networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var slowest string
+	var finality time.Duration
+	for _, name := range names {
+		if f := EstimateFinalityTime(networks[name]); f > finality {
+			slowest, finality = name, f
+		}
+	}
+	return slowest, finality
+}
@@ -0,0 +1,74 @@
+package ccip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+
+	ctfconfig "github.com/smartcontractkit/chainlink-testing-framework/lib/config"
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/config/types"
+)
+
+func TestTimeoutsDefaults(t *testing.T) {
+	var unset *Timeouts
+	require.Equal(t, float64(1), unset.GetScaleFactor())
+	require.Equal(t, DEFAULT_COMMIT_TIMEOUT, unset.GetCommitTimeout())
+	require.Equal(t, DEFAULT_BLESS_TIMEOUT, unset.GetBlessTimeout())
+	require.Equal(t, DEFAULT_EXEC_TIMEOUT, unset.GetExecTimeout())
+	require.Equal(t, DEFAULT_SETUP_TIMEOUT, unset.GetSetupTimeout())
+	require.Equal(t, DEFAULT_OVERALL_TEST_TIMEOUT, unset.GetOverallTestTimeout())
+	require.NoError(t, (&Timeouts{}).Validate(nil))
+
+	scaled := &Timeouts{ScaleFactor: pointer.ToFloat64(2), ExecTimeout: &Duration{time.Minute}}
+	require.Equal(t, 2*DEFAULT_COMMIT_TIMEOUT, scaled.GetCommitTimeout())
+	require.Equal(t, 2*time.Minute, scaled.GetExecTimeout())
+}
+
+func TestValidateTimeouts(t *testing.T) {
+	eth2 := types.EthereumVersion_Eth2
+	networks := map[string]*ctfconfig.EthereumNetworkConfig{
+		"SIMULATED_1": {EthereumChainConfig: &ctfconfig.EthereumChainConfig{SecondsPerSlot: 3}},
+		// 2 epochs of 4 slots of 12s
+		"SIMULATED_2": {EthereumVersion: &eth2, EthereumChainConfig: &ctfconfig.EthereumChainConfig{SecondsPerSlot: 12, SlotsPerEpoch: 4}},
+	}
+	for _, tc := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "scaled up", content: "ScaleFactor = 1.5"},
+		{name: "zero scale factor", content: "ScaleFactor = 0.0", err: "Timeouts.ScaleFactor must be positive, got 0.000000"},
+		{name: "zero commit timeout", content: "CommitTimeout = '0s'", err: "Timeouts.CommitTimeout must be positive, got 0s"},
+		{name: "zero bless timeout", content: "BlessTimeout = '0s'", err: "Timeouts.BlessTimeout must be positive, got 0s"},
+		{name: "zero exec timeout", content: "ExecTimeout = '0s'", err: "Timeouts.ExecTimeout must be positive, got 0s"},
+		{name: "zero setup timeout", content: "SetupTimeout = '0s'", err: "Timeouts.SetupTimeout must be positive, got 0s"},
+		{name: "zero overall timeout", content: "OverallTestTimeout = '0s'", err: "Timeouts.OverallTestTimeout must be positive, got 0s"},
+		{name: "overall shorter than the phases", content: "OverallTestTimeout = '20m'", err: "Timeouts.OverallTestTimeout (20m0s) must be at least the sum of the phase timeouts (25m0s)"},
+		{name: "commit shorter than finality", content: "CommitTimeout = '1m'", err: "Timeouts.CommitTimeout (1m0s) is too short for network SIMULATED_2 to reach finality, minimum is 1m38s"},
+		{name: "commit scaled to finality", content: "CommitTimeout = '1m'\nScaleFactor = 2.0"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var timeouts Timeouts
+			require.NoError(t, toml.Unmarshal([]byte(tc.content), &timeouts))
+			err := timeouts.Validate(networks)
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestEstimateFinalityTime(t *testing.T) {
+	eth2 := types.EthereumVersion_Eth2
+	require.Zero(t, EstimateFinalityTime(nil))
+	require.Equal(t, 3*time.Second, EstimateFinalityTime(&ctfconfig.EthereumNetworkConfig{EthereumChainConfig: &ctfconfig.EthereumChainConfig{SecondsPerSlot: 3}}))
+	require.Equal(t, 96*time.Second, EstimateFinalityTime(&ctfconfig.EthereumNetworkConfig{
+		EthereumVersion:     &eth2,
+		EthereumChainConfig: &ctfconfig.EthereumChainConfig{SecondsPerSlot: 12, SlotsPerEpoch: 4},
+	}))
+}
@@ -0,0 +1,117 @@
+package ccip
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	ADMIN_MODE_PRE_REGISTERED         = "preRegistered"
+	ADMIN_MODE_SELF_SERVE_DURING_TEST = "selfServeDuringTest"
+	ADMIN_MODE_UNREGISTERED           = "unregistered"
+
+	REGISTRATION_METHOD_REGISTRY_MODULE = "registryModule"
+	REGISTRATION_METHOD_OWNER           = "owner"
+)
+
+// TokenAdminConfig describes how the token is onboarded through the token admin registry.
+type TokenAdminConfig struct {
+	AdminMode *string `toml:",omitempty"`
+	// AdminKeyIndex is the index of the genesis-funded account acting as token admin
+	AdminKeyIndex *int `toml:",omitempty"`
+	// RegistrationMethod is either "registryModule" or "owner", defaults to registryModule
+	RegistrationMethod *string `toml:",omitempty"`
+}
+
+// TokenAdminStep is what the test orchestrator has to do to onboard a single token.
+type TokenAdminStep struct {
+	Token              string
+	AdminMode          string
+	AdminKeyIndex      int
+	RegistrationMethod string
+	// RegisterBeforeTraffic is true when registration happens during environment setup
+	RegisterBeforeTraffic bool
+}
+
+func (t *TokenAdminConfig) GetAdminMode() string {
+	if t == nil || pointer.GetString(t.AdminMode) == "" {
+		return ADMIN_MODE_PRE_REGISTERED
+	}
+	return *t.AdminMode
+}
+
+func (t *TokenAdminConfig) GetAdminKeyIndex() int {
+	if t == nil {
+		return 0
+	}
+	return pointer.GetInt(t.AdminKeyIndex)
+}
+
+func (t *TokenAdminConfig) GetRegistrationMethod() string {
+	if t == nil || pointer.GetString(t.RegistrationMethod) == "" {
+		return REGISTRATION_METHOD_REGISTRY_MODULE
+	}
+	return *t.RegistrationMethod
+}
+
+func (t *TokenAdminConfig) Validate(symbol string, fundedAccounts int) error {
+	switch t.GetAdminMode() {
+	case ADMIN_MODE_PRE_REGISTERED, ADMIN_MODE_SELF_SERVE_DURING_TEST, ADMIN_MODE_UNREGISTERED:
+	default:
+		return fmt.Errorf("token %s: invalid TokenAdmin.AdminMode %q", symbol, t.GetAdminMode())
+	}
+	switch t.GetRegistrationMethod() {
+	case REGISTRATION_METHOD_REGISTRY_MODULE, REGISTRATION_METHOD_OWNER:
+	default:
+		return fmt.Errorf("token %s: invalid TokenAdmin.RegistrationMethod %q", symbol, t.GetRegistrationMethod())
+	}
+	if idx := t.GetAdminKeyIndex(); idx < 0 || (fundedAccounts > 0 && idx >= fundedAccounts) {
+		return fmt.Errorf("token %s: TokenAdmin.AdminKeyIndex %d is out of range, %d genesis accounts are funded", symbol, idx, fundedAccounts)
+	}
+	return nil
+}
+
+// GetTokenAdminPlan returns the token onboarding steps described by the config, ordered by token symbol.
+// Tokens left unregistered are included so the orchestrator can assert registration never happens.
+func (o *Config) GetTokenAdminPlan() []TokenAdminStep {
+	symbols := make([]string, 0, len(o.Tokens))
+	for symbol := range o.Tokens {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	plan := make([]TokenAdminStep, 0, len(symbols))
+	for _, symbol := range symbols {
+		var admin *TokenAdminConfig
+		if o.Tokens[symbol] != nil {
+			admin = o.Tokens[symbol].TokenAdmin
+		}
+		plan = append(plan, TokenAdminStep{
+			Token:                 symbol,
+			AdminMode:             admin.GetAdminMode(),
+			AdminKeyIndex:         admin.GetAdminKeyIndex(),
+			RegistrationMethod:    admin.GetRegistrationMethod(),
+			RegisterBeforeTraffic: admin.GetAdminMode() == ADMIN_MODE_PRE_REGISTERED,
+		})
+	}
+	return plan
+}
+
+// fundedGenesisAccounts returns the smallest number of genesis-funded addresses across private networks,
+// since the admin key must be funded on every chain.
+func (o *Config) fundedGenesisAccounts() int {
+	funded := -1
+	for _, network := range o.PrivateEthereumNetworks {
+		if network == nil || network.EthereumChainConfig == nil {
+			continue
+		}
+		if n := len(network.EthereumChainConfig.AddressesToFund); funded < 0 || n < funded {
+			funded = n
+		}
+	}
+	if funded < 0 {
+		return 0
+	}
+	return funded
+}
@@ -0,0 +1,57 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenAdminConfigDefaults(t *testing.T) {
+	var admin *TokenAdminConfig
+	require.Equal(t, ADMIN_MODE_PRE_REGISTERED, admin.GetAdminMode())
+	require.Zero(t, admin.GetAdminKeyIndex())
+	require.Equal(t, REGISTRATION_METHOD_REGISTRY_MODULE, admin.GetRegistrationMethod())
+	require.NoError(t, (&TokenAdminConfig{}).Validate("LINK", 0))
+}
+
+func TestValidateTokenAdminConfig(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		content        string
+		fundedAccounts int
+		err            string
+	}{
+		{name: "self serve by owner", content: "AdminMode = 'selfServeDuringTest'\nRegistrationMethod = 'owner'"},
+		{name: "unregistered", content: "AdminMode = 'unregistered'"},
+		{name: "unknown admin mode", content: "AdminMode = 'later'", err: `token LINK: invalid TokenAdmin.AdminMode "later"`},
+		{name: "unknown registration method", content: "RegistrationMethod = 'proposal'", err: `token LINK: invalid TokenAdmin.RegistrationMethod "proposal"`},
+		{name: "negative admin key index", content: "AdminKeyIndex = -1", err: "token LINK: TokenAdmin.AdminKeyIndex -1 is out of range, 0 genesis accounts are funded"},
+		{name: "last funded account", content: "AdminKeyIndex = 2", fundedAccounts: 3},
+		{name: "admin key index past the funded accounts", content: "AdminKeyIndex = 3", fundedAccounts: 3, err: "token LINK: TokenAdmin.AdminKeyIndex 3 is out of range, 3 genesis accounts are funded"},
+		{name: "any index without private networks", content: "AdminKeyIndex = 10"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var admin TokenAdminConfig
+			require.NoError(t, toml.Unmarshal([]byte(tc.content), &admin))
+			err := admin.Validate("LINK", tc.fundedAccounts)
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestGetTokenAdminPlan(t *testing.T) {
+	cfg := &Config{Tokens: map[string]*TokenConfig{
+		"WETH": {TokenAdmin: &TokenAdminConfig{AdminMode: pointer.ToString(ADMIN_MODE_SELF_SERVE_DURING_TEST), AdminKeyIndex: pointer.ToInt(1)}},
+		"LINK": nil,
+	}}
+	require.Equal(t, []TokenAdminStep{
+		{Token: "LINK", AdminMode: ADMIN_MODE_PRE_REGISTERED, RegistrationMethod: REGISTRATION_METHOD_REGISTRY_MODULE, RegisterBeforeTraffic: true},
+		{Token: "WETH", AdminMode: ADMIN_MODE_SELF_SERVE_DURING_TEST, AdminKeyIndex: 1, RegistrationMethod: REGISTRATION_METHOD_REGISTRY_MODULE},
+	}, cfg.GetTokenAdminPlan())
+}
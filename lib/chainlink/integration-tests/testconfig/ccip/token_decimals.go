@@ -0,0 +1,179 @@
+package ccip
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	// ROUNDING_MODE_TRUNCATE drops the digits the dest chain has no decimals for
+	ROUNDING_MODE_TRUNCATE = "truncate"
+	// ROUNDING_MODE_REJECT fails amounts that don't convert exactly
+	ROUNDING_MODE_REJECT = "reject"
+
+	DEFAULT_ROUNDING_MODE = ROUNDING_MODE_REJECT
+)
+
+var roundingModes = []string{ROUNDING_MODE_TRUNCATE, ROUNDING_MODE_REJECT}
+
+func (t *TokenConfig) GetRoundingMode() string {
+	if t == nil || pointer.GetString(t.RoundingMode) == "" {
+		return DEFAULT_ROUNDING_MODE
+	}
+	return *t.RoundingMode
+}
+
+// GetTokenDecimals returns the decimals of the token on the chain, DecimalsPerChain if set for it and
+// Decimals otherwise.
+func (o *Config) GetTokenDecimals(symbol string, selector uint64) (uint8, error) {
+	token, ok := o.Tokens[symbol]
+	if !ok {
+		return 0, withKind(ErrTokenNotConfigured, fmt.Errorf("token %s is not configured in Tokens", symbol))
+	}
+	if token != nil {
+		for chain, decimals := range token.DecimalsPerChain {
+			if resolved, err := o.ResolveChainSelector(chain); err == nil && resolved == selector {
+				return decimals, nil
+			}
+		}
+	}
+	return token.GetDecimals(), nil
+}
+
+// NormalizeAmount converts an amount of the token in its smallest unit on fromChain to the smallest unit
+// on toChain. Amounts with more precision than toChain has decimals for are truncated or rejected,
+// depending on RoundingMode.
+func (o *Config) NormalizeAmount(token string, fromChain, toChain uint64, amount *big.Int) (*big.Int, error) {
+	from, err := o.GetTokenDecimals(token, fromChain)
+	if err != nil {
+		return nil, err
+	}
+	to, err := o.GetTokenDecimals(token, toChain)
+	if err != nil {
+		return nil, err
+	}
+	if to >= from {
+		return new(big.Int).Mul(amount, pow10(to-from)), nil
+	}
+	converted, remainder := new(big.Int).QuoRem(amount, pow10(from-to), new(big.Int))
+	if remainder.Sign() != 0 && o.Tokens[token].GetRoundingMode() == ROUNDING_MODE_REJECT {
+		return nil, fmt.Errorf("amount %s of token %s has %d decimals on %s, which don't convert exactly to its %d decimals on %s",
+			amount, token, from, chainName(fromChain), to, chainName(toChain))
+	}
+	return converted, nil
+}
+
+func pow10(n uint8) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// TokenTransfer is a token a message carries, with the amount sent and the amount the balance of the
+// receiver is expected to grow by.
+type TokenTransfer struct {
+	Symbol     string
+	Amount     *big.Int
+	DestAmount *big.Int
+}
+
+// TokenTransfers returns the transfers of the tokens of a composed message, LoadProfile.TokenAmountPerMessage
+// of each, converted to the decimals of the token on the dest chain.
+func (o *Config) TokenTransfers(lane ResolvedLane, msg ComposedMessage) ([]TokenTransfer, error) {
+	transfers := make([]TokenTransfer, 0, len(msg.Tokens))
+	for _, symbol := range msg.Tokens {
+		amount := o.LoadProfile.GetTokenAmountPerMessage()
+		destAmount, err := o.NormalizeAmount(symbol, lane.SourceSelector, lane.DestSelector, amount)
+		if err != nil {
+			return nil, fieldError("Tokens."+symbol, err)
+		}
+		transfers = append(transfers, TokenTransfer{Symbol: symbol, Amount: amount, DestAmount: destAmount})
+	}
+	return transfers, nil
+}
+
+// tokenChains returns the chains the token is deployed on by name, every private network and the chains
+// it is given liquidity on.
+func (o *Config) tokenChains(token *TokenConfig) map[uint64]string {
+	chains := make(map[uint64]string)
+	for name := range o.PrivateEthereumNetworks {
+		if selector, err := o.ResolveChainSelector(name); err == nil {
+			chains[selector] = name
+		}
+	}
+	for ref := range token.InitialLiquidity {
+		if selector, err := o.ResolveChainSelector(ref); err == nil {
+			if _, ok := chains[selector]; !ok {
+				chains[selector] = ref
+			}
+		}
+	}
+	return chains
+}
+
+// validateTokenDecimals checks that DecimalsPerChain only names chains the token is deployed on, and
+// that the transfer amount survives the conversion on every lane between them.
+func (o *Config) validateTokenDecimals() error {
+	symbols := make([]string, 0, len(o.Tokens))
+	for symbol := range o.Tokens {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	amount := o.LoadProfile.GetTokenAmountPerMessage()
+	for _, symbol := range symbols {
+		token := o.Tokens[symbol]
+		if token == nil {
+			continue
+		}
+		if mode := pointer.GetString(token.RoundingMode); mode != "" && !containsString(roundingModes, mode) {
+			return fmt.Errorf("Tokens.%s.RoundingMode must be one of %v, got %q", symbol, roundingModes, mode)
+		}
+		chains := o.tokenChains(token)
+		refs := make([]string, 0, len(token.DecimalsPerChain))
+		for ref := range token.DecimalsPerChain {
+			refs = append(refs, ref)
+		}
+		sort.Strings(refs)
+		for _, ref := range refs {
+			field := "Tokens." + symbol + ".DecimalsPerChain." + ref
+			selector, err := o.ResolveChainSelector(ref)
+			if err != nil {
+				return fieldError(field, err)
+			}
+			if _, ok := chains[selector]; !ok {
+				return fmt.Errorf("%s: token %s is not deployed on %s, it's neither a private network nor given InitialLiquidity", field, symbol, ref)
+			}
+			if decimals := token.DecimalsPerChain[ref]; decimals > 36 {
+				return fmt.Errorf("%s must be <= 36, got %d", field, decimals)
+			}
+		}
+		if len(token.DecimalsPerChain) == 0 || amount.Sign() == 0 {
+			continue
+		}
+		selectors := make([]uint64, 0, len(chains))
+		for selector := range chains {
+			selectors = append(selectors, selector)
+		}
+		sort.Slice(selectors, func(i, j int) bool { return chains[selectors[i]] < chains[selectors[j]] })
+		for _, source := range selectors {
+			for _, dest := range selectors {
+				if source == dest {
+					continue
+				}
+				lane := LaneKey(chains[source], chains[dest])
+				converted, err := o.NormalizeAmount(symbol, source, dest, amount)
+				if err != nil {
+					return fmt.Errorf("LoadProfile.TokenAmountPerMessage can't be transferred on lane %s: %w", lane, err)
+				}
+				if converted.Sign() == 0 {
+					from, _ := o.GetTokenDecimals(symbol, source)
+					to, _ := o.GetTokenDecimals(symbol, dest)
+					return fmt.Errorf("LoadProfile.TokenAmountPerMessage %s of token %s becomes 0 on lane %s, it has %d decimals on %s and %d on %s",
+						amount, symbol, lane, from, chains[source], to, chains[dest])
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package ccip
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const tokenDecimalsTOML = `
+[PrivateEthereumNetworks.SIMULATED_1.EthereumChainConfig]
+chain_id = 1337
+
+[PrivateEthereumNetworks.SIMULATED_2.EthereumChainConfig]
+chain_id = 2337
+
+[LoadProfile]
+TokenAmountPerMessage = 1000000000000000000
+
+[Tokens.USDX]
+Decimals = 18
+DecimalsPerChain = { SIMULATED_2 = 6 }
+`
+
+func TestNormalizeAmount(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(tokenDecimalsTOML), &cfg))
+	require.NoError(t, cfg.validateTokenDecimals())
+	eighteen, six := uint64(3379446385462418246), uint64(12922642891491394802)
+
+	amount, err := cfg.NormalizeAmount("USDX", eighteen, six, big.NewInt(1_500_000_000_000_000_000))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1_500_000), amount)
+	amount, err = cfg.NormalizeAmount("USDX", six, eighteen, big.NewInt(1_500_000))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1_500_000_000_000_000_000), amount)
+
+	// rejected unless set to truncate
+	_, err = cfg.NormalizeAmount("USDX", eighteen, six, big.NewInt(1_500_000_000_000_000_001))
+	require.ErrorContains(t, err, "amount 1500000000000000001 of token USDX has 18 decimals")
+	cfg.Tokens["USDX"].RoundingMode = pointer.ToString(ROUNDING_MODE_TRUNCATE)
+	amount, err = cfg.NormalizeAmount("USDX", eighteen, six, big.NewInt(1_500_000_000_000_000_001))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1_500_000), amount)
+
+	_, err = cfg.NormalizeAmount("UNKNOWN", eighteen, six, big.NewInt(1))
+	require.ErrorIs(t, err, ErrTokenNotConfigured)
+
+	transfers, err := cfg.TokenTransfers(ResolvedLane{SourceSelector: eighteen, DestSelector: six}, ComposedMessage{Tokens: []string{"USDX"}})
+	require.NoError(t, err)
+	require.Equal(t, []TokenTransfer{{Symbol: "USDX", Amount: big.NewInt(1_000_000_000_000_000_000), DestAmount: big.NewInt(1_000_000)}}, transfers)
+}
+
+func TestValidateTokenDecimals(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		extra string
+		err   string
+	}{
+		{
+			name:  "chain without the token",
+			extra: "[Tokens.LINK.DecimalsPerChain]\nethereum-testnet-sepolia = 18",
+			err:   "Tokens.LINK.DecimalsPerChain.ethereum-testnet-sepolia: token LINK is not deployed on ethereum-testnet-sepolia",
+		},
+		{
+			name:  "amount converted to zero",
+			extra: "[Tokens.LINK]\nRoundingMode = 'truncate'\nDecimalsPerChain = { SIMULATED_1 = 30, SIMULATED_2 = 6 }",
+			err:   "LoadProfile.TokenAmountPerMessage 1000000000000000000 of token LINK becomes 0 on lane SIMULATED_1->SIMULATED_2, it has 30 decimals on SIMULATED_1 and 6 on SIMULATED_2",
+		},
+		{
+			name:  "inexact amount rejected",
+			extra: "[Tokens.LINK]\nDecimalsPerChain = { SIMULATED_1 = 30, SIMULATED_2 = 6 }",
+			err:   "LoadProfile.TokenAmountPerMessage can't be transferred on lane SIMULATED_1->SIMULATED_2",
+		},
+		{name: "unknown rounding mode", extra: "[Tokens.LINK]\nRoundingMode = 'round'", err: `Tokens.LINK.RoundingMode must be one of [truncate reject], got "round"`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte(tokenDecimalsTOML+tc.extra), &cfg))
+			require.ErrorContains(t, cfg.validateTokenDecimals(), tc.err)
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package ccip
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/AlekSi/pointer"
+)
+
+const DEFAULT_SEND_AFTER_ONBOARDING = 1
+
+// ScheduledToken is a token deployed and registered on lanes that are already live, At into the test.
+type ScheduledToken struct {
+	// Symbol must not be one of Tokens, the token is deployed during the test
+	Symbol *string     `toml:",omitempty"`
+	Token  TokenConfig `toml:",omitempty"`
+	At     *Duration   `toml:",omitempty"`
+	// Lanes are the "source->dest" lanes the pools of the token are registered on
+	Lanes []string `toml:",omitempty"`
+	// SendAfterOnboarding is the number of transfers of the token sent on each lane to verify it
+	SendAfterOnboarding *int `toml:",omitempty"`
+}
+
+func (s *ScheduledToken) GetSendAfterOnboarding() int {
+	if s == nil || s.SendAfterOnboarding == nil {
+		return DEFAULT_SEND_AFTER_ONBOARDING
+	}
+	return *s.SendAfterOnboarding
+}
+
+// TokenOnboarding is a resolved ScheduledToken.
+type TokenOnboarding struct {
+	Symbol                string
+	Token                 *TokenConfig
+	At                    time.Duration
+	Lanes                 []ResolvedLane
+	VerificationTransfers int
+}
+
+// Chains returns the chains of the lanes of the token, in the order of the lanes.
+func (t TokenOnboarding) Chains() []uint64 {
+	var chains []uint64
+	seen := make(map[uint64]bool)
+	for _, lane := range t.Lanes {
+		for _, selector := range []uint64{lane.SourceSelector, lane.DestSelector} {
+			if !seen[selector] {
+				seen[selector] = true
+				chains = append(chains, selector)
+			}
+		}
+	}
+	return chains
+}
+
+// TokenOnboarder deploys tokens and their pools on live lanes.
+type TokenOnboarder interface {
+	DeployToken(ctx context.Context, symbol string, token *TokenConfig, chains []uint64) error
+	RegisterTokenPools(ctx context.Context, symbol string, lanes []ResolvedLane) error
+	ConfigureTokenPools(ctx context.Context, symbol string, lanes []ResolvedLane) error
+	SendTokenTransfer(ctx context.Context, symbol string, lane ResolvedLane) error
+}
+
+// GetMidTestTokenOnboarding resolves MidTestTokenOnboarding, sorted by At.
+func (o *Config) GetMidTestTokenOnboarding() ([]TokenOnboarding, error) {
+	onboardings := make([]TokenOnboarding, 0, len(o.MidTestTokenOnboarding))
+	for i := range o.MidTestTokenOnboarding {
+		scheduled := &o.MidTestTokenOnboarding[i]
+		onboarding := TokenOnboarding{
+			Symbol:                pointer.GetString(scheduled.Symbol),
+			Token:                 &scheduled.Token,
+			VerificationTransfers: scheduled.GetSendAfterOnboarding(),
+		}
+		if scheduled.At != nil {
+			onboarding.At = scheduled.At.Duration
+		}
+		field := fmt.Sprintf("MidTestTokenOnboarding[%d].Lanes", i)
+		for _, laneKey := range scheduled.Lanes {
+			source, dest, err := ParseLaneKey(laneKey)
+			if err != nil {
+				return nil, fieldError(field, err)
+			}
+			lane := ResolvedLane{Source: source, Dest: dest}
+			if lane.SourceSelector, err = o.ResolveChainSelector(source); err != nil {
+				return nil, fieldError(field, err)
+			}
+			if lane.DestSelector, err = o.ResolveChainSelector(dest); err != nil {
+				return nil, fieldError(field, err)
+			}
+			onboarding.Lanes = append(onboarding.Lanes, lane)
+		}
+		onboardings = append(onboardings, onboarding)
+	}
+	sort.SliceStable(onboardings, func(i, j int) bool { return onboardings[i].At < onboardings[j].At })
+	return onboardings, nil
+}
+
+// OnboardTokensMidTest onboards each scheduled token At after the call: it deploys the token, registers
+// and configures its pools on the lanes, and sends the verification transfers on each lane.
+func (o *Config) OnboardTokensMidTest(ctx context.Context, onboarder TokenOnboarder) error {
+	onboardings, err := o.GetMidTestTokenOnboarding()
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	for _, t := range onboardings {
+		if err := sleepUntil(ctx, start.Add(t.At)); err != nil {
+			return err
+		}
+		if err := onboarder.DeployToken(ctx, t.Symbol, t.Token, t.Chains()); err != nil {
+			return fmt.Errorf("deploy token %s: %w", t.Symbol, err)
+		}
+		if err := onboarder.RegisterTokenPools(ctx, t.Symbol, t.Lanes); err != nil {
+			return fmt.Errorf("register pools of token %s: %w", t.Symbol, err)
+		}
+		if err := onboarder.ConfigureTokenPools(ctx, t.Symbol, t.Lanes); err != nil {
+			return fmt.Errorf("configure pools of token %s: %w", t.Symbol, err)
+		}
+		for _, lane := range t.Lanes {
+			for i := 0; i < t.VerificationTransfers; i++ {
+				if err := onboarder.SendTokenTransfer(ctx, t.Symbol, lane); err != nil {
+					return fmt.Errorf("send verification transfer of token %s on lane %s: %w", t.Symbol, lane.Key(), err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (o *Config) validateMidTestTokenOnboarding() error {
+	if len(o.MidTestTokenOnboarding) == 0 {
+		return nil
+	}
+	onboardings, err := o.GetMidTestTokenOnboarding()
+	if err != nil {
+		return err
+	}
+	symbols := make(map[string]bool)
+	for i, scheduled := range o.MidTestTokenOnboarding {
+		field := fmt.Sprintf("MidTestTokenOnboarding[%d]", i)
+		symbol := pointer.GetString(scheduled.Symbol)
+		if symbol == "" {
+			return fmt.Errorf("%s.Symbol must be set", field)
+		}
+		if _, ok := o.Tokens[symbol]; ok || (o.USDCMock.IsEnabled() && o.USDCMock.GetTokenSymbol() == symbol) {
+			return fmt.Errorf("%s.Symbol %s collides with a token deployed before the test", field, symbol)
+		}
+		if symbols[symbol] {
+			return fmt.Errorf("%s.Symbol %s is onboarded more than once", field, symbol)
+		}
+		symbols[symbol] = true
+		if err := scheduled.Token.Validate(symbol, o.fundedGenesisAccounts()); err != nil {
+			return fieldError(field+".Token", err)
+		}
+		if scheduled.At == nil {
+			return fmt.Errorf("%s.At must be set", field)
+		}
+		if at, duration := scheduled.At.Duration, o.LoadProfile.GetTestDuration(); at < 0 || at >= duration {
+			return fmt.Errorf("%s.At (%s) must be within LoadProfile.TestDuration (%s)", field, at, duration)
+		}
+		if len(scheduled.Lanes) == 0 {
+			return fmt.Errorf("%s.Lanes must not be empty", field)
+		}
+		if scheduled.SendAfterOnboarding != nil && *scheduled.SendAfterOnboarding < 0 {
+			return fmt.Errorf("%s.SendAfterOnboarding cannot be negative", field)
+		}
+	}
+	for _, onboarding := range onboardings {
+		for _, lane := range onboarding.Lanes {
+			if lane.SourceSelector == lane.DestSelector {
+				return fmt.Errorf("token %s: lane %s sends from and to the same chain", onboarding.Symbol, lane.Key())
+			}
+		}
+	}
+	return nil
+}
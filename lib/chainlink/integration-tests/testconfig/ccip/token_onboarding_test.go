@@ -0,0 +1,98 @@
+package ccip
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const tokenOnboardingTOML = chainHaltNetworks + `
+[LoadProfile]
+TestDuration = '1h'
+
+[Tokens.LINK]
+
+[[MidTestTokenOnboarding]]
+Symbol = 'LATE'
+At = '10ms'
+Lanes = ['SIMULATED_1->SIMULATED_2', 'SIMULATED_2->SIMULATED_1']
+SendAfterOnboarding = 2
+
+[MidTestTokenOnboarding.Token]
+Decimals = 6
+PoolType = 'lockRelease'
+
+[[MidTestTokenOnboarding]]
+Symbol = 'EARLY'
+At = '0s'
+Lanes = ['SIMULATED_1->SIMULATED_2']
+`
+
+type fakeTokenOnboarder struct {
+	calls []string
+}
+
+func (f *fakeTokenOnboarder) DeployToken(_ context.Context, symbol string, token *TokenConfig, chains []uint64) error {
+	f.calls = append(f.calls, fmt.Sprintf("deploy %s %d decimals on %d chains", symbol, token.GetDecimals(), len(chains)))
+	return nil
+}
+
+func (f *fakeTokenOnboarder) RegisterTokenPools(_ context.Context, symbol string, lanes []ResolvedLane) error {
+	f.calls = append(f.calls, fmt.Sprintf("register %s on %d lanes", symbol, len(lanes)))
+	return nil
+}
+
+func (f *fakeTokenOnboarder) ConfigureTokenPools(_ context.Context, symbol string, lanes []ResolvedLane) error {
+	f.calls = append(f.calls, fmt.Sprintf("configure %s on %d lanes", symbol, len(lanes)))
+	return nil
+}
+
+func (f *fakeTokenOnboarder) SendTokenTransfer(_ context.Context, symbol string, lane ResolvedLane) error {
+	f.calls = append(f.calls, fmt.Sprintf("send %s on %s", symbol, lane.Key()))
+	return nil
+}
+
+func TestMidTestTokenOnboarding(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(tokenOnboardingTOML), &cfg))
+	require.NoError(t, cfg.validateMidTestTokenOnboarding())
+
+	onboarder := &fakeTokenOnboarder{}
+	require.NoError(t, cfg.OnboardTokensMidTest(context.Background(), onboarder))
+	require.Equal(t, []string{
+		"deploy EARLY 18 decimals on 2 chains",
+		"register EARLY on 1 lanes",
+		"configure EARLY on 1 lanes",
+		"send EARLY on SIMULATED_1->SIMULATED_2",
+		"deploy LATE 6 decimals on 2 chains",
+		"register LATE on 2 lanes",
+		"configure LATE on 2 lanes",
+		"send LATE on SIMULATED_1->SIMULATED_2",
+		"send LATE on SIMULATED_1->SIMULATED_2",
+		"send LATE on SIMULATED_2->SIMULATED_1",
+		"send LATE on SIMULATED_2->SIMULATED_1",
+	}, onboarder.calls)
+}
+
+func TestValidateMidTestTokenOnboarding(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "collides with a token", content: "Symbol = 'LINK'\nAt = '1m'\nLanes = ['SIMULATED_1->SIMULATED_2']", err: "MidTestTokenOnboarding[0].Symbol LINK collides with a token deployed before the test"},
+		{name: "after the test", content: "Symbol = 'NEW'\nAt = '1h'\nLanes = ['SIMULATED_1->SIMULATED_2']", err: "MidTestTokenOnboarding[0].At (1h0m0s) must be within LoadProfile.TestDuration (1h0m0s)"},
+		{name: "unknown chain", content: "Symbol = 'NEW'\nAt = '1m'\nLanes = ['SIMULATED_1->nope']", err: "MidTestTokenOnboarding[0].Lanes: "},
+		{name: "same chain", content: "Symbol = 'NEW'\nAt = '1m'\nLanes = ['SIMULATED_1->3379446385462418246']", err: "token NEW: lane SIMULATED_1->3379446385462418246 sends from and to the same chain"},
+		{name: "invalid token", content: "Symbol = 'NEW'\nAt = '1m'\nLanes = ['SIMULATED_1->SIMULATED_2']\n[MidTestTokenOnboarding.Token]\nPoolType = 'nope'", err: `MidTestTokenOnboarding[0].Token: token NEW: invalid PoolType "nope"`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte(chainHaltNetworks+"[LoadProfile]\nTestDuration = '1h'\n[Tokens.LINK]\n[[MidTestTokenOnboarding]]\n"+tc.content), &cfg))
+			require.ErrorContains(t, cfg.validateMidTestTokenOnboarding(), tc.err)
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package ccip
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/AlekSi/pointer"
+)
+
+// TokenConfig describes a token transferred on the lanes under test.
+// Tokens are keyed by symbol in Config.Tokens.
+type TokenConfig struct {
+	Decimals *uint8 `toml:",omitempty"`
+	// PoolType is one of "burnMint" or "lockRelease", defaults to burnMint
+	PoolType *string `toml:",omitempty"`
+	// CCTP marks the token as CCTP-backed, which requires a USDC token pool
+	CCTP *bool `toml:",omitempty"`
+	// InitialLiquidity is provided to the token pool on each chain before traffic starts, keyed by chain
+	InitialLiquidity map[string]*big.Int `toml:",omitempty"`
+	TokenAdmin       *TokenAdminConfig   `toml:",omitempty"`
+	// PoolTypePerLane overrides PoolType on lanes keyed by "source->dest"
+	PoolTypePerLane map[string]string `toml:",omitempty"`
+	// GrantMintRole grants the burn/mint pools mint and burn rights on the token, true unless set
+	GrantMintRole *bool `toml:",omitempty"`
+	// DecimalsPerChain overrides Decimals on the chains it's keyed by
+	DecimalsPerChain map[string]uint8 `toml:",omitempty"`
+	// RoundingMode is "truncate" or "reject" for amounts that don't convert exactly between decimals,
+	// defaults to reject
+	RoundingMode *string `toml:",omitempty"`
+}
+
+const (
+	DEFAULT_TOKEN_DECIMALS uint8 = 18
+
+	POOL_TYPE_BURN_MINT    = "burnMint"
+	POOL_TYPE_LOCK_RELEASE = "lockRelease"
+	POOL_TYPE_USDC         = "usdc"
+)
+
+func (t *TokenConfig) GetDecimals() uint8 {
+	if t == nil || t.Decimals == nil {
+		return DEFAULT_TOKEN_DECIMALS
+	}
+	return *t.Decimals
+}
+
+func (t *TokenConfig) IsMintRoleGranted() bool {
+	return t == nil || t.GrantMintRole == nil || *t.GrantMintRole
+}
+
+func (t *TokenConfig) Validate(symbol string, fundedAccounts int) error {
+	if symbol == "" {
+		return fmt.Errorf("token symbol cannot be empty")
+	}
+	if t == nil {
+		return fmt.Errorf("token %s has no configuration", symbol)
+	}
+	if t.Decimals != nil && *t.Decimals > 36 {
+		return fmt.Errorf("token %s: decimals must be <= 36, got %d", symbol, *t.Decimals)
+	}
+	if t.PoolType != nil {
+		switch *t.PoolType {
+		case POOL_TYPE_BURN_MINT, POOL_TYPE_LOCK_RELEASE:
+		default:
+			return fmt.Errorf("token %s: invalid PoolType %q, expected one of %s, %s", symbol, *t.PoolType, POOL_TYPE_BURN_MINT, POOL_TYPE_LOCK_RELEASE)
+		}
+	}
+	for laneKey, poolType := range t.PoolTypePerLane {
+		if _, _, err := ParseLaneKey(laneKey); err != nil {
+			return fieldError("Tokens."+symbol+".PoolTypePerLane", err)
+		}
+		switch poolType {
+		case POOL_TYPE_BURN_MINT, POOL_TYPE_LOCK_RELEASE:
+		default:
+			return fmt.Errorf("token %s: invalid PoolTypePerLane %q on %s, expected one of %s, %s", symbol, poolType, laneKey, POOL_TYPE_BURN_MINT, POOL_TYPE_LOCK_RELEASE)
+		}
+	}
+	for chain, amount := range t.InitialLiquidity {
+		if amount == nil || amount.Sign() < 0 {
+			return fmt.Errorf("token %s: InitialLiquidity on %s must be a non-negative amount", symbol, chain)
+		}
+	}
+	if t.TokenAdmin != nil {
+		if err := t.TokenAdmin.Validate(symbol, fundedAccounts); err != nil {
+			return err
+		}
+	}
+	// pools of tokens onboarded during the test aren't registered yet when liquidity is provided during setup
+	if t.TokenAdmin.GetAdminMode() == ADMIN_MODE_SELF_SERVE_DURING_TEST && len(t.InitialLiquidity) > 0 {
+		return fmt.Errorf("token %s: InitialLiquidity cannot be set for tokens onboarded with AdminMode %q", symbol, ADMIN_MODE_SELF_SERVE_DURING_TEST)
+	}
+	return nil
+}
+
+// IsCCTPToken returns true if the token is CCTP-backed, either explicitly or because the USDC attestation mock is enabled for it.
+func (o *Config) IsCCTPToken(symbol string) bool {
+	if token, ok := o.Tokens[symbol]; ok && token != nil && pointer.GetBool(token.CCTP) {
+		return true
+	}
+	return o.USDCMock.IsEnabled() && o.USDCMock.GetTokenSymbol() == symbol
+}
+
+// GetTokenPoolType returns the type of token pool to deploy for the token.
+func (o *Config) GetTokenPoolType(symbol string) (string, error) {
+	token, ok := o.Tokens[symbol]
+	if !ok {
+		return "", withKind(ErrTokenNotConfigured, fmt.Errorf("token %s is not configured in Tokens", symbol))
+	}
+	if o.IsCCTPToken(symbol) {
+		return POOL_TYPE_USDC, nil
+	}
+	if token == nil || token.PoolType == nil {
+		return POOL_TYPE_BURN_MINT, nil
+	}
+	return *token.PoolType, nil
+}
+
+// GetPoolType returns the type of token pool the token uses on the lane, PoolTypePerLane if one of its
+// keys matches the lane and GetTokenPoolType otherwise. CCTP tokens always use the USDC pool.
+func (o *Config) GetPoolType(token string, lane ResolvedLane) (string, error) {
+	poolType, err := o.GetTokenPoolType(token)
+	if err != nil || poolType == POOL_TYPE_USDC {
+		return poolType, err
+	}
+	if laneKey, ok := o.Tokens[token].poolTypeOverride(lane); ok {
+		return o.Tokens[token].PoolTypePerLane[laneKey], nil
+	}
+	return poolType, nil
+}
+
+// poolTypeOverride returns the PoolTypePerLane key matching the lane, see laneKeyFor.
+func (t *TokenConfig) poolTypeOverride(lane ResolvedLane) (string, bool) {
+	if t == nil {
+		return "", false
+	}
+	return laneKeyFor(lane, t.PoolTypePerLane)
+}
+
+// poolTypeField returns the path of the field the pool type of the token on the lane comes from.
+func (o *Config) poolTypeField(symbol string, lane ResolvedLane) string {
+	if o.IsCCTPToken(symbol) {
+		return "Tokens." + symbol + ".CCTP"
+	}
+	if laneKey, ok := o.Tokens[symbol].poolTypeOverride(lane); ok {
+		return "Tokens." + symbol + ".PoolTypePerLane." + laneKey
+	}
+	return "Tokens." + symbol + ".PoolType"
+}
+
+// privateNetworkLanes returns the lanes between every pair of private networks, sorted by name.
+func (o *Config) privateNetworkLanes() []ResolvedLane {
+	names := make([]string, 0, len(o.PrivateEthereumNetworks))
+	for name := range o.PrivateEthereumNetworks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var lanes []ResolvedLane
+	for _, source := range names {
+		for _, dest := range names {
+			// private networks without a chain id are reported by the rules checking them
+			sourceSelector, sourceErr := o.ResolveChainSelector(source)
+			destSelector, destErr := o.ResolveChainSelector(dest)
+			if source != dest && sourceErr == nil && destErr == nil {
+				lanes = append(lanes, ResolvedLane{Source: source, Dest: dest, SourceSelector: sourceSelector, DestSelector: destSelector})
+			}
+		}
+	}
+	return lanes
+}
+
+// poolTypeLanes returns the lanes the pool types are checked on, every pair of private networks and the
+// lanes of PoolTypePerLane. Lanes to live networks are only known with the networks of the test.
+func (o *Config) poolTypeLanes(token *TokenConfig) ([]ResolvedLane, error) {
+	lanes := o.privateNetworkLanes()
+	laneKeys := make([]string, 0, len(token.PoolTypePerLane))
+	for laneKey := range token.PoolTypePerLane {
+		laneKeys = append(laneKeys, laneKey)
+	}
+	sort.Strings(laneKeys)
+	for _, laneKey := range laneKeys {
+		source, dest, err := ParseLaneKey(laneKey)
+		if err != nil {
+			return nil, err
+		}
+		sourceSelector, err := o.ResolveChainSelector(source)
+		if err != nil {
+			return nil, err
+		}
+		destSelector, err := o.ResolveChainSelector(dest)
+		if err != nil {
+			return nil, err
+		}
+		lanes = append(lanes, ResolvedLane{Source: source, Dest: dest, SourceSelector: sourceSelector, DestSelector: destSelector})
+	}
+	return lanes, nil
+}
+
+// GetInitialLiquidity returns the InitialLiquidity of the token on the chain, nil if none is given.
+func (o *Config) GetInitialLiquidity(symbol string, selector uint64) *big.Int {
+	token := o.Tokens[symbol]
+	if token == nil {
+		return nil
+	}
+	for chain, amount := range token.InitialLiquidity {
+		if resolved, err := o.ResolveChainSelector(chain); err == nil && resolved == selector {
+			return amount
+		}
+	}
+	return nil
+}
+
+// hasLiquidityOn returns true if InitialLiquidity provides a positive amount on the chain.
+func (o *Config) hasLiquidityOn(token *TokenConfig, selector uint64) bool {
+	for chain, amount := range token.InitialLiquidity {
+		if resolved, err := o.ResolveChainSelector(chain); err == nil && resolved == selector && amount != nil && amount.Sign() > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// validatePoolTypes checks that lock/release lanes have liquidity to release on their destination and
+// burn/mint lanes have pools allowed to mint.
+func (o *Config) validatePoolTypes() error {
+	symbols := make([]string, 0, len(o.Tokens))
+	for symbol := range o.Tokens {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	for _, symbol := range symbols {
+		token := o.Tokens[symbol]
+		if token == nil {
+			continue
+		}
+		lanes, err := o.poolTypeLanes(token)
+		if err != nil {
+			return fieldError("Tokens."+symbol+".PoolTypePerLane", err)
+		}
+		for _, lane := range lanes {
+			poolType, err := o.GetPoolType(symbol, lane)
+			if err != nil {
+				return err
+			}
+			field := o.poolTypeField(symbol, lane)
+			switch {
+			case poolType == POOL_TYPE_LOCK_RELEASE && !o.hasLiquidityOn(token, lane.DestSelector):
+				return fmt.Errorf("%s makes %s a %s lane, but Tokens.%s.InitialLiquidity has no liquidity on %s to release",
+					field, lane.Key(), POOL_TYPE_LOCK_RELEASE, symbol, lane.Dest)
+			case poolType == POOL_TYPE_BURN_MINT && !token.IsMintRoleGranted():
+				return fmt.Errorf("%s makes %s a %s lane, but Tokens.%s.GrantMintRole is false",
+					field, lane.Key(), POOL_TYPE_BURN_MINT, symbol)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package ccip
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const poolTypeMatrixTOML = `
+[PrivateEthereumNetworks.SIMULATED_1.EthereumChainConfig]
+chain_id = 1337
+
+[PrivateEthereumNetworks.SIMULATED_2.EthereumChainConfig]
+chain_id = 2337
+
+[Tokens.TEST]
+PoolType = 'burnMint'
+
+[Tokens.TEST.PoolTypePerLane]
+'SIMULATED_1->SIMULATED_2' = 'lockRelease'
+
+[Tokens.TEST.InitialLiquidity]
+SIMULATED_2 = 1000
+`
+
+func TestGetPoolType(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(poolTypeMatrixTOML), &cfg))
+	require.NoError(t, cfg.validatePoolTypes())
+
+	forward := ResolvedLane{Source: "SIMULATED_1", Dest: "SIMULATED_2", SourceSelector: 3379446385462418246, DestSelector: 12922642891491394802}
+	backward := ResolvedLane{Source: "SIMULATED_2", Dest: "SIMULATED_1", SourceSelector: 12922642891491394802, DestSelector: 3379446385462418246}
+	poolType, err := cfg.GetPoolType("TEST", forward)
+	require.NoError(t, err)
+	require.Equal(t, POOL_TYPE_LOCK_RELEASE, poolType)
+	poolType, err = cfg.GetPoolType("TEST", backward)
+	require.NoError(t, err)
+	require.Equal(t, POOL_TYPE_BURN_MINT, poolType)
+	// the lane can be referenced by selector too
+	poolType, err = cfg.GetPoolType("TEST", ResolvedLane{Source: "geth-testnet", Dest: "geth-devnet-2", SourceSelector: 3379446385462418246, DestSelector: 12922642891491394802})
+	require.NoError(t, err)
+	require.Equal(t, POOL_TYPE_BURN_MINT, poolType)
+
+	_, err = cfg.GetPoolType("OTHER", forward)
+	require.ErrorIs(t, err, ErrTokenNotConfigured)
+}
+
+func TestGetInitialLiquidity(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(poolTypeMatrixTOML), &cfg))
+	require.Equal(t, big.NewInt(1000), cfg.GetInitialLiquidity("TEST", 12922642891491394802))
+	require.Nil(t, cfg.GetInitialLiquidity("TEST", 3379446385462418246))
+	require.Nil(t, cfg.GetInitialLiquidity("OTHER", 12922642891491394802))
+}
+
+func TestValidatePoolTypes(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		mutate func(*Config)
+		err    string
+	}{
+		{
+			name:   "lockRelease lane without liquidity on the destination",
+			mutate: func(cfg *Config) { cfg.Tokens["TEST"].InitialLiquidity = nil },
+			err:    "Tokens.TEST.PoolTypePerLane.SIMULATED_1->SIMULATED_2 makes SIMULATED_1->SIMULATED_2 a lockRelease lane, but Tokens.TEST.InitialLiquidity has no liquidity on SIMULATED_2 to release",
+		},
+		{
+			name:   "burnMint lane without mint rights",
+			mutate: func(cfg *Config) { cfg.Tokens["TEST"].GrantMintRole = new(bool) },
+			err:    "Tokens.TEST.PoolType makes SIMULATED_2->SIMULATED_1 a burnMint lane, but Tokens.TEST.GrantMintRole is false",
+		},
+		{
+			name: "every lane lockRelease without mint rights",
+			mutate: func(cfg *Config) {
+				cfg.Tokens["TEST"].PoolTypePerLane["SIMULATED_2->SIMULATED_1"] = POOL_TYPE_LOCK_RELEASE
+				cfg.Tokens["TEST"].InitialLiquidity["SIMULATED_1"] = cfg.Tokens["TEST"].InitialLiquidity["SIMULATED_2"]
+				cfg.Tokens["TEST"].GrantMintRole = new(bool)
+			},
+		},
+		{
+			name:   "unknown chain in the lane key",
+			mutate: func(cfg *Config) { cfg.Tokens["TEST"].PoolTypePerLane["SIMULATED_1->UNKNOWN"] = POOL_TYPE_BURN_MINT },
+			err:    "Tokens.TEST.PoolTypePerLane: chain UNKNOWN is neither a chain selector",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var cfg Config
+			require.NoError(t, toml.Unmarshal([]byte(poolTypeMatrixTOML), &cfg))
+			tc.mutate(&cfg)
+			err := cfg.validatePoolTypes()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestGetPoolTypeLaneKeyPrecedence(t *testing.T) {
+	// both keys match the lane, by name and by selector, the later in sorted order wins as for RateLimits
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(`
+[Tokens.TEST]
+PoolType = 'burnMint'
+
+[Tokens.TEST.PoolTypePerLane]
+'SIMULATED_1->SIMULATED_2' = 'lockRelease'
+'3379446385462418246->12922642891491394802' = 'burnMint'
+`), &cfg))
+	lane := ResolvedLane{Source: "SIMULATED_1", Dest: "SIMULATED_2", SourceSelector: 3379446385462418246, DestSelector: 12922642891491394802}
+	for i := 0; i < 20; i++ {
+		poolType, err := cfg.GetPoolType("TEST", lane)
+		require.NoError(t, err)
+		require.Equal(t, POOL_TYPE_LOCK_RELEASE, poolType)
+		require.Equal(t, "Tokens.TEST.PoolTypePerLane.SIMULATED_1->SIMULATED_2", cfg.poolTypeField("TEST", lane))
+	}
+}
@@ -0,0 +1,141 @@
+package ccip
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	DEFAULT_TRACING_SAMPLING_RATIO = 1.0
+	// NODE_TRACING_MODE_* mirror the modes accepted by the core node's [Tracing] block
+	NODE_TRACING_MODE_TLS         = "tls"
+	NODE_TRACING_MODE_UNENCRYPTED = "unencrypted"
+)
+
+// Tracing configures OTEL tracing for the test harness and, optionally, the node containers.
+type Tracing struct {
+	Enabled *bool `toml:",omitempty"`
+	// CollectorEndpoint is either host:port or a http(s) URL of an OTLP gRPC collector
+	CollectorEndpoint *string  `toml:",omitempty"`
+	SamplingRatio     *float64 `toml:",omitempty"`
+	// InjectIntoNodes enables the node's own [Tracing] block pointed at the same collector
+	InjectIntoNodes *bool `toml:",omitempty"`
+}
+
+// OTLPTraceExporterConfig is what the harness needs to build an otlptracegrpc exporter and sampler.
+type OTLPTraceExporterConfig struct {
+	Endpoint      string
+	Insecure      bool
+	SamplingRatio float64
+}
+
+// NodeTracingConfig holds the values of the core node's [Tracing] block.
+type NodeTracingConfig struct {
+	CollectorTarget string
+	NodeID          string
+	SamplingRatio   float64
+	Mode            string
+}
+
+func (t *Tracing) IsEnabled() bool {
+	return t != nil && pointer.GetBool(t.Enabled)
+}
+
+func (t *Tracing) GetSamplingRatio() float64 {
+	if t == nil || t.SamplingRatio == nil {
+		return DEFAULT_TRACING_SAMPLING_RATIO
+	}
+	return *t.SamplingRatio
+}
+
+func (t *Tracing) GetInjectIntoNodes() bool {
+	return t.IsEnabled() && pointer.GetBool(t.InjectIntoNodes)
+}
+
+// GetExporterConfig returns the exporter config for the harness, nil when tracing is disabled.
+func (t *Tracing) GetExporterConfig() (*OTLPTraceExporterConfig, error) {
+	if !t.IsEnabled() {
+		return nil, nil
+	}
+	endpoint, insecure, err := parseCollectorEndpoint(pointer.GetString(t.CollectorEndpoint))
+	if err != nil {
+		return nil, fieldError("Tracing.CollectorEndpoint", err)
+	}
+	return &OTLPTraceExporterConfig{
+		Endpoint:      endpoint,
+		Insecure:      insecure,
+		SamplingRatio: t.GetSamplingRatio(),
+	}, nil
+}
+
+// GetNodeTracingConfig returns the [Tracing] block for the named node, nil unless InjectIntoNodes is set.
+func (t *Tracing) GetNodeTracingConfig(nodeName string) (*NodeTracingConfig, error) {
+	if !t.GetInjectIntoNodes() {
+		return nil, nil
+	}
+	exporter, err := t.GetExporterConfig()
+	if err != nil {
+		return nil, err
+	}
+	mode := NODE_TRACING_MODE_TLS
+	if exporter.Insecure {
+		mode = NODE_TRACING_MODE_UNENCRYPTED
+	}
+	return &NodeTracingConfig{
+		CollectorTarget: exporter.Endpoint,
+		NodeID:          nodeName,
+		SamplingRatio:   exporter.SamplingRatio,
+		Mode:            mode,
+	}, nil
+}
+
+func (t *Tracing) Validate() error {
+	if t.SamplingRatio != nil && (*t.SamplingRatio <= 0 || *t.SamplingRatio > 1) {
+		return fmt.Errorf("Tracing.SamplingRatio must be in (0,1], got %f", *t.SamplingRatio)
+	}
+	if pointer.GetBool(t.InjectIntoNodes) && !t.IsEnabled() {
+		return fmt.Errorf("Tracing.InjectIntoNodes requires Tracing.Enabled")
+	}
+	if !t.IsEnabled() {
+		return nil
+	}
+	if _, _, err := parseCollectorEndpoint(pointer.GetString(t.CollectorEndpoint)); err != nil {
+		return fieldError("Tracing.CollectorEndpoint", err)
+	}
+	return nil
+}
+
+// parseCollectorEndpoint returns the host:port of the collector and whether it is reached without TLS.
+// Bare host:port endpoints are assumed to be local collectors without TLS.
+func parseCollectorEndpoint(endpoint string) (string, bool, error) {
+	if endpoint == "" {
+		return "", false, fmt.Errorf("must be set when tracing is enabled")
+	}
+	insecure := true
+	if strings.Contains(endpoint, "://") {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return "", false, withKind(ErrInvalidEndpoint, err)
+		}
+		switch u.Scheme {
+		case "http":
+		case "https":
+			insecure = false
+		default:
+			return "", false, withKind(ErrInvalidEndpoint, fmt.Errorf("%q must use http or https scheme", endpoint))
+		}
+		endpoint = u.Host
+	}
+	host, port, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return "", false, withKind(ErrInvalidEndpoint, fmt.Errorf("%q is not a host:port: %w", endpoint, err))
+	}
+	if host == "" || port == "" {
+		return "", false, withKind(ErrInvalidEndpoint, fmt.Errorf("%q must have both host and port", endpoint))
+	}
+	return endpoint, insecure, nil
+}
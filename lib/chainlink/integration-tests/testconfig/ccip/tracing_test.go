@@ -0,0 +1,78 @@
+package ccip
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracingDefaults(t *testing.T) {
+	var tracing *Tracing
+	require.False(t, tracing.IsEnabled())
+	require.Equal(t, DEFAULT_TRACING_SAMPLING_RATIO, tracing.GetSamplingRatio())
+	require.False(t, tracing.GetInjectIntoNodes())
+	exporter, err := tracing.GetExporterConfig()
+	require.NoError(t, err)
+	require.Nil(t, exporter)
+	node, err := tracing.GetNodeTracingConfig("node-1")
+	require.NoError(t, err)
+	require.Nil(t, node)
+	require.NoError(t, (&Tracing{}).Validate())
+}
+
+func TestGetNodeTracingConfig(t *testing.T) {
+	for _, tc := range []struct {
+		endpoint string
+		target   string
+		mode     string
+	}{
+		{endpoint: "otel-collector:4317", target: "otel-collector:4317", mode: NODE_TRACING_MODE_UNENCRYPTED},
+		{endpoint: "http://otel-collector:4317", target: "otel-collector:4317", mode: NODE_TRACING_MODE_UNENCRYPTED},
+		{endpoint: "https://otel.example.com:443/v1", target: "otel.example.com:443", mode: NODE_TRACING_MODE_TLS},
+	} {
+		t.Run(tc.endpoint, func(t *testing.T) {
+			var tracing Tracing
+			require.NoError(t, toml.Unmarshal([]byte("Enabled = true\nInjectIntoNodes = true\nSamplingRatio = 0.5\nCollectorEndpoint = '"+tc.endpoint+"'"), &tracing))
+			exporter, err := tracing.GetExporterConfig()
+			require.NoError(t, err)
+			require.Equal(t, &OTLPTraceExporterConfig{Endpoint: tc.target, Insecure: tc.mode == NODE_TRACING_MODE_UNENCRYPTED, SamplingRatio: 0.5}, exporter)
+			node, err := tracing.GetNodeTracingConfig("node-1")
+			require.NoError(t, err)
+			require.Equal(t, &NodeTracingConfig{CollectorTarget: tc.target, NodeID: "node-1", SamplingRatio: 0.5, Mode: tc.mode}, node)
+		})
+	}
+}
+
+func TestValidateTracing(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		content  string
+		err      string
+		endpoint bool
+	}{
+		{name: "disabled without endpoint", content: "SamplingRatio = 0.1"},
+		{name: "enabled", content: "Enabled = true\nCollectorEndpoint = 'localhost:4317'\nSamplingRatio = 1.0"},
+		{name: "zero sampling ratio", content: "SamplingRatio = 0.0", err: "Tracing.SamplingRatio must be in (0,1], got 0.000000"},
+		{name: "sampling ratio above 1", content: "SamplingRatio = 1.5", err: "Tracing.SamplingRatio must be in (0,1], got 1.500000"},
+		{name: "inject while disabled", content: "InjectIntoNodes = true", err: "Tracing.InjectIntoNodes requires Tracing.Enabled"},
+		{name: "endpoint not set", content: "Enabled = true", err: "Tracing.CollectorEndpoint: must be set when tracing is enabled"},
+		{name: "unparseable url", content: "Enabled = true\nCollectorEndpoint = 'http://[::1'", err: "Tracing.CollectorEndpoint: parse", endpoint: true},
+		{name: "unknown scheme", content: "Enabled = true\nCollectorEndpoint = 'grpc://localhost:4317'", err: `Tracing.CollectorEndpoint: "grpc://localhost:4317" must use http or https scheme`, endpoint: true},
+		{name: "no port", content: "Enabled = true\nCollectorEndpoint = 'localhost'", err: `Tracing.CollectorEndpoint: "localhost" is not a host:port`, endpoint: true},
+		{name: "no host", content: "Enabled = true\nCollectorEndpoint = ':4317'", err: `Tracing.CollectorEndpoint: ":4317" must have both host and port`, endpoint: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var tracing Tracing
+			require.NoError(t, toml.Unmarshal([]byte(tc.content), &tracing))
+			err := tracing.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.err)
+			require.Equal(t, tc.endpoint, errors.Is(err, ErrInvalidEndpoint))
+		})
+	}
+}
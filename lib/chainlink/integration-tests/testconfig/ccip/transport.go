@@ -0,0 +1,171 @@
+package ccip
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/AlekSi/pointer"
+	chainselectors "github.com/smartcontractkit/chain-selectors"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/blockchain"
+)
+
+const (
+	TRANSPORT_WS   = "ws"
+	TRANSPORT_HTTP = "http"
+
+	OPERATION_SEND      = "send"
+	OPERATION_QUERY     = "query"
+	OPERATION_SUBSCRIBE = "subscribe"
+
+	LINT_SINGLE_TRANSPORT = "SINGLE_TRANSPORT"
+)
+
+var transports = []string{TRANSPORT_WS, TRANSPORT_HTTP}
+
+// defaultTransports sends over HTTP, which survives reconnects better, and reads logs over WS.
+var defaultTransports = map[string]string{
+	OPERATION_SEND:      TRANSPORT_HTTP,
+	OPERATION_QUERY:     TRANSPORT_WS,
+	OPERATION_SUBSCRIBE: TRANSPORT_WS,
+}
+
+// TransportPreference selects the transport of each operation type, one of the TRANSPORT_* constants.
+type TransportPreference struct {
+	Send      *string `toml:",omitempty"`
+	Query     *string `toml:",omitempty"`
+	Subscribe *string `toml:",omitempty"`
+}
+
+func (p *TransportPreference) get(operation string) string {
+	if p == nil {
+		return ""
+	}
+	switch operation {
+	case OPERATION_SEND:
+		return pointer.GetString(p.Send)
+	case OPERATION_QUERY:
+		return pointer.GetString(p.Query)
+	case OPERATION_SUBSCRIBE:
+		return pointer.GetString(p.Subscribe)
+	}
+	return ""
+}
+
+// TransportPreferences are the transports the chain clients use per operation type. Chains overrides
+// Default per chain, keyed by network name or selector.
+type TransportPreferences struct {
+	Default *TransportPreference            `toml:",omitempty"`
+	Chains  map[string]*TransportPreference `toml:",omitempty"`
+}
+
+// GetPreferredTransport returns the transport preferred for the operation on the chain.
+func (t *TransportPreferences) GetPreferredTransport(name string, selector uint64, operation string) string {
+	if t != nil {
+		for ref, preference := range t.Chains {
+			if transport := preference.get(operation); transport != "" && chainRefMatches(ref, name, selector) {
+				return transport
+			}
+		}
+		if transport := t.Default.get(operation); transport != "" {
+			return transport
+		}
+	}
+	return defaultTransports[operation]
+}
+
+// ResolveTransport returns the transport the client factory uses for the operation on the network, with
+// its URLs. It falls back to the other transport if the network has no URL for the preferred one,
+// except for subscriptions, which need WS.
+func (o *Config) ResolveTransport(network blockchain.EVMNetwork, operation string) (string, []string, error) {
+	if _, ok := defaultTransports[operation]; !ok {
+		return "", nil, fmt.Errorf("unknown operation %q, must be one of %s, %s or %s", operation, OPERATION_SEND, OPERATION_QUERY, OPERATION_SUBSCRIBE)
+	}
+	selector, _ := chainselectors.SelectorFromChainId(uint64(network.ChainID))
+	urls := map[string][]string{TRANSPORT_WS: network.URLs, TRANSPORT_HTTP: network.HTTPURLs}
+	preferred := o.TransportPreferences.GetPreferredTransport(network.Name, selector, operation)
+	if len(urls[preferred]) > 0 {
+		return preferred, urls[preferred], nil
+	}
+	if operation == OPERATION_SUBSCRIBE {
+		return "", nil, withKind(ErrInvalidEndpoint, fmt.Errorf("network %s: subscriptions need a WS URL", network.Name))
+	}
+	fallback := TRANSPORT_WS
+	if preferred == TRANSPORT_WS {
+		fallback = TRANSPORT_HTTP
+	}
+	if len(urls[fallback]) == 0 {
+		return "", nil, withKind(ErrInvalidEndpoint, fmt.Errorf("network %s: no RPC endpoints", network.Name))
+	}
+	return fallback, urls[fallback], nil
+}
+
+// CheckTransports warns about live networks that have URLs for one transport only while the
+// preferences use both on them, those operations silently fall back. Private networks always have both.
+func (o *Config) CheckTransports(evmNetworks []blockchain.EVMNetwork) []Warning {
+	var warnings []Warning
+	for _, network := range evmNetworks {
+		if network.Simulated || (len(network.URLs) > 0) == (len(network.HTTPURLs) > 0) {
+			continue
+		}
+		selector, _ := chainselectors.SelectorFromChainId(uint64(network.ChainID))
+		used := map[string]bool{}
+		for operation := range defaultTransports {
+			used[o.TransportPreferences.GetPreferredTransport(network.Name, selector, operation)] = true
+		}
+		if len(used) < 2 || containsString(o.SuppressWarnings, LINT_SINGLE_TRANSPORT) {
+			continue
+		}
+		warnings = append(warnings, Warning{
+			Code:     LINT_SINGLE_TRANSPORT,
+			Field:    "TransportPreferences",
+			Message:  fmt.Sprintf("network %s only has %s URLs, operations preferring the other transport fall back to them", network.Name, configuredTransport(network)),
+			Severity: WARNING_SEVERITY_WARNING,
+		})
+	}
+	return warnings
+}
+
+func configuredTransport(network blockchain.EVMNetwork) string {
+	if len(network.URLs) > 0 {
+		return TRANSPORT_WS
+	}
+	return TRANSPORT_HTTP
+}
+
+func (o *Config) validateTransportPreferences() error {
+	t := o.TransportPreferences
+	if t == nil {
+		return nil
+	}
+	if err := validateTransportPreference("TransportPreferences.Default", t.Default); err != nil {
+		return err
+	}
+	refs := make([]string, 0, len(t.Chains))
+	for ref := range t.Chains {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	for _, ref := range refs {
+		if err := validateTransportPreference("TransportPreferences.Chains."+ref, t.Chains[ref]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateTransportPreference(field string, p *TransportPreference) error {
+	for _, operation := range []string{OPERATION_SEND, OPERATION_QUERY, OPERATION_SUBSCRIBE} {
+		transport := p.get(operation)
+		if transport == "" {
+			continue
+		}
+		if !containsString(transports, transport) {
+			return fmt.Errorf("%s: transport of %s must be one of %v, got %q", field, operation, transports, transport)
+		}
+		if operation == OPERATION_SUBSCRIBE && transport == TRANSPORT_HTTP {
+			return fmt.Errorf("%s: %s can't be done over %s", field, OPERATION_SUBSCRIBE, TRANSPORT_HTTP)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/blockchain"
+)
+
+func TestResolveTransport(t *testing.T) {
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(`
+[TransportPreferences.Default]
+Query = 'http'
+
+[TransportPreferences.Chains.SEPOLIA]
+Query = 'ws'
+`), &cfg))
+	require.NoError(t, cfg.validateTransportPreferences())
+
+	simulated := blockchain.EVMNetwork{Name: "SIMULATED_1", ChainID: 1337, Simulated: true, URLs: []string{"ws://geth:8546"}, HTTPURLs: []string{"http://geth:8545"}}
+	sepolia := blockchain.EVMNetwork{Name: "SEPOLIA", ChainID: 11155111, URLs: []string{"wss://sepolia"}}
+	for _, tc := range []struct {
+		network   blockchain.EVMNetwork
+		operation string
+		transport string
+		url       string
+		err       string
+	}{
+		{network: simulated, operation: OPERATION_SEND, transport: TRANSPORT_HTTP, url: "http://geth:8545"},
+		{network: simulated, operation: OPERATION_QUERY, transport: TRANSPORT_HTTP, url: "http://geth:8545"},
+		{network: simulated, operation: OPERATION_SUBSCRIBE, transport: TRANSPORT_WS, url: "ws://geth:8546"},
+		// the chain override wins over Default
+		{network: sepolia, operation: OPERATION_QUERY, transport: TRANSPORT_WS, url: "wss://sepolia"},
+		// no HTTP URL, sends fall back to WS
+		{network: sepolia, operation: OPERATION_SEND, transport: TRANSPORT_WS, url: "wss://sepolia"},
+		{network: blockchain.EVMNetwork{Name: "HTTP_ONLY", HTTPURLs: []string{"http://rpc"}}, operation: OPERATION_SUBSCRIBE, err: "network HTTP_ONLY: subscriptions need a WS URL"},
+		{network: blockchain.EVMNetwork{Name: "NO_URLS"}, operation: OPERATION_SEND, err: "network NO_URLS: no RPC endpoints"},
+		{network: simulated, operation: "trace", err: `unknown operation "trace", must be one of send, query or subscribe`},
+	} {
+		transport, urls, err := cfg.ResolveTransport(tc.network, tc.operation)
+		if tc.err != "" {
+			require.EqualError(t, err, tc.err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, tc.transport, transport, tc.network.Name+" "+tc.operation)
+		require.Equal(t, []string{tc.url}, urls)
+	}
+	_, _, err := cfg.ResolveTransport(blockchain.EVMNetwork{Name: "HTTP_ONLY", HTTPURLs: []string{"http://rpc"}}, OPERATION_SUBSCRIBE)
+	require.ErrorIs(t, err, ErrInvalidEndpoint)
+
+	warnings := cfg.CheckTransports([]blockchain.EVMNetwork{simulated, sepolia})
+	require.Len(t, warnings, 1)
+	require.Equal(t, "[warning] SINGLE_TRANSPORT TransportPreferences: network SEPOLIA only has ws URLs, operations preferring the other transport fall back to them", warnings[0].String())
+	cfg.SuppressWarnings = []string{LINT_SINGLE_TRANSPORT}
+	require.Empty(t, cfg.CheckTransports([]blockchain.EVMNetwork{simulated, sepolia}))
+
+	for _, tc := range []struct {
+		preferences string
+		err         string
+	}{
+		{"[TransportPreferences.Default]\nSubscribe = 'http'\n", "TransportPreferences.Default: subscribe can't be done over http"},
+		{"[TransportPreferences.Chains.SIMULATED_1]\nSend = 'grpc'\n", `TransportPreferences.Chains.SIMULATED_1: transport of send must be one of [ws http], got "grpc"`},
+	} {
+		var cfg Config
+		require.NoError(t, toml.Unmarshal([]byte(tc.preferences), &cfg))
+		require.EqualError(t, cfg.validateTransportPreferences(), tc.err)
+	}
+}
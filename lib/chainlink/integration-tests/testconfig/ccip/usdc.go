@@ -0,0 +1,170 @@
+package ccip
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	DEFAULT_USDC_MOCK_ATTESTATION_DELAY = time.Second
+	DEFAULT_USDC_TOKEN_SYMBOL           = "USDC"
+	// USDC_MOCK_PATH_ATTESTATIONS is the CCTP attestation API path, followed by the message hash
+	USDC_MOCK_PATH_ATTESTATIONS = "/v1/attestations/"
+
+	// ports used by every chainlink node container
+	NODE_HTTP_PORT = 6688
+	NODE_P2P_PORT  = 6690
+)
+
+// USDCMockConfig configures the mock CCTP attestation service used by USDC lane tests. Its port is drawn
+// from the PortAllocator when not set.
+type USDCMockConfig struct {
+	Enabled          *bool     `toml:",omitempty"`
+	AttestationDelay *Duration `toml:",omitempty"`
+	FailureRatePct   *float64  `toml:",omitempty"`
+	Port             *int      `toml:",omitempty"`
+	// FixedAttestationResponse is returned for every request when set, for deterministic replay tests
+	FixedAttestationResponse *string `toml:",omitempty"`
+	// TokenSymbol is the token in Tokens that is backed by CCTP, defaults to USDC
+	TokenSymbol *string `toml:",omitempty"`
+}
+
+func (u *USDCMockConfig) IsEnabled() bool {
+	return u != nil && pointer.GetBool(u.Enabled)
+}
+
+func (u *USDCMockConfig) GetAttestationDelay() time.Duration {
+	if u == nil || u.AttestationDelay == nil {
+		return DEFAULT_USDC_MOCK_ATTESTATION_DELAY
+	}
+	return u.AttestationDelay.Duration
+}
+
+func (u *USDCMockConfig) GetFailureRatePct() float64 {
+	if u == nil {
+		return 0
+	}
+	return pointer.GetFloat64(u.FailureRatePct)
+}
+
+func (u *USDCMockConfig) GetFixedAttestationResponse() (string, bool) {
+	if u == nil || u.FixedAttestationResponse == nil {
+		return "", false
+	}
+	return *u.FixedAttestationResponse, true
+}
+
+func (u *USDCMockConfig) GetTokenSymbol() string {
+	symbol := ""
+	if u != nil {
+		symbol = pointer.GetString(u.TokenSymbol)
+	}
+	if symbol == "" {
+		return DEFAULT_USDC_TOKEN_SYMBOL
+	}
+	return symbol
+}
+
+func (u *USDCMockConfig) Validate(tokens map[string]*TokenConfig) error {
+	if u.FailureRatePct != nil && (*u.FailureRatePct < 0 || *u.FailureRatePct > 100) {
+		return fieldError("USDCMockConfig.FailureRatePct", withKind(ErrInvalidValue, fmt.Errorf("must be between 0 and 100, got %f", *u.FailureRatePct)))
+	}
+	if u.AttestationDelay != nil && u.AttestationDelay.Duration < 0 {
+		return fieldError("USDCMockConfig.AttestationDelay", withKind(ErrInvalidValue, fmt.Errorf("cannot be negative")))
+	}
+	if u.Port != nil {
+		if err := validatePort(*u.Port); err != nil {
+			return fieldError("USDCMockConfig.Port", err)
+		}
+	}
+	if resp, ok := u.GetFixedAttestationResponse(); ok {
+		if _, err := hex.DecodeString(strings.TrimPrefix(resp, "0x")); err != nil {
+			return fieldError("USDCMockConfig.FixedAttestationResponse", withKind(ErrInvalidValue, fmt.Errorf("must be hex encoded: %w", err)))
+		}
+	}
+	if u.IsEnabled() && len(tokens) > 0 {
+		if _, ok := tokens[u.GetTokenSymbol()]; !ok {
+			return fieldError("USDCMockConfig.TokenSymbol", withKind(ErrTokenNotConfigured, fmt.Errorf("USDCMockConfig is enabled for token %s, which is not configured in Tokens", u.GetTokenSymbol())))
+		}
+	}
+	return nil
+}
+
+// StartUSDCMock starts serving USDCMockHandler on all interfaces, the nodes reach it from their containers.
+// It returns nil if the USDC mock is not enabled, callers close the returned server once the environment is gone.
+func (o *Config) StartUSDCMock() (*http.Server, error) {
+	if !o.USDCMock.IsEnabled() {
+		return nil, nil
+	}
+	port, err := o.GetUSDCMockPort()
+	if err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("tcp", net.JoinHostPort("", strconv.Itoa(o.HostPort(port))))
+	if err != nil {
+		return nil, fieldError(PORT_CLAIMANT_USDC_MOCK, err)
+	}
+	server := &http.Server{Handler: o.USDCMockHandler(), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Warn().Err(err).Msg("USDC mock stopped")
+		}
+	}()
+	log.Info().Str("Address", listener.Addr().String()).Msg("Serving USDC attestations")
+	return server, nil
+}
+
+// USDCMockHandler returns the handler of the mock attestation API, GET /v1/attestations/{messageHash}.
+// Every response is delayed by AttestationDelay and FailureRatePct of them fail, drawn from the
+// RAND_COMPONENT_USDC_MOCK stream. The others are complete with the FixedAttestationResponse, or the
+// message hash itself, which the mock USDC transmitter accepts as it only checks one is present.
+func (o *Config) USDCMockHandler() http.Handler {
+	var mu sync.Mutex
+	rng := o.NewRand(RAND_COMPONENT_USDC_MOCK)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hash, ok := strings.CutPrefix(r.URL.Path, USDC_MOCK_PATH_ATTESTATIONS)
+		if r.Method != http.MethodGet || !ok || hash == "" {
+			http.NotFound(w, r)
+			return
+		}
+		select {
+		case <-time.After(o.USDCMock.GetAttestationDelay()):
+		case <-r.Context().Done():
+			return
+		}
+		mu.Lock()
+		failed := rng.Float64()*100 < o.USDCMock.GetFailureRatePct()
+		mu.Unlock()
+		if failed {
+			http.Error(w, "injected attestation failure", http.StatusInternalServerError)
+			return
+		}
+		attestation, ok := o.USDCMock.GetFixedAttestationResponse()
+		if !ok {
+			attestation = hash
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(struct {
+			Status      string `json:"status"`
+			Attestation string `json:"attestation"`
+		}{Status: "complete", Attestation: attestation})
+	})
+}
+
+func validatePort(port int) error {
+	if port < 1 || port > 65535 {
+		return withKind(ErrInvalidPort, fmt.Errorf("port must be between 1 and 65535, got %d", port))
+	}
+	return nil
+}
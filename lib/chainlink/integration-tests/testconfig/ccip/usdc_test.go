@@ -0,0 +1,91 @@
+package ccip
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUSDCMockConfigDefaults(t *testing.T) {
+	var u *USDCMockConfig
+	require.False(t, u.IsEnabled())
+	require.Equal(t, DEFAULT_USDC_MOCK_ATTESTATION_DELAY, u.GetAttestationDelay())
+	require.Zero(t, u.GetFailureRatePct())
+	require.Equal(t, DEFAULT_USDC_TOKEN_SYMBOL, u.GetTokenSymbol())
+	_, ok := u.GetFixedAttestationResponse()
+	require.False(t, ok)
+}
+
+func TestValidateUSDCMockConfig(t *testing.T) {
+	tokens := map[string]*TokenConfig{"USDC": {}}
+	for _, tc := range []struct {
+		name    string
+		content string
+		tokens  map[string]*TokenConfig
+		err     string
+	}{
+		{name: "enabled with defaults", content: "Enabled = true"},
+		{name: "enabled without tokens", content: "Enabled = true", tokens: map[string]*TokenConfig{}},
+		{name: "full failure rate", content: "FailureRatePct = 100.0"},
+		{name: "negative failure rate", content: "FailureRatePct = -1.0", err: "USDCMockConfig.FailureRatePct: must be between 0 and 100, got -1.000000"},
+		{name: "failure rate above 100", content: "FailureRatePct = 100.5", err: "USDCMockConfig.FailureRatePct: must be between 0 and 100, got 100.500000"},
+		{name: "port out of range", content: "Port = 65536", err: "USDCMockConfig.Port: port must be between 1 and 65535, got 65536"},
+		{name: "fixed response", content: "FixedAttestationResponse = '0xdeadbeef'"},
+		{name: "fixed response not hex", content: "FixedAttestationResponse = 'zz'", err: "USDCMockConfig.FixedAttestationResponse: must be hex encoded: encoding/hex: invalid byte: U+007A 'z'"},
+		{name: "token not configured", content: "Enabled = true\nTokenSymbol = 'USDC.e'", err: "USDCMockConfig is enabled for token USDC.e, which is not configured in Tokens"},
+		{name: "unknown token while disabled", content: "TokenSymbol = 'USDC.e'"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var u USDCMockConfig
+			require.NoError(t, toml.Unmarshal([]byte(tc.content), &u))
+			if tc.tokens == nil {
+				tc.tokens = tokens
+			}
+			err := u.Validate(tc.tokens)
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+
+	// decoding refuses negative durations already, configs built in code still go through Validate
+	negative := USDCMockConfig{AttestationDelay: &Duration{-time.Second}}
+	require.EqualError(t, negative.Validate(tokens), "USDCMockConfig.AttestationDelay: cannot be negative")
+}
+
+func TestUSDCMockHandler(t *testing.T) {
+	cfg := &Config{
+		RandomSeed: pointer.ToInt64(1),
+		USDCMock:   &USDCMockConfig{Enabled: pointer.ToBool(true), AttestationDelay: &Duration{time.Millisecond}},
+	}
+	get := func(path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		cfg.USDCMockHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		return recorder
+	}
+	resp := get(USDC_MOCK_PATH_ATTESTATIONS + "0xabcd")
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.JSONEq(t, `{"status": "complete", "attestation": "0xabcd"}`, resp.Body.String())
+	require.Equal(t, http.StatusNotFound, get(USDC_MOCK_PATH_ATTESTATIONS).Code)
+	require.Equal(t, http.StatusNotFound, get("/v1/other/0xabcd").Code)
+
+	cfg.USDCMock.FixedAttestationResponse = pointer.ToString("0x01")
+	require.JSONEq(t, `{"status": "complete", "attestation": "0x01"}`, get(USDC_MOCK_PATH_ATTESTATIONS+"0xabcd").Body.String())
+
+	cfg.USDCMock.FailureRatePct = pointer.ToFloat64(100)
+	require.Equal(t, http.StatusInternalServerError, get(USDC_MOCK_PATH_ATTESTATIONS+"0xabcd").Code)
+}
+
+func TestStartUSDCMockDisabledByDefault(t *testing.T) {
+	var cfg Config
+	server, err := cfg.StartUSDCMock()
+	require.NoError(t, err)
+	require.Nil(t, server)
+}
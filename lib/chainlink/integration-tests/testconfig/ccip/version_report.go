@@ -0,0 +1,215 @@
+package ccip
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	// VERSION_REPORT_SCHEMA_VERSION must be bumped when a field of the version report is renamed or removed
+	VERSION_REPORT_SCHEMA_VERSION = 1
+	// VERSION_REPORT_ARTIFACT is the name of the version report among the config artifacts
+	VERSION_REPORT_ARTIFACT = "versions.json"
+	// NODE_BUILD_INFO_PATH is the endpoint of the node returning its version and commit
+	NODE_BUILD_INFO_PATH = "/v2/build_info"
+)
+
+// RuntimeVersion is what a component reports about itself while it runs.
+type RuntimeVersion struct {
+	Version     string
+	ImageDigest string
+}
+
+// VersionProbe queries the version of a running component, e.g. the build info endpoint of a node, the
+// version RPC of JD or the labels and digest of the RMN containers.
+type VersionProbe func(ctx context.Context) (RuntimeVersion, error)
+
+// VersionReport is the schema of the versions the run actually used. Fields must only be added, never
+// renamed or removed, without bumping VERSION_REPORT_SCHEMA_VERSION.
+type VersionReport struct {
+	SchemaVersion int       `json:"schemaVersion"`
+	CollectedAt   time.Time `json:"collectedAt"`
+	// Components are sorted by component and name
+	Components []ComponentVersion `json:"components"`
+}
+
+// ComponentVersion is the version of one instance of a component, e.g. one node of COMPONENT_NODES.
+type ComponentVersion struct {
+	Component string `json:"component"`
+	Name      string `json:"name"`
+	// ConfiguredTag is the image tag or client the config asked for, e.g. "develop"
+	ConfiguredTag string `json:"configuredTag,omitempty"`
+	Version       string `json:"version,omitempty"`
+	ImageDigest   string `json:"imageDigest,omitempty"`
+	// Error is set when the component couldn't be queried
+	Error string `json:"error,omitempty"`
+}
+
+// VersionCollector queries the versions of the components registered with it. Collection is best effort,
+// a failing probe is recorded in the report of its component. It is safe for concurrent use.
+type VersionCollector struct {
+	cfg    *Config
+	now    func() time.Time
+	mu     sync.Mutex
+	probes map[string]map[string]VersionProbe
+}
+
+// NewVersionCollector returns the collector of the component versions, nil unless CollectVersions is set.
+// Components register their probes with it once they're up.
+func (o *Config) NewVersionCollector() *VersionCollector {
+	if !pointer.GetBool(o.CollectVersions) {
+		return nil
+	}
+	return &VersionCollector{cfg: o, now: time.Now, probes: make(map[string]map[string]VersionProbe)}
+}
+
+// Register adds the probe of an instance of the component, one of the COMPONENT_* constants, replacing
+// a probe registered with the same name.
+func (c *VersionCollector) Register(component, name string, probe VersionProbe) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.probes[component] == nil {
+		c.probes[component] = make(map[string]VersionProbe)
+	}
+	c.probes[component][name] = probe
+}
+
+// Collect queries every registered component, nil if the collector is disabled.
+func (c *VersionCollector) Collect(ctx context.Context) *VersionReport {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	var components []ComponentVersion
+	probes := make(map[string]VersionProbe)
+	for component, named := range c.probes {
+		for name, probe := range named {
+			components = append(components, ComponentVersion{Component: component, Name: name})
+			probes[component+"/"+name] = probe
+		}
+	}
+	c.mu.Unlock()
+	sort.Slice(components, func(i, j int) bool {
+		if components[i].Component != components[j].Component {
+			return components[i].Component < components[j].Component
+		}
+		return components[i].Name < components[j].Name
+	})
+	configured := c.cfg.GetComponentVersions()
+	for i := range components {
+		component := &components[i]
+		component.ConfiguredTag = configured[component.Component]
+		if component.Component == COMPONENT_CHAINS {
+			component.ConfiguredTag = c.cfg.configuredChainClient(component.Name)
+		}
+		runtime, err := probes[component.Component+"/"+component.Name](ctx)
+		if err != nil {
+			component.Error = err.Error()
+			continue
+		}
+		component.Version = runtime.Version
+		component.ImageDigest = runtime.ImageDigest
+	}
+	return &VersionReport{
+		SchemaVersion: VERSION_REPORT_SCHEMA_VERSION,
+		CollectedAt:   c.now().UTC(),
+		Components:    components,
+	}
+}
+
+// ArtifactCollector returns the collector writing the version report next to the resolved config, to
+// register with the ARTIFACT_CONFIG kind.
+func (c *VersionCollector) ArtifactCollector() ArtifactCollector {
+	return func(ctx context.Context) ([]Artifact, error) {
+		report := c.Collect(ctx)
+		if report == nil {
+			return nil, nil
+		}
+		content, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return []Artifact{{Name: VERSION_REPORT_ARTIFACT, Content: content}}, nil
+	}
+}
+
+// configuredChainClient returns the execution layer client the private network is configured with.
+func (o *Config) configuredChainClient(name string) string {
+	network, ok := o.PrivateEthereumNetworks[name]
+	if !ok || network == nil || network.ExecutionLayer == nil {
+		return ""
+	}
+	return string(*network.ExecutionLayer)
+}
+
+// NodeBuildInfoProbe queries the build info endpoint of the node at url, reporting its version and commit
+// as "version@commit".
+func NodeBuildInfoProbe(client *http.Client, url string) VersionProbe {
+	return func(ctx context.Context) (RuntimeVersion, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(url, "/")+NODE_BUILD_INFO_PATH, nil)
+		if err != nil {
+			return RuntimeVersion{}, err
+		}
+		var buildInfo struct {
+			Version   string `json:"version"`
+			CommitSHA string `json:"commitSHA"`
+		}
+		if err := doJSON(client, req, &buildInfo); err != nil {
+			return RuntimeVersion{}, err
+		}
+		version := buildInfo.Version
+		if buildInfo.CommitSHA != "" {
+			version += "@" + buildInfo.CommitSHA
+		}
+		return RuntimeVersion{Version: version}, nil
+	}
+}
+
+// ChainClientVersionProbe queries web3_clientVersion of the chain client at rpcURL.
+func ChainClientVersionProbe(client *http.Client, rpcURL string) VersionProbe {
+	return func(ctx context.Context) (RuntimeVersion, error) {
+		body := []byte(`{"jsonrpc":"2.0","id":1,"method":"web3_clientVersion","params":[]}`)
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, rpcURL, bytes.NewReader(body))
+		if err != nil {
+			return RuntimeVersion{}, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		var resp struct {
+			Result string `json:"result"`
+			Error  *struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if err := doJSON(client, req, &resp); err != nil {
+			return RuntimeVersion{}, err
+		}
+		if resp.Error != nil {
+			return RuntimeVersion{}, fmt.Errorf("web3_clientVersion: %s", resp.Error.Message)
+		}
+		return RuntimeVersion{Version: resp.Result}, nil
+	}
+}
+
+func doJSON(client *http.Client, req *http.Request, v any) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s: %s", req.Method, req.URL.Path, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
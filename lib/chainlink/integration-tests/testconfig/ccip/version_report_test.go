@@ -0,0 +1,72 @@
+package ccip
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionReportGolden(t *testing.T) {
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, NODE_BUILD_INFO_PATH, r.URL.Path)
+		_, _ = w.Write([]byte(`{"version":"2.19.0","commitSHA":"a1b2c3"}`))
+	}))
+	defer node.Close()
+	chain := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"Geth/v1.14.11-stable/linux-amd64/go1.23.2"}`))
+	}))
+	defer chain.Close()
+
+	var cfg Config
+	require.NoError(t, toml.Unmarshal([]byte(`
+CollectVersions = true
+
+[CLNode]
+Version = 'develop'
+
+[JobDistributorConfig]
+Version = '0.9.0'
+
+[RMNConfig]
+AFNVersion = 'develop'
+
+[PrivateEthereumNetworks.SIMULATED_1]
+execution_layer = 'geth'
+`), &cfg))
+	collector := cfg.NewVersionCollector()
+	require.NotNil(t, collector)
+	collector.now = func() time.Time { return time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC) }
+	collector.Register(COMPONENT_NODES, "node-1", NodeBuildInfoProbe(http.DefaultClient, node.URL))
+	collector.Register(COMPONENT_CHAINS, "SIMULATED_1", ChainClientVersionProbe(http.DefaultClient, chain.URL))
+	collector.Register(COMPONENT_JD, "jd", func(context.Context) (RuntimeVersion, error) {
+		return RuntimeVersion{Version: "0.9.1", ImageDigest: "sha256:0f1e"}, nil
+	})
+	// a failing component is recorded, the others are still collected
+	collector.Register(COMPONENT_RMN, "rmn-0", func(context.Context) (RuntimeVersion, error) {
+		return RuntimeVersion{}, errors.New("container rmn-0 not found")
+	})
+
+	got, err := json.MarshalIndent(collector.Collect(context.Background()), "", "  ")
+	require.NoError(t, err)
+	want, err := os.ReadFile(filepath.Join("testdata", "versions.golden.json"))
+	require.NoError(t, err)
+	require.Equal(t, strings.TrimSpace(string(want)), string(got))
+
+	artifacts, err := collector.ArtifactCollector()(context.Background())
+	require.NoError(t, err)
+	require.Len(t, artifacts, 1)
+	require.Equal(t, VERSION_REPORT_ARTIFACT, artifacts[0].Name)
+
+	cfg.CollectVersions = nil
+	require.Nil(t, cfg.NewVersionCollector().Collect(context.Background()))
+}
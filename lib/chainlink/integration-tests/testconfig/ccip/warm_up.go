@@ -0,0 +1,80 @@
+package ccip
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AlekSi/pointer"
+)
+
+// WarmUp is traffic sent on every lane before measurements start, so that plugin warm-up and the first
+// price updates don't end up in the latencies. It's bounded by either a message count or a duration.
+type WarmUp struct {
+	MessagesPerLane *int      `toml:",omitempty"`
+	Duration        *Duration `toml:",omitempty"`
+	// ExcludeFromResults keeps warm-up messages out of the threshold evaluation and report totals, true unless set
+	ExcludeFromResults *bool `toml:",omitempty"`
+	// AbortIfWarmUpFails fails the test before measurements start if any warm-up message isn't executed
+	AbortIfWarmUpFails *bool `toml:",omitempty"`
+}
+
+func (w *WarmUp) GetExcludeFromResults() bool {
+	if w == nil {
+		return false
+	}
+	return w.ExcludeFromResults == nil || *w.ExcludeFromResults
+}
+
+func (w *WarmUp) GetAbortIfWarmUpFails() bool {
+	return w != nil && pointer.GetBool(w.AbortIfWarmUpFails)
+}
+
+// IsWarmUp returns true if a message is warm-up traffic, given the number of messages already sent on
+// its lane and the time elapsed since the lane started sending.
+func (w *WarmUp) IsWarmUp(sentOnLane int, elapsed time.Duration) bool {
+	switch {
+	case w == nil:
+		return false
+	case w.MessagesPerLane != nil:
+		return sentOnLane < *w.MessagesPerLane
+	case w.Duration != nil:
+		return elapsed < w.Duration.Duration
+	}
+	return false
+}
+
+// EstimateDuration returns how long warm-up lasts, for a message count at the profile's message rate.
+// It's 0 if that can't be estimated.
+func (w *WarmUp) EstimateDuration(profile *LoadProfile) time.Duration {
+	switch {
+	case w == nil:
+		return 0
+	case w.Duration != nil:
+		return w.Duration.Duration
+	case w.MessagesPerLane != nil && profile.GetMessagesPerSecond() > 0:
+		return time.Duration(float64(*w.MessagesPerLane) / profile.GetMessagesPerSecond() * float64(time.Second))
+	}
+	return 0
+}
+
+func (o *Config) validateWarmUp() error {
+	w := o.WarmUp
+	if w == nil {
+		return nil
+	}
+	if (w.MessagesPerLane == nil) == (w.Duration == nil) {
+		return fmt.Errorf("WarmUp requires exactly one of MessagesPerLane or Duration")
+	}
+	if w.MessagesPerLane != nil && *w.MessagesPerLane < 1 {
+		return fmt.Errorf("WarmUp.MessagesPerLane must be at least 1, got %d", *w.MessagesPerLane)
+	}
+	if w.Duration != nil && w.Duration.Duration <= 0 {
+		return fmt.Errorf("WarmUp.Duration must be positive, got %s", w.Duration.Duration)
+	}
+	warmUp := w.EstimateDuration(o.LoadProfile)
+	if total, timeout := warmUp+o.LoadProfile.GetTestDuration(), o.Timeouts.GetOverallTestTimeout(); total > timeout {
+		return fmt.Errorf("WarmUp (%s) and LoadProfile.TestDuration (%s) don't fit in Timeouts.OverallTestTimeout (%s)",
+			warmUp, o.LoadProfile.GetTestDuration(), timeout)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package ccip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateWarmUp(t *testing.T) {
+	profile := &LoadProfile{MessagesPerSecond: pointer.ToFloat64(2), TestDuration: &Duration{Duration: 20 * time.Minute}}
+	for _, tc := range []struct {
+		name    string
+		warmUp  *WarmUp
+		wantErr string
+	}{
+		{"count", &WarmUp{MessagesPerLane: pointer.ToInt(10)}, ""},
+		{"duration", &WarmUp{Duration: &Duration{2 * time.Minute}}, ""},
+		{"neither", &WarmUp{}, "exactly one of MessagesPerLane or Duration"},
+		{"both", &WarmUp{MessagesPerLane: pointer.ToInt(10), Duration: &Duration{time.Minute}}, "exactly one of MessagesPerLane or Duration"},
+		{"zero count", &WarmUp{MessagesPerLane: pointer.ToInt(0)}, "MessagesPerLane must be at least 1"},
+		{"too long", &WarmUp{Duration: &Duration{15 * time.Minute}}, "WarmUp (15m0s) and LoadProfile.TestDuration (20m0s) don't fit in Timeouts.OverallTestTimeout (30m0s)"},
+		// 1200 messages at 2 msg/s take 10m
+		{"count too long", &WarmUp{MessagesPerLane: pointer.ToInt(1201)}, "don't fit in Timeouts.OverallTestTimeout"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := (&Config{LoadProfile: profile, WarmUp: tc.warmUp}).validateWarmUp()
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestIsWarmUp(t *testing.T) {
+	byCount := &WarmUp{MessagesPerLane: pointer.ToInt(2)}
+	require.True(t, byCount.IsWarmUp(1, time.Hour))
+	require.False(t, byCount.IsWarmUp(2, 0))
+	byDuration := &WarmUp{Duration: &Duration{time.Minute}}
+	require.True(t, byDuration.IsWarmUp(100, 59*time.Second))
+	require.False(t, byDuration.IsWarmUp(0, time.Minute))
+	require.False(t, (*WarmUp)(nil).IsWarmUp(0, 0))
+}
+
+func TestReporterWarmUp(t *testing.T) {
+	r := newTestReporter(t, &Reporting{IncludePerMessageDetail: pointer.ToBool(true)})
+	start := r.startedAt
+	for _, event := range []MessageEvent{
+		{Lane: "SIMULATED_1->SIMULATED_2", SeqNr: 0, Phase: MESSAGE_PHASE_SENT, At: start.Add(-time.Minute), WarmUp: true},
+		{Lane: "SIMULATED_1->SIMULATED_2", SeqNr: 0, Phase: MESSAGE_PHASE_EXECUTED, At: start.Add(5 * time.Minute)},
+	} {
+		require.NoError(t, r.RecordMessageEvent(event))
+	}
+	require.NoError(t, r.VerifyWarmUp())
+	require.Equal(t, 3, r.Results().TotalMessages)
+
+	r.ApplyWarmUp(&WarmUp{MessagesPerLane: pointer.ToInt(1)})
+	require.Equal(t, 2, r.Results().TotalMessages)
+	report := r.Report()
+	require.Equal(t, 2, report.Totals.Sent)
+	require.Equal(t, &ReportCounts{Sent: 1, Executed: 1}, report.WarmUp)
+	require.Equal(t, int64(60000), report.Lanes[0].Latencies[MESSAGE_PHASE_EXECUTED].MaxMs)
+	require.True(t, report.Messages[0].WarmUp)
+
+	require.NoError(t, r.RecordMessageEvent(MessageEvent{Lane: "SIMULATED_2->SIMULATED_1", SeqNr: 0, Phase: MESSAGE_PHASE_SENT, At: start, WarmUp: true}))
+	require.EqualError(t, r.VerifyWarmUp(), "1 of 2 warm-up messages failed or weren't executed")
+}
@@ -0,0 +1,135 @@
+package ccip
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// WIRE_SCHEMA_VERSION is bumped whenever the wire representation changes incompatibly.
+// Adding fields is compatible: FromWire ignores fields it doesn't know.
+const WIRE_SCHEMA_VERSION = 1
+
+// WireConfig is the JSON representation of Config submitted by external orchestrators.
+// Config fields are encoded under their Go names, unset pointer fields as null so
+// nil-vs-set survives the round trip.
+type WireConfig struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	Config        json.RawMessage `json:"config"`
+}
+
+type WireOptions struct {
+	// IncludeSecrets exports secret values instead of redacting them
+	IncludeSecrets bool
+}
+
+// ToWire encodes the config as WireConfig JSON, with secrets redacted unless opts.IncludeSecrets is set.
+func (o *Config) ToWire(opts WireOptions) ([]byte, error) {
+	content, err := json.Marshal(o)
+	if err != nil {
+		return nil, err
+	}
+	if opts.IncludeSecrets {
+		if content, err = withSecrets(content, o); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(WireConfig{SchemaVersion: WIRE_SCHEMA_VERSION, Config: content})
+}
+
+// FromWire decodes WireConfig JSON. Unknown fields, e.g. from a newer schema version, are ignored.
+func FromWire(content []byte) (*Config, error) {
+	var wire WireConfig
+	if err := json.Unmarshal(content, &wire); err != nil {
+		return nil, fmt.Errorf("invalid wire config: %w", err)
+	}
+	if wire.SchemaVersion > WIRE_SCHEMA_VERSION {
+		log.Warn().Int("SchemaVersion", wire.SchemaVersion).Int("SupportedSchemaVersion", WIRE_SCHEMA_VERSION).
+			Msg("wire config is from a newer schema version, unknown fields are ignored")
+	}
+	cfg := &Config{}
+	if len(wire.Config) == 0 {
+		return cfg, nil
+	}
+	if err := json.Unmarshal(wire.Config, cfg); err != nil {
+		return nil, fmt.Errorf("invalid wire config: %w", err)
+	}
+	return cfg, nil
+}
+
+// withSecrets replaces the redacted secrets in the JSON encoded config with their values.
+func withSecrets(content []byte, cfg *Config) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	var doc map[string]interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	secrets := make(map[string]Secret)
+	collectSecrets(reflect.ValueOf(cfg), "", secrets)
+	for path, secret := range secrets {
+		// references are exported as is, the receiver resolves them itself
+		setJSONPath(doc, strings.Split(path, "."), string(secret))
+	}
+	return json.Marshal(doc)
+}
+
+var secretType = reflect.TypeOf(Secret(""))
+
+// collectSecrets finds every Secret reachable from v, keyed by its dot separated JSON path.
+func collectSecrets(v reflect.Value, path string, out map[string]Secret) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			collectSecrets(v.Elem(), path, out)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := field.Name
+			if tag := strings.Split(field.Tag.Get("json"), ",")[0]; tag == "-" {
+				continue
+			} else if tag != "" {
+				name = tag
+			}
+			collectSecrets(v.Field(i), joinPath(path, name), out)
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			collectSecrets(iter.Value(), joinPath(path, iter.Key().String()), out)
+		}
+	case reflect.String:
+		if v.Type() == secretType && v.String() != "" {
+			out[path] = Secret(v.String())
+		}
+	}
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func setJSONPath(doc map[string]interface{}, path []string, value string) {
+	for _, key := range path[:len(path)-1] {
+		next, ok := doc[key].(map[string]interface{})
+		if !ok {
+			return
+		}
+		doc = next
+	}
+	doc[path[len(path)-1]] = value
+}
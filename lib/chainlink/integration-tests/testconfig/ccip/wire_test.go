@@ -0,0 +1,63 @@
+package ccip
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const wireTestTOML = `
+HomeChainSelector = '3379446385462418246'
+FeedChainSelector = '3379446385462418246'
+
+[CLNode]
+NoOfPluginNodes = 4
+NoOfBootstraps = 1
+
+[JobDistributorConfig]
+JDGRPC = 'jd:42242'
+
+[Tokens.LINK]
+Decimals = 18
+InitialLiquidity = { SIMULATED_1 = 1000000000000000000000000 }
+
+[Timeouts]
+CommitTimeout = '3m'
+ScaleFactor = 1.5
+
+[Observability]
+GrafanaURL = 'https://grafana.example.com'
+GrafanaToken = 'grafana-token'
+
+[Notifications]
+WebhookURL = 'https://hooks.example.com/abc'
+NotifyOn = ['failure']
+`
+
+func TestWireRoundTrip(t *testing.T) {
+	var original Config
+	require.NoError(t, toml.Unmarshal([]byte(wireTestTOML), &original))
+
+	content, err := original.ToWire(WireOptions{IncludeSecrets: true})
+	require.NoError(t, err)
+	decoded, err := FromWire(content)
+	require.NoError(t, err)
+	require.Equal(t, original, *decoded)
+	require.Nil(t, decoded.PriceConfig)
+	require.Nil(t, decoded.CLNode.DONConfig)
+
+	redacted, err := original.ToWire(WireOptions{})
+	require.NoError(t, err)
+	require.NotContains(t, string(redacted), "grafana-token")
+	require.NotContains(t, string(redacted), "hooks.example.com")
+	decoded, err = FromWire(redacted)
+	require.NoError(t, err)
+	require.Equal(t, Secret(""), decoded.Observability.GetGrafanaToken())
+}
+
+func TestFromWireIgnoresUnknownFields(t *testing.T) {
+	cfg, err := FromWire([]byte(`{"schemaVersion": 2, "newTopLevel": true, "config": {"HomeChainSelector": "1", "FieldFromTheFuture": {"a": 1}}}`))
+	require.NoError(t, err)
+	require.Equal(t, "1", *cfg.HomeChainSelector)
+}
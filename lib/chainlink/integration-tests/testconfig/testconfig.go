@@ -141,7 +141,8 @@ func (c *TestConfig) GetGrafanaDashboardURL() (string, error) {
 func (c *TestConfig) Save() (string, error) {
 	filePath := fmt.Sprintf("test_config-%s.toml", uuid.New())
 
-	content, err := toml.Marshal(*c)
+	// secrets redact themselves when marshalled, keep them so the config can be read back
+	content, err := ccip_config.MarshalTOMLWithSecrets(*c)
 	if err != nil {
 		return "", errors.Wrapf(err, "error marshaling test config")
 	}
@@ -237,7 +238,7 @@ func (c TestConfig) GetActiveOCRConfig() *ocr_config.Config {
 }
 
 func (c *TestConfig) AsBase64() (string, error) {
-	content, err := toml.Marshal(*c)
+	content, err := ccip_config.MarshalTOMLWithSecrets(*c)
 	if err != nil {
 		return "", errors.Wrapf(err, "error marshaling test config")
 	}
@@ -409,6 +410,18 @@ func GetConfig(configurationNames []string, product Product) (TestConfig, error)
 		return TestConfig{}, errors.Wrapf(err, "error reading network config")
 	}
 
+	if testConfig.CCIP != nil {
+		testConfig.CCIP, err = testConfig.CCIP.ApplyPreset()
+		if err != nil {
+			return TestConfig{}, errors.Wrapf(err, "error applying CCIP preset")
+		}
+		testConfig.CCIP = testConfig.CCIP.ApplyAutoSize()
+		testConfig.CCIP, err = testConfig.CCIP.ApplyChainIDAllocation()
+		if err != nil {
+			return TestConfig{}, errors.Wrapf(err, "error allocating CCIP chain ids")
+		}
+	}
+
 	logger.Debug().Msg("Validating test config")
 	err = testConfig.Validate()
 	if err != nil {
@@ -728,6 +741,12 @@ func (c *TestConfig) Validate() error {
 			return errors.Wrapf(err, "WaspAutoBuildConfig validation failed")
 		}
 	}
+
+	if c.CCIP != nil && c.NodeConfig != nil {
+		if err := ccip_config.ValidateNodeChainConfigs(networks.MustGetSelectedNetworkConfig(c.Network), c.NodeConfig.ChainConfigTOMLByChainID); err != nil {
+			return errors.Wrapf(err, "CCIP node config validation failed")
+		}
+	}
 	return nil
 }
 
@@ -2,16 +2,27 @@ package testsetups
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"net"
+	"net/http"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	chainsel "github.com/smartcontractkit/chain-selectors"
 
+	"github.com/smartcontractkit/chainlink-ccip/chainconfig"
+	"github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
 	commonconfig "github.com/smartcontractkit/chainlink-common/pkg/config"
+	"github.com/smartcontractkit/chainlink-common/pkg/hashutil"
+	"github.com/smartcontractkit/chainlink-common/pkg/merklemulti"
 	jobv1 "github.com/smartcontractkit/chainlink-protos/job-distributor/v1/job"
 	"github.com/smartcontractkit/chainlink-testing-framework/lib/blockchain"
 	ctfconfig "github.com/smartcontractkit/chainlink-testing-framework/lib/config"
@@ -20,6 +31,7 @@ import (
 	"github.com/smartcontractkit/chainlink-testing-framework/lib/networks"
 	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/conversions"
 	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/ptr"
+	seth_utils "github.com/smartcontractkit/chainlink-testing-framework/lib/utils/seth"
 	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
 	"github.com/smartcontractkit/chainlink-testing-framework/seth"
 	"github.com/smartcontractkit/chainlink/deployment"
@@ -27,7 +39,12 @@ import (
 	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset"
 	commontypes "github.com/smartcontractkit/chainlink/deployment/common/types"
 	integrationnodes "github.com/smartcontractkit/chainlink/integration-tests/types/config/node"
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/assets"
 	evmcfg "github.com/smartcontractkit/chainlink/v2/core/chains/evm/config/toml"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/fee_quoter"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/offramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/onramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/shared/generated/burn_mint_erc677"
 	corechainlink "github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
 
 	"github.com/smartcontractkit/chainlink/deployment/environment/devenv"
@@ -38,21 +55,87 @@ import (
 	"github.com/smartcontractkit/chainlink/integration-tests/docker/test_env"
 	"github.com/smartcontractkit/chainlink/integration-tests/testconfig"
 	tc "github.com/smartcontractkit/chainlink/integration-tests/testconfig"
+	ccip_config "github.com/smartcontractkit/chainlink/integration-tests/testconfig/ccip"
 	"github.com/smartcontractkit/chainlink/integration-tests/utils"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/relay"
 
 	"github.com/AlekSi/pointer"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/volume"
+	dockerclient "github.com/docker/docker/client"
+	"github.com/docker/go-connections/nat"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
+	tomlv2 "github.com/pelletier/go-toml/v2"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
 	"github.com/subosito/gotenv"
+	"github.com/testcontainers/testcontainers-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
+// TRACER_NAME is the name of the tracer the harness spans are started with
+const TRACER_NAME = "ccip-integration-tests"
+
+// StartTracing sets the global tracer provider to export the harness spans to the collector of CCIP.Tracing,
+// it's shut down at the end of the test. It does nothing when tracing is disabled.
+func StartTracing(t *testing.T, cfg *ccip_config.Config) error {
+	exporterConfig, err := cfg.Tracing.GetExporterConfig()
+	if err != nil || exporterConfig == nil {
+		return err
+	}
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(exporterConfig.Endpoint)}
+	if exporterConfig.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(testcontext.Get(t), opts...)
+	if err != nil {
+		return fmt.Errorf("failed to create the trace exporter of %s: %w", exporterConfig.Endpoint, err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(exporterConfig.SamplingRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		if err := provider.Shutdown(context.Background()); err != nil {
+			logging.GetTestLogger(t).Error().Err(err).Msg("Error flushing the trace exporter")
+		}
+	})
+	return nil
+}
+
+// CaptureProfiles captures the CCIP.Profiling profiles of the plugin nodes, load tests call it when they reach each
+// ccip_config.PROFILE_AT_* point.
+func CaptureProfiles(ctx context.Context, cfg *ccip_config.Config, env *test_env.CLClusterTestEnv, captureAt string) error {
+	bootstraps := cfg.CLNode.GetNoOfBootstrapContainers()
+	return cfg.Profiling.Capture(ctx, captureAt, func(ctx context.Context, nodeIndex int, profileType string, duration time.Duration) ([]byte, error) {
+		if env.ClCluster == nil || bootstraps+nodeIndex >= len(env.ClCluster.Nodes) {
+			return nil, fmt.Errorf("plugin node %d is not running", nodeIndex)
+		}
+		req := env.ClCluster.Nodes[bootstraps+nodeIndex].API.APIClient.R().SetContext(ctx)
+		if duration > 0 {
+			req.SetQueryParam("seconds", strconv.Itoa(int(duration.Seconds())))
+		}
+		resp, err := req.Get(ccip_config.NodePprofPath(profileType))
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode() != http.StatusOK {
+			return nil, fmt.Errorf("node responded with %s", resp.Status())
+		}
+		return resp.Body(), nil
+	})
+}
+
 // DeployedLocalDevEnvironment is a helper struct for setting up a local dev environment with docker
 type DeployedLocalDevEnvironment struct {
 	changeset.DeployedEnv
@@ -60,11 +143,13 @@ type DeployedLocalDevEnvironment struct {
 	DON     *devenv.DON
 }
 
+// RestartChainlinkNodes restarts the containers of every node, each restart annotated as a chaos event.
 func (d DeployedLocalDevEnvironment) RestartChainlinkNodes(t *testing.T) error {
 	errGrp := errgroup.Group{}
 	for _, n := range d.testEnv.ClCluster.Nodes {
 		n := n
 		errGrp.Go(func() error {
+			d.testEnv.Annotator.ChaosEvent(testcontext.Get(t), ccip_config.CHAOS_EVENT_NODE_RESTART, n.ContainerName)
 			if err := n.Container.Terminate(testcontext.Get(t)); err != nil {
 				return err
 			}
@@ -94,6 +179,19 @@ func NewLocalDevEnvironment(
 	// we cannot create the chainlink nodes yet as we need to deploy the capability registry first
 	envConfig, testEnv, cfg := CreateDockerEnv(t)
 	require.NotNil(t, envConfig)
+	// notifies the failure of the bring-up too, the start once the environment is up
+	testEnv.Notifier = ccip_config.NewNotifier(cfg.CCIP.Notifications)
+	notification := map[string]string{"test": t.Name(), "runID": cfg.CCIP.GetRunID()}
+	t.Cleanup(func() {
+		if t.Failed() {
+			testEnv.Notifier.Notify(ccip_config.NOTIFY_ON_FAILURE, notification)
+			return
+		}
+		testEnv.Notifier.Notify(ccip_config.NOTIFY_ON_COMPLETION, notification)
+	})
+	require.NoError(t, StartTracing(t, cfg.CCIP))
+	ctx, span := otel.Tracer(TRACER_NAME).Start(ctx, "NewLocalDevEnvironment")
+	defer span.End()
 	require.NotEmpty(t, envConfig.Chains, "chainConfigs should not be empty")
 	require.NotEmpty(t, envConfig.JDConfig, "jdUrl should not be empty")
 	chains, err := devenv.NewChains(lggr, envConfig.Chains)
@@ -107,18 +205,96 @@ func NewLocalDevEnvironment(
 	require.NoError(t, err)
 
 	ab := deployment.NewMemoryAddressBook()
-	crConfig := changeset.DeployTestContracts(t, lggr, ab, homeChainSel, feedSel, chains, linkPrice, wethPrice)
+	if testEnv.ReusedState != nil {
+		// the nodes of a reused environment are running, with the contracts of its address book
+		ab, err = reusedAddressBook(testEnv.ReusedState)
+		require.NoError(t, err)
+		require.NoError(t, attachChainlinkNodes(envConfig, testEnv.ReusedState, cfg.CCIP))
+	} else {
+		crConfig := changeset.DeployTestContracts(t, lggr, ab, homeChainSel, feedSel, chains, linkPrice, wethPrice)
 
-	// start the chainlink nodes with the CR address
-	err = StartChainlinkNodes(t, envConfig,
-		crConfig,
-		testEnv, cfg)
-	require.NoError(t, err)
+		// start the chainlink nodes with the CR address
+		err = StartChainlinkNodes(t, envConfig,
+			crConfig,
+			testEnv, cfg)
+		require.NoError(t, err)
+	}
 	e, don, err := devenv.NewEnvironment(ctx, lggr, *envConfig)
 	require.NoError(t, err)
 	require.NotNil(t, e)
 	e.ExistingAddresses = ab
+	existing, err := existingContractsAddressBook(cfg.CCIP, e.AllChainSelectors())
+	require.NoError(t, err)
+	require.NoError(t, e.ExistingAddresses.Merge(existing))
+
+	runLabels := make(map[string]interface{})
+	for k, v := range cfg.CCIP.Labels() {
+		runLabels[k] = v
+	}
+	zeroLogLggr := logging.GetTestLogger(t).With().Fields(runLabels).Logger()
+	// fund the nodes, those of a reused environment were funded by the run that created it
+	if testEnv.ReusedState == nil {
+		FundNodes(t, zeroLogLggr, testEnv, cfg, don.PluginNodes())
+	}
+
+	// USDC attestations are served by the USDC mock when it's enabled and by the mock adapter otherwise, USDC
+	// is left out if the mocks are optional and failed to start
+	var usdcConfig changeset.USDCConfig
+	usdcAttestationConfig := func(api string) changeset.USDCConfig {
+		return changeset.USDCConfig{
+			Enabled: true,
+			USDCAttestationConfig: changeset.USDCAttestationConfig{
+				API:         api,
+				APITimeout:  commonconfig.MustNewDuration(time.Second),
+				APIInterval: commonconfig.MustNewDuration(500 * time.Millisecond),
+			},
+		}
+	}
+	switch {
+	case cfg.CCIP.USDCMock.IsEnabled():
+		usdcMock, err := cfg.CCIP.StartUSDCMock()
+		require.NoError(t, err, "Error starting the USDC mock")
+		t.Cleanup(func() { _ = usdcMock.Close() })
+		usdcConfig = usdcAttestationConfig(usdcMockURL(t, cfg.CCIP, testEnv))
+	case testEnv.MockAdapter != nil:
+		err = ccipactions.SetMockServerWithUSDCAttestation(testEnv.MockAdapter, nil)
+		require.NoError(t, err)
+		usdcConfig = usdcAttestationConfig(testEnv.MockAdapter.InternalEndpoint)
+	default:
+		require.False(t, cfg.CCIP.IsRequired(ccip_config.COMPONENT_MOCKS), "USDC attestation is served by the mock adapter, which is required but not running")
+		logging.GetTestLogger(t).Warn().Msg("Mock adapter is not running, deploying without USDC")
+	}
+
+	// a run skipping contracts uses the address book of the reused environment or ExistingContracts
+	if cfg.CCIP.ShouldRun(ccip_config.PHASE_CONTRACTS) {
+		deployCCIPContracts(ctx, t, e, cfg.CCIP, homeChainSel, feedSel, usdcConfig)
+	}
+	ExportAddressBook(t, cfg, e.ExistingAddresses)
+
+	// Ensure capreg logs are up to date.
+	changeset.ReplayLogs(t, e.Offchain, replayBlocks)
 
+	// a run skipping jobs uses the jobs the reused environment's nodes already run
+	switch {
+	case cfg.CCIP.ShouldRun(ccip_config.PHASE_JOBS):
+		require.NoError(t, proposeJobs(ctx, *e, cfg.CCIP))
+	case testEnv.ReusedState != nil:
+		cfg.CCIP.RecordProposedJobs(testEnv.ReusedState.Jobs)
+	}
+	startHeartbeats(t, zeroLogLggr, testEnv, cfg, e.AllChainSelectors())
+	testEnv.Notifier.Notify(ccip_config.NOTIFY_ON_START, notification)
+
+	return changeset.DeployedEnv{
+		Env:          *e,
+		HomeChainSel: homeChainSel,
+		FeedChainSel: feedSel,
+		ReplayBlocks: replayBlocks,
+	}, testEnv, cfg
+}
+
+// deployCCIPContracts deploys the home chain, the prerequisites, MCMS and the CCIP contracts of every chain,
+// merging their addresses into the address book of the environment.
+func deployCCIPContracts(ctx context.Context, t *testing.T, e *deployment.Environment, cfg *ccip_config.Config, homeChainSel, feedSel uint64, usdcConfig changeset.USDCConfig) {
 	envNodes, err := deployment.NodeInfo(e.NodeIDs, e.Offchain)
 	require.NoError(t, err)
 	out, err := changeset.DeployHomeChain(*e,
@@ -126,7 +302,7 @@ func NewLocalDevEnvironment(
 			HomeChainSel:     homeChainSel,
 			RMNStaticConfig:  changeset.NewTestRMNStaticConfig(),
 			RMNDynamicConfig: changeset.NewTestRMNDynamicConfig(),
-			NodeOperators:    changeset.NewTestNodeOperator(chains[homeChainSel].DeployerKey.From),
+			NodeOperators:    changeset.NewTestNodeOperator(e.Chains[homeChainSel].DeployerKey.From),
 			NodeP2PIDsPerNodeOpAdmin: map[string][][32]byte{
 				"NodeOperator": envNodes.NonBootstraps().PeerIDs(),
 			},
@@ -134,90 +310,980 @@ func NewLocalDevEnvironment(
 	)
 	require.NoError(t, err)
 	require.NoError(t, e.ExistingAddresses.Merge(out.AddressBook))
-	zeroLogLggr := logging.GetTestLogger(t)
-	// fund the nodes
-	FundNodes(t, zeroLogLggr, testEnv, cfg, don.PluginNodes())
 
-	output, err := changeset.DeployPrerequisites(*e, changeset.DeployPrerequisiteConfig{
-		ChainSelectors: e.AllChainSelectors(),
-	})
-	require.NoError(t, err)
-	require.NoError(t, e.ExistingAddresses.Merge(output.AddressBook))
-	mcmsCfg := make(map[uint64]commontypes.MCMSWithTimelockConfig)
-	for _, chain := range e.AllChainSelectors() {
-		mcmsCfg[chain] = commontypes.MCMSWithTimelockConfig{
-			Canceller:         commonchangeset.SingleGroupMCMS(t),
-			Bypasser:          commonchangeset.SingleGroupMCMS(t),
-			Proposer:          commonchangeset.SingleGroupMCMS(t),
-			TimelockExecutors: e.AllDeployerKeys(),
-			TimelockMinDelay:  big.NewInt(0),
+	require.NoError(t, cfg.DeploymentConfig.ForEachChain(ctx, e.AllChainSelectors(), func(_ context.Context, selector uint64) error {
+		output, err := changeset.DeployPrerequisites(*e, changeset.DeployPrerequisiteConfig{
+			ChainSelectors: []uint64{selector},
+		})
+		if err != nil {
+			return err
 		}
-	}
-	output, err = commonchangeset.DeployMCMSWithTimelock(*e, mcmsCfg)
-	require.NoError(t, err)
-	require.NoError(t, e.ExistingAddresses.Merge(output.AddressBook))
+		return e.ExistingAddresses.Merge(output.AddressBook)
+	}))
+	require.NoError(t, cfg.DeploymentConfig.ForEachChain(ctx, e.AllChainSelectors(), func(_ context.Context, selector uint64) error {
+		output, err := commonchangeset.DeployMCMSWithTimelock(*e, map[uint64]commontypes.MCMSWithTimelockConfig{
+			selector: {
+				Canceller:         commonchangeset.SingleGroupMCMS(t),
+				Bypasser:          commonchangeset.SingleGroupMCMS(t),
+				Proposer:          commonchangeset.SingleGroupMCMS(t),
+				TimelockExecutors: e.AllDeployerKeys(),
+				TimelockMinDelay:  big.NewInt(0),
+			},
+		})
+		if err != nil {
+			return err
+		}
+		return e.ExistingAddresses.Merge(output.AddressBook)
+	}))
 
 	state, err := changeset.LoadOnchainState(*e)
 	require.NoError(t, err)
-
-	var endpoint string
-	err = ccipactions.SetMockServerWithUSDCAttestation(testEnv.MockAdapter, nil)
-	require.NoError(t, err)
-	endpoint = testEnv.MockAdapter.InternalEndpoint
-
 	tokenConfig := changeset.NewTestTokenConfig(state.Chains[feedSel].USDFeeds)
-	// Apply migration
-	output, err = changeset.InitialDeploy(*e, changeset.DeployCCIPContractConfig{
+	tokenConfig.PriceUpdateInterval = cfg.PriceConfig.GetPriceUpdateInterval()
+	for symbol, info := range tokenConfig.TokenSymbolToInfo {
+		info.DeviationPPB = ccipocr3.NewBigIntFromInt64(int64(cfg.PriceConfig.GetTokenPriceDeviationPPB()))
+		tokenConfig.UpsertTokenInfo(symbol, info)
+	}
+	// Apply migration, it configures every chain on the home chain so it's not run per chain
+	output, err := changeset.InitialDeploy(*e, changeset.DeployCCIPContractConfig{
 		HomeChainSel:   homeChainSel,
 		FeedChainSel:   feedSel,
 		ChainsToDeploy: e.AllChainSelectors(),
 		TokenConfig:    tokenConfig,
 		OCRSecrets:     deployment.XXXGenerateTestOCRSecrets(),
-		USDCConfig: changeset.USDCConfig{
-			Enabled: true,
-			USDCAttestationConfig: changeset.USDCAttestationConfig{
-				API:         endpoint,
-				APITimeout:  commonconfig.MustNewDuration(time.Second),
-				APIInterval: commonconfig.MustNewDuration(500 * time.Millisecond),
-			},
-		},
+		USDCConfig:     usdcConfig,
 	})
 	require.NoError(t, err)
 	require.NoError(t, e.ExistingAddresses.Merge(output.AddressBook))
+	require.NoError(t, setPermissionlessExecThreshold(ctx, *e, cfg))
+	require.NoError(t, configureReceivers(ctx, *e, cfg))
+}
 
-	// Ensure capreg logs are up to date.
-	changeset.ReplayLogs(t, e.Offchain, replayBlocks)
-
-	// Apply the jobs.
-	for nodeID, jobs := range output.JobSpecs {
+// proposeJobs proposes the CCIP jobs to the nodes, rendered with CCIP.JobSpecOverrides, and records their IDs
+// for the state of a kept environment.
+func proposeJobs(ctx context.Context, e deployment.Environment, cfg *ccip_config.Config) error {
+	generated, err := changeset.NewCCIPJobSpecs(e.NodeIDs, e.Offchain)
+	if err != nil {
+		return err
+	}
+	jobSpecs, err := renderJobSpecs(cfg, generated)
+	if err != nil {
+		return err
+	}
+	jobIDs := make(map[string][]string, len(jobSpecs))
+	for nodeID, jobs := range jobSpecs {
 		for _, job := range jobs {
 			// Note these auto-accept
-			_, err := e.Offchain.ProposeJob(ctx,
+			resp, err := e.Offchain.ProposeJob(ctx,
 				&jobv1.ProposeJobRequest{
 					NodeId: nodeID,
 					Spec:   job,
 				})
-			require.NoError(t, err)
+			if err != nil {
+				return fmt.Errorf("failed to propose a job to node %s: %w", nodeID, err)
+			}
+			jobIDs[nodeID] = append(jobIDs[nodeID], resp.GetProposal().GetJobId())
 		}
 	}
+	cfg.RecordProposedJobs(jobIDs)
+	return nil
+}
 
-	return changeset.DeployedEnv{
-		Env:          *e,
-		HomeChainSel: homeChainSel,
-		FeedChainSel: feedSel,
-		ReplayBlocks: replayBlocks,
-	}, testEnv, cfg
+// setPermissionlessExecThreshold sets ExecutionScenario.PermissionlessExecThreshold on the offramp of every chain,
+// the offramps are deployed with the default threshold.
+func setPermissionlessExecThreshold(ctx context.Context, e deployment.Environment, cfg *ccip_config.Config) error {
+	if cfg.ExecutionScenario == nil {
+		return nil
+	}
+	state, err := changeset.LoadOnchainState(e)
+	if err != nil {
+		return err
+	}
+	threshold := uint32(cfg.ExecutionScenario.GetPermissionlessExecThreshold().Seconds())
+	return cfg.DeploymentConfig.ForEachChain(ctx, e.AllChainSelectors(), func(ctx context.Context, selector uint64) error {
+		offRamp := state.Chains[selector].OffRamp
+		dynamicConfig, err := offRamp.GetDynamicConfig(&bind.CallOpts{Context: ctx})
+		if err != nil {
+			return err
+		}
+		dynamicConfig.PermissionLessExecutionThresholdSeconds = threshold
+		tx, err := offRamp.SetDynamicConfig(e.Chains[selector].DeployerKey, dynamicConfig)
+		if _, err := deployment.ConfirmIfNoError(e.Chains[selector], tx, err); err != nil {
+			return fmt.Errorf("ExecutionScenario.PermissionlessExecThreshold of chain %d: %w", selector, err)
+		}
+		return nil
+	})
+}
+
+// configureReceivers makes the receivers of the chains whose Receivers mode is revertAll revert every message.
+func configureReceivers(ctx context.Context, e deployment.Environment, cfg *ccip_config.Config) error {
+	if cfg.Receivers == nil {
+		return nil
+	}
+	state, err := changeset.LoadOnchainState(e)
+	if err != nil {
+		return err
+	}
+	return cfg.DeploymentConfig.ForEachChain(ctx, e.AllChainSelectors(), func(_ context.Context, selector uint64) error {
+		receiver, err := cfg.GetReceiverConfigForDest(selector)
+		if err != nil || !receiver.ShouldRevert() {
+			return err
+		}
+		tx, err := state.Chains[selector].Receiver.SetRevert(e.Chains[selector].DeployerKey, true)
+		if _, err := deployment.ConfirmIfNoError(e.Chains[selector], tx, err); err != nil {
+			return fmt.Errorf("receiver of chain %d: %w", selector, err)
+		}
+		return nil
+	})
+}
+
+// renderJobSpecs renders the plugin job specs deployment generated with CCIP.JobSpecOverrides, when set.
+// A commit or exec template renders a job of its own, bootstrap specs are kept as generated.
+func renderJobSpecs(cfg *ccip_config.Config, jobSpecs map[string][]string) (map[string][]string, error) {
+	if cfg.JobSpecOverrides == nil {
+		return jobSpecs, nil
+	}
+	templated := cfg.JobSpecOverrides.GetTemplateFile(ccip_config.JOB_SPEC_KIND_COMMIT) != "" ||
+		cfg.JobSpecOverrides.GetTemplateFile(ccip_config.JOB_SPEC_KIND_EXEC) != ""
+	rendered := make(map[string][]string, len(jobSpecs))
+	for nodeID, specs := range jobSpecs {
+		for _, spec := range specs {
+			var params ccip_config.JobSpecParams
+			if err := tomlv2.Unmarshal([]byte(spec), &params); err != nil {
+				return nil, fmt.Errorf("failed to decode the job spec of node %s: %w", nodeID, err)
+			}
+			if len(params.P2PV2Bootstrappers) == 0 {
+				rendered[nodeID] = append(rendered[nodeID], spec)
+				continue
+			}
+			kinds := []string{ccip_config.JOB_SPEC_KIND_COMMIT}
+			if templated {
+				kinds = append(kinds, ccip_config.JOB_SPEC_KIND_EXEC)
+			}
+			for _, kind := range kinds {
+				job, err := cfg.RenderJobSpec(kind, params)
+				if err != nil {
+					return nil, err
+				}
+				rendered[nodeID] = append(rendered[nodeID], job)
+			}
+		}
+	}
+	return rendered, nil
+}
+
+// writeReport records the thresholds the results of the test violate, notifying and annotating each, and the
+// components that failed to start, then writes the report if CCIP.Reporting is set.
+func writeReport(t *testing.T, cfg *ccip_config.Config, reporter *ccip_config.Reporter, env *test_env.CLClusterTestEnv) {
+	violations := cfg.Thresholds.EvaluateThresholds(reporter.Results())
+	reporter.RecordThresholdViolations(violations...)
+	if env != nil {
+		reporter.RecordDegradedComponents(env.DegradedComponents...)
+		env.Annotator.ThresholdViolations(context.Background(), violations...)
+		for _, violation := range violations {
+			env.Notifier.Notify(ccip_config.NOTIFY_ON_THRESHOLD_VIOLATION, map[string]string{
+				"test":      t.Name(),
+				"runID":     cfg.GetRunID(),
+				"threshold": violation.Threshold,
+				"limit":     violation.Limit,
+				"actual":    violation.Actual,
+			})
+		}
+	}
+	if cfg.Reporting == nil {
+		return
+	}
+	require.NoError(t, reporter.Write(), "Error writing the report")
+}
+
+// keepOrTeardown tears down the environment if the lifecycle doesn't keep it after this test, otherwise it
+// stores its state under its EnvironmentID for a later run to reuse. A torn down environment is verified with
+// CCIP.TeardownVerification, its outcome recorded with the reporter of the test.
+func keepOrTeardown(t *testing.T, cfg tc.TestConfig, env *test_env.CLClusterTestEnv) {
+	lggr := logging.GetTestLogger(t)
+	if env == nil {
+		return
+	}
+	if cfg.CCIP.Lifecycle.ShouldTeardown(t.Failed()) {
+		// the accounts are swept with clients dialed while the environment is still up
+		var funds ccip_config.FundSweeper
+		if cfg.CCIP.TeardownVerification != nil && pointer.GetBool(cfg.CCIP.TeardownVerification.SweepRemainingFunds) {
+			funds = newSethFundSweeper(t, lggr, env, cfg)
+		}
+		if err := env.Teardown(context.Background()); err != nil {
+			lggr.Error().Err(err).Msg("Error tearing down the test environment")
+		}
+		verifyTeardown(t, cfg.CCIP, env.Reporter, funds)
+		return
+	}
+	id, err := cfg.CCIP.EnvironmentID()
+	if err != nil {
+		lggr.Error().Err(err).Msg("Error deriving the environment ID, the environment is kept but can't be reused")
+		return
+	}
+	if err := cfg.CCIP.SaveEnvironmentConfig(id, envState(cfg.CCIP, env)); err != nil {
+		lggr.Error().Err(err).Msg("Error storing the state of the kept environment")
+		return
+	}
+	lggr.Info().Str("EnvironmentID", id).Msg("Keeping the test environment, reuse it with Lifecycle.ReuseEnvironmentID")
+}
+
+// verifyTeardown removes the docker resources the run leaked and sweeps its accounts with funds, failing the
+// test on the leaks CCIP.TeardownVerification fails on. It does nothing when the verification isn't set.
+func verifyTeardown(t *testing.T, cfg *ccip_config.Config, reporter *ccip_config.Reporter, funds ccip_config.FundSweeper) {
+	if cfg.TeardownVerification == nil {
+		return
+	}
+	provider, err := testcontainers.NewDockerProvider()
+	require.NoError(t, err, "Error connecting to docker")
+	defer provider.Close()
+	report, err := cfg.NewTeardownVerifier(dockerResourceCleaner{client: provider.Client()}, funds).Verify(context.Background())
+	reporter.RecordTeardown(report)
+	require.NoError(t, err, "Error verifying the teardown")
+}
+
+// dockerResourceCleaner lists and removes docker containers and volumes by their labels.
+type dockerResourceCleaner struct {
+	client dockerclient.APIClient
+}
+
+func (d dockerResourceCleaner) ListLabeled(ctx context.Context, labels map[string]string) ([]ccip_config.LabeledResource, error) {
+	args := filters.NewArgs()
+	for k, v := range labels {
+		args.Add("label", k+"="+v)
+	}
+	containers, err := d.client.ContainerList(ctx, container.ListOptions{All: true, Filters: args})
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]ccip_config.LabeledResource, 0, len(containers))
+	for _, c := range containers {
+		name := c.ID
+		if len(c.Names) > 0 {
+			name = strings.TrimPrefix(c.Names[0], "/")
+		}
+		resources = append(resources, ccip_config.LabeledResource{Kind: ccip_config.RESOURCE_KIND_CONTAINER, Name: name})
+	}
+	volumes, err := d.client.VolumeList(ctx, volume.ListOptions{Filters: args})
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range volumes.Volumes {
+		resources = append(resources, ccip_config.LabeledResource{Kind: ccip_config.RESOURCE_KIND_VOLUME, Name: v.Name})
+	}
+	return resources, nil
+}
+
+func (d dockerResourceCleaner) Remove(ctx context.Context, resource ccip_config.LabeledResource) error {
+	switch resource.Kind {
+	case ccip_config.RESOURCE_KIND_CONTAINER:
+		return d.client.ContainerRemove(ctx, resource.Name, container.RemoveOptions{Force: true, RemoveVolumes: true})
+	case ccip_config.RESOURCE_KIND_VOLUME:
+		return d.client.VolumeRemove(ctx, resource.Name, true)
+	}
+	return fmt.Errorf("unknown resource kind %q", resource.Kind)
+}
+
+// sethFundSweeper sweeps the genesis funded keys of the live networks, the first one deployed the contracts
+// and funded the nodes, the others sent. The nodes return their funds themselves in FundNodes, the simulated
+// chains are gone with the environment.
+type sethFundSweeper struct {
+	lggr    zerolog.Logger
+	clients map[uint64]*seth.Client
+}
+
+func newSethFundSweeper(t *testing.T, lggr zerolog.Logger, env *test_env.CLClusterTestEnv, cfg tc.TestConfig) sethFundSweeper {
+	sweeper := sethFundSweeper{lggr: lggr, clients: make(map[uint64]*seth.Client)}
+	for i, network := range publicEVMNetworks(t, env, cfg) {
+		if network.Simulated {
+			continue
+		}
+		selector, err := chainsel.SelectorFromChainId(uint64(network.ChainID))
+		require.NoError(t, err, "Error getting chain selector")
+		client, err := sethClientForNetwork(t, cfg, cfg.GetNetworkConfig().SelectedNetworks[i], &network)
+		require.NoError(t, err, "Error getting seth client for network %s", network.Name)
+		sweeper.clients[selector] = client
+	}
+	return sweeper
+}
+
+func (s sethFundSweeper) ListFundedAccounts(ctx context.Context, selector uint64) ([]ccip_config.FundedAccount, error) {
+	client, ok := s.clients[selector]
+	if !ok {
+		return nil, nil
+	}
+	accounts := make([]ccip_config.FundedAccount, 0, len(client.PrivateKeys))
+	for i, key := range client.PrivateKeys {
+		address, err := actions.PrivateKeyToAddress(key)
+		if err != nil {
+			return nil, err
+		}
+		balance, err := client.Client.BalanceAt(ctx, address, nil)
+		if err != nil {
+			return nil, fmt.Errorf("balance of %s: %w", address.Hex(), err)
+		}
+		role := ccip_config.ACCOUNT_ROLE_SENDER
+		if i == 0 {
+			role = ccip_config.ACCOUNT_ROLE_DEPLOYER
+		}
+		accounts = append(accounts, ccip_config.FundedAccount{ChainSelector: selector, Address: address.Hex(), Role: role, Balance: balance})
+	}
+	return accounts, nil
 }
 
+func (s sethFundSweeper) Transfer(_ context.Context, from ccip_config.FundedAccount, to string, amount *big.Int) (string, error) {
+	client, ok := s.clients[from.ChainSelector]
+	if !ok {
+		return "", fmt.Errorf("no client for chain %d", from.ChainSelector)
+	}
+	for _, key := range client.PrivateKeys {
+		address, err := actions.PrivateKeyToAddress(key)
+		if err != nil {
+			return "", err
+		}
+		if address.Hex() != from.Address {
+			continue
+		}
+		receipt, err := actions.SendFunds(s.lggr, client, actions.FundsToSendPayload{
+			ToAddress:  common.HexToAddress(to),
+			Amount:     amount,
+			PrivateKey: key,
+		})
+		if err != nil {
+			return "", err
+		}
+		return receipt.TxHash.Hex(), nil
+	}
+	return "", fmt.Errorf("no key for account %s", from.Address)
+}
+
+// envState returns what a later run needs to attach to the environment. An attached environment is stored as
+// it was reused, with the contracts and jobs this run recorded.
+func envState(cfg *ccip_config.Config, env *test_env.CLClusterTestEnv) ccip_config.EnvState {
+	if env.ReusedState != nil {
+		state := *env.ReusedState
+		if contracts := cfg.DeployedContracts(); contracts != nil {
+			state.Contracts = contracts
+		}
+		if jobs := cfg.ProposedJobs(); jobs != nil {
+			state.Jobs = jobs
+		}
+		return state
+	}
+	state := ccip_config.EnvState{
+		Contracts: cfg.DeployedContracts(),
+		Jobs:      cfg.ProposedJobs(),
+		JDGRPC:    cfg.JobDistributorConfig.GetJDGRPC(),
+		JDWSRPC:   cfg.JobDistributorConfig.GetJDWSRPC(),
+	}
+	if env.DockerNetwork != nil {
+		state.DockerNetwork = env.DockerNetwork.Name
+	}
+	for _, network := range env.EVMNetworks {
+		// live networks have no RPC provider, their RPCs come from the network config
+		rpcProvider, err := env.GetRpcProvider(network.ChainID)
+		if err != nil {
+			continue
+		}
+		state.Chains = append(state.Chains, ccip_config.ChainState{
+			ChainID:          uint64(network.ChainID),
+			HTTPRPCs:         rpcProvider.PublicHttpUrls(),
+			WSRPCs:           rpcProvider.PublicWsUrls(),
+			InternalHTTPRPCs: rpcProvider.PrivateHttpUrls(),
+			InternalWSRPCs:   rpcProvider.PrivateWsUrsl(),
+		})
+	}
+	if env.JobDistributor != nil {
+		// the nodes are on the docker network, they connect to the internal wsrpc
+		state.JDGRPC, state.JDWSRPC = env.JobDistributor.Grpc, env.JobDistributor.InternalWSRPC
+	}
+	if env.ClCluster != nil {
+		bootstraps := cfg.CLNode.GetNoOfBootstrapContainers()
+		for i, node := range env.ClCluster.Nodes {
+			nodeState := ccip_config.NodeState{
+				Name:          nodeName(i, bootstraps),
+				ContainerName: node.ContainerName,
+				IsBootstrap:   i < bootstraps,
+				Email:         node.UserEmail,
+			}
+			if node.API != nil {
+				nodeState.APIURL = node.API.URL()
+				nodeState.InternalIP = node.API.InternalIP()
+			}
+			state.Nodes = append(state.Nodes, nodeState)
+		}
+	}
+	return state
+}
+
+// reusedAddressBook returns the address book stored in the state of a reused environment.
+func reusedAddressBook(state *ccip_config.EnvState) (*deployment.AddressBookMap, error) {
+	ab := deployment.NewMemoryAddressBook()
+	for selector, contracts := range state.Contracts {
+		for address, contract := range contracts {
+			tv, err := deployment.TypeAndVersionFromString(contract.Type + " " + contract.Version)
+			if err != nil {
+				return nil, fmt.Errorf("stored contract %s of chain %d: %w", address, selector, err)
+			}
+			if err := ab.Save(selector, address, tv); err != nil {
+				return nil, fmt.Errorf("stored contract %s of chain %d: %w", address, selector, err)
+			}
+		}
+	}
+	return ab, nil
+}
+
+// existingContractsAddressBook returns the ExistingContracts of the chains as an address book, the changesets
+// don't deploy the contracts they find in it.
+func existingContractsAddressBook(cfg *ccip_config.Config, selectors []uint64) (deployment.AddressBook, error) {
+	ab := deployment.NewMemoryAddressBook()
+	for _, selector := range selectors {
+		contracts, ok := cfg.GetContracts(selector)
+		if !ok {
+			continue
+		}
+		for _, contract := range []struct {
+			address *string
+			tv      deployment.TypeAndVersion
+		}{
+			{contracts.Router, deployment.NewTypeAndVersion(changeset.Router, deployment.Version1_2_0)},
+			{contracts.OnRamp, deployment.NewTypeAndVersion(changeset.OnRamp, deployment.Version1_6_0_dev)},
+			{contracts.OffRamp, deployment.NewTypeAndVersion(changeset.OffRamp, deployment.Version1_6_0_dev)},
+			{contracts.FeeQuoter, deployment.NewTypeAndVersion(changeset.FeeQuoter, deployment.Version1_6_0_dev)},
+			{contracts.TokenAdminRegistry, deployment.NewTypeAndVersion(changeset.TokenAdminRegistry, deployment.Version1_5_0)},
+			{contracts.RMNRemote, deployment.NewTypeAndVersion(changeset.RMNRemote, deployment.Version1_6_0_dev)},
+			{contracts.RMNProxy, deployment.NewTypeAndVersion(changeset.ARMProxy, deployment.Version1_6_0_dev)},
+			{contracts.NonceManager, deployment.NewTypeAndVersion(changeset.NonceManager, deployment.Version1_6_0_dev)},
+		} {
+			if pointer.GetString(contract.address) == "" {
+				continue
+			}
+			if err := ab.Save(selector, *contract.address, contract.tv); err != nil {
+				return nil, fmt.Errorf("ExistingContracts of chain %d: %w", selector, err)
+			}
+		}
+	}
+	return ab, nil
+}
+
+// AddLanesForAll adds lanes between every pair of chains like changeset.AddLanesForAll, then deploys the
+// fee quoter dest chain configs with the per-message limits of MessageLimits and the gas price staleness
+// of PriceConfig, and sets the gas price deviation of PriceConfig on the home chain.
+func AddLanesForAll(e deployment.Environment, state changeset.CCIPOnChainState, cfg *ccip_config.Config) error {
+	if err := changeset.AddLanesForAll(e, state); err != nil {
+		return err
+	}
+	destChainConfig := changeset.DefaultFeeQuoterDestChainConfig()
+	if cfg.MessageLimits != nil {
+		destChainConfig.MaxDataBytes = cfg.MessageLimits.GetMaxDataBytes()
+		destChainConfig.MaxPerMsgGasLimit = uint32(cfg.MessageLimits.GetMaxPerMsgGasLimit())
+		destChainConfig.MaxNumberOfTokensPerMsg = cfg.MessageLimits.GetMaxNumberOfTokensPerMsg()
+	}
+	destChainConfig.GasPriceStalenessThreshold = uint32(cfg.PriceConfig.GetStalenessThreshold().Seconds())
+	for source := range e.Chains {
+		for dest := range e.Chains {
+			if source == dest {
+				continue
+			}
+			tx, err := state.Chains[source].FeeQuoter.ApplyDestChainConfigUpdates(e.Chains[source].DeployerKey,
+				[]fee_quoter.FeeQuoterDestChainConfigArgs{{DestChainSelector: dest, DestChainConfig: destChainConfig}})
+			if _, err := deployment.ConfirmIfNoError(e.Chains[source], tx, err); err != nil {
+				return fmt.Errorf("fee quoter config of lane %d->%d: %w", source, dest, err)
+			}
+		}
+	}
+	return setGasPriceDeviation(e, state, cfg.PriceConfig.GetGasPriceDeviationPPB())
+}
+
+// setGasPriceDeviation rewrites the chain configs on CCIPHome with the gas price deviation, the commit plugin
+// picks them up from the home chain.
+func setGasPriceDeviation(e deployment.Environment, state changeset.CCIPOnChainState, deviationPPB uint64) error {
+	for selector, chainState := range state.Chains {
+		if chainState.CCIPHome == nil {
+			continue
+		}
+		count, err := chainState.CCIPHome.GetNumChainConfigurations(&bind.CallOpts{})
+		if err != nil {
+			return err
+		}
+		chainConfigs, err := chainState.CCIPHome.GetAllChainConfigs(&bind.CallOpts{}, big.NewInt(0), count)
+		if err != nil {
+			return err
+		}
+		for i, chainConfig := range chainConfigs {
+			decoded, err := chainconfig.DecodeChainConfig(chainConfig.ChainConfig.Config)
+			if err != nil {
+				return fmt.Errorf("chain config of %d: %w", chainConfig.ChainSelector, err)
+			}
+			decoded.GasPriceDeviationPPB = ccipocr3.NewBigIntFromInt64(int64(deviationPPB))
+			if chainConfigs[i].ChainConfig.Config, err = chainconfig.EncodeChainConfig(decoded); err != nil {
+				return err
+			}
+		}
+		tx, err := chainState.CCIPHome.ApplyChainConfigUpdates(e.Chains[selector].DeployerKey, nil, chainConfigs)
+		if _, err := deployment.ConfirmIfNoError(e.Chains[selector], tx, err); err != nil {
+			return fmt.Errorf("gas price deviation of the chain configs on %d: %w", selector, err)
+		}
+	}
+	return nil
+}
+
+// DeployTransferableToken deploys the token and its pools on the lane from src to dst, with the pool type
+// CCIP.Tokens resolves for the lane. Tokens missing from CCIP.Tokens use burn/mint pools.
+func DeployTransferableToken(
+	e deployment.Environment,
+	state changeset.CCIPOnChainState,
+	cfg *ccip_config.Config,
+	src, dst uint64,
+	token string,
+) (*burn_mint_erc677.BurnMintERC677, *burn_mint_erc677.BurnMintERC677, error) {
+	poolType, err := cfg.GetPoolType(token, ccip_config.SelectorLane(src, dst))
+	if errors.Is(err, ccip_config.ErrTokenNotConfigured) {
+		poolType = ccip_config.POOL_TYPE_BURN_MINT
+	} else if err != nil {
+		return nil, nil, err
+	}
+	switch poolType {
+	case ccip_config.POOL_TYPE_BURN_MINT:
+		srcToken, _, dstToken, _, err := changeset.DeployTransferableToken(e.Logger, e.Chains, src, dst, state, e.ExistingAddresses, token)
+		return srcToken, dstToken, err
+	case ccip_config.POOL_TYPE_LOCK_RELEASE:
+		liquidity := map[uint64]*big.Int{
+			src: cfg.GetInitialLiquidity(token, src),
+			dst: cfg.GetInitialLiquidity(token, dst),
+		}
+		srcToken, _, dstToken, _, err := changeset.DeployLockReleaseTransferableToken(e.Logger, e.Chains, src, dst, state, e.ExistingAddresses, token, liquidity)
+		return srcToken, dstToken, err
+	default:
+		return nil, nil, fmt.Errorf("token %s: pool type %s of lane %d->%d is not deployed as a transferable token", token, poolType, src, dst)
+	}
+}
+
+// ExportAddressBook writes the deployed addresses in the formats set by CCIP.AddressExport, if any, and records
+// them for the state of a kept environment.
+func ExportAddressBook(t *testing.T, cfg tc.TestConfig, ab deployment.AddressBook) {
+	addresses, err := ab.Addresses()
+	require.NoError(t, err)
+	contracts := make(map[uint64]map[string]ccip_config.DeployedContract, len(addresses))
+	for selector, byAddress := range addresses {
+		contracts[selector] = make(map[string]ccip_config.DeployedContract, len(byAddress))
+		for address, tv := range byAddress {
+			contracts[selector][address] = ccip_config.DeployedContract{Type: string(tv.Type), Version: tv.Version.String()}
+		}
+	}
+	cfg.CCIP.RecordDeployedContracts(contracts)
+	files, err := cfg.CCIP.ExportAddresses(contracts)
+	require.NoError(t, err)
+	lggr := logging.GetTestLogger(t)
+	for _, file := range files {
+		lggr.Info().Str("File", file).Msg("Exported deployed addresses")
+	}
+}
+
+// MessageRecorder records the messages a test sends with the reporter of the test, and what sending them cost
+// with its cost tracker. It waits for their commits and executions lane by lane like the changeset helpers, so
+// each lane's phases are recorded when it reaches them. It is safe for concurrent use.
+type MessageRecorder struct {
+	reporter      *ccip_config.Reporter
+	costs         *ccip_config.CostTracker
+	env           deployment.Environment
+	mu            sync.Mutex
+	lanes         map[changeset.SourceDestPair]*recordedLane
+	remediation   *ccip_config.RemediationDriver
+	remediationMu sync.Mutex
+}
+
+// recordedLane holds the sequence numbers sent on a lane in order, and how many of them committed and executed.
+type recordedLane struct {
+	seqNrs              []uint64
+	sent                map[uint64]sentMessage
+	committed, executed int
+}
+
+// sentMessage is a message sent on a lane and when it was sent.
+type sentMessage struct {
+	event  *onramp.OnRampCCIPMessageSent
+	sentAt time.Time
+}
+
+// NewMessageRecorder returns the recorder of the messages sent on the chains of e, with the reporter and
+// cost tracker of testEnv.
+func NewMessageRecorder(testEnv *test_env.CLClusterTestEnv, e deployment.Environment) *MessageRecorder {
+	return &MessageRecorder{
+		reporter: testEnv.Reporter,
+		costs:    testEnv.CostTracker,
+		env:      e,
+		lanes:    make(map[changeset.SourceDestPair]*recordedLane),
+	}
+}
+
+// WithRemediation makes ConfirmExecForAll remediate the messages that failed on the offramp as configured by
+// CCIP.Remediation, manually executing them on the offramps. It returns the recorder.
+func (m *MessageRecorder) WithRemediation(cfg *ccip_config.Config) *MessageRecorder {
+	m.remediation = cfg.NewRemediationDriver(&offRampExecutor{recorder: m, env: m.env}, m.reporter)
+	return m
+}
+
+// Sent records a message sent from src to dest, typically the event changeset.TestSendRequest returns. The
+// cost of its send transaction is recorded with the CCIP fee at its LINK value, whatever token paid it.
+func (m *MessageRecorder) Sent(t *testing.T, src, dest uint64, event *onramp.OnRampCCIPMessageSent) {
+	pair := changeset.SourceDestPair{SourceChainSelector: src, DestChainSelector: dest}
+	sentAt := time.Now()
+	receipt, err := m.env.Chains[src].Client.TransactionReceipt(testcontext.Get(t), event.Raw.TxHash)
+	require.NoError(t, err, "Error getting the receipt of message %d", event.SequenceNumber)
+	m.costs.RecordReceipt(src, receipt.GasUsed, receipt.EffectiveGasPrice, event.Message.FeeValueJuels)
+	m.mu.Lock()
+	if m.lanes[pair] == nil {
+		m.lanes[pair] = &recordedLane{sent: make(map[uint64]sentMessage)}
+	}
+	m.lanes[pair].seqNrs = append(m.lanes[pair].seqNrs, event.SequenceNumber)
+	m.lanes[pair].sent[event.SequenceNumber] = sentMessage{event: event, sentAt: sentAt}
+	m.mu.Unlock()
+	require.NoError(t, m.reporter.RecordMessageEvent(ccip_config.MessageEvent{
+		Lane:      ccip_config.SelectorLane(src, dest).Key(),
+		SeqNr:     event.SequenceNumber,
+		MessageID: hexutil.Encode(event.Message.Header.MessageId[:]),
+		Phase:     ccip_config.MESSAGE_PHASE_SENT,
+		At:        sentAt,
+		TxHash:    event.Raw.TxHash.Hex(),
+	}))
+}
+
+// ConfirmCommitForAll waits for the commits of expectedSeqNums like changeset.ConfirmCommitForAllWithExpectedSeqNums,
+// and records every message of a lane up to its expected sequence number as committed once it lands.
+func (m *MessageRecorder) ConfirmCommitForAll(
+	t *testing.T,
+	e deployment.Environment,
+	state changeset.CCIPOnChainState,
+	expectedSeqNums map[changeset.SourceDestPair]uint64,
+	startBlocks map[uint64]*uint64,
+) {
+	var wg errgroup.Group
+	for pair, expectedSeqNum := range expectedSeqNums {
+		if expectedSeqNum == 0 {
+			continue
+		}
+		pair, expectedSeqNum := pair, expectedSeqNum
+		wg.Go(func() error {
+			err := changeset.ConfirmCommitWithExpectedSeqNumRange(t,
+				e.Chains[pair.SourceChainSelector],
+				e.Chains[pair.DestChainSelector],
+				state.Chains[pair.DestChainSelector].OffRamp,
+				startBlocks[pair.DestChainSelector],
+				ccipocr3.NewSeqNumRange(ccipocr3.SeqNum(expectedSeqNum), ccipocr3.SeqNum(expectedSeqNum)))
+			if err != nil {
+				return err
+			}
+			committedAt := time.Now()
+			for _, seqNr := range m.advance(pair, expectedSeqNum, func(l *recordedLane) *int { return &l.committed }) {
+				if err := m.reporter.RecordMessageEvent(ccip_config.MessageEvent{
+					Lane:  ccip_config.SelectorLane(pair.SourceChainSelector, pair.DestChainSelector).Key(),
+					SeqNr: seqNr,
+					Phase: ccip_config.MESSAGE_PHASE_COMMITTED,
+					At:    committedAt,
+				}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	require.NoError(t, wg.Wait(), "all commitments did not confirm")
+}
+
+// ConfirmExecForAll waits for the executions of expectedSeqNums like changeset.ConfirmExecWithSeqNrForAll and
+// returns their execution states. Every message of a lane up to its expected sequence number that reached a final
+// state is recorded as executed or failed. With remediation, the failed messages are remediated once they're stuck
+// and recorded as executed.
+func (m *MessageRecorder) ConfirmExecForAll(
+	t *testing.T,
+	e deployment.Environment,
+	state changeset.CCIPOnChainState,
+	expectedSeqNums map[changeset.SourceDestPair]uint64,
+	startBlocks map[uint64]*uint64,
+) map[uint64]int {
+	var (
+		wg              errgroup.Group
+		mu              sync.Mutex
+		executionStates = make(map[uint64]int)
+	)
+	for pair, expectedSeqNum := range expectedSeqNums {
+		if expectedSeqNum == 0 {
+			continue
+		}
+		pair, expectedSeqNum := pair, expectedSeqNum
+		wg.Go(func() error {
+			source, dest := e.Chains[pair.SourceChainSelector], e.Chains[pair.DestChainSelector]
+			offRamp := state.Chains[pair.DestChainSelector].OffRamp
+			executionState, err := changeset.ConfirmExecWithSeqNr(t, source, dest, offRamp, startBlocks[pair.DestChainSelector], expectedSeqNum)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			executionStates[expectedSeqNum] = executionState
+			mu.Unlock()
+			executedAt := time.Now()
+			for _, seqNr := range m.advance(pair, expectedSeqNum, func(l *recordedLane) *int { return &l.executed }) {
+				seqNrState := uint8(executionState)
+				if seqNr != expectedSeqNum {
+					_, seqNrState = changeset.GetExecutionState(t, source, dest, offRamp, seqNr)
+				}
+				event := ccip_config.MessageEvent{
+					Lane:  ccip_config.SelectorLane(pair.SourceChainSelector, pair.DestChainSelector).Key(),
+					SeqNr: seqNr,
+					At:    executedAt,
+				}
+				switch seqNrState {
+				case changeset.EXECUTION_STATE_SUCCESS:
+					event.Phase = ccip_config.MESSAGE_PHASE_EXECUTED
+				case changeset.EXECUTION_STATE_FAILURE:
+					event.Phase, event.Error = ccip_config.MESSAGE_PHASE_FAILED, "execution failed on the offramp"
+				default:
+					// executed out of order, not yet
+					continue
+				}
+				if err := m.reporter.RecordMessageEvent(event); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	require.NoError(t, wg.Wait())
+	return executionStates
+}
+
+// advance moves the counter of the lane past the messages sent up to seqNr and returns their sequence numbers.
+func (m *MessageRecorder) advance(pair changeset.SourceDestPair, seqNr uint64, counter func(*recordedLane) *int) []uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	lane := m.lanes[pair]
+	if lane == nil {
+		return nil
+	}
+	count := counter(lane)
+	start := *count
+	for *count < len(lane.seqNrs) && lane.seqNrs[*count] <= seqNr {
+		*count++
+	}
+	return lane.seqNrs[start:*count]
+}
+
+// sentMessage returns the message sent on the lane with seqNr.
+func (m *MessageRecorder) sentMessage(pair changeset.SourceDestPair, seqNr uint64) (sentMessage, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if lane := m.lanes[pair]; lane != nil {
+		sent, ok := lane.sent[seqNr]
+		return sent, ok
+	}
+	return sentMessage{}, false
+}
+
+// remediate polls the remediation driver until the failed message is stuck long enough to be remediated
+// and remediates it.
+func (m *MessageRecorder) remediate(ctx context.Context, pair changeset.SourceDestPair, seqNr uint64) error {
+	sent, ok := m.sentMessage(pair, seqNr)
+	if !ok {
+		return fmt.Errorf("message %d on lane %d->%d was not recorded", seqNr, pair.SourceChainSelector, pair.DestChainSelector)
+	}
+	msg := ccip_config.StuckMessage{
+		Lane:      ccip_config.SelectorLane(pair.SourceChainSelector, pair.DestChainSelector),
+		SeqNr:     seqNr,
+		MessageID: hexutil.Encode(sent.event.Message.Header.MessageId[:]),
+		SentAt:    sent.sentAt,
+	}
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+	for {
+		m.remediationMu.Lock()
+		remediated, err := m.remediation.Remediate(ctx, msg)
+		m.remediationMu.Unlock()
+		if remediated || err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("message %s on lane %s not remediated: %w", msg.MessageID, msg.Lane.Key(), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// offRampExecutor manually executes the messages the recorder recorded on the offramps of env. It proves
+// a message against the committed merkle root covering it, so all the other messages of that root must have
+// been executed, successfully or not. Tokens that need offchain data, like USDC, can't be executed manually.
+type offRampExecutor struct {
+	recorder *MessageRecorder
+	env      deployment.Environment
+}
+
+func (o *offRampExecutor) ManuallyExecute(ctx context.Context, msg ccip_config.StuckMessage, gasLimit uint64) error {
+	pair := changeset.SourceDestPair{SourceChainSelector: msg.Lane.SourceSelector, DestChainSelector: msg.Lane.DestSelector}
+	sent, ok := o.recorder.sentMessage(pair, msg.SeqNr)
+	if !ok {
+		return fmt.Errorf("message %d on lane %s was not recorded", msg.SeqNr, msg.Lane.Key())
+	}
+	state, err := changeset.LoadOnchainState(o.env)
+	if err != nil {
+		return fmt.Errorf("load onchain state: %w", err)
+	}
+	offRamp := state.Chains[pair.DestChainSelector].OffRamp
+	root, err := committedMerkleRoot(ctx, offRamp, pair.SourceChainSelector, msg.SeqNr)
+	if err != nil {
+		return err
+	}
+	hashes, err := messageHashes(ctx, offRamp, pair.SourceChainSelector, root.MinSeqNr, root.MaxSeqNr)
+	if err != nil {
+		return err
+	}
+	tree, err := merklemulti.NewTree(hashutil.NewKeccak(), hashes)
+	if err != nil {
+		return fmt.Errorf("build merkle tree: %w", err)
+	}
+	if tree.Root() != root.MerkleRoot {
+		return fmt.Errorf("merkle root of sequence numbers %d-%d doesn't match the committed root", root.MinSeqNr, root.MaxSeqNr)
+	}
+	proof, err := tree.Prove([]int{int(msg.SeqNr - root.MinSeqNr)})
+	if err != nil {
+		return fmt.Errorf("prove message %d: %w", msg.SeqNr, err)
+	}
+	message, err := offRampMessage(sent.event)
+	if err != nil {
+		return err
+	}
+	proofFlagBits := big.NewInt(0)
+	for i, flag := range proof.SourceFlags {
+		if flag {
+			proofFlagBits.SetBit(proofFlagBits, i, 1)
+		}
+	}
+	dest := o.env.Chains[pair.DestChainSelector]
+	tx, err := offRamp.ManuallyExecute(
+		dest.DeployerKey,
+		[]offramp.InternalExecutionReport{{
+			SourceChainSelector: pair.SourceChainSelector,
+			Messages:            []offramp.InternalAny2EVMRampMessage{message},
+			OffchainTokenData:   [][][]byte{make([][]byte, len(message.TokenAmounts))},
+			Proofs:              proof.Hashes,
+			ProofFlagBits:       proofFlagBits,
+		}},
+		[][]offramp.OffRampGasLimitOverride{{{
+			// zero keeps the gas limits the message was sent with
+			ReceiverExecutionGasLimit: new(big.Int).SetUint64(gasLimit),
+			TokenGasOverrides:         make([]uint32, len(message.TokenAmounts)),
+		}}},
+	)
+	if _, err := deployment.ConfirmIfNoError(dest, tx, err); err != nil {
+		return fmt.Errorf("manually execute message %d: %w", msg.SeqNr, err)
+	}
+	return nil
+}
+
+// committedMerkleRoot returns the merkle root the offramp committed for seqNr of the source chain.
+func committedMerkleRoot(ctx context.Context, offRamp *offramp.OffRamp, sourceChainSelector, seqNr uint64) (offramp.InternalMerkleRoot, error) {
+	iter, err := offRamp.FilterCommitReportAccepted(&bind.FilterOpts{Context: ctx})
+	if err != nil {
+		return offramp.InternalMerkleRoot{}, fmt.Errorf("filter commit reports: %w", err)
+	}
+	defer iter.Close()
+	for iter.Next() {
+		for _, root := range iter.Event.MerkleRoots {
+			if root.SourceChainSelector == sourceChainSelector && root.MinSeqNr <= seqNr && seqNr <= root.MaxSeqNr {
+				return root, nil
+			}
+		}
+	}
+	return offramp.InternalMerkleRoot{}, fmt.Errorf("no merkle root committed for sequence number %d", seqNr)
+}
+
+// messageHashes returns the hashes of the messages minSeqNr to maxSeqNr of the source chain, as the offramp
+// emitted them when executing them.
+func messageHashes(ctx context.Context, offRamp *offramp.OffRamp, sourceChainSelector, minSeqNr, maxSeqNr uint64) ([][32]byte, error) {
+	seqNrs := make([]uint64, 0, maxSeqNr-minSeqNr+1)
+	for seqNr := minSeqNr; seqNr <= maxSeqNr; seqNr++ {
+		seqNrs = append(seqNrs, seqNr)
+	}
+	iter, err := offRamp.FilterExecutionStateChanged(&bind.FilterOpts{Context: ctx}, []uint64{sourceChainSelector}, seqNrs, nil)
+	if err != nil {
+		return nil, fmt.Errorf("filter execution state changes: %w", err)
+	}
+	defer iter.Close()
+	hashes := make([][32]byte, len(seqNrs))
+	found := make([]bool, len(seqNrs))
+	for iter.Next() {
+		hashes[iter.Event.SequenceNumber-minSeqNr] = iter.Event.MessageHash
+		found[iter.Event.SequenceNumber-minSeqNr] = true
+	}
+	for i, ok := range found {
+		if !ok {
+			return nil, fmt.Errorf("message %d was not executed yet, its hash is unknown", seqNrs[i])
+		}
+	}
+	return hashes, nil
+}
+
+// offRampMessage converts the message the onramp sent to the message the offramp executes.
+func offRampMessage(event *onramp.OnRampCCIPMessageSent) (offramp.InternalAny2EVMRampMessage, error) {
+	sent := event.Message
+	// the extra args are tagged, the gas limit is the first word after the tag in all versions
+	if len(sent.ExtraArgs) < 36 {
+		return offramp.InternalAny2EVMRampMessage{}, fmt.Errorf("extra args of message %d have no gas limit", event.SequenceNumber)
+	}
+	tokenAmounts := make([]offramp.InternalAny2EVMTokenTransfer, 0, len(sent.TokenAmounts))
+	for _, token := range sent.TokenAmounts {
+		tokenAmounts = append(tokenAmounts, offramp.InternalAny2EVMTokenTransfer{
+			SourcePoolAddress: common.LeftPadBytes(token.SourcePoolAddress.Bytes(), 32),
+			DestTokenAddress:  common.BytesToAddress(token.DestTokenAddress),
+			DestGasAmount:     uint32(new(big.Int).SetBytes(token.DestExecData).Uint64()),
+			ExtraData:         token.ExtraData,
+			Amount:            token.Amount,
+		})
+	}
+	return offramp.InternalAny2EVMRampMessage{
+		Header: offramp.InternalRampMessageHeader{
+			MessageId:           sent.Header.MessageId,
+			SourceChainSelector: sent.Header.SourceChainSelector,
+			DestChainSelector:   sent.Header.DestChainSelector,
+			SequenceNumber:      sent.Header.SequenceNumber,
+			Nonce:               sent.Header.Nonce,
+		},
+		Sender:       common.LeftPadBytes(sent.Sender.Bytes(), 32),
+		Data:         sent.Data,
+		Receiver:     common.BytesToAddress(sent.Receiver),
+		GasLimit:     new(big.Int).SetBytes(sent.ExtraArgs[4:36]),
+		TokenAmounts: tokenAmounts,
+	}, nil
+}
+
+// usdcMockURL returns the URL the nodes reach the USDC mock running in the test process under, the
+// gateway of their docker network.
+func usdcMockURL(t *testing.T, cfg *ccip_config.Config, env *test_env.CLClusterTestEnv) string {
+	port, err := cfg.GetUSDCMockPort()
+	require.NoError(t, err, "Error getting the USDC mock port")
+	provider, err := testcontainers.NewDockerProvider()
+	require.NoError(t, err, "Error connecting to docker")
+	defer provider.Close()
+	network, err := provider.GetNetwork(testcontext.Get(t), testcontainers.NetworkRequest{Name: env.DockerNetwork.Name})
+	require.NoError(t, err, "Error inspecting docker network %s", env.DockerNetwork.Name)
+	require.NotEmpty(t, network.IPAM.Config, "Docker network %s has no gateway", env.DockerNetwork.Name)
+	return fmt.Sprintf("http://%s:%d", network.IPAM.Config[0].Gateway, cfg.HostPort(port))
+}
+
+// NewLocalDevEnvironmentWithRMN sets up the environment like NewLocalDevEnvironment with numRmnNodes RMN nodes,
+// the returned recorder records the messages of the test with its reporter.
 func NewLocalDevEnvironmentWithRMN(
 	t *testing.T,
 	lggr logger.Logger,
 	numRmnNodes int,
-) (changeset.DeployedEnv, devenv.RMNCluster) {
+) (changeset.DeployedEnv, devenv.RMNCluster, *MessageRecorder) {
 	tenv, dockerenv, testCfg := NewLocalDevEnvironmentWithDefaultPrice(t, lggr)
+	recorder := NewMessageRecorder(dockerenv, tenv.Env).WithRemediation(testCfg.CCIP)
 	l := logging.GetTestLogger(t)
-	config := GenerateTestRMNConfig(t, numRmnNodes, tenv, MustNetworksToRPCMap(dockerenv.EVMNetworks))
 	require.NotNil(t, testCfg.CCIP)
+	listenPort, rageProxyPort, err := testCfg.CCIP.GetRMNPorts()
+	require.NoError(t, err, "Error getting the RMN proxy ports")
+	config := GenerateTestRMNConfig(t, numRmnNodes, tenv, MustNetworksToRPCMap(dockerenv.EVMNetworks), listenPort, rageProxyPort)
 	rmnCluster, err := devenv.NewRMNCluster(
 		t, l,
 		[]string{dockerenv.DockerNetwork.ID},
@@ -228,8 +1294,24 @@ func NewLocalDevEnvironmentWithRMN(
 		testCfg.CCIP.RMNConfig.GetAFN2ProxyVersion(),
 		dockerenv.LogStream,
 	)
-	require.NoError(t, err)
-	return tenv, *rmnCluster
+	if err != nil {
+		degraded, err := testCfg.CCIP.HandleStartError(ccip_config.COMPONENT_RMN, err)
+		require.NoError(t, err)
+		dockerenv.DegradedComponents = append(dockerenv.DegradedComponents, *degraded)
+		return tenv, devenv.RMNCluster{}, recorder
+	}
+	rmnContainers := make([]string, 0, numRmnNodes)
+	var readiness ccip_config.ReadinessEndpoints
+	for i := 0; i < numRmnNodes; i++ {
+		rmnNode := rmnCluster.Nodes[fmt.Sprintf("rmn_%d", i)]
+		rmnContainers = append(rmnContainers, rmnNode.RMN.ContainerName)
+		proxy, err := rmnNode.Proxy.Container.PortEndpoint(testcontext.Get(t), nat.Port(fmt.Sprintf("%d/tcp", listenPort)), "")
+		require.NoError(t, err, "Error getting the RMN proxy endpoint")
+		readiness.RMNProxies = append(readiness.RMNProxies, proxy)
+	}
+	require.NoError(t, testCfg.CCIP.ReadinessGate(testcontext.Get(t), readiness), "Error waiting for the RMN proxies to be ready")
+	testCfg.CCIP.RecordContainerNames(ccip_config.SCRAPE_JOB_RMN, rmnContainers...)
+	return tenv, *rmnCluster, recorder
 }
 
 func MustNetworksToRPCMap(evmNetworks []*blockchain.EVMNetwork) map[uint64]string {
@@ -260,7 +1342,9 @@ func MustCCIPNameToRMNName(a string) string {
 	return v
 }
 
-func GenerateTestRMNConfig(t *testing.T, nRMNNodes int, tenv changeset.DeployedEnv, rpcMap map[uint64]string) map[string]devenv.RMNConfig {
+// GenerateTestRMNConfig returns the config of nRMNNodes RMN nodes, whose proxies listen for the nodes on
+// listenPort and for their RMN on rageProxyPort.
+func GenerateTestRMNConfig(t *testing.T, nRMNNodes int, tenv changeset.DeployedEnv, rpcMap map[uint64]string, listenPort, rageProxyPort int) map[string]devenv.RMNConfig {
 	// Find the bootstrappers.
 	nodes, err := deployment.NodeInfo(tenv.Env.NodeIDs, tenv.Env.Offchain)
 	require.NoError(t, err)
@@ -311,21 +1395,23 @@ func GenerateTestRMNConfig(t *testing.T, nRMNNodes int, tenv changeset.DeployedE
 		ChainParams:  chainParams,
 	}
 
+	listenAddress := net.JoinHostPort("0.0.0.0", strconv.Itoa(listenPort))
+	rageProxy := net.JoinHostPort("0.0.0.0", strconv.Itoa(rageProxyPort))
 	rmnConfig := make(map[string]devenv.RMNConfig)
 	for i := 0; i < nRMNNodes; i++ {
 		// Listen addresses _should_ be able to operator on the same port since
 		// they are inside the docker network.
 		proxyLocal := devenv.ProxyLocalConfig{
-			ListenAddresses:   []string{devenv.DefaultProxyListenAddress},
+			ListenAddresses:   []string{listenAddress},
 			AnnounceAddresses: []string{},
-			ProxyAddress:      devenv.DefaultRageProxy,
+			ProxyAddress:      rageProxy,
 			DiscovererDbPath:  devenv.DefaultDiscovererDbPath,
 		}
 		rmnConfig[fmt.Sprintf("rmn_%d", i)] = devenv.RMNConfig{
 			Shared: shared,
 			Local: devenv.LocalConfig{
 				Networking: devenv.LocalConfigNetworking{
-					RageProxy: devenv.DefaultRageProxy,
+					RageProxy: rageProxy,
 				},
 				Chains: rpcs,
 			},
@@ -353,6 +1439,40 @@ func CreateDockerEnv(t *testing.T) (
 
 	evmNetworks := networks.MustGetSelectedNetworkConfig(cfg.GetNetworkConfig())
 
+	// the node version lives in the top level config, check it against the JD and RMN versions
+	if cfg.CCIP.CLNode != nil && cfg.CCIP.CLNode.Version == nil {
+		cfg.CCIP.CLNode.Version = cfg.GetChainlinkImageConfig().Version
+		require.NoError(t, cfg.CCIP.Revalidate("CLNode.Version"))
+	}
+	for _, w := range cfg.CCIP.CheckCompatibility() {
+		t.Logf("component versions: %s", w)
+	}
+
+	startupPlan, err := cfg.CCIP.ResolveStartupPlan()
+	require.NoError(t, err, "Error resolving startup plan")
+
+	// fails with a diff of the configs if the stored environment was created from a different one
+	reused, err := cfg.CCIP.LoadReusedEnvironment()
+	require.NoError(t, err, "Error loading the reused environment")
+	// one reporter collects everything the test measures, the report is written by the cleanup registered
+	// first so it runs after every other cleanup recorded into it
+	var env *test_env.CLClusterTestEnv
+	reporter := ccip_config.NewReporter(cfg.CCIP.Reporting)
+	reporter.AttachLabels(cfg.CCIP)
+	reporter.LinkTransactions(cfg.CCIP)
+	reporter.ApplyWarmUp(cfg.CCIP.WarmUp)
+	costs := ccip_config.NewCostTracker()
+	reporter.TrackCosts(costs, estimateCost(t, cfg.CCIP, evmNetworks))
+	t.Cleanup(func() { writeReport(t, cfg.CCIP, reporter, env) })
+	// an environment the lifecycle may keep must outlive the test binary, so Ryuk can't reap it
+	if cfg.CCIP.Lifecycle.MayKeepEnvironment() {
+		t.Setenv("TESTCONTAINERS_RYUK_DISABLED", "true")
+	}
+	// the teardown can only be verified once the environment is explicitly torn down
+	if cfg.CCIP.Lifecycle.MayKeepEnvironment() || cfg.CCIP.TeardownVerification != nil {
+		t.Cleanup(func() { keepOrTeardown(t, cfg, env) })
+	}
+
 	// find out if the selected networks are provided with PrivateEthereumNetworks configs
 	// if yes, PrivateEthereumNetworkConfig will be used to create simulated private ethereum networks in docker environment
 	var privateEthereumNetworks []*ctfconfig.EthereumNetworkConfig
@@ -362,20 +1482,51 @@ func CreateDockerEnv(t *testing.T) (
 		}
 	}
 
+	jdGRPCPort, jdWSRPCPort, err := cfg.CCIP.GetJDPorts()
+	require.NoError(t, err, "Error getting the job distributor ports")
 	builder := test_env.NewCLTestEnvBuilder().
 		WithTestConfig(&cfg).
 		WithTestInstance(t).
 		WithMockAdapter().
-		WithJobDistributor(cfg.CCIP.JobDistributorConfig).
+		WithJobDistributor(cfg.CCIP.JobDistributorConfig, jdGRPCPort, jdWSRPCPort).
+		WithComponentCriticality(cfg.CCIP).
+		WithLogCollection(cfg.CCIP).
+		WithStartupPlan(startupPlan).
 		WithStandardCleanup()
+	var dockerRuntime *ccip_config.DockerRuntimeConfig
+	switch runtime := cfg.CCIP.GetRuntimeConfig().(type) {
+	case *ccip_config.DockerRuntimeConfig:
+		dockerRuntime = runtime
+		builder = builder.WithDockerRuntime(runtime)
+	case *ccip_config.K8sRuntimeConfig:
+		// k8s environments are brought up by crib, tests only connect to them through the network config
+		require.FailNowf(t, "unsupported runtime", "CCIP.Runtime is %s, the environment of namespace %q is brought up with crib, not by CreateDockerEnv",
+			runtime.Runtime(), runtime.Namespace)
+	}
 
 	// if private ethereum networks are provided, we will use them to create the test environment
 	// otherwise we will use the network URLs provided in the network config
 	if len(privateEthereumNetworks) > 0 {
 		builder = builder.WithPrivateEthereumNetworks(privateEthereumNetworks)
 	}
-	env, err := builder.Build()
-	require.NoError(t, err, "Error building test environment")
+	if reused != nil {
+		// the infra of a reused environment is still running, attach to it instead of starting it again
+		env = test_env.AttachTestEnv(t, dockerRuntime, reused)
+	} else {
+		require.True(t, cfg.CCIP.ShouldRun(ccip_config.PHASE_INFRA), "Phases skips %s, which requires Lifecycle.ReuseEnvironmentID", ccip_config.PHASE_INFRA)
+		env, err = builder.Build()
+		require.NoError(t, err, "Error building test environment")
+	}
+	env.Reporter = reporter
+	env.CostTracker = costs
+
+	annotator, degraded, err := cfg.CCIP.StartAnnotator(testcontext.Get(t), t.Name())
+	require.NoError(t, err, "Error starting Grafana annotations")
+	if degraded != nil {
+		env.DegradedComponents = append(env.DegradedComponents, *degraded)
+	}
+	env.Annotator = annotator
+	t.Cleanup(func() { annotator.TestFinished(context.Background(), t.Name(), !t.Failed()) })
 
 	// we need to update the URLs for the simulated networks to the private chain RPCs in the docker test environment
 	// so that the chainlink nodes and rmn nodes can internally connect to the chain
@@ -392,7 +1543,14 @@ func CreateDockerEnv(t *testing.T) (
 		env.EVMNetworks = append(env.EVMNetworks, &evmNetworks[i])
 	}
 
+	configServer, err := cfg.CCIP.StartConfigServer(evmNetworks)
+	require.NoError(t, err, "Error starting config server")
+	if configServer != nil {
+		t.Cleanup(func() { _ = configServer.Close() })
+	}
+
 	chains := CreateChainConfigFromNetworks(t, env, privateEthereumNetworks, cfg.GetNetworkConfig())
+	dialBudgetedChainClients(t, cfg.CCIP, chains, reporter)
 
 	jdConfig := devenv.JDConfig{
 		GRPC:  cfg.CCIP.JobDistributorConfig.GetJDGRPC(),
@@ -400,9 +1558,19 @@ func CreateDockerEnv(t *testing.T) (
 	}
 	// TODO : move this as a part of test_env setup with an input in testconfig
 	// if JD is not provided, we will spin up a new JD
-	if jdConfig.GRPC == "" || jdConfig.WSRPC == "" {
+	switch {
+	case jdConfig.GRPC != "" && jdConfig.WSRPC != "":
+	case reused != nil:
+		// the JD of a reused environment was started by the run that created it
+		jdConfig = devenv.JDConfig{
+			GRPC:  reused.JDGRPC,
+			WSRPC: reused.JDWSRPC,
+			Creds: insecure.NewCredentials(),
+		}
+	default:
 		jd := env.JobDistributor
 		require.NotNil(t, jd, "JD is not found in test environment")
+		cfg.CCIP.RecordContainerNames(ccip_config.SCRAPE_JOB_JOB_DISTRIBUTOR, jd.ContainerName)
 		jdConfig = devenv.JDConfig{
 			GRPC: jd.Grpc,
 			// we will use internal wsrpc for nodes on same docker network to connect to JD
@@ -412,6 +1580,13 @@ func CreateDockerEnv(t *testing.T) (
 	}
 	require.NotEmpty(t, jdConfig, "JD config is empty")
 
+	// the nodes are started once the contracts are deployed, they're waited for by StartChainlinkNodes
+	readiness := ccip_config.ReadinessEndpoints{JDGRPC: jdConfig.GRPC}
+	for _, chain := range chains {
+		readiness.ChainRPCs = append(readiness.ChainRPCs, chain.HTTPRPCs...)
+	}
+	require.NoError(t, cfg.CCIP.ReadinessGate(testcontext.Get(t), readiness), "Error waiting for the environment to be ready")
+
 	homeChainSelector, err := cfg.CCIP.GetHomeChainSelector(evmNetworks)
 	require.NoError(t, err, "Error getting home chain selector")
 	feedChainSelector, err := cfg.CCIP.GetFeedChainSelector(evmNetworks)
@@ -425,6 +1600,56 @@ func CreateDockerEnv(t *testing.T) (
 	}, env, cfg
 }
 
+// estimateCost returns the estimate of what the test spends on the chains of the networks, nil if it can't be
+// estimated.
+func estimateCost(t *testing.T, cfg *ccip_config.Config, evmNetworks []blockchain.EVMNetwork) *ccip_config.CostEstimate {
+	selectors := make([]uint64, 0, len(evmNetworks))
+	for _, network := range evmNetworks {
+		selector, err := chainsel.SelectorFromChainId(uint64(network.ChainID))
+		require.NoError(t, err, "Error getting chain selector")
+		selectors = append(selectors, selector)
+	}
+	estimate, err := cfg.EstimateCost(selectors)
+	if err != nil {
+		logging.GetTestLogger(t).Warn().Err(err).Msg("Failed to estimate the cost of the test, the report has the actual spend only")
+		return nil
+	}
+	return estimate
+}
+
+// dialBudgetedChainClients builds the clients of the chains with an RPCBudget through the client factory
+// of the CCIP config, so their requests are within the budget, the other chains are dialed by devenv.
+// The requests made per chain are pushed as metrics and recorded with the reporter once the test is done.
+func dialBudgetedChainClients(t *testing.T, cfg *ccip_config.Config, chains []devenv.ChainConfig, reporter *ccip_config.Reporter) {
+	budgets := cfg.NewRPCBudgets()
+	if budgets == nil {
+		return
+	}
+	for i, chain := range chains {
+		selector, err := chainsel.SelectorFromChainId(chain.ChainID)
+		require.NoError(t, err, "Error getting chain selector")
+		if budgets.Limiter(selector) == nil {
+			continue
+		}
+		// the deployment client subscribes to events, so it needs the subscription transport
+		_, urls, err := cfg.ResolveTransport(blockchain.EVMNetwork{
+			Name:     chain.ChainName,
+			ChainID:  int64(chain.ChainID),
+			URLs:     chain.WSRPCs,
+			HTTPURLs: chain.HTTPRPCs,
+		}, ccip_config.OPERATION_SUBSCRIBE)
+		require.NoError(t, err, "Error resolving the RPC transport of chain %s", chain.ChainName)
+		chains[i].Client, err = budgets.DialChainClient(testcontext.Get(t), selector, urls)
+		require.NoError(t, err, "Error dialing chain %s", chain.ChainName)
+	}
+	t.Cleanup(func() {
+		if err := budgets.PushMetrics(context.Background(), nil); err != nil {
+			logging.GetTestLogger(t).Warn().Err(err).Msg("Failed to push the RPC usage metrics")
+		}
+		reporter.RecordRPCUsage(budgets.Usage()...)
+	})
+}
+
 // StartChainlinkNodes starts docker containers for chainlink nodes on the existing test environment based on provided test config
 // Once the nodes starts, it updates the devenv EnvironmentConfig with the node info
 // which includes chainlink API URL, email, password and internal IP
@@ -439,32 +1664,26 @@ func StartChainlinkNodes(
 	for i := range env.EVMNetworks {
 		evmNetworks = append(evmNetworks, *env.EVMNetworks[i])
 	}
-	noOfNodes := pointer.GetInt(cfg.CCIP.CLNode.NoOfPluginNodes) + pointer.GetInt(cfg.CCIP.CLNode.NoOfBootstraps)
+	// standby bootstraps are started with the active ones, so their locators are in the bootstrappers of every job spec
+	noOfNodes := cfg.CCIP.CLNode.GetNoOfPluginNodes() + cfg.CCIP.CLNode.GetNoOfBootstrapContainers()
 	if env.ClCluster == nil {
 		env.ClCluster = &test_env.ClCluster{}
 	}
 	var nodeInfo []devenv.NodeInfo
+	bootstraps := cfg.CCIP.CLNode.GetNoOfBootstrapContainers()
 	for i := 1; i <= noOfNodes; i++ {
-		if i <= pointer.GetInt(cfg.CCIP.CLNode.NoOfBootstraps) {
-			nodeInfo = append(nodeInfo, devenv.NodeInfo{
-				IsBootstrap: true,
-				Name:        fmt.Sprintf("bootstrap-%d", i),
-				// TODO : make this configurable
-				P2PPort: "6690",
-			})
-		} else {
-			nodeInfo = append(nodeInfo, devenv.NodeInfo{
-				IsBootstrap: false,
-				Name:        fmt.Sprintf("node-%d", i-1),
-				// TODO : make this configurable
-				P2PPort: "6690",
-			})
-		}
+		nodeInfo = append(nodeInfo, devenv.NodeInfo{
+			IsBootstrap: i <= bootstraps,
+			Name:        nodeName(i-1, bootstraps),
+			// TODO : make this configurable
+			P2PPort: "6690",
+		})
 		toml, _, err := SetNodeConfig(
 			evmNetworks,
 			cfg.NodeConfig.BaseConfigTOML,
 			cfg.NodeConfig.CommonChainConfigTOML,
 			cfg.NodeConfig.ChainConfigTOMLByChainID,
+			nodeEVMChain(cfg.CCIP),
 		)
 
 		toml.Capabilities.ExternalRegistry.NetworkID = ptr.Ptr(relay.NetworkEVM)
@@ -474,6 +1693,38 @@ func StartChainlinkNodes(
 		if err != nil {
 			return err
 		}
+
+		tracing, err := cfg.CCIP.Tracing.GetNodeTracingConfig(nodeInfo[len(nodeInfo)-1].Name)
+		if err != nil {
+			return err
+		}
+		if tracing != nil {
+			toml.Tracing.Enabled = ptr.Ptr(true)
+			toml.Tracing.CollectorTarget = ptr.Ptr(tracing.CollectorTarget)
+			toml.Tracing.NodeID = ptr.Ptr(tracing.NodeID)
+			toml.Tracing.SamplingRatio = ptr.Ptr(tracing.SamplingRatio)
+			toml.Tracing.Mode = ptr.Ptr(tracing.Mode)
+		}
+		pluginLogging, err := cfg.CCIP.PluginLogging.GetNodePluginLogging(nodeInfo[len(nodeInfo)-1].Name)
+		if err != nil {
+			return err
+		}
+		if pluginLogging != nil {
+			var overrides corechainlink.Config
+			if err := commonconfig.DecodeTOML(bytes.NewReader([]byte(pluginLogging.TOML())), &overrides); err != nil {
+				return err
+			}
+			if err := toml.SetFrom(&overrides); err != nil {
+				return err
+			}
+		}
+		nodeTOML, err := toml.TOMLString()
+		if err != nil {
+			return err
+		}
+		if err := cfg.CCIP.ValidateNodeConfigForCCIP(nodeTOML, evmNetworks); err != nil {
+			return fmt.Errorf("%s: %w", nodeInfo[len(nodeInfo)-1].Name, err)
+		}
 		ccipNode, err := test_env.NewClNode(
 			[]string{env.DockerNetwork.Name},
 			pointer.GetString(cfg.GetChainlinkImageConfig().Image),
@@ -483,6 +1734,9 @@ func StartChainlinkNodes(
 			test_env.WithPgDBOptions(
 				ctftestenv.WithPostgresImageVersion(pointer.GetString(cfg.GetChainlinkImageConfig().PostgresVersion)),
 			),
+			test_env.WithReadinessGate(),
+			// the teardown verification finds what the run leaked by its label
+			test_env.WithLabels(map[string]string{ccip_config.LABEL_RUN_ID: cfg.CCIP.GetRunID()}),
 		)
 		if err != nil {
 			return err
@@ -494,6 +1748,20 @@ func StartChainlinkNodes(
 	if err != nil {
 		return err
 	}
+	// scrape targets are named after the containers, whose names have a random suffix
+	containers := make([]string, 0, len(env.ClCluster.Nodes))
+	for _, n := range env.ClCluster.Nodes {
+		containers = append(containers, n.ContainerName)
+	}
+	cfg.CCIP.RecordContainerNames(ccip_config.SCRAPE_JOB_BOOTSTRAP, containers[:bootstraps]...)
+	cfg.CCIP.RecordContainerNames(ccip_config.SCRAPE_JOB_NODE, containers[bootstraps:]...)
+	var readiness ccip_config.ReadinessEndpoints
+	for _, n := range env.ClCluster.Nodes {
+		readiness.NodeAPIs = append(readiness.NodeAPIs, n.API.URL())
+	}
+	if err := cfg.CCIP.ReadinessGate(testcontext.Get(t), readiness); err != nil {
+		return err
+	}
 	for i, n := range env.ClCluster.Nodes {
 		nodeInfo[i].CLConfig = clclient.ChainlinkConfig{
 			URL:        n.API.URL(),
@@ -509,20 +1777,53 @@ func StartChainlinkNodes(
 	return nil
 }
 
+// nodeName returns the name of the i-th node StartChainlinkNodes starts, the bootstraps come first.
+func nodeName(i, bootstraps int) string {
+	if i < bootstraps {
+		return fmt.Sprintf("bootstrap-%d", i+1)
+	}
+	return fmt.Sprintf("node-%d", i)
+}
+
+// attachChainlinkNodes updates the devenv EnvironmentConfig with the nodes of a reused environment, which
+// keep running with the config StartChainlinkNodes started them with.
+func attachChainlinkNodes(envConfig *devenv.EnvironmentConfig, state *ccip_config.EnvState, cfg *ccip_config.Config) error {
+	var bootstraps, plugins []string
+	for _, node := range state.Nodes {
+		password := test_env.DefaultUserPassword
+		if node.PasswordRef != "" {
+			var err error
+			if password, err = ccip_config.Secret(node.PasswordRef).Value(); err != nil {
+				return fmt.Errorf("password of node %s: %w", node.Name, err)
+			}
+		}
+		envConfig.JDConfig.NodeInfo = append(envConfig.JDConfig.NodeInfo, devenv.NodeInfo{
+			IsBootstrap: node.IsBootstrap,
+			Name:        node.Name,
+			P2PPort:     "6690",
+			CLConfig: clclient.ChainlinkConfig{
+				URL:        node.APIURL,
+				Email:      node.Email,
+				Password:   password,
+				InternalIP: node.InternalIP,
+			},
+		})
+		if node.IsBootstrap {
+			bootstraps = append(bootstraps, node.ContainerName)
+		} else {
+			plugins = append(plugins, node.ContainerName)
+		}
+	}
+	cfg.RecordContainerNames(ccip_config.SCRAPE_JOB_BOOTSTRAP, bootstraps...)
+	cfg.RecordContainerNames(ccip_config.SCRAPE_JOB_NODE, plugins...)
+	return nil
+}
+
 // FundNodes sends funds to the chainlink nodes based on the provided test config
 // It also sets up a clean-up function to return the funds back to the deployer account once the test is done
 // It assumes that the chainlink nodes are already started and the account addresses for all chains are available
 func FundNodes(t *testing.T, lggr zerolog.Logger, env *test_env.CLClusterTestEnv, cfg tc.TestConfig, nodes []devenv.Node) {
-	evmNetworks := networks.MustGetSelectedNetworkConfig(cfg.GetNetworkConfig())
-	for i, net := range evmNetworks {
-		// if network is simulated, update the URLs with deployed chain RPCs in the docker test environment
-		if net.Simulated {
-			rpcProvider, err := env.GetRpcProvider(net.ChainID)
-			require.NoError(t, err, "Error getting rpc provider")
-			evmNetworks[i].HTTPURLs = rpcProvider.PublicHttpUrls()
-			evmNetworks[i].URLs = rpcProvider.PublicWsUrls()
-		}
-	}
+	evmNetworks := publicEVMNetworks(t, env, cfg)
 	t.Cleanup(func() {
 		for i := range evmNetworks {
 			// if simulated no need for balance return
@@ -530,7 +1831,7 @@ func FundNodes(t *testing.T, lggr zerolog.Logger, env *test_env.CLClusterTestEnv
 				continue
 			}
 			evmNetwork := evmNetworks[i]
-			sethClient, err := utils.TestAwareSethClient(t, cfg, &evmNetwork)
+			sethClient, err := sethClientForNetwork(t, cfg, cfg.GetNetworkConfig().SelectedNetworks[i], &evmNetwork)
 			require.NoError(t, err, "Error getting seth client for network %s", evmNetwork.Name)
 			require.Greater(t, len(sethClient.PrivateKeys), 0, seth.ErrNoKeyLoaded)
 			var keyExporters []contracts.ChainlinkKeyExporter
@@ -547,39 +1848,157 @@ func FundNodes(t *testing.T, lggr zerolog.Logger, env *test_env.CLClusterTestEnv
 	})
 	for i := range evmNetworks {
 		evmNetwork := evmNetworks[i]
-		sethClient, err := utils.TestAwareSethClient(t, cfg, &evmNetwork)
+		sethClient, err := sethClientForNetwork(t, cfg, cfg.GetNetworkConfig().SelectedNetworks[i], &evmNetwork)
 		require.NoError(t, err, "Error getting seth client for network %s", evmNetwork.Name)
 		require.Greater(t, len(sethClient.PrivateKeys), 0, seth.ErrNoKeyLoaded)
 		privateKey := sethClient.PrivateKeys[0]
 		if evmNetwork.ChainID < 0 {
 			t.Fatalf("negative chain ID: %d", evmNetwork.ChainID)
 		}
-		for _, node := range nodes {
+		fromAddress, err := actions.PrivateKeyToAddress(privateKey)
+		require.NoError(t, err, "Error getting address from private key")
+		selector, err := chainsel.SelectorFromChainId(uint64(evmNetwork.ChainID))
+		require.NoError(t, err, "Error getting chain selector")
+		amount := big.NewFloat(pointer.GetFloat64(cfg.Common.ChainlinkNodeFunding))
+		// every transfer comes from the same key, so the nonces are handed out up front, SendFunds reading
+		// the pending nonce itself would give concurrent transfers the same one
+		nonce, err := sethClient.Client.PendingNonceAt(testcontext.Get(t), fromAddress)
+		require.NoError(t, err, "Error getting the pending nonce of %s", fromAddress.Hex())
+		err = cfg.CCIP.DeploymentConfig.ForEachTx(testcontext.Get(t), len(nodes), func(_ context.Context, j int) error {
+			node := nodes[j]
 			nodeAddr, ok := node.AccountAddr[uint64(evmNetwork.ChainID)]
-			require.True(t, ok, "Account address not found for chain %d", evmNetwork.ChainID)
-			fromAddress, err := actions.PrivateKeyToAddress(privateKey)
-			require.NoError(t, err, "Error getting address from private key")
-			amount := big.NewFloat(pointer.GetFloat64(cfg.Common.ChainlinkNodeFunding))
+			if !ok {
+				return fmt.Errorf("account address not found for node %s on chain %d", node.Name, evmNetwork.ChainID)
+			}
 			toAddr := common.HexToAddress(nodeAddr)
 			receipt, err := actions.SendFunds(lggr, sethClient, actions.FundsToSendPayload{
 				ToAddress:  toAddr,
 				Amount:     conversions.EtherToWei(amount),
 				PrivateKey: privateKey,
+				Nonce:      pointer.ToUint64(nonce + uint64(j)),
 			})
-			require.NoError(t, err, "Error sending funds to node %s", node.Name)
-			require.NotNil(t, receipt, "Receipt is nil")
-			txHash := "(none)"
-			if receipt != nil {
-				txHash = receipt.TxHash.String()
+			if err != nil {
+				return fmt.Errorf("error sending funds to node %s: %w", node.Name, err)
+			}
+			if receipt == nil {
+				return fmt.Errorf("no receipt for the funds sent to node %s", node.Name)
 			}
+			env.CostTracker.RecordReceipt(selector, receipt.GasUsed, receipt.EffectiveGasPrice, nil)
 			lggr.Info().
 				Str("From", fromAddress.Hex()).
 				Str("To", toAddr.String()).
-				Str("TxHash", txHash).
+				Str("TxHash", receipt.TxHash.String()).
 				Str("Amount", amount.String()).
 				Msg("Funded Chainlink node")
+			return nil
+		})
+		require.NoError(t, err, "Error funding nodes on network %s", evmNetwork.Name)
+	}
+}
+
+// publicEVMNetworks returns the selected networks, the simulated ones with the URLs the test process reaches their
+// chains in the docker test environment under.
+func publicEVMNetworks(t *testing.T, env *test_env.CLClusterTestEnv, cfg tc.TestConfig) []blockchain.EVMNetwork {
+	evmNetworks := networks.MustGetSelectedNetworkConfig(cfg.GetNetworkConfig())
+	for i, net := range evmNetworks {
+		// if network is simulated, update the URLs with deployed chain RPCs in the docker test environment
+		if net.Simulated {
+			rpcProvider, err := env.GetRpcProvider(net.ChainID)
+			require.NoError(t, err, "Error getting rpc provider")
+			evmNetworks[i].HTTPURLs = rpcProvider.PublicHttpUrls()
+			evmNetworks[i].URLs = rpcProvider.PublicWsUrls()
 		}
 	}
+	return evmNetworks
+}
+
+// sethHeartbeatSender sends the heartbeats as zero value self-transfers of the last genesis funded key of each
+// chain, the first one deploys the contracts and funds the nodes.
+type sethHeartbeatSender struct {
+	lggr    zerolog.Logger
+	clients map[uint64]*seth.Client
+	costs   *ccip_config.CostTracker
+}
+
+func (s sethHeartbeatSender) SendHeartbeat(_ context.Context, selector uint64) error {
+	client, ok := s.clients[selector]
+	if !ok {
+		return fmt.Errorf("no client for chain %d", selector)
+	}
+	key := client.PrivateKeys[len(client.PrivateKeys)-1]
+	from, err := actions.PrivateKeyToAddress(key)
+	if err != nil {
+		return err
+	}
+	receipt, err := actions.SendFunds(s.lggr, client, actions.FundsToSendPayload{
+		ToAddress:  from,
+		Amount:     big.NewInt(0),
+		PrivateKey: key,
+		GasLimit:   pointer.ToInt64(int64(ccip_config.HEARTBEAT_GAS)),
+	})
+	if err != nil {
+		return err
+	}
+	if receipt != nil {
+		s.costs.RecordReceipt(selector, receipt.GasUsed, receipt.EffectiveGasPrice, nil)
+	}
+	return nil
+}
+
+// startHeartbeats sends the heartbeats of CCIP.Heartbeat on the chains of the test until it ends, recorded with its
+// reporter and notified with its notifier. It does nothing when heartbeats are disabled.
+func startHeartbeats(t *testing.T, lggr zerolog.Logger, env *test_env.CLClusterTestEnv, cfg tc.TestConfig, selectors []uint64) {
+	chains, err := cfg.CCIP.GetHeartbeatChains(selectors)
+	require.NoError(t, err, "Error resolving the heartbeat chains")
+	if len(chains) == 0 {
+		return
+	}
+	sender := sethHeartbeatSender{lggr: lggr, clients: make(map[uint64]*seth.Client, len(chains)), costs: env.CostTracker}
+	for i, network := range publicEVMNetworks(t, env, cfg) {
+		selector, err := chainsel.SelectorFromChainId(uint64(network.ChainID))
+		require.NoError(t, err, "Error getting chain selector")
+		if !slices.Contains(chains, selector) {
+			continue
+		}
+		client, err := sethClientForNetwork(t, cfg, cfg.GetNetworkConfig().SelectedNetworks[i], &network)
+		require.NoError(t, err, "Error getting seth client for network %s", network.Name)
+		require.Greater(t, len(client.PrivateKeys), 0, seth.ErrNoKeyLoaded)
+		sender.clients[selector] = client
+	}
+	driver, err := cfg.CCIP.NewHeartbeatDriver(selectors, sender, env.Reporter, env.Notifier)
+	require.NoError(t, err, "Error creating the heartbeat driver")
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = driver.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+// sethClientForNetwork returns the Seth client of the named network, tuned with its CCIP.SethConfig settings
+// when the section is set.
+func sethClientForNetwork(t *testing.T, cfg tc.TestConfig, name string, network *blockchain.EVMNetwork) (*seth.Client, error) {
+	if cfg.CCIP.SethConfig == nil {
+		return utils.TestAwareSethClient(t, cfg, network)
+	}
+	settings := cfg.CCIP.GetSethConfig(name)
+	return seth_utils.GetChainClientWithConfigFunction(cfg, *network, func(sethCfg *seth.Config) error {
+		if err := utils.DynamicArtifactDirConfigFn(t)(sethCfg); err != nil {
+			return err
+		}
+		sethCfg.TracingLevel = settings.TracingLevel
+		sethCfg.PendingNonceProtectionEnabled = settings.PendingNonceProtection
+		if sethCfg.GasBump == nil {
+			sethCfg.GasBump = &seth.GasBumpConfig{}
+		}
+		sethCfg.GasBump.Retries = uint(settings.GasBumpRetries)
+		sethCfg.EphemeralAddrs = ptr.Ptr(int64(settings.EphemeralKeys))
+		return nil
+	})
 }
 
 // CreateChainConfigFromNetworks creates a list of ChainConfig from the network config provided in test config.
@@ -653,7 +2072,32 @@ func CreateChainConfigFromNetworks(
 	return chains
 }
 
-func SetNodeConfig(nets []blockchain.EVMNetwork, nodeConfig, commonChain string, configByChain map[string]string) (*corechainlink.Config, string, error) {
+// nodeEVMChain returns the node chain settings derived from the CCIP test config, see ccip_config.Config.GetNodeEVMSettings.
+func nodeEVMChain(cfg *ccip_config.Config) func(blockchain.EVMNetwork) evmcfg.Chain {
+	return func(network blockchain.EVMNetwork) evmcfg.Chain {
+		settings := cfg.GetNodeEVMSettings(network)
+		chain := evmcfg.Chain{
+			FinalityDepth:      settings.FinalityDepth,
+			FinalityTagEnabled: settings.FinalityTagEnabled,
+		}
+		chain.GasEstimator.Mode = settings.GasEstimatorMode
+		chain.GasEstimator.EIP1559DynamicFees = settings.EIP1559DynamicFees
+		chain.GasEstimator.PriceDefault = gweiToWei(settings.PriceDefaultGwei)
+		chain.GasEstimator.FeeCapDefault = gweiToWei(settings.FeeCapDefaultGwei)
+		chain.GasEstimator.TipCapDefault = gweiToWei(settings.TipCapDefaultGwei)
+		return chain
+	}
+}
+
+func gweiToWei(gwei *float64) *assets.Wei {
+	if gwei == nil {
+		return nil
+	}
+	wei, _ := new(big.Float).Mul(big.NewFloat(*gwei), big.NewFloat(1e9)).Int(nil)
+	return assets.NewWei(wei)
+}
+
+func SetNodeConfig(nets []blockchain.EVMNetwork, nodeConfig, commonChain string, configByChain map[string]string, derived ...func(blockchain.EVMNetwork) evmcfg.Chain) (*corechainlink.Config, string, error) {
 	var tomlCfg *corechainlink.Config
 	var err error
 	var commonChainConfig *evmcfg.Chain
@@ -679,9 +2123,9 @@ func SetNodeConfig(nets []blockchain.EVMNetwork, nodeConfig, commonChain string,
 	if nodeConfig == "" {
 		tomlCfg = integrationnodes.NewConfig(
 			integrationnodes.NewBaseConfig(),
-			integrationnodes.WithPrivateEVMs(nets, commonChainConfig, configByChainMap))
+			integrationnodes.WithPrivateEVMs(nets, commonChainConfig, configByChainMap, derived...))
 	} else {
-		tomlCfg, err = integrationnodes.NewConfigFromToml([]byte(nodeConfig), integrationnodes.WithPrivateEVMs(nets, commonChainConfig, configByChainMap))
+		tomlCfg, err = integrationnodes.NewConfigFromToml([]byte(nodeConfig), integrationnodes.WithPrivateEVMs(nets, commonChainConfig, configByChainMap, derived...))
 		if err != nil {
 			return nil, "", err
 		}
@@ -86,7 +86,10 @@ func NewConfigFromToml(tomlConfig []byte, opts ...NodeConfigOpt) (*chainlink.Con
 	return &cfg, nil
 }
 
-func WithPrivateEVMs(networks []blockchain.EVMNetwork, commonChainConfig *evmcfg.Chain, chainSpecificConfig map[int64]evmcfg.Chain) NodeConfigOpt {
+// WithPrivateEVMs sets an EVM config for every network. The chain specific config of a network replaces the
+// common one. The chain settings returned by derived, e.g. derived from the test config, go under them and
+// only fill the fields they leave unset.
+func WithPrivateEVMs(networks []blockchain.EVMNetwork, commonChainConfig *evmcfg.Chain, chainSpecificConfig map[int64]evmcfg.Chain, derived ...func(blockchain.EVMNetwork) evmcfg.Chain) NodeConfigOpt {
 	var evmConfigs []*evmcfg.EVMConfig
 	for _, network := range networks {
 		var evmNodes []*evmcfg.Node
@@ -123,6 +126,15 @@ func WithPrivateEVMs(networks []blockchain.EVMNetwork, commonChainConfig *evmcfg
 				evmConfig.Chain = overriddenChainCfg
 			}
 		}
+		if len(derived) > 0 {
+			var chain evmcfg.Chain
+			for _, derive := range derived {
+				derivedChain := derive(network)
+				chain.SetFrom(&derivedChain)
+			}
+			chain.SetFrom(&evmConfig.Chain)
+			evmConfig.Chain = chain
+		}
 		evmConfigs = append(evmConfigs, evmConfig)
 	}
 	return func(c *chainlink.Config) {